@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"erp-service/config"
+	rpc "erp-service/delivery/grpc"
+	"erp-service/infrastructure"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tracing"
+)
+
+func main() {
+	cfg, err := config.Load(config.WithSecrets(infrastructure.NewSecretReader))
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, cfg.App, "grpc")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
+	server := rpc.NewServer(cfg)
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	metricsServer.Start()
+
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatalf("failed to start grpc server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("shutting down grpc server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GRPC.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("grpc server did not drain in time: %v", err)
+	}
+
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Printf("metrics server shutdown: %v", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("tracing shutdown: %v", err)
+	}
+
+	log.Println("grpc server stopped")
+}
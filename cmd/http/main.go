@@ -2,9 +2,7 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,26 +10,32 @@ import (
 
 	"erp-service/config"
 	erphttp "erp-service/delivery/http"
-
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"erp-service/infrastructure"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/migrator"
+	"erp-service/pkg/tracing"
 )
 
 func main() {
-	cfg, err := config.Load()
+	cfg, err := config.Load(config.WithSecrets(infrastructure.NewSecretReader))
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	if err := runMigrations(cfg); err != nil {
-		log.Fatalf("failed to run migrations: %v", err)
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, cfg.App, "http")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
 	}
 
-	server := erphttp.NewServer(cfg)
+	if cfg.Migration.OnStartup {
+		if err := runMigrations(cfg); err != nil {
+			log.Fatalf("failed to run migrations: %v", err)
+		}
+	}
 
-	workerCtx, workerCancel := context.WithCancel(context.Background())
-	server.StartWorker(workerCtx)
+	server := erphttp.NewServer(cfg)
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	metricsServer.Start()
 
 	go func() {
 		if err := server.Start(); err != nil {
@@ -48,9 +52,6 @@ func main() {
 	log.Println("shutting down server...")
 	log.Printf("Serever is starting on port %s", os.DevNull)
 
-	workerCancel()
-	server.StopWorker()
-
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -58,31 +59,42 @@ func main() {
 		log.Fatalf("failed to shutdown server: %v", err)
 	}
 
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Printf("metrics server shutdown: %v", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("tracing shutdown: %v", err)
+	}
+
 	log.Println("server stopped")
 }
 
+// runMigrations applies pending migrations under the migrator lock, so
+// replicas booting together do not race.
 func runMigrations(cfg *config.Config) error {
-	pg := cfg.Infra.Postgres.Platform
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		url.QueryEscape(pg.User),
-		url.QueryEscape(pg.Password),
-		pg.Host,
-		pg.Port,
-		pg.Database,
-		pg.SSLMode,
-	)
-
-	m, err := migrate.New("file://migration", dsn)
+	ctx := context.Background()
+	url, release, err := infrastructure.PostgresURL(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("create migrator: %w", err)
+		return err
 	}
-	defer m.Close()
+	defer func() {
+		if err := release(ctx); err != nil {
+			log.Printf("failed to revoke migration credentials: %v", err)
+		}
+	}()
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("run migrations: %w", err)
+	m, err := migrator.Open(url, migrator.Options{
+		Path:        cfg.Migration.Path,
+		Environment: cfg.App.Environment,
+		Production:  cfg.IsProduction(),
+		LockTimeout: cfg.Migration.LockTimeout,
+		Out:         log.Writer(),
+		Role:        cfg.Migration.Role,
+	})
+	if err != nil {
+		return err
 	}
-
-	version, dirty, _ := m.Version()
-	log.Printf("migrations applied with version: %d, dirty: %v", version, dirty)
-	return nil
+	defer m.Close()
+	return m.Run(ctx, migrator.Command{Name: migrator.CommandUp})
 }
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"erp-service/config"
+	"erp-service/infrastructure"
+	"erp-service/pkg/migrator"
+)
+
+const usage = `usage: migrate [flags] <command> [arg]
+       migrate [flags] move-tenant <tenant-code> <database>
+
+commands:
+  up [N]     apply all pending migrations, or the next N
+  down N     revert the last N migrations
+  goto V     migrate up or down to version V
+  version    print the current version
+  force V    set the version without running anything (after a manual fix)
+  status     list applied and pending migrations
+  seed       run the seed files for APP_ENV that have not run yet
+
+  move-tenant moves a tenant from the shared tables into its own database,
+  which must already exist: it migrates the database up, blocks the tenant,
+  copies and verifies its rows, then switches it over and deletes the
+  shared rows. A failed move leaves the tenant on the shared tables.
+
+flags:
+`
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print the files that would run without applying them")
+	allowDestructive := flag.Bool("allow-destructive", false, "allow down and backwards goto when APP_ENV is production")
+	tenantCode := flag.String("tenant", "", "run the command on this tenant's dedicated database instead of the platform database")
+	allTenants := flag.Bool("all-tenants", false, "run the command on the platform database, then on every dedicated tenant database")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cmd, err := parseArgs(flag.Args(), *tenantCode, *allTenants, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(config.WithSecrets(infrastructure.NewSecretReader))
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	url, release, err := infrastructure.PostgresURL(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("failed to get database credentials: %v", err)
+	}
+
+	opts := migrator.Options{
+		Path:             cfg.Migration.Path,
+		Environment:      cfg.App.Environment,
+		Production:       cfg.IsProduction(),
+		LockTimeout:      cfg.Migration.LockTimeout,
+		DryRun:           *dryRun,
+		AllowDestructive: *allowDestructive,
+		Out:              os.Stdout,
+		Role:             cfg.Migration.Role,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	var runErr error
+	if cmd.Name == commandMoveTenant {
+		args := flag.Args()
+		runErr = moveTenant(ctx, cfg, url, opts, args[1], args[2])
+	} else {
+		runErr = run(ctx, cfg, url, opts, cmd, *tenantCode, *allTenants)
+	}
+	stop()
+	if err := release(context.Background()); err != nil {
+		log.Printf("failed to revoke database credentials: %v", err)
+	}
+	if runErr != nil {
+		log.Fatalf("%s failed: %v", cmd.Name, runErr)
+	}
+}
+
+func parseArgs(args []string, tenantCode string, allTenants, dryRun bool) (migrator.Command, error) {
+	if tenantCode != "" && allTenants {
+		return migrator.Command{}, fmt.Errorf("-tenant and -all-tenants cannot be combined")
+	}
+	if len(args) > 0 && args[0] == commandMoveTenant {
+		if len(args) != 3 {
+			return migrator.Command{}, fmt.Errorf("%s: tenant code and database are required", commandMoveTenant)
+		}
+		if tenantCode != "" || allTenants || dryRun {
+			return migrator.Command{}, fmt.Errorf("%s: takes no -tenant, -all-tenants or -dry-run", commandMoveTenant)
+		}
+		return migrator.Command{Name: commandMoveTenant}, nil
+	}
+
+	cmd, err := migrator.ParseCommand(args)
+	if err != nil {
+		return migrator.Command{}, err
+	}
+	// Seeds fill platform data such as masterdata, which tenant databases
+	// do not hold.
+	if cmd.Name == migrator.CommandSeed && (tenantCode != "" || allTenants) {
+		return migrator.Command{}, fmt.Errorf("seed: only runs on the platform database")
+	}
+	return cmd, nil
+}
+
+func run(ctx context.Context, cfg *config.Config, platformURL string, opts migrator.Options, cmd migrator.Command, tenantCode string, allTenants bool) error {
+	dbs, err := targets(ctx, cfg, platformURL, tenantCode, allTenants)
+	if err != nil {
+		return err
+	}
+	for _, db := range dbs {
+		if len(dbs) > 1 {
+			fmt.Fprintf(opts.Out, "== %s\n", db.name)
+		}
+		m, err := migrator.Open(db.url, opts)
+		if err != nil {
+			return fmt.Errorf("%s: failed to open migrator: %w", db.name, err)
+		}
+		runErr := m.Run(ctx, cmd)
+		if err := m.Close(); err != nil {
+			log.Printf("failed to close migrator: %v", err)
+		}
+		if runErr != nil {
+			return fmt.Errorf("%s: %w", db.name, runErr)
+		}
+	}
+	return nil
+}
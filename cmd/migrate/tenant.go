@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"erp-service/config"
+	"erp-service/pkg/migrator"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const commandMoveTenant = "move-tenant"
+
+// target is a database to run a migration command on.
+type target struct {
+	name string
+	url  string
+}
+
+// targets resolves -tenant and -all-tenants to the databases to migrate.
+// The platform database comes first so a tenant database is never ahead of
+// it.
+func targets(ctx context.Context, cfg *config.Config, platformURL, tenantCode string, allTenants bool) ([]target, error) {
+	platform := target{name: cfg.Infra.Postgres.Platform.Database, url: platformURL}
+	if tenantCode == "" && !allTenants {
+		return []target{platform}, nil
+	}
+
+	conn, err := pgx.Connect(ctx, platformURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to platform database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	if tenantCode != "" {
+		tenantID, err := lookupTenant(ctx, conn, tenantCode)
+		if err != nil {
+			return nil, err
+		}
+		var database string
+		err = conn.QueryRow(ctx, `SELECT database_name FROM tenant_databases WHERE tenant_id = $1`, tenantID).Scan(&database)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("tenant %s has no dedicated database", tenantCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("look up tenant database: %w", err)
+		}
+		return []target{{name: database, url: cfg.Infra.Postgres.Tenant.GetURL(database)}}, nil
+	}
+
+	// Databases still being moved to are included, so their schema keeps
+	// up with the platform's and the copy can finish.
+	rows, err := conn.Query(ctx, `SELECT database_name FROM tenant_databases ORDER BY database_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list tenant databases: %w", err)
+	}
+	databases, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list tenant databases: %w", err)
+	}
+	all := []target{platform}
+	for _, database := range databases {
+		all = append(all, target{name: database, url: cfg.Infra.Postgres.Tenant.GetURL(database)})
+	}
+	return all, nil
+}
+
+// moveTenant moves a tenant's data from the shared tables into database,
+// which must already exist. The tenant's requests fail with 503 from the
+// moment it is blocked until the cutover, which waits out the routers'
+// placement cache first.
+func moveTenant(ctx context.Context, cfg *config.Config, platformURL string, opts migrator.Options, tenantCode, database string) error {
+	tenantURL := cfg.Infra.Postgres.Tenant.GetURL(database)
+
+	platform, err := pgx.Connect(ctx, platformURL)
+	if err != nil {
+		return fmt.Errorf("connect to platform database: %w", err)
+	}
+	defer platform.Close(context.Background())
+	dedicated, err := pgx.Connect(ctx, tenantURL)
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", database, err)
+	}
+	defer dedicated.Close(context.Background())
+
+	tenantID, err := lookupTenant(ctx, platform, tenantCode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "migrating %s\n", database)
+	m, err := migrator.Open(tenantURL, opts)
+	if err != nil {
+		return err
+	}
+	runErr := m.Run(ctx, migrator.Command{Name: migrator.CommandUp})
+	if err := m.Close(); err != nil {
+		log.Printf("failed to close migrator: %v", err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("migrate %s: %w", database, runErr)
+	}
+
+	if err := tenantdb.Block(ctx, platform, tenantID, database); err != nil {
+		return err
+	}
+	wait := cfg.Infra.Postgres.Tenant.PlacementTTL
+	fmt.Fprintf(opts.Out, "blocked tenant %s, waiting %s for running services to notice\n", tenantCode, wait)
+
+	err = copyAndActivate(ctx, platform, dedicated, tenantID, wait, opts)
+	if err != nil {
+		// The cutover is all or nothing, so the shared rows are intact and
+		// the tenant can go back to them.
+		if unblockErr := tenantdb.Unblock(context.Background(), platform, tenantID); unblockErr != nil {
+			return errors.Join(err, fmt.Errorf("unblock tenant: %w", unblockErr))
+		}
+		fmt.Fprintf(opts.Out, "move failed, tenant %s is back on the shared tables\n", tenantCode)
+		return err
+	}
+	fmt.Fprintf(opts.Out, "tenant %s now uses %s\n", tenantCode, database)
+	return nil
+}
+
+func copyAndActivate(ctx context.Context, platform, dedicated *pgx.Conn, tenantID uuid.UUID, wait time.Duration, opts migrator.Options) error {
+	select {
+	case <-time.After(wait):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	sums, err := tenantdb.Copy(ctx, platform, dedicated, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, table := range tenantdb.Tables {
+		fmt.Fprintf(opts.Out, "copied %d row(s) of %s\n", sums[table.Name].Rows, table.Name)
+	}
+	return tenantdb.Activate(ctx, platform, tenantID, sums)
+}
+
+func lookupTenant(ctx context.Context, conn *pgx.Conn, code string) (uuid.UUID, error) {
+	var tenantID uuid.UUID
+	err := conn.QueryRow(ctx, `SELECT id FROM tenants WHERE code = $1 AND deleted_at IS NULL`, code).Scan(&tenantID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("tenant %s not found", code)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("look up tenant: %w", err)
+	}
+	return tenantID, nil
+}
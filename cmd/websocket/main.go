@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"erp-service/config"
+	ws "erp-service/delivery/websocket"
+	"erp-service/infrastructure"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tracing"
+)
+
+func main() {
+	cfg, err := config.Load(config.WithSecrets(infrastructure.NewSecretReader))
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, cfg.App, "websocket")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
+	server := ws.NewServer(cfg)
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	metricsServer.Start()
+
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatalf("failed to start websocket server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("shutting down websocket server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.WebSocket.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("websocket server did not drain in time: %v", err)
+	}
+
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Printf("metrics server shutdown: %v", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("tracing shutdown: %v", err)
+	}
+
+	log.Println("websocket server stopped")
+}
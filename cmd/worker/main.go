@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"erp-service/config"
+	"erp-service/delivery/worker"
+	"erp-service/files"
+	"erp-service/impl/clamd"
+	"erp-service/impl/postgres"
+	implredis "erp-service/impl/redis"
+	"erp-service/infrastructure"
+	"erp-service/pkg/health"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/pii"
+	"erp-service/pkg/tracing"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	cfg, err := config.Load(config.WithSecrets(infrastructure.NewSecretReader))
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, cfg.App, "worker")
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
+	zapLogger, _ := logger.NewZapLoggerWithConfig(cfg.Log, cfg.App.Environment)
+	auditLogger := logger.NewAuditLogger(zapLogger, logger.AuditConfig{
+		Enabled: cfg.Log.AuditEnabled,
+	})
+
+	postgresDB, closeDB, err := infrastructure.NewPostgres(cfg, zapLogger)
+	if err != nil {
+		log.Fatal("failed to connect to postgres:", err)
+	}
+	tenantDBs := infrastructure.NewTenantRouter(cfg, postgresDB, zapLogger)
+
+	redisClient, err := infrastructure.NewRedis(cfg.Infra.Redis)
+	if err != nil {
+		log.Fatal("failed to connect to redis:", err)
+	}
+	queue := implredis.NewRedis(redisClient)
+
+	piiCipher, err := infrastructure.NewPIICipher(cfg)
+	if err != nil {
+		log.Fatal("failed to configure pii encryption:", err)
+	}
+	pii.Register(piiCipher)
+
+	fileEncryptor, err := infrastructure.NewFileEncryptor(cfg)
+	if err != nil {
+		log.Fatal("failed to configure file encryption:", err)
+	}
+
+	fileStorage, _, err := infrastructure.NewFileStorage(cfg, fileEncryptor)
+	if err != nil {
+		log.Fatal("failed to configure file storage:", err)
+	}
+
+	malwareScanner, err := clamd.NewScanner(cfg.Infra.Clamd)
+	if err != nil {
+		log.Fatal("failed to configure clamd scanner:", err)
+	}
+
+	filesCfg := files.DefaultConfig()
+	if cfg.Infra.Minio.QuarantineBucket != "" {
+		filesCfg.QuarantineBucket = cfg.Infra.Minio.QuarantineBucket
+	}
+	if cfg.Infra.FileEncryption.RewrapAge > 0 {
+		filesCfg.RewrapAge = cfg.Infra.FileEncryption.RewrapAge
+	}
+	if cfg.Infra.Clamd.MaxScanAttempts > 0 {
+		filesCfg.MaxScanAttempts = cfg.Infra.Clamd.MaxScanAttempts
+	}
+	filesUsecase := files.NewUsecase(
+		postgres.NewFileRepository(tenantDBs),
+		postgres.NewUploadSlotRepository(tenantDBs),
+		fileStorage,
+		malwareScanner,
+		fileEncryptor,
+		postgres.NewTenantTransactionManager(tenantDBs),
+		zapLogger,
+		auditLogger,
+		filesCfg,
+	)
+
+	registry := worker.NewRegistry()
+	scheduler := worker.NewScheduler(queue, registry, zapLogger)
+
+	fileJobs := worker.NewFileJobs(filesUsecase, tenantDBs, zapLogger)
+	if err := fileJobs.Register(registry); err != nil {
+		log.Fatal("failed to register file jobs:", err)
+	}
+	intervals := worker.DefaultFileJobIntervals()
+	if cfg.Infra.Clamd.ScanInterval > 0 {
+		intervals.Scan = cfg.Infra.Clamd.ScanInterval
+	}
+	if cfg.Infra.FileEncryption.RewrapInterval > 0 {
+		intervals.Rewrap = cfg.Infra.FileEncryption.RewrapInterval
+	}
+	if cfg.Infra.FileRetention.PurgeInterval > 0 {
+		intervals.Purge = cfg.Infra.FileRetention.PurgeInterval
+	}
+	if err := fileJobs.Schedule(scheduler, intervals); err != nil {
+		log.Fatal("failed to schedule file jobs:", err)
+	}
+
+	concurrency, err := cfg.Worker.QueueConcurrency()
+	if err != nil {
+		log.Fatal("invalid worker concurrency:", err)
+	}
+	processor := worker.NewProcessor(queue, registry, zapLogger, worker.ProcessorConfig{
+		Concurrency:      concurrency,
+		PollInterval:     cfg.Worker.PollInterval,
+		LeaseDuration:    cfg.Worker.LeaseDuration,
+		JobTimeout:       cfg.Worker.JobTimeout,
+		DrainTimeout:     cfg.Worker.DrainTimeout,
+		RecoveryInterval: cfg.Worker.RecoveryInterval,
+		RetryBaseDelay:   cfg.Worker.RetryBaseDelay,
+		RetryMaxDelay:    cfg.Worker.RetryMaxDelay,
+	})
+
+	healthRegistry, err := infrastructure.NewHealthRegistry(cfg, postgresDB, redisClient)
+	if err != nil {
+		log.Fatal("failed to configure health checks:", err)
+	}
+	healthRegistry.RegisterLiveness(health.Check{
+		Name: "job_processor",
+		Run:  processor.CheckStalled,
+	})
+
+	metrics.WatchQueues(queue, registry.Queues())
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	metricsServer.Handle("/health/live", health.Handler(healthRegistry.Live, cfg.IsDevelopment()))
+	metricsServer.Handle("/health/ready", health.Handler(healthRegistry.Ready, cfg.IsDevelopment()))
+	metricsServer.Start()
+
+	piiBackfill := worker.NewPIIBackfill(postgres.NewPIIBackfillRepository(tenantDBs), tenantDBs, zapLogger)
+	if cfg.Infra.PIIEncryption.BackfillBatchSize > 0 {
+		piiBackfill.SetBatchSize(cfg.Infra.PIIEncryption.BackfillBatchSize)
+	}
+
+	// The processor gets a context that is never cancelled: on shutdown it
+	// drains through Stop, while the scheduler and backfill just quit.
+	backgroundCtx, cancelBackground := context.WithCancel(context.Background())
+	processor.Start(context.Background())
+	scheduler.Start(backgroundCtx)
+	piiBackfill.Start(backgroundCtx)
+	zapLogger.Info("worker started", zap.Strings("queues", registry.Queues()))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	zapLogger.Info("shutting down worker, draining in-flight jobs")
+	cancelBackground()
+	scheduler.Stop()
+	piiBackfill.Stop()
+	processor.Stop()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	if err := errors.Join(tenantDBs.Close(), closeDB(shutdownCtx)); err != nil {
+		zapLogger.Warn("postgres shutdown", zap.Error(err))
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		zapLogger.Warn("metrics server shutdown", zap.Error(err))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		zapLogger.Warn("tracing shutdown", zap.Error(err))
+	}
+
+	zapLogger.Info("worker stopped")
+}
@@ -1,6 +1,11 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type AppConfig struct {
 	Name        string `mapstructure:"name"`
@@ -17,6 +22,116 @@ type ServerConfig struct {
 	CORSOrigins  string        `mapstructure:"cors_origins"`
 }
 
+// MetricsConfig configures the admin listener every server and the worker
+// open for Prometheus to scrape /metrics. It is kept off the public port.
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"`
+	Port    int    `mapstructure:"port"`
+}
+
+// HealthConfig tunes the probe checks. CheckTimeout, CacheTTL and
+// FailureThreshold are the defaults for every dependency check;
+// PoolStallTimeout is how long the Postgres pool may stay wedged before
+// liveness fails.
+type HealthConfig struct {
+	CheckTimeout     time.Duration `mapstructure:"check_timeout"`
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	PoolStallTimeout time.Duration `mapstructure:"pool_stall_timeout"`
+}
+
+// TracingConfig selects where OpenTelemetry spans go: "otlp" to a collector
+// over gRPC, "stdout" for local debugging, or "none". SampleRatio applies
+// to new traces only; a sampled parent from an incoming traceparent is
+// always followed.
+type TracingConfig struct {
+	Exporter     string  `mapstructure:"exporter"`
+	OTLPEndpoint string  `mapstructure:"otlp_endpoint"`
+	OTLPInsecure bool    `mapstructure:"otlp_insecure"`
+	SampleRatio  float64 `mapstructure:"sample_ratio"`
+}
+
+// GRPCConfig configures cmd/grpc, the API other internal services call.
+// Reflection lets grpcurl and similar tools discover the services.
+type GRPCConfig struct {
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	Reflection      bool          `mapstructure:"reflection"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// GraphQLConfig configures cmd/graphql, the read API over participants and
+// masterdata. MaxComplexity bounds the estimated number of fields a query
+// resolves, counting list fields as DefaultListSize items unless a perPage
+// or ids argument says how many.
+type GraphQLConfig struct {
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	MaxDepth        int           `mapstructure:"max_depth"`
+	MaxComplexity   int           `mapstructure:"max_complexity"`
+	DefaultListSize int           `mapstructure:"default_list_size"`
+	Introspection   bool          `mapstructure:"introspection"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// WebSocketConfig configures cmd/websocket, which pushes workflow events to
+// browsers. A client whose SendBuffer fills up is disconnected and expected
+// to reconnect with its last event ID; ReplayLimit caps how many missed
+// events a reconnect replays before asking the client to resync instead.
+type WebSocketConfig struct {
+	Host            string        `mapstructure:"host"`
+	Port            int           `mapstructure:"port"`
+	PingInterval    time.Duration `mapstructure:"ping_interval"`
+	PongTimeout     time.Duration `mapstructure:"pong_timeout"`
+	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
+	SendBuffer      int           `mapstructure:"send_buffer"`
+	ReplayLimit     int           `mapstructure:"replay_limit"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// WorkerConfig tunes the background job processor. Concurrency lists
+// consumers per queue as "queue=n" pairs, e.g. "files=2,default=4"; queues
+// not listed get one consumer.
+type WorkerConfig struct {
+	Concurrency      string        `mapstructure:"concurrency"`
+	PollInterval     time.Duration `mapstructure:"poll_interval"`
+	LeaseDuration    time.Duration `mapstructure:"lease_duration"`
+	JobTimeout       time.Duration `mapstructure:"job_timeout"`
+	DrainTimeout     time.Duration `mapstructure:"drain_timeout"`
+	RecoveryInterval time.Duration `mapstructure:"recovery_interval"`
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+}
+
+func (c *WorkerConfig) QueueConcurrency() (map[string]int, error) {
+	concurrency := make(map[string]int)
+	for _, pair := range strings.Split(c.Concurrency, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		queue, value, ok := strings.Cut(pair, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || strings.TrimSpace(queue) == "" || err != nil || n < 1 {
+			return nil, fmt.Errorf("WORKER_CONCURRENCY: invalid entry %q, want queue=n with n >= 1", pair)
+		}
+		concurrency[strings.TrimSpace(queue)] = n
+	}
+	return concurrency, nil
+}
+
+// MigrationConfig controls schema migrations. Deployments apply them with
+// cmd/migrate; the HTTP server only migrates at boot when OnStartup is set.
+// Role is the stable owner migrations run as (SET ROLE); it is required with
+// dynamic database credentials, whose users are dropped when they expire.
+type MigrationConfig struct {
+	Path        string        `mapstructure:"path"`
+	OnStartup   bool          `mapstructure:"on_startup"`
+	LockTimeout time.Duration `mapstructure:"lock_timeout"`
+	Role        string        `mapstructure:"role"`
+}
+
 type JWTConfig struct {
 	AccessSecret       string `mapstructure:"access_secret"`
 	RefreshSecret      string `mapstructure:"refresh_secret"`
@@ -11,6 +11,14 @@ import (
 type Config struct {
 	App        AppConfig        `mapstructure:"app"`
 	Server     ServerConfig     `mapstructure:"server"`
+	Metrics    MetricsConfig    `mapstructure:"metrics"`
+	Tracing    TracingConfig    `mapstructure:"tracing"`
+	Health     HealthConfig     `mapstructure:"health"`
+	GRPC       GRPCConfig       `mapstructure:"grpc"`
+	GraphQL    GraphQLConfig    `mapstructure:"graphql"`
+	WebSocket  WebSocketConfig  `mapstructure:"websocket"`
+	Worker     WorkerConfig     `mapstructure:"worker"`
+	Migration  MigrationConfig  `mapstructure:"migration"`
 	Infra      InfraConfig      `mapstructure:"infra"`
 	JWT        JWTConfig        `mapstructure:"jwt"`
 	Log        LogConfig        `mapstructure:"log"`
@@ -20,7 +28,15 @@ type Config struct {
 	Masterdata MasterdataConfig `mapstructure:"masterdata"`
 }
 
-func Load() (*Config, error) {
+// Load reads the configuration from the environment and .env files. Values
+// of the form vault:<path>#<key> are read from Vault KV when WithSecrets is
+// given, and rejected otherwise.
+func Load(opts ...Option) (*Config, error) {
+	var options loadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	_ = godotenv.Load()
 	_ = godotenv.Load(".env")
 	_ = godotenv.Load("../.env")
@@ -35,6 +51,10 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	if err := cfg.applySecrets(options); err != nil {
+		return nil, fmt.Errorf("error resolving secrets: %w", err)
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -54,6 +74,56 @@ func bindEnvVariables() {
 	_ = viper.BindEnv("server.idle_timeout", "SERVER_IDLE_TIMEOUT")
 	_ = viper.BindEnv("server.cors_origins", "SERVER_CORS_ORIGINS")
 
+	_ = viper.BindEnv("metrics.enabled", "METRICS_ENABLED")
+	_ = viper.BindEnv("metrics.host", "METRICS_HOST")
+	_ = viper.BindEnv("metrics.port", "METRICS_PORT")
+
+	_ = viper.BindEnv("tracing.exporter", "TRACING_EXPORTER")
+	_ = viper.BindEnv("tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT")
+	_ = viper.BindEnv("tracing.otlp_insecure", "TRACING_OTLP_INSECURE")
+	_ = viper.BindEnv("tracing.sample_ratio", "TRACING_SAMPLE_RATIO")
+
+	_ = viper.BindEnv("health.check_timeout", "HEALTH_CHECK_TIMEOUT")
+	_ = viper.BindEnv("health.cache_ttl", "HEALTH_CACHE_TTL")
+	_ = viper.BindEnv("health.failure_threshold", "HEALTH_FAILURE_THRESHOLD")
+	_ = viper.BindEnv("health.pool_stall_timeout", "HEALTH_POOL_STALL_TIMEOUT")
+
+	_ = viper.BindEnv("grpc.host", "GRPC_HOST")
+	_ = viper.BindEnv("grpc.port", "GRPC_PORT")
+	_ = viper.BindEnv("grpc.reflection", "GRPC_REFLECTION")
+	_ = viper.BindEnv("grpc.shutdown_timeout", "GRPC_SHUTDOWN_TIMEOUT")
+
+	_ = viper.BindEnv("graphql.host", "GRAPHQL_HOST")
+	_ = viper.BindEnv("graphql.port", "GRAPHQL_PORT")
+	_ = viper.BindEnv("graphql.max_depth", "GRAPHQL_MAX_DEPTH")
+	_ = viper.BindEnv("graphql.max_complexity", "GRAPHQL_MAX_COMPLEXITY")
+	_ = viper.BindEnv("graphql.default_list_size", "GRAPHQL_DEFAULT_LIST_SIZE")
+	_ = viper.BindEnv("graphql.introspection", "GRAPHQL_INTROSPECTION")
+	_ = viper.BindEnv("graphql.shutdown_timeout", "GRAPHQL_SHUTDOWN_TIMEOUT")
+
+	_ = viper.BindEnv("websocket.host", "WEBSOCKET_HOST")
+	_ = viper.BindEnv("websocket.port", "WEBSOCKET_PORT")
+	_ = viper.BindEnv("websocket.ping_interval", "WEBSOCKET_PING_INTERVAL")
+	_ = viper.BindEnv("websocket.pong_timeout", "WEBSOCKET_PONG_TIMEOUT")
+	_ = viper.BindEnv("websocket.write_timeout", "WEBSOCKET_WRITE_TIMEOUT")
+	_ = viper.BindEnv("websocket.send_buffer", "WEBSOCKET_SEND_BUFFER")
+	_ = viper.BindEnv("websocket.replay_limit", "WEBSOCKET_REPLAY_LIMIT")
+	_ = viper.BindEnv("websocket.shutdown_timeout", "WEBSOCKET_SHUTDOWN_TIMEOUT")
+
+	_ = viper.BindEnv("migration.path", "MIGRATION_PATH")
+	_ = viper.BindEnv("migration.on_startup", "MIGRATE_ON_STARTUP")
+	_ = viper.BindEnv("migration.lock_timeout", "MIGRATION_LOCK_TIMEOUT")
+	_ = viper.BindEnv("migration.role", "MIGRATION_ROLE")
+
+	_ = viper.BindEnv("worker.concurrency", "WORKER_CONCURRENCY")
+	_ = viper.BindEnv("worker.poll_interval", "WORKER_POLL_INTERVAL")
+	_ = viper.BindEnv("worker.lease_duration", "WORKER_LEASE_DURATION")
+	_ = viper.BindEnv("worker.job_timeout", "WORKER_JOB_TIMEOUT")
+	_ = viper.BindEnv("worker.drain_timeout", "WORKER_DRAIN_TIMEOUT")
+	_ = viper.BindEnv("worker.recovery_interval", "WORKER_RECOVERY_INTERVAL")
+	_ = viper.BindEnv("worker.retry_base_delay", "WORKER_RETRY_BASE_DELAY")
+	_ = viper.BindEnv("worker.retry_max_delay", "WORKER_RETRY_MAX_DELAY")
+
 	_ = viper.BindEnv("infra.postgres.platform.host", "POSTGRES_HOST")
 	_ = viper.BindEnv("infra.postgres.platform.port", "POSTGRES_PORT")
 	_ = viper.BindEnv("infra.postgres.platform.user", "POSTGRES_USER")
@@ -63,12 +133,19 @@ func bindEnvVariables() {
 	_ = viper.BindEnv("infra.postgres.platform.max_open_conns", "POSTGRES_MAX_OPEN_CONNS")
 	_ = viper.BindEnv("infra.postgres.platform.max_idle_conns", "POSTGRES_MAX_IDLE_CONNS")
 	_ = viper.BindEnv("infra.postgres.platform.conn_max_lifetime", "POSTGRES_CONN_MAX_LIFETIME")
+	_ = viper.BindEnv("infra.postgres.platform.vault_role", "POSTGRES_VAULT_ROLE")
 
 	_ = viper.BindEnv("infra.postgres.tenant.host", "POSTGRES_TENANT_HOST", "POSTGRES_HOST")
 	_ = viper.BindEnv("infra.postgres.tenant.port", "POSTGRES_TENANT_PORT", "POSTGRES_PORT")
 	_ = viper.BindEnv("infra.postgres.tenant.user", "POSTGRES_TENANT_USER", "POSTGRES_USER")
 	_ = viper.BindEnv("infra.postgres.tenant.password", "POSTGRES_TENANT_PASSWORD", "POSTGRES_PASSWORD")
 	_ = viper.BindEnv("infra.postgres.tenant.ssl_mode", "POSTGRES_TENANT_SSL_MODE", "POSTGRES_SSL_MODE")
+	_ = viper.BindEnv("infra.postgres.tenant.max_open_conns", "POSTGRES_TENANT_MAX_OPEN_CONNS")
+	_ = viper.BindEnv("infra.postgres.tenant.max_idle_conns", "POSTGRES_TENANT_MAX_IDLE_CONNS")
+	_ = viper.BindEnv("infra.postgres.tenant.conn_max_lifetime", "POSTGRES_TENANT_CONN_MAX_LIFETIME")
+	_ = viper.BindEnv("infra.postgres.tenant.max_pools", "POSTGRES_TENANT_MAX_POOLS")
+	_ = viper.BindEnv("infra.postgres.tenant.pool_idle_timeout", "POSTGRES_TENANT_POOL_IDLE_TIMEOUT")
+	_ = viper.BindEnv("infra.postgres.tenant.placement_ttl", "POSTGRES_TENANT_PLACEMENT_TTL")
 
 	_ = viper.BindEnv("infra.redis.host", "REDIS_HOST")
 	_ = viper.BindEnv("infra.redis.port", "REDIS_PORT")
@@ -87,10 +164,38 @@ func bindEnvVariables() {
 	_ = viper.BindEnv("infra.minio.bucket", "MINIO_BUCKET")
 	_ = viper.BindEnv("infra.minio.use_ssl", "MINIO_USE_SSL")
 	_ = viper.BindEnv("infra.minio.region", "MINIO_REGION")
+	_ = viper.BindEnv("infra.minio.presign_expiry", "MINIO_PRESIGN_EXPIRY")
+	_ = viper.BindEnv("infra.minio.quarantine_bucket", "MINIO_QUARANTINE_BUCKET")
+
+	_ = viper.BindEnv("infra.clamd.address", "CLAMD_ADDRESS")
+	_ = viper.BindEnv("infra.clamd.timeout", "CLAMD_TIMEOUT")
+	_ = viper.BindEnv("infra.clamd.scan_interval", "CLAMD_SCAN_INTERVAL")
+	_ = viper.BindEnv("infra.clamd.max_scan_attempts", "CLAMD_MAX_SCAN_ATTEMPTS")
 
 	_ = viper.BindEnv("infra.vault.address", "VAULT_ADDR")
 	_ = viper.BindEnv("infra.vault.token", "VAULT_TOKEN")
 
+	_ = viper.BindEnv("infra.file_storage.driver", "FILE_STORAGE_DRIVER")
+	_ = viper.BindEnv("infra.file_storage.local_root", "FILE_STORAGE_LOCAL_ROOT")
+	_ = viper.BindEnv("infra.file_storage.local_public_url", "FILE_STORAGE_LOCAL_PUBLIC_URL")
+	_ = viper.BindEnv("infra.file_storage.local_signing_key", "FILE_STORAGE_LOCAL_SIGNING_KEY")
+
+	_ = viper.BindEnv("infra.file_encryption.provider", "FILE_ENCRYPTION_PROVIDER")
+	_ = viper.BindEnv("infra.file_encryption.local_key", "FILE_ENCRYPTION_LOCAL_KEY")
+	_ = viper.BindEnv("infra.file_encryption.key_prefix", "FILE_ENCRYPTION_KEY_PREFIX")
+	_ = viper.BindEnv("infra.file_encryption.rewrap_interval", "FILE_KEY_REWRAP_INTERVAL")
+	_ = viper.BindEnv("infra.file_encryption.rewrap_age", "FILE_KEY_REWRAP_AGE")
+
+	_ = viper.BindEnv("infra.file_retention.purge_interval", "FILE_RETENTION_PURGE_INTERVAL")
+
+	_ = viper.BindEnv("infra.pii_encryption.provider", "PII_ENCRYPTION_PROVIDER")
+	_ = viper.BindEnv("infra.pii_encryption.local_key", "PII_ENCRYPTION_LOCAL_KEY")
+	_ = viper.BindEnv("infra.pii_encryption.key_name", "PII_ENCRYPTION_KEY_NAME")
+	_ = viper.BindEnv("infra.pii_encryption.index_key_name", "PII_BLIND_INDEX_KEY_NAME")
+	_ = viper.BindEnv("infra.pii_encryption.backfill_batch_size", "PII_BACKFILL_BATCH_SIZE")
+	_ = viper.BindEnv("infra.bank_verification.provider", "BANK_VERIFICATION_PROVIDER")
+	_ = viper.BindEnv("infra.bank_verification.timeout", "BANK_VERIFICATION_TIMEOUT")
+
 	_ = viper.BindEnv("jwt.access_secret", "JWT_ACCESS_SECRET")
 	_ = viper.BindEnv("jwt.refresh_secret", "JWT_REFRESH_SECRET")
 	_ = viper.BindEnv("jwt.private_key_path", "JWT_PRIVATE_KEY_PATH")
@@ -138,6 +243,55 @@ func setDefaults() {
 	viper.SetDefault("server.idle_timeout", 120*time.Second)
 	viper.SetDefault("server.cors_origins", "*")
 
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.host", "0.0.0.0")
+	viper.SetDefault("metrics.port", 9100)
+
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlp_endpoint", "localhost:4317")
+	viper.SetDefault("tracing.otlp_insecure", true)
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	viper.SetDefault("health.check_timeout", 2*time.Second)
+	viper.SetDefault("health.cache_ttl", 5*time.Second)
+	viper.SetDefault("health.failure_threshold", 3)
+	viper.SetDefault("health.pool_stall_timeout", 30*time.Second)
+
+	viper.SetDefault("grpc.host", "0.0.0.0")
+	viper.SetDefault("grpc.port", 9090)
+	viper.SetDefault("grpc.reflection", true)
+	viper.SetDefault("grpc.shutdown_timeout", 10*time.Second)
+
+	viper.SetDefault("graphql.host", "0.0.0.0")
+	viper.SetDefault("graphql.port", 8081)
+	viper.SetDefault("graphql.max_depth", 8)
+	viper.SetDefault("graphql.max_complexity", 5000)
+	viper.SetDefault("graphql.default_list_size", 5)
+	viper.SetDefault("graphql.introspection", true)
+	viper.SetDefault("graphql.shutdown_timeout", 10*time.Second)
+
+	viper.SetDefault("websocket.host", "0.0.0.0")
+	viper.SetDefault("websocket.port", 8082)
+	viper.SetDefault("websocket.ping_interval", 25*time.Second)
+	viper.SetDefault("websocket.pong_timeout", 60*time.Second)
+	viper.SetDefault("websocket.write_timeout", 10*time.Second)
+	viper.SetDefault("websocket.send_buffer", 64)
+	viper.SetDefault("websocket.replay_limit", 500)
+	viper.SetDefault("websocket.shutdown_timeout", 10*time.Second)
+
+	viper.SetDefault("migration.path", "migration")
+	viper.SetDefault("migration.on_startup", false)
+	viper.SetDefault("migration.lock_timeout", 5*time.Minute)
+
+	viper.SetDefault("worker.concurrency", "files=2")
+	viper.SetDefault("worker.poll_interval", time.Second)
+	viper.SetDefault("worker.lease_duration", time.Minute)
+	viper.SetDefault("worker.job_timeout", 10*time.Minute)
+	viper.SetDefault("worker.drain_timeout", 30*time.Second)
+	viper.SetDefault("worker.recovery_interval", 30*time.Second)
+	viper.SetDefault("worker.retry_base_delay", 5*time.Second)
+	viper.SetDefault("worker.retry_max_delay", 10*time.Minute)
+
 	viper.SetDefault("infra.postgres.platform.host", "localhost")
 	viper.SetDefault("infra.postgres.platform.port", 5432)
 	viper.SetDefault("infra.postgres.platform.database", "erp_db")
@@ -152,6 +306,9 @@ func setDefaults() {
 	viper.SetDefault("infra.postgres.tenant.max_open_conns", 10)
 	viper.SetDefault("infra.postgres.tenant.max_idle_conns", 5)
 	viper.SetDefault("infra.postgres.tenant.conn_max_lifetime", 5*time.Minute)
+	viper.SetDefault("infra.postgres.tenant.max_pools", 20)
+	viper.SetDefault("infra.postgres.tenant.pool_idle_timeout", 15*time.Minute)
+	viper.SetDefault("infra.postgres.tenant.placement_ttl", 30*time.Second)
 
 	viper.SetDefault("infra.redis.host", "localhost")
 	viper.SetDefault("infra.redis.port", 6379)
@@ -167,9 +324,33 @@ func setDefaults() {
 	viper.SetDefault("infra.minio.use_ssl", false)
 	viper.SetDefault("infra.minio.bucket", "erp-storage")
 	viper.SetDefault("infra.minio.region", "us-east-1")
+	viper.SetDefault("infra.minio.presign_expiry", 5*time.Minute)
+	viper.SetDefault("infra.minio.quarantine_bucket", "quarantine")
+
+	viper.SetDefault("infra.clamd.address", "tcp://localhost:3310")
+	viper.SetDefault("infra.clamd.timeout", 2*time.Minute)
+	viper.SetDefault("infra.clamd.scan_interval", 30*time.Second)
+	viper.SetDefault("infra.clamd.max_scan_attempts", 10)
 
 	viper.SetDefault("infra.vault.address", "http://localhost:8200")
 
+	viper.SetDefault("infra.file_storage.driver", "minio")
+	viper.SetDefault("infra.file_storage.local_root", "./var/storage")
+	viper.SetDefault("infra.file_storage.local_public_url", "http://localhost:8080")
+
+	viper.SetDefault("infra.file_encryption.provider", "vault")
+	viper.SetDefault("infra.file_encryption.key_prefix", "erp-files-tenant-")
+	viper.SetDefault("infra.file_encryption.rewrap_interval", 5*time.Minute)
+	viper.SetDefault("infra.file_encryption.rewrap_age", 30*24*time.Hour)
+
+	viper.SetDefault("infra.file_retention.purge_interval", time.Hour)
+
+	viper.SetDefault("infra.pii_encryption.provider", "vault")
+	viper.SetDefault("infra.pii_encryption.key_name", "erp-pii")
+	viper.SetDefault("infra.pii_encryption.index_key_name", "erp-pii-blind-index")
+	viper.SetDefault("infra.pii_encryption.backfill_batch_size", 200)
+	viper.SetDefault("infra.bank_verification.timeout", 15*time.Second)
+
 	viper.SetDefault("jwt.signing_method", "HS256")
 	viper.SetDefault("jwt.access_expiry", 15*time.Minute)
 	viper.SetDefault("jwt.refresh_expiry", 30*24*time.Hour)
@@ -227,11 +408,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_SIGNING_METHOD must be either 'HS256' or 'RS256'")
 	}
 
-	if c.Infra.Postgres.Platform.User == "" {
-		return fmt.Errorf("POSTGRES_USER is required")
+	if c.Infra.Postgres.Platform.VaultRole == "" {
+		if c.Infra.Postgres.Platform.User == "" {
+			return fmt.Errorf("POSTGRES_USER is required")
+		}
+		if c.Infra.Postgres.Platform.Password == "" {
+			return fmt.Errorf("POSTGRES_PASSWORD is required")
+		}
+	} else if c.Migration.Role == "" {
+		return fmt.Errorf("MIGRATION_ROLE is required with POSTGRES_VAULT_ROLE")
+	}
+	if c.IsProduction() && c.Infra.BankVerification.Provider == "fake" {
+		return fmt.Errorf("BANK_VERIFICATION_PROVIDER 'fake' cannot be used in production")
+	}
+	if _, err := c.Worker.QueueConcurrency(); err != nil {
+		return err
+	}
+	switch c.Tracing.Exporter {
+	case "otlp", "stdout", "none":
+	default:
+		return fmt.Errorf("TRACING_EXPORTER must be one of 'otlp', 'stdout' or 'none'")
 	}
-	if c.Infra.Postgres.Platform.Password == "" {
-		return fmt.Errorf("POSTGRES_PASSWORD is required")
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1")
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -10,6 +11,14 @@ type InfraConfig struct {
 	Redis    RedisConfig    `mapstructure:"redis"`
 	Minio    MinioConfig    `mapstructure:"minio"`
 	Vault    VaultConfig    `mapstructure:"vault"`
+	Clamd    ClamdConfig    `mapstructure:"clamd"`
+
+	FileStorage    FileStorageConfig    `mapstructure:"file_storage"`
+	FileEncryption FileEncryptionConfig `mapstructure:"file_encryption"`
+	FileRetention  FileRetentionConfig  `mapstructure:"file_retention"`
+	PIIEncryption  PIIEncryptionConfig  `mapstructure:"pii_encryption"`
+
+	BankVerification BankVerificationConfig `mapstructure:"bank_verification"`
 }
 
 type PostgresConfig struct {
@@ -27,6 +36,10 @@ type PlatformDBConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// VaultRole, when set, replaces User and Password with dynamic
+	// credentials from Vault's database/creds/<role>, renewed and rotated
+	// for the life of the process.
+	VaultRole string `mapstructure:"vault_role"`
 }
 
 type TenantDBConfig struct {
@@ -38,6 +51,13 @@ type TenantDBConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// MaxPools caps how many dedicated tenant databases a process holds a
+	// pool for at once, so connections stay within MaxPools * MaxOpenConns.
+	MaxPools        int           `mapstructure:"max_pools"`
+	PoolIdleTimeout time.Duration `mapstructure:"pool_idle_timeout"`
+	// PlacementTTL is how long a process caches which database a tenant
+	// uses; moving a tenant takes at least this long.
+	PlacementTTL time.Duration `mapstructure:"placement_ttl"`
 }
 
 type RedisConfig struct {
@@ -60,9 +80,71 @@ type MinioConfig struct {
 	Bucket    string `mapstructure:"bucket"`
 	UseSSL    bool   `mapstructure:"use_ssl"`
 	Region    string `mapstructure:"region"`
+
+	PresignExpiry    time.Duration `mapstructure:"presign_expiry"`
+	QuarantineBucket string        `mapstructure:"quarantine_bucket"`
+}
+
+// FileStorageConfig selects the object storage backend: "minio", or "local",
+// which keeps buckets as directories under LocalRoot and serves presigned
+// URLs signed with LocalSigningKey from LocalPublicURL.
+type FileStorageConfig struct {
+	Driver          string `mapstructure:"driver"`
+	LocalRoot       string `mapstructure:"local_root"`
+	LocalPublicURL  string `mapstructure:"local_public_url"`
+	LocalSigningKey string `mapstructure:"local_signing_key"`
+}
+
+// ClamdConfig points at a clamd daemon, either "tcp://host:port" or
+// "unix:///path/to/clamd.sock". A file that fails MaxScanAttempts scans is
+// left SCAN_FAILED and has to be uploaded again.
+type ClamdConfig struct {
+	Address         string        `mapstructure:"address"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	ScanInterval    time.Duration `mapstructure:"scan_interval"`
+	MaxScanAttempts int           `mapstructure:"max_scan_attempts"`
+}
+
+// FileEncryptionConfig selects where tenant key-encryption keys live:
+// "vault" (transit engine) or "local", which derives them from LocalKey
+// (base64, 32 bytes) and is only meant for development.
+type FileEncryptionConfig struct {
+	Provider       string        `mapstructure:"provider"`
+	LocalKey       string        `mapstructure:"local_key"`
+	KeyPrefix      string        `mapstructure:"key_prefix"`
+	RewrapInterval time.Duration `mapstructure:"rewrap_interval"`
+	RewrapAge      time.Duration `mapstructure:"rewrap_age"`
+}
+
+// FileRetentionConfig sets how often files past their retention period are
+// purged. The periods themselves are per-tenant policies in the database.
+type FileRetentionConfig struct {
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// PIIEncryptionConfig selects the cipher for encrypted personal-data
+// columns: "vault" (transit keys KeyName and IndexKeyName) or "local", which
+// derives both keys from LocalKey (base64, 32 bytes) and is only meant for
+// development.
+type PIIEncryptionConfig struct {
+	Provider          string `mapstructure:"provider"`
+	LocalKey          string `mapstructure:"local_key"`
+	KeyName           string `mapstructure:"key_name"`
+	IndexKeyName      string `mapstructure:"index_key_name"`
+	BackfillBatchSize int    `mapstructure:"backfill_batch_size"`
+}
+
+// BankVerificationConfig selects the bank account inquiry provider; there is
+// no default. "fake" answers deterministically from the account number, is
+// only meant for development and is refused in production. Timeout bounds a
+// single inquiry.
+type BankVerificationConfig struct {
+	Provider string        `mapstructure:"provider"`
+	Timeout  time.Duration `mapstructure:"timeout"`
 }
 
 type VaultConfig struct {
+	Address       string `mapstructure:"address"`
 	Host          string `mapstructure:"host"`
 	Port          int    `mapstructure:"port"`
 	Token         string `mapstructure:"token"`
@@ -76,6 +158,9 @@ type VaultConfig struct {
 }
 
 func (c *VaultConfig) GetAddress() string {
+	if c.Address != "" {
+		return c.Address
+	}
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
@@ -86,6 +171,18 @@ func (c *PlatformDBConfig) GetDSN() string {
 	)
 }
 
+// GetURL is GetDSN in URL form, as golang-migrate expects.
+func (c *PlatformDBConfig) GetURL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		url.QueryEscape(c.User),
+		url.QueryEscape(c.Password),
+		c.Host,
+		c.Port,
+		c.Database,
+		c.SSLMode,
+	)
+}
+
 func (c *TenantDBConfig) GetDSN(databaseName string) string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -93,6 +190,17 @@ func (c *TenantDBConfig) GetDSN(databaseName string) string {
 	)
 }
 
+func (c *TenantDBConfig) GetURL(databaseName string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		url.QueryEscape(c.User),
+		url.QueryEscape(c.Password),
+		c.Host,
+		c.Port,
+		url.PathEscape(databaseName),
+		c.SSLMode,
+	)
+}
+
 func (c *RedisConfig) GetAddress() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// secretRefPrefix marks a value to be read from Vault KV at startup, as
+// vault:<path>#<key>, e.g. POSTGRES_PASSWORD=vault:erp/postgres#password.
+const secretRefPrefix = "vault:"
+
+const secretResolveTimeout = 30 * time.Second
+
+// SecretReader reads a KV secret at path. hashivault.SecureVault
+// implements it.
+type SecretReader interface {
+	ReadSecret(ctx context.Context, path string) (map[string]interface{}, error)
+}
+
+type Option func(*loadOptions)
+
+type loadOptions struct {
+	openSecrets func(VaultConfig) (SecretReader, error)
+}
+
+// WithSecrets lets Load resolve vault: references through the reader open
+// returns. open is only called when the configuration holds a reference.
+func WithSecrets(open func(VaultConfig) (SecretReader, error)) Option {
+	return func(o *loadOptions) {
+		o.openSecrets = open
+	}
+}
+
+// secretRefs returns every string field of c holding a vault: reference,
+// keyed by its mapstructure path. The Vault connection settings are skipped:
+// they are needed to reach Vault in the first place.
+func (c *Config) secretRefs() map[string]*string {
+	refs := make(map[string]*string)
+	collectSecretRefs(reflect.ValueOf(c).Elem(), "", refs)
+	return refs
+}
+
+func collectSecretRefs(v reflect.Value, path string, refs map[string]*string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(VaultConfig{}) {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Tag.Get("mapstructure")
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			collectSecretRefs(v.Field(i), name, refs)
+		}
+	case reflect.String:
+		if strings.HasPrefix(v.String(), secretRefPrefix) {
+			refs[path] = v.Addr().Interface().(*string)
+		}
+	}
+}
+
+// resolveSecrets replaces every vault: reference with the value it names,
+// reading each secret path once.
+func (c *Config) resolveSecrets(ctx context.Context, reader SecretReader) error {
+	secrets := make(map[string]map[string]interface{})
+	for field, value := range c.secretRefs() {
+		path, key, ok := strings.Cut(strings.TrimPrefix(*value, secretRefPrefix), "#")
+		if !ok || path == "" || key == "" {
+			return fmt.Errorf("%s: vault reference must look like vault:<path>#<key>", field)
+		}
+		data, ok := secrets[path]
+		if !ok {
+			var err error
+			if data, err = reader.ReadSecret(ctx, path); err != nil {
+				return fmt.Errorf("%s: read vault secret %s: %w", field, path, err)
+			}
+			secrets[path] = data
+		}
+		resolved, ok := data[key].(string)
+		if !ok {
+			return fmt.Errorf("%s: vault secret %s has no string key %q", field, path, key)
+		}
+		*value = resolved
+	}
+	return nil
+}
+
+func (c *Config) applySecrets(opts loadOptions) error {
+	if len(c.secretRefs()) == 0 {
+		return nil
+	}
+	if opts.openSecrets == nil {
+		return fmt.Errorf("configuration holds vault: references but this binary cannot resolve them")
+	}
+	reader, err := opts.openSecrets(c.Infra.Vault)
+	if err != nil {
+		return fmt.Errorf("connect to vault: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+	return c.resolveSecrets(ctx, reader)
+}
@@ -0,0 +1,594 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/ast"
+)
+
+// queryCost estimates the work an operation asks for before it runs. Every
+// field costs one, times the number of items its enclosing lists are
+// expected to return: the length of an ids argument, a perPage argument (or
+// its schema default) for the lists of a page, and defaultListSize for any
+// other list. A document it cannot parse, or an operation it cannot find,
+// is an error rather than a free pass, so nothing unpriced reaches Exec.
+func queryCost(schema *ast.Schema, query, operationName string, variables map[string]any, defaultListSize int) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, fmt.Errorf("invalid query: %w", err)
+	}
+
+	op, err := doc.operation(operationName)
+	if err != nil {
+		return 0, err
+	}
+	root, ok := schema.RootOperationTypes[op.kind].(*ast.ObjectTypeDefinition)
+	if !ok {
+		return 0, fmt.Errorf("schema does not support %s operations", op.kind)
+	}
+
+	c := &costEstimator{
+		schema:          schema,
+		fragments:       doc.fragments,
+		variables:       variables,
+		defaultListSize: defaultListSize,
+		visiting:        make(map[string]bool),
+	}
+	return c.selections(root, op.selections, 0)
+}
+
+type costEstimator struct {
+	schema          *ast.Schema
+	fragments       map[string]*fragmentDef
+	variables       map[string]any
+	defaultListSize int
+	visiting        map[string]bool
+}
+
+// selections returns the cost of one item of typ with the given selections.
+// pageSize, when set, is the size hint a parent field's perPage argument
+// gives to the lists below it.
+func (c *costEstimator) selections(typ ast.NamedType, sels []*selectionNode, pageSize int) (int, error) {
+	total := 0
+	for _, sel := range sels {
+		switch {
+		case sel.spread != "":
+			frag, ok := c.fragments[sel.spread]
+			if !ok {
+				return 0, fmt.Errorf("unknown fragment %q", sel.spread)
+			}
+			if c.visiting[sel.spread] {
+				return 0, fmt.Errorf("fragment %q spreads itself", sel.spread)
+			}
+			c.visiting[sel.spread] = true
+			cost, err := c.selections(c.typeOr(frag.on, typ), frag.selections, pageSize)
+			delete(c.visiting, sel.spread)
+			if err != nil {
+				return 0, err
+			}
+			total += cost
+
+		case sel.name == "":
+			cost, err := c.selections(c.typeOr(sel.on, typ), sel.children, pageSize)
+			if err != nil {
+				return 0, err
+			}
+			total += cost
+
+		default:
+			cost, err := c.field(typ, sel, pageSize)
+			if err != nil {
+				return 0, err
+			}
+			total += cost
+		}
+	}
+	return total, nil
+}
+
+func (c *costEstimator) field(parent ast.NamedType, sel *selectionNode, pageSize int) (int, error) {
+	obj, ok := parent.(*ast.ObjectTypeDefinition)
+	if !ok || strings.HasPrefix(sel.name, "__") {
+		return 1, nil
+	}
+	def := obj.Fields.Get(sel.name)
+	if def == nil || len(sel.children) == 0 {
+		return 1, nil
+	}
+
+	childPageSize := c.intArg(def, sel, "perPage")
+	children, err := c.selections(unwrap(def.Type), sel.children, childPageSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if !isList(def.Type) {
+		return 1 + children, nil
+	}
+	size := c.defaultListSize
+	switch {
+	case sel.args["ids"] != nil:
+		size = c.listLength(sel.args["ids"])
+	case pageSize > 0:
+		size = pageSize
+	}
+	return 1 + size*children, nil
+}
+
+func (c *costEstimator) typeOr(name string, fallback ast.NamedType) ast.NamedType {
+	if name == "" {
+		return fallback
+	}
+	if t, ok := c.schema.Types[name]; ok {
+		return t
+	}
+	return fallback
+}
+
+func (c *costEstimator) resolve(v any) any {
+	if ref, ok := v.(variableRef); ok {
+		return c.variables[string(ref)]
+	}
+	return v
+}
+
+func (c *costEstimator) intArg(def *ast.FieldDefinition, sel *selectionNode, name string) int {
+	if v, ok := sel.args[name]; ok {
+		return toInt(c.resolve(v))
+	}
+	if arg := def.Arguments.Get(name); arg != nil && arg.Default != nil {
+		return toInt(arg.Default.Deserialize(nil))
+	}
+	return 0
+}
+
+func (c *costEstimator) listLength(v any) int {
+	if list, ok := c.resolve(v).([]any); ok {
+		return len(list)
+	}
+	return 1
+}
+
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func unwrap(t ast.Type) ast.NamedType {
+	for {
+		switch w := t.(type) {
+		case *ast.NonNull:
+			t = w.OfType
+		case *ast.List:
+			t = w.OfType
+		case ast.NamedType:
+			return w
+		default:
+			return nil
+		}
+	}
+}
+
+func isList(t ast.Type) bool {
+	if nn, ok := t.(*ast.NonNull); ok {
+		t = nn.OfType
+	}
+	_, ok := t.(*ast.List)
+	return ok
+}
+
+// The rest of this file is a parser for just enough of an executable
+// document to price it: operations, fragments, selections and argument
+// values. Directives and variable definitions are skipped.
+
+type variableRef string
+
+type selectionNode struct {
+	name     string
+	args     map[string]any
+	spread   string
+	on       string
+	children []*selectionNode
+}
+
+type operationDef struct {
+	kind       string
+	name       string
+	selections []*selectionNode
+}
+
+type fragmentDef struct {
+	on         string
+	selections []*selectionNode
+}
+
+type document struct {
+	operations []*operationDef
+	fragments  map[string]*fragmentDef
+}
+
+func (d *document) operation(name string) (*operationDef, error) {
+	if name == "" {
+		if len(d.operations) != 1 {
+			return nil, fmt.Errorf("operation name required")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	src = strings.TrimPrefix(src, "\uFEFF")
+	for i := 0; i < len(src); {
+		ch := src[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			i++
+		case ch == '#':
+			for i < len(src) && src[i] != '\n' && src[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, token{tokenPunct, "..."})
+			i += 3
+		case strings.ContainsRune("!$&()[]{}:=@|", rune(ch)):
+			tokens = append(tokens, token{tokenPunct, string(ch)})
+			i++
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] >= 'a' && src[i] <= 'z' || src[i] >= 'A' && src[i] <= 'Z' || src[i] >= '0' && src[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, token{tokenName, src[start:i]})
+		case ch == '-' || ch >= '0' && ch <= '9':
+			start := i
+			kind := tokenInt
+			i++
+			for i < len(src) && strings.ContainsRune("0123456789.eE+-", rune(src[i])) {
+				if src[i] == '.' || src[i] == 'e' || src[i] == 'E' {
+					kind = tokenFloat
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind, src[start:i]})
+		case strings.HasPrefix(src[i:], `"""`):
+			j := i + 3
+			for j < len(src) && !strings.HasPrefix(src[j:], `"""`) {
+				if strings.HasPrefix(src[j:], `\"""`) {
+					j += 3
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated block string")
+			}
+			tokens = append(tokens, token{tokenString, src[i+3 : j]})
+			i = j + 3
+		case ch == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && (src[j] == '\n' || src[j] == '\r') {
+					return nil, fmt.Errorf("unterminated string")
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, token{tokenString, src[i+1 : j]})
+			i = j + 1
+		default:
+			return nil, fmt.Errorf("unexpected character %q", ch)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parseDocument(src string) (*document, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	doc := &document{fragments: make(map[string]*fragmentDef)}
+
+	for p.peek().kind != tokenEOF {
+		if p.isName("fragment") {
+			p.next()
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			on, err := p.typeCondition()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.skipDirectives(); err != nil {
+				return nil, err
+			}
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = &fragmentDef{on: on, selections: sels}
+			continue
+		}
+
+		op, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (*operationDef, error) {
+	op := &operationDef{kind: "query"}
+	if p.isPunct("{") {
+		sels, err := p.selectionSet()
+		op.selections = sels
+		return op, err
+	}
+
+	kind, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "query", "mutation", "subscription":
+		op.kind = kind
+	default:
+		return nil, fmt.Errorf("unexpected %q", kind)
+	}
+	if p.peek().kind == tokenName {
+		op.name, _ = p.name()
+	}
+	if p.isPunct("(") {
+		if err := p.skipBalanced("(", ")"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	op.selections, err = p.selectionSet()
+	return op, err
+}
+
+func (p *parser) selectionSet() ([]*selectionNode, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []*selectionNode
+	for !p.isPunct("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	p.next()
+	return sels, nil
+}
+
+func (p *parser) selection() (*selectionNode, error) {
+	if p.isPunct("...") {
+		p.next()
+		sel := &selectionNode{}
+		if p.peek().kind == tokenName && !p.isName("on") {
+			sel.spread, _ = p.name()
+			return sel, p.skipDirectives()
+		}
+		if p.isName("on") {
+			on, err := p.typeCondition()
+			if err != nil {
+				return nil, err
+			}
+			sel.on = on
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		children, err := p.selectionSet()
+		sel.children = children
+		return sel, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct(":") {
+		p.next()
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	sel := &selectionNode{name: name, args: make(map[string]any)}
+	if p.isPunct("(") {
+		p.next()
+		for !p.isPunct(")") {
+			arg, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if sel.args[arg], err = p.value(); err != nil {
+				return nil, err
+			}
+		}
+		p.next()
+	}
+	if err := p.skipDirectives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if sel.children, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+// value parses an argument value. Ints and lists keep enough shape to be
+// priced, variables become variableRefs; anything else is returned as its
+// raw token.
+func (p *parser) value() (any, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokenPunct && tok.value == "$":
+		p.next()
+		name, err := p.name()
+		return variableRef(name), err
+	case tok.kind == tokenPunct && tok.value == "[":
+		p.next()
+		list := []any{}
+		for !p.isPunct("]") {
+			if p.peek().kind == tokenEOF {
+				return nil, fmt.Errorf("unterminated list")
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		p.next()
+		return list, nil
+	case tok.kind == tokenPunct && tok.value == "{":
+		return nil, p.skipBalanced("{", "}")
+	case tok.kind == tokenInt:
+		p.next()
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		return n, err
+	case tok.kind == tokenFloat || tok.kind == tokenString || tok.kind == tokenName:
+		p.next()
+		return tok.value, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.value)
+}
+
+func (p *parser) typeCondition() (string, error) {
+	if !p.isName("on") {
+		return "", fmt.Errorf("expected type condition")
+	}
+	p.next()
+	return p.name()
+}
+
+func (p *parser) skipDirectives() error {
+	for p.isPunct("@") {
+		p.next()
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if p.isPunct("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) skipBalanced(open, close string) error {
+	depth := 0
+	for {
+		tok := p.next()
+		switch {
+		case tok.kind == tokenEOF:
+			return fmt.Errorf("unbalanced %q", open)
+		case tok.kind == tokenPunct && tok.value == open:
+			depth++
+		case tok.kind == tokenPunct && tok.value == close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunct(v string) bool {
+	tok := p.peek()
+	return tok.kind == tokenPunct && tok.value == v
+}
+
+func (p *parser) isName(v string) bool {
+	tok := p.peek()
+	return tok.kind == tokenName && tok.value == v
+}
+
+func (p *parser) name() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenName {
+		return "", fmt.Errorf("expected name, got %q", tok.value)
+	}
+	return tok.value, nil
+}
+
+func (p *parser) expect(v string) error {
+	if tok := p.next(); tok.kind != tokenPunct || tok.value != v {
+		return fmt.Errorf("expected %q, got %q", v, tok.value)
+	}
+	return nil
+}
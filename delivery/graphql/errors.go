@@ -0,0 +1,62 @@
+package gql
+
+import (
+	"context"
+	stderrors "errors"
+
+	"erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// resolverError is what a client sees for a failed field: the AppError
+// message, with its code and any field errors under "extensions".
+type resolverError struct {
+	message    string
+	extensions map[string]any
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return e.extensions
+}
+
+// toResolverError hides anything that is not an AppError behind a generic
+// message and logs it, the way the REST error handler does.
+func toResolverError(ctx context.Context, log *zap.Logger, err error) error {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		ext := map[string]any{"code": appErr.Code}
+		if fields, ok := appErr.Details["fields"]; ok {
+			ext["fields"] = fields
+		}
+		return &resolverError{message: appErr.Message, extensions: ext}
+	}
+
+	if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+		return &resolverError{message: "request cancelled", extensions: map[string]any{"code": errors.CodeInternal}}
+	}
+
+	requestID, _ := ctx.Value(logger.CtxRequestID).(string)
+	log.Error("graphql resolver failed",
+		zap.String("request_id", requestID),
+		zap.Error(err),
+	)
+	return &resolverError{message: "internal server error", extensions: map[string]any{"code": errors.CodeInternal}}
+}
+
+func validationError(err error) error {
+	var ve validator.ValidationErrors
+	if !stderrors.As(err, &ve) {
+		return errors.ErrBadRequest("invalid request")
+	}
+	fields := make([]errors.FieldError, len(ve))
+	for i, fe := range ve {
+		fields[i] = errors.FieldError{Field: fe.Field(), Message: fe.Field() + " is invalid"}
+	}
+	return errors.ErrValidationWithFields(fields)
+}
@@ -0,0 +1,86 @@
+package gql
+
+import (
+	_ "embed"
+	"fmt"
+
+	"erp-service/config"
+	"erp-service/delivery/http/middleware"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/gofiber/fiber/v2"
+	graphql "github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// NewSchema parses the schema against the root resolver with the depth and
+// introspection limits from cfg.
+func NewSchema(cfg config.GraphQLConfig) (*graphql.Schema, error) {
+	opts := []graphql.SchemaOpt{
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(cfg.MaxDepth),
+	}
+	if !cfg.Introspection {
+		opts = append(opts, graphql.DisableIntrospection())
+	}
+	return graphql.ParseSchema(schemaSDL, &Resolver{}, opts...)
+}
+
+type Handler struct {
+	schema *graphql.Schema
+	reader participant.BatchReader
+	items  MasterdataReader
+	config config.GraphQLConfig
+	logger *zap.Logger
+}
+
+func NewHandler(schema *graphql.Schema, reader participant.BatchReader, items MasterdataReader, cfg config.GraphQLConfig, logger *zap.Logger) *Handler {
+	return &Handler{
+		schema: schema,
+		reader: reader,
+		items:  items,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+type queryRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Serve executes a query within the tenant and product the route middleware
+// resolved. Queries estimated above the complexity limit, or that cannot be
+// estimated at all, are rejected before any resolver runs.
+func (h *Handler) Serve(c *fiber.Ctx) error {
+	var req queryRequest
+	if err := c.BodyParser(&req); err != nil || req.Query == "" {
+		return apperrors.ErrBadRequest("request body must contain a query")
+	}
+
+	cost, err := queryCost(h.schema.AST(), req.Query, req.OperationName, req.Variables, h.config.DefaultListSize)
+	if err != nil {
+		return apperrors.ErrBadRequest(err.Error())
+	}
+	if cost > h.config.MaxComplexity {
+		return apperrors.ErrBadRequest(fmt.Sprintf("query complexity %d exceeds the limit of %d", cost, h.config.MaxComplexity))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	scope := newRequestScope(tenantID, productID, middleware.GetMaskingPolicy(c), h.reader, h.items, h.logger)
+	resp := h.schema.Exec(withScope(c.UserContext(), scope), req.Query, req.OperationName, req.Variables)
+	return c.JSON(resp)
+}
@@ -0,0 +1,88 @@
+package gql
+
+import (
+	"context"
+	"sync"
+)
+
+type loaded[V any] struct {
+	value V
+	err   error
+}
+
+// loader batches and caches lookups for a single request. Keys announced
+// with expect are fetched together with the next Load that misses, so the
+// nested fields of a page of participants cost one fetch per field instead
+// of one per participant. Loads are serialised; concurrent callers wait for
+// the fetch in flight and are then served from the cache.
+type loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	maxBatch int
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	cache   map[K]loaded[V]
+}
+
+func newLoader[K comparable, V any](maxBatch int, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		maxBatch: maxBatch,
+		queued:   make(map[K]struct{}),
+		cache:    make(map[K]loaded[V]),
+	}
+}
+
+// expect queues keys for the next fetch without loading them.
+func (l *loader[K, V]) expect(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := l.cache[key]; ok {
+			continue
+		}
+		if _, ok := l.queued[key]; ok {
+			continue
+		}
+		l.queued[key] = struct{}{}
+		l.pending = append(l.pending, key)
+	}
+}
+
+// Load returns the value for key, fetching it along with up to maxBatch-1
+// queued keys on a miss. Keys the fetch does not return load as the zero
+// value.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if hit, ok := l.cache[key]; ok {
+		return hit.value, hit.err
+	}
+
+	batch := []K{key}
+	rest := l.pending[:0]
+	for _, queued := range l.pending {
+		if queued != key && len(batch) < l.maxBatch {
+			batch = append(batch, queued)
+			delete(l.queued, queued)
+			continue
+		}
+		if queued == key {
+			delete(l.queued, queued)
+			continue
+		}
+		rest = append(rest, queued)
+	}
+	l.pending = rest
+
+	values, err := l.fetch(ctx, batch)
+	for _, k := range batch {
+		l.cache[k] = loaded[V]{value: values[k], err: err}
+	}
+
+	hit := l.cache[key]
+	return hit.value, hit.err
+}
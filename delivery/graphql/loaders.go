@@ -0,0 +1,148 @@
+package gql
+
+import (
+	"context"
+
+	"erp-service/masterdata"
+	"erp-service/pkg/masking"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type scopeKey struct{}
+
+func withScope(ctx context.Context, scope *requestScope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+func scopeFromContext(ctx context.Context) *requestScope {
+	scope, _ := ctx.Value(scopeKey{}).(*requestScope)
+	return scope
+}
+
+// requestScope carries what the route middleware established about the
+// caller, the tenant and product every query is confined to and their PII
+// visibility, along with the loaders of one request. Every loader fetches
+// within that scope and masks what it returns, so a resolver never sees
+// data the REST endpoints would not show.
+type requestScope struct {
+	tenantID  uuid.UUID
+	productID uuid.UUID
+	policy    masking.Policy
+	reader    participant.BatchReader
+	items     MasterdataReader
+	log       *zap.Logger
+
+	participants  *loader[uuid.UUID, *participant.ParticipantResponse]
+	identities    *loader[uuid.UUID, []participant.IdentityResponse]
+	addresses     *loader[uuid.UUID, []participant.AddressResponse]
+	bankAccounts  *loader[uuid.UUID, []participant.BankAccountResponse]
+	familyMembers *loader[uuid.UUID, []participant.FamilyMemberResponse]
+	employments   *loader[uuid.UUID, *participant.EmploymentResponse]
+	pensions      *loader[uuid.UUID, *participant.PensionResponse]
+	beneficiaries *loader[uuid.UUID, []participant.BeneficiaryResponse]
+	statusHistory *loader[uuid.UUID, []participant.StatusHistoryResponse]
+	labels        *loader[string, map[string]*masterdata.ItemResponse]
+}
+
+func newRequestScope(tenantID, productID uuid.UUID, policy masking.Policy, reader participant.BatchReader, items MasterdataReader, log *zap.Logger) *requestScope {
+	batch := func(ids []uuid.UUID) *participant.BatchParticipantsRequest {
+		return &participant.BatchParticipantsRequest{
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantIDs: ids,
+		}
+	}
+
+	return &requestScope{
+		tenantID:  tenantID,
+		productID: productID,
+		policy:    policy,
+		reader:    reader,
+		items:     items,
+		log:       log,
+		participants: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*participant.ParticipantResponse, error) {
+			result, err := reader.GetParticipantsByIDs(ctx, batch(ids))
+			for _, p := range result {
+				masking.Apply(p, policy)
+			}
+			return result, err
+		}),
+		identities: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.IdentityResponse, error) {
+			result, err := reader.ListIdentitiesByParticipantIDs(ctx, batch(ids))
+			maskValues(result, policy)
+			return result, err
+		}),
+		addresses: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.AddressResponse, error) {
+			return reader.ListAddressesByParticipantIDs(ctx, batch(ids))
+		}),
+		bankAccounts: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.BankAccountResponse, error) {
+			result, err := reader.ListBankAccountsByParticipantIDs(ctx, batch(ids))
+			maskValues(result, policy)
+			return result, err
+		}),
+		familyMembers: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.FamilyMemberResponse, error) {
+			return reader.ListFamilyMembersByParticipantIDs(ctx, batch(ids))
+		}),
+		employments: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*participant.EmploymentResponse, error) {
+			return reader.GetEmploymentsByParticipantIDs(ctx, batch(ids))
+		}),
+		pensions: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*participant.PensionResponse, error) {
+			return reader.GetPensionsByParticipantIDs(ctx, batch(ids))
+		}),
+		beneficiaries: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.BeneficiaryResponse, error) {
+			result, err := reader.ListBeneficiariesByParticipantIDs(ctx, batch(ids))
+			maskValues(result, policy)
+			return result, err
+		}),
+		statusHistory: newLoader(participant.MaxBatchParticipants, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]participant.StatusHistoryResponse, error) {
+			return reader.ListStatusHistoriesByParticipantIDs(ctx, batch(ids))
+		}),
+		labels: newLoader(1, func(ctx context.Context, categories []string) (map[string]map[string]*masterdata.ItemResponse, error) {
+			result := make(map[string]map[string]*masterdata.ItemResponse, len(categories))
+			for _, category := range categories {
+				tree, err := items.GetItemTree(ctx, category, &tenantID)
+				if err != nil {
+					return nil, err
+				}
+				byCode := make(map[string]*masterdata.ItemResponse, len(tree))
+				for _, item := range tree {
+					byCode[item.Code] = item
+				}
+				result[category] = byCode
+			}
+			return result, nil
+		}),
+	}
+}
+
+// expectParticipants queues every child loader for the given participants,
+// which are about to be rendered together.
+func (s *requestScope) expectParticipants(ids ...uuid.UUID) {
+	s.identities.expect(ids...)
+	s.addresses.expect(ids...)
+	s.bankAccounts.expect(ids...)
+	s.familyMembers.expect(ids...)
+	s.employments.expect(ids...)
+	s.pensions.expect(ids...)
+	s.beneficiaries.expect(ids...)
+	s.statusHistory.expect(ids...)
+}
+
+func (s *requestScope) mask(v interface{}) {
+	masking.Apply(v, s.policy)
+}
+
+func (s *requestScope) fail(ctx context.Context, err error) error {
+	return toResolverError(ctx, s.log, err)
+}
+
+// maskValues masks every slice in a batch result; map values are not
+// addressable, so masking.Apply cannot walk the map itself.
+func maskValues[V any](result map[uuid.UUID][]V, policy masking.Policy) {
+	for _, values := range result {
+		masking.Apply(values, policy)
+	}
+}
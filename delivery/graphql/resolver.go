@@ -0,0 +1,171 @@
+package gql
+
+import (
+	"context"
+
+	"erp-service/masterdata"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type MasterdataReader interface {
+	GetItemTree(ctx context.Context, categoryCode string, tenantID *uuid.UUID) ([]*masterdata.ItemResponse, error)
+}
+
+var validate = validator.New()
+
+// Resolver is the root Query resolver. Per-request state, the caller's
+// scope and the loaders, travels in the context; see requestScope.
+type Resolver struct{}
+
+func (r *Resolver) Participant(ctx context.Context, args struct{ ID graphql.ID }) (*participantResolver, error) {
+	scope := scopeFromContext(ctx)
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+
+	p, err := scope.participants.Load(ctx, id)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	if p == nil {
+		return nil, nil
+	}
+	scope.expectParticipants(p.ID)
+	return &participantResolver{p: p}, nil
+}
+
+func (r *Resolver) Participants(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*participantResolver, error) {
+	scope := scopeFromContext(ctx)
+	if len(args.IDs) > participant.MaxBatchParticipants {
+		return nil, scope.fail(ctx, errors.ErrBadRequest("too many participant IDs"))
+	}
+
+	ids := make([]uuid.UUID, len(args.IDs))
+	for i, raw := range args.IDs {
+		id, err := parseID(raw)
+		if err != nil {
+			return nil, scope.fail(ctx, err)
+		}
+		ids[i] = id
+	}
+	scope.participants.expect(ids...)
+
+	results := make([]*participantResolver, len(ids))
+	found := make([]uuid.UUID, 0, len(ids))
+	for i, id := range ids {
+		p, err := scope.participants.Load(ctx, id)
+		if err != nil {
+			return nil, scope.fail(ctx, err)
+		}
+		if p != nil {
+			results[i] = &participantResolver{p: p}
+			found = append(found, p.ID)
+		}
+	}
+	scope.expectParticipants(found...)
+	return results, nil
+}
+
+type participantPageArgs struct {
+	Page      int32
+	PerPage   int32
+	Status    *string
+	Search    *string
+	SortBy    string
+	SortOrder string
+}
+
+func (r *Resolver) ParticipantPage(ctx context.Context, args participantPageArgs) (*participantPageResolver, error) {
+	scope := scopeFromContext(ctx)
+	req := &participant.ListParticipantsRequest{
+		TenantID:  scope.tenantID,
+		ProductID: scope.productID,
+		Status:    args.Status,
+		Page:      int(args.Page),
+		PerPage:   int(args.PerPage),
+		SortBy:    args.SortBy,
+		SortOrder: args.SortOrder,
+	}
+	if args.Search != nil {
+		req.Search = *args.Search
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, scope.fail(ctx, validationError(err))
+	}
+
+	items, pagination, err := scope.reader.ListParticipantPage(ctx, req)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+
+	page := &participantPageResolver{pagination: pagination}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, p := range items {
+		scope.mask(p)
+		page.items = append(page.items, &participantResolver{p: p})
+		ids = append(ids, p.ID)
+	}
+	scope.expectParticipants(ids...)
+	return page, nil
+}
+
+func (r *Resolver) MasterdataItems(ctx context.Context, args struct{ Category string }) ([]*masterdataItemResolver, error) {
+	scope := scopeFromContext(ctx)
+	items, err := scope.items.GetItemTree(ctx, args.Category, &scope.tenantID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+
+	results := make([]*masterdataItemResolver, 0, len(items))
+	for _, item := range items {
+		results = append(results, &masterdataItemResolver{item: item})
+	}
+	return results, nil
+}
+
+type participantPageResolver struct {
+	items      []*participantResolver
+	pagination *participant.PaginationMeta
+}
+
+func (r *participantPageResolver) Items() []*participantResolver {
+	return r.items
+}
+
+func (r *participantPageResolver) Pagination() *paginationResolver {
+	return &paginationResolver{meta: r.pagination}
+}
+
+type paginationResolver struct {
+	meta *participant.PaginationMeta
+}
+
+func (r *paginationResolver) Page() int32       { return int32(r.meta.Page) }
+func (r *paginationResolver) PerPage() int32    { return int32(r.meta.PerPage) }
+func (r *paginationResolver) Total() int32      { return int32(r.meta.Total) }
+func (r *paginationResolver) TotalPages() int32 { return int32(r.meta.TotalPages) }
+
+type masterdataItemResolver struct {
+	item *masterdata.ItemResponse
+}
+
+func (r *masterdataItemResolver) Code() string          { return r.item.Code }
+func (r *masterdataItemResolver) Name() string          { return r.item.Name }
+func (r *masterdataItemResolver) AltName() *string      { return r.item.AltName }
+func (r *masterdataItemResolver) ParentID() *graphql.ID { return optionalID(r.item.ParentItemID) }
+func (r *masterdataItemResolver) SortOrder() int32      { return int32(r.item.SortOrder) }
+func (r *masterdataItemResolver) IsDefault() bool       { return r.item.IsDefault }
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, errors.ErrBadRequest("invalid ID: " + string(id))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,174 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"erp-service/config"
+	"erp-service/delivery/http/middleware"
+	"erp-service/iam/product"
+	"erp-service/impl/postgres"
+	implredis "erp-service/impl/redis"
+	"erp-service/infrastructure"
+	"erp-service/masterdata"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/pii"
+	"erp-service/saving/participant"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	app     *fiber.App
+	config  *config.Config
+	logger  *zap.Logger
+	closeDB func(context.Context) error
+}
+
+// NewServer wires the participant and masterdata reads behind the same
+// authentication, tenant, product and role checks as the REST participant
+// routes.
+func NewServer(cfg *config.Config) *Server {
+	zapLogger, _ := logger.NewZapLoggerWithConfig(cfg.Log, cfg.App.Environment)
+
+	postgresDB, closePostgres, err := infrastructure.NewPostgres(cfg, zapLogger)
+	if err != nil {
+		log.Fatal("failed to connect to postgres:", err)
+	}
+	tenantDBs := infrastructure.NewTenantRouter(cfg, postgresDB, zapLogger)
+	closeDB := func(ctx context.Context) error {
+		return errors.Join(tenantDBs.Close(), closePostgres(ctx))
+	}
+
+	redisClient, err := infrastructure.NewRedis(cfg.Infra.Redis)
+	if err != nil {
+		log.Fatal("failed to connect to redis:", err)
+	}
+	inMemoryStore := implredis.NewRedis(redisClient)
+
+	piiCipher, err := infrastructure.NewPIICipher(cfg)
+	if err != nil {
+		log.Fatal("failed to configure pii encryption:", err)
+	}
+	pii.Register(piiCipher)
+
+	masterdataUsecase := masterdata.NewUsecase(
+		cfg,
+		postgres.NewMasterdataCategoryRepository(postgresDB),
+		postgres.NewMasterdataItemRepository(postgresDB),
+		inMemoryStore,
+	)
+	productUsecase := product.NewUsecase(postgres.NewProductRepository(postgresDB), inMemoryStore)
+	batchReader := participant.NewBatchReader(
+		postgres.NewParticipantRepository(tenantDBs),
+		postgres.NewParticipantIdentityRepository(tenantDBs),
+		postgres.NewParticipantAddressRepository(tenantDBs),
+		postgres.NewParticipantBankAccountRepository(tenantDBs),
+		postgres.NewParticipantFamilyMemberRepository(tenantDBs),
+		postgres.NewParticipantEmploymentRepository(tenantDBs),
+		postgres.NewParticipantPensionRepository(tenantDBs),
+		postgres.NewParticipantBeneficiaryRepository(tenantDBs),
+		postgres.NewParticipantStatusHistoryRepository(tenantDBs),
+	)
+
+	schema, err := NewSchema(cfg.GraphQL)
+	if err != nil {
+		log.Fatal("failed to parse graphql schema:", err)
+	}
+	handler := NewHandler(schema, batchReader, masterdataUsecase, cfg.GraphQL, zapLogger)
+
+	app := fiber.New(fiber.Config{
+		JSONEncoder:  json.Marshal,
+		JSONDecoder:  json.Unmarshal,
+		AppName:      cfg.App.Name,
+		BodyLimit:    1024 * 1024,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+		ErrorHandler: ErrorHandler(zapLogger),
+	})
+	middleware.New(cfg, zapLogger).Setup(app)
+
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "ok"})
+	})
+	app.Post("/graphql",
+		middleware.JWTAuth(cfg, inMemoryStore),
+		middleware.ExtractTenantContext(),
+		middleware.ExtractFrendzSavingProduct(productUsecase),
+		middleware.RequireProductRole("PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER"),
+		handler.Serve,
+	)
+
+	return &Server{
+		app:     app,
+		config:  cfg,
+		logger:  zapLogger,
+		closeDB: closeDB,
+	}
+}
+
+func (s *Server) App() *fiber.App {
+	return s.app
+}
+
+func (s *Server) Start() error {
+	addr := fmt.Sprintf("%s:%d", s.config.GraphQL.Host, s.config.GraphQL.Port)
+	s.logger.Info("graphql server listening", zap.String("addr", addr))
+	return s.app.Listen(addr)
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.app.ShutdownWithContext(ctx)
+	return errors.Join(err, s.closeDB(ctx))
+}
+
+type errorBody struct {
+	Errors []*errorEntry `json:"errors"`
+}
+
+type errorEntry struct {
+	Message    string         `json:"message"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// ErrorHandler answers requests that fail before execution, in the
+// middleware chain or the complexity check, in the GraphQL error shape
+// so clients need only one error path.
+func ErrorHandler(zapLogger *zap.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		status := fiber.StatusInternalServerError
+		body := &errorEntry{
+			Message:    "internal server error",
+			Extensions: map[string]any{"code": apperrors.CodeInternal},
+		}
+
+		var appErr *apperrors.AppError
+		var fiberErr *fiber.Error
+		switch {
+		case apperrors.As(err, &appErr):
+			status = appErr.HTTPStatus
+			if status < 500 {
+				body.Message = appErr.Message
+				body.Extensions["code"] = appErr.Code
+			}
+		case errors.As(err, &fiberErr):
+			status = fiberErr.Code
+			body.Message = fiberErr.Message
+		}
+
+		if status >= 500 {
+			zapLogger.Error("graphql request failed",
+				zap.String("request_id", middleware.GetRequestID(c)),
+				zap.String("path", c.Path()),
+				zap.Error(err),
+			)
+		}
+		return c.Status(status).JSON(errorBody{Errors: []*errorEntry{body}})
+	}
+}
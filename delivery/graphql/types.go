@@ -0,0 +1,444 @@
+package gql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Masterdata categories behind the *Label fields.
+const (
+	categoryGender          = "GENDER"
+	categoryMaritalStatus   = "MARITAL_STATUS"
+	categoryNationality     = "NATIONALITY"
+	categoryReligion        = "RELIGION"
+	categoryIdentityType    = "IDENTITY_TYPE"
+	categoryProvince        = "PROVINCE"
+	categoryRetirementType  = "RETIREMENT_TYPE"
+	categoryPensionCategory = "PARTICIPANT_PENSION_CATEGORY"
+	categoryPensionStatus   = "PARTICIPANT_PENSION_STATUS"
+)
+
+// label resolves a stored masterdata code to its display name. Unknown
+// codes resolve to null rather than failing the query.
+func label(ctx context.Context, category string, code *string) (*string, error) {
+	if code == nil || *code == "" {
+		return nil, nil
+	}
+	scope := scopeFromContext(ctx)
+	items, err := scope.labels.Load(ctx, category)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	if item, ok := items[*code]; ok {
+		return &item.Name, nil
+	}
+	return nil, nil
+}
+
+func labelOf(ctx context.Context, category, code string) (*string, error) {
+	return label(ctx, category, &code)
+}
+
+func toID(id uuid.UUID) graphql.ID {
+	return graphql.ID(id.String())
+}
+
+func optionalID(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	gid := toID(*id)
+	return &gid
+}
+
+func toTime(t time.Time) graphql.Time {
+	return graphql.Time{Time: t}
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	gt := toTime(*t)
+	return &gt
+}
+
+type participantResolver struct {
+	p *participant.ParticipantResponse
+}
+
+func (r *participantResolver) ID() graphql.ID             { return toID(r.p.ID) }
+func (r *participantResolver) TenantID() graphql.ID       { return toID(r.p.TenantID) }
+func (r *participantResolver) ProductID() graphql.ID      { return toID(r.p.ProductID) }
+func (r *participantResolver) UserID() *graphql.ID        { return optionalID(r.p.UserID) }
+func (r *participantResolver) FullName() string           { return r.p.FullName }
+func (r *participantResolver) Gender() *string            { return r.p.Gender }
+func (r *participantResolver) PlaceOfBirth() *string      { return r.p.PlaceOfBirth }
+func (r *participantResolver) DateOfBirth() *graphql.Time { return optionalTime(r.p.DateOfBirth) }
+func (r *participantResolver) MaritalStatus() *string     { return r.p.MaritalStatus }
+func (r *participantResolver) Citizenship() *string       { return r.p.Citizenship }
+func (r *participantResolver) Religion() *string          { return r.p.Religion }
+func (r *participantResolver) KtpNumber() *string         { return r.p.KTPNumber }
+func (r *participantResolver) EmployeeNumber() *string    { return r.p.EmployeeNumber }
+func (r *participantResolver) PhoneNumber() *string       { return r.p.PhoneNumber }
+func (r *participantResolver) Status() string             { return r.p.Status }
+func (r *participantResolver) CreatedBy() graphql.ID      { return toID(r.p.CreatedBy) }
+func (r *participantResolver) SubmittedBy() *graphql.ID   { return optionalID(r.p.SubmittedBy) }
+func (r *participantResolver) SubmittedAt() *graphql.Time { return optionalTime(r.p.SubmittedAt) }
+func (r *participantResolver) ApprovedBy() *graphql.ID    { return optionalID(r.p.ApprovedBy) }
+func (r *participantResolver) ApprovedAt() *graphql.Time  { return optionalTime(r.p.ApprovedAt) }
+func (r *participantResolver) RejectedBy() *graphql.ID    { return optionalID(r.p.RejectedBy) }
+func (r *participantResolver) RejectedAt() *graphql.Time  { return optionalTime(r.p.RejectedAt) }
+func (r *participantResolver) RejectionReason() *string   { return r.p.RejectionReason }
+func (r *participantResolver) Version() int32             { return int32(r.p.Version) }
+func (r *participantResolver) CreatedAt() graphql.Time    { return toTime(r.p.CreatedAt) }
+func (r *participantResolver) UpdatedAt() graphql.Time    { return toTime(r.p.UpdatedAt) }
+
+func (r *participantResolver) StatusEffectiveDate() *graphql.Time {
+	return optionalTime(r.p.StatusEffectiveDate)
+}
+
+func (r *participantResolver) StepsCompleted() *stepsCompletedResolver {
+	return &stepsCompletedResolver{steps: r.p.StepsCompleted}
+}
+
+func (r *participantResolver) GenderLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryGender, r.p.Gender)
+}
+
+func (r *participantResolver) MaritalStatusLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryMaritalStatus, r.p.MaritalStatus)
+}
+
+func (r *participantResolver) CitizenshipLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryNationality, r.p.Citizenship)
+}
+
+func (r *participantResolver) ReligionLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryReligion, r.p.Religion)
+}
+
+func (r *participantResolver) Identities(ctx context.Context) ([]*identityResolver, error) {
+	scope := scopeFromContext(ctx)
+	identities, err := scope.identities.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*identityResolver, len(identities))
+	for i := range identities {
+		results[i] = &identityResolver{identity: &identities[i]}
+	}
+	return results, nil
+}
+
+func (r *participantResolver) Addresses(ctx context.Context) ([]*addressResolver, error) {
+	scope := scopeFromContext(ctx)
+	addresses, err := scope.addresses.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*addressResolver, len(addresses))
+	for i := range addresses {
+		results[i] = &addressResolver{address: &addresses[i]}
+	}
+	return results, nil
+}
+
+func (r *participantResolver) BankAccounts(ctx context.Context) ([]*bankAccountResolver, error) {
+	scope := scopeFromContext(ctx)
+	accounts, err := scope.bankAccounts.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*bankAccountResolver, len(accounts))
+	for i := range accounts {
+		results[i] = &bankAccountResolver{account: &accounts[i]}
+	}
+	return results, nil
+}
+
+func (r *participantResolver) FamilyMembers(ctx context.Context) ([]*familyMemberResolver, error) {
+	scope := scopeFromContext(ctx)
+	members, err := scope.familyMembers.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*familyMemberResolver, len(members))
+	for i := range members {
+		results[i] = &familyMemberResolver{member: &members[i]}
+	}
+	return results, nil
+}
+
+func (r *participantResolver) Employment(ctx context.Context) (*employmentResolver, error) {
+	scope := scopeFromContext(ctx)
+	employment, err := scope.employments.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	if employment == nil {
+		return nil, nil
+	}
+	return &employmentResolver{employment: employment}, nil
+}
+
+func (r *participantResolver) Pension(ctx context.Context) (*pensionResolver, error) {
+	scope := scopeFromContext(ctx)
+	pension, err := scope.pensions.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	if pension == nil {
+		return nil, nil
+	}
+	return &pensionResolver{pension: pension}, nil
+}
+
+func (r *participantResolver) Beneficiaries(ctx context.Context) ([]*beneficiaryResolver, error) {
+	scope := scopeFromContext(ctx)
+	beneficiaries, err := scope.beneficiaries.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*beneficiaryResolver, len(beneficiaries))
+	for i := range beneficiaries {
+		results[i] = &beneficiaryResolver{beneficiary: &beneficiaries[i]}
+	}
+	return results, nil
+}
+
+func (r *participantResolver) StatusHistory(ctx context.Context) ([]*statusChangeResolver, error) {
+	scope := scopeFromContext(ctx)
+	histories, err := scope.statusHistory.Load(ctx, r.p.ID)
+	if err != nil {
+		return nil, scope.fail(ctx, err)
+	}
+	results := make([]*statusChangeResolver, len(histories))
+	for i := range histories {
+		results[i] = &statusChangeResolver{change: &histories[i]}
+	}
+	return results, nil
+}
+
+type stepsCompletedResolver struct {
+	steps participant.StepsCompleted
+}
+
+func (r *stepsCompletedResolver) PersonalData() bool  { return r.steps.PersonalData }
+func (r *stepsCompletedResolver) Address() bool       { return r.steps.Address }
+func (r *stepsCompletedResolver) BankAccount() bool   { return r.steps.BankAccount }
+func (r *stepsCompletedResolver) FamilyMembers() bool { return r.steps.FamilyMembers }
+func (r *stepsCompletedResolver) Employment() bool    { return r.steps.Employment }
+func (r *stepsCompletedResolver) Beneficiaries() bool { return r.steps.Beneficiaries }
+func (r *stepsCompletedResolver) Pension() bool       { return r.steps.Pension }
+
+type identityResolver struct {
+	identity *participant.IdentityResponse
+}
+
+func (r *identityResolver) ID() graphql.ID             { return toID(r.identity.ID) }
+func (r *identityResolver) IdentityType() string       { return r.identity.IdentityType }
+func (r *identityResolver) IdentityNumber() string     { return r.identity.IdentityNumber }
+func (r *identityResolver) IdentityAuthority() *string { return r.identity.IdentityAuthority }
+func (r *identityResolver) IssueDate() *graphql.Time   { return optionalTime(r.identity.IssueDate) }
+func (r *identityResolver) ExpiryDate() *graphql.Time  { return optionalTime(r.identity.ExpiryDate) }
+func (r *identityResolver) PhotoFileID() *graphql.ID   { return optionalID(r.identity.PhotoFileID) }
+func (r *identityResolver) Version() int32             { return int32(r.identity.Version) }
+func (r *identityResolver) CreatedAt() graphql.Time    { return toTime(r.identity.CreatedAt) }
+func (r *identityResolver) UpdatedAt() graphql.Time    { return toTime(r.identity.UpdatedAt) }
+
+func (r *identityResolver) IdentityTypeLabel(ctx context.Context) (*string, error) {
+	return labelOf(ctx, categoryIdentityType, r.identity.IdentityType)
+}
+
+type addressResolver struct {
+	address *participant.AddressResponse
+}
+
+func (r *addressResolver) ID() graphql.ID           { return toID(r.address.ID) }
+func (r *addressResolver) AddressType() string      { return r.address.AddressType }
+func (r *addressResolver) CountryCode() *string     { return r.address.CountryCode }
+func (r *addressResolver) ProvinceCode() *string    { return r.address.ProvinceCode }
+func (r *addressResolver) CityCode() *string        { return r.address.CityCode }
+func (r *addressResolver) DistrictCode() *string    { return r.address.DistrictCode }
+func (r *addressResolver) SubdistrictCode() *string { return r.address.SubdistrictCode }
+func (r *addressResolver) PostalCode() *string      { return r.address.PostalCode }
+func (r *addressResolver) Rt() *string              { return r.address.RT }
+func (r *addressResolver) Rw() *string              { return r.address.RW }
+func (r *addressResolver) AddressLine() *string     { return r.address.AddressLine }
+func (r *addressResolver) IsPrimary() bool          { return r.address.IsPrimary }
+func (r *addressResolver) Version() int32           { return int32(r.address.Version) }
+func (r *addressResolver) CreatedAt() graphql.Time  { return toTime(r.address.CreatedAt) }
+func (r *addressResolver) UpdatedAt() graphql.Time  { return toTime(r.address.UpdatedAt) }
+
+func (r *addressResolver) ProvinceLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryProvince, r.address.ProvinceCode)
+}
+
+type bankAccountResolver struct {
+	account *participant.BankAccountResponse
+}
+
+func (r *bankAccountResolver) ID() graphql.ID                { return toID(r.account.ID) }
+func (r *bankAccountResolver) BankCode() string              { return r.account.BankCode }
+func (r *bankAccountResolver) AccountNumber() string         { return r.account.AccountNumber }
+func (r *bankAccountResolver) AccountHolderName() string     { return r.account.AccountHolderName }
+func (r *bankAccountResolver) AccountType() *string          { return r.account.AccountType }
+func (r *bankAccountResolver) CurrencyCode() string          { return r.account.CurrencyCode }
+func (r *bankAccountResolver) IsPrimary() bool               { return r.account.IsPrimary }
+func (r *bankAccountResolver) IssueDate() *graphql.Time      { return optionalTime(r.account.IssueDate) }
+func (r *bankAccountResolver) ExpiryDate() *graphql.Time     { return optionalTime(r.account.ExpiryDate) }
+func (r *bankAccountResolver) VerificationStatus() string    { return r.account.VerificationStatus }
+func (r *bankAccountResolver) VerificationProvider() *string { return r.account.VerificationProvider }
+func (r *bankAccountResolver) VerifiedHolderName() *string   { return r.account.VerifiedHolderName }
+func (r *bankAccountResolver) VerificationScore() *float64   { return r.account.VerificationScore }
+func (r *bankAccountResolver) VerificationMessage() *string  { return r.account.VerificationMessage }
+func (r *bankAccountResolver) Version() int32                { return int32(r.account.Version) }
+func (r *bankAccountResolver) CreatedAt() graphql.Time       { return toTime(r.account.CreatedAt) }
+func (r *bankAccountResolver) UpdatedAt() graphql.Time       { return toTime(r.account.UpdatedAt) }
+
+func (r *bankAccountResolver) VerificationCheckedAt() *graphql.Time {
+	return optionalTime(r.account.VerificationCheckedAt)
+}
+
+type familyMemberResolver struct {
+	member *participant.FamilyMemberResponse
+}
+
+func (r *familyMemberResolver) ID() graphql.ID           { return toID(r.member.ID) }
+func (r *familyMemberResolver) FullName() string         { return r.member.FullName }
+func (r *familyMemberResolver) RelationshipType() string { return r.member.RelationshipType }
+func (r *familyMemberResolver) IsDependent() bool        { return r.member.IsDependent }
+func (r *familyMemberResolver) Version() int32           { return int32(r.member.Version) }
+func (r *familyMemberResolver) CreatedAt() graphql.Time  { return toTime(r.member.CreatedAt) }
+func (r *familyMemberResolver) UpdatedAt() graphql.Time  { return toTime(r.member.UpdatedAt) }
+
+func (r *familyMemberResolver) SupportingDocFileID() *graphql.ID {
+	return optionalID(r.member.SupportingDocFileID)
+}
+
+type employmentResolver struct {
+	employment *participant.EmploymentResponse
+}
+
+func (r *employmentResolver) ID() graphql.ID              { return toID(r.employment.ID) }
+func (r *employmentResolver) PersonnelNumber() *string    { return r.employment.PersonnelNumber }
+func (r *employmentResolver) DateOfHire() *graphql.Time   { return optionalTime(r.employment.DateOfHire) }
+func (r *employmentResolver) CorporateGroupName() *string { return r.employment.CorporateGroupName }
+func (r *employmentResolver) LegalEntityCode() *string    { return r.employment.LegalEntityCode }
+func (r *employmentResolver) LegalEntityName() *string    { return r.employment.LegalEntityName }
+func (r *employmentResolver) BusinessUnitCode() *string   { return r.employment.BusinessUnitCode }
+func (r *employmentResolver) BusinessUnitName() *string   { return r.employment.BusinessUnitName }
+func (r *employmentResolver) TenantName() *string         { return r.employment.TenantName }
+func (r *employmentResolver) EmploymentStatus() *string   { return r.employment.EmploymentStatus }
+func (r *employmentResolver) PositionName() *string       { return r.employment.PositionName }
+func (r *employmentResolver) JobLevel() *string           { return r.employment.JobLevel }
+func (r *employmentResolver) LocationCode() *string       { return r.employment.LocationCode }
+func (r *employmentResolver) LocationName() *string       { return r.employment.LocationName }
+func (r *employmentResolver) SubLocationName() *string    { return r.employment.SubLocationName }
+func (r *employmentResolver) RetirementDate() *graphql.Time {
+	return optionalTime(r.employment.RetirementDate)
+}
+func (r *employmentResolver) RetirementTypeCode() *string { return r.employment.RetirementTypeCode }
+func (r *employmentResolver) Version() int32              { return int32(r.employment.Version) }
+func (r *employmentResolver) CreatedAt() graphql.Time     { return toTime(r.employment.CreatedAt) }
+func (r *employmentResolver) UpdatedAt() graphql.Time     { return toTime(r.employment.UpdatedAt) }
+
+func (r *employmentResolver) RetirementTypeLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryRetirementType, r.employment.RetirementTypeCode)
+}
+
+type pensionResolver struct {
+	pension *participant.PensionResponse
+}
+
+func (r *pensionResolver) ID() graphql.ID               { return toID(r.pension.ID) }
+func (r *pensionResolver) ParticipantNumber() *string   { return r.pension.ParticipantNumber }
+func (r *pensionResolver) PensionCategory() *string     { return r.pension.PensionCategory }
+func (r *pensionResolver) PensionStatus() *string       { return r.pension.PensionStatus }
+func (r *pensionResolver) EffectiveDate() *graphql.Time { return optionalTime(r.pension.EffectiveDate) }
+func (r *pensionResolver) EndDate() *graphql.Time       { return optionalTime(r.pension.EndDate) }
+func (r *pensionResolver) Version() int32               { return int32(r.pension.Version) }
+func (r *pensionResolver) CreatedAt() graphql.Time      { return toTime(r.pension.CreatedAt) }
+func (r *pensionResolver) UpdatedAt() graphql.Time      { return toTime(r.pension.UpdatedAt) }
+
+func (r *pensionResolver) ProjectedRetirementDate() *graphql.Time {
+	return optionalTime(r.pension.ProjectedRetirementDate)
+}
+
+func (r *pensionResolver) PensionCategoryLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryPensionCategory, r.pension.PensionCategory)
+}
+
+func (r *pensionResolver) PensionStatusLabel(ctx context.Context) (*string, error) {
+	return label(ctx, categoryPensionStatus, r.pension.PensionStatus)
+}
+
+type beneficiaryResolver struct {
+	beneficiary *participant.BeneficiaryResponse
+}
+
+func (r *beneficiaryResolver) ID() graphql.ID             { return toID(r.beneficiary.ID) }
+func (r *beneficiaryResolver) FamilyMemberID() graphql.ID { return toID(r.beneficiary.FamilyMemberID) }
+func (r *beneficiaryResolver) BankCode() *string          { return r.beneficiary.BankCode }
+func (r *beneficiaryResolver) AccountNumber() *string     { return r.beneficiary.AccountNumber }
+func (r *beneficiaryResolver) Version() int32             { return int32(r.beneficiary.Version) }
+func (r *beneficiaryResolver) CreatedAt() graphql.Time    { return toTime(r.beneficiary.CreatedAt) }
+func (r *beneficiaryResolver) UpdatedAt() graphql.Time    { return toTime(r.beneficiary.UpdatedAt) }
+
+func (r *beneficiaryResolver) IdentityPhotoFileID() *graphql.ID {
+	return optionalID(r.beneficiary.IdentityPhotoFileID)
+}
+
+func (r *beneficiaryResolver) FamilyCardPhotoFileID() *graphql.ID {
+	return optionalID(r.beneficiary.FamilyCardPhotoFileID)
+}
+
+func (r *beneficiaryResolver) BankBookPhotoFileID() *graphql.ID {
+	return optionalID(r.beneficiary.BankBookPhotoFileID)
+}
+
+type statusChangeResolver struct {
+	change *participant.StatusHistoryResponse
+}
+
+func (r *statusChangeResolver) ID() graphql.ID          { return toID(r.change.ID) }
+func (r *statusChangeResolver) FromStatus() *string     { return r.change.FromStatus }
+func (r *statusChangeResolver) ToStatus() string        { return r.change.ToStatus }
+func (r *statusChangeResolver) ChangedBy() graphql.ID   { return toID(r.change.ChangedBy) }
+func (r *statusChangeResolver) Reason() *string         { return r.change.Reason }
+func (r *statusChangeResolver) ChangedAt() graphql.Time { return toTime(r.change.ChangedAt) }
+func (r *statusChangeResolver) EffectiveDate() *graphql.Time {
+	return optionalTime(r.change.EffectiveDate)
+}
+
+func (r *statusChangeResolver) SupportingFileID() *graphql.ID {
+	return optionalID(r.change.SupportingFileID)
+}
+
+func (r *statusChangeResolver) Details() []*statusDetailResolver {
+	keys := make([]string, 0, len(r.change.Details))
+	for key := range r.change.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := make([]*statusDetailResolver, len(keys))
+	for i, key := range keys {
+		results[i] = &statusDetailResolver{key: key, value: r.change.Details[key]}
+	}
+	return results
+}
+
+type statusDetailResolver struct {
+	key, value string
+}
+
+func (r *statusDetailResolver) Key() string   { return r.key }
+func (r *statusDetailResolver) Value() string { return r.value }
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"time"
+
+	"erp-service/iam/user"
+	"erp-service/masterdata"
+	apperrors "erp-service/pkg/errors"
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func parseUUID(field, value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, apperrors.ErrBadRequest(field + " is required")
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, apperrors.ErrBadRequest("invalid " + field)
+	}
+	return id, nil
+}
+
+func parseOptionalUUID(field, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := parseUUID(field, value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func numericTimestamp(d *jwt.NumericDate) *timestamppb.Timestamp {
+	if d == nil {
+		return nil
+	}
+	return timestamppb.New(d.Time)
+}
+
+func toProtoClaims(caller *Caller) *iamv1.Claims {
+	claims := caller.Claims
+	out := &iamv1.Claims{
+		UserId:        claims.UserID.String(),
+		Email:         claims.Email,
+		Roles:         claims.Roles,
+		SessionId:     claims.SessionID.String(),
+		TokenId:       claims.RegisteredClaims.ID,
+		IssuedAt:      numericTimestamp(claims.IssuedAt),
+		ExpiresAt:     numericTimestamp(claims.ExpiresAt),
+		PlatformAdmin: caller.IsPlatformAdmin(),
+	}
+
+	if caller.MultiTenant == nil {
+		out.TenantId = uuidString(claims.TenantID)
+		out.ProductId = uuidString(claims.ProductID)
+		out.BranchId = uuidString(claims.BranchID)
+		out.Permissions = claims.Permissions
+		return out
+	}
+
+	for _, tenant := range caller.MultiTenant.Tenants {
+		tenantClaim := &iamv1.TenantClaim{TenantId: tenant.TenantID.String()}
+		for _, product := range tenant.Products {
+			tenantClaim.Products = append(tenantClaim.Products, &iamv1.ProductClaim{
+				ProductId:   product.ProductID.String(),
+				ProductCode: product.ProductCode,
+				Roles:       product.Roles,
+				Permissions: product.Permissions,
+			})
+		}
+		out.Tenants = append(out.Tenants, tenantClaim)
+	}
+	return out
+}
+
+func toProtoUser(u *user.UserDetailResponse) *iamv1.User {
+	roles := make([]string, len(u.Roles))
+	for i, r := range u.Roles {
+		roles[i] = r.Code
+	}
+	return &iamv1.User{
+		Id:            u.ID.String(),
+		Email:         u.Email,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		FullName:      u.FullName,
+		PhoneNumber:   stringValue(u.PhoneNumber),
+		Status:        u.Status,
+		IsActive:      u.IsActive,
+		EmailVerified: u.EmailVerified,
+		Roles:         roles,
+		CreatedAt:     timestamppb.New(u.CreatedAt),
+		UpdatedAt:     timestamppb.New(u.UpdatedAt),
+	}
+}
+
+func toProtoItem(item *masterdata.ItemResponse) *masterdatav1.Item {
+	if item == nil {
+		return nil
+	}
+	return &masterdatav1.Item{
+		Id:             item.ID.String(),
+		CategoryId:     item.CategoryID.String(),
+		TenantId:       uuidString(item.TenantID),
+		ParentItemId:   uuidString(item.ParentItemID),
+		Code:           item.Code,
+		Name:           item.Name,
+		AltName:        stringValue(item.AltName),
+		Description:    stringValue(item.Description),
+		SortOrder:      int32(item.SortOrder),
+		IsSystem:       item.IsSystem,
+		IsDefault:      item.IsDefault,
+		Status:         item.Status,
+		EffectiveFrom:  timestamp(item.EffectiveFrom),
+		EffectiveUntil: timestamp(item.EffectiveUntil),
+		Metadata:       item.Metadata,
+		Version:        int32(item.Version),
+		CreatedAt:      timestamppb.New(item.CreatedAt),
+		UpdatedAt:      timestamppb.New(item.UpdatedAt),
+	}
+}
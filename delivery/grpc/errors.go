@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	apperrors "erp-service/pkg/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain tags the ErrorInfo detail attached to every AppError status;
+// its Reason is the AppError code the REST API returns.
+const errorDomain = "erp-service"
+
+// toStatus converts handler errors to gRPC statuses. Anything that is not
+// an AppError is reported as a bare Internal error so internals do not leak.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request cancelled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+
+	appErr := apperrors.GetAppError(err)
+	if appErr == nil {
+		return status.Error(codes.Internal, "an unexpected error occurred")
+	}
+
+	st := status.New(codeForHTTPStatus(appErr.HTTPStatus), appErr.Message)
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func codeForHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		if httpStatus >= 400 && httpStatus < 500 {
+			return codes.FailedPrecondition
+		}
+		return codes.Internal
+	}
+}
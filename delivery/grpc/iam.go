@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+
+	"erp-service/iam/auth"
+	"erp-service/iam/user"
+	apperrors "erp-service/pkg/errors"
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+
+	"github.com/google/uuid"
+)
+
+type UserReader interface {
+	GetByID(ctx context.Context, callerTenantID *uuid.UUID, id uuid.UUID) (*user.UserDetailResponse, error)
+}
+
+type iamServer struct {
+	iamv1.UnimplementedIAMServiceServer
+	tokens     *TokenValidator
+	authorizer auth.Authorizer
+	users      UserReader
+}
+
+func NewIAMServer(tokens *TokenValidator, authorizer auth.Authorizer, users UserReader) iamv1.IAMServiceServer {
+	return &iamServer{tokens: tokens, authorizer: authorizer, users: users}
+}
+
+// ValidateToken reports rejected tokens in the response rather than as an
+// error, so callers can tell a bad token from a failed call.
+func (s *iamServer) ValidateToken(ctx context.Context, req *iamv1.ValidateTokenRequest) (*iamv1.ValidateTokenResponse, error) {
+	if req.GetAccessToken() == "" {
+		return nil, apperrors.ErrBadRequest("access_token is required")
+	}
+
+	caller, err := s.tokens.Validate(ctx, req.GetAccessToken())
+	if err != nil {
+		return &iamv1.ValidateTokenResponse{ErrorCode: apperrors.GetCode(err)}, nil
+	}
+
+	return &iamv1.ValidateTokenResponse{Valid: true, Claims: toProtoClaims(caller)}, nil
+}
+
+func (s *iamServer) CheckPermission(ctx context.Context, req *iamv1.CheckPermissionRequest) (*iamv1.CheckPermissionResponse, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseUUID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseUUID("product_id", req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+	if caller.Claims.UserID != userID && !caller.IsPlatformAdmin() {
+		return nil, apperrors.ErrAccessForbidden("cannot check permissions of another user")
+	}
+
+	resp, err := s.authorizer.CheckPermission(ctx, &auth.CheckPermissionRequest{
+		UserID:     userID,
+		TenantID:   tenantID,
+		ProductID:  productID,
+		Permission: req.GetPermission(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &iamv1.CheckPermissionResponse{Allowed: resp.Allowed, PlatformAdmin: resp.PlatformAdmin}, nil
+}
+
+func (s *iamServer) GetUser(ctx context.Context, req *iamv1.GetUserRequest) (*iamv1.GetUserResponse, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if caller.Claims.UserID != userID && !caller.IsPlatformAdmin() {
+		return nil, apperrors.ErrAccessForbidden("cannot read another user")
+	}
+
+	resp, err := s.users.GetByID(ctx, caller.Claims.TenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iamv1.GetUserResponse{User: toProtoUser(resp)}, nil
+}
@@ -0,0 +1,178 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/grpcapi"
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+	"erp-service/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// isPublic reports whether a method may be called without a token, mirroring
+// the REST routes: ValidateToken authenticates its argument, masterdata reads
+// are public, and health checks and reflection come from infrastructure.
+func isPublic(fullMethod string) bool {
+	if fullMethod == iamv1.IAMService_ValidateToken_FullMethodName {
+		return true
+	}
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	return service == masterdatav1.MasterdataService_ServiceDesc.ServiceName ||
+		service == healthpb.Health_ServiceDesc.ServiceName ||
+		strings.HasPrefix(service, "grpc.reflection.")
+}
+
+// withRequestContext stores the request ID, client IP and user agent where
+// the audit logger reads them. The request ID comes from the caller's
+// x-request-id metadata or is generated, and is echoed in the response header.
+func withRequestContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := firstValue(md, grpcapi.MetadataRequestID)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(grpcapi.MetadataRequestID, requestID))
+
+	ctx = context.WithValue(ctx, logger.CtxRequestID, requestID)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ctx = context.WithValue(ctx, logger.CtxIPAddress, host)
+		}
+	}
+	return context.WithValue(ctx, logger.CtxUserAgent, firstValue(md, "user-agent"))
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(logger.CtxRequestID).(string)
+	return requestID
+}
+
+func recoverPanic(log *zap.Logger, ctx context.Context, method string, err *error) {
+	if r := recover(); r != nil {
+		log.Error("rpc_panic",
+			zap.String("request_id", requestIDFromContext(ctx)),
+			zap.String("method", method),
+			zap.Any("panic", r),
+			zap.String("stack", string(debug.Stack())),
+		)
+		*err = status.Error(codes.Internal, "an unexpected error occurred")
+	}
+}
+
+// logCompleted logs a finished call at a level chosen like the REST request
+// logger, with the AppError location for failures.
+func logCompleted(log *zap.Logger, ctx context.Context, method string, start time.Time, err error) {
+	if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("request_id", requestIDFromContext(ctx)),
+		zap.String("method", method),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if err == nil {
+		log.Info("rpc_completed", fields...)
+		return
+	}
+
+	appErr := apperrors.GetAppError(err)
+	if appErr == nil {
+		log.Error("rpc_completed", append(fields, zap.Error(err))...)
+		return
+	}
+	fields = append(fields,
+		zap.String("code", appErr.Code),
+		zap.String("message", appErr.Message),
+		zap.String("kind", appErr.Kind.String()),
+		zap.String("file", appErr.File),
+		zap.Int("line", appErr.Line),
+	)
+	if appErr.Op != "" {
+		fields = append(fields, zap.String("op", appErr.Op))
+	}
+	if appErr.Err != nil {
+		fields = append(fields, zap.Error(appErr.Err))
+	}
+	if appErr.HTTPStatus >= 500 {
+		log.Error("rpc_completed", fields...)
+	} else {
+		log.Warn("rpc_completed", fields...)
+	}
+}
+
+// UnaryInterceptor sets up the request context, recovers panics, logs the
+// call, authenticates non-public methods and converts errors to statuses.
+func UnaryInterceptor(log *zap.Logger, tokens *TokenValidator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = withRequestContext(ctx)
+		defer recoverPanic(log, ctx, info.FullMethod, &err)
+
+		start := time.Now()
+		if !isPublic(info.FullMethod) {
+			caller, authErr := tokens.authenticate(ctx)
+			if authErr != nil {
+				logCompleted(log, ctx, info.FullMethod, start, authErr)
+				return nil, toStatus(authErr)
+			}
+			ctx = context.WithValue(ctx, callerKey{}, caller)
+		}
+
+		resp, err = handler(ctx, req)
+		logCompleted(log, ctx, info.FullMethod, start, err)
+		return resp, toStatus(err)
+	}
+}
+
+// StreamInterceptor applies the same policy to streaming methods; only health
+// watches and reflection stream today.
+func StreamInterceptor(log *zap.Logger, tokens *TokenValidator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := withRequestContext(ss.Context())
+		defer recoverPanic(log, ctx, info.FullMethod, &err)
+
+		if !isPublic(info.FullMethod) {
+			caller, authErr := tokens.authenticate(ctx)
+			if authErr != nil {
+				return toStatus(authErr)
+			}
+			ctx = context.WithValue(ctx, callerKey{}, caller)
+		}
+
+		return toStatus(handler(srv, &contextStream{ServerStream: ss, ctx: ctx}))
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
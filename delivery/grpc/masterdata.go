@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"context"
+
+	"erp-service/masterdata"
+	apperrors "erp-service/pkg/errors"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+
+	"github.com/google/uuid"
+)
+
+// maxValidations matches the REST batch validation limit.
+const maxValidations = 100
+
+type MasterdataReader interface {
+	GetItemByCode(ctx context.Context, categoryCode string, tenantID *uuid.UUID, itemCode string) (*masterdata.ItemResponse, error)
+	GetItemTree(ctx context.Context, categoryCode string, tenantID *uuid.UUID) ([]*masterdata.ItemResponse, error)
+	ValidateItemCodes(ctx context.Context, req *masterdata.ValidateCodesRequest) (*masterdata.ValidateCodesResponse, error)
+}
+
+type masterdataServer struct {
+	masterdatav1.UnimplementedMasterdataServiceServer
+	usecase MasterdataReader
+}
+
+func NewMasterdataServer(usecase MasterdataReader) masterdatav1.MasterdataServiceServer {
+	return &masterdataServer{usecase: usecase}
+}
+
+func (s *masterdataServer) GetItemByCode(ctx context.Context, req *masterdatav1.GetItemByCodeRequest) (*masterdatav1.GetItemByCodeResponse, error) {
+	if req.GetCategoryCode() == "" {
+		return nil, apperrors.ErrBadRequest("category_code is required")
+	}
+	if req.GetItemCode() == "" {
+		return nil, apperrors.ErrBadRequest("item_code is required")
+	}
+	tenantID, err := parseOptionalUUID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.usecase.GetItemByCode(ctx, req.GetCategoryCode(), tenantID, req.GetItemCode())
+	if err != nil {
+		return nil, err
+	}
+
+	return &masterdatav1.GetItemByCodeResponse{Item: toProtoItem(item)}, nil
+}
+
+func (s *masterdataServer) ValidateItemCodes(ctx context.Context, req *masterdatav1.ValidateItemCodesRequest) (*masterdatav1.ValidateItemCodesResponse, error) {
+	if len(req.GetValidations()) == 0 {
+		return nil, apperrors.ErrBadRequest("validations is required")
+	}
+	if len(req.GetValidations()) > maxValidations {
+		return nil, apperrors.ErrBadRequest("at most 100 validations are allowed")
+	}
+
+	validations := make([]masterdata.ValidationItem, len(req.GetValidations()))
+	for i, v := range req.GetValidations() {
+		if v.GetCategoryCode() == "" || v.GetItemCode() == "" {
+			return nil, apperrors.ErrBadRequest("category_code and item_code are required")
+		}
+		tenantID, err := parseOptionalUUID("tenant_id", v.GetTenantId())
+		if err != nil {
+			return nil, err
+		}
+		validations[i] = masterdata.ValidationItem{
+			CategoryCode: v.GetCategoryCode(),
+			ItemCode:     v.GetItemCode(),
+			TenantID:     tenantID,
+		}
+	}
+
+	resp, err := s.usecase.ValidateItemCodes(ctx, &masterdata.ValidateCodesRequest{Validations: validations})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*masterdatav1.ItemCodeValidationResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &masterdatav1.ItemCodeValidationResult{
+			CategoryCode: r.CategoryCode,
+			ItemCode:     r.ItemCode,
+			Valid:        r.Valid,
+			Message:      r.Message,
+		}
+	}
+	return &masterdatav1.ValidateItemCodesResponse{AllValid: resp.AllValid, Results: results}, nil
+}
+
+func (s *masterdataServer) GetItemTree(ctx context.Context, req *masterdatav1.GetItemTreeRequest) (*masterdatav1.GetItemTreeResponse, error) {
+	if req.GetCategoryCode() == "" {
+		return nil, apperrors.ErrBadRequest("category_code is required")
+	}
+	tenantID, err := parseOptionalUUID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.usecase.GetItemTree(ctx, req.GetCategoryCode(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &masterdatav1.GetItemTreeResponse{Items: make([]*masterdatav1.Item, len(items))}
+	for i, item := range items {
+		resp.Items[i] = toProtoItem(item)
+	}
+	return resp, nil
+}
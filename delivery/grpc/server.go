@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"erp-service/config"
+	"erp-service/iam/auth"
+	"erp-service/iam/user"
+	"erp-service/impl/mailer"
+	"erp-service/impl/postgres"
+	implredis "erp-service/impl/redis"
+	"erp-service/infrastructure"
+	"erp-service/masterdata"
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/pii"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Server struct {
+	grpcServer *grpc.Server
+	health     *health.Server
+	config     *config.Config
+	logger     *zap.Logger
+	closeDB    func(context.Context) error
+}
+
+// NewServer wires the IAM and masterdata usecases the way the HTTP server
+// does and registers them behind the auth interceptors.
+func NewServer(cfg *config.Config) *Server {
+	zapLogger, _ := logger.NewZapLoggerWithConfig(cfg.Log, cfg.App.Environment)
+	auditLogger := logger.NewAuditLogger(zapLogger, logger.AuditConfig{
+		Enabled: cfg.Log.AuditEnabled,
+	})
+
+	postgresDB, closeDB, err := infrastructure.NewPostgres(cfg, zapLogger)
+	if err != nil {
+		log.Fatal("failed to connect to postgres:", err)
+	}
+
+	redisClient, err := infrastructure.NewRedis(cfg.Infra.Redis)
+	if err != nil {
+		log.Fatal("failed to connect to redis:", err)
+	}
+	inMemoryStore := implredis.NewRedis(redisClient)
+
+	piiCipher, err := infrastructure.NewPIICipher(cfg)
+	if err != nil {
+		log.Fatal("failed to configure pii encryption:", err)
+	}
+	pii.Register(piiCipher)
+
+	txManager := postgres.NewTransactionManager(postgresDB)
+	userRepo := postgres.NewUserRepository(postgresDB)
+	userProfileRepo := postgres.NewUserProfileRepository(postgresDB)
+	userAuthMethodRepo := postgres.NewUserAuthMethodRepository(postgresDB)
+	userSecurityStateRepo := postgres.NewUserSecurityStateRepository(postgresDB)
+	tenantRepo := postgres.NewTenantRepository(postgresDB)
+	roleRepo := postgres.NewRoleRepository(postgresDB)
+	userRoleRepo := postgres.NewUserRoleRepository(postgresDB)
+
+	masterdataUsecase := masterdata.NewUsecase(
+		cfg,
+		postgres.NewMasterdataCategoryRepository(postgresDB),
+		postgres.NewMasterdataItemRepository(postgresDB),
+		inMemoryStore,
+	)
+	authUsecase := auth.NewUsecase(
+		txManager,
+		cfg,
+		userRepo,
+		userProfileRepo,
+		userAuthMethodRepo,
+		userSecurityStateRepo,
+		tenantRepo,
+		roleRepo,
+		postgres.NewRefreshTokenRepository(postgresDB),
+		userRoleRepo,
+		postgres.NewProductRepository(postgresDB),
+		postgres.NewPermissionRepository(postgresDB),
+		mailer.NewEmailService(&cfg.Email),
+		inMemoryStore,
+		postgres.NewUserSessionRepository(postgresDB),
+		postgres.NewUserTenantRegistrationRepository(postgresDB),
+		postgres.NewProductsByTenantRepository(postgresDB),
+		auditLogger,
+		masterdataUsecase,
+	)
+	userUsecase := user.NewUsecase(
+		txManager,
+		cfg,
+		userRepo,
+		userProfileRepo,
+		userAuthMethodRepo,
+		userSecurityStateRepo,
+		tenantRepo,
+		roleRepo,
+		userRoleRepo,
+	)
+
+	tokens := NewTokenValidator(cfg, inMemoryStore)
+	grpcServer, healthServer := New(
+		zapLogger,
+		tokens,
+		NewIAMServer(tokens, authUsecase, userUsecase),
+		NewMasterdataServer(masterdataUsecase),
+		cfg.GRPC.Reflection,
+	)
+
+	return &Server{
+		grpcServer: grpcServer,
+		health:     healthServer,
+		config:     cfg,
+		logger:     zapLogger,
+		closeDB:    closeDB,
+	}
+}
+
+// New builds a gRPC server for the given services with the interceptors,
+// health checking and, when enabled, reflection. Every service reports
+// SERVING until the health server is shut down.
+func New(zapLogger *zap.Logger, tokens *TokenValidator, iam iamv1.IAMServiceServer, md masterdatav1.MasterdataServiceServer, enableReflection bool) (*grpc.Server, *health.Server) {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryInterceptor(zapLogger, tokens)),
+		grpc.StreamInterceptor(StreamInterceptor(zapLogger, tokens)),
+	)
+	iamv1.RegisterIAMServiceServer(grpcServer, iam)
+	masterdatav1.RegisterMasterdataServiceServer(grpcServer, md)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	for service := range grpcServer.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	if enableReflection {
+		reflection.Register(grpcServer)
+	}
+	return grpcServer, healthServer
+}
+
+func (s *Server) Start() error {
+	addr := fmt.Sprintf("%s:%d", s.config.GRPC.Host, s.config.GRPC.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	s.logger.Info("grpc server listening", zap.String("addr", addr))
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown reports NOT_SERVING so load balancers drain the instance, then
+// waits for in-flight calls until ctx is done and cuts off the rest.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		err = ctx.Err()
+	}
+	return errors.Join(err, s.closeDB(ctx))
+}
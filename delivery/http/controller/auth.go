@@ -28,6 +28,8 @@ func convertValidationErrors(errs validator.ValidationErrors) []errors.FieldErro
 			message = field + " must be at least " + err.Param() + " characters"
 		case "max":
 			message = field + " must be at most " + err.Param() + " characters"
+		case "unmasked":
+			message = field + " must not be a masked value"
 		default:
 			message = field + " is invalid"
 		}
@@ -1,5 +1,19 @@
 package controller
 
-import "github.com/go-playground/validator/v10"
+import (
+	"erp-service/pkg/masking"
 
-var validate = validator.New()
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// unmasked refuses a masked value a client echoed back from a response,
+	// which would otherwise overwrite the real one.
+	_ = v.RegisterValidation("unmasked", func(fl validator.FieldLevel) bool {
+		return !masking.IsMasked(fl.Field().String())
+	})
+	return v
+}
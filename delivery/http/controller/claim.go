@@ -0,0 +1,456 @@
+package controller
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/errors"
+	"erp-service/saving/claim"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const maxClaimDocumentSize = 5 * 1024 * 1024
+
+type ClaimController struct {
+	usecase claim.Usecase
+}
+
+func NewClaimController(uc claim.Usecase) *ClaimController {
+	return &ClaimController{
+		usecase: uc,
+	}
+}
+
+func (ctrl *ClaimController) Open(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req claim.OpenClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.OpenClaim(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) List(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.Query("per_page", "10"))
+	if err != nil || perPage < 1 || perPage > 100 {
+		perPage = 10
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req := &claim.ListClaimsRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		Page:      page,
+		PerPage:   perPage,
+	}
+	if status := c.Query("status"); status != "" {
+		req.Status = &status
+	}
+	if claimType := c.Query("claim_type"); claimType != "" {
+		req.ClaimType = &claimType
+	}
+	if participantID := c.Query("participant_id"); participantID != "" {
+		pID, err := uuid.Parse(participantID)
+		if err != nil {
+			return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+		}
+		req.ParticipantID = &pID
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	result, err := ctrl.usecase.ListClaims(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) Get(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.GetClaim(c.UserContext(), &claim.GetClaimRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		ClaimID:   claimID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) UploadDocument(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	documentType := c.FormValue("document_type")
+	if documentType == "" {
+		return participantError(c, errors.ErrBadRequest("document_type is required"))
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("file is required"))
+	}
+
+	if fileHeader.Size > maxClaimDocumentSize {
+		return participantError(c, errors.ErrBadRequest("file size exceeds 5MB limit"))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return participantError(c, errors.ErrInternal("failed to open uploaded file"))
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, err := file.Read(buf)
+	if err != nil && err != io.EOF {
+		return participantError(c, errors.ErrInternal("failed to read uploaded file"))
+	}
+	detectedType := http.DetectContentType(buf[:n])
+	if !allowedUploadContentTypes[detectedType] {
+		return participantError(c, errors.ErrBadRequest("file content does not match an allowed type; allowed: jpeg, png, gif, pdf"))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return participantError(c, errors.ErrInternal("failed to process uploaded file"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	uploaderID, err := middleware.GetUserID(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.UploadClaimDocument(c.UserContext(), &claim.UploadClaimDocumentRequest{
+		TenantID:     tenantID,
+		ProductID:    productID,
+		ClaimID:      claimID,
+		UploadedBy:   uploaderID,
+		DocumentType: documentType,
+		FileName:     fileHeader.Filename,
+		ContentType:  detectedType,
+		Reader:       file,
+		Size:         fileHeader.Size,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) Verify(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.VerifyClaim(c.UserContext(), &claim.VerifyClaimRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		ClaimID:   claimID,
+		UserID:    userClaims.UserID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) Approve(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.ApproveClaim(c.UserContext(), &claim.ApproveClaimRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		ClaimID:   claimID,
+		UserID:    userClaims.UserID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) Reject(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req claim.RejectClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ClaimID = claimID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.RejectClaim(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) Pay(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req claim.PayClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ClaimID = claimID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.PayClaim(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ClaimController) StatusHistory(c *fiber.Ctx) error {
+	claimID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid claim ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.GetStatusHistory(c.UserContext(), &claim.GetClaimRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		ClaimID:   claimID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	stderrors "errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/errors"
+	"erp-service/saving/contribution"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	maxPayrollFileSize = 10 * 1024 * 1024
+	dateQueryLayout    = "2006-01-02"
+)
+
+type ContributionController struct {
+	usecase contribution.Usecase
+}
+
+func NewContributionController(uc contribution.Usecase) *ContributionController {
+	return &ContributionController{
+		usecase: uc,
+	}
+}
+
+func (ctrl *ContributionController) Import(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("file is required"))
+	}
+	if fileHeader.Size > maxPayrollFileSize {
+		return participantError(c, errors.ErrFileTooLarge("10MB"))
+	}
+	if !strings.EqualFold(filepath.Ext(fileHeader.Filename), ".csv") {
+		return participantError(c, errors.ErrUnsupportedFormat("payroll file must be a .csv file"))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return participantError(c, errors.ErrInternal("failed to open uploaded file"))
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxPayrollFileSize+1))
+	if err != nil {
+		return participantError(c, errors.ErrInternal("failed to read uploaded file"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req := &contribution.ImportContributionsRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		UserID:    userID,
+		Period:    c.FormValue("period"),
+		FileName:  filepath.Base(fileHeader.Filename),
+		Content:   content,
+	}
+
+	if postingDate := c.FormValue("posting_date"); postingDate != "" {
+		d, err := time.Parse(dateQueryLayout, postingDate)
+		if err != nil {
+			return participantError(c, errors.ErrBadRequest("posting_date must be in YYYY-MM-DD format"))
+		}
+		req.PostingDate = &d
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	result, err := ctrl.usecase.ImportContributions(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	if !result.IsPosted() {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"success": false,
+			"error":   "payroll file rejected; no contributions were posted",
+			"code":    errors.CodeContributionInvalid,
+			"data":    result,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ContributionController) ListImports(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.Query("per_page", "10"))
+	if err != nil || perPage < 1 || perPage > 100 {
+		perPage = 10
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req := &contribution.ListImportBatchesRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		Page:      page,
+		PerPage:   perPage,
+	}
+	if status := c.Query("status"); status != "" {
+		req.Status = &status
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	result, err := ctrl.usecase.ListImportBatches(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ContributionController) GetImport(c *fiber.Ctx) error {
+	batchID, err := uuid.Parse(c.Params("batchId"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid batch ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.GetImportBatch(c.UserContext(), &contribution.GetImportBatchRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		BatchID:   batchID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ContributionController) ReverseEntry(c *fiber.Ctx) error {
+	entryID, err := uuid.Parse(c.Params("entryId"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid entry ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req contribution.ReverseEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.EntryID = entryID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.ReverseEntry(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ContributionController) Statement(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req := &contribution.GetStatementRequest{
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: pID,
+	}
+
+	if from := c.Query("from"); from != "" {
+		d, err := time.Parse(dateQueryLayout, from)
+		if err != nil {
+			return participantError(c, errors.ErrBadRequest("from must be in YYYY-MM-DD format"))
+		}
+		req.From = &d
+	}
+	if to := c.Query("to"); to != "" {
+		d, err := time.Parse(dateQueryLayout, to)
+		if err != nil {
+			return participantError(c, errors.ErrBadRequest("to must be in YYYY-MM-DD format"))
+		}
+		req.To = &d
+	}
+
+	result, err := ctrl.usecase.GetStatement(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
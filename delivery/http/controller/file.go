@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"mime"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/errors"
+	"erp-service/saving/fileaccess"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FileController struct {
+	usecase fileaccess.Usecase
+}
+
+func NewFileController(uc fileaccess.Usecase) *FileController {
+	return &FileController{
+		usecase: uc,
+	}
+}
+
+func (ctrl *FileController) Get(c *fiber.Ctx) error {
+	req, err := buildGetFileRequest(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.GetFile(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *FileController) Content(c *fiber.Ctx) error {
+	return ctrl.sendContent(c, ctrl.usecase.GetFileContent)
+}
+
+func (ctrl *FileController) Thumbnail(c *fiber.Ctx) error {
+	return ctrl.sendContent(c, ctrl.usecase.GetFileThumbnail)
+}
+
+func (ctrl *FileController) sendContent(
+	c *fiber.Ctx,
+	load func(context.Context, *fileaccess.GetFileContentRequest) (*fileaccess.FileContentResponse, error),
+) error {
+	req, err := buildGetFileRequest(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := load(c.UserContext(), &fileaccess.GetFileContentRequest{
+		GetFileRequest: *req,
+		Inline:         c.QueryBool("inline"),
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+
+	if result.Body == nil {
+		return c.Redirect(result.URL, fiber.StatusFound)
+	}
+
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": result.FileName}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(result.Body, int(result.SizeBytes))
+}
+
+func buildGetFileRequest(c *fiber.Ctx) (*fileaccess.GetFileRequest, error) {
+	fileID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, errors.ErrBadRequest("invalid file ID")
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileaccess.GetFileRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		UserID:    userClaims.UserID,
+		FileID:    fileID,
+		CanViewAll: userClaims.IsPlatformAdmin() ||
+			userClaims.HasRoleInProduct(tenantID, productID, "PARTICIPANT_APPROVER"),
+	}, nil
+}
@@ -3,17 +3,21 @@ package controller
 import (
 	"erp-service/config"
 	"erp-service/delivery/http/dto/response"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/health"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type HealthController struct {
-	config *config.Config
+	config   *config.Config
+	registry *health.Registry
 }
 
-func NewHealthController(cfg *config.Config) *HealthController {
+func NewHealthController(cfg *config.Config, registry *health.Registry) *HealthController {
 	return &HealthController{
-		config: cfg,
+		config:   cfg,
+		registry: registry,
 	}
 }
 
@@ -26,14 +30,26 @@ func (h *HealthController) Check(c *fiber.Ctx) error {
 	}))
 }
 
+// Ready reports the dependencies a request needs. It answers 503 only when a
+// critical one has been down for its whole failure threshold.
 func (h *HealthController) Ready(c *fiber.Ctx) error {
-	return c.JSON(response.SuccessResponse("Service ready", fiber.Map{
-		"status": "ready",
-	}))
+	return h.respond(c, "Service ready", "Service not ready", h.registry.Ready(c.UserContext()))
 }
 
+// Live reports whether this process can still make progress. It never
+// depends on another service being up.
 func (h *HealthController) Live(c *fiber.Ctx) error {
-	return c.JSON(response.SuccessResponse("Service alive", fiber.Map{
-		"status": "alive",
-	}))
+	return h.respond(c, "Service alive", "Service not alive", h.registry.Live(c.UserContext()))
+}
+
+func (h *HealthController) respond(c *fiber.Ctx, okMessage, failMessage string, report health.Report) error {
+	if !h.config.IsDevelopment() {
+		report = report.WithoutErrors()
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if !report.Healthy() {
+		return c.Status(fiber.StatusServiceUnavailable).
+			JSON(response.ErrorResponseWithDetails(apperrors.CodeServiceUnavailable, failMessage, report))
+	}
+	return c.JSON(response.SuccessResponse(okMessage, report))
 }
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"mime"
+	"net/url"
+	"path"
+
+	"erp-service/impl/localfs"
+	"erp-service/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalStorageController answers the presigned URLs of the local file
+// storage backend. Requests carry no session; the signature is the
+// authorization.
+type LocalStorageController struct {
+	server *localfs.Server
+}
+
+func NewLocalStorageController(server *localfs.Server) *LocalStorageController {
+	return &LocalStorageController{
+		server: server,
+	}
+}
+
+func (ctrl *LocalStorageController) Download(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid object key"))
+	}
+
+	f, info, err := ctrl.server.Open(c.Params("bucket"), key, c.Query(localfs.FormExpires), c.Query(localfs.FormSignature))
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(f, int(info.Size()))
+}
+
+func (ctrl *LocalStorageController) Upload(c *fiber.Ctx) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid multipart form"))
+	}
+
+	files := form.File[localfs.FormFile]
+	if len(files) != 1 {
+		return participantError(c, errors.ErrBadRequest("exactly one file is required"))
+	}
+
+	fields := make(map[string]string, len(form.Value))
+	for name, values := range form.Value {
+		if len(values) > 0 {
+			fields[name] = values[0]
+		}
+	}
+
+	f, err := files[0].Open()
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("failed to read uploaded file"))
+	}
+	defer f.Close()
+
+	contentType := files[0].Header.Get(fiber.HeaderContentType)
+	if err := ctrl.server.Accept(c.Params("bucket"), fields, contentType, f, files[0].Size); err != nil {
+		return participantError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -6,9 +6,11 @@ import (
 	"net/http"
 	"strconv"
 
+	"erp-service/delivery/http/dto/response"
 	"erp-service/delivery/http/middleware"
 	"erp-service/delivery/http/presenter"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/masking"
 	"erp-service/saving/participant"
 
 	"github.com/go-playground/validator/v10"
@@ -53,6 +55,17 @@ func participantError(c *fiber.Ctx, err error) error {
 	return c.Status(appErr.HTTPStatus).JSON(resp)
 }
 
+// maskPII applies the caller's PII visibility to a response in place.
+func maskPII(c *fiber.Ctx, resp interface{}) {
+	masking.Apply(resp, middleware.GetMaskingPolicy(c))
+}
+
+func presentParticipant(c *fiber.Ctx, result *participant.ParticipantResponse) response.ParticipantResponse {
+	resp := presenter.MapParticipantResponse(result)
+	maskPII(c, &resp)
+	return resp
+}
+
 func (ctrl *ParticipantController) Create(c *fiber.Ctx) error {
 	tenantID, err := middleware.GetTenantIDFromContext(c)
 	if err != nil {
@@ -93,7 +106,7 @@ func (ctrl *ParticipantController) Create(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
@@ -124,7 +137,7 @@ func (ctrl *ParticipantController) Get(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
@@ -176,6 +189,8 @@ func (ctrl *ParticipantController) List(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	maskPII(c, result)
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    result,
@@ -228,7 +243,7 @@ func (ctrl *ParticipantController) UpdatePersonalData(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
@@ -270,6 +285,8 @@ func (ctrl *ParticipantController) SaveIdentity(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	maskPII(c, result)
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    result,
@@ -424,6 +441,8 @@ func (ctrl *ParticipantController) SaveBankAccount(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	maskPII(c, result)
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    result,
@@ -463,6 +482,44 @@ func (ctrl *ParticipantController) DeleteBankAccount(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+func (ctrl *ParticipantController) VerifyBankAccount(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	aID, err := uuid.Parse(c.Params("accountId"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid bank account ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.VerifyBankAccount(c.UserContext(), &participant.VerifyBankAccountRequest{
+		AccountID:     aID,
+		ParticipantID: pID,
+		TenantID:      tenantID,
+		ProductID:     productID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, result)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
 func (ctrl *ParticipantController) SaveFamilyMember(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
@@ -666,6 +723,8 @@ func (ctrl *ParticipantController) SaveBeneficiary(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	maskPII(c, result)
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    result,
@@ -789,6 +848,99 @@ func (ctrl *ParticipantController) UploadFile(c *fiber.Ctx) error {
 	})
 }
 
+func (ctrl *ParticipantController) RequestUploadSlot(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.RequestUploadSlotRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	uploaderID, err := middleware.GetUserID(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ParticipantID = pID
+	req.UploadedBy = uploaderID
+
+	result, err := ctrl.usecase.RequestUploadSlot(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) CompleteUpload(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	uploadID, err := uuid.Parse(c.Params("uploadId"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid upload ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	uploaderID, err := middleware.GetUserID(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.CompleteUpload(c.UserContext(), &participant.CompleteUploadRequest{
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: pID,
+		UploadedBy:    uploaderID,
+		UploadID:      uploadID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
 func (ctrl *ParticipantController) GetStatusHistory(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
@@ -855,7 +1007,7 @@ func (ctrl *ParticipantController) Submit(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
@@ -875,26 +1027,39 @@ func (ctrl *ParticipantController) Approve(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	var req participant.ApproveParticipantRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return participantError(c, errors.ErrBadRequest("invalid request body"))
+		}
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
 	productID, err := middleware.GetProductIDFromContext(c)
 	if err != nil {
 		return participantError(c, err)
 	}
 
-	req := &participant.ApproveParticipantRequest{
-		TenantID:      tenantID,
-		ParticipantID: pID,
-		ProductID:     productID,
-		UserID:        userClaims.UserID,
-	}
+	req.TenantID = tenantID
+	req.ParticipantID = pID
+	req.ProductID = productID
+	req.UserID = userClaims.UserID
 
-	result, err := ctrl.usecase.ApproveParticipant(c.UserContext(), req)
+	result, err := ctrl.usecase.ApproveParticipant(c.UserContext(), &req)
 	if err != nil {
 		return participantError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
@@ -949,17 +1114,27 @@ func (ctrl *ParticipantController) Reject(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapParticipantResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
-func (ctrl *ParticipantController) SelfRegister(c *fiber.Ctx) error {
-	claims, err := middleware.GetUserClaims(c)
+func (ctrl *ParticipantController) Activate(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
 	if err != nil {
 		return participantError(c, err)
 	}
 
-	var req participant.SelfRegisterRequest
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.ActivateParticipantRequest
 	if err := c.BodyParser(&req); err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid request body"))
 	}
@@ -972,24 +1147,28 @@ func (ctrl *ParticipantController) SelfRegister(c *fiber.Ctx) error {
 		return participantError(c, errors.ErrBadRequest("invalid request"))
 	}
 
-	req.UserID = claims.UserID
-
-	result, err := ctrl.usecase.SelfRegister(c.UserContext(), &req)
+	productID, err := middleware.GetProductIDFromContext(c)
 	if err != nil {
 		return participantError(c, err)
 	}
 
-	status := fiber.StatusCreated
-	if result.IsLinked {
-		status = fiber.StatusOK
+	req.TenantID = tenantID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+	req.ProductID = productID
+
+	result, err := ctrl.usecase.ActivateParticipant(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
 	}
-	return c.Status(status).JSON(fiber.Map{
+
+	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    presenter.MapSelfRegisterResponse(result),
+		"data":    presentParticipant(c, result),
 	})
 }
 
-func (ctrl *ParticipantController) Delete(c *fiber.Ctx) error {
+func (ctrl *ParticipantController) Suspend(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
@@ -1005,25 +1184,41 @@ func (ctrl *ParticipantController) Delete(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
+	var req participant.SuspendParticipantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
 	productID, err := middleware.GetProductIDFromContext(c)
 	if err != nil {
 		return participantError(c, err)
 	}
 
-	err = ctrl.usecase.DeleteParticipant(c.UserContext(), &participant.DeleteParticipantRequest{
-		ParticipantID: pID,
-		TenantID:      tenantID,
-		ProductID:     productID,
-		UserID:        userClaims.UserID,
-	})
+	req.TenantID = tenantID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+	req.ProductID = productID
+
+	result, err := ctrl.usecase.SuspendParticipant(c.UserContext(), &req)
 	if err != nil {
 		return participantError(c, err)
 	}
 
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    presentParticipant(c, result),
+	})
 }
 
-func (ctrl *ParticipantController) SaveAddresses(c *fiber.Ctx) error {
+func (ctrl *ParticipantController) Terminate(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
@@ -1039,7 +1234,7 @@ func (ctrl *ParticipantController) SaveAddresses(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
-	var req participant.SaveAddressesRequest
+	var req participant.TerminateParticipantRequest
 	if err := c.BodyParser(&req); err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid request body"))
 	}
@@ -1058,22 +1253,22 @@ func (ctrl *ParticipantController) SaveAddresses(c *fiber.Ctx) error {
 	}
 
 	req.TenantID = tenantID
-	req.ProductID = productID
 	req.ParticipantID = pID
 	req.UserID = userClaims.UserID
+	req.ProductID = productID
 
-	result, err := ctrl.usecase.SaveAddresses(c.UserContext(), &req)
+	result, err := ctrl.usecase.TerminateParticipant(c.UserContext(), &req)
 	if err != nil {
 		return participantError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    result,
+		"data":    presentParticipant(c, result),
 	})
 }
 
-func (ctrl *ParticipantController) SaveFamilyMembers(c *fiber.Ctx) error {
+func (ctrl *ParticipantController) Retire(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
@@ -1089,7 +1284,7 @@ func (ctrl *ParticipantController) SaveFamilyMembers(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
-	var req participant.SaveFamilyMembersRequest
+	var req participant.RetireParticipantRequest
 	if err := c.BodyParser(&req); err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid request body"))
 	}
@@ -1108,22 +1303,22 @@ func (ctrl *ParticipantController) SaveFamilyMembers(c *fiber.Ctx) error {
 	}
 
 	req.TenantID = tenantID
-	req.ProductID = productID
 	req.ParticipantID = pID
 	req.UserID = userClaims.UserID
+	req.ProductID = productID
 
-	result, err := ctrl.usecase.SaveFamilyMembers(c.UserContext(), &req)
+	result, err := ctrl.usecase.RetireParticipant(c.UserContext(), &req)
 	if err != nil {
 		return participantError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    result,
+		"data":    presentParticipant(c, result),
 	})
 }
 
-func (ctrl *ParticipantController) SaveBeneficiaries(c *fiber.Ctx) error {
+func (ctrl *ParticipantController) MarkDeceased(c *fiber.Ctx) error {
 	pID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
@@ -1139,7 +1334,7 @@ func (ctrl *ParticipantController) SaveBeneficiaries(c *fiber.Ctx) error {
 		return participantError(c, err)
 	}
 
-	var req participant.SaveBeneficiariesRequest
+	var req participant.MarkParticipantDeceasedRequest
 	if err := c.BodyParser(&req); err != nil {
 		return participantError(c, errors.ErrBadRequest("invalid request body"))
 	}
@@ -1158,17 +1353,493 @@ func (ctrl *ParticipantController) SaveBeneficiaries(c *fiber.Ctx) error {
 	}
 
 	req.TenantID = tenantID
-	req.ProductID = productID
 	req.ParticipantID = pID
 	req.UserID = userClaims.UserID
+	req.ProductID = productID
 
-	result, err := ctrl.usecase.SaveBeneficiaries(c.UserContext(), &req)
+	result, err := ctrl.usecase.MarkParticipantDeceased(c.UserContext(), &req)
 	if err != nil {
 		return participantError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    result,
+		"data":    presentParticipant(c, result),
+	})
+}
+
+func (ctrl *ParticipantController) TransferOut(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.TransferOutParticipantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+	req.ProductID = productID
+
+	result, err := ctrl.usecase.TransferOutParticipant(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    presentParticipant(c, result),
+	})
+}
+
+func (ctrl *ParticipantController) SelfRegister(c *fiber.Ctx) error {
+	claims, err := middleware.GetUserClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.SelfRegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	req.UserID = claims.UserID
+
+	result, err := ctrl.usecase.SelfRegister(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	status := fiber.StatusCreated
+	if result.IsLinked {
+		status = fiber.StatusOK
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"success": true,
+		"data":    presenter.MapSelfRegisterResponse(result),
+	})
+}
+
+func (ctrl *ParticipantController) Delete(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	err = ctrl.usecase.DeleteParticipant(c.UserContext(), &participant.DeleteParticipantRequest{
+		ParticipantID: pID,
+		TenantID:      tenantID,
+		ProductID:     productID,
+		UserID:        userClaims.UserID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ctrl *ParticipantController) SaveAddresses(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.SaveAddressesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.SaveAddresses(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) SaveFamilyMembers(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.SaveFamilyMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.SaveFamilyMembers(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) SaveBeneficiaries(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.SaveBeneficiariesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.SaveBeneficiaries(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	maskPII(c, &result)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) FindDuplicates(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	crossTenant := c.QueryBool("cross_tenant", false)
+	if crossTenant && !userClaims.IsPlatformAdmin() {
+		return participantError(c, errors.ErrForbidden("cross-tenant duplicate search requires platform admin"))
+	}
+
+	result, err := ctrl.usecase.FindDuplicates(c.UserContext(), &participant.FindDuplicatesRequest{
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: pID,
+		CrossTenant:   crossTenant,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    presenter.MapDuplicateMatchResponses(result),
+	})
+}
+
+func (ctrl *ParticipantController) ListDuplicateCandidates(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(c.Query("per_page", "10"))
+	if err != nil || perPage < 1 || perPage > 100 {
+		perPage = 10
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	allTenants := c.QueryBool("all_tenants", false)
+	if allTenants && !userClaims.IsPlatformAdmin() {
+		return participantError(c, errors.ErrForbidden("listing duplicates across tenants requires platform admin"))
+	}
+
+	req := &participant.ListDuplicateCandidatesRequest{
+		TenantID:   tenantID,
+		ProductID:  productID,
+		AllTenants: allTenants,
+		Page:       page,
+		PerPage:    perPage,
+	}
+
+	if status := c.Query("status", "PENDING"); status != "" {
+		req.Status = &status
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	result, err := ctrl.usecase.ListDuplicateCandidates(c.UserContext(), req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) DismissDuplicateCandidate(c *fiber.Ctx) error {
+	candidateID, err := uuid.Parse(c.Params("candidateId"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid duplicate candidate ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.DismissDuplicateCandidateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.CandidateID = candidateID
+	req.UserID = userClaims.UserID
+	req.AllTenants = userClaims.IsPlatformAdmin()
+
+	result, err := ctrl.usecase.DismissDuplicateCandidate(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *ParticipantController) Merge(c *fiber.Ctx) error {
+	pID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req participant.MergeParticipantsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.ParticipantID = pID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.MergeParticipants(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    presentParticipant(c, result),
 	})
 }
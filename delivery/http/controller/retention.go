@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/errors"
+	"erp-service/saving/retention"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RetentionController struct {
+	usecase retention.Usecase
+}
+
+func NewRetentionController(uc retention.Usecase) *RetentionController {
+	return &RetentionController{
+		usecase: uc,
+	}
+}
+
+func (ctrl *RetentionController) ListPolicies(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	result, err := ctrl.usecase.ListPolicies(c.UserContext(), &retention.ListPoliciesRequest{
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *RetentionController) SavePolicy(c *fiber.Ctx) error {
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req retention.SavePolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	req.TenantID = tenantID
+	req.UserID = userClaims.UserID
+
+	result, err := ctrl.usecase.SavePolicy(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (ctrl *RetentionController) DeletePolicy(c *fiber.Ctx) error {
+	policyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid retention policy ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	if err := ctrl.usecase.DeletePolicy(c.UserContext(), &retention.DeletePolicyRequest{
+		TenantID: tenantID,
+		UserID:   userClaims.UserID,
+		PolicyID: policyID,
+	}); err != nil {
+		return participantError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (ctrl *RetentionController) SetParticipantLegalHold(c *fiber.Ctx) error {
+	return ctrl.setLegalHold(c, "invalid participant ID", ctrl.usecase.SetParticipantLegalHold)
+}
+
+func (ctrl *RetentionController) SetFileLegalHold(c *fiber.Ctx) error {
+	return ctrl.setLegalHold(c, "invalid file ID", ctrl.usecase.SetFileLegalHold)
+}
+
+func (ctrl *RetentionController) setLegalHold(
+	c *fiber.Ctx,
+	invalidIDMessage string,
+	set func(context.Context, *retention.SetLegalHoldRequest) (*retention.LegalHoldResponse, error),
+) error {
+	targetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest(invalidIDMessage))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req retention.SetLegalHoldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.UserID = userClaims.UserID
+	req.TargetID = targetID
+
+	result, err := set(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
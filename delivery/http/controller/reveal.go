@@ -0,0 +1,74 @@
+package controller
+
+import (
+	stderrors "errors"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/errors"
+	"erp-service/saving/reveal"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RevealController struct {
+	usecase reveal.Usecase
+}
+
+func NewRevealController(uc reveal.Usecase) *RevealController {
+	return &RevealController{
+		usecase: uc,
+	}
+}
+
+func (ctrl *RevealController) RevealParticipantField(c *fiber.Ctx) error {
+	participantID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid participant ID"))
+	}
+
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	userClaims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	var req reveal.RevealRequest
+	if err := c.BodyParser(&req); err != nil {
+		return participantError(c, errors.ErrBadRequest("invalid request body"))
+	}
+
+	if err := validate.Struct(&req); err != nil {
+		var ve validator.ValidationErrors
+		if stderrors.As(err, &ve) {
+			return participantError(c, errors.ErrValidationWithFields(convertValidationErrors(ve)))
+		}
+		return participantError(c, errors.ErrBadRequest("invalid request"))
+	}
+
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	req.TenantID = tenantID
+	req.ProductID = productID
+	req.UserID = userClaims.UserID
+	req.ParticipantID = participantID
+
+	result, err := ctrl.usecase.RevealParticipantField(c.UserContext(), &req)
+	if err != nil {
+		return participantError(c, err)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
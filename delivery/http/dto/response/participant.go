@@ -24,13 +24,13 @@ type ParticipantResponse struct {
 	FullName        string                 `json:"full_name"`
 	Gender          *string                `json:"gender,omitempty"`
 	PlaceOfBirth    *string                `json:"place_of_birth,omitempty"`
-	DateOfBirth     *time.Time             `json:"date_of_birth,omitempty"`
+	DateOfBirth     *time.Time             `json:"date_of_birth,omitempty" mask:"date_of_birth"`
 	MaritalStatus   *string                `json:"marital_status,omitempty"`
 	Citizenship     *string                `json:"citizenship,omitempty"`
 	Religion        *string                `json:"religion,omitempty"`
-	KTPNumber       *string                `json:"ktp_number,omitempty"`
+	KTPNumber       *string                `json:"ktp_number,omitempty" mask:"national_id"`
 	EmployeeNumber  *string                `json:"employee_number,omitempty"`
-	PhoneNumber     *string                `json:"phone_number,omitempty"`
+	PhoneNumber     *string                `json:"phone_number,omitempty" mask:"phone"`
 	Status          string                 `json:"status"`
 	StepsCompleted  StepsCompleted         `json:"steps_completed"`
 	CreatedBy       uuid.UUID              `json:"created_by"`
@@ -51,12 +51,15 @@ type ParticipantResponse struct {
 	Employment      *EmploymentResponse    `json:"employment,omitempty"`
 	Pension         *PensionResponse       `json:"pension,omitempty"`
 	Beneficiaries   []BeneficiaryResponse  `json:"beneficiaries,omitempty"`
+
+	StatusEffectiveDate *time.Time               `json:"status_effective_date,omitempty"`
+	DuplicateWarnings   []DuplicateMatchResponse `json:"duplicate_warnings,omitempty"`
 }
 
 type IdentityResponse struct {
 	ID                uuid.UUID  `json:"id"`
 	IdentityType      string     `json:"identity_type"`
-	IdentityNumber    string     `json:"identity_number"`
+	IdentityNumber    string     `json:"identity_number" mask:"national_id"`
 	IdentityAuthority *string    `json:"identity_authority,omitempty"`
 	IssueDate         *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
@@ -88,16 +91,24 @@ type AddressResponse struct {
 type BankAccountResponse struct {
 	ID                uuid.UUID  `json:"id"`
 	BankCode          string     `json:"bank_code"`
-	AccountNumber     string     `json:"account_number"`
+	AccountNumber     string     `json:"account_number" mask:"bank_account"`
 	AccountHolderName string     `json:"account_holder_name"`
 	AccountType       *string    `json:"account_type,omitempty"`
 	CurrencyCode      string     `json:"currency_code"`
 	IsPrimary         bool       `json:"is_primary"`
 	IssueDate         *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
-	Version           int        `json:"version"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+
+	VerificationStatus    string     `json:"verification_status"`
+	VerificationProvider  *string    `json:"verification_provider,omitempty"`
+	VerifiedHolderName    *string    `json:"verified_holder_name,omitempty"`
+	VerificationScore     *float64   `json:"verification_score,omitempty"`
+	VerificationMessage   *string    `json:"verification_message,omitempty"`
+	VerificationCheckedAt *time.Time `json:"verification_checked_at,omitempty"`
+
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type FamilyMemberResponse struct {
@@ -157,8 +168,19 @@ type BeneficiaryResponse struct {
 	FamilyCardPhotoFileID   *uuid.UUID `json:"family_card_photo_file_id,omitempty"`
 	BankBookPhotoFilePath   *string    `json:"bank_book_photo_file_path,omitempty"`
 	BankBookPhotoFileID     *uuid.UUID `json:"bank_book_photo_file_id,omitempty"`
-	AccountNumber           *string    `json:"account_number,omitempty"`
+	BankCode                *string    `json:"bank_code,omitempty"`
+	AccountNumber           *string    `json:"account_number,omitempty" mask:"bank_account"`
 	Version                 int        `json:"version"`
 	CreatedAt               time.Time  `json:"created_at"`
 	UpdatedAt               time.Time  `json:"updated_at"`
 }
+
+type DuplicateMatchResponse struct {
+	ParticipantID uuid.UUID `json:"participant_id"`
+	TenantID      uuid.UUID `json:"tenant_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	FullName      string    `json:"full_name"`
+	Status        string    `json:"status"`
+	Score         float64   `json:"score"`
+	MatchedFields []string  `json:"matched_fields"`
+}
@@ -8,20 +8,25 @@ import (
 	"erp-service/delivery/http/dto/response"
 	"erp-service/delivery/http/middleware"
 	"erp-service/delivery/http/router"
-	"erp-service/delivery/worker"
 	"erp-service/iam/auth"
-	"erp-service/files"
 	"erp-service/iam/product"
 	"erp-service/iam/role"
 	"erp-service/iam/user"
+	"erp-service/impl/localfs"
 	"erp-service/impl/mailer"
-	implminio "erp-service/impl/minio"
 	"erp-service/impl/postgres"
 	implredis "erp-service/impl/redis"
 	"erp-service/infrastructure"
 	"erp-service/masterdata"
 	apperrors "erp-service/pkg/errors"
 	"erp-service/pkg/logger"
+	"erp-service/pkg/pii"
+	"erp-service/saving/claim"
+	"erp-service/saving/contribution"
+	"erp-service/saving/fileaccess"
+	"erp-service/saving/projection"
+	"erp-service/saving/retention"
+	"erp-service/saving/reveal"
 	"erp-service/saving/member"
 	"erp-service/saving/participant"
 	"errors"
@@ -34,12 +39,14 @@ import (
 	"go.uber.org/zap"
 )
 
+// apiBodyLimit bounds request bodies under /api, multipart uploads included.
+const apiBodyLimit = 6 * 1024 * 1024
+
 type Server struct {
-	app          *fiber.App
-	config       *config.Config
-	logger       *zap.Logger
-	fileWorker   *worker.Worker
-	workerCancel context.CancelFunc
+	app     *fiber.App
+	config  *config.Config
+	logger  *zap.Logger
+	closeDB func(context.Context) error
 }
 
 func NewServer(cfg *config.Config) *Server {
@@ -48,21 +55,32 @@ func NewServer(cfg *config.Config) *Server {
 		Enabled: cfg.Log.AuditEnabled,
 	})
 
+	// The local storage backend takes presigned uploads as large as an upload
+	// slot allows, past apiBodyLimit, which /api enforces on its own.
+	bodyLimit := apiBodyLimit
+	if cfg.Infra.FileStorage.Driver == "local" {
+		bodyLimit = participant.MaxDirectUploadSize + localfs.FormOverhead
+	}
+
 	app := fiber.New(fiber.Config{
 		JSONEncoder: json.Marshal,
 		JSONDecoder: json.Unmarshal,
 		AppName:     cfg.App.Name,
-		BodyLimit:    6 * 1024 * 1024,
+		BodyLimit:    bodyLimit,
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
 		ErrorHandler: createErrorHandler(cfg, zapLogger),
 	})
 
-	postgresDB, err := infrastructure.NewPostgres(cfg.Infra.Postgres, zapLogger)
+	postgresDB, closePostgres, err := infrastructure.NewPostgres(cfg, zapLogger)
 	if err != nil {
 		log.Fatal("failed to connect to postgres:", err)
 	}
+	tenantDBs := infrastructure.NewTenantRouter(cfg, postgresDB, zapLogger)
+	closeDB := func(ctx context.Context) error {
+		return errors.Join(tenantDBs.Close(), closePostgres(ctx))
+	}
 
 	redisClient, err := infrastructure.NewRedis(cfg.Infra.Redis)
 	if err != nil {
@@ -70,7 +88,7 @@ func NewServer(cfg *config.Config) *Server {
 	}
 	inMemoryStore := implredis.NewRedis(redisClient)
 
-	txManager := postgres.NewTransactionManager(postgresDB)
+	txManager := postgres.NewTenantTransactionManager(tenantDBs)
 
 	authUserRepo := postgres.NewUserRepository(postgresDB)
 	userProfileRepo := postgres.NewUserProfileRepository(postgresDB)
@@ -92,22 +110,46 @@ func NewServer(cfg *config.Config) *Server {
 
 	productRegConfigRepo := postgres.NewProductRegistrationConfigRepository(postgresDB)
 
-	participantRepo := postgres.NewParticipantRepository(postgresDB)
-	participantIdentityRepo := postgres.NewParticipantIdentityRepository(postgresDB)
-	participantAddressRepo := postgres.NewParticipantAddressRepository(postgresDB)
-	participantBankAccountRepo := postgres.NewParticipantBankAccountRepository(postgresDB)
-	participantFamilyMemberRepo := postgres.NewParticipantFamilyMemberRepository(postgresDB)
-	participantEmploymentRepo := postgres.NewParticipantEmploymentRepository(postgresDB)
-	participantPensionRepo := postgres.NewParticipantPensionRepository(postgresDB)
-	participantBeneficiaryRepo := postgres.NewParticipantBeneficiaryRepository(postgresDB)
-	participantStatusHistoryRepo := postgres.NewParticipantStatusHistoryRepository(postgresDB)
-	fileRepo := postgres.NewFileRepository(postgresDB)
-
-	minioClient, err := infrastructure.NewMinIOClient(cfg)
+	participantRepo := postgres.NewParticipantRepository(tenantDBs)
+	participantIdentityRepo := postgres.NewParticipantIdentityRepository(tenantDBs)
+	participantAddressRepo := postgres.NewParticipantAddressRepository(tenantDBs)
+	participantBankAccountRepo := postgres.NewParticipantBankAccountRepository(tenantDBs)
+	participantFamilyMemberRepo := postgres.NewParticipantFamilyMemberRepository(tenantDBs)
+	participantEmploymentRepo := postgres.NewParticipantEmploymentRepository(tenantDBs)
+	participantPensionRepo := postgres.NewParticipantPensionRepository(tenantDBs)
+	participantBeneficiaryRepo := postgres.NewParticipantBeneficiaryRepository(tenantDBs)
+	participantStatusHistoryRepo := postgres.NewParticipantStatusHistoryRepository(tenantDBs)
+	participantDuplicateRepo := postgres.NewParticipantDuplicateRepository(tenantDBs)
+	fileRepo := postgres.NewFileRepository(tenantDBs)
+	uploadSlotRepo := postgres.NewUploadSlotRepository(tenantDBs)
+	contributionLedgerRepo := postgres.NewContributionLedgerRepository(tenantDBs)
+	contributionBatchRepo := postgres.NewContributionImportBatchRepository(tenantDBs)
+	claimRepo := postgres.NewClaimRepository(tenantDBs)
+	claimDocumentRepo := postgres.NewClaimDocumentRepository(tenantDBs)
+	claimStatusHistoryRepo := postgres.NewClaimStatusHistoryRepository(tenantDBs)
+	claimPaymentInstructionRepo := postgres.NewClaimPaymentInstructionRepository(tenantDBs)
+	retentionPolicyRepo := postgres.NewFileRetentionPolicyRepository(tenantDBs)
+
+	piiCipher, err := infrastructure.NewPIICipher(cfg)
+	if err != nil {
+		log.Fatal("failed to configure pii encryption:", err)
+	}
+	pii.Register(piiCipher)
+
+	bankVerifier, err := infrastructure.NewBankAccountVerifier(cfg)
+	if err != nil {
+		log.Fatal("failed to configure bank account verification:", err)
+	}
+
+	fileEncryptor, err := infrastructure.NewFileEncryptor(cfg)
 	if err != nil {
-		log.Fatal("failed to connect to minio:", err)
+		log.Fatal("failed to configure file encryption:", err)
+	}
+
+	fileStorage, localStorageServer, err := infrastructure.NewFileStorage(cfg, fileEncryptor)
+	if err != nil {
+		log.Fatal("failed to configure file storage:", err)
 	}
-	fileStorage := implminio.NewFileStorage(minioClient)
 
 	emailService := mailer.NewEmailService(&cfg.Email)
 
@@ -167,6 +209,8 @@ func NewServer(cfg *config.Config) *Server {
 		productRegConfigRepo,
 		userProfileRepo,
 		authUserRepo,
+		inMemoryStore,
+		zapLogger,
 	)
 	participantUsecase := participant.NewUsecase(
 		cfg,
@@ -181,8 +225,12 @@ func NewServer(cfg *config.Config) *Server {
 		participantPensionRepo,
 		participantBeneficiaryRepo,
 		participantStatusHistoryRepo,
+		participantDuplicateRepo,
 		fileStorage,
 		fileRepo,
+		uploadSlotRepo,
+		bankVerifier,
+		inMemoryStore,
 		tenantRepo,
 		productRepo,
 		productRegConfigRepo,
@@ -190,29 +238,109 @@ func NewServer(cfg *config.Config) *Server {
 		userProfileRepo,
 		masterdataUsecase,
 	)
+	contributionUsecase := contribution.NewUsecase(
+		cfg,
+		zapLogger,
+		txManager,
+		participantRepo,
+		contributionLedgerRepo,
+		contributionBatchRepo,
+	)
+	claimUsecase := claim.NewUsecase(
+		cfg,
+		zapLogger,
+		txManager,
+		claimRepo,
+		claimDocumentRepo,
+		claimStatusHistoryRepo,
+		claimPaymentInstructionRepo,
+		participantRepo,
+		participantBankAccountRepo,
+		participantBeneficiaryRepo,
+		participantFamilyMemberRepo,
+		contributionLedgerRepo,
+		fileRepo,
+		fileStorage,
+	)
+	projectionUsecase := projection.NewUsecase(
+		cfg,
+		zapLogger,
+		txManager,
+		participantRepo,
+		participantEmploymentRepo,
+		participantPensionRepo,
+		tenantRepo,
+		contributionLedgerRepo,
+		masterdataUsecase,
+	)
+	fileAccessUsecase := fileaccess.NewUsecase(
+		cfg,
+		zapLogger,
+		auditLogger,
+		fileRepo,
+		participantRepo,
+		fileStorage,
+	)
+	retentionUsecase := retention.NewUsecase(
+		cfg,
+		zapLogger,
+		auditLogger,
+		txManager,
+		retentionPolicyRepo,
+		participantRepo,
+		fileRepo,
+	)
+	revealUsecase := reveal.NewUsecase(
+		cfg,
+		zapLogger,
+		auditLogger,
+		participantRepo,
+		participantIdentityRepo,
+		participantBankAccountRepo,
+		participantBeneficiaryRepo,
+	)
+
+	healthRegistry, err := infrastructure.NewHealthRegistry(cfg, postgresDB, redisClient)
+	if err != nil {
+		log.Fatal("failed to configure health checks:", err)
+	}
 
-	healthController := controller.NewHealthController(cfg)
+	healthController := controller.NewHealthController(cfg, healthRegistry)
 	authController := controller.NewRegistrationController(cfg, authUsecase)
 	roleController := controller.NewRoleController(cfg, roleUsecase)
 	userController := controller.NewUserController(cfg, userUsecase)
 	masterdataController := controller.NewMasterdataController(cfg, masterdataUsecase)
 	memberController := controller.NewMemberController(memberUsecase)
 	participantController := controller.NewParticipantController(participantUsecase)
-
-	fileCleanupUC := files.NewUsecase(fileRepo, fileStorage, txManager, zapLogger, files.DefaultConfig())
-	fileWorker := worker.NewWorker(fileCleanupUC, zapLogger)
+	contributionController := controller.NewContributionController(contributionUsecase)
+	claimController := controller.NewClaimController(claimUsecase)
+	projectionController := controller.NewProjectionController(projectionUsecase)
+	fileController := controller.NewFileController(fileAccessUsecase)
+	retentionController := controller.NewRetentionController(retentionUsecase)
+	revealController := controller.NewRevealController(revealUsecase)
 
 	server := &Server{
-		app:        app,
-		config:     cfg,
-		logger:     zapLogger,
-		fileWorker: fileWorker,
+		app:     app,
+		config:  cfg,
+		logger:  zapLogger,
+		closeDB: closeDB,
 	}
 
 	mw := middleware.New(cfg, zapLogger)
 	mw.Setup(app)
 
+	// Presigned URLs of the local storage backend live outside /api, clear of
+	// the stricter API rate limit, like the object store they stand in for.
+	if localStorageServer != nil {
+		router.SetupLocalStorageRoutes(app, controller.NewLocalStorageController(localStorageServer))
+	}
+
+	// Probes are mounted ahead of the API rate limit; a kubelet polling from
+	// one node address would exhaust it.
+	router.SetupHealthRoutes(app.Group("/api/v1"), healthController)
+
 	api := app.Group("/api")
+	api.Use(middleware.BodyLimit(apiBodyLimit))
 	api.Use(limiter.New(limiter.Config{
 		Max:               10,
 		Expiration:        1 * time.Minute,
@@ -229,7 +357,6 @@ func NewServer(cfg *config.Config) *Server {
 	}))
 	v1 := api.Group("/v1")
 
-	router.SetupHealthRoutes(v1, healthController)
 	router.SetupDocsRoutes(v1)
 	router.SetupMasterdataRoutes(v1, cfg, masterdataController, inMemoryStore)
 
@@ -246,6 +373,12 @@ func NewServer(cfg *config.Config) *Server {
 	saving := v1.Group("/saving")
 	router.SetupParticipantRoutes(saving, participantController, jwtMiddleware, frendzSavingMW)
 	router.SetupMemberRoutes(saving, memberController, jwtMiddleware, frendzSavingMW)
+	router.SetupContributionRoutes(saving, contributionController, jwtMiddleware, frendzSavingMW)
+	router.SetupClaimRoutes(saving, claimController, jwtMiddleware, frendzSavingMW)
+	router.SetupProjectionRoutes(saving, projectionController, jwtMiddleware, frendzSavingMW)
+	router.SetupFileRoutes(saving, fileController, jwtMiddleware, frendzSavingMW)
+	router.SetupRetentionRoutes(saving, retentionController, jwtMiddleware, frendzSavingMW)
+	router.SetupRevealRoutes(saving, revealController, jwtMiddleware, frendzSavingMW)
 
 	return server
 }
@@ -260,21 +393,11 @@ func (s *Server) Start() error {
 	return s.app.Listen(addr)
 }
 
+// Shutdown stops accepting requests, waits for in-flight ones and then
+// closes the database pool.
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.app.ShutdownWithContext(ctx)
-}
-
-func (s *Server) StartWorker(ctx context.Context) {
-	workerCtx, cancel := context.WithCancel(ctx)
-	s.workerCancel = cancel
-	s.fileWorker.Start(workerCtx)
-}
-
-func (s *Server) StopWorker() {
-	if s.workerCancel != nil {
-		s.workerCancel()
-	}
-	s.fileWorker.Stop()
+	err := s.app.ShutdownWithContext(ctx)
+	return errors.Join(err, s.closeDB(ctx))
 }
 
 func createErrorHandler(cfg *config.Config, zapLogger *zap.Logger) fiber.ErrorHandler {
@@ -9,12 +9,7 @@ import (
 )
 
 func checkBlacklist(c *fiber.Ctx, store auth.TokenBlacklistStore, jti string, userID uuid.UUID, claims jwt.RegisteredClaims) error {
-	if jti == "" {
-		return nil
-	}
-
-	blacklisted, err := store.IsTokenBlacklisted(c.UserContext(), jti)
-	if err == nil && blacklisted {
+	if auth.IsTokenRevoked(c.UserContext(), store, jti, userID, claims) {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"error":   "token has been revoked",
@@ -22,16 +17,5 @@ func checkBlacklist(c *fiber.Ctx, store auth.TokenBlacklistStore, jti string, us
 		})
 	}
 
-	blacklistTS, err := store.GetUserBlacklistTimestamp(c.UserContext(), userID)
-	if err == nil && blacklistTS != nil && claims.IssuedAt != nil {
-		if claims.IssuedAt.Time.Before(*blacklistTS) {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error":   "token has been revoked",
-				"code":    "TOKEN_REVOKED",
-			})
-		}
-	}
-
 	return nil
 }
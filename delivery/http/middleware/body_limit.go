@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects requests whose body exceeds limit. The server-wide
+// BodyLimit has to admit the largest route, so the routes that take less
+// mount this on top of it.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Request().Header.ContentLength() > limit || len(c.Body()) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+		return c.Next()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"erp-service/iam/product"
 	"erp-service/pkg/errors"
 	jwtpkg "erp-service/pkg/jwt"
+	"erp-service/pkg/tenantdb"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -174,6 +175,7 @@ func ExtractTenantContext() fiber.Handler {
 		}
 
 		c.Locals("tenant_id", tenantID)
+		c.SetUserContext(tenantdb.WithTenant(c.UserContext(), tenantID))
 
 		return c.Next()
 	}
@@ -48,31 +48,7 @@ func JWTAuth(cfg *config.Config, blacklistStore ...auth.TokenBlacklistStore) fib
 
 		tokenString := parts[1]
 
-		multiClaims, multiErr := jwtpkg.ParseMultiTenantAccessToken(tokenString, tokenConfig)
-		if multiErr == nil && len(multiClaims.Tenants) > 0 {
-			legacyClaims := &jwtpkg.JWTClaims{
-				UserID:           multiClaims.UserID,
-				Email:            multiClaims.Email,
-				Roles:            multiClaims.Roles,
-				SessionID:        multiClaims.SessionID,
-				RegisteredClaims: multiClaims.RegisteredClaims,
-			}
-			c.Locals(UserClaimsKey, legacyClaims)
-			c.Locals(MultiTenantClaimsKey, multiClaims)
-
-			c.Locals("userID", multiClaims.UserID.String())
-			c.Locals("jti", multiClaims.RegisteredClaims.ID)
-
-			if store != nil {
-				if rejected := checkBlacklist(c, store, multiClaims.RegisteredClaims.ID, multiClaims.UserID, multiClaims.RegisteredClaims); rejected != nil {
-					return rejected
-				}
-			}
-
-			return c.Next()
-		}
-
-		claims, err := jwtpkg.ParseAccessToken(tokenString, tokenConfig)
+		claims, multiClaims, err := jwtpkg.ParseAnyAccessToken(tokenString, tokenConfig)
 		if err != nil {
 			var appErr *errors.AppError
 			switch err {
@@ -92,6 +68,9 @@ func JWTAuth(cfg *config.Config, blacklistStore ...auth.TokenBlacklistStore) fib
 		}
 
 		c.Locals(UserClaimsKey, claims)
+		if multiClaims != nil {
+			c.Locals(MultiTenantClaimsKey, multiClaims)
+		}
 
 		c.Locals("userID", claims.UserID.String())
 		c.Locals("jti", claims.RegisteredClaims.ID)
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"erp-service/pkg/masking"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMaskingPolicy derives the caller's PII visibility from their
+// permissions in the current tenant and product. Platform admins see
+// everything, mirroring RequireProductPermission; a request without claims
+// or product context sees nothing.
+func GetMaskingPolicy(c *fiber.Ctx) masking.Policy {
+	claims, err := GetMultiTenantClaims(c)
+	if err != nil {
+		return masking.Policy{}
+	}
+	if claims.IsPlatformAdmin() {
+		return masking.FullPolicy()
+	}
+
+	tenantID, err := GetTenantIDFromContext(c)
+	if err != nil {
+		return masking.Policy{}
+	}
+	productID, err := GetProductIDFromContext(c)
+	if err != nil {
+		return masking.Policy{}
+	}
+
+	return masking.PolicyFor(func(permission string) bool {
+		return claims.HasPermissionInProduct(tenantID, productID, permission)
+	})
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"time"
+
+	"erp-service/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records RED metrics per route template. The tenant label is only
+// set once ExtractTenantContext has verified it, so an unauthenticated
+// caller cannot mint label values through the X-Tenant-ID header.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				return handlerErr
+			}
+		}
+
+		tenant := ""
+		if tenantID, err := GetTenantIDFromContext(c); err == nil {
+			tenant = tenantID.String()
+		}
+		metrics.ObserveHTTPRequest(c.Method(), c.Route().Path, c.Response().StatusCode(), tenant, time.Since(start))
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"erp-service/config"
@@ -15,6 +16,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const healthPathPrefix = "/api/v1/health"
+
 type Middleware struct {
 	config *config.Config
 	logger *zap.Logger
@@ -59,8 +62,12 @@ func (m *Middleware) Setup(app *fiber.App) {
 		},
 	}))
 
+	app.Use(Tracing())
+
 	app.Use(RequestContext())
 
+	app.Use(Metrics())
+
 	app.Use(RequestLogger(m.logger))
 
 	corsOrigins := m.config.Server.CORSOrigins
@@ -78,6 +85,10 @@ func (m *Middleware) Setup(app *fiber.App) {
 
 	if !m.config.IsDevelopment() {
 		app.Use(limiter.New(limiter.Config{
+			// Probes come from a handful of node addresses every few seconds.
+			Next: func(c *fiber.Ctx) bool {
+				return strings.HasPrefix(c.Path(), healthPathPrefix)
+			},
 			Max:               60,
 			Expiration:        1 * time.Minute,
 			LimiterMiddleware: limiter.SlidingWindow{},
@@ -3,6 +3,8 @@ package middleware
 import (
 	"time"
 
+	"erp-service/pkg/logger"
+
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -13,14 +15,15 @@ func RequestLogger(zapLogger *zap.Logger) fiber.Handler {
 		start := time.Now()
 		requestID := c.GetRespHeader("X-Request-ID")
 
-		log.Info("request_started",
+		traceFields := logger.TraceFields(c.UserContext())
+		log.Info("request_started", append([]zap.Field{
 			zap.String("request_id", requestID),
 			zap.String("method", c.Method()),
 			zap.String("path", c.Path()),
 			zap.String("ip", c.IP()),
 			zap.String("user_agent", c.Get("User-Agent")),
 			zap.String("query", string(c.Request().URI().QueryString())),
-		)
+		}, traceFields...)...)
 
 		err := c.Next()
 
@@ -39,6 +42,7 @@ func RequestLogger(zapLogger *zap.Logger) fiber.Handler {
 			zap.String("ip", c.IP()),
 			zap.Duration("duration", time.Since(start)),
 		}
+		fields = append(fields, traceFields...)
 
 		if status >= 500 {
 			log.Error("request_completed", fields...)
@@ -100,9 +100,17 @@ func MapParticipantResponse(dto *participant.ParticipantResponse) response.Parti
 			IsPrimary:         ba.IsPrimary,
 			IssueDate:         ba.IssueDate,
 			ExpiryDate:        ba.ExpiryDate,
-			Version:           ba.Version,
-			CreatedAt:         ba.CreatedAt,
-			UpdatedAt:         ba.UpdatedAt,
+
+			VerificationStatus:    ba.VerificationStatus,
+			VerificationProvider:  ba.VerificationProvider,
+			VerifiedHolderName:    ba.VerifiedHolderName,
+			VerificationScore:     ba.VerificationScore,
+			VerificationMessage:   ba.VerificationMessage,
+			VerificationCheckedAt: ba.VerificationCheckedAt,
+
+			Version:   ba.Version,
+			CreatedAt: ba.CreatedAt,
+			UpdatedAt: ba.UpdatedAt,
 		})
 	}
 
@@ -174,6 +182,7 @@ func MapParticipantResponse(dto *participant.ParticipantResponse) response.Parti
 			FamilyCardPhotoFileID:   ben.FamilyCardPhotoFileID,
 			BankBookPhotoFilePath:   ben.BankBookPhotoFilePath,
 			BankBookPhotoFileID:     ben.BankBookPhotoFileID,
+			BankCode:                ben.BankCode,
 			AccountNumber:           ben.AccountNumber,
 			Version:                 ben.Version,
 			CreatedAt:               ben.CreatedAt,
@@ -181,6 +190,11 @@ func MapParticipantResponse(dto *participant.ParticipantResponse) response.Parti
 		})
 	}
 
+	var duplicateWarnings []response.DuplicateMatchResponse
+	if len(dto.DuplicateWarnings) > 0 {
+		duplicateWarnings = MapDuplicateMatchResponses(dto.DuplicateWarnings)
+	}
+
 	return response.ParticipantResponse{
 		ID:              dto.ID,
 		TenantID:        dto.TenantID,
@@ -224,5 +238,24 @@ func MapParticipantResponse(dto *participant.ParticipantResponse) response.Parti
 		Employment:      employment,
 		Pension:         pension,
 		Beneficiaries:   beneficiaries,
+
+		StatusEffectiveDate: dto.StatusEffectiveDate,
+		DuplicateWarnings:   duplicateWarnings,
+	}
+}
+
+func MapDuplicateMatchResponses(matches []participant.DuplicateMatchResponse) []response.DuplicateMatchResponse {
+	result := make([]response.DuplicateMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, response.DuplicateMatchResponse{
+			ParticipantID: m.ParticipantID,
+			TenantID:      m.TenantID,
+			ProductID:     m.ProductID,
+			FullName:      m.FullName,
+			Status:        m.Status,
+			Score:         m.Score,
+			MatchedFields: m.MatchedFields,
+		})
 	}
+	return result
 }
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupClaimRoutes(api fiber.Router, ctrl *controller.ClaimController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	claims := api.Group("/claims")
+	claims.Use(jwtMiddleware)
+	claims.Use(middleware.ExtractTenantContext())
+	claims.Use(frendzSavingMW)
+
+	creatorMW := middleware.RequireProductRole("PARTICIPANT_CREATOR")
+	approverMW := middleware.RequireProductRole("PARTICIPANT_APPROVER")
+	anyRoleMW := middleware.RequireProductRole("PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER")
+
+	claims.Post("/", creatorMW, ctrl.Open)
+	claims.Get("/", anyRoleMW, ctrl.List)
+	claims.Get("/:id", anyRoleMW, ctrl.Get)
+	claims.Get("/:id/status-history", anyRoleMW, ctrl.StatusHistory)
+	claims.Post("/:id/documents", creatorMW, ctrl.UploadDocument)
+	claims.Post("/:id/verify", approverMW, ctrl.Verify)
+	claims.Post("/:id/approve", approverMW, ctrl.Approve)
+	claims.Post("/:id/reject", approverMW, ctrl.Reject)
+	claims.Post("/:id/pay", approverMW, ctrl.Pay)
+}
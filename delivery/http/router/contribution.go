@@ -0,0 +1,24 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupContributionRoutes(api fiber.Router, ctrl *controller.ContributionController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	contributions := api.Group("/contributions")
+	contributions.Use(jwtMiddleware)
+	contributions.Use(middleware.ExtractTenantContext())
+	contributions.Use(frendzSavingMW)
+
+	approverMW := middleware.RequireProductRole("PARTICIPANT_APPROVER")
+	anyRoleMW := middleware.RequireProductRole("PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER")
+
+	contributions.Post("/imports", approverMW, ctrl.Import)
+	contributions.Get("/imports", anyRoleMW, ctrl.ListImports)
+	contributions.Get("/imports/:batchId", anyRoleMW, ctrl.GetImport)
+	contributions.Post("/entries/:entryId/reverse", approverMW, ctrl.ReverseEntry)
+	contributions.Get("/participants/:id/statement", anyRoleMW, ctrl.Statement)
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupFileRoutes(api fiber.Router, ctrl *controller.FileController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	files := api.Group("/files")
+	files.Use(jwtMiddleware)
+	files.Use(middleware.ExtractTenantContext())
+	files.Use(frendzSavingMW)
+
+	anyRoleMW := middleware.RequireProductRole("PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER")
+
+	files.Get("/:id", anyRoleMW, ctrl.Get)
+	files.Get("/:id/content", anyRoleMW, ctrl.Content)
+	files.Get("/:id/thumbnail", anyRoleMW, ctrl.Thumbnail)
+}
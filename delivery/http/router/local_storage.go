@@ -0,0 +1,14 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/impl/localfs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupLocalStorageRoutes(app fiber.Router, ctrl *controller.LocalStorageController) {
+	storage := app.Group(localfs.RoutePrefix)
+	storage.Get("/:bucket/*", ctrl.Download)
+	storage.Post("/:bucket", ctrl.Upload)
+}
@@ -46,6 +46,8 @@ func SetupParticipantRoutes(api fiber.Router, ctrl *controller.ParticipantContro
 
 	participants.Post("/", creatorMW, ctrl.Create)
 	participants.Get("/", anyRoleMW, ctrl.List)
+	participants.Get("/duplicates", approverMW, ctrl.ListDuplicateCandidates)
+	participants.Post("/duplicates/:candidateId/dismiss", approverMW, ctrl.DismissDuplicateCandidate)
 	participants.Get("/:id", anyRoleMW, ctrl.Get)
 
 	participants.Put("/:id/personal-data", creatorMW, ctrl.UpdatePersonalData)
@@ -58,6 +60,7 @@ func SetupParticipantRoutes(api fiber.Router, ctrl *controller.ParticipantContro
 
 	participants.Put("/:id/bank-accounts", creatorMW, ctrl.SaveBankAccount)
 	participants.Delete("/:id/bank-accounts/:accountId", creatorMW, ctrl.DeleteBankAccount)
+	participants.Post("/:id/bank-accounts/:accountId/verify", anyRoleMW, ctrl.VerifyBankAccount)
 
 	participants.Put("/:id/family-members", creatorMW, ctrl.SaveFamilyMembers)
 	participants.Delete("/:id/family-members/:memberId", creatorMW, ctrl.DeleteFamilyMember)
@@ -69,10 +72,22 @@ func SetupParticipantRoutes(api fiber.Router, ctrl *controller.ParticipantContro
 	participants.Delete("/:id/beneficiaries/:beneficiaryId", creatorMW, ctrl.DeleteBeneficiary)
 
 	participants.Post("/:id/files", creatorMW, ctrl.UploadFile)
+	participants.Post("/:id/files/uploads", creatorMW, ctrl.RequestUploadSlot)
+	participants.Post("/:id/files/uploads/:uploadId/complete", creatorMW, ctrl.CompleteUpload)
 	participants.Get("/:id/status-history", anyRoleMW, ctrl.GetStatusHistory)
 
 	participants.Post("/:id/submit", creatorMW, ctrl.Submit)
 	participants.Post("/:id/approve", approverMW, ctrl.Approve)
 	participants.Post("/:id/reject", approverMW, ctrl.Reject)
+
+	participants.Post("/:id/activate", approverMW, ctrl.Activate)
+	participants.Post("/:id/suspend", approverMW, ctrl.Suspend)
+	participants.Post("/:id/terminate", approverMW, ctrl.Terminate)
+	participants.Post("/:id/retire", approverMW, ctrl.Retire)
+	participants.Post("/:id/deceased", approverMW, ctrl.MarkDeceased)
+	participants.Post("/:id/transfer-out", approverMW, ctrl.TransferOut)
+
+	participants.Get("/:id/duplicates", anyRoleMW, ctrl.FindDuplicates)
+	participants.Post("/:id/merge", approverMW, ctrl.Merge)
 	participants.Delete("/:id", approverMW, ctrl.Delete)
 }
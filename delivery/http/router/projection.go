@@ -0,0 +1,20 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupProjectionRoutes(api fiber.Router, ctrl *controller.ProjectionController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	projections := api.Group("/projections")
+	projections.Use(jwtMiddleware)
+	projections.Use(middleware.ExtractTenantContext())
+	projections.Use(frendzSavingMW)
+
+	anyRoleMW := middleware.RequireProductRole("PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER")
+
+	projections.Post("/participants/:id", anyRoleMW, ctrl.Project)
+	projections.Post("/participants/:id/retirement-date", anyRoleMW, ctrl.RecalculateRetirementDate)
+}
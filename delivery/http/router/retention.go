@@ -0,0 +1,23 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRetentionRoutes(api fiber.Router, ctrl *controller.RetentionController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	retention := api.Group("/retention")
+	retention.Use(jwtMiddleware)
+	retention.Use(middleware.ExtractTenantContext())
+	retention.Use(frendzSavingMW)
+
+	approverMW := middleware.RequireProductRole("PARTICIPANT_APPROVER")
+
+	retention.Get("/policies", approverMW, ctrl.ListPolicies)
+	retention.Put("/policies", approverMW, ctrl.SavePolicy)
+	retention.Delete("/policies/:id", approverMW, ctrl.DeletePolicy)
+	retention.Put("/legal-holds/participants/:id", approverMW, ctrl.SetParticipantLegalHold)
+	retention.Put("/legal-holds/files/:id", approverMW, ctrl.SetFileLegalHold)
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/masking"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRevealRoutes(api fiber.Router, ctrl *controller.RevealController, jwtMiddleware fiber.Handler, frendzSavingMW fiber.Handler) {
+	pii := api.Group("/pii")
+	pii.Use(jwtMiddleware)
+	pii.Use(middleware.ExtractTenantContext())
+	pii.Use(frendzSavingMW)
+
+	pii.Post("/participants/:id/reveal", middleware.RequireProductPermission(masking.RevealPermission), ctrl.RevealParticipantField)
+}
@@ -0,0 +1,258 @@
+package ws
+
+import (
+	"context"
+	"time"
+
+	"erp-service/config"
+	"erp-service/delivery/http/middleware"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/notify"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// CloseTokenExpired ends a connection whose access token expired; the
+	// client reconnects with a fresh token and its cursor.
+	CloseTokenExpired = 4001
+	// CloseSlowConsumer ends a connection that fell SendBuffer events behind;
+	// the client reconnects with its cursor to catch up from the stream.
+	CloseSlowConsumer = 4008
+)
+
+const subscriptionKey = "ws_subscription"
+
+// topicRoles lists the product roles that may receive each topic.
+var topicRoles = []struct {
+	topic notify.Topic
+	roles []string
+}{
+	{notify.TopicApprovalQueue, []string{"PARTICIPANT_APPROVER"}},
+	{notify.TopicParticipantStatus, []string{"PARTICIPANT_CREATOR", "PARTICIPANT_APPROVER"}},
+	{notify.TopicMembers, []string{"TENANT_PRODUCT_ADMIN"}},
+}
+
+// EventLog replays events a reconnecting client missed.
+type EventLog interface {
+	ReplayEvents(ctx context.Context, tenantID, productID uuid.UUID, cursor string, limit int64) ([]*notify.Event, bool, error)
+}
+
+type subscription struct {
+	tenantID  uuid.UUID
+	productID uuid.UUID
+	topics    []notify.Topic
+	cursor    string
+	expiresAt time.Time
+}
+
+// controlMessage is everything the server sends that is not an event.
+type controlMessage struct {
+	Type   string         `json:"type"`
+	Topics []notify.Topic `json:"topics,omitempty"`
+	Cursor string         `json:"cursor,omitempty"`
+}
+
+type Handler struct {
+	hub    *Hub
+	log    EventLog
+	config config.WebSocketConfig
+	logger *zap.Logger
+}
+
+func NewHandler(hub *Hub, log EventLog, cfg config.WebSocketConfig, logger *zap.Logger) *Handler {
+	return &Handler{
+		hub:    hub,
+		log:    log,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Authorize resolves the topics the caller's roles allow in the tenant and
+// product the route middleware resolved, before the connection is upgraded
+// so a refusal is still a plain HTTP error.
+func (h *Handler) Authorize(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	claims, err := middleware.GetMultiTenantClaims(c)
+	if err != nil {
+		return err
+	}
+	tenantID, err := middleware.GetTenantIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	productID, err := middleware.GetProductIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	cursor := c.Query("cursor")
+	if cursor != "" && !notify.ValidID(cursor) {
+		return apperrors.ErrBadRequest("invalid cursor")
+	}
+
+	var topics []notify.Topic
+	for _, tr := range topicRoles {
+		if claims.IsPlatformAdmin() {
+			topics = append(topics, tr.topic)
+			continue
+		}
+		for _, role := range tr.roles {
+			if claims.HasRoleInProduct(tenantID, productID, role) {
+				topics = append(topics, tr.topic)
+				break
+			}
+		}
+	}
+	if len(topics) == 0 {
+		return apperrors.ErrForbidden("no notification topics available for your roles")
+	}
+
+	sub := &subscription{
+		tenantID:  tenantID,
+		productID: productID,
+		topics:    topics,
+		cursor:    cursor,
+	}
+	if claims.ExpiresAt != nil {
+		sub.expiresAt = claims.ExpiresAt.Time
+	}
+	c.Locals(subscriptionKey, sub)
+	return c.Next()
+}
+
+func (h *Handler) Serve() fiber.Handler {
+	return websocket.New(h.serve)
+}
+
+// serve registers the client before replaying from its cursor, so nothing
+// published in between is lost; live events the replay already covered are
+// skipped by ID.
+func (h *Handler) serve(conn *websocket.Conn) {
+	sub := conn.Locals(subscriptionKey).(*subscription)
+	raw := conn.Conn
+
+	c := newClient(sub, h.config.SendBuffer)
+	h.hub.register(c)
+	defer h.hub.unregister(c)
+
+	readDone := make(chan struct{})
+	raw.SetReadLimit(512)
+	_ = raw.SetReadDeadline(time.Now().Add(h.config.PongTimeout))
+	raw.SetPongHandler(func(string) error {
+		return raw.SetReadDeadline(time.Now().Add(h.config.PongTimeout))
+	})
+	go func() {
+		defer close(readDone)
+		for {
+			if _, _, err := raw.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		_ = raw.Close()
+		<-readDone
+	}()
+
+	write := func(v any) bool {
+		_ = raw.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
+		return raw.WriteJSON(v) == nil
+	}
+	closeWith := func(code int, text string) {
+		_ = raw.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(h.config.WriteTimeout))
+	}
+
+	last := sub.cursor
+	if last != "" {
+		events, gap, err := h.log.ReplayEvents(context.Background(), sub.tenantID, sub.productID, last, int64(h.config.ReplayLimit))
+		if err != nil {
+			h.logger.Warn("failed to replay notifications",
+				zap.String("tenant_id", sub.tenantID.String()),
+				zap.Error(err),
+			)
+			gap = true
+		}
+		if gap {
+			if !write(controlMessage{Type: "resync"}) {
+				return
+			}
+			last = ""
+		}
+		for _, event := range events {
+			if c.topics[event.Topic] && !write(event) {
+				return
+			}
+			last = event.ID
+		}
+	}
+	if !write(controlMessage{Type: "ready", Topics: sub.topics, Cursor: last}) {
+		return
+	}
+
+	ping := time.NewTicker(h.config.PingInterval)
+	defer ping.Stop()
+
+	var expired <-chan time.Time
+	if !sub.expiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(sub.expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case event := <-c.send:
+			if last != "" && !notify.IDAfter(event.ID, last) {
+				continue
+			}
+			if !write(event) {
+				return
+			}
+			last = event.ID
+		case <-ping.C:
+			if err := raw.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.config.WriteTimeout)); err != nil {
+				return
+			}
+		case <-c.overflow:
+			closeWith(CloseSlowConsumer, "too far behind; reconnect with cursor")
+			return
+		case <-expired:
+			closeWith(CloseTokenExpired, "token expired")
+			return
+		case <-h.hub.done:
+			closeWith(websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-readDone:
+			return
+		}
+	}
+}
+
+// QueryCredentials lets browsers, which cannot set headers on a WebSocket
+// handshake, pass the access token and tenant as access_token and tenant_id
+// query parameters. The token is moved into the Authorization header and
+// removed from the URL before anything logs the request.
+func QueryCredentials() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		args := c.Request().URI().QueryArgs()
+		if token := string(args.Peek("access_token")); token != "" {
+			if c.Get(fiber.HeaderAuthorization) == "" {
+				c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+			}
+			args.Del("access_token")
+			c.Request().URI().SetQueryStringBytes(args.QueryString())
+		}
+		if tenantID := string(args.Peek("tenant_id")); tenantID != "" && c.Get("X-Tenant-ID") == "" {
+			c.Request().Header.Set("X-Tenant-ID", tenantID)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"sync"
+
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+type scope struct {
+	tenantID  uuid.UUID
+	productID uuid.UUID
+}
+
+// Hub fans events received from Redis out to the connections of this
+// replica. Dispatch never blocks on a connection: a client whose buffer is
+// full is flagged and disconnected by its own writer.
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[scope]map[*client]struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[scope]map[*client]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (h *Hub) Dispatch(event *notify.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients[scope{event.TenantID, event.ProductID}] {
+		c.offer(event)
+	}
+}
+
+// Close tells every connection to go away, for shutdown.
+func (h *Hub) Close() {
+	h.once.Do(func() { close(h.done) })
+}
+
+// Len returns the number of open connections.
+func (h *Hub) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, clients := range h.clients {
+		n += len(clients)
+	}
+	return n
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := scope{c.tenantID, c.productID}
+	if h.clients[key] == nil {
+		h.clients[key] = make(map[*client]struct{})
+	}
+	h.clients[key][c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := scope{c.tenantID, c.productID}
+	delete(h.clients[key], c)
+	if len(h.clients[key]) == 0 {
+		delete(h.clients, key)
+	}
+}
+
+type client struct {
+	tenantID  uuid.UUID
+	productID uuid.UUID
+	topics    map[notify.Topic]bool
+	send      chan *notify.Event
+	overflow  chan struct{}
+	once      sync.Once
+}
+
+func newClient(sub *subscription, buffer int) *client {
+	topics := make(map[notify.Topic]bool, len(sub.topics))
+	for _, topic := range sub.topics {
+		topics[topic] = true
+	}
+	return &client{
+		tenantID:  sub.tenantID,
+		productID: sub.productID,
+		topics:    topics,
+		send:      make(chan *notify.Event, buffer),
+		overflow:  make(chan struct{}),
+	}
+}
+
+func (c *client) offer(event *notify.Event) {
+	if !c.topics[event.Topic] {
+		return
+	}
+	select {
+	case c.send <- event:
+	default:
+		c.once.Do(func() { close(c.overflow) })
+	}
+}
@@ -0,0 +1,172 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"erp-service/config"
+	"erp-service/delivery/http/middleware"
+	"erp-service/iam/product"
+	"erp-service/impl/postgres"
+	implredis "erp-service/impl/redis"
+	"erp-service/infrastructure"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/notify"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	app     *fiber.App
+	config  *config.Config
+	logger  *zap.Logger
+	redis   *implredis.Redis
+	hub     *Hub
+	cancel  context.CancelFunc
+	closeDB func(context.Context) error
+}
+
+// NewServer serves GET /ws behind the same authentication, tenant and product
+// checks as the REST routes. Every replica subscribes to all notification
+// channels in Redis and delivers to its own connections.
+func NewServer(cfg *config.Config) *Server {
+	zapLogger, _ := logger.NewZapLoggerWithConfig(cfg.Log, cfg.App.Environment)
+
+	postgresDB, closeDB, err := infrastructure.NewPostgres(cfg, zapLogger)
+	if err != nil {
+		log.Fatal("failed to connect to postgres:", err)
+	}
+
+	redisClient, err := infrastructure.NewRedis(cfg.Infra.Redis)
+	if err != nil {
+		log.Fatal("failed to connect to redis:", err)
+	}
+	inMemoryStore := implredis.NewRedis(redisClient)
+
+	productUsecase := product.NewUsecase(postgres.NewProductRepository(postgresDB), inMemoryStore)
+
+	hub := NewHub()
+	handler := NewHandler(hub, inMemoryStore, cfg.WebSocket, zapLogger)
+
+	app := fiber.New(fiber.Config{
+		JSONEncoder:  json.Marshal,
+		JSONDecoder:  json.Unmarshal,
+		AppName:      cfg.App.Name,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+		ErrorHandler: ErrorHandler(zapLogger),
+	})
+	app.Use(QueryCredentials())
+	middleware.New(cfg, zapLogger).Setup(app)
+
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "ok"})
+	})
+	app.Get("/ws",
+		middleware.JWTAuth(cfg, inMemoryStore),
+		middleware.ExtractTenantContext(),
+		middleware.ExtractFrendzSavingProduct(productUsecase),
+		handler.Authorize,
+		handler.Serve(),
+	)
+
+	return &Server{
+		app:     app,
+		config:  cfg,
+		logger:  zapLogger,
+		redis:   inMemoryStore,
+		hub:     hub,
+		closeDB: closeDB,
+	}
+}
+
+func (s *Server) App() *fiber.App {
+	return s.app
+}
+
+func (s *Server) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.relay(ctx)
+
+	addr := fmt.Sprintf("%s:%d", s.config.WebSocket.Host, s.config.WebSocket.Port)
+	s.logger.Info("websocket server listening", zap.String("addr", addr))
+	return s.app.Listen(addr)
+}
+
+// Shutdown stops the relay and closes every connection with "going away" so
+// clients reconnect, with their cursor, to another replica.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.hub.Close()
+	err := s.app.ShutdownWithContext(ctx)
+	return errors.Join(err, s.closeDB(ctx))
+}
+
+func (s *Server) relay(ctx context.Context) {
+	sub := s.redis.PSubscribe(ctx, implredis.NotificationChannelPattern)
+	defer sub.Close()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event notify.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				s.logger.Warn("dropping malformed notification",
+					zap.String("channel", msg.Channel),
+					zap.Error(err),
+				)
+				continue
+			}
+			s.hub.Dispatch(&event)
+		}
+	}
+}
+
+// ErrorHandler answers handshakes refused before the upgrade in the same
+// shape as the REST middleware.
+func ErrorHandler(zapLogger *zap.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		appErr := apperrors.ErrInternal("internal server error")
+
+		var matched *apperrors.AppError
+		var fiberErr *fiber.Error
+		switch {
+		case apperrors.As(err, &matched):
+			if matched.HTTPStatus < 500 {
+				appErr = matched
+			}
+		case errors.As(err, &fiberErr):
+			return c.Status(fiberErr.Code).JSON(fiber.Map{
+				"success": false,
+				"error":   fiberErr.Message,
+			})
+		}
+
+		if appErr.HTTPStatus >= 500 {
+			zapLogger.Error("websocket handshake failed",
+				zap.String("request_id", middleware.GetRequestID(c)),
+				zap.String("path", c.Path()),
+				zap.Error(err),
+			)
+		}
+		return c.Status(appErr.HTTPStatus).JSON(fiber.Map{
+			"success": false,
+			"error":   appErr.Message,
+			"code":    appErr.Code,
+		})
+	}
+}
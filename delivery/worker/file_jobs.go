@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp-service/files"
+	implredis "erp-service/impl/redis"
+	"erp-service/pkg/metrics"
+
+	"go.uber.org/zap"
+)
+
+const (
+	QueueFiles = "files"
+
+	JobFileCleanup = "files.cleanup"
+	JobFileScan    = "files.scan"
+	JobFileRewrap  = "files.rewrap_keys"
+	JobFilePurge   = "files.purge"
+)
+
+type FileJobIntervals struct {
+	Cleanup time.Duration
+	Scan    time.Duration
+	Rewrap  time.Duration
+	Purge   time.Duration
+}
+
+func DefaultFileJobIntervals() FileJobIntervals {
+	return FileJobIntervals{
+		Cleanup: 5 * time.Minute,
+		Scan:    30 * time.Second,
+		Rewrap:  5 * time.Minute,
+		Purge:   time.Hour,
+	}
+}
+
+// Databases runs fn once per database holding tenant data, with ctx routed
+// to it. tenantdb.Router implements it.
+type Databases interface {
+	Each(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// FileJobs runs the periodic file maintenance batches as jobs on the files
+// queue.
+type FileJobs struct {
+	uc        files.Usecase
+	databases Databases
+	logger    *zap.Logger
+}
+
+func NewFileJobs(uc files.Usecase, databases Databases, logger *zap.Logger) *FileJobs {
+	return &FileJobs{uc: uc, databases: databases, logger: logger}
+}
+
+func (f *FileJobs) Register(registry *Registry) error {
+	for name, handler := range map[string]Handler{
+		JobFileCleanup: f.cleanup,
+		JobFileScan:    f.scan,
+		JobFileRewrap:  f.rewrap,
+		JobFilePurge:   f.purge,
+	} {
+		err := registry.Register(JobType{
+			Name:             name,
+			Queue:            QueueFiles,
+			Handler:          f.eachDatabase(handler),
+			DropOnExhaustion: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FileJobs) Schedule(scheduler *Scheduler, intervals FileJobIntervals) error {
+	for name, interval := range map[string]time.Duration{
+		JobFileCleanup: intervals.Cleanup,
+		JobFileScan:    intervals.Scan,
+		JobFileRewrap:  intervals.Rewrap,
+		JobFilePurge:   intervals.Purge,
+	} {
+		if err := scheduler.Every(name, interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachDatabase runs handler once per tenant database: a batch only sees the
+// files of the database ctx routes to. A failing database does not hold
+// back the others.
+func (f *FileJobs) eachDatabase(handler Handler) Handler {
+	return func(ctx context.Context, job *implredis.Job) error {
+		return f.databases.Each(ctx, func(ctx context.Context) error {
+			return handler(ctx, job)
+		})
+	}
+}
+
+func (f *FileJobs) cleanup(ctx context.Context, _ *implredis.Job) error {
+	result, err := f.uc.CleanupBatch(ctx)
+	metrics.ObserveCleanupBatch(result.Processed, result.Failed, result.SlotsReaped, result.SlotsFailed, err)
+	if err != nil {
+		return err
+	}
+	if result.Processed > 0 || result.Failed > 0 || result.SlotsReaped > 0 || result.SlotsFailed > 0 {
+		f.logger.Info("cleanup batch completed",
+			zap.Int("processed", result.Processed),
+			zap.Int("failed", result.Failed),
+			zap.Int("slots_reaped", result.SlotsReaped),
+			zap.Int("slots_failed", result.SlotsFailed),
+		)
+	}
+	return nil
+}
+
+// scan runs the image pass right after the scan pass, since images become
+// eligible once scanned clean. A failed scan does not hold back images that
+// were already clean.
+func (f *FileJobs) scan(ctx context.Context, _ *implredis.Job) error {
+	scanResult, scanErr := f.uc.ScanBatch(ctx)
+	if scanErr == nil && (scanResult.Clean > 0 || scanResult.Infected > 0 || scanResult.Failed > 0) {
+		f.logger.Info("scan batch completed",
+			zap.Int("clean", scanResult.Clean),
+			zap.Int("infected", scanResult.Infected),
+			zap.Int("failed", scanResult.Failed),
+		)
+	}
+
+	imageResult, imageErr := f.uc.ProcessImagesBatch(ctx)
+	if imageErr == nil && (imageResult.Processed > 0 || imageResult.Skipped > 0 || imageResult.Failed > 0) {
+		f.logger.Info("image processing batch completed",
+			zap.Int("processed", imageResult.Processed),
+			zap.Int("skipped", imageResult.Skipped),
+			zap.Int("failed", imageResult.Failed),
+		)
+	}
+	return errors.Join(scanErr, imageErr)
+}
+
+func (f *FileJobs) rewrap(ctx context.Context, _ *implredis.Job) error {
+	result, err := f.uc.RewrapKeysBatch(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Rewrapped > 0 || result.Failed > 0 {
+		f.logger.Info("key rewrap batch completed",
+			zap.Int("rewrapped", result.Rewrapped),
+			zap.Int("skipped", result.Skipped),
+			zap.Int("failed", result.Failed),
+		)
+	}
+	return nil
+}
+
+func (f *FileJobs) purge(ctx context.Context, _ *implredis.Job) error {
+	result, err := f.uc.PurgeBatch(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Purged > 0 || result.Failed > 0 {
+		f.logger.Info("retention purge batch completed",
+			zap.Int("purged", result.Purged),
+			zap.Int("failed", result.Failed),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"erp-service/pkg/pii"
+
+	"go.uber.org/zap"
+)
+
+const defaultPIIBackfillBatchSize = 200
+
+// PIIBackfill seals plaintext left over from before field encryption was
+// enabled. It runs batches once at startup in every tenant database until
+// nothing is left to seal, then exits; an idle deployment pays one query
+// per column per database per restart.
+type PIIBackfill struct {
+	backfiller pii.Backfiller
+	databases  Databases
+	logger     *zap.Logger
+	batchSize  int
+	done       chan struct{}
+	startOnce  sync.Once
+}
+
+func NewPIIBackfill(backfiller pii.Backfiller, databases Databases, logger *zap.Logger) *PIIBackfill {
+	return &PIIBackfill{
+		backfiller: backfiller,
+		databases:  databases,
+		logger:     logger,
+		batchSize:  defaultPIIBackfillBatchSize,
+		done:       make(chan struct{}),
+	}
+}
+
+func (b *PIIBackfill) SetBatchSize(n int) { b.batchSize = n }
+
+func (b *PIIBackfill) Start(ctx context.Context) {
+	b.startOnce.Do(func() {
+		go func() {
+			defer close(b.done)
+			b.run(ctx)
+		}()
+	})
+}
+
+func (b *PIIBackfill) Stop() {
+	<-b.done
+}
+
+// run backfills each database in turn. A database whose batch fails is
+// left for the next start and does not hold back the others.
+func (b *PIIBackfill) run(ctx context.Context) {
+	total := 0
+	err := b.databases.Each(ctx, func(ctx context.Context) error {
+		sealed, err := b.runDatabase(ctx)
+		total += sealed
+		return err
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		b.logger.Error("pii backfill batch failed", zap.Error(err))
+	}
+	if total > 0 {
+		b.logger.Info("pii backfill completed", zap.Int("sealed", total))
+	}
+}
+
+// runDatabase seals the database ctx routes to. Row ids are only ordered
+// within one database, so each gets its own cursor.
+func (b *PIIBackfill) runDatabase(ctx context.Context) (int, error) {
+	sealed := 0
+	cursor := pii.BackfillCursor{}
+	for ctx.Err() == nil {
+		result, err := b.backfiller.BackfillBatch(ctx, cursor, b.batchSize)
+		if err != nil {
+			return sealed, err
+		}
+		for _, failure := range result.Failures {
+			b.logger.Warn("pii backfill failed to seal row",
+				zap.String("table", failure.Table),
+				zap.String("id", failure.ID),
+				zap.Error(failure.Err),
+			)
+		}
+		sealed += result.Sealed
+		// The cursor moves past failed rows too; they are retried on the
+		// next start. The run ends once a batch finds nothing left to visit.
+		if result.Visited == 0 {
+			break
+		}
+	}
+	return sealed, nil
+}
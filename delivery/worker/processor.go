@@ -0,0 +1,395 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	implredis "erp-service/impl/redis"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// settleTimeout bounds the Redis calls that record a job's outcome. They use
+// their own context so a job cancelled at shutdown is still settled.
+const settleTimeout = 5 * time.Second
+
+type ProcessorConfig struct {
+	// Concurrency is the number of consumers per queue; queues missing from
+	// it get one.
+	Concurrency      map[string]int
+	PollInterval     time.Duration
+	LeaseDuration    time.Duration
+	JobTimeout       time.Duration
+	DrainTimeout     time.Duration
+	RecoveryInterval time.Duration
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+}
+
+func DefaultProcessorConfig() ProcessorConfig {
+	return ProcessorConfig{
+		Concurrency:      map[string]int{},
+		PollInterval:     time.Second,
+		LeaseDuration:    time.Minute,
+		JobTimeout:       10 * time.Minute,
+		DrainTimeout:     30 * time.Second,
+		RecoveryInterval: 30 * time.Second,
+		RetryBaseDelay:   5 * time.Second,
+		RetryMaxDelay:    10 * time.Minute,
+	}
+}
+
+// Processor consumes the queues of every registered job type. Delivery is
+// at least once: a job is leased while it runs, settled afterwards, and put
+// back by lease recovery if its worker dies first, so handlers must be
+// idempotent.
+type Processor struct {
+	queue    Queue
+	registry *Registry
+	logger   *zap.Logger
+	cfg      ProcessorConfig
+
+	stop        chan struct{}
+	jobCtx      context.Context
+	cancelJobs  context.CancelFunc
+	consumers   sync.WaitGroup
+	maintenance sync.WaitGroup
+	startOnce   sync.Once
+	stopOnce    sync.Once
+
+	// running maps each in-flight job to the time its handler should have
+	// returned by, for CheckStalled.
+	runningMu sync.Mutex
+	running   map[*implredis.Job]time.Time
+}
+
+// NewProcessor fills zero durations in cfg from DefaultProcessorConfig.
+func NewProcessor(queue Queue, registry *Registry, logger *zap.Logger, cfg ProcessorConfig) *Processor {
+	defaults := DefaultProcessorConfig()
+	for _, d := range []struct{ value, fallback *time.Duration }{
+		{&cfg.PollInterval, &defaults.PollInterval},
+		{&cfg.LeaseDuration, &defaults.LeaseDuration},
+		{&cfg.JobTimeout, &defaults.JobTimeout},
+		{&cfg.DrainTimeout, &defaults.DrainTimeout},
+		{&cfg.RecoveryInterval, &defaults.RecoveryInterval},
+		{&cfg.RetryBaseDelay, &defaults.RetryBaseDelay},
+		{&cfg.RetryMaxDelay, &defaults.RetryMaxDelay},
+	} {
+		if *d.value <= 0 {
+			*d.value = *d.fallback
+		}
+	}
+	return &Processor{
+		queue:    queue,
+		registry: registry,
+		logger:   logger,
+		cfg:      cfg,
+		stop:     make(chan struct{}),
+		running:  make(map[*implredis.Job]time.Time),
+	}
+}
+
+// Start launches the consumers and the per-queue maintenance loops.
+// Cancelling ctx aborts in-flight jobs at once; Stop drains them instead.
+func (p *Processor) Start(ctx context.Context) {
+	p.startOnce.Do(func() {
+		p.jobCtx, p.cancelJobs = context.WithCancel(ctx)
+		for _, queueName := range p.registry.Queues() {
+			n := p.cfg.Concurrency[queueName]
+			if n < 1 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				p.consumers.Add(1)
+				go p.consume(queueName)
+			}
+			p.maintenance.Add(1)
+			go p.maintain(queueName)
+			p.logger.Info("job queue started", zap.String("queue", queueName), zap.Int("concurrency", n))
+		}
+	})
+}
+
+// Stop stops taking new jobs and waits up to DrainTimeout for in-flight
+// ones. Jobs still running after that are cancelled and requeued without
+// counting the attempt.
+func (p *Processor) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	if p.cancelJobs == nil {
+		return
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		p.consumers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(p.cfg.DrainTimeout):
+		p.logger.Warn("drain timeout reached, cancelling in-flight jobs", zap.Duration("drain_timeout", p.cfg.DrainTimeout))
+		p.cancelJobs()
+		<-drained
+	}
+	p.cancelJobs()
+	p.maintenance.Wait()
+}
+
+// Backoff is the delay before retrying a job that failed its attempt-th
+// run: RetryBaseDelay doubled per attempt, capped at RetryMaxDelay.
+func (p *Processor) Backoff(attempt int) time.Duration {
+	delay := p.cfg.RetryBaseDelay
+	for i := 1; i < attempt && delay < p.cfg.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.cfg.RetryMaxDelay {
+		delay = p.cfg.RetryMaxDelay
+	}
+	return delay
+}
+
+func (p *Processor) stopping() bool {
+	select {
+	case <-p.stop:
+		return true
+	case <-p.jobCtx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Processor) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-p.stop:
+		return false
+	case <-p.jobCtx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (p *Processor) consume(queueName string) {
+	defer p.consumers.Done()
+	for !p.stopping() {
+		job, err := p.queue.DequeueLeased(p.jobCtx, queueName, p.cfg.LeaseDuration)
+		if err != nil {
+			if !errors.Is(err, apperrors.SentinelQueueEmpty) && p.jobCtx.Err() == nil {
+				p.logger.Error("dequeue failed", zap.String("queue", queueName), zap.Error(err))
+			}
+			if !p.wait(p.cfg.PollInterval) {
+				return
+			}
+			continue
+		}
+		p.process(queueName, job)
+	}
+}
+
+// process runs job in a consumer span that continues the trace of the
+// request which enqueued it.
+func (p *Processor) process(queueName string, job *implredis.Job) {
+	jobCtx, span := tracing.Tracer().Start(tracing.Extract(p.jobCtx, job.Trace), "job "+job.Type,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(queueName),
+			semconv.MessagingMessageID(job.ID),
+			attribute.Int("job.attempt", job.Attempts),
+		),
+	)
+	defer span.End()
+
+	fields := append([]zap.Field{
+		zap.String("queue", queueName),
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.Int("attempt", job.Attempts),
+	}, logger.TraceFields(jobCtx)...)
+
+	jobType, ok := p.registry.Lookup(job.Type)
+	if !ok {
+		span.SetStatus(codes.Error, "unknown job type")
+		p.logger.Error("no handler for job type, dead-lettering", fields...)
+		metrics.ObserveJob(queueName, job.Type, metrics.JobUnknownType, 0)
+		p.settle(func(ctx context.Context) error {
+			return p.queue.DeadLetterJob(ctx, queueName, job, fmt.Sprintf("unknown job type %q", job.Type))
+		}, fields)
+		return
+	}
+
+	timeout := jobType.Timeout
+	if timeout <= 0 {
+		timeout = p.cfg.JobTimeout
+	}
+	ctx, cancel := context.WithTimeout(jobCtx, timeout)
+	stopHeartbeat := p.heartbeat(ctx, queueName, job)
+	started := time.Now()
+	p.track(job, started.Add(timeout))
+	err := runHandler(ctx, jobType.Handler, job)
+	p.untrack(job)
+	elapsed := time.Since(started)
+	stopHeartbeat()
+	cancel()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	switch {
+	case err == nil:
+		metrics.ObserveJob(queueName, job.Type, metrics.JobCompleted, elapsed)
+		p.settle(func(ctx context.Context) error {
+			return p.queue.CompleteJob(ctx, queueName, job)
+		}, fields)
+	case p.jobCtx.Err() != nil:
+		p.logger.Warn("job interrupted by shutdown, requeueing", fields...)
+		metrics.ObserveJob(queueName, job.Type, metrics.JobInterrupted, elapsed)
+		job.Attempts--
+		p.settle(func(ctx context.Context) error {
+			return p.queue.RetryJob(ctx, queueName, job, "interrupted by shutdown", 0)
+		}, fields)
+	case job.Attempts >= job.MaxRetry && jobType.DropOnExhaustion:
+		p.logger.Error("job failed, dropping", append(fields, zap.Error(err))...)
+		metrics.ObserveJob(queueName, job.Type, metrics.JobDropped, elapsed)
+		p.settle(func(ctx context.Context) error {
+			return p.queue.CompleteJob(ctx, queueName, job)
+		}, fields)
+	case job.Attempts >= job.MaxRetry:
+		p.logger.Error("job failed, dead-lettering", append(fields, zap.Error(err))...)
+		metrics.ObserveJob(queueName, job.Type, metrics.JobDeadLettered, elapsed)
+		p.settle(func(ctx context.Context) error {
+			return p.queue.DeadLetterJob(ctx, queueName, job, err.Error())
+		}, fields)
+	default:
+		delay := p.Backoff(job.Attempts)
+		p.logger.Warn("job failed, retrying", append(fields, zap.Duration("delay", delay), zap.Error(err))...)
+		metrics.ObserveJob(queueName, job.Type, metrics.JobRetried, elapsed)
+		p.settle(func(ctx context.Context) error {
+			return p.queue.RetryJob(ctx, queueName, job, err.Error(), delay)
+		}, fields)
+	}
+}
+
+func (p *Processor) track(job *implredis.Job, deadline time.Time) {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	p.running[job] = deadline
+}
+
+func (p *Processor) untrack(job *implredis.Job) {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	delete(p.running, job)
+}
+
+// CheckStalled fails when a handler is still running a LeaseDuration past
+// its timeout. Such a handler ignores its context and holds its consumer
+// for good; only a restart frees it. It backs the worker's liveness probe.
+func (p *Processor) CheckStalled(ctx context.Context) error {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	now := time.Now()
+	for job, deadline := range p.running {
+		if overdue := now.Sub(deadline); overdue > p.cfg.LeaseDuration {
+			return fmt.Errorf("job %s of type %s is %s past its timeout", job.ID, job.Type, overdue.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+func runHandler(ctx context.Context, handler Handler, job *implredis.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// heartbeat extends the job's lease every third of LeaseDuration until the
+// returned function is called, so long jobs are not recovered mid-run.
+func (p *Processor) heartbeat(ctx context.Context, queueName string, job *implredis.Job) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(p.cfg.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := p.queue.ExtendLease(ctx, queueName, job, p.cfg.LeaseDuration); err != nil && ctx.Err() == nil {
+					p.logger.Warn("extend job lease failed", zap.String("queue", queueName), zap.String("job_id", job.ID), zap.Error(err))
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (p *Processor) settle(fn func(ctx context.Context) error, fields []zap.Field) {
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+	if err := fn(ctx); err != nil {
+		p.logger.Error("settle job failed", append(fields, zap.Error(err))...)
+	}
+}
+
+// maintain promotes due delayed jobs every PollInterval and recovers jobs
+// with expired leases every RecoveryInterval.
+func (p *Processor) maintain(queueName string) {
+	defer p.maintenance.Done()
+	promote := time.NewTicker(p.cfg.PollInterval)
+	defer promote.Stop()
+	recovery := time.NewTicker(p.cfg.RecoveryInterval)
+	defer recovery.Stop()
+
+	p.recoverExpired(queueName)
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-p.jobCtx.Done():
+			return
+		case <-promote.C:
+			if _, err := p.queue.ProcessDelayed(p.jobCtx, queueName); err != nil && p.jobCtx.Err() == nil {
+				p.logger.Error("promote delayed jobs failed", zap.String("queue", queueName), zap.Error(err))
+			}
+		case <-recovery.C:
+			p.recoverExpired(queueName)
+		}
+	}
+}
+
+func (p *Processor) recoverExpired(queueName string) {
+	n, err := p.queue.RequeueExpired(p.jobCtx, queueName)
+	if err != nil {
+		if p.jobCtx.Err() == nil {
+			p.logger.Error("recover expired jobs failed", zap.String("queue", queueName), zap.Error(err))
+		}
+		return
+	}
+	if n > 0 {
+		p.logger.Warn("recovered jobs with expired leases", zap.String("queue", queueName), zap.Int("count", n))
+	}
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	implredis "erp-service/impl/redis"
+)
+
+// Queue is the job store behind the processor and scheduler, implemented by
+// the Redis client.
+type Queue interface {
+	Enqueue(ctx context.Context, queueName string, job *implredis.Job) error
+	DequeueLeased(ctx context.Context, queueName string, lease time.Duration) (*implredis.Job, error)
+	ExtendLease(ctx context.Context, queueName string, job *implredis.Job, lease time.Duration) error
+	CompleteJob(ctx context.Context, queueName string, job *implredis.Job) error
+	RetryJob(ctx context.Context, queueName string, job *implredis.Job, errMsg string, delay time.Duration) error
+	DeadLetterJob(ctx context.Context, queueName string, job *implredis.Job, errMsg string) error
+	ProcessDelayed(ctx context.Context, queueName string) (int, error)
+	RequeueExpired(ctx context.Context, queueName string) (int, error)
+	ClaimSchedule(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error)
+}
+
+var _ Queue = (*implredis.Redis)(nil)
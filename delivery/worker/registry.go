@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	implredis "erp-service/impl/redis"
+)
+
+// Handler processes one job. Returning an error retries the job with
+// backoff until its MaxRetry is used up.
+type Handler func(ctx context.Context, job *implredis.Job) error
+
+type JobType struct {
+	Name    string
+	Queue   string
+	Handler Handler
+	// Timeout bounds a single attempt; zero uses the processor default.
+	Timeout time.Duration
+	// DropOnExhaustion discards a job that used up its retries instead of
+	// dead-lettering it. Periodic maintenance sets it, since the next run
+	// supersedes a failed one.
+	DropOnExhaustion bool
+}
+
+// Registry maps job types to their handlers. It is filled before the
+// processor starts and read-only afterwards.
+type Registry struct {
+	types map[string]JobType
+}
+
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]JobType)}
+}
+
+func (r *Registry) Register(jobType JobType) error {
+	if jobType.Name == "" || jobType.Queue == "" || jobType.Handler == nil {
+		return fmt.Errorf("job type %q needs a name, queue and handler", jobType.Name)
+	}
+	if _, ok := r.types[jobType.Name]; ok {
+		return fmt.Errorf("job type %q is already registered", jobType.Name)
+	}
+	r.types[jobType.Name] = jobType
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (JobType, bool) {
+	jobType, ok := r.types[name]
+	return jobType, ok
+}
+
+// Queues returns every queue with at least one registered job type.
+func (r *Registry) Queues() []string {
+	seen := make(map[string]struct{})
+	queues := make([]string, 0, len(r.types))
+	for _, jobType := range r.types {
+		if _, ok := seen[jobType.Queue]; ok {
+			continue
+		}
+		seen[jobType.Queue] = struct{}{}
+		queues = append(queues, jobType.Queue)
+	}
+	sort.Strings(queues)
+	return queues
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	implredis "erp-service/impl/redis"
+
+	"go.uber.org/zap"
+)
+
+const (
+	schedulerTick = time.Second
+	// scheduledMaxRetry gives periodic jobs a single attempt: the next slot
+	// runs the same work again.
+	scheduledMaxRetry = 1
+)
+
+type schedule struct {
+	jobType  JobType
+	interval time.Duration
+	lastSlot time.Time
+}
+
+// Scheduler enqueues registered job types on fixed intervals. Runs are
+// aligned to interval boundaries and each slot is claimed in the queue
+// store, so any number of replicas enqueue a slot exactly once.
+type Scheduler struct {
+	queue     Queue
+	registry  *Registry
+	logger    *zap.Logger
+	schedules []*schedule
+	done      chan struct{}
+	startOnce sync.Once
+}
+
+func NewScheduler(queue Queue, registry *Registry, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		queue:    queue,
+		registry: registry,
+		logger:   logger,
+		done:     make(chan struct{}),
+	}
+}
+
+// Every schedules jobType every interval. It must be called before Start.
+func (s *Scheduler) Every(jobType string, interval time.Duration) error {
+	registered, ok := s.registry.Lookup(jobType)
+	if !ok {
+		return fmt.Errorf("cannot schedule unregistered job type %q", jobType)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("job type %q needs a positive interval", jobType)
+	}
+	s.schedules = append(s.schedules, &schedule{jobType: registered, interval: interval})
+	return nil
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		go func() {
+			defer close(s.done)
+			ticker := time.NewTicker(schedulerTick)
+			defer ticker.Stop()
+			s.Tick(ctx, time.Now())
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					s.Tick(ctx, now)
+				}
+			}
+		}()
+	})
+}
+
+func (s *Scheduler) Stop() {
+	<-s.done
+}
+
+// Tick enqueues every schedule whose current slot has not been seen yet.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) {
+	for _, sched := range s.schedules {
+		slot := now.Truncate(sched.interval)
+		if !slot.After(sched.lastSlot) {
+			continue
+		}
+		if err := s.enqueue(ctx, sched, slot); err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("schedule job failed", zap.String("job_type", sched.jobType.Name), zap.Error(err))
+			}
+			continue
+		}
+		sched.lastSlot = slot
+	}
+}
+
+func (s *Scheduler) enqueue(ctx context.Context, sched *schedule, slot time.Time) error {
+	claimed, err := s.queue.ClaimSchedule(ctx, sched.jobType.Name, slot, sched.interval)
+	if err != nil || !claimed {
+		return err
+	}
+	job, err := implredis.NewTypedJob(sched.jobType.Name, struct{}{}, scheduledMaxRetry)
+	if err != nil {
+		return err
+	}
+	return s.queue.Enqueue(ctx, sched.jobType.Queue, job)
+}
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClaimType string
+
+const (
+	ClaimTypeNormalRetirement ClaimType = "NORMAL_RETIREMENT"
+	ClaimTypeEarlyRetirement  ClaimType = "EARLY_RETIREMENT"
+	ClaimTypeResignation      ClaimType = "RESIGNATION"
+	ClaimTypeDeath            ClaimType = "DEATH"
+	ClaimTypeDisability       ClaimType = "DISABILITY"
+)
+
+var claimRequiredDocuments = map[ClaimType][]string{
+	ClaimTypeNormalRetirement: {"claim_form", "ktp", "bank_book"},
+	ClaimTypeEarlyRetirement:  {"claim_form", "ktp", "bank_book", "early_retirement_letter"},
+	ClaimTypeResignation:      {"claim_form", "ktp", "bank_book", "resignation_letter"},
+	ClaimTypeDeath:            {"claim_form", "death_certificate", "heir_statement", "family_card"},
+	ClaimTypeDisability:       {"claim_form", "ktp", "bank_book", "medical_certificate"},
+}
+
+var claimEligibleStatuses = map[ClaimType][]ParticipantStatus{
+	ClaimTypeNormalRetirement: {ParticipantStatusApproved, ParticipantStatusActive, ParticipantStatusSuspended, ParticipantStatusRetired},
+	ClaimTypeEarlyRetirement:  {ParticipantStatusApproved, ParticipantStatusActive, ParticipantStatusSuspended, ParticipantStatusRetired},
+	ClaimTypeResignation:      {ParticipantStatusApproved, ParticipantStatusActive, ParticipantStatusSuspended, ParticipantStatusTerminated},
+	ClaimTypeDeath:            {ParticipantStatusDeceased},
+	ClaimTypeDisability:       {ParticipantStatusApproved, ParticipantStatusActive, ParticipantStatusSuspended},
+}
+
+func (t ClaimType) IsValid() bool {
+	_, ok := claimRequiredDocuments[t]
+	return ok
+}
+
+func (t ClaimType) RequiredDocuments() []string {
+	return claimRequiredDocuments[t]
+}
+
+func (t ClaimType) IsRequiredDocument(documentType string) bool {
+	for _, d := range claimRequiredDocuments[t] {
+		if d == documentType {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsParticipantStatus reports whether a claim of this type may be opened
+// for a participant in the given status. Death claims are only opened once the
+// death has been recorded on the participant.
+func (t ClaimType) AcceptsParticipantStatus(s ParticipantStatus) bool {
+	for _, allowed := range claimEligibleStatuses[t] {
+		if allowed == s {
+			return true
+		}
+	}
+	return false
+}
+
+type ClaimStatus string
+
+const (
+	ClaimStatusOpen     ClaimStatus = "OPEN"
+	ClaimStatusVerified ClaimStatus = "VERIFIED"
+	ClaimStatusApproved ClaimStatus = "APPROVED"
+	ClaimStatusPaid     ClaimStatus = "PAID"
+	ClaimStatusRejected ClaimStatus = "REJECTED"
+)
+
+type Claim struct {
+	ID               uuid.UUID   `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	TenantID         uuid.UUID   `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
+	ProductID        uuid.UUID   `json:"product_id" gorm:"column:product_id;not null" db:"product_id"`
+	ParticipantID    uuid.UUID   `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
+	ClaimType        ClaimType   `json:"claim_type" gorm:"column:claim_type;not null" db:"claim_type"`
+	Status           ClaimStatus `json:"status" gorm:"column:status;not null;default:OPEN" db:"status"`
+	EventDate        time.Time   `json:"event_date" gorm:"column:event_date;type:date;not null" db:"event_date"`
+	Notes            *string     `json:"notes,omitempty" gorm:"column:notes" db:"notes"`
+	EmployerAmount   int64       `json:"employer_amount" gorm:"column:employer_amount;not null;default:0" db:"employer_amount"`
+	EmployeeAmount   int64       `json:"employee_amount" gorm:"column:employee_amount;not null;default:0" db:"employee_amount"`
+	OpenedBy         uuid.UUID   `json:"opened_by" gorm:"column:opened_by;not null" db:"opened_by"`
+	VerifiedBy       *uuid.UUID  `json:"verified_by,omitempty" gorm:"column:verified_by" db:"verified_by"`
+	VerifiedAt       *time.Time  `json:"verified_at,omitempty" gorm:"column:verified_at" db:"verified_at"`
+	ApprovedBy       *uuid.UUID  `json:"approved_by,omitempty" gorm:"column:approved_by" db:"approved_by"`
+	ApprovedAt       *time.Time  `json:"approved_at,omitempty" gorm:"column:approved_at" db:"approved_at"`
+	RejectedBy       *uuid.UUID  `json:"rejected_by,omitempty" gorm:"column:rejected_by" db:"rejected_by"`
+	RejectedAt       *time.Time  `json:"rejected_at,omitempty" gorm:"column:rejected_at" db:"rejected_at"`
+	RejectionReason  *string     `json:"rejection_reason,omitempty" gorm:"column:rejection_reason" db:"rejection_reason"`
+	PaidBy           *uuid.UUID  `json:"paid_by,omitempty" gorm:"column:paid_by" db:"paid_by"`
+	PaidAt           *time.Time  `json:"paid_at,omitempty" gorm:"column:paid_at" db:"paid_at"`
+	PaymentReference *string     `json:"payment_reference,omitempty" gorm:"column:payment_reference" db:"payment_reference"`
+	Version          int         `json:"version" gorm:"column:version;not null;default:1" db:"version"`
+	CreatedAt        time.Time   `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+}
+
+func (Claim) TableName() string {
+	return "claims"
+}
+
+func (c *Claim) AcceptsDocuments() bool {
+	return c.Status == ClaimStatusOpen
+}
+
+func (c *Claim) CanBeVerified() bool {
+	return c.Status == ClaimStatusOpen
+}
+
+func (c *Claim) CanBeApproved() bool {
+	return c.Status == ClaimStatusVerified
+}
+
+func (c *Claim) CanBeRejected() bool {
+	return c.Status == ClaimStatusOpen || c.Status == ClaimStatusVerified
+}
+
+func (c *Claim) CanBePaid() bool {
+	return c.Status == ClaimStatusApproved
+}
+
+func (c *Claim) TotalAmount() int64 {
+	return c.EmployerAmount + c.EmployeeAmount
+}
+
+type ClaimDocument struct {
+	ID           uuid.UUID `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	ClaimID      uuid.UUID `json:"claim_id" gorm:"column:claim_id;not null" db:"claim_id"`
+	DocumentType string    `json:"document_type" gorm:"column:document_type;not null" db:"document_type"`
+	FileID       uuid.UUID `json:"file_id" gorm:"column:file_id;not null" db:"file_id"`
+	UploadedBy   uuid.UUID `json:"uploaded_by" gorm:"column:uploaded_by;not null" db:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at" db:"created_at"`
+}
+
+func (ClaimDocument) TableName() string {
+	return "claim_documents"
+}
+
+type ClaimStatusHistory struct {
+	ID         uuid.UUID `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	ClaimID    uuid.UUID `json:"claim_id" gorm:"column:claim_id;not null" db:"claim_id"`
+	FromStatus *string   `json:"from_status,omitempty" gorm:"column:from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" gorm:"column:to_status;not null" db:"to_status"`
+	ChangedBy  uuid.UUID `json:"changed_by" gorm:"column:changed_by;not null" db:"changed_by"`
+	Reason     *string   `json:"reason,omitempty" gorm:"column:reason" db:"reason"`
+	ChangedAt  time.Time `json:"changed_at" gorm:"column:changed_at;not null" db:"changed_at"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at" db:"created_at"`
+}
+
+func (ClaimStatusHistory) TableName() string {
+	return "claim_status_history"
+}
+
+type PayeeType string
+
+const (
+	PayeeTypeParticipant PayeeType = "PARTICIPANT"
+	PayeeTypeBeneficiary PayeeType = "BENEFICIARY"
+)
+
+type PaymentInstructionStatus string
+
+const (
+	PaymentInstructionStatusPending PaymentInstructionStatus = "PENDING"
+	PaymentInstructionStatusPaid    PaymentInstructionStatus = "PAID"
+)
+
+type PaymentInstruction struct {
+	ID               uuid.UUID                `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	ClaimID          uuid.UUID                `json:"claim_id" gorm:"column:claim_id;not null" db:"claim_id"`
+	TenantID         uuid.UUID                `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
+	ProductID        uuid.UUID                `json:"product_id" gorm:"column:product_id;not null" db:"product_id"`
+	PayeeType        PayeeType                `json:"payee_type" gorm:"column:payee_type;not null" db:"payee_type"`
+	PayeeID          uuid.UUID                `json:"payee_id" gorm:"column:payee_id;not null" db:"payee_id"`
+	PayeeName        string                   `json:"payee_name" gorm:"column:payee_name;not null" db:"payee_name"`
+	BankCode         *string                  `json:"bank_code,omitempty" gorm:"column:bank_code" db:"bank_code"`
+	AccountNumber    string                   `json:"account_number" gorm:"column:account_number;not null;serializer:pii" db:"account_number"`
+	Amount           int64                    `json:"amount" gorm:"column:amount;not null" db:"amount"`
+	CurrencyCode     string                   `json:"currency_code" gorm:"column:currency_code;not null;default:IDR" db:"currency_code"`
+	Status           PaymentInstructionStatus `json:"status" gorm:"column:status;not null;default:PENDING" db:"status"`
+	PaymentReference *string                  `json:"payment_reference,omitempty" gorm:"column:payment_reference" db:"payment_reference"`
+	PaidAt           *time.Time               `json:"paid_at,omitempty" gorm:"column:paid_at" db:"paid_at"`
+	CreatedAt        time.Time                `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+}
+
+func (PaymentInstruction) TableName() string {
+	return "claim_payment_instructions"
+}
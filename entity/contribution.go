@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContributionEntryType string
+
+const (
+	ContributionEntryTypeContribution ContributionEntryType = "CONTRIBUTION"
+	ContributionEntryTypeReversal     ContributionEntryType = "REVERSAL"
+	ContributionEntryTypePayout       ContributionEntryType = "PAYOUT"
+)
+
+type ContributionImportStatus string
+
+const (
+	ContributionImportStatusPosted   ContributionImportStatus = "POSTED"
+	ContributionImportStatusRejected ContributionImportStatus = "REJECTED"
+)
+
+// ContributionLedgerEntry is an append-only movement on a participant's
+// pension account. Amounts are in minor currency units; the balance columns
+// hold the running totals after this entry was posted.
+type ContributionLedgerEntry struct {
+	ID              uuid.UUID             `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	EntryNumber     int64                 `json:"entry_number" gorm:"column:entry_number;->" db:"entry_number"`
+	TenantID        uuid.UUID             `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
+	ProductID       uuid.UUID             `json:"product_id" gorm:"column:product_id;not null" db:"product_id"`
+	ParticipantID   uuid.UUID             `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
+	BatchID         *uuid.UUID            `json:"batch_id,omitempty" gorm:"column:batch_id" db:"batch_id"`
+	EntryType       ContributionEntryType `json:"entry_type" gorm:"column:entry_type;not null" db:"entry_type"`
+	Period          time.Time             `json:"period" gorm:"column:period;type:date;not null" db:"period"`
+	PostingDate     time.Time             `json:"posting_date" gorm:"column:posting_date;type:date;not null" db:"posting_date"`
+	EmployerAmount  int64                 `json:"employer_amount" gorm:"column:employer_amount;not null" db:"employer_amount"`
+	EmployeeAmount  int64                 `json:"employee_amount" gorm:"column:employee_amount;not null" db:"employee_amount"`
+	EmployerBalance int64                 `json:"employer_balance" gorm:"column:employer_balance;not null" db:"employer_balance"`
+	EmployeeBalance int64                 `json:"employee_balance" gorm:"column:employee_balance;not null" db:"employee_balance"`
+	ReversesEntryID *uuid.UUID            `json:"reverses_entry_id,omitempty" gorm:"column:reverses_entry_id" db:"reverses_entry_id"`
+	Reference       *string               `json:"reference,omitempty" gorm:"column:reference" db:"reference"`
+	Reason          *string               `json:"reason,omitempty" gorm:"column:reason" db:"reason"`
+	CreatedBy       uuid.UUID             `json:"created_by" gorm:"column:created_by;not null" db:"created_by"`
+	CreatedAt       time.Time             `json:"created_at" gorm:"column:created_at" db:"created_at"`
+}
+
+func (ContributionLedgerEntry) TableName() string {
+	return "contribution_ledger_entries"
+}
+
+func (e *ContributionLedgerEntry) TotalAmount() int64 {
+	return e.EmployerAmount + e.EmployeeAmount
+}
+
+func (e *ContributionLedgerEntry) TotalBalance() int64 {
+	return e.EmployerBalance + e.EmployeeBalance
+}
+
+func (e *ContributionLedgerEntry) CanBeReversed() bool {
+	return e.EntryType == ContributionEntryTypeContribution
+}
+
+type ContributionImportRowError struct {
+	Row            int    `json:"row"`
+	EmployeeNumber string `json:"employee_number,omitempty"`
+	Message        string `json:"message"`
+}
+
+type ContributionImportBatch struct {
+	ID                  uuid.UUID                    `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	TenantID            uuid.UUID                    `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
+	ProductID           uuid.UUID                    `json:"product_id" gorm:"column:product_id;not null" db:"product_id"`
+	Period              time.Time                    `json:"period" gorm:"column:period;type:date;not null" db:"period"`
+	PostingDate         time.Time                    `json:"posting_date" gorm:"column:posting_date;type:date;not null" db:"posting_date"`
+	FileName            string                       `json:"file_name" gorm:"column:file_name;not null" db:"file_name"`
+	FileChecksum        string                       `json:"file_checksum" gorm:"column:file_checksum;not null" db:"file_checksum"`
+	Status              ContributionImportStatus     `json:"status" gorm:"column:status;not null" db:"status"`
+	TotalRows           int                          `json:"total_rows" gorm:"column:total_rows;not null" db:"total_rows"`
+	PostedRows          int                          `json:"posted_rows" gorm:"column:posted_rows;not null" db:"posted_rows"`
+	TotalEmployerAmount int64                        `json:"total_employer_amount" gorm:"column:total_employer_amount;not null" db:"total_employer_amount"`
+	TotalEmployeeAmount int64                        `json:"total_employee_amount" gorm:"column:total_employee_amount;not null" db:"total_employee_amount"`
+	RowErrors           []ContributionImportRowError `json:"row_errors" gorm:"column:row_errors;type:jsonb;serializer:json" db:"row_errors"`
+	ImportedBy          uuid.UUID                    `json:"imported_by" gorm:"column:imported_by;not null" db:"imported_by"`
+	CreatedAt           time.Time                    `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt           time.Time                    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+}
+
+func (ContributionImportBatch) TableName() string {
+	return "contribution_import_batches"
+}
+
+func (b *ContributionImportBatch) IsPosted() bool {
+	return b.Status == ContributionImportStatusPosted
+}
@@ -3,29 +3,106 @@ package entity
 import (
 	"time"
 
+	"erp-service/pkg/envelope"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+type FileScanStatus string
+
+const (
+	FileScanStatusPending  FileScanStatus = "PENDING_SCAN"
+	FileScanStatusClean    FileScanStatus = "CLEAN"
+	FileScanStatusInfected FileScanStatus = "INFECTED"
+	FileScanStatusFailed   FileScanStatus = "SCAN_FAILED"
+)
+
+type FileProcessingStatus string
+
+const (
+	FileProcessingStatusPending FileProcessingStatus = "PENDING"
+	FileProcessingStatusDone    FileProcessingStatus = "DONE"
+	FileProcessingStatusSkipped FileProcessingStatus = "SKIPPED"
+	FileProcessingStatusFailed  FileProcessingStatus = "FAILED"
+)
+
 type File struct {
-	ID                   uuid.UUID      `gorm:"column:id;primaryKey;type:uuid;default:uuidv7()"`
-	TenantID             uuid.UUID      `gorm:"column:tenant_id;not null"`
-	ProductID            uuid.UUID      `gorm:"column:product_id;not null"`
-	UploadedBy           uuid.UUID      `gorm:"column:uploaded_by;not null"`
-	Bucket               string         `gorm:"column:bucket;not null"       json:"-"`
-	StorageKey           string         `gorm:"column:storage_key;not null"  json:"-"`
-	OriginalName         string         `gorm:"column:original_name;not null"`
-	ContentType          string         `gorm:"column:content_type;not null"`
-	SizeBytes            int64          `gorm:"column:size_bytes;not null;default:0"`
-	ExpiresAt            *time.Time     `gorm:"column:expires_at"`
-	ClaimedAt            *time.Time     `gorm:"column:claimed_at"`
-	FailedDeleteAttempts int            `gorm:"column:failed_delete_attempts;not null;default:0"`
-	Version              int            `gorm:"column:version;not null;default:1"`
-	CreatedAt            time.Time      `gorm:"column:created_at"`
-	UpdatedAt            time.Time      `gorm:"column:updated_at"`
-	DeletedAt            gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	ID                   uuid.UUID            `gorm:"column:id;primaryKey;type:uuid;default:uuidv7()"`
+	TenantID             uuid.UUID            `gorm:"column:tenant_id;not null"`
+	ProductID            uuid.UUID            `gorm:"column:product_id;not null"`
+	ParticipantID        *uuid.UUID           `gorm:"column:participant_id"`
+	UploadedBy           uuid.UUID            `gorm:"column:uploaded_by;not null"`
+	Bucket               string               `gorm:"column:bucket;not null"       json:"-"`
+	StorageKey           string               `gorm:"column:storage_key;not null"  json:"-"`
+	OriginalName         string               `gorm:"column:original_name;not null"`
+	ContentType          string               `gorm:"column:content_type;not null"`
+	SizeBytes            int64                `gorm:"column:size_bytes;not null;default:0"`
+	ExpiresAt            *time.Time           `gorm:"column:expires_at"`
+	ClaimedAt            *time.Time           `gorm:"column:claimed_at"`
+	FailedDeleteAttempts int                  `gorm:"column:failed_delete_attempts;not null;default:0"`
+	ScanStatus           FileScanStatus       `gorm:"column:scan_status;not null;default:PENDING_SCAN"`
+	ScanSignature        *string              `gorm:"column:scan_signature"`
+	ScannedAt            *time.Time           `gorm:"column:scanned_at"`
+	ScanClaimedAt        *time.Time           `gorm:"column:scan_claimed_at"`
+	ScanAttempts         int                  `gorm:"column:scan_attempts;not null;default:0"`
+	ProcessingStatus     FileProcessingStatus `gorm:"column:processing_status;not null;default:PENDING"`
+	ProcessClaimedAt     *time.Time           `gorm:"column:process_claimed_at"`
+	ProcessAttempts      int                  `gorm:"column:process_attempts;not null;default:0"`
+	DerivedFromFileID    *uuid.UUID           `gorm:"column:derived_from_file_id"`
+	ThumbnailFileID      *uuid.UUID           `gorm:"column:thumbnail_file_id"`
+	EncryptionKeyID      *string              `gorm:"column:encryption_key_id"     json:"-"`
+	WrappedDataKey       *string              `gorm:"column:wrapped_data_key"      json:"-"`
+	DataKeyRewrappedAt   *time.Time           `gorm:"column:data_key_rewrapped_at" json:"-"`
+	DocumentType         *string              `gorm:"column:document_type"`
+	LegalHold            bool                 `gorm:"column:legal_hold;not null;default:false"`
+	LegalHoldReason      *string              `gorm:"column:legal_hold_reason"`
+	LegalHoldSetBy       *uuid.UUID           `gorm:"column:legal_hold_set_by"`
+	LegalHoldSetAt       *time.Time           `gorm:"column:legal_hold_set_at"`
+	PurgeClaimedAt       *time.Time           `gorm:"column:purge_claimed_at"`
+	Version              int                  `gorm:"column:version;not null;default:1"`
+	CreatedAt            time.Time            `gorm:"column:created_at"`
+	UpdatedAt            time.Time            `gorm:"column:updated_at"`
+	DeletedAt            gorm.DeletedAt       `gorm:"column:deleted_at;index"`
 }
 
 func (File) TableName() string {
 	return "files"
 }
+
+func (f *File) IsClean() bool {
+	return f.ScanStatus == FileScanStatusClean
+}
+
+func (f *File) IsInfected() bool {
+	return f.ScanStatus == FileScanStatusInfected
+}
+
+// IsScanFailed reports whether the scanner gave up on the file after
+// MaxScanAttempts; its content is never served and it has to be replaced.
+func (f *File) IsScanFailed() bool {
+	return f.ScanStatus == FileScanStatusFailed
+}
+
+// Envelope returns the key material needed to decrypt the stored object, or
+// nil for files stored before envelope encryption.
+func (f *File) Envelope() *envelope.Envelope {
+	if f.EncryptionKeyID == nil || f.WrappedDataKey == nil {
+		return nil
+	}
+	return &envelope.Envelope{KeyID: *f.EncryptionKeyID, WrappedKey: *f.WrappedDataKey}
+}
+
+// SetEnvelope records the key material of a freshly encrypted object.
+func (f *File) SetEnvelope(env *envelope.Envelope) {
+	if env == nil {
+		f.EncryptionKeyID, f.WrappedDataKey = nil, nil
+		return
+	}
+	keyID, wrapped := env.KeyID, env.WrappedKey
+	f.EncryptionKeyID, f.WrappedDataKey = &keyID, &wrapped
+}
+
+func (f *File) IsDerived() bool {
+	return f.DerivedFromFileID != nil
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RetentionEvent string
+
+const (
+	RetentionEventUploaded              RetentionEvent = "UPLOADED"
+	RetentionEventParticipantRejected   RetentionEvent = "PARTICIPANT_REJECTED"
+	RetentionEventParticipantTerminated RetentionEvent = "PARTICIPANT_TERMINATED"
+	RetentionEventParticipantDeleted    RetentionEvent = "PARTICIPANT_DELETED"
+)
+
+// AnyDocumentType makes a retention policy apply to every document type
+// that has no policy of its own for the same event.
+const AnyDocumentType = "*"
+
+// FileRetentionPolicy purges a tenant's permanent files of DocumentType
+// RetentionDays after RetentionEvent. PARTICIPANT_TERMINATED counts from
+// the participant leaving the fund for any reason: termination, retirement,
+// death or transfer out.
+type FileRetentionPolicy struct {
+	ID             uuid.UUID      `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()"`
+	TenantID       uuid.UUID      `json:"tenant_id" gorm:"column:tenant_id;not null"`
+	DocumentType   string         `json:"document_type" gorm:"column:document_type;not null"`
+	RetentionEvent RetentionEvent `json:"retention_event" gorm:"column:retention_event;not null"`
+	RetentionDays  int            `json:"retention_days" gorm:"column:retention_days;not null"`
+	CreatedBy      uuid.UUID      `json:"created_by" gorm:"column:created_by;not null"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (FileRetentionPolicy) TableName() string {
+	return "file_retention_policies"
+}
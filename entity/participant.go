@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"time"
 
+	"erp-service/pkg/pii"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ParticipantStatus string
@@ -14,8 +17,53 @@ const (
 	ParticipantStatusPendingApproval ParticipantStatus = "PENDING_APPROVAL"
 	ParticipantStatusApproved        ParticipantStatus = "APPROVED"
 	ParticipantStatusRejected        ParticipantStatus = "REJECTED"
+	ParticipantStatusActive          ParticipantStatus = "ACTIVE"
+	ParticipantStatusSuspended       ParticipantStatus = "SUSPENDED"
+	ParticipantStatusTerminated      ParticipantStatus = "TERMINATED"
+	ParticipantStatusRetired         ParticipantStatus = "RETIRED"
+	ParticipantStatusDeceased        ParticipantStatus = "DECEASED"
+	ParticipantStatusTransferredOut  ParticipantStatus = "TRANSFERRED_OUT"
 )
 
+// participantStatusTransitions lists every allowed move out of a status.
+// Statuses absent from the map (DECEASED, TRANSFERRED_OUT) are final.
+var participantStatusTransitions = map[ParticipantStatus][]ParticipantStatus{
+	ParticipantStatusDraft:           {ParticipantStatusPendingApproval},
+	ParticipantStatusRejected:        {ParticipantStatusPendingApproval},
+	ParticipantStatusPendingApproval: {ParticipantStatusApproved, ParticipantStatusRejected},
+	ParticipantStatusApproved:        {ParticipantStatusActive},
+	ParticipantStatusActive: {
+		ParticipantStatusSuspended,
+		ParticipantStatusTerminated,
+		ParticipantStatusRetired,
+		ParticipantStatusDeceased,
+		ParticipantStatusTransferredOut,
+	},
+	ParticipantStatusSuspended: {
+		ParticipantStatusActive,
+		ParticipantStatusTerminated,
+		ParticipantStatusRetired,
+		ParticipantStatusDeceased,
+		ParticipantStatusTransferredOut,
+	},
+	ParticipantStatusTerminated: {ParticipantStatusDeceased},
+	ParticipantStatusRetired:    {ParticipantStatusDeceased},
+}
+
+func (s ParticipantStatus) CanTransitionTo(to ParticipantStatus) bool {
+	for _, allowed := range participantStatusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s ParticipantStatus) IsFinal() bool {
+	_, ok := participantStatusTransitions[s]
+	return !ok
+}
+
 type Participant struct {
 	ID        uuid.UUID  `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
 	TenantID  uuid.UUID  `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
@@ -30,7 +78,8 @@ type Participant struct {
 	Citizenship   *string    `json:"citizenship,omitempty" gorm:"column:citizenship" db:"citizenship"`
 	Religion      *string    `json:"religion,omitempty" gorm:"column:religion" db:"religion"`
 
-	KTPNumber      *string `json:"ktp_number,omitempty" gorm:"column:ktp_number" db:"ktp_number"`
+	KTPNumber      *string `json:"ktp_number,omitempty" gorm:"column:ktp_number;serializer:pii" db:"ktp_number"`
+	KTPNumberIndex *string `json:"-" gorm:"column:ktp_number_bidx" db:"ktp_number_bidx"`
 	EmployeeNumber *string `json:"employee_number,omitempty" gorm:"column:employee_number" db:"employee_number"`
 	PhoneNumber    *string `json:"phone_number,omitempty" gorm:"column:phone_number" db:"phone_number"`
 
@@ -45,6 +94,14 @@ type Participant struct {
 	RejectedBy      *uuid.UUID        `json:"rejected_by,omitempty" gorm:"column:rejected_by" db:"rejected_by"`
 	RejectedAt      *time.Time        `json:"rejected_at,omitempty" gorm:"column:rejected_at" db:"rejected_at"`
 	RejectionReason *string           `json:"rejection_reason,omitempty" gorm:"column:rejection_reason" db:"rejection_reason"`
+	MergedIntoID    *uuid.UUID        `json:"merged_into_id,omitempty" gorm:"column:merged_into_id" db:"merged_into_id"`
+
+	StatusEffectiveDate *time.Time `json:"status_effective_date,omitempty" gorm:"column:status_effective_date" db:"status_effective_date"`
+
+	LegalHold       bool       `json:"legal_hold" gorm:"column:legal_hold;not null;default:false" db:"legal_hold"`
+	LegalHoldReason *string    `json:"legal_hold_reason,omitempty" gorm:"column:legal_hold_reason" db:"legal_hold_reason"`
+	LegalHoldSetBy  *uuid.UUID `json:"legal_hold_set_by,omitempty" gorm:"column:legal_hold_set_by" db:"legal_hold_set_by"`
+	LegalHoldSetAt  *time.Time `json:"legal_hold_set_at,omitempty" gorm:"column:legal_hold_set_at" db:"legal_hold_set_at"`
 
 	Version   int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
 	CreatedAt time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
@@ -56,6 +113,12 @@ func (Participant) TableName() string {
 	return "participants"
 }
 
+// BeforeSave keeps the KTP blind index in step with the encrypted number.
+func (p *Participant) BeforeSave(tx *gorm.DB) (err error) {
+	p.KTPNumberIndex, err = pii.BlindIndexPtr(tx.Statement.Context, p.KTPNumber)
+	return err
+}
+
 func (p *Participant) IsDraft() bool {
 	return p.Status == ParticipantStatusDraft
 }
@@ -88,6 +151,60 @@ func (p *Participant) CanBeRejected() bool {
 	return p.Status == ParticipantStatusPendingApproval
 }
 
+func (p *Participant) CanBeActivated() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusActive)
+}
+
+func (p *Participant) CanBeSuspended() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusSuspended)
+}
+
+func (p *Participant) CanBeTerminated() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusTerminated)
+}
+
+func (p *Participant) CanBeRetired() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusRetired)
+}
+
+func (p *Participant) CanBeMarkedDeceased() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusDeceased)
+}
+
+func (p *Participant) CanBeTransferredOut() bool {
+	return p.Status.CanTransitionTo(ParticipantStatusTransferredOut)
+}
+
+// IsEnrolled reports whether the participant has been activated in the fund
+// and has not yet reached a final status.
+func (p *Participant) IsEnrolled() bool {
+	switch p.Status {
+	case ParticipantStatusActive, ParticipantStatusSuspended,
+		ParticipantStatusTerminated, ParticipantStatusRetired:
+		return true
+	}
+	return false
+}
+
+func (p *Participant) CanReceiveContributions() bool {
+	return p.Status == ParticipantStatusActive
+}
+
+// CanBeMerged reports whether the participant can be merged away into
+// another record. Only records that were never activated qualify, so no
+// contribution or claim can point at them.
+func (p *Participant) CanBeMerged() bool {
+	switch p.Status {
+	case ParticipantStatusDraft, ParticipantStatusRejected, ParticipantStatusApproved:
+		return true
+	}
+	return false
+}
+
+func (p *Participant) CanAbsorbMerge() bool {
+	return p.Status != ParticipantStatusPendingApproval && !p.Status.IsFinal()
+}
+
 type ParticipantStatusHistory struct {
 	ID            uuid.UUID `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
 	ParticipantID uuid.UUID `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
@@ -96,8 +213,13 @@ type ParticipantStatusHistory struct {
 	ChangedBy     uuid.UUID `json:"changed_by" gorm:"column:changed_by;not null" db:"changed_by"`
 	Reason        *string   `json:"reason,omitempty" gorm:"column:reason" db:"reason"`
 	ChangedAt     time.Time `json:"changed_at" gorm:"column:changed_at;not null" db:"changed_at"`
-	CreatedAt     time.Time `json:"created_at" gorm:"column:created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+
+	EffectiveDate    *time.Time        `json:"effective_date,omitempty" gorm:"column:effective_date" db:"effective_date"`
+	SupportingFileID *uuid.UUID        `json:"supporting_file_id,omitempty" gorm:"column:supporting_file_id" db:"supporting_file_id"`
+	Details          map[string]string `json:"details,omitempty" gorm:"column:details;type:jsonb;serializer:json" db:"details"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
 }
 
 func (ParticipantStatusHistory) TableName() string {
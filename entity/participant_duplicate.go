@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DuplicateCandidateStatus string
+
+const (
+	DuplicateCandidateStatusPending   DuplicateCandidateStatus = "PENDING"
+	DuplicateCandidateStatusDismissed DuplicateCandidateStatus = "DISMISSED"
+	DuplicateCandidateStatusMerged    DuplicateCandidateStatus = "MERGED"
+)
+
+type ParticipantDuplicateCandidate struct {
+	ID                   uuid.UUID                `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	TenantID             uuid.UUID                `json:"tenant_id" gorm:"column:tenant_id;not null" db:"tenant_id"`
+	ProductID            uuid.UUID                `json:"product_id" gorm:"column:product_id;not null" db:"product_id"`
+	ParticipantID        uuid.UUID                `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
+	MatchedParticipantID uuid.UUID                `json:"matched_participant_id" gorm:"column:matched_participant_id;not null" db:"matched_participant_id"`
+	MatchedTenantID      uuid.UUID                `json:"matched_tenant_id" gorm:"column:matched_tenant_id;not null" db:"matched_tenant_id"`
+	Score                float64                  `json:"score" gorm:"column:score;not null" db:"score"`
+	MatchedFields        []string                 `json:"matched_fields" gorm:"column:matched_fields;type:jsonb;serializer:json" db:"matched_fields"`
+	Status               DuplicateCandidateStatus `json:"status" gorm:"column:status;not null;default:PENDING" db:"status"`
+	ReviewedBy           *uuid.UUID               `json:"reviewed_by,omitempty" gorm:"column:reviewed_by" db:"reviewed_by"`
+	ReviewedAt           *time.Time               `json:"reviewed_at,omitempty" gorm:"column:reviewed_at" db:"reviewed_at"`
+	ReviewNote           *string                  `json:"review_note,omitempty" gorm:"column:review_note" db:"review_note"`
+	Version              int                      `json:"version" gorm:"column:version;not null;default:1" db:"version"`
+	CreatedAt            time.Time                `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+}
+
+func (ParticipantDuplicateCandidate) TableName() string {
+	return "participant_duplicate_candidates"
+}
+
+func (c *ParticipantDuplicateCandidate) IsPending() bool {
+	return c.Status == DuplicateCandidateStatusPending
+}
+
+func (c *ParticipantDuplicateCandidate) Involves(participantID uuid.UUID) bool {
+	return c.ParticipantID == participantID || c.MatchedParticipantID == participantID
+}
@@ -35,7 +35,8 @@ type ParticipantBeneficiary struct {
 	FamilyCardPhotoFileID   *uuid.UUID   `json:"family_card_photo_file_id,omitempty" gorm:"column:family_card_photo_file_id" db:"family_card_photo_file_id"`
 	BankBookPhotoFilePath   *string      `json:"bank_book_photo_file_path,omitempty" gorm:"column:bank_book_photo_file_path" db:"bank_book_photo_file_path"`
 	BankBookPhotoFileID     *uuid.UUID   `json:"bank_book_photo_file_id,omitempty" gorm:"column:bank_book_photo_file_id" db:"bank_book_photo_file_id"`
-	AccountNumber           *string      `json:"account_number,omitempty" gorm:"column:account_number" db:"account_number"`
+	BankCode                *string      `json:"bank_code,omitempty" gorm:"column:bank_code" db:"bank_code"`
+	AccountNumber           *string      `json:"account_number,omitempty" gorm:"column:account_number;serializer:pii" db:"account_number"`
 	Version                int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
 	CreatedAt              time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
 	UpdatedAt              time.Time    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
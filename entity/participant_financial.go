@@ -4,26 +4,68 @@ import (
 	"database/sql"
 	"time"
 
+	"erp-service/pkg/pii"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BankAccountVerificationStatus string
+
+const (
+	BankAccountVerificationUnverified    BankAccountVerificationStatus = "UNVERIFIED"
+	BankAccountVerificationVerified      BankAccountVerificationStatus = "VERIFIED"
+	BankAccountVerificationMismatch      BankAccountVerificationStatus = "MISMATCH"
+	BankAccountVerificationNotFound      BankAccountVerificationStatus = "NOT_FOUND"
+	BankAccountVerificationProviderError BankAccountVerificationStatus = "PROVIDER_ERROR"
 )
 
 type ParticipantBankAccount struct {
-	ID                uuid.UUID    `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
-	ParticipantID     uuid.UUID    `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
-	BankCode          string       `json:"bank_code" gorm:"column:bank_code;not null" db:"bank_code"`
-	AccountNumber     string       `json:"account_number" gorm:"column:account_number;not null" db:"account_number"`
-	AccountHolderName string       `json:"account_holder_name" gorm:"column:account_holder_name;not null" db:"account_holder_name"`
-	AccountType       *string      `json:"account_type,omitempty" gorm:"column:account_type" db:"account_type"`
-	CurrencyCode      string       `json:"currency_code" gorm:"column:currency_code;not null;default:IDR" db:"currency_code"`
-	IsPrimary         bool         `json:"is_primary" gorm:"column:is_primary;not null;default:false" db:"is_primary"`
-	IssueDate         *time.Time   `json:"issue_date,omitempty" gorm:"column:issue_date" db:"issue_date"`
-	ExpiryDate        *time.Time   `json:"expiry_date,omitempty" gorm:"column:expiry_date" db:"expiry_date"`
-	Version           int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
-	CreatedAt         time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
-	UpdatedAt         time.Time    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
-	DeletedAt         sql.NullTime `json:"deleted_at,omitempty" gorm:"column:deleted_at" db:"deleted_at"`
+	ID                 uuid.UUID  `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	ParticipantID      uuid.UUID  `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
+	BankCode           string     `json:"bank_code" gorm:"column:bank_code;not null" db:"bank_code"`
+	AccountNumber      string     `json:"account_number" gorm:"column:account_number;not null;serializer:pii" db:"account_number"`
+	AccountNumberIndex *string    `json:"-" gorm:"column:account_number_bidx" db:"account_number_bidx"`
+	AccountHolderName  string     `json:"account_holder_name" gorm:"column:account_holder_name;not null" db:"account_holder_name"`
+	AccountType        *string    `json:"account_type,omitempty" gorm:"column:account_type" db:"account_type"`
+	CurrencyCode       string     `json:"currency_code" gorm:"column:currency_code;not null;default:IDR" db:"currency_code"`
+	IsPrimary          bool       `json:"is_primary" gorm:"column:is_primary;not null;default:false" db:"is_primary"`
+	IssueDate          *time.Time `json:"issue_date,omitempty" gorm:"column:issue_date" db:"issue_date"`
+	ExpiryDate         *time.Time `json:"expiry_date,omitempty" gorm:"column:expiry_date" db:"expiry_date"`
+
+	VerificationStatus    BankAccountVerificationStatus `json:"verification_status" gorm:"column:verification_status;not null;default:UNVERIFIED" db:"verification_status"`
+	VerificationProvider  *string                       `json:"verification_provider,omitempty" gorm:"column:verification_provider" db:"verification_provider"`
+	VerifiedHolderName    *string                       `json:"verified_holder_name,omitempty" gorm:"column:verified_holder_name" db:"verified_holder_name"`
+	VerificationScore     *float64                      `json:"verification_score,omitempty" gorm:"column:verification_score" db:"verification_score"`
+	VerificationMessage   *string                       `json:"verification_message,omitempty" gorm:"column:verification_message" db:"verification_message"`
+	VerificationCheckedAt *time.Time                    `json:"verification_checked_at,omitempty" gorm:"column:verification_checked_at" db:"verification_checked_at"`
+
+	Version   int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
+	CreatedAt time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at,omitempty" gorm:"column:deleted_at" db:"deleted_at"`
 }
 
 func (ParticipantBankAccount) TableName() string {
 	return "participant_bank_accounts"
 }
+
+func (a *ParticipantBankAccount) IsVerified() bool {
+	return a.VerificationStatus == BankAccountVerificationVerified
+}
+
+// ResetVerification discards the previous inquiry result; it is called
+// whenever the bank, number or holder name changes.
+func (a *ParticipantBankAccount) ResetVerification() {
+	a.VerificationStatus = BankAccountVerificationUnverified
+	a.VerificationProvider = nil
+	a.VerifiedHolderName = nil
+	a.VerificationScore = nil
+	a.VerificationMessage = nil
+	a.VerificationCheckedAt = nil
+}
+
+func (a *ParticipantBankAccount) BeforeSave(tx *gorm.DB) (err error) {
+	a.AccountNumberIndex, err = pii.BlindIndexPtr(tx.Statement.Context, &a.AccountNumber)
+	return err
+}
@@ -4,25 +4,34 @@ import (
 	"database/sql"
 	"time"
 
+	"erp-service/pkg/pii"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ParticipantIdentity struct {
-	ID                uuid.UUID    `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
-	ParticipantID     uuid.UUID    `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
-	IdentityType      string       `json:"identity_type" gorm:"column:identity_type;not null" db:"identity_type"`
-	IdentityNumber    string       `json:"identity_number" gorm:"column:identity_number;not null" db:"identity_number"`
-	IdentityAuthority *string      `json:"identity_authority,omitempty" gorm:"column:identity_authority" db:"identity_authority"`
-	IssueDate         *time.Time   `json:"issue_date,omitempty" gorm:"column:issue_date" db:"issue_date"`
-	ExpiryDate        *time.Time   `json:"expiry_date,omitempty" gorm:"column:expiry_date" db:"expiry_date"`
-	PhotoFilePath     *string      `json:"photo_file_path,omitempty" gorm:"column:photo_file_path" db:"photo_file_path"`
-	PhotoFileID       *uuid.UUID   `json:"photo_file_id,omitempty" gorm:"column:photo_file_id" db:"photo_file_id"`
-	Version           int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
-	CreatedAt         time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
-	UpdatedAt         time.Time    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
-	DeletedAt         sql.NullTime `json:"deleted_at,omitempty" gorm:"column:deleted_at" db:"deleted_at"`
+	ID                  uuid.UUID    `json:"id" gorm:"column:id;primaryKey;type:uuid;default:uuidv7()" db:"id"`
+	ParticipantID       uuid.UUID    `json:"participant_id" gorm:"column:participant_id;not null" db:"participant_id"`
+	IdentityType        string       `json:"identity_type" gorm:"column:identity_type;not null" db:"identity_type"`
+	IdentityNumber      string       `json:"identity_number" gorm:"column:identity_number;not null;serializer:pii" db:"identity_number"`
+	IdentityNumberIndex *string      `json:"-" gorm:"column:identity_number_bidx" db:"identity_number_bidx"`
+	IdentityAuthority   *string      `json:"identity_authority,omitempty" gorm:"column:identity_authority" db:"identity_authority"`
+	IssueDate           *time.Time   `json:"issue_date,omitempty" gorm:"column:issue_date" db:"issue_date"`
+	ExpiryDate          *time.Time   `json:"expiry_date,omitempty" gorm:"column:expiry_date" db:"expiry_date"`
+	PhotoFilePath       *string      `json:"photo_file_path,omitempty" gorm:"column:photo_file_path" db:"photo_file_path"`
+	PhotoFileID         *uuid.UUID   `json:"photo_file_id,omitempty" gorm:"column:photo_file_id" db:"photo_file_id"`
+	Version             int          `json:"version" gorm:"column:version;not null;default:1" db:"version"`
+	CreatedAt           time.Time    `json:"created_at" gorm:"column:created_at" db:"created_at"`
+	UpdatedAt           time.Time    `json:"updated_at" gorm:"column:updated_at" db:"updated_at"`
+	DeletedAt           sql.NullTime `json:"deleted_at,omitempty" gorm:"column:deleted_at" db:"deleted_at"`
 }
 
 func (ParticipantIdentity) TableName() string {
 	return "participant_identities"
 }
+
+func (i *ParticipantIdentity) BeforeSave(tx *gorm.DB) (err error) {
+	i.IdentityNumberIndex, err = pii.BlindIndexPtr(tx.Statement.Context, &i.IdentityNumber)
+	return err
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UploadSlot is an upload the client was allowed to send straight to object
+// storage. It becomes a File once completed; slots that expire uncompleted
+// are reaped by the file cleanup worker.
+type UploadSlot struct {
+	ID                   uuid.UUID      `gorm:"column:id;primaryKey;type:uuid;default:uuidv7()"`
+	TenantID             uuid.UUID      `gorm:"column:tenant_id;not null"`
+	ProductID            uuid.UUID      `gorm:"column:product_id;not null"`
+	ParticipantID        uuid.UUID      `gorm:"column:participant_id;not null"`
+	RequestedBy          uuid.UUID      `gorm:"column:requested_by;not null"`
+	FieldName            string         `gorm:"column:field_name;not null"`
+	Bucket               string         `gorm:"column:bucket;not null"`
+	StorageKey           string         `gorm:"column:storage_key;not null"`
+	OriginalName         string         `gorm:"column:original_name;not null"`
+	ContentType          string         `gorm:"column:content_type;not null"`
+	MaxSizeBytes         int64          `gorm:"column:max_size_bytes;not null"`
+	ExpiresAt            time.Time      `gorm:"column:expires_at;not null"`
+	CompletedAt          *time.Time     `gorm:"column:completed_at"`
+	FileID               *uuid.UUID     `gorm:"column:file_id"`
+	ClaimedAt            *time.Time     `gorm:"column:claimed_at"`
+	CompletingAt         *time.Time     `gorm:"column:completing_at"`
+	FailedDeleteAttempts int            `gorm:"column:failed_delete_attempts;not null;default:0"`
+	CreatedAt            time.Time      `gorm:"column:created_at"`
+	UpdatedAt            time.Time      `gorm:"column:updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (UploadSlot) TableName() string {
+	return "upload_slots"
+}
+
+func (s *UploadSlot) IsCompleted() bool {
+	return s.CompletedAt != nil
+}
+
+func (s *UploadSlot) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
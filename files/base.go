@@ -1,11 +1,19 @@
 package files
 
-import "go.uber.org/zap"
+import (
+	"erp-service/pkg/logger"
+
+	"go.uber.org/zap"
+)
 
 type usecase struct {
 	fileRepo    FileRepository
+	slotRepo    UploadSlotRepository
 	fileStorage FileStorageAdapter
+	scanner     MalwareScanner
+	keys        KeyRewrapper
 	txManager   TransactionManager
 	logger      *zap.Logger
+	auditLogger logger.AuditLogger
 	cfg         Config
 }
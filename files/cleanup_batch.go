@@ -38,5 +38,7 @@ func (uc *usecase) CleanupBatch(ctx context.Context) (BatchResult, error) {
 		result.Processed++
 	}
 
+	result.SlotsReaped, result.SlotsFailed = uc.cleanupUploadSlots(ctx)
+
 	return result, nil
 }
@@ -0,0 +1,65 @@
+package files
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+// cleanupUploadSlots reaps upload slots that were issued but never completed,
+// deleting whatever the client may have put in storage. Failures are logged
+// and do not fail the file batch.
+func (uc *usecase) cleanupUploadSlots(ctx context.Context) (reaped, failed int) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.slotRepo.ReleaseStaleClaimsOlderThan(txCtx, uc.cfg.StaleClaimAge)
+	}); err != nil {
+		uc.logger.Warn("failed to release stale upload slot claims", zap.Error(err))
+	}
+
+	var slots []*entity.UploadSlot
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		slots, err = uc.slotRepo.ClaimExpired(txCtx, uc.cfg.BatchSize)
+		return err
+	}); err != nil {
+		uc.logger.Error("failed to claim expired upload slots", zap.Error(err))
+		return 0, 0
+	}
+
+	for _, slot := range slots {
+		if err := uc.reapUploadSlot(ctx, slot); err != nil {
+			uc.logger.Warn("failed to reap upload slot",
+				zap.String("upload_id", slot.ID.String()),
+				zap.Error(err),
+			)
+			failed++
+			continue
+		}
+		reaped++
+	}
+	return reaped, failed
+}
+
+func (uc *usecase) reapUploadSlot(ctx context.Context, slot *entity.UploadSlot) error {
+	if err := uc.fileStorage.DeleteFile(ctx, slot.Bucket, slot.StorageKey); err != nil {
+		if incrErr := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return uc.slotRepo.IncrementFailedAttempts(txCtx, slot.ID)
+		}); incrErr != nil {
+			uc.logger.Error("failed to increment upload slot failed attempts",
+				zap.String("upload_id", slot.ID.String()),
+				zap.Error(incrErr),
+			)
+		}
+		return fmt.Errorf("delete from storage: %w", err)
+	}
+
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.slotRepo.SoftDelete(txCtx, slot.ID)
+	}); err != nil {
+		return fmt.Errorf("soft-delete upload slot: %w", err)
+	}
+	return nil
+}
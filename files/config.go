@@ -5,11 +5,39 @@ import "time"
 type Config struct {
 	BatchSize     int
 	StaleClaimAge time.Duration
+
+	ScanBatchSize    int
+	MaxScanAttempts  int
+	QuarantineBucket string
+
+	ProcessBatchSize   int
+	MaxProcessAttempts int
+	ImageMaxDimension  int
+	ThumbnailDimension int
+
+	RewrapBatchSize int
+	RewrapAge       time.Duration
+
+	PurgeBatchSize int
 }
 
 func DefaultConfig() Config {
 	return Config{
 		BatchSize:     50,
 		StaleClaimAge: 5 * time.Minute,
+
+		ScanBatchSize:    20,
+		MaxScanAttempts:  10,
+		QuarantineBucket: "quarantine",
+
+		ProcessBatchSize:   10,
+		MaxProcessAttempts: 3,
+		ImageMaxDimension:  2048,
+		ThumbnailDimension: 320,
+
+		RewrapBatchSize: 100,
+		RewrapAge:       30 * 24 * time.Hour,
+
+		PurgeBatchSize: 50,
 	}
 }
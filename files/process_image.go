@@ -0,0 +1,137 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"erp-service/entity"
+	"erp-service/pkg/imaging"
+
+	"go.uber.org/zap"
+)
+
+// ProcessImage normalizes a claimed, scanned-clean image in place and stores
+// a JPEG thumbnail as a derived file. Non-image files are marked SKIPPED.
+// Failures count towards MaxProcessAttempts, after which the file is left
+// FAILED and served as uploaded.
+func (uc *usecase) ProcessImage(ctx context.Context, file *entity.File) (entity.FileProcessingStatus, error) {
+	if !imaging.Supported(file.ContentType) {
+		if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return uc.fileRepo.MarkProcessingSkipped(txCtx, file.ID)
+		}); err != nil {
+			return "", fmt.Errorf("mark processing skipped: %w", err)
+		}
+		return entity.FileProcessingStatusSkipped, nil
+	}
+
+	if err := uc.processImage(ctx, file); err != nil {
+		uc.recordProcessingFailure(ctx, file)
+		return "", err
+	}
+	return entity.FileProcessingStatusDone, nil
+}
+
+func (uc *usecase) processImage(ctx context.Context, file *entity.File) error {
+	body, err := uc.fileStorage.GetDecryptedObject(ctx, file.Bucket, file.StorageKey, file.Envelope())
+	if err != nil {
+		return fmt.Errorf("get object: %w", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("read object: %w", err)
+	}
+
+	result, err := imaging.Process(data, imaging.Options{
+		MaxDimension:       uc.cfg.ImageMaxDimension,
+		ThumbnailDimension: uc.cfg.ThumbnailDimension,
+	})
+	if err != nil {
+		return fmt.Errorf("process image: %w", err)
+	}
+
+	// Both objects go to new keys; the original is only removed after the
+	// record points at the normalized copy, so a failed attempt leaves the
+	// file readable and the next attempt overwrites the leftovers.
+	thumbnailKey := derivedStorageKey(file.StorageKey, "_thumb", result.ThumbnailType)
+	normalizedKey := derivedStorageKey(file.StorageKey, "_normalized", result.ContentType)
+	thumbnailEnv, err := uc.fileStorage.UploadEncryptedFile(ctx, file.TenantID, file.Bucket, thumbnailKey,
+		bytes.NewReader(result.Thumbnail), int64(len(result.Thumbnail)))
+	if err != nil {
+		return fmt.Errorf("upload thumbnail: %w", err)
+	}
+	imageEnv, err := uc.fileStorage.UploadEncryptedFile(ctx, file.TenantID, file.Bucket, normalizedKey,
+		bytes.NewReader(result.Image), int64(len(result.Image)))
+	if err != nil {
+		return fmt.Errorf("upload normalized image: %w", err)
+	}
+
+	thumbnail := &entity.File{
+		TenantID:          file.TenantID,
+		ProductID:         file.ProductID,
+		ParticipantID:     file.ParticipantID,
+		UploadedBy:        file.UploadedBy,
+		Bucket:            file.Bucket,
+		StorageKey:        thumbnailKey,
+		OriginalName:      thumbnailName(file.OriginalName),
+		ContentType:       result.ThumbnailType,
+		SizeBytes:         int64(len(result.Thumbnail)),
+		ExpiresAt:         file.ExpiresAt,
+		ScanStatus:        entity.FileScanStatusClean,
+		ScannedAt:         file.ScannedAt,
+		ProcessingStatus:  entity.FileProcessingStatusSkipped,
+		DerivedFromFileID: &file.ID,
+		DocumentType:      file.DocumentType,
+	}
+	thumbnail.SetEnvelope(thumbnailEnv)
+
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.fileRepo.Create(txCtx, thumbnail); err != nil {
+			return fmt.Errorf("create thumbnail record: %w", err)
+		}
+		if err := uc.fileRepo.MarkProcessed(txCtx, file.ID, normalizedKey, result.ContentType,
+			int64(len(result.Image)), imageEnv, thumbnail.ID); err != nil {
+			return fmt.Errorf("mark file processed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := uc.fileStorage.DeleteFile(ctx, file.Bucket, file.StorageKey); err != nil {
+		uc.logger.Warn("failed to delete original after image normalization",
+			zap.String("file_id", file.ID.String()),
+			zap.String("storage_key", file.StorageKey),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+func (uc *usecase) recordProcessingFailure(ctx context.Context, file *entity.File) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.RecordProcessingFailure(txCtx, file.ID, uc.cfg.MaxProcessAttempts)
+	}); err != nil {
+		uc.logger.Error("failed to record image processing failure",
+			zap.String("file_id", file.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func derivedStorageKey(storageKey, suffix, contentType string) string {
+	ext := ".jpg"
+	if contentType == imaging.ContentTypePNG {
+		ext = ".png"
+	}
+	return strings.TrimSuffix(storageKey, path.Ext(storageKey)) + suffix + ext
+}
+
+func thumbnailName(originalName string) string {
+	return strings.TrimSuffix(originalName, path.Ext(originalName)) + "_thumb.jpg"
+}
@@ -0,0 +1,47 @@
+package files
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+func (uc *usecase) ProcessImagesBatch(ctx context.Context) (ProcessBatchResult, error) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.ReleaseStaleProcessClaimsOlderThan(txCtx, uc.cfg.StaleClaimAge)
+	}); err != nil {
+		uc.logger.Warn("failed to release stale processing claims", zap.Error(err))
+	}
+
+	var files []*entity.File
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		files, err = uc.fileRepo.ClaimPendingProcessing(txCtx, uc.cfg.ProcessBatchSize, uc.cfg.MaxProcessAttempts)
+		return err
+	}); err != nil {
+		uc.logger.Error("failed to claim files pending processing", zap.Error(err))
+		return ProcessBatchResult{}, err
+	}
+
+	var result ProcessBatchResult
+	for _, file := range files {
+		status, err := uc.ProcessImage(ctx, file)
+		if err != nil {
+			uc.logger.Warn("failed to process image",
+				zap.String("file_id", file.ID.String()),
+				zap.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+		if status == entity.FileProcessingStatusSkipped {
+			result.Skipped++
+			continue
+		}
+		result.Processed++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package files
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+// PurgeBatch deletes permanent files whose retention period has ended.
+func (uc *usecase) PurgeBatch(ctx context.Context) (PurgeBatchResult, error) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.ReleaseStalePurgeClaimsOlderThan(txCtx, uc.cfg.StaleClaimAge)
+	}); err != nil {
+		uc.logger.Warn("failed to release stale purge claims", zap.Error(err))
+	}
+
+	var files []*entity.File
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		files, err = uc.fileRepo.ClaimDueForPurge(txCtx, uc.cfg.PurgeBatchSize)
+		return err
+	}); err != nil {
+		uc.logger.Error("failed to claim files due for purge", zap.Error(err))
+		return PurgeBatchResult{}, err
+	}
+
+	var result PurgeBatchResult
+	for _, file := range files {
+		if err := uc.PurgeFile(ctx, file); err != nil {
+			uc.logger.Warn("failed to purge file",
+				zap.String("file_id", file.ID.String()),
+				zap.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+		result.Purged++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package files
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// PurgeFile deletes a claimed file's objects, its derived files included,
+// and then hard-deletes the rows. A failure releases the claim so the next
+// batch retries; every attempt is audited.
+func (uc *usecase) PurgeFile(ctx context.Context, file *entity.File) error {
+	err := uc.purgeFile(ctx, file)
+	uc.auditPurge(ctx, file, err)
+	if err != nil {
+		if relErr := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return uc.fileRepo.ReleasePurgeClaim(txCtx, file.ID)
+		}); relErr != nil {
+			uc.logger.Error("failed to release purge claim",
+				zap.String("file_id", file.ID.String()),
+				zap.Error(relErr),
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *usecase) purgeFile(ctx context.Context, file *entity.File) error {
+	derived, err := uc.fileRepo.ListDerived(ctx, file.ID)
+	if err != nil {
+		return fmt.Errorf("list derived files: %w", err)
+	}
+
+	for _, f := range append(derived, file) {
+		if err := uc.fileStorage.DeleteFile(ctx, f.Bucket, f.StorageKey); err != nil {
+			return fmt.Errorf("delete %s from storage: %w", f.ID, err)
+		}
+	}
+
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.Purge(txCtx, file.ID)
+	}); err != nil {
+		return fmt.Errorf("delete file record: %w", err)
+	}
+	return nil
+}
+
+func (uc *usecase) auditPurge(ctx context.Context, file *entity.File, err error) {
+	event := logger.AuditEvent{
+		Domain:     "saving",
+		Action:     "file_purged",
+		ActorType:  "system",
+		TargetID:   file.ID.String(),
+		TargetType: "file",
+		TenantID:   file.TenantID.String(),
+		Success:    err == nil,
+		Metadata: map[string]any{
+			"product_id":  file.ProductID.String(),
+			"bucket":      file.Bucket,
+			"storage_key": file.StorageKey,
+			"created_at":  file.CreatedAt,
+		},
+	}
+	if file.ParticipantID != nil {
+		event.Metadata["participant_id"] = file.ParticipantID.String()
+	}
+	if file.DocumentType != nil {
+		event.Metadata["document_type"] = *file.DocumentType
+	}
+	if err != nil {
+		event.Reason = err.Error()
+	}
+	uc.auditLogger.Log(ctx, event)
+}
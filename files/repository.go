@@ -2,9 +2,11 @@ package files
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"erp-service/entity"
+	"erp-service/pkg/envelope"
 
 	"github.com/google/uuid"
 )
@@ -14,8 +16,46 @@ type FileRepository interface {
 	ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error
 	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+
+	ClaimPendingScan(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error)
+	ReleaseStaleScanClaimsOlderThan(ctx context.Context, age time.Duration) error
+	RecordScanFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error
+	MarkScanned(ctx context.Context, id uuid.UUID) error
+	MarkQuarantined(ctx context.Context, id uuid.UUID, bucket, storageKey, signature string) error
+
+	Create(ctx context.Context, file *entity.File) error
+	ClaimPendingProcessing(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error)
+	ReleaseStaleProcessClaimsOlderThan(ctx context.Context, age time.Duration) error
+	MarkProcessed(ctx context.Context, id uuid.UUID, storageKey, contentType string, sizeBytes int64, env *envelope.Envelope, thumbnailFileID uuid.UUID) error
+	MarkProcessingSkipped(ctx context.Context, id uuid.UUID) error
+	RecordProcessingFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error
+
+	ListDueForRewrap(ctx context.Context, rewrappedBefore time.Time, limit int) ([]*entity.File, error)
+	UpdateWrappedDataKey(ctx context.Context, id uuid.UUID, oldWrappedKey, newWrappedKey string) error
+
+	ClaimDueForPurge(ctx context.Context, limit int) ([]*entity.File, error)
+	ReleaseStalePurgeClaimsOlderThan(ctx context.Context, age time.Duration) error
+	ReleasePurgeClaim(ctx context.Context, id uuid.UUID) error
+	ListDerived(ctx context.Context, id uuid.UUID) ([]*entity.File, error)
+	Purge(ctx context.Context, id uuid.UUID) error
+}
+
+type UploadSlotRepository interface {
+	ClaimExpired(ctx context.Context, limit int) ([]*entity.UploadSlot, error)
+	ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error
+	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
 
 type FileStorageAdapter interface {
+	UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error)
 	DeleteFile(ctx context.Context, bucket, objectKey string) error
+	GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error)
+	CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error
+}
+
+// KeyRewrapper re-encrypts wrapped data keys under the current version of
+// their key-encryption key.
+type KeyRewrapper interface {
+	Rewrap(ctx context.Context, env *envelope.Envelope) (*envelope.Envelope, error)
 }
@@ -0,0 +1,62 @@
+package files
+
+import (
+	"context"
+	"time"
+
+	"erp-service/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+// RewrapKeysBatch rewraps the data keys of files whose key has not been
+// rewrapped within RewrapAge, so a rotated tenant KEK eventually covers
+// every stored object. Objects are not re-encrypted; only the wrapped key on
+// the record changes.
+func (uc *usecase) RewrapKeysBatch(ctx context.Context) (RewrapBatchResult, error) {
+	files, err := uc.fileRepo.ListDueForRewrap(ctx, time.Now().Add(-uc.cfg.RewrapAge), uc.cfg.RewrapBatchSize)
+	if err != nil {
+		uc.logger.Error("failed to list files due for key rewrap", zap.Error(err))
+		return RewrapBatchResult{}, err
+	}
+
+	var result RewrapBatchResult
+	for _, file := range files {
+		env := file.Envelope()
+		if env == nil {
+			result.Skipped++
+			continue
+		}
+
+		rewrapped, err := uc.keys.Rewrap(ctx, env)
+		if err != nil {
+			uc.logger.Warn("failed to rewrap data key",
+				zap.String("file_id", file.ID.String()),
+				zap.String("key_id", env.KeyID),
+				zap.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+
+		err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return uc.fileRepo.UpdateWrappedDataKey(txCtx, file.ID, env.WrappedKey, rewrapped.WrappedKey)
+		})
+		if errors.IsNotFound(err) {
+			// Re-encrypted or deleted since it was listed.
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			uc.logger.Warn("failed to store rewrapped data key",
+				zap.String("file_id", file.ID.String()),
+				zap.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+		result.Rewrapped++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package files
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+func (uc *usecase) ScanBatch(ctx context.Context) (ScanBatchResult, error) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.ReleaseStaleScanClaimsOlderThan(txCtx, uc.cfg.StaleClaimAge)
+	}); err != nil {
+		uc.logger.Warn("failed to release stale scan claims", zap.Error(err))
+	}
+
+	var files []*entity.File
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		files, err = uc.fileRepo.ClaimPendingScan(txCtx, uc.cfg.ScanBatchSize, uc.cfg.MaxScanAttempts)
+		return err
+	}); err != nil {
+		uc.logger.Error("failed to claim files pending scan", zap.Error(err))
+		return ScanBatchResult{}, err
+	}
+
+	var result ScanBatchResult
+	for _, file := range files {
+		status, err := uc.ScanFile(ctx, file)
+		if err != nil {
+			uc.logger.Warn("failed to scan file",
+				zap.String("file_id", file.ID.String()),
+				zap.Error(err),
+			)
+			result.Failed++
+			continue
+		}
+		if status == entity.FileScanStatusInfected {
+			result.Infected++
+			continue
+		}
+		result.Clean++
+	}
+
+	return result, nil
+}
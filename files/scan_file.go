@@ -0,0 +1,88 @@
+package files
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+// ScanFile streams a claimed file to the malware scanner. Infected files are
+// copied to the quarantine bucket before the original is removed, so the
+// evidence survives even if the delete fails. On any error the scan claim is
+// released and the file is picked up again by a later batch, until
+// MaxScanAttempts is reached and the file is left SCAN_FAILED.
+func (uc *usecase) ScanFile(ctx context.Context, file *entity.File) (entity.FileScanStatus, error) {
+	verdict, err := uc.scanObject(ctx, file)
+	if err != nil {
+		uc.recordScanFailure(ctx, file)
+		return "", err
+	}
+
+	if !verdict.Infected {
+		if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return uc.fileRepo.MarkScanned(txCtx, file.ID)
+		}); err != nil {
+			uc.recordScanFailure(ctx, file)
+			return "", fmt.Errorf("mark file scanned: %w", err)
+		}
+		return entity.FileScanStatusClean, nil
+	}
+
+	quarantineBucket := uc.cfg.QuarantineBucket
+	if err := uc.fileStorage.CopyObject(ctx, file.Bucket, file.StorageKey, quarantineBucket, file.StorageKey); err != nil {
+		uc.recordScanFailure(ctx, file)
+		return "", fmt.Errorf("copy to quarantine: %w", err)
+	}
+
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.MarkQuarantined(txCtx, file.ID, quarantineBucket, file.StorageKey, verdict.Signature)
+	}); err != nil {
+		uc.recordScanFailure(ctx, file)
+		return "", fmt.Errorf("mark file quarantined: %w", err)
+	}
+
+	uc.logger.Warn("infected file quarantined",
+		zap.String("file_id", file.ID.String()),
+		zap.String("signature", verdict.Signature),
+		zap.String("quarantine_bucket", quarantineBucket),
+	)
+
+	if err := uc.fileStorage.DeleteFile(ctx, file.Bucket, file.StorageKey); err != nil {
+		uc.logger.Error("failed to delete infected original after quarantine",
+			zap.String("file_id", file.ID.String()),
+			zap.String("bucket", file.Bucket),
+			zap.String("storage_key", file.StorageKey),
+			zap.Error(err),
+		)
+	}
+
+	return entity.FileScanStatusInfected, nil
+}
+
+func (uc *usecase) scanObject(ctx context.Context, file *entity.File) (*ScanVerdict, error) {
+	body, err := uc.fileStorage.GetDecryptedObject(ctx, file.Bucket, file.StorageKey, file.Envelope())
+	if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+	defer body.Close()
+
+	verdict, err := uc.scanner.Scan(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("scan object: %w", err)
+	}
+	return verdict, nil
+}
+
+func (uc *usecase) recordScanFailure(ctx context.Context, file *entity.File) {
+	if err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return uc.fileRepo.RecordScanFailure(txCtx, file.ID, uc.cfg.MaxScanAttempts)
+	}); err != nil {
+		uc.logger.Error("failed to record scan failure",
+			zap.String("file_id", file.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
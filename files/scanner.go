@@ -0,0 +1,17 @@
+package files
+
+import (
+	"context"
+	"io"
+)
+
+// ScanVerdict is the outcome of a completed scan. Scanner failures are
+// reported as errors, never as a verdict.
+type ScanVerdict struct {
+	Infected  bool
+	Signature string
+}
+
+type MalwareScanner interface {
+	Scan(ctx context.Context, data io.Reader) (*ScanVerdict, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"erp-service/entity"
+	"erp-service/pkg/logger"
 
 	"go.uber.org/zap"
 )
@@ -11,25 +12,66 @@ import (
 type BatchResult struct {
 	Processed int
 	Failed    int
+
+	SlotsReaped int
+	SlotsFailed int
+}
+
+type ScanBatchResult struct {
+	Clean    int
+	Infected int
+	Failed   int
+}
+
+type ProcessBatchResult struct {
+	Processed int
+	Skipped   int
+	Failed    int
+}
+
+type RewrapBatchResult struct {
+	Rewrapped int
+	Skipped   int
+	Failed    int
+}
+
+type PurgeBatchResult struct {
+	Purged int
+	Failed int
 }
 
 type Usecase interface {
 	CleanupBatch(ctx context.Context) (BatchResult, error)
 	ProcessFile(ctx context.Context, file *entity.File) error
+	ScanBatch(ctx context.Context) (ScanBatchResult, error)
+	ScanFile(ctx context.Context, file *entity.File) (entity.FileScanStatus, error)
+	ProcessImagesBatch(ctx context.Context) (ProcessBatchResult, error)
+	ProcessImage(ctx context.Context, file *entity.File) (entity.FileProcessingStatus, error)
+	RewrapKeysBatch(ctx context.Context) (RewrapBatchResult, error)
+	PurgeBatch(ctx context.Context) (PurgeBatchResult, error)
+	PurgeFile(ctx context.Context, file *entity.File) error
 }
 
 func NewUsecase(
 	fileRepo FileRepository,
+	slotRepo UploadSlotRepository,
 	fileStorage FileStorageAdapter,
+	scanner MalwareScanner,
+	keys KeyRewrapper,
 	txManager TransactionManager,
 	logger *zap.Logger,
+	auditLogger logger.AuditLogger,
 	cfg Config,
 ) Usecase {
 	return &usecase{
 		fileRepo:    fileRepo,
+		slotRepo:    slotRepo,
 		fileStorage: fileStorage,
+		scanner:     scanner,
+		keys:        keys,
 		txManager:   txManager,
 		logger:      logger,
+		auditLogger: auditLogger,
 		cfg:         cfg,
 	}
 }
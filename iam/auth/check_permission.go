@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// CheckPermission resolves permissions the way login builds token claims:
+// the user's active roles for the product, which must be active in a tenant
+// the user is registered with. Platform admins are allowed everything.
+func (uc *usecase) CheckPermission(ctx context.Context, req *CheckPermissionRequest) (*CheckPermissionResponse, error) {
+	if req.UserID == uuid.Nil || req.TenantID == uuid.Nil || req.ProductID == uuid.Nil {
+		return nil, errors.ErrBadRequest("user, tenant and product are required")
+	}
+	if req.Permission == "" {
+		return nil, errors.ErrBadRequest("permission is required")
+	}
+
+	user, err := uc.UserRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrUserNotFound()
+		}
+		return nil, errors.ErrInternal("failed to load user").WithError(err)
+	}
+	if user.Status != entity.UserStatusActive {
+		return &CheckPermissionResponse{}, nil
+	}
+
+	userRoles, err := uc.UserRoleRepo.ListActiveByUserID(ctx, req.UserID, &req.ProductID)
+	if err != nil {
+		return nil, errors.ErrInternal("failed to load user roles").WithError(err)
+	}
+
+	var platformRoleIDs, roleIDs []uuid.UUID
+	for _, ur := range userRoles {
+		roleIDs = append(roleIDs, ur.RoleID)
+		if ur.ProductID == nil {
+			platformRoleIDs = append(platformRoleIDs, ur.RoleID)
+		}
+	}
+	if len(roleIDs) == 0 {
+		return &CheckPermissionResponse{}, nil
+	}
+
+	if len(platformRoleIDs) > 0 {
+		roles, err := uc.RoleRepo.GetByIDs(ctx, platformRoleIDs)
+		if err != nil {
+			return nil, errors.ErrInternal("failed to load roles").WithError(err)
+		}
+		for _, r := range roles {
+			if r.Code == PlatformAdminRole {
+				return &CheckPermissionResponse{Allowed: true, PlatformAdmin: true}, nil
+			}
+		}
+	}
+
+	registered, err := uc.isRegisteredWithTenant(ctx, req.UserID, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !registered {
+		return &CheckPermissionResponse{}, nil
+	}
+
+	if _, err := uc.ProductRepo.GetByIDAndTenant(ctx, req.ProductID, req.TenantID); err != nil {
+		if errors.IsNotFound(err) {
+			return &CheckPermissionResponse{}, nil
+		}
+		return nil, errors.ErrInternal("failed to load product").WithError(err)
+	}
+
+	permissions, err := uc.PermissionRepo.GetCodesByRoleIDs(ctx, roleIDs)
+	if err != nil {
+		return nil, errors.ErrInternal("failed to load permissions").WithError(err)
+	}
+
+	return &CheckPermissionResponse{Allowed: slices.Contains(permissions, req.Permission)}, nil
+}
+
+func (uc *usecase) isRegisteredWithTenant(ctx context.Context, userID, tenantID uuid.UUID) (bool, error) {
+	registrations, err := uc.UserTenantRegRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return false, errors.ErrInternal("failed to load tenant registrations").WithError(err)
+	}
+	for _, reg := range registrations {
+		if reg.TenantID == tenantID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
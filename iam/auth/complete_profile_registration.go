@@ -12,6 +12,7 @@ import (
 	"erp-service/entity"
 	"erp-service/masterdata"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 )
 
 var genderCodePattern = regexp.MustCompile(`^GENDER_\d{3}$`)
@@ -131,7 +132,7 @@ func (uc *usecase) CompleteProfileRegistration(
 		return nil, errors.ErrInternal("failed to generate auth tokens").WithError(err)
 	}
 
-	uc.sendEmailAsync(ctx, func(ctx context.Context) error {
+	uc.sendEmailAsync(ctx, metrics.EmailTemplateWelcome, func(ctx context.Context) error {
 		return uc.EmailService.SendWelcome(ctx, session.Email, firstName)
 	})
 
@@ -33,3 +33,5 @@ const (
 	LoginRateLimitPerHour     = 5
 	LoginRateLimitWindow      = 60
 )
+
+const PlatformAdminRole = "PLATFORM_ADMIN"
@@ -11,13 +11,16 @@ import (
 
 	"erp-service/pkg/errors"
 	"erp-service/pkg/logger"
+	"erp-service/pkg/metrics"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 var emailSendConcurrency = make(chan struct{}, 50)
 
-func (uc *usecase) sendEmailAsync(ctx context.Context, fn func(ctx context.Context) error) {
+// sendEmailAsync sends in the background. Sends beyond the concurrency limit
+// are shed and counted under template, since the mailer never sees them.
+func (uc *usecase) sendEmailAsync(ctx context.Context, template string, fn func(ctx context.Context) error) {
 	bgCtx := context.WithoutCancel(ctx)
 	select {
 	case emailSendConcurrency <- struct{}{}:
@@ -35,6 +38,7 @@ func (uc *usecase) sendEmailAsync(ctx context.Context, fn func(ctx context.Conte
 			}
 		}()
 	default:
+		metrics.ObserveEmail(template, metrics.EmailShed)
 		uc.AuditLogger.Log(bgCtx, logger.AuditEvent{
 			Domain:  "auth",
 			Action:  "email_send_shed",
@@ -8,6 +8,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 
 	"github.com/google/uuid"
 )
@@ -75,7 +76,8 @@ func (uc *usecase) InitiateLogin(
 		return nil, errors.ErrInternal("failed to create login session").WithError(err)
 	}
 
-	uc.sendEmailAsync(ctx, func(ctx context.Context) error {
+	metrics.OTPIssued(metrics.OTPFlowLogin)
+	uc.sendEmailAsync(ctx, metrics.EmailTemplateLoginOTP, func(ctx context.Context) error {
 		return uc.EmailService.SendLoginOTP(ctx, email, otp, LoginOTPExpiryMinutes)
 	})
 
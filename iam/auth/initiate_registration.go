@@ -6,6 +6,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 
 	"github.com/google/uuid"
 )
@@ -94,7 +95,8 @@ func (uc *usecase) InitiateRegistration(
 		return nil, err
 	}
 
-	uc.sendEmailAsync(ctx, func(ctx context.Context) error {
+	metrics.OTPIssued(metrics.OTPFlowRegistration)
+	uc.sendEmailAsync(ctx, metrics.EmailTemplateRegistrationOTP, func(ctx context.Context) error {
 		return uc.EmailService.SendRegistrationOTP(ctx, req.Email, otp, RegistrationOTPExpiryMinutes)
 	})
 
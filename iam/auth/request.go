@@ -61,3 +61,9 @@ type CompleteProfileRegistrationRequest struct {
 	DateOfBirth       string    `json:"date_of_birth" validate:"required,datetime=2006-01-02"`
 }
 
+type CheckPermissionRequest struct {
+	UserID     uuid.UUID
+	TenantID   uuid.UUID
+	ProductID  uuid.UUID
+	Permission string
+}
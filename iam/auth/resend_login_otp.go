@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 )
 
 func (uc *usecase) ResendLoginOTP(
@@ -48,7 +49,8 @@ func (uc *usecase) ResendLoginOTP(
 		return nil, errors.ErrInternal("failed to update OTP").WithError(err)
 	}
 
-	uc.sendEmailAsync(ctx, func(ctx context.Context) error {
+	metrics.OTPIssued(metrics.OTPFlowLogin)
+	uc.sendEmailAsync(ctx, metrics.EmailTemplateLoginOTP, func(ctx context.Context) error {
 		return uc.EmailService.SendLoginOTP(ctx, session.Email, otp, LoginOTPExpiryMinutes)
 	})
 
@@ -8,6 +8,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 )
 
 func (uc *usecase) ResendRegistrationOTP(
@@ -63,7 +64,8 @@ func (uc *usecase) ResendRegistrationOTP(
 		return nil, err
 	}
 
-	uc.sendEmailAsync(ctx, func(ctx context.Context) error {
+	metrics.OTPIssued(metrics.OTPFlowRegistration)
+	uc.sendEmailAsync(ctx, metrics.EmailTemplateRegistrationOTP, func(ctx context.Context) error {
 		return uc.EmailService.SendRegistrationOTP(ctx, req.Email, otp, RegistrationOTPExpiryMinutes)
 	})
 
@@ -117,3 +117,8 @@ type RegistrationStatusResponse struct {
 	OTPAttemptsRemaining int       `json:"otp_attempts_remaining"`
 	ResendsRemaining     int       `json:"resends_remaining"`
 }
+
+type CheckPermissionResponse struct {
+	Allowed       bool `json:"allowed"`
+	PlatformAdmin bool `json:"platform_admin"`
+}
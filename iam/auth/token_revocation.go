@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// IsTokenRevoked reports whether an access token was blacklisted on logout or
+// issued before the user's last logout-all. Tokens without a jti are never
+// revoked, and store errors fail open so a Redis outage does not log
+// everyone out.
+func IsTokenRevoked(ctx context.Context, store TokenBlacklistStore, jti string, userID uuid.UUID, claims jwt.RegisteredClaims) bool {
+	if jti == "" {
+		return false
+	}
+
+	blacklisted, err := store.IsTokenBlacklisted(ctx, jti)
+	if err == nil && blacklisted {
+		return true
+	}
+
+	blacklistTS, err := store.GetUserBlacklistTimestamp(ctx, userID)
+	if err == nil && blacklistTS != nil && claims.IssuedAt != nil {
+		return claims.IssuedAt.Time.Before(*blacklistTS)
+	}
+
+	return false
+}
@@ -28,8 +28,15 @@ type LoginFlow interface {
 	GetLoginStatus(ctx context.Context, req *GetLoginStatusRequest) (*LoginStatusResponse, error)
 }
 
+// Authorizer answers permission checks from the database rather than from
+// token claims, so role changes apply before the user's tokens are reissued.
+type Authorizer interface {
+	CheckPermission(ctx context.Context, req *CheckPermissionRequest) (*CheckPermissionResponse, error)
+}
+
 type Usecase interface {
 	SessionManager
 	RegistrationFlow
 	LoginFlow
+	Authorizer
 }
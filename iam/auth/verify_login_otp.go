@@ -9,6 +9,7 @@ import (
 	"erp-service/entity"
 	"erp-service/pkg/errors"
 	jwtpkg "erp-service/pkg/jwt"
+	"erp-service/pkg/metrics"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -29,14 +30,18 @@ func (uc *usecase) VerifyLoginOTP(
 
 	if !session.CanAttemptOTP() {
 		if session.IsExpired() {
+			metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailExpired)
 			return nil, errors.New("SESSION_EXPIRED", "Login session has expired. Please start a new login.", http.StatusGone)
 		}
 		if session.IsOTPExpired() {
+			metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailExpired)
 			return nil, errors.New("OTP_EXPIRED", "OTP has expired. Please request a new one.", http.StatusGone)
 		}
 		if session.IsLocked() {
+			metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailLocked)
 			return nil, errors.New("SESSION_LOCKED", "Too many failed attempts. Please start a new login.", http.StatusForbidden)
 		}
+		metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailInvalid)
 		return nil, errors.New("OTP_INVALID", "Unable to verify OTP", http.StatusBadRequest)
 	}
 
@@ -44,10 +49,13 @@ func (uc *usecase) VerifyLoginOTP(
 		_, _ = uc.InMemoryStore.IncrementLoginAttempts(ctx, req.LoginSessionID)
 		remaining := session.RemainingAttempts() - 1
 		if remaining <= 0 {
+			metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailLocked)
 			return nil, errors.New("SESSION_LOCKED", "Too many failed attempts. Please start a new login.", http.StatusForbidden)
 		}
+		metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailInvalid)
 		return nil, errors.New("OTP_INVALID", "Invalid OTP code", http.StatusBadRequest)
 	}
+	metrics.OTPVerified(metrics.OTPFlowLogin)
 
 	if err := uc.InMemoryStore.MarkLoginVerified(ctx, req.LoginSessionID); err != nil {
 		return nil, errors.ErrInternal("failed to mark session verified").WithError(err)
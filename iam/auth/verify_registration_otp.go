@@ -11,6 +11,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/metrics"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -31,6 +32,7 @@ func (uc *usecase) VerifyRegistrationOTP(
 	}
 
 	if session.IsExpired() {
+		metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailExpired)
 		return nil, errors.New("REGISTRATION_EXPIRED", "Registration session has expired", http.StatusGone)
 	}
 
@@ -39,6 +41,7 @@ func (uc *usecase) VerifyRegistrationOTP(
 	}
 
 	if session.Status == entity.RegistrationSessionStatusFailed {
+		metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailLocked)
 		return nil, errors.ErrTooManyRequests("Too many failed attempts. Please start a new registration.")
 	}
 
@@ -47,10 +50,12 @@ func (uc *usecase) VerifyRegistrationOTP(
 	}
 
 	if session.IsOTPExpired() {
+		metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailExpired)
 		return nil, errors.New("OTP_EXPIRED", "Verification code has expired. Please request a new one.", http.StatusGone)
 	}
 
 	if !session.CanAttemptOTP() {
+		metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailLocked)
 		return nil, errors.ErrTooManyRequests("Too many failed attempts. Please start a new registration.")
 	}
 
@@ -64,14 +69,17 @@ func (uc *usecase) VerifyRegistrationOTP(
 
 		remaining := session.MaxAttempts - attempts
 		if remaining <= 0 {
+			metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailLocked)
 			return nil, errors.ErrTooManyRequests("Too many failed attempts. Registration has been invalidated.")
 		}
 
+		metrics.OTPFailed(metrics.OTPFlowRegistration, metrics.OTPFailInvalid)
 		return nil, errors.ErrUnauthorized("The verification code is incorrect").
 			WithDetails(map[string]interface{}{
 				"attempts_remaining": remaining,
 			})
 	}
+	metrics.OTPVerified(metrics.OTPFlowRegistration)
 
 	token, tokenHash, err := uc.generateRegistrationCompleteToken(req.RegistrationID, req.Email)
 	if err != nil {
@@ -0,0 +1,153 @@
+package clamd
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"erp-service/config"
+	"erp-service/files"
+)
+
+const (
+	defaultTimeout = 2 * time.Minute
+	chunkSize      = 64 * 1024
+)
+
+// scanner speaks the clamd INSTREAM protocol: a "zINSTREAM" command followed
+// by length-prefixed chunks and a zero-length terminator, answered with a
+// single NUL-terminated line.
+type scanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewScanner(cfg config.ClamdConfig) (files.MalwareScanner, error) {
+	network, address, err := parseAddress(cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &scanner{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}, nil
+}
+
+func parseAddress(addr string) (network, address string, err error) {
+	switch {
+	case strings.HasPrefix(addr, "tcp://"):
+		return "tcp", strings.TrimPrefix(addr, "tcp://"), nil
+	case strings.HasPrefix(addr, "unix://"):
+		return "unix", strings.TrimPrefix(addr, "unix://"), nil
+	default:
+		return "", "", fmt.Errorf("invalid clamd address %q: expected tcp://host:port or unix:///path", addr)
+	}
+}
+
+func (s *scanner) Scan(ctx context.Context, data io.Reader) (*files.ScanVerdict, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("set clamd deadline: %w", err)
+	}
+
+	if err := stream(conn, data); err != nil {
+		// clamd closes the connection early when the stream exceeds its size
+		// limit; its reply explains why, so prefer that over the write error.
+		if reply, readErr := readReply(conn); readErr == nil {
+			if _, parseErr := parseReply(reply); parseErr != nil {
+				return nil, parseErr
+			}
+		}
+		return nil, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return nil, err
+	}
+	return parseReply(reply)
+}
+
+func stream(conn net.Conn, data io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("send INSTREAM command: %w", err)
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, werr := conn.Write(size); werr != nil {
+				return fmt.Errorf("send chunk size: %w", werr)
+			}
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return fmt.Errorf("send chunk: %w", werr)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read object: %w", err)
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return fmt.Errorf("send stream terminator: %w", err)
+	}
+	return nil
+}
+
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return "", fmt.Errorf("read clamd reply: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// parseReply interprets "stream: OK", "stream: <signature> FOUND" and
+// "<message> ERROR" replies.
+func parseReply(reply string) (*files.ScanVerdict, error) {
+	body := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case body == "OK":
+		return &files.ScanVerdict{}, nil
+	case strings.HasSuffix(body, " FOUND"):
+		return &files.ScanVerdict{
+			Infected:  true,
+			Signature: strings.TrimSuffix(body, " FOUND"),
+		}, nil
+	case strings.HasSuffix(body, " ERROR"):
+		return nil, fmt.Errorf("clamd error: %s", strings.TrimSuffix(body, " ERROR"))
+	default:
+		return nil, fmt.Errorf("unexpected clamd reply: %q", reply)
+	}
+}
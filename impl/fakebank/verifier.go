@@ -0,0 +1,52 @@
+// Package fakebank is a deterministic bank account inquiry provider for
+// local development and tests. The outcome depends only on the last four
+// digits of the account number.
+package fakebank
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"erp-service/saving/participant"
+)
+
+const (
+	// SuffixNotFound marks an account the bank does not know.
+	SuffixNotFound = "0000"
+	// SuffixProviderError makes the inquiry fail as if the bank were down.
+	SuffixProviderError = "9999"
+	// SuffixMismatch returns MismatchHolderName instead of the claimed name.
+	SuffixMismatch = "8888"
+
+	MismatchHolderName = "FAKE OTHER HOLDER"
+)
+
+var ErrUnavailable = errors.New("fake bank: inquiry service unavailable")
+
+type verifier struct{}
+
+func NewVerifier() participant.BankAccountVerifier {
+	return verifier{}
+}
+
+func (verifier) Name() string { return "fake" }
+
+// Inquire echoes the claimed holder name in upper case, the way banks
+// return registered names, unless the account number ends in one of the
+// special suffixes.
+func (verifier) Inquire(ctx context.Context, inquiry participant.BankAccountInquiry) (*participant.BankAccountInquiryResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	number := inquiry.AccountNumber
+	switch {
+	case strings.HasSuffix(number, SuffixNotFound):
+		return nil, participant.ErrBankAccountNotFound
+	case strings.HasSuffix(number, SuffixProviderError):
+		return nil, ErrUnavailable
+	case strings.HasSuffix(number, SuffixMismatch):
+		return &participant.BankAccountInquiryResult{HolderName: MismatchHolderName}, nil
+	}
+	return &participant.BankAccountInquiryResult{HolderName: strings.ToUpper(strings.TrimSpace(inquiry.HolderName))}, nil
+}
@@ -0,0 +1,201 @@
+package hashivault
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	credentialCallTimeout = 10 * time.Second
+	// credentialMinRefresh spaces out attempts once a lease is close to
+	// expiry and Vault keeps failing.
+	credentialMinRefresh = time.Second
+)
+
+// DatabaseCredentialVault is the part of SecureVault that
+// DatabaseCredentialManager uses.
+type DatabaseCredentialVault interface {
+	GenerateDatabaseCredentials(ctx context.Context, role string) (*DatabaseCredentials, error)
+	RenewLease(ctx context.Context, leaseID string, increment int) (*LeaseInfo, error)
+	RevokeLease(ctx context.Context, leaseID string) error
+}
+
+type issuedLease struct {
+	id      string
+	expires time.Time
+}
+
+// DatabaseCredentialManager keeps a dynamic database credential valid for
+// the life of the process. Two thirds into each lease it renews it; once
+// Vault stops extending the lease, because the role's max TTL is near or
+// the lease is not renewable, it issues a new credential. The previous one
+// stays valid until its lease runs out, so connections opened with it can
+// finish their work.
+type DatabaseCredentialManager struct {
+	vault  DatabaseCredentialVault
+	role   string
+	logger *zap.Logger
+
+	mu      sync.RWMutex
+	current *DatabaseCredentials
+	expires time.Time
+	retired []issuedLease
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDatabaseCredentialManager issues the first credential for role and
+// starts renewing it.
+func NewDatabaseCredentialManager(ctx context.Context, vault DatabaseCredentialVault, role string, logger *zap.Logger) (*DatabaseCredentialManager, error) {
+	creds, err := vault.GenerateDatabaseCredentials(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	m := &DatabaseCredentialManager{
+		vault:   vault,
+		role:    role,
+		logger:  logger,
+		current: creds,
+		expires: leaseExpiry(creds.LeaseDuration),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go m.run()
+	return m, nil
+}
+
+// Credentials returns the credential new connections should use.
+func (m *DatabaseCredentialManager) Credentials() (username, password string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.current.Username, m.current.Password
+}
+
+// Close stops renewal and revokes every lease that has not expired yet.
+// Connections using them are cut off, so close the pool first.
+func (m *DatabaseCredentialManager) Close(ctx context.Context) error {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+
+	m.mu.Lock()
+	leases := append(m.unexpiredRetired(), issuedLease{id: m.current.LeaseID, expires: m.expires})
+	m.retired = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for _, lease := range leases {
+		if lease.id == "" {
+			continue
+		}
+		if err := m.vault.RevokeLease(ctx, lease.id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *DatabaseCredentialManager) run() {
+	defer close(m.done)
+	for {
+		wait, ok := m.nextRefresh()
+		if !ok {
+			<-m.stop
+			return
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-m.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			m.refresh()
+		}
+	}
+}
+
+// nextRefresh is two thirds of the remaining lease. A credential without a
+// lease never needs refreshing.
+func (m *DatabaseCredentialManager) nextRefresh() (time.Duration, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.current.LeaseDuration <= 0 {
+		return 0, false
+	}
+	return max(time.Until(m.expires)*2/3, credentialMinRefresh), true
+}
+
+func (m *DatabaseCredentialManager) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCallTimeout)
+	defer cancel()
+
+	m.mu.RLock()
+	current := m.current
+	m.mu.RUnlock()
+
+	if current.Renewable {
+		lease, err := m.vault.RenewLease(ctx, current.LeaseID, current.LeaseDuration)
+		if err == nil {
+			m.mu.Lock()
+			m.expires = leaseExpiry(lease.LeaseDuration)
+			m.mu.Unlock()
+			if lease.LeaseDuration >= current.LeaseDuration {
+				return
+			}
+		} else {
+			m.logger.Warn("renew database credential lease failed, issuing a new one",
+				zap.String("role", m.role),
+				zap.Error(err),
+			)
+		}
+	}
+
+	creds, err := m.vault.GenerateDatabaseCredentials(ctx, m.role)
+	if err != nil {
+		m.logger.Error("issue database credential failed",
+			zap.String("role", m.role),
+			zap.Time("current_expires", m.expiry()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	m.mu.Lock()
+	m.retired = append(m.unexpiredRetired(), issuedLease{id: current.LeaseID, expires: m.expires})
+	m.current = creds
+	m.expires = leaseExpiry(creds.LeaseDuration)
+	m.mu.Unlock()
+
+	m.logger.Info("rotated database credential",
+		zap.String("role", m.role),
+		zap.String("username", creds.Username),
+		zap.Int("lease_seconds", creds.LeaseDuration),
+	)
+}
+
+func (m *DatabaseCredentialManager) expiry() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.expires
+}
+
+// unexpiredRetired must be called with mu held.
+func (m *DatabaseCredentialManager) unexpiredRetired() []issuedLease {
+	now := time.Now()
+	kept := m.retired[:0]
+	for _, lease := range m.retired {
+		if lease.expires.After(now) {
+			kept = append(kept, lease)
+		}
+	}
+	return kept
+}
+
+func leaseExpiry(seconds int) time.Time {
+	return time.Now().Add(time.Duration(seconds) * time.Second)
+}
@@ -0,0 +1,64 @@
+package hashivault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+)
+
+const dataKeyBits = 256
+
+// keyProvider keeps one transit key per tenant KEK. Keys are created on first
+// use, so onboarding a tenant needs no extra provisioning step.
+type keyProvider struct {
+	vault   *SecureVault
+	ensured sync.Map
+}
+
+func NewKeyProvider(vault *SecureVault) envelope.KeyProvider {
+	return &keyProvider{vault: vault}
+}
+
+func (p *keyProvider) GenerateDataKey(ctx context.Context, keyID string) ([]byte, string, error) {
+	if err := p.ensureKey(ctx, keyID); err != nil {
+		return nil, "", err
+	}
+
+	encoded, wrapped, err := p.vault.GenerateDataKey(ctx, keyID, dataKeyBits)
+	if err != nil {
+		return nil, "", err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", fmt.Errorf("decode data key: %w", err)
+	}
+	return dataKey, wrapped, nil
+}
+
+func (p *keyProvider) DecryptDataKey(ctx context.Context, keyID, wrapped string) ([]byte, error) {
+	return p.vault.DecryptData(ctx, keyID, wrapped)
+}
+
+func (p *keyProvider) RewrapDataKey(ctx context.Context, keyID, wrapped string) (string, error) {
+	return p.vault.RewrapData(ctx, keyID, wrapped)
+}
+
+func (p *keyProvider) ensureKey(ctx context.Context, keyID string) error {
+	if _, ok := p.ensured.Load(keyID); ok {
+		return nil
+	}
+	if _, err := p.vault.ReadTransitKey(ctx, keyID); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := p.vault.CreateTransitKey(ctx, keyID, "aes256-gcm96", false); err != nil {
+			return err
+		}
+	}
+	p.ensured.Store(keyID, struct{}{})
+	return nil
+}
@@ -0,0 +1,62 @@
+package hashivault
+
+import (
+	"context"
+	"sync"
+
+	"erp-service/pkg/errors"
+	"erp-service/pkg/pii"
+)
+
+// piiCipher encrypts with a derived transit key, using the column name as
+// derivation context, and computes blind indexes with transit HMAC under a
+// separate key. The index key must not be rotated: HMACs under a new version
+// would no longer match the stored indexes.
+type piiCipher struct {
+	vault        *SecureVault
+	keyName      string
+	indexKeyName string
+	ensured      sync.Map
+}
+
+func NewPIICipher(vault *SecureVault, keyName, indexKeyName string) pii.Cipher {
+	return &piiCipher{
+		vault:        vault,
+		keyName:      keyName,
+		indexKeyName: indexKeyName,
+	}
+}
+
+func (c *piiCipher) Encrypt(ctx context.Context, column string, plaintext []byte) (string, error) {
+	if err := c.ensureKey(ctx, c.keyName, true); err != nil {
+		return "", err
+	}
+	return c.vault.EncryptDataWithContext(ctx, c.keyName, plaintext, []byte(column))
+}
+
+func (c *piiCipher) Decrypt(ctx context.Context, column, ciphertext string) ([]byte, error) {
+	return c.vault.DecryptDataWithContext(ctx, c.keyName, ciphertext, []byte(column))
+}
+
+func (c *piiCipher) BlindIndex(ctx context.Context, value []byte) (string, error) {
+	if err := c.ensureKey(ctx, c.indexKeyName, false); err != nil {
+		return "", err
+	}
+	return c.vault.GenerateHMAC(ctx, c.indexKeyName, value)
+}
+
+func (c *piiCipher) ensureKey(ctx context.Context, name string, derived bool) error {
+	if _, ok := c.ensured.Load(name); ok {
+		return nil
+	}
+	if _, err := c.vault.ReadTransitKey(ctx, name); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := c.vault.CreateTransitKey(ctx, name, "aes256-gcm96", derived); err != nil {
+			return err
+		}
+	}
+	c.ensured.Store(name, struct{}{})
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package localfs is a filesystem-backed object store with the semantics of
+// the MinIO adapter: buckets are directories, writes are atomic and
+// presigned URLs are HMAC-signed and served by Server.
+package localfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"erp-service/pkg/envelope"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+)
+
+type fileStorage struct {
+	store
+	signer    *Signer
+	encryptor *envelope.Encryptor
+}
+
+func NewFileStorage(root string, signer *Signer, encryptor *envelope.Encryptor) participant.FileStorageAdapter {
+	return &fileStorage{
+		store:     store{root: root},
+		signer:    signer,
+		encryptor: encryptor,
+	}
+}
+
+func (fs *fileStorage) UploadFile(ctx context.Context, bucket, objectKey string, data io.Reader, size int64, contentType string) (string, error) {
+	if err := fs.write(bucket, objectKey, data, size); err != nil {
+		return "", fmt.Errorf("upload file to local storage: %w", err)
+	}
+	return objectKey, nil
+}
+
+func (fs *fileStorage) UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error) {
+	sealed, sealedSize, env, err := fs.encryptor.Seal(ctx, tenantID, data, size)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt file: %w", err)
+	}
+	if err := fs.write(bucket, objectKey, sealed, sealedSize); err != nil {
+		return nil, fmt.Errorf("upload file to local storage: %w", err)
+	}
+	return env, nil
+}
+
+func (fs *fileStorage) GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error) {
+	obj, err := fs.GetObject(ctx, bucket, objectKey)
+	if err != nil || env == nil {
+		return obj, err
+	}
+
+	plaintext, err := fs.encryptor.Open(ctx, env, obj)
+	if err != nil {
+		_ = obj.Close()
+		return nil, fmt.Errorf("decrypt object: %w", err)
+	}
+	return readCloser{Reader: plaintext, Closer: obj}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (fs *fileStorage) DeleteFile(ctx context.Context, bucket, objectKey string) error {
+	if err := fs.remove(bucket, objectKey); err != nil {
+		return fmt.Errorf("delete file from local storage: %w", err)
+	}
+	return nil
+}
+
+func (fs *fileStorage) GetPresignedURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	if _, err := fs.objectPath(bucket, objectKey); err != nil {
+		return "", fmt.Errorf("generate presigned URL: %w", err)
+	}
+	return fs.signer.DownloadURL(bucket, objectKey, expiry, time.Now()), nil
+}
+
+func (fs *fileStorage) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	f, _, err := fs.open(bucket, objectKey)
+	if err != nil {
+		return nil, fmt.Errorf("get object from local storage: %w", err)
+	}
+	return f, nil
+}
+
+func (fs *fileStorage) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	src, info, err := fs.open(srcBucket, srcKey)
+	if err != nil {
+		return fmt.Errorf("copy object in local storage: %w", err)
+	}
+	defer src.Close()
+
+	if err := fs.write(dstBucket, dstKey, src, info.Size()); err != nil {
+		return fmt.Errorf("copy object in local storage: %w", err)
+	}
+	return nil
+}
+
+func (fs *fileStorage) PresignUpload(ctx context.Context, bucket, objectKey, contentType string, maxSize int64, expiry time.Duration) (*participant.PresignedUpload, error) {
+	if _, err := fs.objectPath(bucket, objectKey); err != nil {
+		return nil, fmt.Errorf("presign upload: %w", err)
+	}
+	url, formData := fs.signer.UploadForm(UploadPolicy{
+		Bucket:      bucket,
+		Key:         objectKey,
+		ContentType: contentType,
+		MaxSize:     maxSize,
+	}, expiry, time.Now())
+	return &participant.PresignedUpload{
+		URL:      url,
+		FormData: formData,
+	}, nil
+}
+
+func (fs *fileStorage) StatObject(ctx context.Context, bucket, objectKey string) (int64, error) {
+	info, err := fs.stat(bucket, objectKey)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,51 @@
+package localfs
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strings"
+	"time"
+
+	apperrors "erp-service/pkg/errors"
+)
+
+// Server backs the HTTP handler that answers presigned URLs issued by the
+// local file storage.
+type Server struct {
+	store
+	signer *Signer
+}
+
+func NewServer(root string, signer *Signer) *Server {
+	return &Server{
+		store:  store{root: root},
+		signer: signer,
+	}
+}
+
+// Open verifies a presigned download and opens the object.
+func (s *Server) Open(bucket, key, expires, signature string) (*os.File, os.FileInfo, error) {
+	if err := s.signer.VerifyDownload(bucket, key, expires, signature, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	return s.open(bucket, key)
+}
+
+// Accept verifies a presigned upload form and stores the posted file,
+// enforcing the same 1..MaxSize content-length range as the MinIO policy.
+// contentType is that of the file part and has to be the signed one.
+func (s *Server) Accept(bucket string, form map[string]string, contentType string, data io.Reader, size int64) error {
+	policy, err := s.signer.VerifyUpload(bucket, form, time.Now())
+	if err != nil {
+		return err
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !strings.EqualFold(mediaType, policy.ContentType) {
+		return apperrors.ErrBadRequest(fmt.Sprintf("file content type must be %s", policy.ContentType))
+	}
+	if size < 1 || size > policy.MaxSize {
+		return apperrors.ErrBadRequest(fmt.Sprintf("file size must be between 1 and %d bytes", policy.MaxSize))
+	}
+	return s.write(policy.Bucket, policy.Key, data, size)
+}
@@ -0,0 +1,146 @@
+package localfs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "erp-service/pkg/errors"
+)
+
+// RoutePrefix is where the HTTP handler for presigned URLs is mounted.
+const RoutePrefix = "/storage"
+
+// FormOverhead is the room a presigned upload request needs on top of the
+// file for the multipart framing and form fields.
+const FormOverhead = 64 * 1024
+
+const minSigningKeyLen = 32
+
+// Form fields of a presigned upload. The browser posts them back unchanged
+// together with the file part.
+const (
+	FormKey         = "key"
+	FormContentType = "Content-Type"
+	FormMaxSize     = "X-Max-Size"
+	FormExpires     = "X-Expires"
+	FormSignature   = "X-Signature"
+	FormFile        = "file"
+)
+
+// Signer issues and checks HMAC-signed URLs, the local stand-in for S3
+// presigned requests.
+type Signer struct {
+	key       []byte
+	publicURL string
+}
+
+func NewSigner(key []byte, publicURL string) (*Signer, error) {
+	if len(key) < minSigningKeyLen {
+		return nil, fmt.Errorf("local storage signing key must be at least %d bytes", minSigningKeyLen)
+	}
+	return &Signer{
+		key:       append([]byte{}, key...),
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+// UploadPolicy is what a presigned upload allows the client to store.
+type UploadPolicy struct {
+	Bucket      string
+	Key         string
+	ContentType string
+	MaxSize     int64
+}
+
+func (s *Signer) DownloadURL(bucket, key string, expiry time.Duration, now time.Time) string {
+	expires := strconv.FormatInt(now.Add(expiry).Unix(), 10)
+	q := url.Values{}
+	q.Set(FormExpires, expires)
+	q.Set(FormSignature, s.sign("GET", bucket, key, expires))
+	return s.objectURL(bucket, key) + "?" + q.Encode()
+}
+
+func (s *Signer) VerifyDownload(bucket, key, expires, signature string, now time.Time) error {
+	if err := checkExpiry(expires, now); err != nil {
+		return err
+	}
+	if !s.valid(signature, "GET", bucket, key, expires) {
+		return apperrors.ErrForbidden("invalid signature")
+	}
+	return nil
+}
+
+func (s *Signer) UploadForm(policy UploadPolicy, expiry time.Duration, now time.Time) (string, map[string]string) {
+	expires := strconv.FormatInt(now.Add(expiry).Unix(), 10)
+	maxSize := strconv.FormatInt(policy.MaxSize, 10)
+	return s.publicURL + RoutePrefix + "/" + url.PathEscape(policy.Bucket), map[string]string{
+		FormKey:         policy.Key,
+		FormContentType: policy.ContentType,
+		FormMaxSize:     maxSize,
+		FormExpires:     expires,
+		FormSignature:   s.sign("POST", policy.Bucket, policy.Key, policy.ContentType, maxSize, expires),
+	}
+}
+
+// VerifyUpload checks the posted form fields of a presigned upload and
+// returns the policy they grant.
+func (s *Signer) VerifyUpload(bucket string, form map[string]string, now time.Time) (*UploadPolicy, error) {
+	expires := form[FormExpires]
+	if err := checkExpiry(expires, now); err != nil {
+		return nil, err
+	}
+	key, contentType, maxSize := form[FormKey], form[FormContentType], form[FormMaxSize]
+	if !s.valid(form[FormSignature], "POST", bucket, key, contentType, maxSize, expires) {
+		return nil, apperrors.ErrForbidden("invalid signature")
+	}
+	limit, err := strconv.ParseInt(maxSize, 10, 64)
+	if err != nil {
+		return nil, apperrors.ErrBadRequest("invalid max size")
+	}
+	return &UploadPolicy{
+		Bucket:      bucket,
+		Key:         key,
+		ContentType: contentType,
+		MaxSize:     limit,
+	}, nil
+}
+
+func (s *Signer) objectURL(bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return s.publicURL + RoutePrefix + "/" + url.PathEscape(bucket) + "/" + strings.Join(segments, "/")
+}
+
+func (s *Signer) sign(parts ...string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Signer) valid(signature string, parts ...string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	want, _ := hex.DecodeString(s.sign(parts...))
+	return hmac.Equal(got, want)
+}
+
+func checkExpiry(expires string, now time.Time) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return apperrors.ErrForbidden("invalid expiry")
+	}
+	if now.Unix() > unix {
+		return apperrors.ErrForbidden("url has expired")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package localfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	apperrors "erp-service/pkg/errors"
+)
+
+const tempPrefix = ".upload-"
+
+// store maps buckets to directories under root and object keys to files
+// below them. Writes go to a temp file in the target directory and are
+// renamed into place, so readers never see a partial object.
+type store struct {
+	root string
+}
+
+func (s *store) objectPath(bucket, key string) (string, error) {
+	if bucket == "" || bucket == "." || bucket == ".." || strings.ContainsAny(bucket, `/\`) {
+		return "", apperrors.ErrBadRequest("invalid bucket name")
+	}
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") || strings.HasPrefix(path.Base(key), tempPrefix) {
+		return "", apperrors.ErrBadRequest("invalid object key")
+	}
+	return filepath.Join(s.root, bucket, filepath.FromSlash(key)), nil
+}
+
+// write stores data under bucket/key. A non-negative size must match the
+// number of bytes read, as with a MinIO PutObject.
+func (s *store) write(bucket, key string, data io.Reader, size int64) (err error) {
+	dst, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(dst)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, tempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	written, err := io.Copy(tmp, data)
+	if err != nil {
+		return fmt.Errorf("write object: %w", err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("write object: expected %d bytes, got %d", size, written)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("sync object: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close object: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("rename object: %w", err)
+	}
+	return nil
+}
+
+func (s *store) open(bucket, key string) (*os.File, os.FileInfo, error) {
+	p, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, apperrors.ErrNotFound("object not found")
+		}
+		return nil, nil, fmt.Errorf("open object: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("stat object: %w", err)
+	}
+	if info.IsDir() {
+		_ = f.Close()
+		return nil, nil, apperrors.ErrNotFound("object not found")
+	}
+	return f, info, nil
+}
+
+func (s *store) stat(bucket, key string) (os.FileInfo, error) {
+	p, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, apperrors.ErrNotFound("object not found")
+		}
+		return nil, fmt.Errorf("stat object: %w", err)
+	}
+	if info.IsDir() {
+		return nil, apperrors.ErrNotFound("object not found")
+	}
+	return info, nil
+}
+
+// remove deletes an object; like S3, deleting a missing object succeeds.
+// Directories left empty are pruned up to the bucket.
+func (s *store) remove(bucket, key string) error {
+	p, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove object: %w", err)
+	}
+
+	bucketDir := filepath.Join(s.root, bucket)
+	for dir := filepath.Dir(p); dir != bucketDir && strings.HasPrefix(dir, bucketDir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
@@ -3,10 +3,14 @@ package mailer
 import (
 	"context"
 	"erp-service/config"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tracing"
 	"fmt"
 	"log"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/gomail.v2"
 )
 
@@ -43,7 +47,7 @@ func (s *EmailService) SendRegistrationOTP(ctx context.Context, email, otp strin
 		return fmt.Errorf("failed to render registration OTP email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplateRegistrationOTP, email, subject, htmlBody)
 }
 
 func (s *EmailService) SendLoginOTP(ctx context.Context, email, otp string, expiryMinutes int) error {
@@ -54,7 +58,7 @@ func (s *EmailService) SendLoginOTP(ctx context.Context, email, otp string, expi
 		return fmt.Errorf("failed to render login OTP email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplateLoginOTP, email, subject, htmlBody)
 }
 
 func (s *EmailService) SendWelcome(ctx context.Context, email, firstName string) error {
@@ -65,7 +69,7 @@ func (s *EmailService) SendWelcome(ctx context.Context, email, firstName string)
 		return fmt.Errorf("failed to render welcome email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplateWelcome, email, subject, htmlBody)
 }
 
 func (s *EmailService) SendPasswordReset(ctx context.Context, email, token string, expiryMinutes int) error {
@@ -76,7 +80,7 @@ func (s *EmailService) SendPasswordReset(ctx context.Context, email, token strin
 		return fmt.Errorf("failed to render password reset email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplatePasswordReset, email, subject, htmlBody)
 }
 
 func (s *EmailService) SendPINReset(ctx context.Context, email, otp string, expiryMinutes int) error {
@@ -87,7 +91,7 @@ func (s *EmailService) SendPINReset(ctx context.Context, email, otp string, expi
 		return fmt.Errorf("failed to render PIN reset email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplatePINReset, email, subject, htmlBody)
 }
 
 func (s *EmailService) SendAdminInvitation(ctx context.Context, email, token string, expiryMinutes int) error {
@@ -98,14 +102,32 @@ func (s *EmailService) SendAdminInvitation(ctx context.Context, email, token str
 		return fmt.Errorf("failed to render admin invitation email: %w", err)
 	}
 
-	return s.send(ctx, email, subject, htmlBody)
+	return s.send(ctx, metrics.EmailTemplateAdminInvitation, email, subject, htmlBody)
 }
 
-func (s *EmailService) send(ctx context.Context, to, subject, htmlBody string) error {
+func (s *EmailService) send(ctx context.Context, template, to, subject, htmlBody string) error {
+	ctx, span := tracing.StartChild(ctx, "email.send "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("email.template", template),
+			attribute.String("email.provider", s.config.Provider),
+		),
+	)
+	var err error
+	defer func() { tracing.End(span, err) }()
+
 	if s.config.Provider == ProviderConsole {
-		return s.sendConsole(to, subject, htmlBody)
+		err = s.sendConsole(to, subject, htmlBody)
+	} else {
+		err = s.sendSMTP(ctx, to, subject, htmlBody)
+	}
+
+	outcome := metrics.EmailSent
+	if err != nil {
+		outcome = metrics.EmailFailed
 	}
-	return s.sendSMTP(ctx, to, subject, htmlBody)
+	metrics.ObserveEmail(template, outcome)
+	return err
 }
 
 func (s *EmailService) sendConsole(to, subject, htmlBody string) error {
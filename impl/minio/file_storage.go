@@ -6,18 +6,23 @@ import (
 	"io"
 	"time"
 
+	"erp-service/pkg/envelope"
+	apperrors "erp-service/pkg/errors"
 	"erp-service/saving/participant"
 
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 )
 
 type fileStorage struct {
-	client *minio.Client
+	client    *minio.Client
+	encryptor *envelope.Encryptor
 }
 
-func NewFileStorage(client *minio.Client) participant.FileStorageAdapter {
+func NewFileStorage(client *minio.Client, encryptor *envelope.Encryptor) participant.FileStorageAdapter {
 	return &fileStorage{
-		client: client,
+		client:    client,
+		encryptor: encryptor,
 	}
 }
 
@@ -36,6 +41,48 @@ func (fs *fileStorage) UploadFile(ctx context.Context, bucket, objectKey string,
 	return objectKey, nil
 }
 
+// UploadEncryptedFile encrypts on the fly while streaming to MinIO. The
+// object's content type is left generic since the bytes are ciphertext.
+func (fs *fileStorage) UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error) {
+	if err := fs.ensureBucketExists(ctx, bucket); err != nil {
+		return nil, fmt.Errorf("ensure bucket exists: %w", err)
+	}
+
+	sealed, sealedSize, env, err := fs.encryptor.Seal(ctx, tenantID, data, size)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt file: %w", err)
+	}
+
+	_, err = fs.client.PutObject(ctx, bucket, objectKey, sealed, sealedSize, minio.PutObjectOptions{
+		ContentType:  "application/octet-stream",
+		UserMetadata: map[string]string{"encryption": "envelope-v1"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upload file to MinIO: %w", err)
+	}
+
+	return env, nil
+}
+
+func (fs *fileStorage) GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error) {
+	obj, err := fs.GetObject(ctx, bucket, objectKey)
+	if err != nil || env == nil {
+		return obj, err
+	}
+
+	plaintext, err := fs.encryptor.Open(ctx, env, obj)
+	if err != nil {
+		_ = obj.Close()
+		return nil, fmt.Errorf("decrypt object: %w", err)
+	}
+	return readCloser{Reader: plaintext, Closer: obj}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
 func (fs *fileStorage) DeleteFile(ctx context.Context, bucket, objectKey string) error {
 	err := fs.client.RemoveObject(ctx, bucket, objectKey, minio.RemoveObjectOptions{})
 	if err != nil {
@@ -56,6 +103,77 @@ func (fs *fileStorage) GetPresignedURL(ctx context.Context, bucket, objectKey st
 	return url.String(), nil
 }
 
+func (fs *fileStorage) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	obj, err := fs.client.GetObject(ctx, bucket, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get object from MinIO: %w", err)
+	}
+	// GetObject is lazy; Stat surfaces a missing object before streaming starts.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, fmt.Errorf("stat object in MinIO: %w", err)
+	}
+	return obj, nil
+}
+
+func (fs *fileStorage) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	if err := fs.ensureBucketExists(ctx, dstBucket); err != nil {
+		return fmt.Errorf("ensure bucket exists: %w", err)
+	}
+
+	_, err := fs.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: dstBucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: srcBucket, Object: srcKey},
+	)
+	if err != nil {
+		return fmt.Errorf("copy object in MinIO: %w", err)
+	}
+	return nil
+}
+
+func (fs *fileStorage) PresignUpload(ctx context.Context, bucket, objectKey, contentType string, maxSize int64, expiry time.Duration) (*participant.PresignedUpload, error) {
+	if err := fs.ensureBucketExists(ctx, bucket); err != nil {
+		return nil, fmt.Errorf("ensure bucket exists: %w", err)
+	}
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(bucket); err != nil {
+		return nil, fmt.Errorf("set policy bucket: %w", err)
+	}
+	if err := policy.SetKey(objectKey); err != nil {
+		return nil, fmt.Errorf("set policy key: %w", err)
+	}
+	if err := policy.SetExpires(time.Now().UTC().Add(expiry)); err != nil {
+		return nil, fmt.Errorf("set policy expiry: %w", err)
+	}
+	if err := policy.SetContentType(contentType); err != nil {
+		return nil, fmt.Errorf("set policy content type: %w", err)
+	}
+	if err := policy.SetContentLengthRange(1, maxSize); err != nil {
+		return nil, fmt.Errorf("set policy content length: %w", err)
+	}
+
+	url, formData, err := fs.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("presign post policy: %w", err)
+	}
+	return &participant.PresignedUpload{
+		URL:      url.String(),
+		FormData: formData,
+	}, nil
+}
+
+func (fs *fileStorage) StatObject(ctx context.Context, bucket, objectKey string) (int64, error) {
+	info, err := fs.client.StatObject(ctx, bucket, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return 0, apperrors.ErrNotFound("object not found")
+		}
+		return 0, fmt.Errorf("stat object in MinIO: %w", err)
+	}
+	return info.Size, nil
+}
+
 func (fs *fileStorage) PresignGetURL(ctx context.Context, bucket, key string, ttl time.Duration) (string, error) {
 	return fs.GetPresignedURL(ctx, bucket, key, ttl)
 }
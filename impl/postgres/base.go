@@ -3,16 +3,40 @@ package postgres
 import (
 	"context"
 
+	"erp-service/pkg/tenantdb"
+
 	"gorm.io/gorm"
 )
 
 type baseRepository struct {
 	db *gorm.DB
+	// tenants is set on repositories over tenant data, which may live in
+	// a dedicated database rather than on db.
+	tenants *tenantdb.Router
+}
+
+func tenantDataRepository(tenants *tenantdb.Router) baseRepository {
+	return baseRepository{db: tenants.Platform(), tenants: tenants}
 }
 
 func (r *baseRepository) getDB(ctx context.Context) *gorm.DB {
-	if tx, ok := getTx(ctx); ok {
+	if r.tenants == nil {
+		if tx, ok := getTx(ctx); ok {
+			return tx
+		}
+		return r.db.WithContext(ctx)
+	}
+
+	if tx, ok := getTenantTx(ctx); ok {
 		return tx
 	}
-	return r.db.WithContext(ctx)
+	db, err := r.tenants.DB(ctx)
+	if err != nil {
+		// Statements on a session carrying an error never run; the error
+		// comes back from the repository call like a query error.
+		failed := r.db.WithContext(ctx)
+		_ = failed.AddError(err)
+		return failed
+	}
+	return db.WithContext(ctx)
 }
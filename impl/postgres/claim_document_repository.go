@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+)
+
+type claimDocumentRepository struct {
+	baseRepository
+}
+
+func NewClaimDocumentRepository(tenants *tenantdb.Router) claim.ClaimDocumentRepository {
+	return &claimDocumentRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *claimDocumentRepository) Create(ctx context.Context, document *entity.ClaimDocument) error {
+	if err := r.getDB(ctx).Create(document).Error; err != nil {
+		return translateError(err, "claim document")
+	}
+	return nil
+}
+
+func (r *claimDocumentRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimDocument, error) {
+	var documents []*entity.ClaimDocument
+	err := r.getDB(ctx).Where("claim_id = ?", claimID).
+		Order("created_at ASC").
+		Find(&documents).Error
+	if err != nil {
+		return nil, translateError(err, "claim document")
+	}
+	return documents, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+)
+
+type claimPaymentInstructionRepository struct {
+	baseRepository
+}
+
+func NewClaimPaymentInstructionRepository(tenants *tenantdb.Router) claim.PaymentInstructionRepository {
+	return &claimPaymentInstructionRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *claimPaymentInstructionRepository) Create(ctx context.Context, instruction *entity.PaymentInstruction) error {
+	if err := r.getDB(ctx).Create(instruction).Error; err != nil {
+		return translateError(err, "payment instruction")
+	}
+	return nil
+}
+
+func (r *claimPaymentInstructionRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.PaymentInstruction, error) {
+	var instructions []*entity.PaymentInstruction
+	err := r.getDB(ctx).Where("claim_id = ?", claimID).
+		Order("created_at ASC, id ASC").
+		Find(&instructions).Error
+	if err != nil {
+		return nil, translateError(err, "payment instruction")
+	}
+	return instructions, nil
+}
+
+func (r *claimPaymentInstructionRepository) MarkPaidByClaimID(ctx context.Context, claimID uuid.UUID, reference string, paidAt time.Time) error {
+	err := r.getDB(ctx).Model(&entity.PaymentInstruction{}).
+		Where("claim_id = ? AND status = ?", claimID, entity.PaymentInstructionStatusPending).
+		Updates(map[string]interface{}{
+			"status":            entity.PaymentInstructionStatusPaid,
+			"payment_reference": reference,
+			"paid_at":           paidAt,
+		}).Error
+	if err != nil {
+		return translateError(err, "payment instruction")
+	}
+	return nil
+}
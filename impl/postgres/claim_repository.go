@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+
+	"erp-service/entity"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+)
+
+type claimRepository struct {
+	baseRepository
+}
+
+func NewClaimRepository(tenants *tenantdb.Router) claim.ClaimRepository {
+	return &claimRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *claimRepository) Create(ctx context.Context, c *entity.Claim) error {
+	if err := r.getDB(ctx).Create(c).Error; err != nil {
+		return translateError(err, "claim")
+	}
+	return nil
+}
+
+func (r *claimRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Claim, error) {
+	var c entity.Claim
+	if err := r.getDB(ctx).Where("id = ?", id).First(&c).Error; err != nil {
+		return nil, translateError(err, "claim")
+	}
+	return &c, nil
+}
+
+func (r *claimRepository) Update(ctx context.Context, c *entity.Claim) error {
+	oldVersion := c.Version
+	c.Version = oldVersion + 1
+
+	result := r.getDB(ctx).Where("version = ?", oldVersion).Save(c)
+	if result.Error != nil {
+		c.Version = oldVersion
+		return translateError(result.Error, "claim")
+	}
+	if result.RowsAffected == 0 {
+		c.Version = oldVersion
+		return apperrors.ErrConflict("claim was modified by another request")
+	}
+	return nil
+}
+
+func (r *claimRepository) List(ctx context.Context, filter *claim.ClaimFilter) ([]*entity.Claim, int64, error) {
+	var claims []*entity.Claim
+	var total int64
+
+	query := r.getDB(ctx).Model(&entity.Claim{}).
+		Where("tenant_id = ? AND product_id = ?", filter.TenantID, filter.ProductID)
+	if filter.ParticipantID != nil {
+		query = query.Where("participant_id = ?", *filter.ParticipantID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.ClaimType != nil {
+		query = query.Where("claim_type = ?", *filter.ClaimType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, "claim")
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(filter.PerPage).
+		Find(&claims).Error
+	if err != nil {
+		return nil, 0, translateError(err, "claim")
+	}
+	return claims, total, nil
+}
+
+func (r *claimRepository) HasInProgress(ctx context.Context, participantID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&entity.Claim{}).
+		Where("participant_id = ? AND status IN ?", participantID, []entity.ClaimStatus{
+			entity.ClaimStatusOpen, entity.ClaimStatusVerified, entity.ClaimStatusApproved,
+		}).
+		Count(&count).Error
+	if err != nil {
+		return false, translateError(err, "claim")
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+)
+
+type claimStatusHistoryRepository struct {
+	baseRepository
+}
+
+func NewClaimStatusHistoryRepository(tenants *tenantdb.Router) claim.ClaimStatusHistoryRepository {
+	return &claimStatusHistoryRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *claimStatusHistoryRepository) Create(ctx context.Context, history *entity.ClaimStatusHistory) error {
+	if err := r.getDB(ctx).Create(history).Error; err != nil {
+		return translateError(err, "claim status history")
+	}
+	return nil
+}
+
+func (r *claimStatusHistoryRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimStatusHistory, error) {
+	var histories []*entity.ClaimStatusHistory
+	err := r.getDB(ctx).Where("claim_id = ?", claimID).
+		Order("changed_at ASC").
+		Find(&histories).Error
+	if err != nil {
+		return nil, translateError(err, "claim status history")
+	}
+	return histories, nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+)
+
+type contributionImportBatchRepository struct {
+	baseRepository
+}
+
+func NewContributionImportBatchRepository(tenants *tenantdb.Router) contribution.ImportBatchRepository {
+	return &contributionImportBatchRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *contributionImportBatchRepository) Create(ctx context.Context, batch *entity.ContributionImportBatch) error {
+	if err := r.getDB(ctx).Create(batch).Error; err != nil {
+		return translateError(err, "contribution import batch")
+	}
+	return nil
+}
+
+func (r *contributionImportBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionImportBatch, error) {
+	var batch entity.ContributionImportBatch
+	if err := r.getDB(ctx).Where("id = ?", id).First(&batch).Error; err != nil {
+		return nil, translateError(err, "contribution import batch")
+	}
+	return &batch, nil
+}
+
+func (r *contributionImportBatchRepository) ExistsPostedChecksum(ctx context.Context, tenantID, productID uuid.UUID, checksum string) (bool, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&entity.ContributionImportBatch{}).
+		Where("tenant_id = ? AND product_id = ? AND file_checksum = ? AND status = ?",
+			tenantID, productID, checksum, entity.ContributionImportStatusPosted).
+		Count(&count).Error
+	if err != nil {
+		return false, translateError(err, "contribution import batch")
+	}
+	return count > 0, nil
+}
+
+func (r *contributionImportBatchRepository) List(ctx context.Context, filter *contribution.ImportBatchFilter) ([]*entity.ContributionImportBatch, int64, error) {
+	var batches []*entity.ContributionImportBatch
+	var total int64
+
+	query := r.getDB(ctx).Model(&entity.ContributionImportBatch{}).
+		Where("tenant_id = ? AND product_id = ?", filter.TenantID, filter.ProductID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, "contribution import batch")
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(filter.PerPage).
+		Find(&batches).Error
+	if err != nil {
+		return nil, 0, translateError(err, "contribution import batch")
+	}
+	return batches, total, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type contributionLedgerRepository struct {
+	baseRepository
+}
+
+func NewContributionLedgerRepository(tenants *tenantdb.Router) contribution.LedgerRepository {
+	return &contributionLedgerRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *contributionLedgerRepository) Append(ctx context.Context, entry *entity.ContributionLedgerEntry) error {
+	db := r.getDB(ctx)
+
+	// Lock the participant row so concurrent postings for the same participant
+	// read each other's balances instead of the same stale one.
+	if err := r.LockParticipant(ctx, entry.ParticipantID); err != nil {
+		return err
+	}
+
+	var last struct {
+		EmployerBalance int64
+		EmployeeBalance int64
+	}
+	err := db.Raw(`
+		SELECT employer_balance, employee_balance
+		FROM contribution_ledger_entries
+		WHERE participant_id = ?
+		ORDER BY entry_number DESC
+		LIMIT 1
+	`, entry.ParticipantID).Scan(&last).Error
+	if err != nil {
+		return translateError(err, "contribution ledger entry")
+	}
+
+	entry.EmployerBalance = last.EmployerBalance + entry.EmployerAmount
+	entry.EmployeeBalance = last.EmployeeBalance + entry.EmployeeAmount
+
+	if err := db.Clauses(clause.Returning{}).Create(entry).Error; err != nil {
+		return translateError(err, "contribution ledger entry")
+	}
+	return nil
+}
+
+func (r *contributionLedgerRepository) LockParticipant(ctx context.Context, participantID uuid.UUID) error {
+	var locked uuid.UUID
+	if err := r.getDB(ctx).Raw("SELECT id FROM participants WHERE id = ? FOR UPDATE", participantID).Scan(&locked).Error; err != nil {
+		return translateError(err, "participant")
+	}
+	return nil
+}
+
+func (r *contributionLedgerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionLedgerEntry, error) {
+	var entry entity.ContributionLedgerEntry
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entry).Error; err != nil {
+		return nil, translateError(err, "contribution ledger entry")
+	}
+	return &entry, nil
+}
+
+func (r *contributionLedgerRepository) IsReversed(ctx context.Context, entryID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&entity.ContributionLedgerEntry{}).
+		Where("reverses_entry_id = ?", entryID).
+		Count(&count).Error
+	if err != nil {
+		return false, translateError(err, "contribution ledger entry")
+	}
+	return count > 0, nil
+}
+
+func (r *contributionLedgerRepository) HasContributionForPeriod(ctx context.Context, participantID uuid.UUID, period time.Time) (bool, error) {
+	var count int64
+	err := r.getDB(ctx).Model(&entity.ContributionLedgerEntry{}).
+		Where("participant_id = ? AND period = ?::date AND entry_type = ?",
+			participantID, period.Format("2006-01-02"), entity.ContributionEntryTypeContribution).
+		Where("NOT EXISTS (SELECT 1 FROM contribution_ledger_entries r WHERE r.reverses_entry_id = contribution_ledger_entries.id)").
+		Count(&count).Error
+	if err != nil {
+		return false, translateError(err, "contribution ledger entry")
+	}
+	return count > 0, nil
+}
+
+func (r *contributionLedgerRepository) SumBefore(ctx context.Context, participantID uuid.UUID, before time.Time) (int64, int64, error) {
+	var sums struct {
+		Employer int64
+		Employee int64
+	}
+	err := r.getDB(ctx).Raw(`
+		SELECT COALESCE(SUM(employer_amount), 0) AS employer,
+		       COALESCE(SUM(employee_amount), 0) AS employee
+		FROM contribution_ledger_entries
+		WHERE participant_id = ? AND posting_date < ?::date
+	`, participantID, before.Format("2006-01-02")).Scan(&sums).Error
+	if err != nil {
+		return 0, 0, translateError(err, "contribution ledger entry")
+	}
+	return sums.Employer, sums.Employee, nil
+}
+
+func (r *contributionLedgerRepository) GetBalance(ctx context.Context, participantID uuid.UUID) (int64, int64, error) {
+	var last struct {
+		EmployerBalance int64
+		EmployeeBalance int64
+	}
+	err := r.getDB(ctx).Raw(`
+		SELECT employer_balance, employee_balance
+		FROM contribution_ledger_entries
+		WHERE participant_id = ?
+		ORDER BY entry_number DESC
+		LIMIT 1
+	`, participantID).Scan(&last).Error
+	if err != nil {
+		return 0, 0, translateError(err, "contribution ledger entry")
+	}
+	return last.EmployerBalance, last.EmployeeBalance, nil
+}
+
+func (r *contributionLedgerRepository) ListForStatement(ctx context.Context, filter *contribution.StatementFilter) ([]*entity.ContributionLedgerEntry, error) {
+	query := r.getDB(ctx).Where("participant_id = ?", filter.ParticipantID)
+	if filter.From != nil {
+		query = query.Where("posting_date >= ?::date", filter.From.Format("2006-01-02"))
+	}
+	if filter.To != nil {
+		query = query.Where("posting_date <= ?::date", filter.To.Format("2006-01-02"))
+	}
+
+	var entries []*entity.ContributionLedgerEntry
+	if err := query.Order("posting_date ASC, entry_number ASC").Find(&entries).Error; err != nil {
+		return nil, translateError(err, "contribution ledger entry")
+	}
+	return entries, nil
+}
@@ -15,6 +15,13 @@ func translateError(err error, entityName string) error {
 		return nil
 	}
 
+	// Routing failures, such as a tenant in the middle of a move, already
+	// carry the status to answer with.
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+
 	if stderrors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.ErrNotFound(entityName + " not found")
 	}
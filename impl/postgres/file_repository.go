@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"erp-service/entity"
+	"erp-service/pkg/envelope"
 	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -16,9 +18,9 @@ type fileRepository struct {
 	baseRepository
 }
 
-func NewFileRepository(db *gorm.DB) participant.FileRepository {
+func NewFileRepository(tenants *tenantdb.Router) participant.FileRepository {
 	return &fileRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -39,9 +41,11 @@ func (r *fileRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Fil
 	return &file, nil
 }
 
+// SetPermanent and SetExpiring also apply to derived files (thumbnails) so
+// they share the lifetime of their original.
 func (r *fileRepository) SetPermanent(ctx context.Context, id uuid.UUID) error {
 	result := r.getDB(ctx).Model(&entity.File{}).
-		Where("id = ? AND deleted_at IS NULL", id).
+		Where("(id = ? OR derived_from_file_id = ?) AND deleted_at IS NULL", id, id).
 		Updates(map[string]interface{}{
 			"expires_at": nil,
 			"updated_at": time.Now(),
@@ -57,7 +61,7 @@ func (r *fileRepository) SetPermanent(ctx context.Context, id uuid.UUID) error {
 
 func (r *fileRepository) SetExpiring(ctx context.Context, id uuid.UUID, expiry time.Time) error {
 	result := r.getDB(ctx).Model(&entity.File{}).
-		Where("id = ? AND deleted_at IS NULL", id).
+		Where("(id = ? OR derived_from_file_id = ?) AND deleted_at IS NULL", id, id).
 		Updates(map[string]interface{}{
 			"expires_at": expiry,
 			"updated_at": time.Now(),
@@ -138,3 +142,387 @@ func (r *fileRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *fileRepository) ClaimPendingScan(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	var files []*entity.File
+	err := r.getDB(ctx).Raw(`
+		UPDATE files
+		SET scan_claimed_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM files
+			WHERE scan_status = 'PENDING_SCAN'
+			  AND deleted_at IS NULL
+			  AND scan_claimed_at IS NULL
+			  AND scan_attempts < ?
+			ORDER BY created_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, maxAttempts, limit).Scan(&files).Error
+	if err != nil {
+		return nil, translateError(err, "file")
+	}
+	return files, nil
+}
+
+func (r *fileRepository) ReleaseStaleScanClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	cutoff := time.Now().Add(-age)
+	return r.getDB(ctx).Model(&entity.File{}).
+		Where("scan_claimed_at < ? AND scan_status = ? AND deleted_at IS NULL", cutoff, entity.FileScanStatusPending).
+		Updates(map[string]interface{}{
+			"scan_claimed_at": nil,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// RecordScanFailure releases the claim for a retry, or parks the file as
+// SCAN_FAILED once maxAttempts is reached.
+func (r *fileRepository) RecordScanFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	err := r.getDB(ctx).Exec(`
+		UPDATE files
+		SET scan_attempts = scan_attempts + 1,
+		    scan_claimed_at = NULL,
+		    scan_status = CASE WHEN scan_attempts + 1 >= ? THEN 'SCAN_FAILED' ELSE scan_status END,
+		    updated_at = NOW()
+		WHERE id = ? AND scan_status = 'PENDING_SCAN' AND deleted_at IS NULL
+	`, maxAttempts, id).Error
+	if err != nil {
+		return translateError(err, "file")
+	}
+	return nil
+}
+
+func (r *fileRepository) MarkScanned(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	result := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND scan_status = ? AND deleted_at IS NULL", id, entity.FileScanStatusPending).
+		Updates(map[string]interface{}{
+			"scan_status":     entity.FileScanStatusClean,
+			"scanned_at":      now,
+			"scan_claimed_at": nil,
+			"updated_at":      now,
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file pending scan not found")
+	}
+	return nil
+}
+
+// MarkQuarantined points the record at its quarantine copy and clears the
+// expiry so the cleanup worker keeps the evidence.
+func (r *fileRepository) MarkQuarantined(ctx context.Context, id uuid.UUID, bucket, storageKey, signature string) error {
+	now := time.Now()
+	result := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND scan_status = ? AND deleted_at IS NULL", id, entity.FileScanStatusPending).
+		Updates(map[string]interface{}{
+			"scan_status":     entity.FileScanStatusInfected,
+			"scan_signature":  signature,
+			"scanned_at":      now,
+			"scan_claimed_at": nil,
+			"bucket":          bucket,
+			"storage_key":     storageKey,
+			"expires_at":      nil,
+			"updated_at":      now,
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file pending scan not found")
+	}
+	return nil
+}
+
+// ClaimPendingProcessing only picks originals that passed the malware scan;
+// derived files such as thumbnails are never reprocessed.
+func (r *fileRepository) ClaimPendingProcessing(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	var files []*entity.File
+	err := r.getDB(ctx).Raw(`
+		UPDATE files
+		SET process_claimed_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM files
+			WHERE processing_status = 'PENDING'
+			  AND scan_status = 'CLEAN'
+			  AND derived_from_file_id IS NULL
+			  AND deleted_at IS NULL
+			  AND process_claimed_at IS NULL
+			  AND process_attempts < ?
+			ORDER BY created_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, maxAttempts, limit).Scan(&files).Error
+	if err != nil {
+		return nil, translateError(err, "file")
+	}
+	return files, nil
+}
+
+func (r *fileRepository) ReleaseStaleProcessClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	cutoff := time.Now().Add(-age)
+	return r.getDB(ctx).Model(&entity.File{}).
+		Where("process_claimed_at < ? AND processing_status = ? AND deleted_at IS NULL", cutoff, entity.FileProcessingStatusPending).
+		Updates(map[string]interface{}{
+			"process_claimed_at": nil,
+			"updated_at":         time.Now(),
+		}).Error
+}
+
+// MarkProcessed points the record at the normalized object and links the
+// thumbnail. The thumbnail inherits the original's current expiry so it is
+// cleaned up, or kept, together with it.
+func (r *fileRepository) MarkProcessed(ctx context.Context, id uuid.UUID, storageKey, contentType string, sizeBytes int64, env *envelope.Envelope, thumbnailFileID uuid.UUID) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"processing_status":  entity.FileProcessingStatusDone,
+		"process_claimed_at": nil,
+		"storage_key":        storageKey,
+		"content_type":       contentType,
+		"size_bytes":         sizeBytes,
+		"thumbnail_file_id":  thumbnailFileID,
+		"updated_at":         now,
+	}
+	if env != nil {
+		updates["encryption_key_id"] = env.KeyID
+		updates["wrapped_data_key"] = env.WrappedKey
+		updates["data_key_rewrapped_at"] = nil
+	}
+	result := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND processing_status = ? AND deleted_at IS NULL", id, entity.FileProcessingStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file pending processing not found")
+	}
+
+	err := r.getDB(ctx).Exec(`
+		UPDATE files
+		SET expires_at = (SELECT expires_at FROM files WHERE id = ?), updated_at = ?
+		WHERE id = ?
+	`, id, now, thumbnailFileID).Error
+	if err != nil {
+		return translateError(err, "file")
+	}
+	return nil
+}
+
+func (r *fileRepository) MarkProcessingSkipped(ctx context.Context, id uuid.UUID) error {
+	err := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND processing_status = ? AND deleted_at IS NULL", id, entity.FileProcessingStatusPending).
+		Updates(map[string]interface{}{
+			"processing_status":  entity.FileProcessingStatusSkipped,
+			"process_claimed_at": nil,
+			"updated_at":         time.Now(),
+		}).Error
+	if err != nil {
+		return translateError(err, "file")
+	}
+	return nil
+}
+
+// RecordProcessingFailure releases the claim for a retry, or parks the file
+// as FAILED once maxAttempts is reached.
+func (r *fileRepository) RecordProcessingFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	err := r.getDB(ctx).Exec(`
+		UPDATE files
+		SET process_attempts = process_attempts + 1,
+		    process_claimed_at = NULL,
+		    processing_status = CASE WHEN process_attempts + 1 >= ? THEN 'FAILED' ELSE processing_status END,
+		    updated_at = NOW()
+		WHERE id = ? AND processing_status = 'PENDING' AND deleted_at IS NULL
+	`, maxAttempts, id).Error
+	if err != nil {
+		return translateError(err, "file")
+	}
+	return nil
+}
+
+// ListDueForRewrap returns encrypted files whose data key was wrapped (or
+// last rewrapped) before the cutoff, oldest first.
+func (r *fileRepository) ListDueForRewrap(ctx context.Context, rewrappedBefore time.Time, limit int) ([]*entity.File, error) {
+	var files []*entity.File
+	err := r.getDB(ctx).
+		Where("wrapped_data_key IS NOT NULL AND deleted_at IS NULL").
+		Where("COALESCE(data_key_rewrapped_at, created_at) < ?", rewrappedBefore).
+		Order("COALESCE(data_key_rewrapped_at, created_at) ASC").
+		Limit(limit).
+		Find(&files).Error
+	if err != nil {
+		return nil, translateError(err, "file")
+	}
+	return files, nil
+}
+
+// UpdateWrappedDataKey swaps the wrapped key only if it is still the one
+// that was rewrapped, so a concurrent re-encryption of the object wins.
+func (r *fileRepository) UpdateWrappedDataKey(ctx context.Context, id uuid.UUID, oldWrappedKey, newWrappedKey string) error {
+	now := time.Now()
+	result := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND wrapped_data_key = ? AND deleted_at IS NULL", id, oldWrappedKey).
+		Updates(map[string]interface{}{
+			"wrapped_data_key":      newWrappedKey,
+			"data_key_rewrapped_at": now,
+			"updated_at":            now,
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file with this data key not found")
+	}
+	return nil
+}
+
+// ClaimDueForPurge claims permanent originals whose retention has run out
+// under the tenant's policies. For each retention event the policy for the
+// exact document type wins over the '*' policy. Files under legal hold, of a
+// participant under legal hold, or attached to a claim that is still being
+// decided are never claimed. A participant merged into another is deleted
+// but lives on in the survivor, so PARTICIPANT_DELETED does not apply to it.
+func (r *fileRepository) ClaimDueForPurge(ctx context.Context, limit int) ([]*entity.File, error) {
+	var files []*entity.File
+	err := r.getDB(ctx).Raw(`
+		UPDATE files
+		SET purge_claimed_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT f.id FROM files f
+			LEFT JOIN participants p ON p.id = f.participant_id
+			WHERE f.expires_at IS NULL
+			  AND f.derived_from_file_id IS NULL
+			  AND f.deleted_at IS NULL
+			  AND f.purge_claimed_at IS NULL
+			  AND NOT f.legal_hold
+			  AND NOT COALESCE(p.legal_hold, FALSE)
+			  AND NOT EXISTS (
+				SELECT 1 FROM claim_documents cd
+				JOIN claims c ON c.id = cd.claim_id
+				WHERE cd.file_id = f.id AND c.status NOT IN ('PAID', 'REJECTED')
+			  )
+			  AND EXISTS (
+				SELECT 1 FROM (
+					SELECT DISTINCT ON (rp.retention_event) rp.retention_event, rp.retention_days
+					FROM file_retention_policies rp
+					WHERE rp.tenant_id = f.tenant_id
+					  AND (rp.document_type = f.document_type OR rp.document_type = '*')
+					ORDER BY rp.retention_event, rp.document_type = '*'
+				) pol
+				WHERE CASE pol.retention_event
+					WHEN 'UPLOADED' THEN f.created_at
+					WHEN 'PARTICIPANT_REJECTED' THEN
+						CASE WHEN p.status = 'REJECTED' AND p.deleted_at IS NULL THEN p.rejected_at END
+					WHEN 'PARTICIPANT_TERMINATED' THEN
+						CASE WHEN p.status IN ('TERMINATED', 'RETIRED', 'DECEASED', 'TRANSFERRED_OUT') AND p.deleted_at IS NULL
+						THEN COALESCE(p.status_effective_date, (
+							SELECT MAX(h.changed_at) FROM participant_status_history h
+							WHERE h.participant_id = p.id AND h.to_status = p.status
+						)) END
+					WHEN 'PARTICIPANT_DELETED' THEN
+						CASE WHEN p.merged_into_id IS NULL THEN p.deleted_at END
+				END + make_interval(days => pol.retention_days) <= NOW()
+			  )
+			ORDER BY f.created_at ASC
+			LIMIT ?
+			FOR UPDATE OF f SKIP LOCKED
+		)
+		RETURNING *
+	`, limit).Scan(&files).Error
+	if err != nil {
+		return nil, translateError(err, "file")
+	}
+	return files, nil
+}
+
+func (r *fileRepository) ReleaseStalePurgeClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	cutoff := time.Now().Add(-age)
+	return r.getDB(ctx).Model(&entity.File{}).
+		Where("purge_claimed_at < ? AND deleted_at IS NULL", cutoff).
+		Updates(map[string]interface{}{
+			"purge_claimed_at": nil,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+func (r *fileRepository) ReleasePurgeClaim(ctx context.Context, id uuid.UUID) error {
+	err := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"purge_claimed_at": nil,
+			"updated_at":       time.Now(),
+		}).Error
+	if err != nil {
+		return translateError(err, "file")
+	}
+	return nil
+}
+
+// ListDerived returns the files derived from id, including soft-deleted
+// ones, so a purge can remove every object it owns.
+func (r *fileRepository) ListDerived(ctx context.Context, id uuid.UUID) ([]*entity.File, error) {
+	var files []*entity.File
+	err := r.getDB(ctx).Unscoped().
+		Where("derived_from_file_id = ?", id).
+		Find(&files).Error
+	if err != nil {
+		return nil, translateError(err, "file")
+	}
+	return files, nil
+}
+
+// Purge hard-deletes a file row together with its derived files and the
+// claim document entries that reference it. Run it in a transaction.
+func (r *fileRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	db := r.getDB(ctx)
+	if err := db.Exec(`UPDATE files SET thumbnail_file_id = NULL WHERE id = ?`, id).Error; err != nil {
+		return translateError(err, "file")
+	}
+	if err := db.Exec(`DELETE FROM claim_documents WHERE file_id = ?`, id).Error; err != nil {
+		return translateError(err, "claim document")
+	}
+	if err := db.Exec(`DELETE FROM files WHERE derived_from_file_id = ?`, id).Error; err != nil {
+		return translateError(err, "file")
+	}
+	result := db.Exec(`DELETE FROM files WHERE id = ?`, id)
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file not found")
+	}
+	return nil
+}
+
+// SetLegalHold places or lifts a legal hold. Lifting clears the reason and
+// who set it; the audit log keeps the history.
+func (r *fileRepository) SetLegalHold(ctx context.Context, id uuid.UUID, hold bool, reason *string, setBy uuid.UUID) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"legal_hold":        hold,
+		"legal_hold_reason": nil,
+		"legal_hold_set_by": nil,
+		"legal_hold_set_at": nil,
+		"updated_at":        now,
+	}
+	if hold {
+		updates["legal_hold_reason"] = reason
+		updates["legal_hold_set_by"] = setBy
+		updates["legal_hold_set_at"] = now
+	}
+	result := r.getDB(ctx).Model(&entity.File{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(updates)
+	if result.Error != nil {
+		return translateError(result.Error, "file")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("file not found")
+	}
+	return nil
+}
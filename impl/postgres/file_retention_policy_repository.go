@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"erp-service/entity"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/retention"
+
+	"github.com/google/uuid"
+)
+
+type fileRetentionPolicyRepository struct {
+	baseRepository
+}
+
+func NewFileRetentionPolicyRepository(tenants *tenantdb.Router) retention.PolicyRepository {
+	return &fileRetentionPolicyRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *fileRetentionPolicyRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.FileRetentionPolicy, error) {
+	var policies []*entity.FileRetentionPolicy
+	err := r.getDB(ctx).Where("tenant_id = ?", tenantID).
+		Order("document_type ASC, retention_event ASC").
+		Find(&policies).Error
+	if err != nil {
+		return nil, translateError(err, "retention policy")
+	}
+	return policies, nil
+}
+
+func (r *fileRetentionPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.FileRetentionPolicy, error) {
+	var policy entity.FileRetentionPolicy
+	err := r.getDB(ctx).Where("id = ?", id).First(&policy).Error
+	if err != nil {
+		return nil, translateError(err, "retention policy")
+	}
+	return &policy, nil
+}
+
+func (r *fileRetentionPolicyRepository) GetByRule(ctx context.Context, tenantID uuid.UUID, documentType string, event entity.RetentionEvent) (*entity.FileRetentionPolicy, error) {
+	var policy entity.FileRetentionPolicy
+	err := r.getDB(ctx).
+		Where("tenant_id = ? AND document_type = ? AND retention_event = ?", tenantID, documentType, event).
+		First(&policy).Error
+	if err != nil {
+		return nil, translateError(err, "retention policy")
+	}
+	return &policy, nil
+}
+
+func (r *fileRetentionPolicyRepository) Create(ctx context.Context, policy *entity.FileRetentionPolicy) error {
+	if err := r.getDB(ctx).Create(policy).Error; err != nil {
+		return translateError(err, "retention policy")
+	}
+	return nil
+}
+
+func (r *fileRetentionPolicyRepository) Update(ctx context.Context, policy *entity.FileRetentionPolicy) error {
+	if err := r.getDB(ctx).Save(policy).Error; err != nil {
+		return translateError(err, "retention policy")
+	}
+	return nil
+}
+
+func (r *fileRetentionPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("id = ?", id).Delete(&entity.FileRetentionPolicy{})
+	if result.Error != nil {
+		return translateError(result.Error, "retention policy")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("retention policy not found")
+	}
+	return nil
+}
@@ -121,7 +121,9 @@ func (r *masterdataItemRepository) GetByCode(ctx context.Context, categoryID uui
 		Where("category_id = ? AND code = ? AND deleted_at IS NULL", categoryID, code)
 
 	if tenantID != nil {
-		query = query.Where("(tenant_id IS NULL OR tenant_id = ?)", *tenantID)
+		// A tenant's own item overrides the global item with the same code.
+		query = query.Where("(tenant_id IS NULL OR tenant_id = ?)", *tenantID).
+			Order("tenant_id IS NULL")
 	} else {
 		query = query.Where("tenant_id IS NULL")
 	}
@@ -280,7 +282,9 @@ func (r *masterdataItemRepository) GetDefaultItem(ctx context.Context, categoryI
 		Where("category_id = ? AND is_default = true AND status = ? AND deleted_at IS NULL", categoryID, entity.MasterdataItemStatusActive)
 
 	if tenantID != nil {
-		query = query.Where("(tenant_id IS NULL OR tenant_id = ?)", *tenantID)
+		// A tenant's own item overrides the global item with the same code.
+		query = query.Where("(tenant_id IS NULL OR tenant_id = ?)", *tenantID).
+			Order("tenant_id IS NULL")
 	} else {
 		query = query.Where("tenant_id IS NULL")
 	}
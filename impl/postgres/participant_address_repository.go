@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantAddressRepository struct {
 	baseRepository
 }
 
-func NewParticipantAddressRepository(db *gorm.DB) participant.ParticipantAddressRepository {
+func NewParticipantAddressRepository(tenants *tenantdb.Router) participant.ParticipantAddressRepository {
 	return &participantAddressRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -49,6 +50,21 @@ func (r *participantAddressRepository) ListByParticipantID(ctx context.Context,
 	return addresses, nil
 }
 
+func (r *participantAddressRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantAddress, error) {
+	var addresses []*entity.ParticipantAddress
+	if len(participantIDs) == 0 {
+		return addresses, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Order("is_primary DESC, created_at ASC").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, translateError(err, "participant address")
+	}
+	return addresses, nil
+}
+
 func (r *participantAddressRepository) Update(ctx context.Context, address *entity.ParticipantAddress) error {
 	oldVersion := address.Version
 	address.Version = oldVersion + 1
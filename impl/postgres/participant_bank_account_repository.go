@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantBankAccountRepository struct {
 	baseRepository
 }
 
-func NewParticipantBankAccountRepository(db *gorm.DB) participant.ParticipantBankAccountRepository {
+func NewParticipantBankAccountRepository(tenants *tenantdb.Router) participant.ParticipantBankAccountRepository {
 	return &participantBankAccountRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -49,6 +50,21 @@ func (r *participantBankAccountRepository) ListByParticipantID(ctx context.Conte
 	return accounts, nil
 }
 
+func (r *participantBankAccountRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBankAccount, error) {
+	var accounts []*entity.ParticipantBankAccount
+	if len(participantIDs) == 0 {
+		return accounts, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Order("is_primary DESC, created_at ASC").
+		Find(&accounts).Error
+	if err != nil {
+		return nil, translateError(err, "participant bank account")
+	}
+	return accounts, nil
+}
+
 func (r *participantBankAccountRepository) Update(ctx context.Context, account *entity.ParticipantBankAccount) error {
 	oldVersion := account.Version
 	account.Version = oldVersion + 1
@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantBeneficiaryRepository struct {
 	baseRepository
 }
 
-func NewParticipantBeneficiaryRepository(db *gorm.DB) participant.ParticipantBeneficiaryRepository {
+func NewParticipantBeneficiaryRepository(tenants *tenantdb.Router) participant.ParticipantBeneficiaryRepository {
 	return &participantBeneficiaryRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -49,6 +50,21 @@ func (r *participantBeneficiaryRepository) ListByParticipantID(ctx context.Conte
 	return beneficiaries, nil
 }
 
+func (r *participantBeneficiaryRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBeneficiary, error) {
+	var beneficiaries []*entity.ParticipantBeneficiary
+	if len(participantIDs) == 0 {
+		return beneficiaries, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Order("created_at ASC").
+		Find(&beneficiaries).Error
+	if err != nil {
+		return nil, translateError(err, "participant beneficiary")
+	}
+	return beneficiaries, nil
+}
+
 func (r *participantBeneficiaryRepository) Update(ctx context.Context, beneficiary *entity.ParticipantBeneficiary) error {
 	oldVersion := beneficiary.Version
 	beneficiary.Version = oldVersion + 1
@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"erp-service/entity"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type participantDuplicateRepository struct {
+	baseRepository
+}
+
+func NewParticipantDuplicateRepository(tenants *tenantdb.Router) participant.ParticipantDuplicateRepository {
+	return &participantDuplicateRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+// FindCandidates returns every participant sharing a KTP, identity or bank
+// account number, then up to Limit more matched only on the broad keys
+// (phone, date of birth, name prefix), newest first. Weak matches never
+// crowd out strong ones.
+func (r *participantDuplicateRepository) FindCandidates(ctx context.Context, criteria *participant.DuplicateSearchCriteria) ([]*entity.Participant, error) {
+	var (
+		strong, weak         []string
+		strongArgs, weakArgs []interface{}
+	)
+
+	if len(criteria.IdentityNumbers) > 0 {
+		ktpClause, ktpArgs, err := piiMatch(ctx, "p.ktp_number", "p.ktp_number_bidx", criteria.IdentityNumbers...)
+		if err != nil {
+			return nil, err
+		}
+		identityClause, identityArgs, err := piiMatch(ctx, "pi.identity_number", "pi.identity_number_bidx", criteria.IdentityNumbers...)
+		if err != nil {
+			return nil, err
+		}
+		strong = append(strong,
+			ktpClause,
+			"EXISTS (SELECT 1 FROM participant_identities pi WHERE pi.participant_id = p.id AND pi.deleted_at IS NULL AND "+identityClause+")",
+		)
+		strongArgs = append(append(strongArgs, ktpArgs...), identityArgs...)
+	}
+	if len(criteria.BankAccountNumbers) > 0 {
+		accountClause, accountArgs, err := piiMatch(ctx, "ba.account_number", "ba.account_number_bidx", criteria.BankAccountNumbers...)
+		if err != nil {
+			return nil, err
+		}
+		strong = append(strong,
+			"EXISTS (SELECT 1 FROM participant_bank_accounts ba WHERE ba.participant_id = p.id AND ba.deleted_at IS NULL AND "+accountClause+")",
+		)
+		strongArgs = append(strongArgs, accountArgs...)
+	}
+	if criteria.PhoneSuffix != "" {
+		weak = append(weak, "regexp_replace(p.phone_number, '[^0-9]', '', 'g') LIKE ?")
+		weakArgs = append(weakArgs, "%"+criteria.PhoneSuffix)
+	}
+	if criteria.DateOfBirth != nil {
+		weak = append(weak, "p.date_of_birth = ?::date")
+		weakArgs = append(weakArgs, criteria.DateOfBirth.Format("2006-01-02"))
+	}
+	if criteria.NamePrefix != "" {
+		weak = append(weak, "p.full_name ILIKE ?")
+		weakArgs = append(weakArgs, "%"+escapeILIKE(criteria.NamePrefix)+"%")
+	}
+
+	participants := []*entity.Participant{}
+	if len(strong) > 0 {
+		err := r.candidateQuery(ctx, criteria).
+			Where("("+strings.Join(strong, " OR ")+")", strongArgs...).
+			Order("p.created_at DESC").
+			Find(&participants).Error
+		if err != nil {
+			return nil, translateError(err, "participant")
+		}
+	}
+	if len(weak) == 0 {
+		return participants, nil
+	}
+
+	limit := criteria.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := r.candidateQuery(ctx, criteria).Where("("+strings.Join(weak, " OR ")+")", weakArgs...)
+	if len(strong) > 0 {
+		// A NULL KTP makes the strong predicate NULL rather than false.
+		query = query.Where("NOT COALESCE("+strings.Join(strong, " OR ")+", false)", strongArgs...)
+	}
+	var weakMatches []*entity.Participant
+	err := query.Order("p.created_at DESC").Limit(limit).Find(&weakMatches).Error
+	if err != nil {
+		return nil, translateError(err, "participant")
+	}
+	return append(participants, weakMatches...), nil
+}
+
+func (r *participantDuplicateRepository) candidateQuery(ctx context.Context, criteria *participant.DuplicateSearchCriteria) *gorm.DB {
+	query := r.getDB(ctx).Table("participants AS p").
+		Select("p.*").
+		Where("p.id <> ? AND p.deleted_at IS NULL", criteria.ExcludeParticipantID)
+	if criteria.TenantID != nil {
+		query = query.Where("p.tenant_id = ?", *criteria.TenantID)
+	}
+	if criteria.ProductID != nil {
+		query = query.Where("p.product_id = ?", *criteria.ProductID)
+	}
+	return query
+}
+
+func (r *participantDuplicateRepository) UpsertCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error {
+	fields := candidate.MatchedFields
+	if fields == nil {
+		fields = []string{}
+	}
+	matchedFields, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("marshal matched fields: %w", err)
+	}
+
+	err = r.getDB(ctx).Exec(`
+		INSERT INTO participant_duplicate_candidates
+			(tenant_id, product_id, participant_id, matched_participant_id, matched_tenant_id, score, matched_fields)
+		VALUES (?, ?, ?, ?, ?, ?, ?::jsonb)
+		ON CONFLICT (LEAST(participant_id, matched_participant_id), GREATEST(participant_id, matched_participant_id))
+		DO UPDATE SET
+			score          = EXCLUDED.score,
+			matched_fields = EXCLUDED.matched_fields,
+			version        = participant_duplicate_candidates.version + 1
+		WHERE participant_duplicate_candidates.status = 'PENDING'
+	`,
+		candidate.TenantID, candidate.ProductID, candidate.ParticipantID, candidate.MatchedParticipantID,
+		candidate.MatchedTenantID, candidate.Score, string(matchedFields),
+	).Error
+	if err != nil {
+		return translateError(err, "participant duplicate candidate")
+	}
+	return nil
+}
+
+func (r *participantDuplicateRepository) GetCandidateByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantDuplicateCandidate, error) {
+	var candidate entity.ParticipantDuplicateCandidate
+	if err := r.getDB(ctx).Where("id = ?", id).First(&candidate).Error; err != nil {
+		return nil, translateError(err, "participant duplicate candidate")
+	}
+	return &candidate, nil
+}
+
+func (r *participantDuplicateRepository) ListCandidates(ctx context.Context, filter *participant.DuplicateCandidateFilter) ([]*entity.ParticipantDuplicateCandidate, int64, error) {
+	var candidates []*entity.ParticipantDuplicateCandidate
+	var total int64
+
+	query := r.getDB(ctx).Model(&entity.ParticipantDuplicateCandidate{})
+	if filter.TenantID != nil {
+		query = query.Where("tenant_id = ?", *filter.TenantID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, "participant duplicate candidate")
+	}
+
+	offset := (filter.Page - 1) * filter.PerPage
+	err := query.Order("score DESC, created_at DESC").
+		Offset(offset).
+		Limit(filter.PerPage).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, 0, translateError(err, "participant duplicate candidate")
+	}
+	return candidates, total, nil
+}
+
+func (r *participantDuplicateRepository) UpdateCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error {
+	oldVersion := candidate.Version
+	candidate.Version = oldVersion + 1
+
+	result := r.getDB(ctx).Where("version = ?", oldVersion).Save(candidate)
+	if result.Error != nil {
+		candidate.Version = oldVersion
+		return translateError(result.Error, "participant duplicate candidate")
+	}
+	if result.RowsAffected == 0 {
+		candidate.Version = oldVersion
+		return apperrors.ErrConflict("duplicate candidate was modified by another request")
+	}
+	return nil
+}
+
+func (r *participantDuplicateRepository) MarkPairMerged(ctx context.Context, sourceID, targetID, reviewedBy uuid.UUID) error {
+	err := r.getDB(ctx).Exec(`
+		INSERT INTO participant_duplicate_candidates
+			(tenant_id, product_id, participant_id, matched_participant_id, matched_tenant_id, score, matched_fields,
+			 status, reviewed_by, reviewed_at)
+		SELECT s.tenant_id, s.product_id, s.id, t.id, t.tenant_id, 0, '[]'::jsonb, 'MERGED', ?, NOW()
+		FROM participants s, participants t
+		WHERE s.id = ? AND t.id = ?
+		ON CONFLICT (LEAST(participant_id, matched_participant_id), GREATEST(participant_id, matched_participant_id))
+		DO UPDATE SET
+			status      = 'MERGED',
+			reviewed_by = EXCLUDED.reviewed_by,
+			reviewed_at = EXCLUDED.reviewed_at,
+			version     = participant_duplicate_candidates.version + 1
+	`, reviewedBy, sourceID, targetID).Error
+	if err != nil {
+		return translateError(err, "participant duplicate candidate")
+	}
+	return nil
+}
+
+func (r *participantDuplicateRepository) HasFinancialRecords(ctx context.Context, participantID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.getDB(ctx).Raw(`
+		SELECT EXISTS (SELECT 1 FROM contribution_ledger_entries WHERE participant_id = @id)
+			OR EXISTS (SELECT 1 FROM claims WHERE participant_id = @id)
+	`, map[string]interface{}{"id": participantID}).Scan(&exists).Error
+	if err != nil {
+		return false, translateError(err, "participant")
+	}
+	return exists, nil
+}
+
+// ReassignChildRecords moves every live child row of sourceID onto targetID.
+// One-to-one records (employment, pension) are only moved when the target has
+// none; otherwise the source copy is left behind and disappears with it.
+func (r *participantDuplicateRepository) ReassignChildRecords(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	db := r.getDB(ctx)
+
+	statements := []string{
+		`UPDATE participant_identities SET participant_id = @target WHERE participant_id = @source AND deleted_at IS NULL`,
+		`UPDATE participant_addresses SET participant_id = @target WHERE participant_id = @source AND deleted_at IS NULL`,
+		`UPDATE participant_bank_accounts SET is_primary = false
+			WHERE participant_id = @source AND deleted_at IS NULL
+			  AND EXISTS (SELECT 1 FROM participant_bank_accounts t WHERE t.participant_id = @target AND t.is_primary AND t.deleted_at IS NULL)`,
+		`UPDATE participant_bank_accounts SET participant_id = @target WHERE participant_id = @source AND deleted_at IS NULL`,
+		`UPDATE participant_family_members SET participant_id = @target WHERE participant_id = @source AND deleted_at IS NULL`,
+		`UPDATE participant_beneficiaries SET participant_id = @target WHERE participant_id = @source AND deleted_at IS NULL`,
+		`UPDATE participant_employments SET participant_id = @target
+			WHERE participant_id = @source AND deleted_at IS NULL
+			  AND NOT EXISTS (SELECT 1 FROM participant_employments t WHERE t.participant_id = @target AND t.deleted_at IS NULL)`,
+		`UPDATE participant_pensions SET participant_id = @target
+			WHERE participant_id = @source AND deleted_at IS NULL
+			  AND NOT EXISTS (SELECT 1 FROM participant_pensions t WHERE t.participant_id = @target AND t.deleted_at IS NULL)`,
+		`UPDATE participant_status_history SET participant_id = @target WHERE participant_id = @source`,
+		`UPDATE files SET participant_id = @target WHERE participant_id = @source`,
+	}
+
+	params := map[string]interface{}{"source": sourceID, "target": targetID}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt, params).Error; err != nil {
+			return translateError(err, "participant")
+		}
+	}
+	return nil
+}
+
+func (r *participantDuplicateRepository) MarkMerged(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	result := r.getDB(ctx).Model(&entity.Participant{}).
+		Where("id = ? AND deleted_at IS NULL", sourceID).
+		Updates(map[string]interface{}{
+			"merged_into_id": targetID,
+			"deleted_at":     gorm.Expr("NOW()"),
+			"version":        gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "participant")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound("participant not found")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantEmploymentRepository struct {
 	baseRepository
 }
 
-func NewParticipantEmploymentRepository(db *gorm.DB) participant.ParticipantEmploymentRepository {
+func NewParticipantEmploymentRepository(tenants *tenantdb.Router) participant.ParticipantEmploymentRepository {
 	return &participantEmploymentRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -46,6 +47,20 @@ func (r *participantEmploymentRepository) GetByParticipantID(ctx context.Context
 	return &employment, nil
 }
 
+func (r *participantEmploymentRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantEmployment, error) {
+	var employments []*entity.ParticipantEmployment
+	if len(participantIDs) == 0 {
+		return employments, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Find(&employments).Error
+	if err != nil {
+		return nil, translateError(err, "participant employment")
+	}
+	return employments, nil
+}
+
 func (r *participantEmploymentRepository) Update(ctx context.Context, employment *entity.ParticipantEmployment) error {
 	oldVersion := employment.Version
 	employment.Version = oldVersion + 1
@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantFamilyMemberRepository struct {
 	baseRepository
 }
 
-func NewParticipantFamilyMemberRepository(db *gorm.DB) participant.ParticipantFamilyMemberRepository {
+func NewParticipantFamilyMemberRepository(tenants *tenantdb.Router) participant.ParticipantFamilyMemberRepository {
 	return &participantFamilyMemberRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -49,6 +50,21 @@ func (r *participantFamilyMemberRepository) ListByParticipantID(ctx context.Cont
 	return members, nil
 }
 
+func (r *participantFamilyMemberRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantFamilyMember, error) {
+	var members []*entity.ParticipantFamilyMember
+	if len(participantIDs) == 0 {
+		return members, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Order("created_at ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, translateError(err, "participant family member")
+	}
+	return members, nil
+}
+
 func (r *participantFamilyMemberRepository) Update(ctx context.Context, member *entity.ParticipantFamilyMember) error {
 	oldVersion := member.Version
 	member.Version = oldVersion + 1
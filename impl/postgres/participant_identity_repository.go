@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantIdentityRepository struct {
 	baseRepository
 }
 
-func NewParticipantIdentityRepository(db *gorm.DB) participant.ParticipantIdentityRepository {
+func NewParticipantIdentityRepository(tenants *tenantdb.Router) participant.ParticipantIdentityRepository {
 	return &participantIdentityRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -49,6 +50,21 @@ func (r *participantIdentityRepository) ListByParticipantID(ctx context.Context,
 	return identities, nil
 }
 
+func (r *participantIdentityRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantIdentity, error) {
+	var identities []*entity.ParticipantIdentity
+	if len(participantIDs) == 0 {
+		return identities, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Order("created_at ASC").
+		Find(&identities).Error
+	if err != nil {
+		return nil, translateError(err, "participant identity")
+	}
+	return identities, nil
+}
+
 func (r *participantIdentityRepository) Update(ctx context.Context, identity *entity.ParticipantIdentity) error {
 	oldVersion := identity.Version
 	identity.Version = oldVersion + 1
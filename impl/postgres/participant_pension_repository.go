@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -15,9 +16,9 @@ type participantPensionRepository struct {
 	baseRepository
 }
 
-func NewParticipantPensionRepository(db *gorm.DB) participant.ParticipantPensionRepository {
+func NewParticipantPensionRepository(tenants *tenantdb.Router) participant.ParticipantPensionRepository {
 	return &participantPensionRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -46,6 +47,20 @@ func (r *participantPensionRepository) GetByParticipantID(ctx context.Context, p
 	return &pension, nil
 }
 
+func (r *participantPensionRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantPension, error) {
+	var pensions []*entity.ParticipantPension
+	if len(participantIDs) == 0 {
+		return pensions, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ? AND deleted_at IS NULL", participantIDs).
+		Find(&pensions).Error
+	if err != nil {
+		return nil, translateError(err, "participant pension")
+	}
+	return pensions, nil
+}
+
 func (r *participantPensionRepository) Update(ctx context.Context, pension *entity.ParticipantPension) error {
 	oldVersion := pension.Version
 	pension.Version = oldVersion + 1
@@ -7,6 +7,7 @@ import (
 
 	"erp-service/entity"
 	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -34,9 +35,9 @@ type participantRepository struct {
 	baseRepository
 }
 
-func NewParticipantRepository(db *gorm.DB) participant.ParticipantRepository {
+func NewParticipantRepository(tenants *tenantdb.Router) participant.ParticipantRepository {
 	return &participantRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -54,8 +55,14 @@ func (r *participantRepository) GetByKTPAndPensionNumber(ctx context.Context, kt
 		PensionNumber        *string   `gorm:"column:pension_participant_number"`
 	}
 
+	ktpClause, args, err := piiMatch(ctx, "p.ktp_number", "p.ktp_number_bidx", ktpNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GetByKTPAndPensionNumber: %w", err)
+	}
+	args = append(args, pensionNumber, tenantID, productID)
+
 	var row joinedRow
-	err := r.getDB(ctx).Raw(`
+	err = r.getDB(ctx).Raw(`
 		SELECT
 			p.*,
 			pp.id                  AS pension_id,
@@ -65,13 +72,13 @@ func (r *participantRepository) GetByKTPAndPensionNumber(ctx context.Context, kt
 		JOIN participant_pensions pp
 		  ON pp.participant_id = p.id
 		  AND pp.deleted_at IS NULL
-		WHERE p.ktp_number     = ?
+		WHERE `+ktpClause+`
 		  AND pp.participant_number = ?
 		  AND p.tenant_id      = ?
 		  AND p.product_id     = ?
 		  AND p.deleted_at     IS NULL
 		LIMIT 1
-	`, ktpNumber, pensionNumber, tenantID, productID).Scan(&row).Error
+	`, args...).Scan(&row).Error
 
 	if err != nil {
 		return nil, nil, fmt.Errorf("GetByKTPAndPensionNumber: %w", err)
@@ -98,10 +105,28 @@ func (r *participantRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	return &participant, nil
 }
 
+func (r *participantRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Participant, error) {
+	var participants []*entity.Participant
+	if len(ids) == 0 {
+		return participants, nil
+	}
+	err := r.getDB(ctx).Where("id IN ? AND deleted_at IS NULL", ids).Find(&participants).Error
+	if err != nil {
+		return nil, translateError(err, "participant")
+	}
+	return participants, nil
+}
+
 func (r *participantRepository) GetByKTPNumber(ctx context.Context, tenantID, productID uuid.UUID, ktpNumber string) (*entity.Participant, error) {
+	ktpClause, ktpArgs, err := piiMatch(ctx, "ktp_number", "ktp_number_bidx", ktpNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	var participant entity.Participant
-	err := r.getDB(ctx).
-		Where("tenant_id = ? AND product_id = ? AND ktp_number = ? AND deleted_at IS NULL", tenantID, productID, ktpNumber).
+	err = r.getDB(ctx).
+		Where("tenant_id = ? AND product_id = ? AND deleted_at IS NULL", tenantID, productID).
+		Where(ktpClause, ktpArgs...).
 		First(&participant).Error
 	if err != nil {
 		return nil, translateError(err, "participant")
@@ -158,10 +183,16 @@ func (r *participantRepository) List(ctx context.Context, filter *participant.Pa
 	}
 
 	if filter.Search != "" {
+		// KTP numbers are encrypted, so they only match exactly.
+		ktpClause, ktpArgs, err := piiMatch(ctx, "ktp_number", "ktp_number_bidx", filter.Search)
+		if err != nil {
+			return nil, 0, err
+		}
 		search := "%" + escapeILIKE(filter.Search) + "%"
+		args := append([]interface{}{search}, ktpArgs...)
 		query = query.Where(
-			"full_name ILIKE ? OR ktp_number ILIKE ? OR employee_number ILIKE ? OR phone_number ILIKE ?",
-			search, search, search, search,
+			"full_name ILIKE ? OR "+ktpClause+" OR employee_number ILIKE ? OR phone_number ILIKE ?",
+			append(args, search, search)...,
 		)
 	}
 
@@ -4,19 +4,19 @@ import (
 	"context"
 
 	"erp-service/entity"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type participantStatusHistoryRepository struct {
 	baseRepository
 }
 
-func NewParticipantStatusHistoryRepository(db *gorm.DB) participant.ParticipantStatusHistoryRepository {
+func NewParticipantStatusHistoryRepository(tenants *tenantdb.Router) participant.ParticipantStatusHistoryRepository {
 	return &participantStatusHistoryRepository{
-		baseRepository: baseRepository{db: db},
+		baseRepository: tenantDataRepository(tenants),
 	}
 }
 
@@ -38,3 +38,18 @@ func (r *participantStatusHistoryRepository) ListByParticipantID(ctx context.Con
 	}
 	return histories, nil
 }
+
+func (r *participantStatusHistoryRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantStatusHistory, error) {
+	var histories []*entity.ParticipantStatusHistory
+	if len(participantIDs) == 0 {
+		return histories, nil
+	}
+	err := r.getDB(ctx).
+		Where("participant_id IN ?", participantIDs).
+		Order("changed_at DESC").
+		Find(&histories).Error
+	if err != nil {
+		return nil, translateError(err, "participant status history")
+	}
+	return histories, nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/pkg/pii"
+	"erp-service/pkg/tenantdb"
+)
+
+type piiColumn struct {
+	table       string
+	column      string
+	indexColumn string
+}
+
+// piiColumns lists every column tagged serializer:pii in the entities.
+var piiColumns = []piiColumn{
+	{table: "participants", column: "ktp_number", indexColumn: "ktp_number_bidx"},
+	{table: "participant_identities", column: "identity_number", indexColumn: "identity_number_bidx"},
+	{table: "participant_bank_accounts", column: "account_number", indexColumn: "account_number_bidx"},
+	{table: "participant_beneficiaries", column: "account_number"},
+	{table: "claim_payment_instructions", column: "account_number"},
+}
+
+type piiBackfillRepository struct {
+	baseRepository
+}
+
+func NewPIIBackfillRepository(tenants *tenantdb.Router) pii.Backfiller {
+	return &piiBackfillRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+type plaintextRow struct {
+	ID    string `gorm:"column:id"`
+	Value string `gorm:"column:value"`
+}
+
+// BackfillBatch rewrites rows in place, bypassing gorm hooks so neither
+// version nor updated_at change. Soft-deleted rows are sealed as well.
+func (r *piiBackfillRepository) BackfillBatch(ctx context.Context, cursor pii.BackfillCursor, limit int) (pii.BackfillResult, error) {
+	var result pii.BackfillResult
+	for _, col := range piiColumns {
+		key := col.table + "." + col.column
+		where := fmt.Sprintf("%[1]s IS NOT NULL AND %[1]s <> '' AND %[1]s NOT LIKE 'pii:%%'", col.column)
+		var args []interface{}
+		if last, ok := cursor[key]; ok {
+			where += " AND id > ?::uuid"
+			args = append(args, last)
+		}
+		args = append(args, limit)
+
+		var rows []plaintextRow
+		err := r.getDB(ctx).Raw(fmt.Sprintf(`
+			SELECT id::text AS id, %[2]s AS value
+			FROM %[1]s
+			WHERE %[3]s
+			ORDER BY id
+			LIMIT ?
+		`, col.table, col.column, where), args...).Scan(&rows).Error
+		if err != nil {
+			return result, translateError(err, col.table)
+		}
+
+		for _, row := range rows {
+			result.Visited++
+			cursor[key] = row.ID
+			if err := r.sealRow(ctx, col, row); err != nil {
+				result.Failures = append(result.Failures, pii.BackfillFailure{Table: col.table, ID: row.ID, Err: err})
+				continue
+			}
+			result.Sealed++
+		}
+	}
+	return result, nil
+}
+
+func (r *piiBackfillRepository) sealRow(ctx context.Context, col piiColumn, row plaintextRow) error {
+	sealed, err := pii.Seal(ctx, col.column, row.Value)
+	if err != nil {
+		return err
+	}
+
+	sets := fmt.Sprintf("%s = ?", col.column)
+	args := []interface{}{sealed}
+	if col.indexColumn != "" {
+		index, err := pii.BlindIndex(ctx, row.Value)
+		if err != nil {
+			return err
+		}
+		sets += fmt.Sprintf(", %s = NULLIF(?, '')", col.indexColumn)
+		args = append(args, index)
+	}
+	args = append(args, row.ID, row.Value)
+
+	// The plaintext guard skips rows the application rewrote meanwhile.
+	err = r.getDB(ctx).Exec(fmt.Sprintf(
+		"UPDATE %s SET %s WHERE id = ?::uuid AND %s = ?",
+		col.table, sets, col.column,
+	), args...).Error
+	if err != nil {
+		return translateError(err, col.table)
+	}
+	return nil
+}
+
+// piiMatch matches column against the normalized values, through the blind
+// index once a row is sealed and through the plaintext while the backfill
+// has not reached it yet (its index is still NULL).
+func piiMatch(ctx context.Context, column, indexColumn string, values ...string) (string, []interface{}, error) {
+	indexes, err := pii.BlindIndexes(ctx, values)
+	if err != nil {
+		return "", nil, err
+	}
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if n := pii.Normalize(value); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	clause := fmt.Sprintf(
+		"(%[2]s IN ? OR (%[2]s IS NULL AND upper(regexp_replace(%[1]s, '[^[:alnum:]]', '', 'g')) IN ?))",
+		column, indexColumn,
+	)
+	return clause, []interface{}{indexes, normalized}, nil
+}
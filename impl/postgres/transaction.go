@@ -4,31 +4,60 @@ import (
 	"context"
 	"fmt"
 
+	"erp-service/pkg/tenantdb"
+
 	"gorm.io/gorm"
 )
 
 type TransactionManager struct {
-	db *gorm.DB
+	db      *gorm.DB
+	tenants *tenantdb.Router
 }
 
 func NewTransactionManager(db *gorm.DB) *TransactionManager {
 	return &TransactionManager{db: db}
 }
 
+// NewTenantTransactionManager also opens a transaction on the dedicated
+// database of the tenant in ctx, if it has one. The two commit one after
+// the other, tenant first, so a failure between them can leave the tenant
+// data committed without the platform side.
+func NewTenantTransactionManager(tenants *tenantdb.Router) *TransactionManager {
+	return &TransactionManager{db: tenants.Platform(), tenants: tenants}
+}
+
 func (m *TransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	if existingTx, ok := getTx(ctx); ok {
+	if existing, ok := getTxSet(ctx); ok {
 
-		return existingTx.Transaction(func(nestedTx *gorm.DB) error {
-			nestedCtx := withTx(ctx, nestedTx)
-			return fn(nestedCtx)
+		return existing.platform.Transaction(func(nestedTx *gorm.DB) error {
+			run := func(nestedTenantTx *gorm.DB) error {
+				return fn(withTx(ctx, txSet{platform: nestedTx, tenant: nestedTenantTx}))
+			}
+			if existing.shared() {
+				return run(nestedTx)
+			}
+			return existing.tenant.Transaction(run)
 		})
 	}
 
+	tenantDB := m.db
+	if m.tenants != nil {
+		var err error
+		if tenantDB, err = m.tenants.DB(ctx); err != nil {
+			return err
+		}
+	}
+
 	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txCtx := withTx(ctx, tx)
-		if err := fn(txCtx); err != nil {
-			return fmt.Errorf("transaction failed: %w", err)
+		run := func(tenantTx *gorm.DB) error {
+			if err := fn(withTx(ctx, txSet{platform: tx, tenant: tenantTx})); err != nil {
+				return fmt.Errorf("transaction failed: %w", err)
+			}
+			return nil
+		}
+		if tenantDB == m.db {
+			return run(tx)
 		}
-		return nil
+		return tenantDB.WithContext(ctx).Transaction(run)
 	})
 }
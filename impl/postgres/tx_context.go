@@ -8,13 +8,34 @@ import (
 
 type txKey struct{}
 
-func withTx(ctx context.Context, tx *gorm.DB) context.Context {
-	return context.WithValue(ctx, txKey{}, tx)
+// txSet is the open transactions of one unit of work. tenant is platform
+// itself unless the tenant's data lives in a dedicated database.
+type txSet struct {
+	platform *gorm.DB
+	tenant   *gorm.DB
+}
+
+func (s txSet) shared() bool {
+	return s.platform == s.tenant
+}
+
+func withTx(ctx context.Context, txs txSet) context.Context {
+	return context.WithValue(ctx, txKey{}, txs)
+}
+
+func getTxSet(ctx context.Context) (txSet, bool) {
+	txs, ok := ctx.Value(txKey{}).(txSet)
+	return txs, ok
 }
 
 func getTx(ctx context.Context) (*gorm.DB, bool) {
-	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
-	return tx, ok
+	txs, ok := getTxSet(ctx)
+	return txs.platform, ok
+}
+
+func getTenantTx(ctx context.Context) (*gorm.DB, bool) {
+	txs, ok := getTxSet(ctx)
+	return txs.tenant, ok
 }
 
 func hasTx(ctx context.Context) bool {
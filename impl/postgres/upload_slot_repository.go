@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type uploadSlotRepository struct {
+	baseRepository
+}
+
+func NewUploadSlotRepository(tenants *tenantdb.Router) participant.UploadSlotRepository {
+	return &uploadSlotRepository{
+		baseRepository: tenantDataRepository(tenants),
+	}
+}
+
+func (r *uploadSlotRepository) Create(ctx context.Context, slot *entity.UploadSlot) error {
+	if err := r.getDB(ctx).Create(slot).Error; err != nil {
+		return translateError(err, "upload slot")
+	}
+	return nil
+}
+
+func (r *uploadSlotRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.UploadSlot, error) {
+	var slot entity.UploadSlot
+
+	err := r.getDB(ctx).Where("id = ?", id).First(&slot).Error
+	if err != nil {
+		return nil, translateError(err, "upload slot")
+	}
+	return &slot, nil
+}
+
+func (r *uploadSlotRepository) ClaimCompletion(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	result := r.getDB(ctx).Model(&entity.UploadSlot{}).
+		Where("id = ? AND completed_at IS NULL AND completing_at IS NULL AND claimed_at IS NULL AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"completing_at": now,
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "upload slot")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrConflict("upload slot is already being completed or is being reaped")
+	}
+	return nil
+}
+
+func (r *uploadSlotRepository) ReleaseCompletion(ctx context.Context, id uuid.UUID) error {
+	err := r.getDB(ctx).Model(&entity.UploadSlot{}).
+		Where("id = ? AND completed_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"completing_at": nil,
+			"updated_at":    time.Now(),
+		}).Error
+	if err != nil {
+		return translateError(err, "upload slot")
+	}
+	return nil
+}
+
+func (r *uploadSlotRepository) Complete(ctx context.Context, id uuid.UUID, fileID uuid.UUID) error {
+	now := time.Now()
+	result := r.getDB(ctx).Model(&entity.UploadSlot{}).
+		Where("id = ? AND completed_at IS NULL AND claimed_at IS NULL AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"completed_at": now,
+			"file_id":      fileID,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return translateError(result.Error, "upload slot")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrConflict("upload slot was already completed or is being reaped")
+	}
+	return nil
+}
+
+func (r *uploadSlotRepository) ClaimExpired(ctx context.Context, limit int) ([]*entity.UploadSlot, error) {
+	var slots []*entity.UploadSlot
+	err := r.getDB(ctx).Raw(`
+		UPDATE upload_slots
+		SET claimed_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM upload_slots
+			WHERE expires_at <= NOW()
+			  AND completed_at IS NULL
+			  AND deleted_at IS NULL
+			  AND claimed_at IS NULL
+			  AND completing_at IS NULL
+			  AND failed_delete_attempts < 5
+			ORDER BY expires_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`, limit).Scan(&slots).Error
+	if err != nil {
+		return nil, translateError(err, "upload slot")
+	}
+	return slots, nil
+}
+
+// ReleaseStaleClaimsOlderThan frees slots whose reaper or completion claim was
+// abandoned, for example by a crashed process.
+func (r *uploadSlotRepository) ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	cutoff := time.Now().Add(-age)
+	db := r.getDB(ctx)
+	err := db.Model(&entity.UploadSlot{}).
+		Where("claimed_at < ? AND deleted_at IS NULL", cutoff).
+		Updates(map[string]interface{}{
+			"claimed_at": nil,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return err
+	}
+	return db.Model(&entity.UploadSlot{}).
+		Where("completing_at < ? AND completed_at IS NULL AND deleted_at IS NULL", cutoff).
+		Updates(map[string]interface{}{
+			"completing_at": nil,
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+func (r *uploadSlotRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	err := r.getDB(ctx).Model(&entity.UploadSlot{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"failed_delete_attempts": gorm.Expr("failed_delete_attempts + 1"),
+			"updated_at":             time.Now(),
+		}).Error
+	if err != nil {
+		return translateError(err, "upload slot")
+	}
+	return nil
+}
+
+func (r *uploadSlotRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	err := r.getDB(ctx).Where("id = ?", id).Delete(&entity.UploadSlot{}).Error
+	if err != nil {
+		return translateError(err, "upload slot")
+	}
+	return nil
+}
@@ -28,11 +28,19 @@ type RateLimitResult struct {
 
 type Job struct {
 	ID        string          `json:"id"`
+	Type      string          `json:"type,omitempty"`
 	Payload   json.RawMessage `json:"payload"`
 	Attempts  int             `json:"attempts"`
 	MaxRetry  int             `json:"max_retry"`
 	CreatedAt time.Time       `json:"created_at"`
 	Error     string          `json:"error,omitempty"`
+	// Trace is the W3C trace context of the request that enqueued the job,
+	// so its run shows up in the same trace.
+	Trace map[string]string `json:"trace,omitempty"`
+
+	// raw is the entry as stored in the processing list, which settling a
+	// leased job has to remove byte for byte.
+	raw string
 }
 
 type Semaphore struct {
@@ -3,4 +3,8 @@ package redis
 const (
 	SemaphorePrefix = "semaphore:%s"
 	LockPrefix      = "lock:%s"
+
+	// NotificationChannelPattern matches the pub/sub channel of every
+	// tenant and product PublishEvent writes to.
+	NotificationChannelPattern = "notify:*"
 )
@@ -1,6 +1,10 @@
 package redis
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 func rateLimitKey(key string) string {
 	return fmt.Sprintf("ratelimit:%s", key)
@@ -21,3 +25,19 @@ func deadLetterKey(queueName string) string {
 func delayedKey(queueName string) string {
 	return fmt.Sprintf("queue:%s:delayed", queueName)
 }
+
+func leaseKey(queueName string) string {
+	return fmt.Sprintf("queue:%s:leases", queueName)
+}
+
+func scheduleKey(name string, slot int64) string {
+	return fmt.Sprintf("schedule:%s:%d", name, slot)
+}
+
+func notificationStreamKey(tenantID, productID uuid.UUID) string {
+	return fmt.Sprintf("notifications:%s:%s", tenantID, productID)
+}
+
+func notificationChannel(tenantID, productID uuid.UUID) string {
+	return fmt.Sprintf("notify:%s:%s", tenantID, productID)
+}
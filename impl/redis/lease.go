@@ -0,0 +1,177 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"erp-service/pkg/errors"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var leaseDequeueScript = goredis.NewScript(`
+	local raw = redis.call("RPOPLPUSH", KEYS[1], KEYS[2])
+	if raw then
+		redis.call("ZADD", KEYS[3], ARGV[1], raw)
+	end
+	return raw
+`)
+
+// settleScript removes a leased entry from the processing list and, when
+// it was still there, pushes its replacement: ARGV[2] is "" to drop it,
+// "list" to RPUSH ARGV[3] onto KEYS[3], or "zset" to add ARGV[3] to KEYS[3]
+// with score ARGV[4]. An entry that is gone was already recovered by
+// another replica and must not be pushed twice.
+var settleScript = goredis.NewScript(`
+	local removed = redis.call("LREM", KEYS[1], 1, ARGV[1])
+	redis.call("ZREM", KEYS[2], ARGV[1])
+	if removed == 0 then
+		return 0
+	end
+	if ARGV[2] == "list" then
+		redis.call("RPUSH", KEYS[3], ARGV[3])
+	elseif ARGV[2] == "zset" then
+		redis.call("ZADD", KEYS[3], ARGV[4], ARGV[3])
+	end
+	return removed
+`)
+
+// DequeueLeased moves the next job to the processing list and leases it
+// until now+lease. A job whose lease runs out before it is settled is put
+// back by RequeueExpired, so a crashed worker never loses it. It does not
+// block: an empty queue returns SentinelQueueEmpty.
+func (r *Redis) DequeueLeased(ctx context.Context, queueName string, lease time.Duration) (*Job, error) {
+	deadline := time.Now().Add(lease).UnixMilli()
+	raw, err := leaseDequeueScript.Run(ctx, r.client,
+		[]string{queueKey(queueName), processingKey(queueName), leaseKey(queueName)},
+		deadline,
+	).Text()
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, errors.SentinelQueueEmpty
+		}
+		return nil, errors.ErrInternal("failed to dequeue job").WithError(err)
+	}
+
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		// Nothing can process it; park it rather than let recovery requeue
+		// it forever.
+		if settleErr := r.settle(ctx, queueName, raw, "list", deadLetterKey(queueName), raw, 0); settleErr != nil {
+			return nil, settleErr
+		}
+		return nil, errors.ErrInternal("failed to unmarshal job").WithError(err)
+	}
+	job.raw = raw
+	job.Attempts++
+	return &job, nil
+}
+
+// ExtendLease pushes the lease of a job being processed to now+lease.
+func (r *Redis) ExtendLease(ctx context.Context, queueName string, job *Job, lease time.Duration) error {
+	err := r.client.ZAddXX(ctx, leaseKey(queueName), goredis.Z{
+		Score:  float64(time.Now().Add(lease).UnixMilli()),
+		Member: job.raw,
+	}).Err()
+	if err != nil {
+		return errors.ErrInternal("failed to extend job lease").WithError(err)
+	}
+	return nil
+}
+
+// CompleteJob removes a leased job for good.
+func (r *Redis) CompleteJob(ctx context.Context, queueName string, job *Job) error {
+	return r.settle(ctx, queueName, job.raw, "", deadLetterKey(queueName), "", 0)
+}
+
+// RetryJob releases a leased job and schedules it again after delay. The
+// attempt count is kept, so MaxRetry is enforced across retries.
+func (r *Redis) RetryJob(ctx context.Context, queueName string, job *Job, errMsg string, delay time.Duration) error {
+	job.Error = errMsg
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.ErrInternal("failed to marshal job").WithError(err)
+	}
+	score := float64(time.Now().Add(delay).Unix())
+	return r.settle(ctx, queueName, job.raw, "zset", delayedKey(queueName), string(data), score)
+}
+
+// DeadLetterJob releases a leased job onto the dead letter list.
+func (r *Redis) DeadLetterJob(ctx context.Context, queueName string, job *Job, errMsg string) error {
+	job.Error = errMsg
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.ErrInternal("failed to marshal job").WithError(err)
+	}
+	return r.settle(ctx, queueName, job.raw, "list", deadLetterKey(queueName), string(data), 0)
+}
+
+// RequeueExpired puts jobs whose lease ran out back on the queue, counting
+// the lost run as an attempt so a job that keeps killing its worker ends up
+// dead-lettered. Unlike RequeueProcessing it leaves live leases alone and is
+// safe to run from every replica.
+func (r *Redis) RequeueExpired(ctx context.Context, queueName string) (int, error) {
+	expired, err := r.client.ZRangeByScore(ctx, leaseKey(queueName), &goredis.ZRangeBy{
+		Min:   "-inf",
+		Max:   formatScore(time.Now().UnixMilli()),
+		Count: leaseRecoverBatch,
+	}).Result()
+	if err != nil {
+		return 0, errors.ErrInternal("failed to list expired leases").WithError(err)
+	}
+
+	requeued := 0
+	for _, raw := range expired {
+		target, data := queueKey(queueName), raw
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			target = deadLetterKey(queueName)
+		} else {
+			job.Attempts++
+			job.Error = "lease expired"
+			if job.Attempts >= job.MaxRetry {
+				target = deadLetterKey(queueName)
+			}
+			encoded, err := json.Marshal(&job)
+			if err != nil {
+				return requeued, errors.ErrInternal("failed to marshal job").WithError(err)
+			}
+			data = string(encoded)
+		}
+		if err := r.settle(ctx, queueName, raw, "list", target, data, 0); err != nil {
+			return requeued, err
+		}
+		requeued++
+	}
+	return requeued, nil
+}
+
+// ClaimSchedule reports whether the caller is the first to claim slot for
+// the named schedule, so only one replica enqueues each periodic run.
+func (r *Redis) ClaimSchedule(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error) {
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	claimed, err := r.client.SetNX(ctx, scheduleKey(name, slot.Unix()), 1, ttl).Result()
+	if err != nil {
+		return false, errors.ErrInternal("failed to claim schedule").WithError(err)
+	}
+	return claimed, nil
+}
+
+func (r *Redis) settle(ctx context.Context, queueName, raw, mode, target, data string, score float64) error {
+	err := settleScript.Run(ctx, r.client,
+		[]string{processingKey(queueName), leaseKey(queueName), target},
+		raw, mode, data, score,
+	).Err()
+	if err != nil {
+		return errors.ErrInternal("failed to settle job").WithError(err)
+	}
+	return nil
+}
+
+func formatScore(score int64) string {
+	return strconv.FormatInt(score, 10)
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// notificationStreamMaxLen is roughly how many recent events per tenant and
+// product are kept for clients resuming after a disconnect.
+const notificationStreamMaxLen = 10000
+
+// PublishEvent appends event to its tenant and product stream, which assigns
+// event.ID, and then fans it out on pub/sub to every WebSocket replica.
+func (r *Redis) PublishEvent(ctx context.Context, event *notify.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.ErrInternal("failed to marshal event").WithError(err)
+	}
+
+	id, err := r.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: notificationStreamKey(event.TenantID, event.ProductID),
+		MaxLen: notificationStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{"event": data},
+	}).Result()
+	if err != nil {
+		return errors.ErrInternal("failed to append event").WithError(err)
+	}
+	event.ID = id
+
+	data, err = json.Marshal(event)
+	if err != nil {
+		return errors.ErrInternal("failed to marshal event").WithError(err)
+	}
+	return r.client.Publish(ctx, notificationChannel(event.TenantID, event.ProductID), data).Err()
+}
+
+// ReplayEvents returns up to limit events of the tenant and product published
+// after cursor, oldest first. gap is true when events after cursor are no
+// longer retained or more than limit are pending; the caller cannot catch up
+// from the stream and has to reload its state instead.
+func (r *Redis) ReplayEvents(ctx context.Context, tenantID, productID uuid.UUID, cursor string, limit int64) ([]*notify.Event, bool, error) {
+	key := notificationStreamKey(tenantID, productID)
+
+	oldest, err := r.client.XRangeN(ctx, key, "-", "+", 1).Result()
+	if err != nil {
+		return nil, false, errors.ErrInternal("failed to read event stream").WithError(err)
+	}
+	if len(oldest) > 0 && notify.IDAfter(oldest[0].ID, cursor) {
+		return nil, true, nil
+	}
+
+	entries, err := r.client.XRangeN(ctx, key, "("+cursor, "+", limit+1).Result()
+	if err != nil {
+		return nil, false, errors.ErrInternal("failed to read event stream").WithError(err)
+	}
+	if int64(len(entries)) > limit {
+		return nil, true, nil
+	}
+
+	events := make([]*notify.Event, 0, len(entries))
+	for _, entry := range entries {
+		raw, ok := entry.Values["event"].(string)
+		if !ok {
+			continue
+		}
+		var event notify.Event
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			continue
+		}
+		event.ID = entry.ID
+		events = append(events, &event)
+	}
+	return events, false, nil
+}
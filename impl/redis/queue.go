@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"erp-service/pkg/errors"
-	"fmt"
+	"erp-service/pkg/tracing"
 	"time"
 
 	"github.com/google/uuid"
 	goredis "github.com/redis/go-redis/v9"
 )
 
+const (
+	delayedPromoteBatch = 100
+	leaseRecoverBatch   = 100
+)
+
 func NewJob(payload any, maxRetry int) (*Job, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -25,7 +30,22 @@ func NewJob(payload any, maxRetry int) (*Job, error) {
 	}, nil
 }
 
+// NewTypedJob is NewJob for processors that dispatch on Job.Type.
+func NewTypedJob(jobType string, payload any, maxRetry int) (*Job, error) {
+	job, err := NewJob(payload, maxRetry)
+	if err != nil {
+		return nil, err
+	}
+	job.Type = jobType
+	return job, nil
+}
+
+// Enqueue records the trace context of ctx on a job that has none yet;
+// jobs put back after a failure keep the trace they were first queued in.
 func (r *Redis) Enqueue(ctx context.Context, queueName string, job *Job) error {
+	if job.Trace == nil {
+		job.Trace = tracing.Inject(ctx)
+	}
 	data, err := json.Marshal(job)
 	if err != nil {
 		return errors.ErrInternal("failed to marshal job").WithError(err)
@@ -45,6 +65,9 @@ func (r *Redis) EnqueuePayload(ctx context.Context, queueName string, payload an
 }
 
 func (r *Redis) EnqueueDelayed(ctx context.Context, queueName string, job *Job, delay time.Duration) error {
+	if job.Trace == nil {
+		job.Trace = tracing.Inject(ctx)
+	}
 	data, err := json.Marshal(job)
 	if err != nil {
 		return errors.ErrInternal("failed to marshal job").WithError(err)
@@ -125,32 +148,27 @@ func (r *Redis) RejectJob(ctx context.Context, queueName string, job *Job, errMs
 	return r.Enqueue(ctx, queueName, job)
 }
 
+var promoteDelayedScript = goredis.NewScript(`
+	local due = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+	for _, raw in ipairs(due) do
+		redis.call("ZREM", KEYS[1], raw)
+		redis.call("RPUSH", KEYS[2], raw)
+	end
+	return #due
+`)
+
+// ProcessDelayed moves due delayed jobs onto the queue. The move is atomic,
+// so replicas promoting the same queue never push a job twice.
 func (r *Redis) ProcessDelayed(ctx context.Context, queueName string) (int, error) {
-	now := float64(time.Now().Unix())
-
-	jobs, err := r.client.ZRangeByScore(ctx, delayedKey(queueName), &goredis.ZRangeBy{
-		Min: "-inf",
-		Max: fmt.Sprintf("%f", now),
-	}).Result()
-	if err != nil {
-		return 0, errors.ErrInternal("failed to get delayed jobs").WithError(err)
-	}
-
-	if len(jobs) == 0 {
-		return 0, nil
-	}
-
-	pipe := r.client.Pipeline()
-	for _, jobData := range jobs {
-		pipe.RPush(ctx, queueKey(queueName), jobData)
-		pipe.ZRem(ctx, delayedKey(queueName), jobData)
-	}
-	_, err = pipe.Exec(ctx)
+	now := time.Now().Unix()
+	moved, err := promoteDelayedScript.Run(ctx, r.client,
+		[]string{delayedKey(queueName), queueKey(queueName)},
+		now, delayedPromoteBatch,
+	).Int()
 	if err != nil {
 		return 0, errors.ErrInternal("failed to move delayed jobs").WithError(err)
 	}
-
-	return len(jobs), nil
+	return moved, nil
 }
 
 func (r *Redis) QueueLen(ctx context.Context, queueName string) (int64, error) {
@@ -174,6 +192,7 @@ func (r *Redis) ClearQueue(ctx context.Context, queueName string) error {
 	pipe.Del(ctx, queueKey(queueName))
 	pipe.Del(ctx, processingKey(queueName))
 	pipe.Del(ctx, delayedKey(queueName))
+	pipe.Del(ctx, leaseKey(queueName))
 	_, err := pipe.Exec(ctx)
 	return err
 }
@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"erp-service/config"
+	"erp-service/impl/fakebank"
+	"erp-service/saving/participant"
+)
+
+func NewBankAccountVerifier(cfg *config.Config) (participant.BankAccountVerifier, error) {
+	switch provider := cfg.Infra.BankVerification.Provider; provider {
+	case "":
+		return nil, fmt.Errorf("no bank verification provider configured; set BANK_VERIFICATION_PROVIDER")
+	case "fake":
+		return fakebank.NewVerifier(), nil
+	default:
+		return nil, fmt.Errorf("unknown bank verification provider %q", provider)
+	}
+}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"erp-service/config"
+	"erp-service/impl/hashivault"
+	"erp-service/pkg/envelope"
+)
+
+func NewFileEncryptor(cfg *config.Config) (*envelope.Encryptor, error) {
+	encCfg := cfg.Infra.FileEncryption
+
+	var keys envelope.KeyProvider
+	switch encCfg.Provider {
+	case "vault":
+		client, err := NewVault(cfg.Infra.Vault)
+		if err != nil {
+			return nil, err
+		}
+		keys = hashivault.NewKeyProvider(hashivault.NewSecureVault(client))
+	case "local":
+		masterKey, err := base64.StdEncoding.DecodeString(encCfg.LocalKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode FILE_ENCRYPTION_LOCAL_KEY: %w", err)
+		}
+		keys, err = envelope.NewLocalKeyProvider(masterKey)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown file encryption provider %q", encCfg.Provider)
+	}
+
+	return envelope.NewEncryptor(keys, encCfg.KeyPrefix), nil
+}
@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"fmt"
+
+	"erp-service/config"
+	"erp-service/impl/localfs"
+	implminio "erp-service/impl/minio"
+	"erp-service/pkg/envelope"
+	"erp-service/saving/participant"
+)
+
+// NewFileStorage builds the configured storage backend. For the local
+// driver it also returns the server for its presigned URLs, which only the
+// HTTP binary mounts; it is nil otherwise.
+func NewFileStorage(cfg *config.Config, encryptor *envelope.Encryptor) (participant.FileStorageAdapter, *localfs.Server, error) {
+	switch storageCfg := cfg.Infra.FileStorage; storageCfg.Driver {
+	case "local":
+		signer, err := localfs.NewSigner([]byte(storageCfg.LocalSigningKey), storageCfg.LocalPublicURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("configure local file storage: %w", err)
+		}
+		return localfs.NewFileStorage(storageCfg.LocalRoot, signer, encryptor), localfs.NewServer(storageCfg.LocalRoot, signer), nil
+	case "minio":
+		client, err := NewMinIOClient(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("connect to minio: %w", err)
+		}
+		return implminio.NewFileStorage(client, encryptor), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown file storage driver %q", storageCfg.Driver)
+	}
+}
@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"erp-service/config"
+	"erp-service/impl/mailer"
+	"erp-service/pkg/health"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// smtpCacheTTL keeps the SMTP probe infrequent; relays rate-limit
+// connections and mail is sent asynchronously anyway.
+const smtpCacheTTL = 30 * time.Second
+
+// NewHealthRegistry registers a check for every dependency cfg enables.
+// Postgres, Redis and Vault are critical: no request succeeds without them.
+// MinIO and SMTP only degrade the report. Liveness watches the Postgres pool,
+// never a remote service.
+func NewHealthRegistry(cfg *config.Config, db *gorm.DB, redisClient *redis.Client) (*health.Registry, error) {
+	registry := health.NewRegistry(health.Options{
+		Timeout:          cfg.Health.CheckTimeout,
+		CacheTTL:         cfg.Health.CacheTTL,
+		FailureThreshold: cfg.Health.FailureThreshold,
+	})
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get underlying sql.DB: %w", err)
+	}
+	registry.Register(health.Check{
+		Name:     "postgres",
+		Critical: true,
+		Run:      sqlDB.PingContext,
+	})
+	registry.Register(health.Check{
+		Name:     "redis",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		},
+	})
+
+	if cfg.Infra.FileStorage.Driver == "minio" {
+		client, err := NewMinIOClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		bucket := cfg.Infra.Minio.Bucket
+		registry.Register(health.Check{
+			Name: "minio",
+			Run: func(ctx context.Context) error {
+				exists, err := client.BucketExists(ctx, bucket)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return fmt.Errorf("bucket %q does not exist", bucket)
+				}
+				return nil
+			},
+		})
+	}
+
+	if cfg.Email.Provider == mailer.ProviderSMTP {
+		addr := net.JoinHostPort(cfg.Email.SMTPHost, strconv.Itoa(cfg.Email.SMTPPort))
+		registry.Register(health.Check{
+			Name:     "smtp",
+			CacheTTL: smtpCacheTTL,
+			Run: func(ctx context.Context) error {
+				var dialer net.Dialer
+				conn, err := dialer.DialContext(ctx, "tcp", addr)
+				if err != nil {
+					return err
+				}
+				return conn.Close()
+			},
+		})
+	}
+
+	if cfg.Infra.PIIEncryption.Provider == "vault" || cfg.Infra.FileEncryption.Provider == "vault" ||
+		cfg.Infra.Postgres.Platform.VaultRole != "" {
+		client, err := NewVault(cfg.Infra.Vault)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(health.Check{
+			Name:     "vault",
+			Critical: true,
+			Run: func(ctx context.Context) error {
+				resp, err := client.Sys().HealthWithContext(ctx)
+				if err != nil {
+					return err
+				}
+				if resp.Sealed {
+					return errors.New("vault is sealed")
+				}
+				return nil
+			},
+		})
+	}
+
+	registry.RegisterLiveness(health.Check{
+		Name: "postgres_pool",
+		Run:  health.PoolCheck(sqlDB, cfg.Health.PoolStallTimeout),
+	})
+
+	return registry, nil
+}
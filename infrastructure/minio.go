@@ -4,16 +4,23 @@ import (
 	"fmt"
 
 	"erp-service/config"
+	"erp-service/pkg/tracing"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 func NewMinIOClient(cfg *config.Config) (*minio.Client, error) {
+	transport, err := minio.DefaultTransport(cfg.Infra.Minio.UseSSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO transport: %w", err)
+	}
+
 	client, err := minio.New(cfg.Infra.Minio.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.Infra.Minio.AccessKey, cfg.Infra.Minio.SecretKey, ""),
-		Secure: cfg.Infra.Minio.UseSSL,
-		Region: cfg.Infra.Minio.Region,
+		Creds:     credentials.NewStaticV4(cfg.Infra.Minio.AccessKey, cfg.Infra.Minio.SecretKey, ""),
+		Secure:    cfg.Infra.Minio.UseSSL,
+		Region:    cfg.Infra.Minio.Region,
+		Transport: tracing.Transport(transport, "minio"),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MinIO client: %w", err)
@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"erp-service/config"
+	"erp-service/impl/hashivault"
+	"erp-service/pkg/pii"
+)
+
+func NewPIICipher(cfg *config.Config) (pii.Cipher, error) {
+	piiCfg := cfg.Infra.PIIEncryption
+
+	switch piiCfg.Provider {
+	case "vault":
+		client, err := NewVault(cfg.Infra.Vault)
+		if err != nil {
+			return nil, err
+		}
+		return hashivault.NewPIICipher(hashivault.NewSecureVault(client), piiCfg.KeyName, piiCfg.IndexKeyName), nil
+	case "local":
+		masterKey, err := base64.StdEncoding.DecodeString(piiCfg.LocalKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode PII_ENCRYPTION_LOCAL_KEY: %w", err)
+		}
+		return pii.NewLocalCipher(masterKey)
+	default:
+		return nil, fmt.Errorf("unknown pii encryption provider %q", piiCfg.Provider)
+	}
+}
@@ -1,35 +1,180 @@
 package infrastructure
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"erp-service/config"
+	"erp-service/impl/hashivault"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tenantdb"
+	"erp-service/pkg/tracing"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func NewPostgres(cfg config.PostgresConfig, logger *zap.Logger) (*gorm.DB, error) {
-	dsn := cfg.Platform.GetDSN()
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// NewPostgres opens the platform database. The returned function closes
+// the pool and, with dynamic credentials, revokes their leases; call it
+// once the servers using the pool have stopped.
+func NewPostgres(cfg *config.Config, logger *zap.Logger) (*gorm.DB, func(context.Context) error, error) {
+	platform := cfg.Infra.Postgres.Platform
+
+	dialector := postgres.Open(platform.GetDSN())
+	var credentials *hashivault.DatabaseCredentialManager
+	if platform.VaultRole != "" {
+		client, err := NewVault(cfg.Infra.Vault)
+		if err != nil {
+			return nil, nil, err
+		}
+		credentials, err = hashivault.NewDatabaseCredentialManager(context.Background(), hashivault.NewSecureVault(client), platform.VaultRole, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to issue database credentials: %w", err)
+		}
+		conn, err := openWithCredentials(platform, credentials)
+		if err != nil {
+			return nil, nil, errors.Join(err, credentials.Close(context.Background()))
+		}
+		dialector = postgres.New(postgres.Config{Conn: conn})
+	}
+	revoke := func(ctx context.Context) error {
+		if credentials == nil {
+			return nil
+		}
+		return credentials.Close(ctx)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
-		return nil, err
+		return nil, nil, errors.Join(err, revoke(context.Background()))
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		return nil, nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	closeDB := func(ctx context.Context) error {
+		return errors.Join(sqlDB.Close(), revoke(ctx))
 	}
 
-	sqlDB.SetMaxOpenConns(cfg.Platform.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(cfg.Platform.MaxIdleConns)
-	sqlDB.SetConnMaxLifetime(cfg.Platform.ConnMaxLifetime)
+	sqlDB.SetMaxOpenConns(platform.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(platform.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(platform.ConnMaxLifetime)
+
+	if err := metrics.InstrumentGorm(db, "platform"); err != nil {
+		return nil, nil, fmt.Errorf("failed to instrument postgres: %w", err)
+	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		return nil, nil, fmt.Errorf("failed to instrument postgres: %w", err)
+	}
 
 	logger.Info("Successfully connected to Postgres",
-		zap.Int("max_open_conns", cfg.Platform.MaxOpenConns),
-		zap.Int("max_idle_conns", cfg.Platform.MaxIdleConns),
-		zap.Duration("conn_max_lifetime", cfg.Platform.ConnMaxLifetime),
+		zap.Int("max_open_conns", platform.MaxOpenConns),
+		zap.Int("max_idle_conns", platform.MaxIdleConns),
+		zap.Duration("conn_max_lifetime", platform.ConnMaxLifetime),
+		zap.Bool("dynamic_credentials", credentials != nil),
+	)
+
+	return db, closeDB, nil
+}
+
+// NewTenantRouter routes the tenant data repositories to the database each
+// tenant's data lives in. A dedicated database gets a pool on first use,
+// sized by the POSTGRES_TENANT_* settings.
+func NewTenantRouter(cfg *config.Config, platform *gorm.DB, logger *zap.Logger) *tenantdb.Router {
+	tenant := cfg.Infra.Postgres.Tenant
+	open := func(database string) (*gorm.DB, func() error, error) {
+		return openTenantDB(tenant, database, logger)
+	}
+	return tenantdb.NewRouter(platform, open, tenantdb.Options{
+		MaxPools:     tenant.MaxPools,
+		IdleTimeout:  tenant.PoolIdleTimeout,
+		PlacementTTL: tenant.PlacementTTL,
+	}, logger)
+}
+
+func openTenantDB(tenant config.TenantDBConfig, database string, logger *zap.Logger) (*gorm.DB, func() error, error) {
+	db, err := gorm.Open(postgres.Open(tenant.GetDSN(database)), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	closeDB := func() error {
+		metrics.ReleaseGorm(database)
+		return sqlDB.Close()
+	}
+
+	sqlDB.SetMaxOpenConns(tenant.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(tenant.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(tenant.ConnMaxLifetime)
+
+	if err := metrics.InstrumentGorm(db, database); err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to instrument postgres: %w", err), closeDB())
+	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to instrument postgres: %w", err), closeDB())
+	}
+
+	logger.Info("Connected to tenant database",
+		zap.String("database", database),
+		zap.Int("max_open_conns", tenant.MaxOpenConns),
 	)
+	return db, closeDB, nil
+}
 
-	return db, nil
+// openWithCredentials opens a pool whose new connections log in with the
+// credential current at the time. After a rotation, a connection still on
+// the old credential finishes whatever it is running and is discarded the
+// next time it is checked out, so the pool moves over without failing a
+// request.
+func openWithCredentials(platform config.PlatformDBConfig, credentials *hashivault.DatabaseCredentialManager) (*sql.DB, error) {
+	platform.User, platform.Password = credentials.Credentials()
+	connConfig, err := pgx.ParseConfig(platform.GetDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse postgres dsn: %w", err)
+	}
+
+	return sql.OpenDB(stdlib.GetConnector(*connConfig,
+		stdlib.OptionBeforeConnect(func(_ context.Context, cc *pgx.ConnConfig) error {
+			cc.User, cc.Password = credentials.Credentials()
+			return nil
+		}),
+		stdlib.OptionResetSession(func(_ context.Context, conn *pgx.Conn) error {
+			if username, _ := credentials.Credentials(); conn.Config().User != username {
+				return driver.ErrBadConn
+			}
+			return nil
+		}),
+	)), nil
+}
+
+// PostgresURL returns the platform database URL for golang-migrate. With
+// dynamic credentials it issues a one-off credential; release revokes it.
+func PostgresURL(ctx context.Context, cfg *config.Config) (url string, release func(context.Context) error, err error) {
+	platform := cfg.Infra.Postgres.Platform
+	if platform.VaultRole == "" {
+		return platform.GetURL(), func(context.Context) error { return nil }, nil
+	}
+
+	client, err := NewVault(cfg.Infra.Vault)
+	if err != nil {
+		return "", nil, err
+	}
+	vault := hashivault.NewSecureVault(client)
+	creds, err := vault.GenerateDatabaseCredentials(ctx, platform.VaultRole)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to issue database credentials: %w", err)
+	}
+	platform.User, platform.Password = creds.Username, creds.Password
+	return platform.GetURL(), func(ctx context.Context) error {
+		return vault.RevokeLease(ctx, creds.LeaseID)
+	}, nil
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"erp-service/config"
+	"erp-service/pkg/metrics"
+	"erp-service/pkg/tracing"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -54,6 +56,8 @@ func NewRedis(cfg config.RedisConfig) (*redis.Client, error) {
 		WriteTimeout:    writeTimeout,
 		PoolTimeout:     readTimeout + time.Second,
 	})
+	client.AddHook(metrics.RedisHook())
+	client.AddHook(tracing.RedisHook())
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
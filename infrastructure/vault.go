@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"erp-service/config"
+	"erp-service/impl/hashivault"
 
 	"github.com/hashicorp/vault/api"
 )
@@ -151,3 +152,13 @@ func NewVaultWithKubernetes(cfg config.VaultConfig, role, jwt string) (*api.Clie
 
 	return client, nil
 }
+
+// NewSecretReader resolves vault: references in the configuration; pass it
+// to config.Load through config.WithSecrets.
+func NewSecretReader(cfg config.VaultConfig) (config.SecretReader, error) {
+	client, err := NewVault(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return hashivault.NewSecureVault(client), nil
+}
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_participant_duplicate_candidates_updated_at ON participant_duplicate_candidates;
+DROP TABLE IF EXISTS participant_duplicate_candidates;
+
+DROP INDEX IF EXISTS idx_participant_bank_accounts_account_number;
+DROP INDEX IF EXISTS idx_participant_identities_identity_number;
+DROP INDEX IF EXISTS idx_participants_date_of_birth;
+
+ALTER TABLE participants DROP COLUMN IF EXISTS merged_into_id;
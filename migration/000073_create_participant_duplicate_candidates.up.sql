@@ -0,0 +1,90 @@
+-- ============================================================================
+-- CREATE TABLE: participant_duplicate_candidates
+-- Description: Review queue of likely duplicate participant pairs produced by
+--              the matching engine on create/submit or on demand.
+--              A pair is stored once regardless of which side detected it.
+-- ============================================================================
+
+ALTER TABLE participants ADD COLUMN IF NOT EXISTS merged_into_id UUID NULL;
+
+COMMENT ON COLUMN participants.merged_into_id IS 'Surviving participant this record was merged into. Set together with deleted_at.';
+
+CREATE TABLE IF NOT EXISTS participant_duplicate_candidates (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Scoping of the participant that triggered detection
+    tenant_id               UUID NOT NULL,
+    product_id              UUID NOT NULL,
+
+    -- Pair (intra-domain)
+    participant_id          UUID NOT NULL,
+    matched_participant_id  UUID NOT NULL,
+    matched_tenant_id       UUID NOT NULL,
+
+    -- Match Result
+    score                   NUMERIC(5, 4) NOT NULL,
+    matched_fields          JSONB NOT NULL DEFAULT '[]',
+
+    -- Review Workflow
+    status                  VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    reviewed_by             UUID NULL,
+    reviewed_at             TIMESTAMPTZ NULL,
+    review_note             TEXT NULL,
+
+    -- Audit Fields
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Optimistic Locking
+    version                 INTEGER NOT NULL DEFAULT 1,
+
+    -- Constraints
+    CONSTRAINT fk_participant_duplicate_candidates_participant FOREIGN KEY (participant_id)
+        REFERENCES participants(id) ON DELETE CASCADE,
+    CONSTRAINT fk_participant_duplicate_candidates_matched FOREIGN KEY (matched_participant_id)
+        REFERENCES participants(id) ON DELETE CASCADE,
+    CONSTRAINT chk_participant_duplicate_candidates_pair CHECK (participant_id <> matched_participant_id),
+    CONSTRAINT chk_participant_duplicate_candidates_score CHECK (score >= 0 AND score <= 1),
+    CONSTRAINT chk_participant_duplicate_candidates_status CHECK (status IN (
+        'PENDING', 'DISMISSED', 'MERGED'
+    ))
+);
+
+-- One row per unordered pair
+CREATE UNIQUE INDEX IF NOT EXISTS uq_participant_duplicate_candidates_pair
+    ON participant_duplicate_candidates (
+        LEAST(participant_id, matched_participant_id),
+        GREATEST(participant_id, matched_participant_id)
+    );
+
+-- Review queue
+CREATE INDEX IF NOT EXISTS idx_participant_duplicate_candidates_queue
+    ON participant_duplicate_candidates (tenant_id, product_id, status, score DESC);
+
+CREATE INDEX IF NOT EXISTS idx_participant_duplicate_candidates_matched
+    ON participant_duplicate_candidates (matched_participant_id);
+
+-- Blocking keys used by the matching engine
+CREATE INDEX IF NOT EXISTS idx_participants_date_of_birth
+    ON participants (date_of_birth)
+    WHERE date_of_birth IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_participant_identities_identity_number
+    ON participant_identities (identity_number)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_participant_bank_accounts_account_number
+    ON participant_bank_accounts (account_number)
+    WHERE deleted_at IS NULL;
+
+CREATE TRIGGER trg_participant_duplicate_candidates_updated_at
+    BEFORE UPDATE ON participant_duplicate_candidates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE participant_duplicate_candidates IS 'Likely duplicate participant pairs awaiting review. Dismissed pairs are not re-flagged.';
+COMMENT ON COLUMN participant_duplicate_candidates.matched_tenant_id IS 'Tenant of the matched participant. Differs from tenant_id for cross-tenant matches found by platform admins.';
+COMMENT ON COLUMN participant_duplicate_candidates.score IS 'Weighted match score between 0 and 1.';
+COMMENT ON COLUMN participant_duplicate_candidates.matched_fields IS 'Signals that contributed to the score, e.g. full_name, date_of_birth, identity_number.';
+COMMENT ON COLUMN participant_duplicate_candidates.status IS 'PENDING -> DISMISSED (not a duplicate) or MERGED.';
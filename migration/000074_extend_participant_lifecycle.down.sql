@@ -0,0 +1,26 @@
+DROP INDEX IF EXISTS idx_participant_status_history_to_status_effective;
+DROP INDEX IF EXISTS idx_participants_tenant_product_status;
+
+ALTER TABLE participant_status_history DROP COLUMN IF EXISTS details;
+ALTER TABLE participant_status_history DROP COLUMN IF EXISTS supporting_file_id;
+ALTER TABLE participant_status_history DROP COLUMN IF EXISTS effective_date;
+
+ALTER TABLE participant_status_history DROP CONSTRAINT IF EXISTS chk_participant_status_history_to_status;
+ALTER TABLE participant_status_history ADD CONSTRAINT chk_participant_status_history_to_status CHECK (
+    to_status IN ('DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED')
+);
+
+ALTER TABLE participant_status_history DROP CONSTRAINT IF EXISTS chk_participant_status_history_from_status;
+ALTER TABLE participant_status_history ADD CONSTRAINT chk_participant_status_history_from_status CHECK (
+    from_status IS NULL OR from_status IN ('DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED')
+);
+
+ALTER TABLE participants DROP COLUMN IF EXISTS status_effective_date;
+
+ALTER TABLE participants DROP CONSTRAINT IF EXISTS chk_participants_status;
+ALTER TABLE participants ADD CONSTRAINT chk_participants_status CHECK (status IN (
+    'DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED'
+));
+
+COMMENT ON COLUMN participants.status IS 'Approval workflow: DRAFT -> PENDING_APPROVAL -> APPROVED/REJECTED.';
+COMMENT ON COLUMN participant_status_history.reason IS 'Free-text reason for transition. Required for REJECTED, optional otherwise.';
@@ -0,0 +1,53 @@
+-- ============================================================================
+-- ALTER TABLE: participants, participant_status_history
+-- Description: Extends the participant lifecycle past approval with the
+--              membership statuses reported to the regulator:
+--              APPROVED -> ACTIVE <-> SUSPENDED
+--              ACTIVE/SUSPENDED -> TERMINATED | RETIRED | TRANSFERRED_OUT
+--              ACTIVE/SUSPENDED/TERMINATED/RETIRED -> DECEASED
+-- ============================================================================
+
+ALTER TABLE participants DROP CONSTRAINT IF EXISTS chk_participants_status;
+ALTER TABLE participants ADD CONSTRAINT chk_participants_status CHECK (status IN (
+    'DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED',
+    'ACTIVE', 'SUSPENDED', 'TERMINATED', 'RETIRED', 'DECEASED', 'TRANSFERRED_OUT'
+));
+
+ALTER TABLE participants ADD COLUMN IF NOT EXISTS status_effective_date DATE NULL;
+
+ALTER TABLE participant_status_history DROP CONSTRAINT IF EXISTS chk_participant_status_history_from_status;
+ALTER TABLE participant_status_history ADD CONSTRAINT chk_participant_status_history_from_status CHECK (
+    from_status IS NULL OR from_status IN (
+        'DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED',
+        'ACTIVE', 'SUSPENDED', 'TERMINATED', 'RETIRED', 'DECEASED', 'TRANSFERRED_OUT'
+    )
+);
+
+ALTER TABLE participant_status_history DROP CONSTRAINT IF EXISTS chk_participant_status_history_to_status;
+ALTER TABLE participant_status_history ADD CONSTRAINT chk_participant_status_history_to_status CHECK (
+    to_status IN (
+        'DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED',
+        'ACTIVE', 'SUSPENDED', 'TERMINATED', 'RETIRED', 'DECEASED', 'TRANSFERRED_OUT'
+    )
+);
+
+ALTER TABLE participant_status_history ADD COLUMN IF NOT EXISTS effective_date DATE NULL;
+ALTER TABLE participant_status_history ADD COLUMN IF NOT EXISTS supporting_file_id UUID NULL;
+ALTER TABLE participant_status_history ADD COLUMN IF NOT EXISTS details JSONB NULL;
+
+-- Regulator reporting: participants by status within a tenant/product
+CREATE INDEX IF NOT EXISTS idx_participants_tenant_product_status
+    ON participants (tenant_id, product_id, status)
+    WHERE deleted_at IS NULL;
+
+-- Regulator reporting: transitions within a period
+CREATE INDEX IF NOT EXISTS idx_participant_status_history_to_status_effective
+    ON participant_status_history (to_status, effective_date)
+    WHERE effective_date IS NOT NULL;
+
+COMMENT ON COLUMN participants.status IS 'Lifecycle: DRAFT -> PENDING_APPROVAL -> APPROVED/REJECTED -> ACTIVE <-> SUSPENDED -> TERMINATED/RETIRED/TRANSFERRED_OUT/DECEASED.';
+COMMENT ON COLUMN participants.status_effective_date IS 'Business date the current lifecycle status took effect (e.g. termination date, date of death).';
+COMMENT ON COLUMN participant_status_history.effective_date IS 'Business date of the transition, distinct from changed_at which records when it was entered.';
+COMMENT ON COLUMN participant_status_history.supporting_file_id IS 'File backing the transition, e.g. death certificate. References files.id.';
+COMMENT ON COLUMN participant_status_history.details IS 'Transition-specific data such as retirement type or destination fund.';
+COMMENT ON COLUMN participant_status_history.reason IS 'Free-text reason for transition. Required for REJECTED, SUSPENDED, TERMINATED and TRANSFERRED_OUT.';
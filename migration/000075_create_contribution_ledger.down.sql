@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS contribution_ledger_entries;
+
+DROP TRIGGER IF EXISTS trg_contribution_import_batches_updated_at ON contribution_import_batches;
+DROP TABLE IF EXISTS contribution_import_batches;
@@ -0,0 +1,124 @@
+-- ============================================================================
+-- CREATE TABLE: contribution_import_batches, contribution_ledger_entries
+-- Description: Pension contribution ledger per participant, filled from
+--              employer payroll contribution files. The ledger is append-only:
+--              corrections are posted as REVERSAL entries, never as updates.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS contribution_import_batches (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Scoping
+    tenant_id               UUID NOT NULL,
+    product_id              UUID NOT NULL,
+
+    -- Payroll File
+    period                  DATE NOT NULL,
+    posting_date            DATE NOT NULL,
+    file_name               VARCHAR(255) NOT NULL,
+    file_checksum           VARCHAR(64) NOT NULL,
+
+    -- Result
+    status                  VARCHAR(20) NOT NULL,
+    total_rows              INTEGER NOT NULL DEFAULT 0,
+    posted_rows             INTEGER NOT NULL DEFAULT 0,
+    total_employer_amount   BIGINT NOT NULL DEFAULT 0,
+    total_employee_amount   BIGINT NOT NULL DEFAULT 0,
+    row_errors              JSONB NOT NULL DEFAULT '[]',
+
+    -- Audit Fields
+    imported_by             UUID NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT chk_contribution_import_batches_status CHECK (status IN ('POSTED', 'REJECTED')),
+    CONSTRAINT chk_contribution_import_batches_period CHECK (period = date_trunc('month', period)::date)
+);
+
+-- The same payroll file can only be posted once per product
+CREATE UNIQUE INDEX IF NOT EXISTS uq_contribution_import_batches_checksum
+    ON contribution_import_batches (tenant_id, product_id, file_checksum)
+    WHERE status = 'POSTED';
+
+CREATE INDEX IF NOT EXISTS idx_contribution_import_batches_tenant_product
+    ON contribution_import_batches (tenant_id, product_id, created_at DESC);
+
+CREATE TRIGGER trg_contribution_import_batches_updated_at
+    BEFORE UPDATE ON contribution_import_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS contribution_ledger_entries (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    entry_number            BIGINT GENERATED ALWAYS AS IDENTITY,
+
+    -- Scoping
+    tenant_id               UUID NOT NULL,
+    product_id              UUID NOT NULL,
+    participant_id          UUID NOT NULL,
+    batch_id                UUID NULL,
+
+    -- Movement
+    entry_type              VARCHAR(20) NOT NULL,
+    period                  DATE NOT NULL,
+    posting_date            DATE NOT NULL,
+    employer_amount         BIGINT NOT NULL,
+    employee_amount         BIGINT NOT NULL,
+
+    -- Running Balances (after this entry)
+    employer_balance        BIGINT NOT NULL,
+    employee_balance        BIGINT NOT NULL,
+
+    -- Reversal
+    reverses_entry_id       UUID NULL,
+    reference               VARCHAR(100) NULL,
+    reason                  TEXT NULL,
+
+    -- Audit Fields
+    created_by              UUID NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT fk_contribution_ledger_entries_participant FOREIGN KEY (participant_id)
+        REFERENCES participants(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_contribution_ledger_entries_batch FOREIGN KEY (batch_id)
+        REFERENCES contribution_import_batches(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_contribution_ledger_entries_reverses FOREIGN KEY (reverses_entry_id)
+        REFERENCES contribution_ledger_entries(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_contribution_ledger_entries_type CHECK (entry_type IN ('CONTRIBUTION', 'REVERSAL')),
+    CONSTRAINT chk_contribution_ledger_entries_reversal CHECK (
+        (entry_type = 'REVERSAL') = (reverses_entry_id IS NOT NULL)
+    ),
+    CONSTRAINT chk_contribution_ledger_entries_period CHECK (period = date_trunc('month', period)::date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_contribution_ledger_entries_entry_number
+    ON contribution_ledger_entries (entry_number);
+
+-- An entry can only be reversed once
+CREATE UNIQUE INDEX IF NOT EXISTS uq_contribution_ledger_entries_reverses
+    ON contribution_ledger_entries (reverses_entry_id)
+    WHERE reverses_entry_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_contribution_ledger_entries_participant
+    ON contribution_ledger_entries (participant_id, entry_number DESC);
+
+CREATE INDEX IF NOT EXISTS idx_contribution_ledger_entries_participant_period
+    ON contribution_ledger_entries (participant_id, period);
+
+CREATE INDEX IF NOT EXISTS idx_contribution_ledger_entries_batch
+    ON contribution_ledger_entries (batch_id)
+    WHERE batch_id IS NOT NULL;
+
+COMMENT ON TABLE contribution_import_batches IS 'Employer payroll contribution files. A file is posted in full or rejected in full.';
+COMMENT ON COLUMN contribution_import_batches.period IS 'Contribution month, stored as the first day of the month.';
+COMMENT ON COLUMN contribution_import_batches.file_checksum IS 'SHA-256 of the uploaded file, used to refuse re-posting the same file.';
+COMMENT ON COLUMN contribution_import_batches.row_errors IS 'Per-row validation errors for REJECTED batches.';
+COMMENT ON TABLE contribution_ledger_entries IS 'Append-only pension contribution ledger. Amounts are in minor currency units.';
+COMMENT ON COLUMN contribution_ledger_entries.entry_number IS 'Monotonic posting order used to compute running balances.';
+COMMENT ON COLUMN contribution_ledger_entries.employer_balance IS 'Participant employer balance after this entry.';
+COMMENT ON COLUMN contribution_ledger_entries.employee_balance IS 'Participant employee balance after this entry.';
+COMMENT ON COLUMN contribution_ledger_entries.reverses_entry_id IS 'CONTRIBUTION entry cancelled by this REVERSAL entry.';
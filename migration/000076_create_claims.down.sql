@@ -0,0 +1,22 @@
+-- PAYOUT entries record money that has left the fund; refuse to roll back
+-- over them rather than rewrite the ledger.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM contribution_ledger_entries WHERE entry_type = 'PAYOUT') THEN
+        RAISE EXCEPTION 'Cannot roll back claims: contribution_ledger_entries holds PAYOUT entries.';
+    END IF;
+END $$;
+
+ALTER TABLE contribution_ledger_entries
+    DROP CONSTRAINT IF EXISTS chk_contribution_ledger_entries_type;
+ALTER TABLE contribution_ledger_entries
+    ADD CONSTRAINT chk_contribution_ledger_entries_type CHECK (entry_type IN ('CONTRIBUTION', 'REVERSAL'));
+
+DROP TRIGGER IF EXISTS trg_claim_payment_instructions_updated_at ON claim_payment_instructions;
+DROP TABLE IF EXISTS claim_payment_instructions;
+
+DROP TABLE IF EXISTS claim_status_history;
+DROP TABLE IF EXISTS claim_documents;
+
+DROP TRIGGER IF EXISTS trg_claims_updated_at ON claims;
+DROP TABLE IF EXISTS claims;
@@ -0,0 +1,174 @@
+-- ============================================================================
+-- CREATE TABLE: claims, claim_documents, claim_status_history,
+--               claim_payment_instructions
+-- Description: Benefit claims (retirement, resignation, death, disability).
+--              A claim moves OPEN -> VERIFIED -> APPROVED -> PAID, or is
+--              REJECTED before approval. Approval fixes the payable amount
+--              and produces one payment instruction per payee; payment posts
+--              a PAYOUT entry to the contribution ledger.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS claims (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Scoping
+    tenant_id               UUID NOT NULL,
+    product_id              UUID NOT NULL,
+    participant_id          UUID NOT NULL,
+
+    -- Claim
+    claim_type              VARCHAR(30) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    event_date              DATE NOT NULL,
+    notes                   TEXT NULL,
+
+    -- Payable Amount (fixed on approval, minor currency units)
+    employer_amount         BIGINT NOT NULL DEFAULT 0,
+    employee_amount         BIGINT NOT NULL DEFAULT 0,
+
+    -- Workflow
+    opened_by               UUID NOT NULL,
+    verified_by             UUID NULL,
+    verified_at             TIMESTAMPTZ NULL,
+    approved_by             UUID NULL,
+    approved_at             TIMESTAMPTZ NULL,
+    rejected_by             UUID NULL,
+    rejected_at             TIMESTAMPTZ NULL,
+    rejection_reason        TEXT NULL,
+    paid_by                 UUID NULL,
+    paid_at                 TIMESTAMPTZ NULL,
+    payment_reference       VARCHAR(100) NULL,
+
+    -- Audit Fields
+    version                 INTEGER NOT NULL DEFAULT 1,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT fk_claims_participant FOREIGN KEY (participant_id)
+        REFERENCES participants(id) ON DELETE RESTRICT,
+    CONSTRAINT chk_claims_type CHECK (claim_type IN (
+        'NORMAL_RETIREMENT', 'EARLY_RETIREMENT', 'RESIGNATION', 'DEATH', 'DISABILITY'
+    )),
+    CONSTRAINT chk_claims_status CHECK (status IN ('OPEN', 'VERIFIED', 'APPROVED', 'PAID', 'REJECTED')),
+    CONSTRAINT chk_claims_amounts CHECK (employer_amount >= 0 AND employee_amount >= 0)
+);
+
+-- A participant can only have one claim in progress at a time
+CREATE UNIQUE INDEX IF NOT EXISTS uq_claims_participant_in_progress
+    ON claims (participant_id)
+    WHERE status IN ('OPEN', 'VERIFIED', 'APPROVED');
+
+CREATE INDEX IF NOT EXISTS idx_claims_tenant_product
+    ON claims (tenant_id, product_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_claims_participant
+    ON claims (participant_id);
+
+CREATE TRIGGER trg_claims_updated_at
+    BEFORE UPDATE ON claims
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS claim_documents (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Relations
+    claim_id                UUID NOT NULL,
+    document_type           VARCHAR(50) NOT NULL,
+    file_id                 UUID NOT NULL,
+
+    -- Audit Fields
+    uploaded_by             UUID NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT fk_claim_documents_claim FOREIGN KEY (claim_id)
+        REFERENCES claims(id) ON DELETE CASCADE,
+    CONSTRAINT fk_claim_documents_file FOREIGN KEY (file_id)
+        REFERENCES files(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_documents_claim
+    ON claim_documents (claim_id, document_type);
+
+CREATE TABLE IF NOT EXISTS claim_status_history (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Transition
+    claim_id                UUID NOT NULL,
+    from_status             VARCHAR(20) NULL,
+    to_status               VARCHAR(20) NOT NULL,
+    changed_by              UUID NOT NULL,
+    reason                  TEXT NULL,
+    changed_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Audit Fields
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT fk_claim_status_history_claim FOREIGN KEY (claim_id)
+        REFERENCES claims(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_status_history_claim
+    ON claim_status_history (claim_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS claim_payment_instructions (
+    -- Primary Key
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+
+    -- Scoping
+    claim_id                UUID NOT NULL,
+    tenant_id               UUID NOT NULL,
+    product_id              UUID NOT NULL,
+
+    -- Payee
+    payee_type              VARCHAR(20) NOT NULL,
+    payee_id                UUID NOT NULL,
+    payee_name              VARCHAR(255) NOT NULL,
+    bank_code               VARCHAR(20) NULL,
+    account_number          VARCHAR(50) NOT NULL,
+
+    -- Payment
+    amount                  BIGINT NOT NULL,
+    currency_code           VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    status                  VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    payment_reference       VARCHAR(100) NULL,
+    paid_at                 TIMESTAMPTZ NULL,
+
+    -- Audit Fields
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT fk_claim_payment_instructions_claim FOREIGN KEY (claim_id)
+        REFERENCES claims(id) ON DELETE CASCADE,
+    CONSTRAINT chk_claim_payment_instructions_payee_type CHECK (payee_type IN ('PARTICIPANT', 'BENEFICIARY')),
+    CONSTRAINT chk_claim_payment_instructions_status CHECK (status IN ('PENDING', 'PAID')),
+    CONSTRAINT chk_claim_payment_instructions_amount CHECK (amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_payment_instructions_claim
+    ON claim_payment_instructions (claim_id);
+
+CREATE TRIGGER trg_claim_payment_instructions_updated_at
+    BEFORE UPDATE ON claim_payment_instructions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Claim payouts are posted to the contribution ledger
+ALTER TABLE contribution_ledger_entries
+    DROP CONSTRAINT IF EXISTS chk_contribution_ledger_entries_type;
+ALTER TABLE contribution_ledger_entries
+    ADD CONSTRAINT chk_contribution_ledger_entries_type CHECK (entry_type IN ('CONTRIBUTION', 'REVERSAL', 'PAYOUT'));
+
+COMMENT ON TABLE claims IS 'Benefit claims against a participant pension balance.';
+COMMENT ON COLUMN claims.event_date IS 'Date of the retirement, resignation, death or disability being claimed.';
+COMMENT ON COLUMN claims.employer_amount IS 'Employer balance payable, fixed when the claim is approved.';
+COMMENT ON COLUMN claims.employee_amount IS 'Employee balance payable, fixed when the claim is approved.';
+COMMENT ON TABLE claim_payment_instructions IS 'Payment instructions produced on claim approval, one per payee.';
+COMMENT ON COLUMN claim_payment_instructions.payee_id IS 'Participant bank account or beneficiary the payment is made to.';
@@ -0,0 +1,7 @@
+DELETE FROM masterdata_items
+WHERE category_id IN (
+    SELECT id FROM masterdata_categories
+    WHERE code = 'RETIREMENT_TYPE' AND deleted_at IS NULL
+);
+
+DELETE FROM masterdata_categories WHERE code = 'RETIREMENT_TYPE';
@@ -0,0 +1,63 @@
+-- ============================================================================
+-- SEED: RETIREMENT_TYPE masterdata
+-- Description: Retirement types referenced by participant_employments.retirement_type_code.
+--              Each item carries its normal retirement age in metadata, used to
+--              project retirement dates. Tenants can override an item (same code,
+--              tenant_id set) to apply their own retirement age.
+-- ============================================================================
+
+DO $$
+DECLARE
+    v_category_id UUID;
+BEGIN
+    INSERT INTO masterdata_categories (
+        code, name, description,
+        parent_category_id,
+        is_system, is_tenant_extensible,
+        sort_order, status, metadata
+    ) VALUES (
+        'RETIREMENT_TYPE',
+        'Jenis Pensiun',
+        'Jenis pensiun peserta beserta usia pensiun normalnya',
+        NULL,
+        TRUE,
+        TRUE,
+        34,
+        'ACTIVE',
+        '{"domain": "participant", "metadata_keys": ["normal_retirement_age"]}'::jsonb
+    )
+    ON CONFLICT (code) DO NOTHING;
+
+    SELECT id INTO v_category_id
+    FROM masterdata_categories
+    WHERE code = 'RETIREMENT_TYPE' AND deleted_at IS NULL;
+
+    IF v_category_id IS NULL THEN
+        RAISE EXCEPTION 'RETIREMENT_TYPE category not found';
+    END IF;
+
+    INSERT INTO masterdata_items (
+        category_id, tenant_id, parent_item_id,
+        code, name, description,
+        sort_order, is_system, is_default, status, metadata
+    ) VALUES
+        (v_category_id, NULL, NULL,
+         'RETIREMENT_TYPE_001', 'Pensiun Normal',
+         'Pensiun pada usia pensiun normal',
+         1, TRUE, TRUE, 'ACTIVE',
+         '{"normal_retirement_age": 56}'::jsonb),
+
+        (v_category_id, NULL, NULL,
+         'RETIREMENT_TYPE_002', 'Pensiun Dipercepat',
+         'Pensiun paling cepat 10 tahun sebelum usia pensiun normal',
+         2, TRUE, FALSE, 'ACTIVE',
+         '{"normal_retirement_age": 46}'::jsonb),
+
+        (v_category_id, NULL, NULL,
+         'RETIREMENT_TYPE_003', 'Pensiun Ditunda',
+         'Pensiun setelah usia pensiun normal atas persetujuan pemberi kerja',
+         3, TRUE, FALSE, 'ACTIVE',
+         '{"normal_retirement_age": 60}'::jsonb)
+    ON CONFLICT (category_id, COALESCE(tenant_id, '00000000-0000-0000-0000-000000000000'::uuid), code)
+        WHERE deleted_at IS NULL DO NOTHING;
+END $$;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_files_participant_id;
+ALTER TABLE files DROP COLUMN IF EXISTS participant_id;
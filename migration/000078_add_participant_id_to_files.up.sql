@@ -0,0 +1,27 @@
+ALTER TABLE files ADD COLUMN IF NOT EXISTS participant_id UUID;
+
+UPDATE files f SET participant_id = i.participant_id
+FROM participant_identities i
+WHERE i.photo_file_id = f.id AND f.participant_id IS NULL;
+
+UPDATE files f SET participant_id = m.participant_id
+FROM participant_family_members m
+WHERE m.supporting_doc_file_id = f.id AND f.participant_id IS NULL;
+
+UPDATE files f SET participant_id = b.participant_id
+FROM participant_beneficiaries b
+WHERE f.id IN (b.identity_photo_file_id, b.family_card_photo_file_id, b.bank_book_photo_file_id)
+  AND f.participant_id IS NULL;
+
+UPDATE files f SET participant_id = h.participant_id
+FROM participant_status_history h
+WHERE h.supporting_file_id = f.id AND f.participant_id IS NULL;
+
+UPDATE files f SET participant_id = c.participant_id
+FROM claim_documents d
+JOIN claims c ON c.id = d.claim_id
+WHERE d.file_id = f.id AND f.participant_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_participant_id
+    ON files (participant_id)
+    WHERE participant_id IS NOT NULL;
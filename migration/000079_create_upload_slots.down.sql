@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_upload_slots_pending_expiry;
+DROP INDEX IF EXISTS idx_upload_slots_participant;
+DROP TABLE IF EXISTS upload_slots;
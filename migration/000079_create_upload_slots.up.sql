@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS upload_slots (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    participant_id UUID NOT NULL,
+    requested_by UUID NOT NULL,
+    field_name VARCHAR(100) NOT NULL,
+    bucket VARCHAR(255) NOT NULL,
+    storage_key TEXT NOT NULL,
+    original_name VARCHAR(500) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    max_size_bytes BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    file_id UUID,
+    claimed_at TIMESTAMPTZ,
+    failed_delete_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+CREATE INDEX idx_upload_slots_participant ON upload_slots (participant_id);
+CREATE INDEX idx_upload_slots_pending_expiry ON upload_slots (expires_at)
+    WHERE completed_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_files_pending_scan;
+ALTER TABLE files DROP CONSTRAINT IF EXISTS chk_files_scan_status;
+ALTER TABLE files
+    DROP COLUMN IF EXISTS scan_claimed_at,
+    DROP COLUMN IF EXISTS scanned_at,
+    DROP COLUMN IF EXISTS scan_signature,
+    DROP COLUMN IF EXISTS scan_status;
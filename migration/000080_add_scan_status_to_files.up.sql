@@ -0,0 +1,14 @@
+-- Existing rows start as PENDING_SCAN too so the scan worker covers files
+-- uploaded before scanning was introduced.
+ALTER TABLE files
+    ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'PENDING_SCAN',
+    ADD COLUMN IF NOT EXISTS scan_signature VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS scan_claimed_at TIMESTAMPTZ;
+
+ALTER TABLE files
+    ADD CONSTRAINT chk_files_scan_status CHECK (scan_status IN ('PENDING_SCAN', 'CLEAN', 'INFECTED'));
+
+CREATE INDEX IF NOT EXISTS idx_files_pending_scan
+    ON files (created_at)
+    WHERE scan_status = 'PENDING_SCAN' AND deleted_at IS NULL;
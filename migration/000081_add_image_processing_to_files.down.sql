@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_files_derived_from_file_id;
+DROP INDEX IF EXISTS idx_files_pending_processing;
+ALTER TABLE files DROP CONSTRAINT IF EXISTS chk_files_processing_status;
+ALTER TABLE files
+    DROP COLUMN IF EXISTS thumbnail_file_id,
+    DROP COLUMN IF EXISTS derived_from_file_id,
+    DROP COLUMN IF EXISTS process_attempts,
+    DROP COLUMN IF EXISTS process_claimed_at,
+    DROP COLUMN IF EXISTS processing_status;
@@ -0,0 +1,20 @@
+-- Only images go through the processor; everything else is marked SKIPPED
+-- the first time the worker sees it. Thumbnails are stored as their own file
+-- rows pointing back at the original through derived_from_file_id.
+ALTER TABLE files
+    ADD COLUMN IF NOT EXISTS processing_status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS process_claimed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS process_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS derived_from_file_id UUID REFERENCES files(id),
+    ADD COLUMN IF NOT EXISTS thumbnail_file_id UUID REFERENCES files(id);
+
+ALTER TABLE files
+    ADD CONSTRAINT chk_files_processing_status CHECK (processing_status IN ('PENDING', 'DONE', 'SKIPPED', 'FAILED'));
+
+CREATE INDEX IF NOT EXISTS idx_files_pending_processing
+    ON files (created_at)
+    WHERE processing_status = 'PENDING' AND scan_status = 'CLEAN' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_derived_from_file_id
+    ON files (derived_from_file_id)
+    WHERE derived_from_file_id IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_files_data_key_rewrap;
+ALTER TABLE files
+    DROP COLUMN IF EXISTS data_key_rewrapped_at,
+    DROP COLUMN IF EXISTS wrapped_data_key,
+    DROP COLUMN IF EXISTS encryption_key_id;
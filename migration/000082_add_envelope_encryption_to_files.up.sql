@@ -0,0 +1,11 @@
+-- Objects written with envelope encryption carry the tenant KEK name and
+-- their data key wrapped under it. Rows without a wrapped key predate
+-- encryption and are stored in plaintext.
+ALTER TABLE files
+    ADD COLUMN IF NOT EXISTS encryption_key_id VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS wrapped_data_key TEXT,
+    ADD COLUMN IF NOT EXISTS data_key_rewrapped_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_files_data_key_rewrap
+    ON files (COALESCE(data_key_rewrapped_at, created_at))
+    WHERE wrapped_data_key IS NOT NULL AND deleted_at IS NULL;
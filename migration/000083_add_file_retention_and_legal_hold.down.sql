@@ -0,0 +1,16 @@
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS legal_hold_set_at,
+    DROP COLUMN IF EXISTS legal_hold_set_by,
+    DROP COLUMN IF EXISTS legal_hold_reason,
+    DROP COLUMN IF EXISTS legal_hold;
+
+DROP INDEX IF EXISTS idx_files_retention_candidates;
+ALTER TABLE files
+    DROP COLUMN IF EXISTS purge_claimed_at,
+    DROP COLUMN IF EXISTS legal_hold_set_at,
+    DROP COLUMN IF EXISTS legal_hold_set_by,
+    DROP COLUMN IF EXISTS legal_hold_reason,
+    DROP COLUMN IF EXISTS legal_hold,
+    DROP COLUMN IF EXISTS document_type;
+
+DROP TABLE IF EXISTS file_retention_policies;
@@ -0,0 +1,52 @@
+-- Retention rules decide when a permanent file is purged: retention_days
+-- after the retention_event. document_type '*' matches any type and is
+-- overridden by a rule for the exact type.
+CREATE TABLE IF NOT EXISTS file_retention_policies (
+    id UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id UUID NOT NULL,
+    document_type VARCHAR(100) NOT NULL,
+    retention_event VARCHAR(30) NOT NULL,
+    retention_days INT NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_file_retention_policies_event CHECK (retention_event IN
+        ('UPLOADED', 'PARTICIPANT_REJECTED', 'PARTICIPANT_TERMINATED', 'PARTICIPANT_DELETED')),
+    CONSTRAINT chk_file_retention_policies_days CHECK (retention_days > 0),
+    CONSTRAINT uq_file_retention_policies UNIQUE (tenant_id, document_type, retention_event)
+);
+
+-- document_type is the participant upload field or the claim document type.
+ALTER TABLE files
+    ADD COLUMN IF NOT EXISTS document_type VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS legal_hold_reason TEXT,
+    ADD COLUMN IF NOT EXISTS legal_hold_set_by UUID,
+    ADD COLUMN IF NOT EXISTS legal_hold_set_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS purge_claimed_at TIMESTAMPTZ;
+
+UPDATE files f
+SET document_type = cd.document_type
+FROM claim_documents cd
+WHERE cd.file_id = f.id AND f.document_type IS NULL;
+
+UPDATE files
+SET document_type = split_part(storage_key, '/', 5)
+WHERE document_type IS NULL
+  AND derived_from_file_id IS NULL
+  AND storage_key LIKE 'participants/%/%/%/%/%';
+
+UPDATE files t
+SET document_type = o.document_type
+FROM files o
+WHERE t.derived_from_file_id = o.id AND t.document_type IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_retention_candidates
+    ON files (tenant_id, created_at)
+    WHERE expires_at IS NULL AND derived_from_file_id IS NULL AND deleted_at IS NULL;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS legal_hold_reason TEXT,
+    ADD COLUMN IF NOT EXISTS legal_hold_set_by UUID,
+    ADD COLUMN IF NOT EXISTS legal_hold_set_at TIMESTAMPTZ;
@@ -0,0 +1,24 @@
+-- Ciphertext cannot be decrypted in SQL; run this only before any row has
+-- been sealed, or after decrypting them in the application.
+
+DROP INDEX IF EXISTS idx_participant_bank_accounts_account_number_bidx;
+ALTER TABLE participant_bank_accounts DROP COLUMN IF EXISTS account_number_bidx;
+CREATE INDEX IF NOT EXISTS idx_participant_bank_accounts_account_number
+    ON participant_bank_accounts (account_number)
+    WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_participant_identities_identity_number_bidx;
+ALTER TABLE participant_identities DROP COLUMN IF EXISTS identity_number_bidx;
+CREATE INDEX IF NOT EXISTS idx_participant_identities_identity_number
+    ON participant_identities (identity_number)
+    WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS uk_participants_ktp_bidx_tenant_product;
+ALTER TABLE participants DROP COLUMN IF EXISTS ktp_number_bidx;
+CREATE INDEX IF NOT EXISTS idx_participants_ktp_number ON participants(ktp_number)
+    WHERE ktp_number IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_participants_ktp_tenant_app
+    ON participants (ktp_number, tenant_id, product_id)
+    WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN participants.ktp_number IS 'Indonesian national ID number. Used for deferred user-linking matching.';
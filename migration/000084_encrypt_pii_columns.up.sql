@@ -0,0 +1,46 @@
+-- Field-level encryption of personal identifiers. The columns now hold
+-- "pii:"-prefixed ciphertext (legacy rows stay plaintext until the backfill
+-- job seals them), and *_bidx holds a keyed HMAC of the normalized value for
+-- uniqueness and exact-match lookups.
+
+ALTER TABLE participants
+    ALTER COLUMN ktp_number TYPE TEXT,
+    ADD COLUMN ktp_number_bidx VARCHAR(128) NULL;
+
+DROP INDEX IF EXISTS uk_participants_ktp_tenant_app;
+DROP INDEX IF EXISTS idx_participants_ktp_number;
+
+CREATE UNIQUE INDEX uk_participants_ktp_bidx_tenant_product
+    ON participants (ktp_number_bidx, tenant_id, product_id)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE participant_identities
+    ALTER COLUMN identity_number TYPE TEXT,
+    ADD COLUMN identity_number_bidx VARCHAR(128) NULL;
+
+DROP INDEX IF EXISTS idx_participant_identities_identity_number;
+
+CREATE INDEX idx_participant_identities_identity_number_bidx
+    ON participant_identities (identity_number_bidx)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE participant_bank_accounts
+    ALTER COLUMN account_number TYPE TEXT,
+    ADD COLUMN account_number_bidx VARCHAR(128) NULL;
+
+DROP INDEX IF EXISTS idx_participant_bank_accounts_account_number;
+
+CREATE INDEX idx_participant_bank_accounts_account_number_bidx
+    ON participant_bank_accounts (account_number_bidx)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE participant_beneficiaries
+    ALTER COLUMN account_number TYPE TEXT;
+
+ALTER TABLE claim_payment_instructions
+    ALTER COLUMN account_number TYPE TEXT;
+
+COMMENT ON COLUMN participants.ktp_number IS 'Indonesian national ID number, encrypted. Used for deferred user-linking matching via ktp_number_bidx.';
+COMMENT ON COLUMN participants.ktp_number_bidx IS 'Blind index (keyed HMAC) of the normalized KTP number.';
+COMMENT ON COLUMN participant_identities.identity_number_bidx IS 'Blind index (keyed HMAC) of the normalized identity number.';
+COMMENT ON COLUMN participant_bank_accounts.account_number_bidx IS 'Blind index (keyed HMAC) of the normalized account number.';
@@ -0,0 +1,23 @@
+DO $$
+DECLARE
+    v_platform_tenant_id UUID;
+    v_frendz_product_id UUID;
+BEGIN
+    SELECT id INTO v_platform_tenant_id FROM tenants WHERE code = 'platform';
+    IF v_platform_tenant_id IS NULL THEN RAISE NOTICE 'Platform tenant not found, nothing to delete'; RETURN; END IF;
+
+    SELECT id INTO v_frendz_product_id FROM products WHERE tenant_id = v_platform_tenant_id AND code = 'frendz-saving';
+    IF v_frendz_product_id IS NULL THEN RAISE NOTICE 'frendz-saving product not found, nothing to delete'; RETURN; END IF;
+
+    DELETE FROM role_permissions
+    WHERE permission_id IN (
+        SELECT id FROM permissions
+        WHERE product_id = v_frendz_product_id AND resource_type = 'pii'
+    );
+
+    DELETE FROM permissions
+    WHERE product_id = v_frendz_product_id
+      AND resource_type = 'pii';
+
+    RAISE NOTICE 'Removed pii permissions';
+END $$;
@@ -0,0 +1,48 @@
+DO $$
+DECLARE
+    v_platform_tenant_id UUID;
+    v_frendz_product_id UUID;
+BEGIN
+    SELECT id INTO v_platform_tenant_id FROM tenants WHERE code = 'platform';
+    IF v_platform_tenant_id IS NULL THEN RAISE NOTICE 'Platform tenant not found, skipping'; RETURN; END IF;
+
+    SELECT id INTO v_frendz_product_id FROM products WHERE tenant_id = v_platform_tenant_id AND code = 'frendz-saving';
+    IF v_frendz_product_id IS NULL THEN RAISE NOTICE 'frendz-saving product not found, skipping'; RETURN; END IF;
+
+    -- 1. Seed PII visibility permissions (9)
+    INSERT INTO permissions (product_id, code, name, resource_type, action, status) VALUES
+        (v_frendz_product_id, 'pii_national_id:full',      'View Full National ID',      'pii', 'full',    'ACTIVE'),
+        (v_frendz_product_id, 'pii_national_id:partial',   'View Partial National ID',   'pii', 'partial', 'ACTIVE'),
+        (v_frendz_product_id, 'pii_bank_account:full',     'View Full Bank Account',     'pii', 'full',    'ACTIVE'),
+        (v_frendz_product_id, 'pii_bank_account:partial',  'View Partial Bank Account',  'pii', 'partial', 'ACTIVE'),
+        (v_frendz_product_id, 'pii_phone:full',            'View Full Phone',            'pii', 'full',    'ACTIVE'),
+        (v_frendz_product_id, 'pii_phone:partial',         'View Partial Phone',         'pii', 'partial', 'ACTIVE'),
+        (v_frendz_product_id, 'pii_date_of_birth:full',    'View Full Date of Birth',    'pii', 'full',    'ACTIVE'),
+        (v_frendz_product_id, 'pii_date_of_birth:partial', 'View Partial Date of Birth', 'pii', 'partial', 'ACTIVE'),
+        (v_frendz_product_id, 'pii:reveal',                'Reveal PII Value',           'pii', 'reveal',  'ACTIVE')
+    ON CONFLICT DO NOTHING;
+
+    RAISE NOTICE 'Ensured 9 pii permissions';
+
+    -- 2. PARTICIPANT_CREATOR sees the tail of identifiers
+    INSERT INTO role_permissions (role_id, permission_id)
+    SELECT r.id, p.id
+    FROM roles r, permissions p
+    WHERE r.product_id = v_frendz_product_id
+      AND r.code = 'PARTICIPANT_CREATOR'
+      AND p.product_id = v_frendz_product_id
+      AND p.code IN ('pii_national_id:partial', 'pii_bank_account:partial', 'pii_phone:partial', 'pii_date_of_birth:full')
+    ON CONFLICT DO NOTHING;
+
+    -- 3. PARTICIPANT_APPROVER verifies identity and may reveal the rest
+    INSERT INTO role_permissions (role_id, permission_id)
+    SELECT r.id, p.id
+    FROM roles r, permissions p
+    WHERE r.product_id = v_frendz_product_id
+      AND r.code = 'PARTICIPANT_APPROVER'
+      AND p.product_id = v_frendz_product_id
+      AND p.code IN ('pii_national_id:full', 'pii_bank_account:partial', 'pii_phone:partial', 'pii_date_of_birth:full', 'pii:reveal')
+    ON CONFLICT DO NOTHING;
+
+    RAISE NOTICE 'Assigned pii permissions to participant roles';
+END $$;
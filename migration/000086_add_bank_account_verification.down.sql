@@ -0,0 +1,10 @@
+ALTER TABLE participant_bank_accounts
+    DROP CONSTRAINT IF EXISTS chk_participant_bank_accounts_verification_status;
+
+ALTER TABLE participant_bank_accounts
+    DROP COLUMN IF EXISTS verification_checked_at,
+    DROP COLUMN IF EXISTS verification_message,
+    DROP COLUMN IF EXISTS verification_score,
+    DROP COLUMN IF EXISTS verified_holder_name,
+    DROP COLUMN IF EXISTS verification_provider,
+    DROP COLUMN IF EXISTS verification_status;
@@ -0,0 +1,13 @@
+-- Result of the last ownership inquiry against the bank. Any change to the
+-- bank, number or holder name resets the account to UNVERIFIED.
+ALTER TABLE participant_bank_accounts
+    ADD COLUMN IF NOT EXISTS verification_status VARCHAR(20) NOT NULL DEFAULT 'UNVERIFIED',
+    ADD COLUMN IF NOT EXISTS verification_provider VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS verified_holder_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS verification_score NUMERIC(4, 3),
+    ADD COLUMN IF NOT EXISTS verification_message TEXT,
+    ADD COLUMN IF NOT EXISTS verification_checked_at TIMESTAMPTZ;
+
+ALTER TABLE participant_bank_accounts
+    ADD CONSTRAINT chk_participant_bank_accounts_verification_status CHECK (verification_status IN
+        ('UNVERIFIED', 'VERIFIED', 'MISMATCH', 'NOT_FOUND', 'PROVIDER_ERROR'));
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_tenant_databases_updated_at ON tenant_databases;
+DROP TABLE IF EXISTS tenant_databases;
@@ -0,0 +1,19 @@
+-- Tenants whose participant and file data lives in a dedicated database
+-- rather than the shared tables. IAM and masterdata stay here either way.
+-- MOVING blocks the tenant's data while the migrate tool copies it; the
+-- row turns ACTIVE once the copy is verified and the shared rows are gone.
+CREATE TABLE IF NOT EXISTS tenant_databases (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE RESTRICT,
+    database_name VARCHAR(63) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'MOVING',
+    activated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_tenant_databases_status CHECK (status IN ('MOVING', 'ACTIVE')),
+    CONSTRAINT uq_tenant_databases_database_name UNIQUE (database_name)
+);
+
+CREATE TRIGGER trg_tenant_databases_updated_at
+    BEFORE UPDATE ON tenant_databases
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
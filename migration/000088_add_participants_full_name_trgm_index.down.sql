@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_participants_full_name_trgm;
@@ -0,0 +1,7 @@
+-- Duplicate detection matches the first name token anywhere in full_name,
+-- which a btree index cannot serve.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_participants_full_name_trgm
+    ON participants USING gin (full_name gin_trgm_ops)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE participant_beneficiaries DROP COLUMN IF EXISTS bank_code;
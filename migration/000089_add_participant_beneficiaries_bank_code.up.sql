@@ -0,0 +1,4 @@
+ALTER TABLE participant_beneficiaries
+    ADD COLUMN IF NOT EXISTS bank_code VARCHAR(10) NULL;
+
+COMMENT ON COLUMN participant_beneficiaries.bank_code IS 'Bank of account_number. Death claims are only paid when every beneficiary has both.';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uk_participants_unsealed_ktp_tenant_product;
@@ -0,0 +1,6 @@
+-- 000084 moved KTP uniqueness to ktp_number_bidx, which stays NULL until the
+-- PII backfill seals a row. Until then legacy rows are kept unique on their
+-- normalized plaintext, the same expression the repository falls back to.
+CREATE UNIQUE INDEX IF NOT EXISTS uk_participants_unsealed_ktp_tenant_product
+    ON participants (upper(regexp_replace(ktp_number, '[^[:alnum:]]', '', 'g')), tenant_id, product_id)
+    WHERE ktp_number_bidx IS NULL AND ktp_number <> '' AND ktp_number NOT LIKE 'pii:%' AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+UPDATE files SET scan_status = 'PENDING_SCAN' WHERE scan_status = 'SCAN_FAILED';
+
+ALTER TABLE files DROP CONSTRAINT IF EXISTS chk_files_scan_status;
+ALTER TABLE files
+    ADD CONSTRAINT chk_files_scan_status CHECK (scan_status IN ('PENDING_SCAN', 'CLEAN', 'INFECTED'));
+
+ALTER TABLE files DROP COLUMN IF EXISTS scan_attempts;
@@ -0,0 +1,9 @@
+-- Scans that keep failing, such as a file that crashes the scanner, are
+-- retried until scan_attempts reaches the worker's limit and then parked as
+-- SCAN_FAILED instead of being claimed forever.
+ALTER TABLE files
+    ADD COLUMN IF NOT EXISTS scan_attempts INT NOT NULL DEFAULT 0;
+
+ALTER TABLE files DROP CONSTRAINT IF EXISTS chk_files_scan_status;
+ALTER TABLE files
+    ADD CONSTRAINT chk_files_scan_status CHECK (scan_status IN ('PENDING_SCAN', 'CLEAN', 'INFECTED', 'SCAN_FAILED'));
@@ -0,0 +1 @@
+ALTER TABLE upload_slots DROP COLUMN IF EXISTS completing_at;
@@ -0,0 +1,7 @@
+-- A completion claims its slot before reading the uploaded object, so two
+-- concurrent completions of the same slot cannot both seal and record a file,
+-- and the cleanup worker leaves a slot alone while it is being completed.
+ALTER TABLE upload_slots
+    ADD COLUMN IF NOT EXISTS completing_at TIMESTAMPTZ NULL;
+
+COMMENT ON COLUMN upload_slots.completing_at IS 'Set while a completion request is sealing the uploaded object; cleared if it fails.';
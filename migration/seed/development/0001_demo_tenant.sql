@@ -0,0 +1,6 @@
+-- Demo employer tenant for local development; not part of any real rollout.
+INSERT INTO tenants (code, name, tenant_type, status, settings)
+VALUES
+    ('DEMO-EMPLOYER', 'PT Demo Employer', 'MITRA_PENDIRI', 'ACTIVE',
+     '{"approval_required": true, "password_policy": {"min_length": 8, "require_special": true}}'::jsonb)
+ON CONFLICT (code) DO NOTHING;
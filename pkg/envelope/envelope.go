@@ -0,0 +1,92 @@
+// Package envelope implements client-side envelope encryption for stored
+// objects: every object is encrypted with its own data key, and only the data
+// key wrapped by a per-tenant key-encryption key (KEK) is persisted.
+package envelope
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// KeyProvider holds the key-encryption keys. Implementations never expose a
+// KEK, only wrap and unwrap data keys with it.
+type KeyProvider interface {
+	// GenerateDataKey returns a fresh DataKeySize-byte data key together with
+	// its wrapped form under the KEK keyID.
+	GenerateDataKey(ctx context.Context, keyID string) (plaintext []byte, wrapped string, err error)
+	DecryptDataKey(ctx context.Context, keyID, wrapped string) ([]byte, error)
+	// RewrapDataKey re-encrypts a wrapped data key under the latest version
+	// of the KEK without exposing the plaintext key to the caller.
+	RewrapDataKey(ctx context.Context, keyID, wrapped string) (string, error)
+}
+
+// Envelope is what has to be stored next to an object to decrypt it.
+type Envelope struct {
+	KeyID      string
+	WrappedKey string
+}
+
+type Encryptor struct {
+	keys      KeyProvider
+	keyPrefix string
+}
+
+func NewEncryptor(keys KeyProvider, keyPrefix string) *Encryptor {
+	return &Encryptor{
+		keys:      keys,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// TenantKeyID names the KEK of a tenant.
+func (e *Encryptor) TenantKeyID(tenantID uuid.UUID) string {
+	return e.keyPrefix + tenantID.String()
+}
+
+// Seal wraps plaintext in a streaming encrypter under a new data key for the
+// tenant. The returned size is the ciphertext size, -1 if size is unknown.
+func (e *Encryptor) Seal(ctx context.Context, tenantID uuid.UUID, plaintext io.Reader, size int64) (io.Reader, int64, *Envelope, error) {
+	keyID := e.TenantKeyID(tenantID)
+	dataKey, wrapped, err := e.keys.GenerateDataKey(ctx, keyID)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("generate data key: %w", err)
+	}
+	defer wipe(dataKey)
+
+	r, err := NewEncryptReader(plaintext, dataKey)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return r, CiphertextSize(size), &Envelope{KeyID: keyID, WrappedKey: wrapped}, nil
+}
+
+// Open unwraps the data key and returns a reader over the plaintext.
+func (e *Encryptor) Open(ctx context.Context, env *Envelope, ciphertext io.Reader) (io.Reader, error) {
+	dataKey, err := e.keys.DecryptDataKey(ctx, env.KeyID, env.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data key: %w", err)
+	}
+	defer wipe(dataKey)
+
+	return NewDecryptReader(ciphertext, dataKey)
+}
+
+// Rewrap returns the envelope with its data key wrapped by the current KEK
+// version. The object itself is untouched.
+func (e *Encryptor) Rewrap(ctx context.Context, env *Envelope) (*Envelope, error) {
+	wrapped, err := e.keys.RewrapDataKey(ctx, env.KeyID, env.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("rewrap data key: %w", err)
+	}
+	return &Envelope{KeyID: env.KeyID, WrappedKey: wrapped}, nil
+}
+
+// wipe clears key material once the cipher has been set up. AES expands the
+// key into its own schedule, so the caller's copy is no longer needed.
+func wipe(b []byte) {
+	subtle.XORBytes(b, b, b)
+}
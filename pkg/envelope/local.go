@@ -0,0 +1,90 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const localWrapPrefix = "local:v1:"
+
+// localKeyProvider derives one KEK per key ID from a single master key and
+// wraps data keys with AES-GCM. It is meant for tests and local development;
+// deployments use the Vault transit provider.
+type localKeyProvider struct {
+	masterKey []byte
+}
+
+func NewLocalKeyProvider(masterKey []byte) (KeyProvider, error) {
+	if len(masterKey) != DataKeySize {
+		return nil, fmt.Errorf("local master key must be %d bytes, got %d", DataKeySize, len(masterKey))
+	}
+	return &localKeyProvider{masterKey: append([]byte{}, masterKey...)}, nil
+}
+
+func (p *localKeyProvider) kek(keyID string) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, p.masterKey)
+	mac.Write([]byte("kek:" + keyID))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (p *localKeyProvider) GenerateDataKey(ctx context.Context, keyID string) ([]byte, string, error) {
+	dataKey := make([]byte, DataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, "", fmt.Errorf("generate data key: %w", err)
+	}
+	wrapped, err := p.wrap(keyID, dataKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return dataKey, wrapped, nil
+}
+
+func (p *localKeyProvider) DecryptDataKey(ctx context.Context, keyID, wrapped string) ([]byte, error) {
+	aead, err := p.kek(keyID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(wrapped, localWrapPrefix))
+	if err != nil || !strings.HasPrefix(wrapped, localWrapPrefix) || len(raw) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, sealed := raw[:aead.NonceSize()], raw[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, sealed, []byte(keyID))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return dataKey, nil
+}
+
+func (p *localKeyProvider) RewrapDataKey(ctx context.Context, keyID, wrapped string) (string, error) {
+	dataKey, err := p.DecryptDataKey(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	defer wipe(dataKey)
+	return p.wrap(keyID, dataKey)
+}
+
+func (p *localKeyProvider) wrap(keyID string, dataKey []byte) (string, error) {
+	aead, err := p.kek(keyID)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, dataKey, []byte(keyID))
+	return localWrapPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,204 @@
+package envelope
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Stream format: a header of magic + random nonce prefix, followed by
+// AES-256-GCM sealed chunks of at most ChunkSize plaintext bytes. Each chunk
+// nonce is prefix || counter || last-flag, so reordered, dropped or truncated
+// chunks fail authentication. Empty plaintext still produces one sealed chunk.
+const (
+	ChunkSize   = 64 * 1024
+	DataKeySize = 32
+
+	magic          = "ENV1"
+	noncePrefixLen = 7
+	headerLen      = len(magic) + noncePrefixLen
+	tagLen         = 16
+)
+
+var ErrInvalidCiphertext = errors.New("invalid or tampered ciphertext")
+
+// CiphertextSize returns the stored size of a plaintext of n bytes, or -1
+// when n is unknown.
+func CiphertextSize(n int64) int64 {
+	if n < 0 {
+		return -1
+	}
+	chunks := (n + ChunkSize - 1) / ChunkSize
+	if chunks == 0 {
+		chunks = 1
+	}
+	return int64(headerLen) + n + chunks*tagLen
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != DataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes, got %d", DataKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixLen:], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+type encryptReader struct {
+	aead    cipher.AEAD
+	src     *bufio.Reader
+	header  []byte
+	prefix  []byte
+	counter uint32
+	plain   []byte
+	out     []byte
+	done    bool
+}
+
+// NewEncryptReader returns a reader producing the encrypted stream of src.
+func NewEncryptReader(src io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, noncePrefixLen)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	header := append([]byte(magic), prefix...)
+	return &encryptReader{
+		aead:   aead,
+		src:    bufio.NewReaderSize(src, ChunkSize),
+		header: header,
+		prefix: prefix,
+		plain:  make([]byte, ChunkSize),
+		out:    append([]byte{}, header...),
+	}, nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.sealNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *encryptReader) sealNext() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("read plaintext: %w", err)
+	}
+	last := err != nil
+	if !last {
+		// A full chunk is the last one when nothing follows it.
+		if _, peekErr := r.src.Peek(1); peekErr == io.EOF {
+			last = true
+		} else if peekErr != nil {
+			return fmt.Errorf("read plaintext: %w", peekErr)
+		}
+	}
+
+	nonce := chunkNonce(r.prefix, r.counter, last)
+	r.out = r.aead.Seal(r.out[:0], nonce, r.plain[:n], r.header)
+	r.counter++
+	r.done = last
+	return nil
+}
+
+type decryptReader struct {
+	aead    cipher.AEAD
+	src     *bufio.Reader
+	header  []byte
+	prefix  []byte
+	counter uint32
+	sealed  []byte
+	out     []byte
+	done    bool
+}
+
+// NewDecryptReader returns a reader yielding the plaintext of an encrypted
+// stream. Authentication failures surface as ErrInvalidCiphertext.
+func NewDecryptReader(src io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReaderSize(src, ChunkSize+tagLen)
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(br, header); err != nil || string(header[:len(magic)]) != magic {
+		return nil, ErrInvalidCiphertext
+	}
+	return &decryptReader{
+		aead:   aead,
+		src:    br,
+		header: header,
+		prefix: header[len(magic):],
+		sealed: make([]byte, ChunkSize+tagLen),
+	}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.openNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) openNext() error {
+	n, err := io.ReadFull(r.src, r.sealed)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("read ciphertext: %w", err)
+	}
+	last := err != nil
+	if !last {
+		if _, peekErr := r.src.Peek(1); peekErr == io.EOF {
+			last = true
+		} else if peekErr != nil {
+			return fmt.Errorf("read ciphertext: %w", peekErr)
+		}
+	}
+	if n < tagLen {
+		return ErrInvalidCiphertext
+	}
+
+	nonce := chunkNonce(r.prefix, r.counter, last)
+	plain, openErr := r.aead.Open(r.sealed[:0], nonce, r.sealed[:n], r.header)
+	if openErr != nil {
+		return ErrInvalidCiphertext
+	}
+	r.out = plain
+	r.counter++
+	r.done = last
+	return nil
+}
@@ -0,0 +1,61 @@
+// Package grpcapi is the Go client for this service's gRPC API. The iam/v1
+// and masterdata/v1 packages are generated from the .proto files next to
+// them; regenerate with `go generate ./pkg/grpcapi` (needs protoc,
+// protoc-gen-go and protoc-gen-go-grpc on PATH).
+package grpcapi
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative pkg/grpcapi/iam/v1/iam.proto pkg/grpcapi/masterdata/v1/masterdata.proto
+
+import (
+	"context"
+	"fmt"
+
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the server reads. gRPC lower-cases keys on the wire.
+const (
+	MetadataAuthorization = "authorization"
+	MetadataRequestID     = "x-request-id"
+)
+
+type Client struct {
+	IAM        iamv1.IAMServiceClient
+	Masterdata masterdatav1.MasterdataServiceClient
+
+	conn *grpc.ClientConn
+}
+
+// NewClient connects lazily to target, e.g. "erp-service:9090". Pass
+// transport credentials in opts; use insecure.NewCredentials() for
+// plaintext inside the cluster.
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("grpc client for %s: %w", target, err)
+	}
+	return &Client{
+		IAM:        iamv1.NewIAMServiceClient(conn),
+		Masterdata: masterdatav1.NewMasterdataServiceClient(conn),
+		conn:       conn,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// WithAccessToken authenticates calls made with the returned context as the
+// token's user.
+func WithAccessToken(ctx context.Context, accessToken string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataAuthorization, "Bearer "+accessToken)
+}
+
+// WithRequestID propagates an upstream request ID so both services log it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataRequestID, requestID)
+}
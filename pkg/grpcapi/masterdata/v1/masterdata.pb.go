@@ -0,0 +1,760 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: pkg/grpcapi/masterdata/v1/masterdata.proto
+
+package masterdatav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryId     string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	TenantId       string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	ParentItemId   string                 `protobuf:"bytes,4,opt,name=parent_item_id,json=parentItemId,proto3" json:"parent_item_id,omitempty"`
+	Code           string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Name           string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	AltName        string                 `protobuf:"bytes,7,opt,name=alt_name,json=altName,proto3" json:"alt_name,omitempty"`
+	Description    string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	SortOrder      int32                  `protobuf:"varint,9,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	IsSystem       bool                   `protobuf:"varint,10,opt,name=is_system,json=isSystem,proto3" json:"is_system,omitempty"`
+	IsDefault      bool                   `protobuf:"varint,11,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Status         string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	EffectiveFrom  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	EffectiveUntil *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=effective_until,json=effectiveUntil,proto3" json:"effective_until,omitempty"`
+	// metadata is the item's raw JSON metadata.
+	Metadata      []byte                 `protobuf:"bytes,15,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Version       int32                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Item) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Item) GetParentItemId() string {
+	if x != nil {
+		return x.ParentItemId
+	}
+	return ""
+}
+
+func (x *Item) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetAltName() string {
+	if x != nil {
+		return x.AltName
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+func (x *Item) GetIsSystem() bool {
+	if x != nil {
+		return x.IsSystem
+	}
+	return false
+}
+
+func (x *Item) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *Item) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Item) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *Item) GetEffectiveUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveUntil
+	}
+	return nil
+}
+
+func (x *Item) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Item) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Item) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Item) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetItemByCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryCode  string                 `protobuf:"bytes,1,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	ItemCode      string                 `protobuf:"bytes,2,opt,name=item_code,json=itemCode,proto3" json:"item_code,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemByCodeRequest) Reset() {
+	*x = GetItemByCodeRequest{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemByCodeRequest) ProtoMessage() {}
+
+func (x *GetItemByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemByCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetItemByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetItemByCodeRequest) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *GetItemByCodeRequest) GetItemCode() string {
+	if x != nil {
+		return x.ItemCode
+	}
+	return ""
+}
+
+func (x *GetItemByCodeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type GetItemByCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemByCodeResponse) Reset() {
+	*x = GetItemByCodeResponse{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemByCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemByCodeResponse) ProtoMessage() {}
+
+func (x *GetItemByCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemByCodeResponse.ProtoReflect.Descriptor instead.
+func (*GetItemByCodeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetItemByCodeResponse) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type ValidateItemCodesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 100 validations per call.
+	Validations   []*ItemCodeValidation `protobuf:"bytes,1,rep,name=validations,proto3" json:"validations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateItemCodesRequest) Reset() {
+	*x = ValidateItemCodesRequest{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateItemCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateItemCodesRequest) ProtoMessage() {}
+
+func (x *ValidateItemCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateItemCodesRequest.ProtoReflect.Descriptor instead.
+func (*ValidateItemCodesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidateItemCodesRequest) GetValidations() []*ItemCodeValidation {
+	if x != nil {
+		return x.Validations
+	}
+	return nil
+}
+
+type ItemCodeValidation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryCode  string                 `protobuf:"bytes,1,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	ItemCode      string                 `protobuf:"bytes,2,opt,name=item_code,json=itemCode,proto3" json:"item_code,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemCodeValidation) Reset() {
+	*x = ItemCodeValidation{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemCodeValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemCodeValidation) ProtoMessage() {}
+
+func (x *ItemCodeValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemCodeValidation.ProtoReflect.Descriptor instead.
+func (*ItemCodeValidation) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ItemCodeValidation) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *ItemCodeValidation) GetItemCode() string {
+	if x != nil {
+		return x.ItemCode
+	}
+	return ""
+}
+
+func (x *ItemCodeValidation) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ValidateItemCodesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	AllValid      bool                        `protobuf:"varint,1,opt,name=all_valid,json=allValid,proto3" json:"all_valid,omitempty"`
+	Results       []*ItemCodeValidationResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateItemCodesResponse) Reset() {
+	*x = ValidateItemCodesResponse{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateItemCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateItemCodesResponse) ProtoMessage() {}
+
+func (x *ValidateItemCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateItemCodesResponse.ProtoReflect.Descriptor instead.
+func (*ValidateItemCodesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValidateItemCodesResponse) GetAllValid() bool {
+	if x != nil {
+		return x.AllValid
+	}
+	return false
+}
+
+func (x *ValidateItemCodesResponse) GetResults() []*ItemCodeValidationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ItemCodeValidationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryCode  string                 `protobuf:"bytes,1,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	ItemCode      string                 `protobuf:"bytes,2,opt,name=item_code,json=itemCode,proto3" json:"item_code,omitempty"`
+	Valid         bool                   `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemCodeValidationResult) Reset() {
+	*x = ItemCodeValidationResult{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemCodeValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemCodeValidationResult) ProtoMessage() {}
+
+func (x *ItemCodeValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemCodeValidationResult.ProtoReflect.Descriptor instead.
+func (*ItemCodeValidationResult) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ItemCodeValidationResult) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *ItemCodeValidationResult) GetItemCode() string {
+	if x != nil {
+		return x.ItemCode
+	}
+	return ""
+}
+
+func (x *ItemCodeValidationResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ItemCodeValidationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetItemTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryCode  string                 `protobuf:"bytes,1,opt,name=category_code,json=categoryCode,proto3" json:"category_code,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemTreeRequest) Reset() {
+	*x = GetItemTreeRequest{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemTreeRequest) ProtoMessage() {}
+
+func (x *GetItemTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetItemTreeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetItemTreeRequest) GetCategoryCode() string {
+	if x != nil {
+		return x.CategoryCode
+	}
+	return ""
+}
+
+func (x *GetItemTreeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type GetItemTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemTreeResponse) Reset() {
+	*x = GetItemTreeResponse{}
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemTreeResponse) ProtoMessage() {}
+
+func (x *GetItemTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetItemTreeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetItemTreeResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_pkg_grpcapi_masterdata_v1_masterdata_proto protoreflect.FileDescriptor
+
+const file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDesc = "" +
+	"\n" +
+	"*pkg/grpcapi/masterdata/v1/masterdata.proto\x12\x11erp.masterdata.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x05\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12$\n" +
+	"\x0eparent_item_id\x18\x04 \x01(\tR\fparentItemId\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x19\n" +
+	"\balt_name\x18\a \x01(\tR\aaltName\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\t \x01(\x05R\tsortOrder\x12\x1b\n" +
+	"\tis_system\x18\n" +
+	" \x01(\bR\bisSystem\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\v \x01(\bR\tisDefault\x12\x16\n" +
+	"\x06status\x18\f \x01(\tR\x06status\x12A\n" +
+	"\x0eeffective_from\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12C\n" +
+	"\x0feffective_until\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0eeffectiveUntil\x12\x1a\n" +
+	"\bmetadata\x18\x0f \x01(\fR\bmetadata\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"u\n" +
+	"\x14GetItemByCodeRequest\x12#\n" +
+	"\rcategory_code\x18\x01 \x01(\tR\fcategoryCode\x12\x1b\n" +
+	"\titem_code\x18\x02 \x01(\tR\bitemCode\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\"D\n" +
+	"\x15GetItemByCodeResponse\x12+\n" +
+	"\x04item\x18\x01 \x01(\v2\x17.erp.masterdata.v1.ItemR\x04item\"c\n" +
+	"\x18ValidateItemCodesRequest\x12G\n" +
+	"\vvalidations\x18\x01 \x03(\v2%.erp.masterdata.v1.ItemCodeValidationR\vvalidations\"s\n" +
+	"\x12ItemCodeValidation\x12#\n" +
+	"\rcategory_code\x18\x01 \x01(\tR\fcategoryCode\x12\x1b\n" +
+	"\titem_code\x18\x02 \x01(\tR\bitemCode\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\"\x7f\n" +
+	"\x19ValidateItemCodesResponse\x12\x1b\n" +
+	"\tall_valid\x18\x01 \x01(\bR\ballValid\x12E\n" +
+	"\aresults\x18\x02 \x03(\v2+.erp.masterdata.v1.ItemCodeValidationResultR\aresults\"\x8c\x01\n" +
+	"\x18ItemCodeValidationResult\x12#\n" +
+	"\rcategory_code\x18\x01 \x01(\tR\fcategoryCode\x12\x1b\n" +
+	"\titem_code\x18\x02 \x01(\tR\bitemCode\x12\x14\n" +
+	"\x05valid\x18\x03 \x01(\bR\x05valid\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"V\n" +
+	"\x12GetItemTreeRequest\x12#\n" +
+	"\rcategory_code\x18\x01 \x01(\tR\fcategoryCode\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\"D\n" +
+	"\x13GetItemTreeResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.erp.masterdata.v1.ItemR\x05items2\xc5\x02\n" +
+	"\x11MasterdataService\x12b\n" +
+	"\rGetItemByCode\x12'.erp.masterdata.v1.GetItemByCodeRequest\x1a(.erp.masterdata.v1.GetItemByCodeResponse\x12n\n" +
+	"\x11ValidateItemCodes\x12+.erp.masterdata.v1.ValidateItemCodesRequest\x1a,.erp.masterdata.v1.ValidateItemCodesResponse\x12\\\n" +
+	"\vGetItemTree\x12%.erp.masterdata.v1.GetItemTreeRequest\x1a&.erp.masterdata.v1.GetItemTreeResponseB4Z2erp-service/pkg/grpcapi/masterdata/v1;masterdatav1b\x06proto3"
+
+var (
+	file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescOnce sync.Once
+	file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescData []byte
+)
+
+func file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescGZIP() []byte {
+	file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescOnce.Do(func() {
+		file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDesc), len(file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDesc)))
+	})
+	return file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDescData
+}
+
+var file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_grpcapi_masterdata_v1_masterdata_proto_goTypes = []any{
+	(*Item)(nil),                      // 0: erp.masterdata.v1.Item
+	(*GetItemByCodeRequest)(nil),      // 1: erp.masterdata.v1.GetItemByCodeRequest
+	(*GetItemByCodeResponse)(nil),     // 2: erp.masterdata.v1.GetItemByCodeResponse
+	(*ValidateItemCodesRequest)(nil),  // 3: erp.masterdata.v1.ValidateItemCodesRequest
+	(*ItemCodeValidation)(nil),        // 4: erp.masterdata.v1.ItemCodeValidation
+	(*ValidateItemCodesResponse)(nil), // 5: erp.masterdata.v1.ValidateItemCodesResponse
+	(*ItemCodeValidationResult)(nil),  // 6: erp.masterdata.v1.ItemCodeValidationResult
+	(*GetItemTreeRequest)(nil),        // 7: erp.masterdata.v1.GetItemTreeRequest
+	(*GetItemTreeResponse)(nil),       // 8: erp.masterdata.v1.GetItemTreeResponse
+	(*timestamppb.Timestamp)(nil),     // 9: google.protobuf.Timestamp
+}
+var file_pkg_grpcapi_masterdata_v1_masterdata_proto_depIdxs = []int32{
+	9,  // 0: erp.masterdata.v1.Item.effective_from:type_name -> google.protobuf.Timestamp
+	9,  // 1: erp.masterdata.v1.Item.effective_until:type_name -> google.protobuf.Timestamp
+	9,  // 2: erp.masterdata.v1.Item.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: erp.masterdata.v1.Item.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: erp.masterdata.v1.GetItemByCodeResponse.item:type_name -> erp.masterdata.v1.Item
+	4,  // 5: erp.masterdata.v1.ValidateItemCodesRequest.validations:type_name -> erp.masterdata.v1.ItemCodeValidation
+	6,  // 6: erp.masterdata.v1.ValidateItemCodesResponse.results:type_name -> erp.masterdata.v1.ItemCodeValidationResult
+	0,  // 7: erp.masterdata.v1.GetItemTreeResponse.items:type_name -> erp.masterdata.v1.Item
+	1,  // 8: erp.masterdata.v1.MasterdataService.GetItemByCode:input_type -> erp.masterdata.v1.GetItemByCodeRequest
+	3,  // 9: erp.masterdata.v1.MasterdataService.ValidateItemCodes:input_type -> erp.masterdata.v1.ValidateItemCodesRequest
+	7,  // 10: erp.masterdata.v1.MasterdataService.GetItemTree:input_type -> erp.masterdata.v1.GetItemTreeRequest
+	2,  // 11: erp.masterdata.v1.MasterdataService.GetItemByCode:output_type -> erp.masterdata.v1.GetItemByCodeResponse
+	5,  // 12: erp.masterdata.v1.MasterdataService.ValidateItemCodes:output_type -> erp.masterdata.v1.ValidateItemCodesResponse
+	8,  // 13: erp.masterdata.v1.MasterdataService.GetItemTree:output_type -> erp.masterdata.v1.GetItemTreeResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pkg_grpcapi_masterdata_v1_masterdata_proto_init() }
+func file_pkg_grpcapi_masterdata_v1_masterdata_proto_init() {
+	if File_pkg_grpcapi_masterdata_v1_masterdata_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDesc), len(file_pkg_grpcapi_masterdata_v1_masterdata_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_grpcapi_masterdata_v1_masterdata_proto_goTypes,
+		DependencyIndexes: file_pkg_grpcapi_masterdata_v1_masterdata_proto_depIdxs,
+		MessageInfos:      file_pkg_grpcapi_masterdata_v1_masterdata_proto_msgTypes,
+	}.Build()
+	File_pkg_grpcapi_masterdata_v1_masterdata_proto = out.File
+	file_pkg_grpcapi_masterdata_v1_masterdata_proto_goTypes = nil
+	file_pkg_grpcapi_masterdata_v1_masterdata_proto_depIdxs = nil
+}
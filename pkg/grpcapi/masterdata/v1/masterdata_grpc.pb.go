@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pkg/grpcapi/masterdata/v1/masterdata.proto
+
+package masterdatav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MasterdataService_GetItemByCode_FullMethodName     = "/erp.masterdata.v1.MasterdataService/GetItemByCode"
+	MasterdataService_ValidateItemCodes_FullMethodName = "/erp.masterdata.v1.MasterdataService/ValidateItemCodes"
+	MasterdataService_GetItemTree_FullMethodName       = "/erp.masterdata.v1.MasterdataService/GetItemTree"
+)
+
+// MasterdataServiceClient is the client API for MasterdataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MasterdataService exposes the read side of masterdata. Like the public REST
+// endpoints it needs no caller credentials; a tenant_id selects that tenant's
+// items in addition to the global ones.
+type MasterdataServiceClient interface {
+	GetItemByCode(ctx context.Context, in *GetItemByCodeRequest, opts ...grpc.CallOption) (*GetItemByCodeResponse, error)
+	ValidateItemCodes(ctx context.Context, in *ValidateItemCodesRequest, opts ...grpc.CallOption) (*ValidateItemCodesResponse, error)
+	// GetItemTree returns a category's items flat; parent_item_id links them.
+	GetItemTree(ctx context.Context, in *GetItemTreeRequest, opts ...grpc.CallOption) (*GetItemTreeResponse, error)
+}
+
+type masterdataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMasterdataServiceClient(cc grpc.ClientConnInterface) MasterdataServiceClient {
+	return &masterdataServiceClient{cc}
+}
+
+func (c *masterdataServiceClient) GetItemByCode(ctx context.Context, in *GetItemByCodeRequest, opts ...grpc.CallOption) (*GetItemByCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemByCodeResponse)
+	err := c.cc.Invoke(ctx, MasterdataService_GetItemByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterdataServiceClient) ValidateItemCodes(ctx context.Context, in *ValidateItemCodesRequest, opts ...grpc.CallOption) (*ValidateItemCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateItemCodesResponse)
+	err := c.cc.Invoke(ctx, MasterdataService_ValidateItemCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterdataServiceClient) GetItemTree(ctx context.Context, in *GetItemTreeRequest, opts ...grpc.CallOption) (*GetItemTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemTreeResponse)
+	err := c.cc.Invoke(ctx, MasterdataService_GetItemTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MasterdataServiceServer is the server API for MasterdataService service.
+// All implementations must embed UnimplementedMasterdataServiceServer
+// for forward compatibility.
+//
+// MasterdataService exposes the read side of masterdata. Like the public REST
+// endpoints it needs no caller credentials; a tenant_id selects that tenant's
+// items in addition to the global ones.
+type MasterdataServiceServer interface {
+	GetItemByCode(context.Context, *GetItemByCodeRequest) (*GetItemByCodeResponse, error)
+	ValidateItemCodes(context.Context, *ValidateItemCodesRequest) (*ValidateItemCodesResponse, error)
+	// GetItemTree returns a category's items flat; parent_item_id links them.
+	GetItemTree(context.Context, *GetItemTreeRequest) (*GetItemTreeResponse, error)
+	mustEmbedUnimplementedMasterdataServiceServer()
+}
+
+// UnimplementedMasterdataServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMasterdataServiceServer struct{}
+
+func (UnimplementedMasterdataServiceServer) GetItemByCode(context.Context, *GetItemByCodeRequest) (*GetItemByCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItemByCode not implemented")
+}
+func (UnimplementedMasterdataServiceServer) ValidateItemCodes(context.Context, *ValidateItemCodesRequest) (*ValidateItemCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateItemCodes not implemented")
+}
+func (UnimplementedMasterdataServiceServer) GetItemTree(context.Context, *GetItemTreeRequest) (*GetItemTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItemTree not implemented")
+}
+func (UnimplementedMasterdataServiceServer) mustEmbedUnimplementedMasterdataServiceServer() {}
+func (UnimplementedMasterdataServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeMasterdataServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MasterdataServiceServer will
+// result in compilation errors.
+type UnsafeMasterdataServiceServer interface {
+	mustEmbedUnimplementedMasterdataServiceServer()
+}
+
+func RegisterMasterdataServiceServer(s grpc.ServiceRegistrar, srv MasterdataServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMasterdataServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MasterdataService_ServiceDesc, srv)
+}
+
+func _MasterdataService_GetItemByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterdataServiceServer).GetItemByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MasterdataService_GetItemByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterdataServiceServer).GetItemByCode(ctx, req.(*GetItemByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterdataService_ValidateItemCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateItemCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterdataServiceServer).ValidateItemCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MasterdataService_ValidateItemCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterdataServiceServer).ValidateItemCodes(ctx, req.(*ValidateItemCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MasterdataService_GetItemTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterdataServiceServer).GetItemTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MasterdataService_GetItemTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterdataServiceServer).GetItemTree(ctx, req.(*GetItemTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MasterdataService_ServiceDesc is the grpc.ServiceDesc for MasterdataService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MasterdataService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "erp.masterdata.v1.MasterdataService",
+	HandlerType: (*MasterdataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItemByCode",
+			Handler:    _MasterdataService_GetItemByCode_Handler,
+		},
+		{
+			MethodName: "ValidateItemCodes",
+			Handler:    _MasterdataService_ValidateItemCodes_Handler,
+		},
+		{
+			MethodName: "GetItemTree",
+			Handler:    _MasterdataService_GetItemTree_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/grpcapi/masterdata/v1/masterdata.proto",
+}
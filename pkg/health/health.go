@@ -0,0 +1,253 @@
+// Package health runs the readiness and liveness checks behind the probe
+// endpoints. Readiness asks whether the dependencies a request needs are
+// reachable; liveness only asks whether this process can still make
+// progress, so an outage elsewhere never gets a healthy pod restarted.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp Status = "up"
+	// StatusFailing is a check that failed fewer times in a row than its
+	// threshold. It does not take the process out of rotation yet.
+	StatusFailing Status = "failing"
+	StatusDown    Status = "down"
+	// StatusDegraded is an aggregate with a non-critical check down or a
+	// critical one failing.
+	StatusDegraded Status = "degraded"
+)
+
+type Check struct {
+	Name string
+	// Critical checks decide the aggregate; the others are reported only.
+	Critical bool
+	Timeout  time.Duration
+	// CacheTTL is how long a result is served before the check runs again,
+	// so probes from many sources do not hammer the dependency.
+	CacheTTL time.Duration
+	// FailureThreshold is the number of consecutive failures before the
+	// check reports down.
+	FailureThreshold int
+	Run              func(ctx context.Context) error
+}
+
+type Options struct {
+	Timeout          time.Duration
+	CacheTTL         time.Duration
+	FailureThreshold int
+}
+
+type Result struct {
+	Status              Status    `json:"status"`
+	Critical            bool      `json:"critical"`
+	Error               string    `json:"error,omitempty"`
+	LatencyMS           int64     `json:"latency_ms"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+	CheckedAt           time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Healthy reports whether the aggregate should keep the process in
+// rotation, or alive for a liveness report.
+func (r Report) Healthy() bool {
+	return r.Status != StatusDown
+}
+
+// WithoutErrors returns r with error messages removed, for responses that
+// must not reveal internal addresses.
+func (r Report) WithoutErrors() Report {
+	checks := make(map[string]Result, len(r.Checks))
+	for name, result := range r.Checks {
+		result.Error = ""
+		checks[name] = result
+	}
+	return Report{Status: r.Status, Checks: checks}
+}
+
+type Registry struct {
+	opts Options
+
+	mu        sync.RWMutex
+	readiness []*entry
+	liveness  []*entry
+}
+
+// NewRegistry fills zero fields of registered checks from opts.
+func NewRegistry(opts Options) *Registry {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	if opts.FailureThreshold < 1 {
+		opts.FailureThreshold = 1
+	}
+	return &Registry{opts: opts}
+}
+
+func (r *Registry) Register(check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.readiness = append(r.readiness, r.newEntry(check))
+}
+
+// RegisterLiveness adds a check of this process itself. It must not call
+// out to other services. Liveness checks are always critical.
+func (r *Registry) RegisterLiveness(check Check) {
+	check.Critical = true
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.liveness = append(r.liveness, r.newEntry(check))
+}
+
+func (r *Registry) newEntry(check Check) *entry {
+	if check.Timeout <= 0 {
+		check.Timeout = r.opts.Timeout
+	}
+	if check.CacheTTL <= 0 {
+		check.CacheTTL = r.opts.CacheTTL
+	}
+	if check.FailureThreshold < 1 {
+		check.FailureThreshold = r.opts.FailureThreshold
+	}
+	return &entry{check: check}
+}
+
+func (r *Registry) Ready(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := r.readiness
+	r.mu.RUnlock()
+	return aggregate(ctx, entries)
+}
+
+func (r *Registry) Live(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := r.liveness
+	r.mu.RUnlock()
+	return aggregate(ctx, entries)
+}
+
+func aggregate(ctx context.Context, entries []*entry) Report {
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.result(ctx)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(entries))}
+	for i, e := range entries {
+		result := results[i]
+		report.Checks[e.check.Name] = result
+		switch {
+		case result.Status == StatusUp:
+		case result.Critical && result.Status == StatusDown:
+			report.Status = StatusDown
+		case report.Status != StatusDown:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+type entry struct {
+	check Check
+
+	// mu is held while the check runs, so concurrent probes wait for one
+	// run and share its result.
+	mu       sync.Mutex
+	last     Result
+	failures int
+}
+
+func (e *entry) result(ctx context.Context) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.last.CheckedAt.IsZero() && time.Since(e.last.CheckedAt) < e.check.CacheTTL {
+		return e.last
+	}
+
+	start := time.Now()
+	err := e.run(ctx)
+	result := Result{
+		Status:    StatusUp,
+		Critical:  e.check.Critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		e.failures++
+		result.Error = err.Error()
+		result.ConsecutiveFailures = e.failures
+		result.Status = StatusFailing
+		if e.failures >= e.check.FailureThreshold {
+			result.Status = StatusDown
+		}
+	} else {
+		e.failures = 0
+	}
+	e.last = result
+	return result
+}
+
+// run enforces the timeout even when the check ignores its context.
+func (e *entry) run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, e.check.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- e.check.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", e.check.Timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// Handler serves report as JSON, with 503 when it is down. Error
+// messages are included only when detailed is set.
+func Handler(report func(context.Context) Report, detailed bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep := report(r.Context())
+		if !detailed {
+			rep = rep.WithoutErrors()
+		}
+		status := http.StatusOK
+		if !rep.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(rep)
+	})
+}
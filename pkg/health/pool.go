@@ -0,0 +1,58 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// poolAcquireTimeout is how long PoolCheck waits for a saturated pool to
+// hand it a connection.
+const poolAcquireTimeout = 500 * time.Millisecond
+
+// PoolCheck is a liveness check for a connection pool that has wedged:
+// every connection checked out and none returned, continuously for longer
+// than stall. While the pool has room it passes on its statistics alone;
+// only a saturated pool is probed, by queueing for a connection that some
+// caller has to give back, so the check never dials the database and a
+// database outage does not fail it.
+func PoolCheck(db *sql.DB, stall time.Duration) func(context.Context) error {
+	var (
+		mu    sync.Mutex
+		since time.Time
+	)
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stats := db.Stats()
+		if stats.MaxOpenConnections <= 0 || stats.InUse < stats.MaxOpenConnections || acquire(ctx, db) {
+			since = time.Time{}
+			return nil
+		}
+		if since.IsZero() {
+			since = time.Now()
+		}
+		if stalled := time.Since(since); stalled >= stall {
+			return fmt.Errorf("all %d connections in use and none released for %s", stats.InUse, stalled.Round(time.Second))
+		}
+		return nil
+	}
+}
+
+// acquire reports whether a connection became free within
+// poolAcquireTimeout. Errors other than the wait running out mean the pool
+// did hand over a connection, so they count as progress.
+func acquire(ctx context.Context, db *sql.DB) bool {
+	ctx, cancel := context.WithTimeout(ctx, poolAcquireTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return !errors.Is(err, context.DeadlineExceeded)
+	}
+	_ = conn.Close()
+	return true
+}
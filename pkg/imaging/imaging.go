@@ -0,0 +1,145 @@
+// Package imaging normalizes user-uploaded photos: it applies the EXIF
+// orientation, bounds the resolution and re-encodes the pixels, which drops
+// EXIF, GPS and any other embedded metadata.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+
+	// DefaultMaxPixels rejects decompression bombs before the pixel buffer
+	// is allocated (roughly a 100 megapixel photo).
+	DefaultMaxPixels = 100_000_000
+
+	jpegQuality      = 85
+	thumbnailQuality = 75
+)
+
+var ErrTooLarge = errors.New("image dimensions exceed limit")
+
+// Supported reports whether the content type is an image format the
+// processor can decode.
+func Supported(contentType string) bool {
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeGIF:
+		return true
+	}
+	return false
+}
+
+type Options struct {
+	MaxDimension       int
+	ThumbnailDimension int
+	MaxPixels          int
+}
+
+type Result struct {
+	Image           []byte
+	ContentType     string
+	Thumbnail       []byte
+	ThumbnailType   string
+	Width, Height   int
+	ThumbnailWidth  int
+	ThumbnailHeight int
+}
+
+// Process decodes data, orients it upright, scales it to fit within
+// MaxDimension and produces a JPEG thumbnail. JPEG input stays JPEG; PNG and
+// GIF input is re-encoded as PNG to keep transparency and sharp edges.
+func Process(data []byte, opts Options) (*Result, error) {
+	maxPixels := opts.MaxPixels
+	if maxPixels <= 0 {
+		maxPixels = DefaultMaxPixels
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	normalized := fit(img, opts.MaxDimension)
+	result := &Result{
+		Width:  normalized.Bounds().Dx(),
+		Height: normalized.Bounds().Dy(),
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, normalized, &jpeg.Options{Quality: jpegQuality})
+		result.ContentType = ContentTypeJPEG
+	} else {
+		err = png.Encode(&buf, normalized)
+		result.ContentType = ContentTypePNG
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode image: %w", err)
+	}
+	result.Image = buf.Bytes()
+
+	thumb := flatten(fit(normalized, opts.ThumbnailDimension))
+	var thumbBuf bytes.Buffer
+	if err := jpeg.Encode(&thumbBuf, thumb, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	result.Thumbnail = thumbBuf.Bytes()
+	result.ThumbnailType = ContentTypeJPEG
+	result.ThumbnailWidth = thumb.Bounds().Dx()
+	result.ThumbnailHeight = thumb.Bounds().Dy()
+
+	return result, nil
+}
+
+// fit scales img down so neither side exceeds maxDim, keeping the aspect
+// ratio. Images already within bounds are returned as-is.
+func fit(img image.Image, maxDim int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxDim <= 0 || (w <= maxDim && h <= maxDim) {
+		return img
+	}
+
+	nw, nh := maxDim, maxDim
+	if w >= h {
+		nh = max(1, h*maxDim/w)
+	} else {
+		nw = max(1, w*maxDim/h)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, nw, nh))
+	xdraw.CatmullRom.Scale(dst, dst.Rect, img, b, xdraw.Src, nil)
+	return dst
+}
+
+// flatten composites img onto white, since JPEG has no alpha channel.
+func flatten(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	xdraw.Draw(dst, dst.Rect, image.NewUniform(color.White), image.Point{}, xdraw.Src)
+	xdraw.Draw(dst, dst.Rect, img, b.Min, xdraw.Over)
+	return dst
+}
@@ -133,3 +133,26 @@ func ParseRefreshToken(tokenString string, config *TokenConfig) (*jwt.Registered
 
 	return nil, ErrTokenInvalid
 }
+
+// ParseAnyAccessToken accepts both access token shapes. A multi-tenant token
+// with at least one tenant wins; anything else is parsed as a legacy token,
+// so the returned error is the legacy parser's. The multi-tenant claims are
+// nil for legacy tokens.
+func ParseAnyAccessToken(tokenString string, config *TokenConfig) (*JWTClaims, *MultiTenantClaims, error) {
+	multiClaims, err := ParseMultiTenantAccessToken(tokenString, config)
+	if err == nil && len(multiClaims.Tenants) > 0 {
+		return &JWTClaims{
+			UserID:           multiClaims.UserID,
+			Email:            multiClaims.Email,
+			Roles:            multiClaims.Roles,
+			SessionID:        multiClaims.SessionID,
+			RegisteredClaims: multiClaims.RegisteredClaims,
+		}, multiClaims, nil
+	}
+
+	claims, err := ParseAccessToken(tokenString, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claims, nil, nil
+}
@@ -97,6 +97,7 @@ func (l *zapAuditLogger) Log(ctx context.Context, event AuditEvent) {
 	if sessionID := extractString(ctx, CtxSessionID); sessionID != "" {
 		fields = append(fields, zap.String("session_id", sessionID))
 	}
+	fields = append(fields, TraceFields(ctx)...)
 
 	if len(event.Metadata) > 0 {
 		fields = append(fields, zap.Any("metadata", event.Metadata))
@@ -0,0 +1,164 @@
+// Package masking redacts personal data in response structs according to
+// the caller's permissions. Sensitive fields carry a classification tag:
+//
+//	KTPNumber *string `json:"ktp_number,omitempty" mask:"national_id"`
+//
+// and Apply rewrites each tagged field to its full, partial or hidden form.
+package masking
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Classification string
+
+const (
+	NationalID  Classification = "national_id"
+	BankAccount Classification = "bank_account"
+	Phone       Classification = "phone"
+	DateOfBirth Classification = "date_of_birth"
+)
+
+var Classifications = []Classification{NationalID, BankAccount, Phone, DateOfBirth}
+
+type Level int
+
+const (
+	Hidden Level = iota
+	Partial
+	Full
+)
+
+const (
+	maskRune       = '*'
+	partialVisible = 4
+)
+
+// Permission codes granting a visibility level for a classification, e.g.
+// "pii_national_id:full".
+func FullPermission(c Classification) string    { return "pii_" + string(c) + ":full" }
+func PartialPermission(c Classification) string { return "pii_" + string(c) + ":partial" }
+
+// RevealPermission allows fetching a single unmasked value through the
+// audited reveal endpoint.
+const RevealPermission = "pii:reveal"
+
+// Policy is the level granted per classification. Classifications missing
+// from the policy are hidden.
+type Policy map[Classification]Level
+
+// FullPolicy shows everything.
+func FullPolicy() Policy {
+	p := make(Policy, len(Classifications))
+	for _, c := range Classifications {
+		p[c] = Full
+	}
+	return p
+}
+
+// PolicyFor builds a policy from a permission check; full wins over partial.
+func PolicyFor(has func(permission string) bool) Policy {
+	p := make(Policy, len(Classifications))
+	for _, c := range Classifications {
+		switch {
+		case has(FullPermission(c)):
+			p[c] = Full
+		case has(PartialPermission(c)):
+			p[c] = Partial
+		default:
+			p[c] = Hidden
+		}
+	}
+	return p
+}
+
+func (p Policy) Level(c Classification) Level {
+	return p[c]
+}
+
+// Mask keeps the last four characters and replaces the rest with '*', so
+// "3201010101010001" becomes "************0001". Values of four characters
+// or fewer are masked entirely.
+func Mask(value string) string {
+	runes := []rune(value)
+	if len(runes) <= partialVisible {
+		return strings.Repeat(string(maskRune), len(runes))
+	}
+	hidden := len(runes) - partialVisible
+	return strings.Repeat(string(maskRune), hidden) + string(runes[hidden:])
+}
+
+// IsMasked reports whether value looks like the output of Mask. Writes use
+// it to refuse a masked value echoed back by a client.
+func IsMasked(value string) bool {
+	return strings.ContainsRune(value, maskRune)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Apply masks v in place. v must be a pointer or a slice; structs,
+// pointers, slices and arrays are walked recursively. Tagged string and
+// *string fields are masked or cleared; time.Time and *time.Time fields have
+// no partial form and are cleared unless the level is Full. Tagged pointer
+// fields are replaced, never written through, so a value shared with an
+// entity stays intact.
+func Apply(v interface{}, policy Policy) {
+	walk(reflect.ValueOf(v), policy)
+}
+
+func walk(v reflect.Value, policy Policy) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), policy)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), policy)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			if !t.Field(i).IsExported() || !field.CanSet() {
+				continue
+			}
+			if tag, ok := t.Field(i).Tag.Lookup("mask"); ok {
+				maskField(field, policy.Level(Classification(tag)))
+				continue
+			}
+			walk(field, policy)
+		}
+	}
+}
+
+func maskField(field reflect.Value, level Level) {
+	if level == Full {
+		return
+	}
+	switch {
+	case field.Kind() == reflect.String:
+		if level == Partial {
+			field.SetString(Mask(field.String()))
+		} else {
+			field.SetString("")
+		}
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.String:
+		if field.IsNil() {
+			return
+		}
+		if level == Partial {
+			masked := Mask(field.Elem().String())
+			field.Set(reflect.ValueOf(&masked))
+		} else {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	case field.Type() == timeType, field.Kind() == reflect.Ptr && field.Type().Elem() == timeType:
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database statement latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"database", "operation", "table"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Database statements that failed, not counting record-not-found.",
+	}, []string{"database", "operation", "table"})
+)
+
+// dbStats holds the pool stats collector registered per database name, so
+// a pool that is closed and reopened reports the new one.
+var dbStats = struct {
+	sync.Mutex
+	collectors map[string]prometheus.Collector
+}{collectors: make(map[string]prometheus.Collector)}
+
+func init() {
+	Registry.MustRegister(dbQueryDuration, dbQueryErrors)
+}
+
+// InstrumentGorm times every statement db runs and exports its connection
+// pool stats, labelled with name.
+func InstrumentGorm(db *gorm.DB, name string) error {
+	cb := db.Callback()
+	if err := errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", startTimer),
+		cb.Create().After("gorm:create").Register("metrics:after_create", observeStatement(name, "create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", startTimer),
+		cb.Query().After("gorm:query").Register("metrics:after_query", observeStatement(name, "query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", startTimer),
+		cb.Update().After("gorm:update").Register("metrics:after_update", observeStatement(name, "update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", startTimer),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", observeStatement(name, "delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", startTimer),
+		cb.Row().After("gorm:row").Register("metrics:after_row", observeStatement(name, "row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", startTimer),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", observeStatement(name, "raw")),
+	); err != nil {
+		return fmt.Errorf("register gorm callbacks: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("get underlying sql.DB: %w", err)
+	}
+	collector := collectors.NewDBStatsCollector(sqlDB, name)
+	dbStats.Lock()
+	defer dbStats.Unlock()
+	if previous, ok := dbStats.collectors[name]; ok {
+		Registry.Unregister(previous)
+	}
+	if err := register(collector); err != nil {
+		return err
+	}
+	dbStats.collectors[name] = collector
+	return nil
+}
+
+// ReleaseGorm stops exporting the pool stats of the database instrumented
+// as name, once its pool is closed.
+func ReleaseGorm(name string) {
+	dbStats.Lock()
+	defer dbStats.Unlock()
+	if collector, ok := dbStats.collectors[name]; ok {
+		Registry.Unregister(collector)
+		delete(dbStats.collectors, name)
+	}
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func observeStatement(database, operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		dbQueryDuration.WithLabelValues(database, operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbQueryErrors.WithLabelValues(database, operation, table).Inc()
+		}
+	}
+}
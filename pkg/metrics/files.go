@@ -0,0 +1,35 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	cleanupBatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_cleanup_batches_total",
+		Help:      "File cleanup batches by outcome: ok or error.",
+	}, []string{"outcome"})
+
+	cleanupItems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "file_cleanup_items_total",
+		Help:      "Files and upload slots handled by cleanup batches, by kind and result.",
+	}, []string{"kind", "result"})
+)
+
+func init() {
+	Registry.MustRegister(cleanupBatches, cleanupItems)
+}
+
+// ObserveCleanupBatch records one cleanup run. The counts are those of
+// files.BatchResult; they are ignored when err is set.
+func ObserveCleanupBatch(processed, failed, slotsReaped, slotsFailed int, err error) {
+	if err != nil {
+		cleanupBatches.WithLabelValues("error").Inc()
+		return
+	}
+	cleanupBatches.WithLabelValues("ok").Inc()
+	cleanupItems.WithLabelValues("file", "deleted").Add(float64(processed))
+	cleanupItems.WithLabelValues("file", "failed").Add(float64(failed))
+	cleanupItems.WithLabelValues("upload_slot", "reaped").Add(float64(slotsReaped))
+	cleanupItems.WithLabelValues("upload_slot", "failed").Add(float64(slotsFailed))
+}
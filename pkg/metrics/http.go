@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// httpRequests carries the tenant so error rates can be split per
+	// tenant; the histogram leaves it out to keep its series count bounded.
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, status and tenant.",
+	}, []string{"method", "route", "status", "tenant"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+func init() {
+	Registry.MustRegister(httpRequests, httpDuration)
+}
+
+// ObserveHTTPRequest records a finished request. route is the template the
+// router matched, such as /api/v1/participants/:id, never the raw path.
+func ObserveHTTPRequest(method, route string, status int, tenant string, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code, tenant).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
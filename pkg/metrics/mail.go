@@ -0,0 +1,34 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	EmailTemplateRegistrationOTP = "registration_otp"
+	EmailTemplateLoginOTP        = "login_otp"
+	EmailTemplateWelcome         = "welcome"
+	EmailTemplatePasswordReset   = "password_reset"
+	EmailTemplatePINReset        = "pin_reset"
+	EmailTemplateAdminInvitation = "admin_invitation"
+)
+
+const (
+	EmailSent   = "sent"
+	EmailFailed = "failed"
+	// EmailShed is an email dropped before sending because too many were
+	// already in flight.
+	EmailShed = "shed"
+)
+
+var emailSends = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "email_sends_total",
+	Help:      "Emails by template and outcome: sent, failed or shed.",
+}, []string{"template", "outcome"})
+
+func init() {
+	Registry.MustRegister(emailSends)
+}
+
+func ObserveEmail(template, outcome string) {
+	emailSends.WithLabelValues(template, outcome).Inc()
+}
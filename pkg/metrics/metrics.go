@@ -0,0 +1,101 @@
+// Package metrics holds the Prometheus collectors the service exports and
+// the admin server that exposes them. Callers record through the helpers in
+// this package so domain code does not depend on the Prometheus client.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"erp-service/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "erp"
+
+// Registry holds every collector of this process. It is separate from the
+// client's default registry so tests can scrape it in isolation.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// register adds c to Registry, tolerating a second registration of the same
+// collector, e.g. when a process opens the same pool twice.
+func register(c prometheus.Collector) error {
+	if err := Registry.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+type Server struct {
+	server *http.Server
+	mux    *http.ServeMux
+}
+
+// NewServer returns the admin server for cfg, or nil when metrics are
+// disabled; a nil *Server is safe to Start and Shutdown.
+func NewServer(cfg config.MetricsConfig) *Server {
+	if !cfg.Enabled {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &Server{
+		server: &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		mux: mux,
+	}
+}
+
+// Handle mounts h next to /metrics, for binaries such as the worker that
+// serve nothing else over HTTP. It must be called before Start.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	if s == nil {
+		return
+	}
+	s.mux.Handle(pattern, h)
+}
+
+// Start listens in the background. A failure to listen is logged and does
+// not stop the process it observes.
+func (s *Server) Start() {
+	if s == nil {
+		return
+	}
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics server stopped: %v", err)
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	OTPFlowLogin        = "login"
+	OTPFlowRegistration = "registration"
+
+	OTPFailInvalid = "invalid"
+	OTPFailExpired = "expired"
+	OTPFailLocked  = "locked"
+)
+
+var (
+	otpIssued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otp_issued_total",
+		Help:      "One-time passwords generated, including resends.",
+	}, []string{"flow"})
+
+	otpVerified = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otp_verified_total",
+		Help:      "One-time passwords verified successfully.",
+	}, []string{"flow"})
+
+	otpFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otp_failed_total",
+		Help:      "One-time password verifications rejected, by reason.",
+	}, []string{"flow", "reason"})
+)
+
+func init() {
+	Registry.MustRegister(otpIssued, otpVerified, otpFailed)
+}
+
+func OTPIssued(flow string) {
+	otpIssued.WithLabelValues(flow).Inc()
+}
+
+func OTPVerified(flow string) {
+	otpVerified.WithLabelValues(flow).Inc()
+}
+
+func OTPFailed(flow, reason string) {
+	otpFailed.WithLabelValues(flow, reason).Inc()
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// queueScrapeTimeout bounds the Redis calls made during one scrape.
+const queueScrapeTimeout = 2 * time.Second
+
+const (
+	JobCompleted    = "completed"
+	JobRetried      = "retried"
+	JobDeadLettered = "dead_lettered"
+	JobDropped      = "dropped"
+	JobInterrupted  = "interrupted"
+	JobUnknownType  = "unknown_type"
+)
+
+var (
+	jobsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_processed_total",
+		Help:      "Jobs run by the worker, by queue, type and outcome.",
+	}, []string{"queue", "type", "outcome"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Job handler run time by queue and type.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600},
+	}, []string{"queue", "type"})
+)
+
+func init() {
+	Registry.MustRegister(jobsProcessed, jobDuration)
+}
+
+func ObserveJob(queue, jobType, outcome string, duration time.Duration) {
+	jobsProcessed.WithLabelValues(queue, jobType, outcome).Inc()
+	jobDuration.WithLabelValues(queue, jobType).Observe(duration.Seconds())
+}
+
+// QueueInspector reports how many jobs sit in each list of a queue.
+type QueueInspector interface {
+	QueueLen(ctx context.Context, queueName string) (int64, error)
+	ProcessingLen(ctx context.Context, queueName string) (int64, error)
+	DeadLetterLen(ctx context.Context, queueName string) (int64, error)
+}
+
+// WatchQueues exports the pending, processing and dead-letter depths of
+// queues, read from inspector on every scrape.
+func WatchQueues(inspector QueueInspector, queues []string) {
+	queueDepths.mu.Lock()
+	defer queueDepths.mu.Unlock()
+	queueDepths.sources = append(queueDepths.sources, queueSource{inspector: inspector, queues: queues})
+}
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "queue_depth"),
+		"Jobs in a queue by state: pending, processing or dead_letter.",
+		[]string{"queue", "state"}, nil,
+	)
+	queueScrapeErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "queue_depth_scrape_error"),
+		"1 if reading the queue depth failed during this scrape.",
+		[]string{"queue"}, nil,
+	)
+)
+
+var queueDepths = &queueCollector{}
+
+func init() {
+	Registry.MustRegister(queueDepths)
+}
+
+type queueSource struct {
+	inspector QueueInspector
+	queues    []string
+}
+
+type queueCollector struct {
+	mu      sync.Mutex
+	sources []queueSource
+}
+
+func (q *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueScrapeErrorDesc
+}
+
+func (q *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	q.mu.Lock()
+	sources := q.sources
+	q.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), queueScrapeTimeout)
+	defer cancel()
+
+	for _, source := range sources {
+		for _, queue := range source.queues {
+			failed := 0.0
+			for _, state := range []struct {
+				name string
+				len  func(context.Context, string) (int64, error)
+			}{
+				{"pending", source.inspector.QueueLen},
+				{"processing", source.inspector.ProcessingLen},
+				{"dead_letter", source.inspector.DeadLetterLen},
+			} {
+				n, err := state.len(ctx, queue)
+				if err != nil {
+					failed = 1
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(n), queue, state.name)
+			}
+			ch <- prometheus.MustNewConstMetric(queueScrapeErrorDesc, prometheus.GaugeValue, failed, queue)
+		}
+	}
+}
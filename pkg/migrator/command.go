@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	CommandUp      = "up"
+	CommandDown    = "down"
+	CommandGoto    = "goto"
+	CommandVersion = "version"
+	CommandForce   = "force"
+	CommandStatus  = "status"
+	CommandSeed    = "seed"
+)
+
+type Command struct {
+	Name string
+	// Arg is the step count for up and down, or the target version for
+	// goto and force. HasArg tells "up" from "up 0".
+	Arg    int
+	HasArg bool
+}
+
+// ParseCommand reads "<command> [arg]": up [N], down N, goto V, force V,
+// version, status and seed.
+func ParseCommand(args []string) (Command, error) {
+	if len(args) == 0 {
+		return Command{}, fmt.Errorf("missing command")
+	}
+	cmd := Command{Name: args[0]}
+	if len(args) > 2 {
+		return Command{}, fmt.Errorf("%s: too many arguments", cmd.Name)
+	}
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return Command{}, fmt.Errorf("%s: %q is not a number", cmd.Name, args[1])
+		}
+		cmd.Arg, cmd.HasArg = n, true
+	}
+
+	switch cmd.Name {
+	case CommandUp:
+		if cmd.HasArg && cmd.Arg < 1 {
+			return Command{}, fmt.Errorf("up: step count must be at least 1")
+		}
+	case CommandDown:
+		// A bare "down" would revert every migration; make the count
+		// explicit.
+		if !cmd.HasArg || cmd.Arg < 1 {
+			return Command{}, fmt.Errorf("down: step count N >= 1 is required")
+		}
+	case CommandGoto:
+		if !cmd.HasArg || cmd.Arg < 1 {
+			return Command{}, fmt.Errorf("goto: target version is required")
+		}
+	case CommandForce:
+		// -1 marks the database as having no version, as in migrate.
+		if !cmd.HasArg || cmd.Arg < -1 {
+			return Command{}, fmt.Errorf("force: version is required")
+		}
+	case CommandVersion, CommandStatus, CommandSeed:
+		if cmd.HasArg {
+			return Command{}, fmt.Errorf("%s: takes no arguments", cmd.Name)
+		}
+	default:
+		return Command{}, fmt.Errorf("unknown command %q", cmd.Name)
+	}
+	return cmd, nil
+}
+
+// Destructive reports whether cmd reverts migrations from current, which
+// may drop tables or columns.
+func (c Command) Destructive(current uint, hasVersion bool) bool {
+	switch c.Name {
+	case CommandDown:
+		return true
+	case CommandGoto:
+		return hasVersion && uint(c.Arg) < current
+	default:
+		return false
+	}
+}
+
+// Mutates reports whether cmd writes to the database.
+func (c Command) Mutates() bool {
+	switch c.Name {
+	case CommandVersion, CommandStatus:
+		return false
+	default:
+		return true
+	}
+}
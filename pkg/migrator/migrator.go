@@ -0,0 +1,266 @@
+// Package migrator applies the SQL migrations in migration/ under a
+// Postgres advisory lock, so concurrent deploys run them one at a time.
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// lockKey is the advisory lock every migrator instance takes. It is not the
+// key migrate locks per statement batch, so both can be held at once.
+const lockKey int64 = 0x6572705f6d6967 // "erp_mig"
+
+const lockPollInterval = 500 * time.Millisecond
+
+var (
+	ErrLockTimeout        = errors.New("timed out waiting for the migration lock")
+	ErrDestructiveRefused = errors.New("refusing to revert migrations in production without -allow-destructive")
+	ErrDirty              = errors.New("database is dirty; repair the failed migration by hand, then run force")
+)
+
+type Options struct {
+	// Path is the migration directory; seeds live in Path/seed/<Environment>.
+	Path             string
+	Environment      string
+	Production       bool
+	LockTimeout      time.Duration
+	DryRun           bool
+	AllowDestructive bool
+	Out              io.Writer
+	// Role, when set, is assumed on every connection so the objects
+	// migrations create are owned by it rather than by the login user,
+	// which may be a short-lived Vault credential.
+	Role string
+}
+
+type Migrator struct {
+	db   *sql.DB
+	m    *migrate.Migrate
+	opts Options
+}
+
+func Open(dsn string, opts Options) (*Migrator, error) {
+	if opts.Role != "" {
+		var err error
+		if dsn, err = WithRole(dsn, opts.Role); err != nil {
+			return nil, err
+		}
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://"+opts.Path, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("create migrator: %w", err)
+	}
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	return &Migrator{db: db, m: m, opts: opts}, nil
+}
+
+// WithRole adds role to the startup options of a postgres:// URL, the
+// equivalent of SET ROLE right after connecting. The login user has to be a
+// member of role.
+func WithRole(dsn, role string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("parse database url: %w", err)
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, " ", `\ `).Replace(role)
+	q := u.Query()
+	q.Set("options", strings.TrimSpace(q.Get("options")+" -c role="+escaped))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Close also closes the database handle.
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+func (m *Migrator) Run(ctx context.Context, cmd Command) error {
+	if cmd.Mutates() && !m.opts.DryRun {
+		unlock, err := m.lock(ctx)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	current, dirty, hasVersion, err := m.version()
+	if err != nil {
+		return err
+	}
+	if cmd.Destructive(current, hasVersion) && m.opts.Production && !m.opts.AllowDestructive && !m.opts.DryRun {
+		return ErrDestructiveRefused
+	}
+
+	switch cmd.Name {
+	case CommandVersion:
+		fmt.Fprintln(m.opts.Out, formatVersion(current, dirty, hasVersion))
+		return nil
+	case CommandStatus:
+		return m.status(current, dirty, hasVersion)
+	case CommandForce:
+		if m.opts.DryRun {
+			fmt.Fprintf(m.opts.Out, "would force version %d (now %s)\n", cmd.Arg, formatVersion(current, dirty, hasVersion))
+			return nil
+		}
+		return m.m.Force(cmd.Arg)
+	case CommandSeed:
+		return m.seed(ctx)
+	}
+
+	migrations, err := LoadMigrations(m.opts.Path)
+	if err != nil {
+		return err
+	}
+	steps, err := Plan(migrations, current, hasVersion, cmd)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		fmt.Fprintln(m.opts.Out, "no change")
+		return nil
+	}
+	for _, step := range steps {
+		fmt.Fprintf(m.opts.Out, "%s %s\n", step.Direction, step.File)
+	}
+	if m.opts.DryRun {
+		fmt.Fprintf(m.opts.Out, "dry run: %d migration(s) not applied\n", len(steps))
+		return nil
+	}
+	if dirty {
+		return fmt.Errorf("%w (version %d)", ErrDirty, current)
+	}
+
+	switch cmd.Name {
+	case CommandUp:
+		if cmd.HasArg {
+			err = m.m.Steps(cmd.Arg)
+		} else {
+			err = m.m.Up()
+		}
+	case CommandDown:
+		err = m.m.Steps(-cmd.Arg)
+	case CommandGoto:
+		err = m.m.Migrate(uint(cmd.Arg))
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	current, dirty, hasVersion, err = m.version()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(m.opts.Out, formatVersion(current, dirty, hasVersion))
+	return nil
+}
+
+func (m *Migrator) version() (uint, bool, bool, error) {
+	current, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, fmt.Errorf("read version: %w", err)
+	}
+	return current, dirty, true, nil
+}
+
+func (m *Migrator) status(current uint, dirty, hasVersion bool) error {
+	migrations, err := LoadMigrations(m.opts.Path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(m.opts.Out, formatVersion(current, dirty, hasVersion))
+
+	pending := 0
+	for _, mig := range migrations {
+		mark := "x"
+		if !hasVersion || mig.Version > current {
+			mark = " "
+			pending++
+		}
+		fmt.Fprintf(m.opts.Out, "  [%s] %06d %s\n", mark, mig.Version, mig.Name)
+	}
+	fmt.Fprintf(m.opts.Out, "pending: %d\n", pending)
+	if hasVersion && !hasMigration(migrations, current) {
+		fmt.Fprintf(m.opts.Out, "warning: version %d is not in %s\n", current, m.opts.Path)
+	}
+	return nil
+}
+
+// lock takes the migrator advisory lock on a dedicated session, polling
+// until LockTimeout. The returned function releases it.
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock connection: %w", err)
+	}
+
+	deadline := time.Now().Add(m.opts.LockTimeout)
+	for {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey).Scan(&locked); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			conn.Close()
+			return nil, ErrLockTimeout
+		}
+		fmt.Fprintln(m.opts.Out, "waiting for another migration to finish...")
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+		conn.Close()
+	}, nil
+}
+
+func (m *Migrator) seedDir() string {
+	return filepath.Join(m.opts.Path, "seed", m.opts.Environment)
+}
+
+func formatVersion(current uint, dirty, hasVersion bool) string {
+	switch {
+	case !hasVersion:
+		return "version: none"
+	case dirty:
+		return fmt.Sprintf("version: %d (dirty)", current)
+	default:
+		return fmt.Sprintf("version: %d", current)
+	}
+}
@@ -0,0 +1,126 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadSeeds lists the .sql files in dir in name order.
+func LoadSeeds(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// seed runs the files in Path/seed/<environment> that have not run yet,
+// each in one transaction with its row in schema_seeds. Seeds hold data for
+// one environment, such as demo tenants; reference data every environment
+// needs belongs in a migration.
+func (m *Migrator) seed(ctx context.Context) error {
+	dir := m.seedDir()
+	files, err := LoadSeeds(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(m.opts.Out, "no seeds for environment %q\n", m.opts.Environment)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read seeds: %w", err)
+	}
+
+	applied, err := m.appliedSeeds(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, name := range files {
+		if applied[name] {
+			continue
+		}
+		pending++
+		fmt.Fprintf(m.opts.Out, "seed %s\n", name)
+		if m.opts.DryRun {
+			continue
+		}
+		if err := m.applySeed(ctx, filepath.Join(dir, name), name); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case pending == 0:
+		fmt.Fprintln(m.opts.Out, "no change")
+	case m.opts.DryRun:
+		fmt.Fprintf(m.opts.Out, "dry run: %d seed(s) not applied\n", pending)
+	}
+	return nil
+}
+
+func (m *Migrator) appliedSeeds(ctx context.Context) (map[string]bool, error) {
+	if m.opts.DryRun {
+		var exists bool
+		if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_seeds') IS NOT NULL").Scan(&exists); err != nil {
+			return nil, fmt.Errorf("check seed table: %w", err)
+		}
+		if !exists {
+			return map[string]bool{}, nil
+		}
+	} else {
+		_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_seeds (
+			name TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+		if err != nil {
+			return nil, fmt.Errorf("create seed table: %w", err)
+		}
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT name FROM schema_seeds")
+	if err != nil {
+		return nil, fmt.Errorf("read applied seeds: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("read applied seeds: %w", err)
+		}
+		applied[name] = true
+	}
+	return applied, rows.Err()
+}
+
+func (m *Migrator) applySeed(ctx context.Context, path, name string) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read seed %s: %w", name, err)
+	}
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("seed %s: %w", name, err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, string(body)); err != nil {
+		return fmt.Errorf("seed %s: %w", name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_seeds (name) VALUES ($1)", name); err != nil {
+		return fmt.Errorf("record seed %s: %w", name, err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var migrationFileRe = regexp.MustCompile(`^([0-9]+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is one version in the migration directory.
+type Migration struct {
+	Version  uint
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+// Step is one migration file a command would run.
+type Step struct {
+	Version   uint
+	Direction string
+	File      string
+}
+
+// LoadMigrations lists the versioned migrations in dir, oldest first.
+// Subdirectories and other files are ignored, as migrate does.
+func LoadMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		v, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		version := uint(v)
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("version %d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.UpFile = entry.Name()
+		} else {
+			m.DownFile = entry.Name()
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Plan lists the files cmd would run against a database at current, in
+// order. hasVersion is false for a database nothing was applied to.
+func Plan(migrations []Migration, current uint, hasVersion bool, cmd Command) ([]Step, error) {
+	var steps []Step
+	switch cmd.Name {
+	case CommandUp:
+		for _, m := range migrations {
+			if hasVersion && m.Version <= current {
+				continue
+			}
+			if cmd.HasArg && len(steps) == cmd.Arg {
+				break
+			}
+			steps = append(steps, Step{Version: m.Version, Direction: "up", File: m.UpFile})
+		}
+	case CommandDown:
+		for i := len(migrations) - 1; i >= 0 && len(steps) < cmd.Arg; i-- {
+			m := migrations[i]
+			if !hasVersion || m.Version > current {
+				continue
+			}
+			steps = append(steps, Step{Version: m.Version, Direction: "down", File: m.DownFile})
+		}
+		if len(steps) < cmd.Arg {
+			return nil, fmt.Errorf("down %d: only %d applied migration(s)", cmd.Arg, len(steps))
+		}
+	case CommandGoto:
+		target := uint(cmd.Arg)
+		if !hasVersion || target > current {
+			for _, m := range migrations {
+				if (!hasVersion || m.Version > current) && m.Version <= target {
+					steps = append(steps, Step{Version: m.Version, Direction: "up", File: m.UpFile})
+				}
+			}
+		} else {
+			for i := len(migrations) - 1; i >= 0; i-- {
+				m := migrations[i]
+				if m.Version <= current && m.Version > target {
+					steps = append(steps, Step{Version: m.Version, Direction: "down", File: m.DownFile})
+				}
+			}
+		}
+		if !hasMigration(migrations, target) {
+			return nil, fmt.Errorf("goto %d: no such migration", target)
+		}
+	default:
+		return nil, fmt.Errorf("%s has no migration plan", cmd.Name)
+	}
+
+	for _, step := range steps {
+		if step.File == "" {
+			return nil, fmt.Errorf("migration %d has no %s file", step.Version, step.Direction)
+		}
+	}
+	return steps, nil
+}
+
+func hasMigration(migrations []Migration, version uint) bool {
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
+		}
+	}
+	return false
+}
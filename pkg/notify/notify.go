@@ -0,0 +1,114 @@
+// Package notify describes the workflow events pushed to connected clients.
+// Events are scoped to a tenant and product and grouped into topics, which is
+// the unit the WebSocket server grants by role.
+package notify
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Topic string
+
+const (
+	// TopicApprovalQueue carries participants entering the approval queue.
+	TopicApprovalQueue Topic = "approval_queue"
+	// TopicParticipantStatus carries every other participant status change,
+	// including approvals and rejections leaving the queue.
+	TopicParticipantStatus Topic = "participant_status"
+	// TopicMembers carries member registration and membership changes.
+	TopicMembers Topic = "members"
+)
+
+const (
+	ParticipantSubmitted     = "participant.submitted"
+	ParticipantApproved      = "participant.approved"
+	ParticipantRejected      = "participant.rejected"
+	ParticipantStatusChanged = "participant.status_changed"
+
+	MemberRegistered  = "member.registered"
+	MemberApproved    = "member.approved"
+	MemberRejected    = "member.rejected"
+	MemberDeactivated = "member.deactivated"
+	MemberRoleChanged = "member.role_changed"
+)
+
+type Event struct {
+	// ID is assigned when the event is published and increases with every
+	// event of the same tenant and product, so clients resume from it.
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Topic      Topic           `json:"topic"`
+	TenantID   uuid.UUID       `json:"tenant_id"`
+	ProductID  uuid.UUID       `json:"product_id"`
+	Data       json.RawMessage `json:"data"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+func NewEvent(topic Topic, eventType string, tenantID, productID uuid.UUID, data any) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:       eventType,
+		Topic:      topic,
+		TenantID:   tenantID,
+		ProductID:  productID,
+		Data:       raw,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+// ParticipantStatusData is the payload of participant events. It carries
+// identifiers and statuses only; clients fetch details they may see.
+type ParticipantStatusData struct {
+	ParticipantID uuid.UUID `json:"participant_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	ChangedBy     uuid.UUID `json:"changed_by"`
+}
+
+// MemberData is the payload of member events.
+type MemberData struct {
+	MemberID  uuid.UUID  `json:"member_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Status    string     `json:"status"`
+	RoleCode  *string    `json:"role_code,omitempty"`
+	ChangedBy *uuid.UUID `json:"changed_by,omitempty"`
+}
+
+// IDAfter reports whether event ID id was published after cursor. IDs have
+// the "<milliseconds>-<sequence>" form; malformed ones compare as zero.
+func IDAfter(id, cursor string) bool {
+	idMs, idSeq := splitID(id)
+	curMs, curSeq := splitID(cursor)
+	if idMs != curMs {
+		return idMs > curMs
+	}
+	return idSeq > curSeq
+}
+
+// ValidID reports whether s has the event ID form.
+func ValidID(s string) bool {
+	ms, seq, ok := strings.Cut(s, "-")
+	if !ok {
+		return false
+	}
+	if _, err := strconv.ParseUint(ms, 10, 64); err != nil {
+		return false
+	}
+	_, err := strconv.ParseUint(seq, 10, 64)
+	return err == nil
+}
+
+func splitID(s string) (uint64, uint64) {
+	ms, seq, _ := strings.Cut(s, "-")
+	msN, _ := strconv.ParseUint(ms, 10, 64)
+	seqN, _ := strconv.ParseUint(seq, 10, 64)
+	return msN, seqN
+}
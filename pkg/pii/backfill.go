@@ -0,0 +1,28 @@
+package pii
+
+import "context"
+
+// Backfiller seals column values written before encryption was enabled and
+// fills in their blind indexes.
+type Backfiller interface {
+	// BackfillBatch seals up to limit plaintext values per column after the
+	// cursor's position and moves the cursor past every row it visited, so a
+	// row that fails to seal is not selected again within the same run.
+	BackfillBatch(ctx context.Context, cursor BackfillCursor, limit int) (BackfillResult, error)
+}
+
+// BackfillCursor holds the last row id visited per table and column. A run
+// starts from an empty cursor.
+type BackfillCursor map[string]string
+
+type BackfillResult struct {
+	Visited  int
+	Sealed   int
+	Failures []BackfillFailure
+}
+
+type BackfillFailure struct {
+	Table string
+	ID    string
+	Err   error
+}
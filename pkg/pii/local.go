@@ -0,0 +1,82 @@
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	LocalKeySize = 32
+
+	localPrefix = "local:v1:"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid pii ciphertext")
+
+// localCipher derives an AES-256-GCM key and an HMAC-SHA256 index key from
+// one master key. It is meant for tests and local development; deployments
+// use the Vault transit cipher.
+type localCipher struct {
+	aead     cipher.AEAD
+	indexKey []byte
+}
+
+func NewLocalCipher(masterKey []byte) (Cipher, error) {
+	if len(masterKey) != LocalKeySize {
+		return nil, fmt.Errorf("local pii key must be %d bytes, got %d", LocalKeySize, len(masterKey))
+	}
+	block, err := aes.NewCipher(deriveKey(masterKey, "pii:encrypt"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &localCipher{
+		aead:     aead,
+		indexKey: deriveKey(masterKey, "pii:blind-index"),
+	}, nil
+}
+
+func deriveKey(masterKey []byte, label string) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+func (c *localCipher) Encrypt(ctx context.Context, column string, plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(column))
+	return localPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *localCipher) Decrypt(ctx context.Context, column, ciphertext string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, localPrefix))
+	if err != nil || !strings.HasPrefix(ciphertext, localPrefix) || len(raw) < c.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, sealed := raw[:c.aead.NonceSize()], raw[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, sealed, []byte(column))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+func (c *localCipher) BlindIndex(ctx context.Context, value []byte) (string, error) {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write(value)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
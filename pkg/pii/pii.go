@@ -0,0 +1,148 @@
+// Package pii encrypts designated personal-data columns at rest. Values are
+// sealed by a Cipher before they reach the database, and exact-match lookups
+// go through keyed blind indexes stored next to the ciphertext.
+package pii
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// sealedPrefix marks a column value as ciphertext. Anything without it is
+// legacy plaintext written before encryption was enabled.
+const sealedPrefix = "pii:"
+
+var ErrNotConfigured = errors.New("pii cipher is not configured")
+
+// Cipher encrypts column values and derives their blind indexes.
+type Cipher interface {
+	// Encrypt binds the ciphertext to column, so a value copied into
+	// another column does not decrypt.
+	Encrypt(ctx context.Context, column string, plaintext []byte) (string, error)
+	Decrypt(ctx context.Context, column, ciphertext string) ([]byte, error)
+	// BlindIndex returns a deterministic keyed hash of value. It is not
+	// column-bound so the same number matches across columns.
+	BlindIndex(ctx context.Context, value []byte) (string, error)
+}
+
+var (
+	mu      sync.RWMutex
+	current Cipher
+)
+
+// Register sets the cipher used by the gorm serializer and the package
+// functions. It is called once at startup.
+func Register(c Cipher) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+func registered() (Cipher, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return nil, ErrNotConfigured
+	}
+	return current, nil
+}
+
+// IsSealed reports whether a stored value is already ciphertext.
+func IsSealed(stored string) bool {
+	return strings.HasPrefix(stored, sealedPrefix)
+}
+
+// Seal encrypts plaintext into its stored form. Empty values stay empty.
+func Seal(ctx context.Context, column, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	c, err := registered()
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := c.Encrypt(ctx, column, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return sealedPrefix + ciphertext, nil
+}
+
+// Open decrypts a stored value. Legacy plaintext is returned unchanged so
+// rows can be read before the backfill has reached them.
+func Open(ctx context.Context, column, stored string) (string, error) {
+	if !IsSealed(stored) {
+		return stored, nil
+	}
+	c, err := registered()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := c.Decrypt(ctx, column, strings.TrimPrefix(stored, sealedPrefix))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Normalize reduces an identifier to upper-case letters and digits, so
+// "3201-0101" and "32010101" share a blind index.
+func Normalize(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// BlindIndex returns the blind index of value, or "" when nothing is left
+// of it after normalization.
+func BlindIndex(ctx context.Context, value string) (string, error) {
+	normalized := Normalize(value)
+	if normalized == "" {
+		return "", nil
+	}
+	c, err := registered()
+	if err != nil {
+		return "", err
+	}
+	return c.BlindIndex(ctx, []byte(normalized))
+}
+
+// BlindIndexPtr is BlindIndex for nullable columns.
+func BlindIndexPtr(ctx context.Context, value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	index, err := BlindIndex(ctx, *value)
+	if err != nil || index == "" {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// BlindIndexes indexes every value, dropping empty and repeated ones.
+func BlindIndexes(ctx context.Context, values []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(values))
+	indexes := make([]string, 0, len(values))
+	for _, value := range values {
+		index, err := BlindIndex(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if index == "" {
+			continue
+		}
+		if _, ok := seen[index]; ok {
+			continue
+		}
+		seen[index] = struct{}{}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
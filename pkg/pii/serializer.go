@@ -0,0 +1,79 @@
+package pii
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the gorm serializer for encrypted columns:
+//
+//	KTPNumber *string `gorm:"column:ktp_number;serializer:pii"`
+//
+// The column name is the encryption context, so a field must keep its
+// column name once data has been written.
+const SerializerName = "pii"
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Serializer seals string and *string fields on write and opens them on
+// read.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	if dbValue != nil {
+		var stored string
+		switch v := dbValue.(type) {
+		case string:
+			stored = v
+		case []byte:
+			stored = string(v)
+		default:
+			return fmt.Errorf("pii: unsupported column value %T for %s", dbValue, field.DBName)
+		}
+
+		plaintext, err := Open(ctx, field.DBName, stored)
+		if err != nil {
+			return fmt.Errorf("pii: decrypt %s: %w", field.DBName, err)
+		}
+
+		switch field.FieldType.Kind() {
+		case reflect.String:
+			fieldValue.Elem().SetString(plaintext)
+		case reflect.Ptr:
+			fieldValue.Elem().Set(reflect.ValueOf(&plaintext))
+		default:
+			return fmt.Errorf("pii: unsupported field type %s for %s", field.FieldType, field.DBName)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var plaintext string
+	switch v := fieldValue.(type) {
+	case string:
+		plaintext = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		plaintext = *v
+	default:
+		return nil, fmt.Errorf("pii: unsupported field type %T for %s", fieldValue, field.DBName)
+	}
+
+	sealed, err := Seal(ctx, field.DBName, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("pii: encrypt %s: %w", field.DBName, err)
+	}
+	return sealed, nil
+}
@@ -0,0 +1,25 @@
+package tenantdb
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type tenantKey struct{}
+
+// WithTenant marks ctx as working on tenantID's data, so repositories over
+// tenant data use that tenant's database.
+func WithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// WithoutTenant routes ctx to the platform database.
+func WithoutTenant(ctx context.Context) context.Context {
+	return WithTenant(ctx, uuid.Nil)
+}
+
+func TenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	return tenantID, ok && tenantID != uuid.Nil
+}
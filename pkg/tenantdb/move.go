@@ -0,0 +1,346 @@
+package tenantdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Table is tenant data that moves with its tenant. Filter selects the
+// tenant's rows; %s is the tenant ID literal.
+type Table struct {
+	Name   string
+	Filter string
+}
+
+const (
+	byTenant      = "tenant_id = %s"
+	byParticipant = "participant_id IN (SELECT id FROM participants WHERE tenant_id = %s)"
+)
+
+// Tables lists the tenant data tables, parents before children. A
+// migration adding tenant data must add its table here, or moves leave
+// its rows behind on the shared tables.
+var Tables = []Table{
+	{Name: "participants", Filter: byTenant},
+	{Name: "participant_identities", Filter: byParticipant},
+	{Name: "participant_addresses", Filter: byParticipant},
+	{Name: "participant_bank_accounts", Filter: byParticipant},
+	{Name: "participant_family_members", Filter: byParticipant},
+	{Name: "participant_employments", Filter: byParticipant},
+	{Name: "participant_beneficiaries", Filter: byParticipant},
+	{Name: "participant_status_history", Filter: byParticipant},
+	{Name: "participant_pensions", Filter: byParticipant},
+	{Name: "participant_duplicate_candidates", Filter: byTenant},
+	{Name: "files", Filter: byTenant},
+	{Name: "upload_slots", Filter: byTenant},
+	{Name: "file_retention_policies", Filter: byTenant},
+	{Name: "contribution_import_batches", Filter: byTenant},
+	{Name: "contribution_ledger_entries", Filter: byTenant},
+	{Name: "claims", Filter: byTenant},
+	{Name: "claim_documents", Filter: byTenant},
+	{Name: "claim_status_history", Filter: byTenant},
+	{Name: "claim_payment_instructions", Filter: byTenant},
+}
+
+var (
+	ErrAlreadyDedicated   = errors.New("tenant already has a dedicated database")
+	ErrSchemaMismatch     = errors.New("platform and tenant databases are on different migration versions")
+	ErrChecksumMismatch   = errors.New("copied rows do not match the source")
+	ErrChangedDuringMove  = errors.New("tenant rows changed on the shared tables during the copy")
+	ErrNotMoving          = errors.New("tenant is not being moved")
+	ErrUnexpectedRowCount = errors.New("deleted a different number of shared rows than were copied")
+)
+
+// Checksum summarises a table's rows for one tenant.
+type Checksum struct {
+	Rows int64
+	Sum  string
+}
+
+// Block records database as tenantID's dedicated database in MOVING state,
+// which fails its requests until Activate or Unblock. Wait for the
+// routers' placement TTL before copying, so no process still writes to the
+// shared tables.
+func Block(ctx context.Context, platform *pgx.Conn, tenantID uuid.UUID, database string) error {
+	tag, err := platform.Exec(ctx, `
+		INSERT INTO tenant_databases (tenant_id, database_name, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id) DO UPDATE SET database_name = EXCLUDED.database_name
+		WHERE tenant_databases.status = $3`,
+		tenantID, database, string(StatusMoving))
+	if err != nil {
+		return fmt.Errorf("block tenant: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAlreadyDedicated
+	}
+	return nil
+}
+
+// Unblock puts a tenant whose move failed back on the shared tables.
+func Unblock(ctx context.Context, platform *pgx.Conn, tenantID uuid.UUID) error {
+	_, err := platform.Exec(ctx,
+		`DELETE FROM tenant_databases WHERE tenant_id = $1 AND status = $2`,
+		tenantID, string(StatusMoving))
+	return err
+}
+
+// Copy copies tenantID's rows from the shared tables on platform into the
+// dedicated database, replacing whatever an earlier attempt left there. It
+// reads one snapshot of platform and returns the checksums of that
+// snapshot for Activate.
+func Copy(ctx context.Context, platform, dedicated *pgx.Conn, tenantID uuid.UUID) (map[string]Checksum, error) {
+	for _, conn := range []*pgx.Conn{platform, dedicated} {
+		if err := stableTextOutput(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+	platformVersion, err := schemaVersion(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+	dedicatedVersion, err := schemaVersion(ctx, dedicated)
+	if err != nil {
+		return nil, err
+	}
+	if platformVersion != dedicatedVersion {
+		return nil, fmt.Errorf("%w: %d and %d", ErrSchemaMismatch, platformVersion, dedicatedVersion)
+	}
+
+	src, err := platform.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin snapshot: %w", err)
+	}
+	defer src.Rollback(ctx)
+	dst, err := dedicated.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin copy: %w", err)
+	}
+	defer dst.Rollback(ctx)
+
+	if err := deleteRows(ctx, dst, tenantID, nil); err != nil {
+		return nil, fmt.Errorf("clear earlier attempt: %w", err)
+	}
+
+	sums := make(map[string]Checksum, len(Tables))
+	for _, table := range Tables {
+		columns, err := copyColumns(ctx, src, table.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := copyTable(ctx, src, dst, table, columns, tenantID); err != nil {
+			return nil, fmt.Errorf("copy %s: %w", table.Name, err)
+		}
+		want, err := checksum(ctx, src, table, columns, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		got, err := checksum(ctx, dst, table, columns, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			return nil, fmt.Errorf("%w: %s has %d rows (%s), source %d rows (%s)",
+				ErrChecksumMismatch, table.Name, got.Rows, got.Sum, want.Rows, want.Sum)
+		}
+		sums[table.Name] = want
+	}
+	if err := advanceIdentities(ctx, dst); err != nil {
+		return nil, err
+	}
+	if err := dst.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit copy: %w", err)
+	}
+	return sums, nil
+}
+
+// Activate deletes tenantID's rows from the shared tables and routes the
+// tenant to its dedicated database, in one transaction. It fails without
+// changing anything if a row changed since the snapshot Copy took.
+func Activate(ctx context.Context, platform *pgx.Conn, tenantID uuid.UUID, sums map[string]Checksum) error {
+	if err := stableTextOutput(ctx, platform); err != nil {
+		return err
+	}
+	tx, err := platform.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin cutover: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, table := range Tables {
+		columns, err := copyColumns(ctx, tx, table.Name)
+		if err != nil {
+			return err
+		}
+		now, err := checksum(ctx, tx, table, columns, tenantID)
+		if err != nil {
+			return err
+		}
+		if now != sums[table.Name] {
+			return fmt.Errorf("%w: %s", ErrChangedDuringMove, table.Name)
+		}
+	}
+	if err := deleteRows(ctx, tx, tenantID, sums); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE tenant_databases SET status = $2, activated_at = NOW()
+		WHERE tenant_id = $1 AND status = $3`,
+		tenantID, string(StatusActive), string(StatusMoving))
+	if err != nil {
+		return fmt.Errorf("activate tenant database: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotMoving
+	}
+	return tx.Commit(ctx)
+}
+
+// stableTextOutput pins the settings that decide how values print, so
+// checksums of the same rows agree across servers.
+func stableTextOutput(ctx context.Context, conn *pgx.Conn) error {
+	_, err := conn.Exec(ctx, `SET TIME ZONE 'UTC'; SET DateStyle = 'ISO, YMD'; SET IntervalStyle = 'postgres'; SET extra_float_digits = 3`)
+	if err != nil {
+		return fmt.Errorf("set session output settings: %w", err)
+	}
+	return nil
+}
+
+func schemaVersion(ctx context.Context, conn *pgx.Conn) (int64, error) {
+	var version int64
+	var dirty bool
+	if err := conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+		return 0, fmt.Errorf("read migration version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("database is dirty at migration %d", version)
+	}
+	return version, nil
+}
+
+func copyColumns(ctx context.Context, tx pgx.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, fmt.Errorf("list columns of %s: %w", table, err)
+	}
+	columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list columns of %s: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+	return columns, nil
+}
+
+// copyTable streams the rows through COPY, which keeps identity values and
+// checks self-references such as files.thumbnail_file_id only once the
+// whole table is in.
+func copyTable(ctx context.Context, src, dst pgx.Tx, table Table, columns []string, tenantID uuid.UUID) error {
+	list := columnList(columns)
+	reader, writer := io.Pipe()
+	copyOut := make(chan error, 1)
+	go func() {
+		_, err := src.Conn().PgConn().CopyTo(ctx, writer,
+			fmt.Sprintf("COPY (SELECT %s FROM %s WHERE %s) TO STDOUT", list, quote(table.Name), filter(table, tenantID)))
+		writer.CloseWithError(err)
+		copyOut <- err
+	}()
+	_, copyInErr := dst.Conn().PgConn().CopyFrom(ctx, reader,
+		fmt.Sprintf("COPY %s (%s) FROM STDIN", quote(table.Name), list))
+	reader.CloseWithError(copyInErr)
+	return errors.Join(<-copyOut, copyInErr)
+}
+
+func checksum(ctx context.Context, tx pgx.Tx, table Table, columns []string, tenantID uuid.UUID) (Checksum, error) {
+	var sum Checksum
+	err := tx.QueryRow(ctx, fmt.Sprintf(`
+		SELECT count(*), coalesce(md5(string_agg(md5(r::text), '' ORDER BY r::text)), '')
+		FROM (SELECT %s FROM %s WHERE %s) r`,
+		columnList(columns), quote(table.Name), filter(table, tenantID))).Scan(&sum.Rows, &sum.Sum)
+	if err != nil {
+		return Checksum{}, fmt.Errorf("checksum %s: %w", table.Name, err)
+	}
+	return sum, nil
+}
+
+// deleteRows removes tenantID's rows, children first. With want set, each
+// table must lose exactly the rows that were copied.
+func deleteRows(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, want map[string]Checksum) error {
+	for _, table := range slices.Backward(Tables) {
+		tag, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", quote(table.Name), filter(table, tenantID)))
+		if err != nil {
+			return fmt.Errorf("delete from %s: %w", table.Name, err)
+		}
+		if want != nil && tag.RowsAffected() != want[table.Name].Rows {
+			return fmt.Errorf("%w: %s", ErrUnexpectedRowCount, table.Name)
+		}
+	}
+	return nil
+}
+
+// advanceIdentities moves identity sequences past the copied values, which
+// COPY inserts without drawing from the sequence.
+func advanceIdentities(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+		SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND is_identity = 'YES' AND table_name = ANY($1)`,
+		tableNames())
+	if err != nil {
+		return fmt.Errorf("list identity columns: %w", err)
+	}
+	type identity struct{ table, column string }
+	identities, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (identity, error) {
+		var id identity
+		err := row.Scan(&id.table, &id.column)
+		return id, err
+	})
+	if err != nil {
+		return fmt.Errorf("list identity columns: %w", err)
+	}
+	for _, id := range identities {
+		_, err := tx.Exec(ctx, fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence($1, $2), coalesce((SELECT max(%s) FROM %s), 0) + 1, false)",
+			quote(id.column), quote(id.table)), id.table, id.column)
+		if err != nil {
+			return fmt.Errorf("advance %s.%s: %w", id.table, id.column, err)
+		}
+	}
+	return nil
+}
+
+func tableNames() []string {
+	names := make([]string, len(Tables))
+	for i, table := range Tables {
+		names[i] = table.Name
+	}
+	return names
+}
+
+// filter inlines the tenant ID: COPY takes no parameters. A uuid.UUID
+// prints as hex and dashes only.
+func filter(table Table, tenantID uuid.UUID) string {
+	return fmt.Sprintf(table.Filter, "'"+tenantID.String()+"'::uuid")
+}
+
+func columnList(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quote(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func quote(identifier string) string {
+	return pgx.Identifier{identifier}.Sanitize()
+}
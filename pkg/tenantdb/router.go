@@ -0,0 +1,354 @@
+// Package tenantdb routes tenant data to the database it lives in: the
+// shared tables on the platform database, or a database dedicated to the
+// tenant as recorded in tenant_databases. IAM and masterdata always stay
+// on the platform database.
+package tenantdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	apperrors "erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Status string
+
+const (
+	// StatusMoving blocks the tenant's data while it is copied over.
+	StatusMoving Status = "MOVING"
+	StatusActive Status = "ACTIVE"
+)
+
+const (
+	defaultMaxPools = 20
+	// evictGrace keeps a pool that was handed out moments ago from being
+	// closed under a caller that is between two statements.
+	evictGrace = 10 * time.Second
+)
+
+var (
+	ErrTenantMoving = errors.New("tenant data is being moved to a dedicated database")
+	ErrPoolLimit    = errors.New("every tenant database pool is busy")
+	ErrClosed       = errors.New("tenant router is closed")
+)
+
+// Placement is a tenant_databases row.
+type Placement struct {
+	TenantID     uuid.UUID  `gorm:"column:tenant_id;primaryKey"`
+	DatabaseName string     `gorm:"column:database_name"`
+	Status       Status     `gorm:"column:status"`
+	ActivatedAt  *time.Time `gorm:"column:activated_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+}
+
+func (Placement) TableName() string {
+	return "tenant_databases"
+}
+
+// Opener opens a pool on a dedicated tenant database. The router calls
+// close once it no longer hands the pool out.
+type Opener func(databaseName string) (db *gorm.DB, close func() error, err error)
+
+type Options struct {
+	// MaxPools caps the dedicated databases with an open pool. Opening one
+	// more closes the least recently used idle pool, or fails while every
+	// pool is busy.
+	MaxPools int
+	// IdleTimeout closes a pool nothing has asked for in this long. Zero
+	// keeps pools open until they are evicted or the router is closed.
+	IdleTimeout time.Duration
+	// PlacementTTL is how long a tenant's placement is cached. A move
+	// waits this long after blocking the tenant so every process sees it.
+	PlacementTTL time.Duration
+}
+
+type pool struct {
+	ready    chan struct{}
+	db       *gorm.DB
+	close    func() error
+	err      error
+	lastUsed time.Time
+}
+
+type cachedPlacement struct {
+	placement *Placement
+	expires   time.Time
+}
+
+// Router hands out the *gorm.DB holding a tenant's data, opening and
+// caching a pool per dedicated database.
+type Router struct {
+	platform *gorm.DB
+	open     Opener
+	opts     Options
+	logger   *zap.Logger
+
+	mu         sync.Mutex
+	placements map[uuid.UUID]cachedPlacement
+	pools      map[string]*pool
+	closed     bool
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewRouter(platform *gorm.DB, open Opener, opts Options, logger *zap.Logger) *Router {
+	if opts.MaxPools <= 0 {
+		opts.MaxPools = defaultMaxPools
+	}
+	r := &Router{
+		platform:   platform,
+		open:       open,
+		opts:       opts,
+		logger:     logger,
+		placements: make(map[uuid.UUID]cachedPlacement),
+		pools:      make(map[string]*pool),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *Router) Platform() *gorm.DB {
+	return r.platform
+}
+
+// DB returns the database holding the data of the tenant in ctx. Without a
+// tenant, or for a tenant on the shared tables, that is the platform
+// database.
+func (r *Router) DB(ctx context.Context) (*gorm.DB, error) {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return r.platform, nil
+	}
+	placement, err := r.placement(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if placement == nil {
+		return r.platform, nil
+	}
+	if placement.Status != StatusActive {
+		return nil, apperrors.New(apperrors.CodeServiceUnavailable,
+			"tenant data is being moved, please retry shortly", http.StatusServiceUnavailable).WithError(ErrTenantMoving)
+	}
+	return r.pool(ctx, placement.DatabaseName)
+}
+
+// Each calls fn for the platform database and then for every dedicated
+// tenant database, with ctx routed to it, so batch jobs reach rows outside
+// the shared tables.
+func (r *Router) Each(ctx context.Context, fn func(ctx context.Context) error) error {
+	var errs []error
+	if err := fn(WithoutTenant(ctx)); err != nil {
+		errs = append(errs, err)
+	}
+
+	var placements []Placement
+	if err := r.platform.WithContext(ctx).
+		Where("status = ?", StatusActive).
+		Order("database_name").
+		Find(&placements).Error; err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list tenant databases: %w", err))...)
+	}
+	for _, placement := range placements {
+		if err := fn(WithTenant(ctx, placement.TenantID)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant database %s: %w", placement.DatabaseName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops idle eviction and closes every tenant pool. The platform
+// database is left to its owner.
+func (r *Router) Close() error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	<-r.done
+
+	r.mu.Lock()
+	r.closed = true
+	pools := r.pools
+	r.pools = make(map[string]*pool)
+	r.mu.Unlock()
+
+	var errs []error
+	for name, p := range pools {
+		<-p.ready
+		if err := r.closePool(name, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) placement(ctx context.Context, tenantID uuid.UUID) (*Placement, error) {
+	now := time.Now()
+	r.mu.Lock()
+	cached, ok := r.placements[tenantID]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.placement, nil
+	}
+
+	var rows []Placement
+	if err := r.platform.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("look up tenant database: %w", err)
+	}
+	var placement *Placement
+	if len(rows) > 0 {
+		placement = &rows[0]
+	}
+
+	if r.opts.PlacementTTL > 0 {
+		r.mu.Lock()
+		r.placements[tenantID] = cachedPlacement{placement: placement, expires: now.Add(r.opts.PlacementTTL)}
+		r.mu.Unlock()
+	}
+	return placement, nil
+}
+
+func (r *Router) pool(ctx context.Context, name string) (*gorm.DB, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, ErrClosed
+	}
+	p, ok := r.pools[name]
+	var evicted string
+	var evictedPool *pool
+	if !ok {
+		if len(r.pools) >= r.opts.MaxPools {
+			evicted, evictedPool = r.leastRecentlyUsedIdle()
+			if evictedPool == nil {
+				r.mu.Unlock()
+				return nil, apperrors.New(apperrors.CodeServiceUnavailable,
+					"tenant database capacity exhausted, please retry shortly", http.StatusServiceUnavailable).WithError(ErrPoolLimit)
+			}
+			delete(r.pools, evicted)
+		}
+		p = &pool{ready: make(chan struct{})}
+		r.pools[name] = p
+	}
+	p.lastUsed = time.Now()
+	r.mu.Unlock()
+
+	if evictedPool != nil {
+		r.logger.Info("closing least recently used tenant database pool", zap.String("database", evicted))
+		if err := r.closePool(evicted, evictedPool); err != nil {
+			r.logger.Warn("close tenant database pool", zap.String("database", evicted), zap.Error(err))
+		}
+	}
+
+	if !ok {
+		p.db, p.close, p.err = r.open(name)
+		if p.err != nil {
+			r.mu.Lock()
+			if r.pools[name] == p {
+				delete(r.pools, name)
+			}
+			r.mu.Unlock()
+		}
+		close(p.ready)
+	}
+
+	select {
+	case <-p.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if p.err != nil {
+		return nil, fmt.Errorf("open tenant database %s: %w", name, p.err)
+	}
+	return p.db, nil
+}
+
+// leastRecentlyUsedIdle must be called with mu held.
+func (r *Router) leastRecentlyUsedIdle() (string, *pool) {
+	var name string
+	var oldest *pool
+	for candidate, p := range r.pools {
+		if !r.idle(p, evictGrace) {
+			continue
+		}
+		if oldest == nil || p.lastUsed.Before(oldest.lastUsed) {
+			name, oldest = candidate, p
+		}
+	}
+	return name, oldest
+}
+
+// idle reports whether p is open, has not been handed out for at least d
+// and has no connection checked out. It must be called with mu held.
+func (r *Router) idle(p *pool, d time.Duration) bool {
+	select {
+	case <-p.ready:
+	default:
+		return false
+	}
+	if p.err != nil || time.Since(p.lastUsed) < d {
+		return false
+	}
+	sqlDB, err := p.db.DB()
+	return err == nil && sqlDB.Stats().InUse == 0
+}
+
+func (r *Router) closePool(name string, p *pool) error {
+	if p.err != nil {
+		return nil
+	}
+	if err := p.close(); err != nil {
+		return fmt.Errorf("close tenant database %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *Router) run() {
+	defer close(r.done)
+	if r.opts.IdleTimeout <= 0 {
+		<-r.stop
+		return
+	}
+	ticker := time.NewTicker(r.opts.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.closeIdle()
+		}
+	}
+}
+
+func (r *Router) closeIdle() {
+	r.mu.Lock()
+	idle := make(map[string]*pool)
+	for name, p := range r.pools {
+		if r.idle(p, r.opts.IdleTimeout) {
+			idle[name] = p
+			delete(r.pools, name)
+		}
+	}
+	r.mu.Unlock()
+
+	for name, p := range idle {
+		r.logger.Info("closing idle tenant database pool", zap.String("database", name))
+		if err := r.closePool(name, p); err != nil {
+			r.logger.Warn("close tenant database pool", zap.String("database", name), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// InstrumentGorm wraps every statement db runs in a client span. The
+// recorded query text keeps its placeholders, so bound values, which may
+// be personal data, never reach the trace.
+func InstrumentGorm(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", startStatement("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", endStatement),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", startStatement("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", endStatement),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", startStatement("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", endStatement),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", startStatement("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", endStatement),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", startStatement("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", endStatement),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", startStatement("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", endStatement),
+	); err != nil {
+		return fmt.Errorf("register gorm callbacks: %w", err)
+	}
+	return nil
+}
+
+func startStatement(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		name := operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(db.Statement.Table),
+			),
+		)
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endStatement(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
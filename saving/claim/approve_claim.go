@@ -0,0 +1,79 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) ApproveClaim(ctx context.Context, req *ApproveClaimRequest) (*ClaimResponse, error) {
+	var result *ClaimResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claim, err := uc.getOwnedClaim(txCtx, req.ClaimID, req.TenantID, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !claim.CanBeApproved() {
+			return errors.ErrBadRequest(fmt.Sprintf("claim in %s status cannot be approved", claim.Status))
+		}
+
+		if claim.VerifiedBy != nil && *claim.VerifiedBy == req.UserID {
+			return errors.ErrForbidden("claim must be approved by a different user than the one who verified it")
+		}
+
+		if _, err := uc.listCleanDocuments(txCtx, claim.ID); err != nil {
+			return err
+		}
+
+		employer, employee, err := uc.ledgerRepo.GetBalance(txCtx, claim.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("get balance: %w", err)
+		}
+		if employer < 0 || employee < 0 || employer+employee <= 0 {
+			return errors.ErrBadRequest("participant has no balance to pay out")
+		}
+
+		payees, err := uc.resolvePayees(txCtx, claim)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		from := claim.Status
+		claim.Status = entity.ClaimStatusApproved
+		claim.ApprovedBy = &req.UserID
+		claim.ApprovedAt = &now
+		claim.EmployerAmount = employer
+		claim.EmployeeAmount = employee
+
+		if err := uc.updateStatus(txCtx, claim, from, req.UserID, nil, now); err != nil {
+			return err
+		}
+
+		for i, amount := range splitAmount(claim.TotalAmount(), len(payees)) {
+			instruction := payees[i].instruction(claim, amount)
+			instruction.CreatedAt = now
+			instruction.UpdatedAt = now
+			if err := uc.instructionRepo.Create(txCtx, instruction); err != nil {
+				return fmt.Errorf("create payment instruction: %w", err)
+			}
+		}
+
+		resp, err := uc.buildClaimResponse(txCtx, claim)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
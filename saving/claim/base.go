@@ -0,0 +1,58 @@
+package claim
+
+import (
+	"erp-service/config"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg               *config.Config
+	logger            *zap.Logger
+	txManager         TransactionManager
+	claimRepo         ClaimRepository
+	documentRepo      ClaimDocumentRepository
+	statusHistoryRepo ClaimStatusHistoryRepository
+	instructionRepo   PaymentInstructionRepository
+	participantRepo   ParticipantRepository
+	bankAccountRepo   BankAccountRepository
+	beneficiaryRepo   BeneficiaryRepository
+	familyMemberRepo  FamilyMemberRepository
+	ledgerRepo        LedgerRepository
+	fileRepo          FileRepository
+	fileStorage       FileStorageAdapter
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	txManager TransactionManager,
+	claimRepo ClaimRepository,
+	documentRepo ClaimDocumentRepository,
+	statusHistoryRepo ClaimStatusHistoryRepository,
+	instructionRepo PaymentInstructionRepository,
+	participantRepo ParticipantRepository,
+	bankAccountRepo BankAccountRepository,
+	beneficiaryRepo BeneficiaryRepository,
+	familyMemberRepo FamilyMemberRepository,
+	ledgerRepo LedgerRepository,
+	fileRepo FileRepository,
+	fileStorage FileStorageAdapter,
+) Usecase {
+	return &usecase{
+		cfg:               cfg,
+		logger:            logger,
+		txManager:         txManager,
+		claimRepo:         claimRepo,
+		documentRepo:      documentRepo,
+		statusHistoryRepo: statusHistoryRepo,
+		instructionRepo:   instructionRepo,
+		participantRepo:   participantRepo,
+		bankAccountRepo:   bankAccountRepo,
+		beneficiaryRepo:   beneficiaryRepo,
+		familyMemberRepo:  familyMemberRepo,
+		ledgerRepo:        ledgerRepo,
+		fileRepo:          fileRepo,
+		fileStorage:       fileStorage,
+	}
+}
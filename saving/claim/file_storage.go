@@ -0,0 +1,15 @@
+package claim
+
+import (
+	"context"
+	"io"
+
+	"erp-service/pkg/envelope"
+
+	"github.com/google/uuid"
+)
+
+type FileStorageAdapter interface {
+	UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error)
+	DeleteFile(ctx context.Context, bucket, objectKey string) error
+}
@@ -0,0 +1,12 @@
+package claim
+
+import "context"
+
+func (uc *usecase) GetClaim(ctx context.Context, req *GetClaimRequest) (*ClaimResponse, error) {
+	claim, err := uc.getOwnedClaim(ctx, req.ClaimID, req.TenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildClaimResponse(ctx, claim)
+}
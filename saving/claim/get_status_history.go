@@ -0,0 +1,25 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+)
+
+func (uc *usecase) GetStatusHistory(ctx context.Context, req *GetClaimRequest) ([]StatusHistoryResponse, error) {
+	claim, err := uc.getOwnedClaim(ctx, req.ClaimID, req.TenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := uc.statusHistoryRepo.ListByClaimID(ctx, claim.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list status history: %w", err)
+	}
+
+	results := make([]StatusHistoryResponse, 0, len(histories))
+	for _, h := range histories {
+		results = append(results, mapStatusHistoryToResponse(h))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,221 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const claimBucket = "claims"
+
+func validateParticipantOwnership(participant *entity.Participant, tenantID, productID uuid.UUID) error {
+	if participant.TenantID != tenantID {
+		return errors.ErrForbidden("participant does not belong to this tenant")
+	}
+	if participant.ProductID != productID {
+		return errors.ErrForbidden("participant does not belong to this product")
+	}
+	return nil
+}
+
+func validateClaimOwnership(claim *entity.Claim, tenantID, productID uuid.UUID) error {
+	if claim.TenantID != tenantID {
+		return errors.ErrForbidden("claim does not belong to this tenant")
+	}
+	if claim.ProductID != productID {
+		return errors.ErrForbidden("claim does not belong to this product")
+	}
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func sanitizeFilename(filename string) string {
+	safe := filepath.Base(filename)
+	if safe == "." || safe == ".." || strings.ContainsAny(safe, "/\\") {
+		return "upload"
+	}
+	return safe
+}
+
+func generateObjectKey(claim *entity.Claim, documentType, filename string) string {
+	return fmt.Sprintf("claims/%s/%s/%s/%s/%s",
+		claim.TenantID.String(), claim.ProductID.String(), claim.ID.String(), documentType, sanitizeFilename(filename))
+}
+
+func missingDocuments(claimType entity.ClaimType, documents []*entity.ClaimDocument) []string {
+	uploaded := make(map[string]bool, len(documents))
+	for _, d := range documents {
+		uploaded[d.DocumentType] = true
+	}
+
+	var missing []string
+	for _, required := range claimType.RequiredDocuments() {
+		if !uploaded[required] {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+// listCleanDocuments loads the claim's documents and rejects the claim unless
+// every one has passed malware scanning, the same rule participant submission
+// applies.
+func (uc *usecase) listCleanDocuments(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimDocument, error) {
+	documents, err := uc.documentRepo.ListByClaimID(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("list claim documents: %w", err)
+	}
+
+	for _, d := range documents {
+		file, err := uc.fileRepo.GetByID(ctx, d.FileID)
+		if err != nil {
+			return nil, fmt.Errorf("get %s file: %w", d.DocumentType, err)
+		}
+		if file.IsInfected() || file.IsScanFailed() {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("%s document %s failed malware scanning; replace it before continuing", d.DocumentType, d.FileID))
+		}
+		if !file.IsClean() {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("%s document %s is still being scanned; try again shortly", d.DocumentType, d.FileID))
+		}
+	}
+	return documents, nil
+}
+
+func (uc *usecase) getOwnedClaim(ctx context.Context, claimID, tenantID, productID uuid.UUID) (*entity.Claim, error) {
+	claim, err := uc.claimRepo.GetByID(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("get claim: %w", err)
+	}
+	if err := validateClaimOwnership(claim, tenantID, productID); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// updateStatus persists the claim in its new status and records the change in
+// the claim status history.
+func (uc *usecase) updateStatus(ctx context.Context, claim *entity.Claim, from entity.ClaimStatus, changedBy uuid.UUID, reason *string, now time.Time) error {
+	if err := uc.claimRepo.Update(ctx, claim); err != nil {
+		return fmt.Errorf("update claim: %w", err)
+	}
+
+	fromStatus := string(from)
+	history := &entity.ClaimStatusHistory{
+		ClaimID:    claim.ID,
+		FromStatus: &fromStatus,
+		ToStatus:   string(claim.Status),
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		ChangedAt:  now,
+		CreatedAt:  now,
+	}
+	if err := uc.statusHistoryRepo.Create(ctx, history); err != nil {
+		return fmt.Errorf("create status history: %w", err)
+	}
+	return nil
+}
+
+func (uc *usecase) buildClaimResponse(ctx context.Context, claim *entity.Claim) (*ClaimResponse, error) {
+	documents, err := uc.documentRepo.ListByClaimID(ctx, claim.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list claim documents: %w", err)
+	}
+
+	instructions, err := uc.instructionRepo.ListByClaimID(ctx, claim.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list payment instructions: %w", err)
+	}
+
+	resp := mapClaimToResponse(claim)
+	resp.RequiredDocuments = claim.ClaimType.RequiredDocuments()
+	resp.MissingDocuments = missingDocuments(claim.ClaimType, documents)
+
+	resp.Documents = make([]ClaimDocumentResponse, 0, len(documents))
+	for _, d := range documents {
+		resp.Documents = append(resp.Documents, mapDocumentToResponse(d))
+	}
+
+	resp.PaymentInstructions = make([]PaymentInstructionResponse, 0, len(instructions))
+	for _, pi := range instructions {
+		resp.PaymentInstructions = append(resp.PaymentInstructions, mapInstructionToResponse(pi))
+	}
+
+	return &resp, nil
+}
+
+func mapClaimToResponse(c *entity.Claim) ClaimResponse {
+	return ClaimResponse{
+		ID:               c.ID,
+		ParticipantID:    c.ParticipantID,
+		ClaimType:        string(c.ClaimType),
+		Status:           string(c.Status),
+		EventDate:        c.EventDate,
+		Notes:            c.Notes,
+		EmployerAmount:   c.EmployerAmount,
+		EmployeeAmount:   c.EmployeeAmount,
+		TotalAmount:      c.TotalAmount(),
+		OpenedBy:         c.OpenedBy,
+		VerifiedBy:       c.VerifiedBy,
+		VerifiedAt:       c.VerifiedAt,
+		ApprovedBy:       c.ApprovedBy,
+		ApprovedAt:       c.ApprovedAt,
+		RejectedBy:       c.RejectedBy,
+		RejectedAt:       c.RejectedAt,
+		RejectionReason:  c.RejectionReason,
+		PaidBy:           c.PaidBy,
+		PaidAt:           c.PaidAt,
+		PaymentReference: c.PaymentReference,
+		Version:          c.Version,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+func mapDocumentToResponse(d *entity.ClaimDocument) ClaimDocumentResponse {
+	return ClaimDocumentResponse{
+		ID:           d.ID,
+		DocumentType: d.DocumentType,
+		FileID:       d.FileID,
+		UploadedBy:   d.UploadedBy,
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+func mapInstructionToResponse(pi *entity.PaymentInstruction) PaymentInstructionResponse {
+	return PaymentInstructionResponse{
+		ID:               pi.ID,
+		PayeeType:        string(pi.PayeeType),
+		PayeeID:          pi.PayeeID,
+		PayeeName:        pi.PayeeName,
+		BankCode:         pi.BankCode,
+		AccountNumber:    pi.AccountNumber,
+		Amount:           pi.Amount,
+		CurrencyCode:     pi.CurrencyCode,
+		Status:           string(pi.Status),
+		PaymentReference: pi.PaymentReference,
+		PaidAt:           pi.PaidAt,
+	}
+}
+
+func mapStatusHistoryToResponse(h *entity.ClaimStatusHistory) StatusHistoryResponse {
+	return StatusHistoryResponse{
+		ID:         h.ID,
+		FromStatus: h.FromStatus,
+		ToStatus:   h.ToStatus,
+		ChangedBy:  h.ChangedBy,
+		Reason:     h.Reason,
+		ChangedAt:  h.ChangedAt,
+		CreatedAt:  h.CreatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+)
+
+func (uc *usecase) ListClaims(ctx context.Context, req *ListClaimsRequest) (*ListClaimsResponse, error) {
+	claims, total, err := uc.claimRepo.List(ctx, &ClaimFilter{
+		TenantID:      req.TenantID,
+		ProductID:     req.ProductID,
+		ParticipantID: req.ParticipantID,
+		Status:        req.Status,
+		ClaimType:     req.ClaimType,
+		Page:          req.Page,
+		PerPage:       req.PerPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list claims: %w", err)
+	}
+
+	items := make([]ClaimResponse, 0, len(claims))
+	for _, c := range claims {
+		items = append(items, mapClaimToResponse(c))
+	}
+
+	totalPages := 0
+	if req.PerPage > 0 {
+		totalPages = int((total + int64(req.PerPage) - 1) / int64(req.PerPage))
+	}
+
+	return &ListClaimsResponse{
+		Claims: items,
+		Pagination: PaginationMeta{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
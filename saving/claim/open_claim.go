@@ -0,0 +1,89 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) OpenClaim(ctx context.Context, req *OpenClaimRequest) (*ClaimResponse, error) {
+	claimType := entity.ClaimType(req.ClaimType)
+	if !claimType.IsValid() {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("unknown claim type %s", req.ClaimType))
+	}
+
+	eventDate := truncateToDate(req.EventDate)
+	if eventDate.After(truncateToDate(time.Now())) {
+		return nil, errors.ErrBadRequest("event date cannot be in the future")
+	}
+
+	var result *ClaimResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		participant, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("get participant: %w", err)
+		}
+
+		if err := validateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+			return err
+		}
+
+		if !claimType.AcceptsParticipantStatus(participant.Status) {
+			return errors.ErrBadRequest(fmt.Sprintf("%s claim cannot be opened for participant in %s status", claimType, participant.Status))
+		}
+
+		inProgress, err := uc.claimRepo.HasInProgress(txCtx, participant.ID)
+		if err != nil {
+			return fmt.Errorf("check claims in progress: %w", err)
+		}
+		if inProgress {
+			return errors.ErrConflict("participant already has a claim in progress")
+		}
+
+		now := time.Now()
+		claim := &entity.Claim{
+			TenantID:      req.TenantID,
+			ProductID:     req.ProductID,
+			ParticipantID: participant.ID,
+			ClaimType:     claimType,
+			Status:        entity.ClaimStatusOpen,
+			EventDate:     eventDate,
+			Notes:         req.Notes,
+			OpenedBy:      req.UserID,
+			Version:       1,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		if err := uc.claimRepo.Create(txCtx, claim); err != nil {
+			return fmt.Errorf("create claim: %w", err)
+		}
+
+		history := &entity.ClaimStatusHistory{
+			ClaimID:   claim.ID,
+			ToStatus:  string(entity.ClaimStatusOpen),
+			ChangedBy: req.UserID,
+			ChangedAt: now,
+			CreatedAt: now,
+		}
+		if err := uc.statusHistoryRepo.Create(txCtx, history); err != nil {
+			return fmt.Errorf("create status history: %w", err)
+		}
+
+		resp, err := uc.buildClaimResponse(txCtx, claim)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
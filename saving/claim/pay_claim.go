@@ -0,0 +1,98 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) PayClaim(ctx context.Context, req *PayClaimRequest) (*ClaimResponse, error) {
+	now := time.Now()
+	paidAt := now
+	if req.PaidAt != nil {
+		paidAt = *req.PaidAt
+		if paidAt.After(now) {
+			return nil, errors.ErrBadRequest("paid at cannot be in the future")
+		}
+	}
+
+	var result *ClaimResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claim, err := uc.getOwnedClaim(txCtx, req.ClaimID, req.TenantID, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !claim.CanBePaid() {
+			return errors.ErrBadRequest(fmt.Sprintf("claim in %s status cannot be paid", claim.Status))
+		}
+
+		if claim.ApprovedAt != nil && paidAt.Before(*claim.ApprovedAt) {
+			return errors.ErrBadRequest("paid at cannot be before the claim was approved")
+		}
+
+		if _, err := uc.listCleanDocuments(txCtx, claim.ID); err != nil {
+			return err
+		}
+
+		// Reversals posted after approval may have reduced the balance below
+		// the approved amount; paying would then drive the ledger negative.
+		employer, employee, err := uc.ledgerRepo.GetBalance(txCtx, claim.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("get balance: %w", err)
+		}
+		if employer < claim.EmployerAmount || employee < claim.EmployeeAmount {
+			return errors.ErrConflict("participant balance is below the approved claim amount")
+		}
+
+		postingDate := truncateToDate(paidAt)
+		reference := req.PaymentReference
+		reason := fmt.Sprintf("%s claim %s", claim.ClaimType, claim.ID)
+		payout := &entity.ContributionLedgerEntry{
+			TenantID:       claim.TenantID,
+			ProductID:      claim.ProductID,
+			ParticipantID:  claim.ParticipantID,
+			EntryType:      entity.ContributionEntryTypePayout,
+			Period:         time.Date(postingDate.Year(), postingDate.Month(), 1, 0, 0, 0, 0, time.UTC),
+			PostingDate:    postingDate,
+			EmployerAmount: -claim.EmployerAmount,
+			EmployeeAmount: -claim.EmployeeAmount,
+			Reference:      &reference,
+			Reason:         &reason,
+			CreatedBy:      req.UserID,
+		}
+		if err := uc.ledgerRepo.Append(txCtx, payout); err != nil {
+			return fmt.Errorf("append payout entry: %w", err)
+		}
+
+		if err := uc.instructionRepo.MarkPaidByClaimID(txCtx, claim.ID, reference, paidAt); err != nil {
+			return fmt.Errorf("mark payment instructions paid: %w", err)
+		}
+
+		from := claim.Status
+		claim.Status = entity.ClaimStatusPaid
+		claim.PaidBy = &req.UserID
+		claim.PaidAt = &paidAt
+		claim.PaymentReference = &reference
+
+		if err := uc.updateStatus(txCtx, claim, from, req.UserID, nil, now); err != nil {
+			return err
+		}
+
+		resp, err := uc.buildClaimResponse(txCtx, claim)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
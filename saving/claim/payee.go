@@ -0,0 +1,133 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type payee struct {
+	payeeType     entity.PayeeType
+	id            uuid.UUID
+	name          string
+	bankCode      *string
+	accountNumber string
+	currencyCode  string
+}
+
+func (p payee) instruction(claim *entity.Claim, amount int64) *entity.PaymentInstruction {
+	return &entity.PaymentInstruction{
+		ClaimID:       claim.ID,
+		TenantID:      claim.TenantID,
+		ProductID:     claim.ProductID,
+		PayeeType:     p.payeeType,
+		PayeeID:       p.id,
+		PayeeName:     p.name,
+		BankCode:      p.bankCode,
+		AccountNumber: p.accountNumber,
+		Amount:        amount,
+		CurrencyCode:  p.currencyCode,
+		Status:        entity.PaymentInstructionStatusPending,
+	}
+}
+
+// resolvePayees returns who a claim is paid to: the participant's primary bank
+// account, or for death claims every beneficiary, each of whom must have a
+// bank code and account number.
+func (uc *usecase) resolvePayees(ctx context.Context, claim *entity.Claim) ([]payee, error) {
+	if claim.ClaimType == entity.ClaimTypeDeath {
+		return uc.resolveBeneficiaryPayees(ctx, claim.ParticipantID)
+	}
+
+	accounts, err := uc.bankAccountRepo.ListByParticipantID(ctx, claim.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("list bank accounts: %w", err)
+	}
+
+	for _, a := range accounts {
+		if !a.IsPrimary {
+			continue
+		}
+		bankCode := a.BankCode
+		return []payee{{
+			payeeType:     entity.PayeeTypeParticipant,
+			id:            a.ID,
+			name:          a.AccountHolderName,
+			bankCode:      &bankCode,
+			accountNumber: a.AccountNumber,
+			currencyCode:  a.CurrencyCode,
+		}}, nil
+	}
+
+	return nil, errors.ErrBadRequest("participant has no primary bank account to pay the claim to")
+}
+
+// resolveBeneficiaryPayees refuses to pay anyone unless every beneficiary
+// can be paid; skipping one would hand their share to the others.
+func (uc *usecase) resolveBeneficiaryPayees(ctx context.Context, participantID uuid.UUID) ([]payee, error) {
+	beneficiaries, err := uc.beneficiaryRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("list beneficiaries: %w", err)
+	}
+	if len(beneficiaries) == 0 {
+		return nil, errors.ErrBadRequest("participant has no beneficiary to pay the claim to")
+	}
+
+	members, err := uc.familyMemberRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("list family members: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(members))
+	for _, m := range members {
+		names[m.ID] = m.FullName
+	}
+
+	payees := make([]payee, 0, len(beneficiaries))
+	for _, b := range beneficiaries {
+		name, ok := names[b.FamilyMemberID]
+		if !ok {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("beneficiary %s has no matching family member", b.ID))
+		}
+		accountNumber := trimmed(b.AccountNumber)
+		bankCode := trimmed(b.BankCode)
+		if accountNumber == "" || bankCode == "" {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("beneficiary %s (%s) is missing a bank code or account number to pay the claim to", name, b.ID))
+		}
+		payees = append(payees, payee{
+			payeeType:     entity.PayeeTypeBeneficiary,
+			id:            b.ID,
+			name:          name,
+			bankCode:      &bankCode,
+			accountNumber: accountNumber,
+			currencyCode:  "IDR",
+		})
+	}
+	return payees, nil
+}
+
+func trimmed(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+// splitAmount divides total into n equal parts in minor units; the remainder
+// goes to the first parts so the parts always add up to total.
+func splitAmount(total int64, n int) []int64 {
+	parts := make([]int64, n)
+	share := total / int64(n)
+	remainder := total % int64(n)
+	for i := range parts {
+		parts[i] = share
+		if int64(i) < remainder {
+			parts[i]++
+		}
+	}
+	return parts
+}
@@ -0,0 +1,49 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) RejectClaim(ctx context.Context, req *RejectClaimRequest) (*ClaimResponse, error) {
+	var result *ClaimResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claim, err := uc.getOwnedClaim(txCtx, req.ClaimID, req.TenantID, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !claim.CanBeRejected() {
+			return errors.ErrBadRequest(fmt.Sprintf("claim in %s status cannot be rejected", claim.Status))
+		}
+
+		now := time.Now()
+		from := claim.Status
+		reason := req.Reason
+		claim.Status = entity.ClaimStatusRejected
+		claim.RejectedBy = &req.UserID
+		claim.RejectedAt = &now
+		claim.RejectionReason = &reason
+
+		if err := uc.updateStatus(txCtx, claim, from, req.UserID, &reason, now); err != nil {
+			return err
+		}
+
+		resp, err := uc.buildClaimResponse(txCtx, claim)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package claim
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type ClaimFilter struct {
+	TenantID      uuid.UUID
+	ProductID     uuid.UUID
+	ParticipantID *uuid.UUID
+	Status        *string
+	ClaimType     *string
+	Page          int
+	PerPage       int
+}
+
+type ClaimRepository interface {
+	Create(ctx context.Context, claim *entity.Claim) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Claim, error)
+	Update(ctx context.Context, claim *entity.Claim) error
+	List(ctx context.Context, filter *ClaimFilter) ([]*entity.Claim, int64, error)
+	HasInProgress(ctx context.Context, participantID uuid.UUID) (bool, error)
+}
+
+type ClaimDocumentRepository interface {
+	Create(ctx context.Context, document *entity.ClaimDocument) error
+	ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimDocument, error)
+}
+
+type ClaimStatusHistoryRepository interface {
+	Create(ctx context.Context, history *entity.ClaimStatusHistory) error
+	ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimStatusHistory, error)
+}
+
+type PaymentInstructionRepository interface {
+	Create(ctx context.Context, instruction *entity.PaymentInstruction) error
+	ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.PaymentInstruction, error)
+	MarkPaidByClaimID(ctx context.Context, claimID uuid.UUID, reference string, paidAt time.Time) error
+}
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+}
+
+type BankAccountRepository interface {
+	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBankAccount, error)
+}
+
+type BeneficiaryRepository interface {
+	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBeneficiary, error)
+}
+
+type FamilyMemberRepository interface {
+	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantFamilyMember, error)
+}
+
+// LedgerRepository is the slice of the contribution ledger a claim needs: the
+// balance to pay out and the PAYOUT posting once the claim is paid.
+type LedgerRepository interface {
+	GetBalance(ctx context.Context, participantID uuid.UUID) (employer int64, employee int64, err error)
+	Append(ctx context.Context, entry *entity.ContributionLedgerEntry) error
+}
+
+type FileRepository interface {
+	Create(ctx context.Context, file *entity.File) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error)
+}
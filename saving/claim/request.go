@@ -0,0 +1,77 @@
+package claim
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OpenClaimRequest struct {
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+	UserID        uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"participant_id" validate:"required"`
+	ClaimType     string    `json:"claim_type" validate:"required,oneof=NORMAL_RETIREMENT EARLY_RETIREMENT RESIGNATION DEATH DISABILITY"`
+	EventDate     time.Time `json:"event_date" validate:"required"`
+	Notes         *string   `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+type UploadClaimDocumentRequest struct {
+	TenantID     uuid.UUID `json:"-"`
+	ProductID    uuid.UUID `json:"-"`
+	ClaimID      uuid.UUID `json:"-"`
+	UploadedBy   uuid.UUID `json:"-"`
+	DocumentType string    `json:"-"`
+	FileName     string    `json:"-"`
+	ContentType  string    `json:"-"`
+	Reader       io.Reader `json:"-"`
+	Size         int64     `json:"-"`
+}
+
+type GetClaimRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	ClaimID   uuid.UUID `json:"-"`
+}
+
+type ListClaimsRequest struct {
+	TenantID      uuid.UUID  `json:"-"`
+	ProductID     uuid.UUID  `json:"-"`
+	ParticipantID *uuid.UUID `json:"participant_id,omitempty"`
+	Status        *string    `json:"status,omitempty" validate:"omitempty,oneof=OPEN VERIFIED APPROVED PAID REJECTED"`
+	ClaimType     *string    `json:"claim_type,omitempty" validate:"omitempty,oneof=NORMAL_RETIREMENT EARLY_RETIREMENT RESIGNATION DEATH DISABILITY"`
+	Page          int        `json:"page" validate:"min=1"`
+	PerPage       int        `json:"per_page" validate:"min=1,max=100"`
+}
+
+type VerifyClaimRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	ClaimID   uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+}
+
+type ApproveClaimRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	ClaimID   uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+}
+
+type RejectClaimRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	ClaimID   uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+	Reason    string    `json:"reason" validate:"required,min=10,max=500"`
+}
+
+type PayClaimRequest struct {
+	TenantID         uuid.UUID  `json:"-"`
+	ProductID        uuid.UUID  `json:"-"`
+	ClaimID          uuid.UUID  `json:"-"`
+	UserID           uuid.UUID  `json:"-"`
+	PaymentReference string     `json:"payment_reference" validate:"required,max=100"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+}
@@ -0,0 +1,82 @@
+package claim
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ClaimDocumentResponse struct {
+	ID           uuid.UUID `json:"id"`
+	DocumentType string    `json:"document_type"`
+	FileID       uuid.UUID `json:"file_id"`
+	UploadedBy   uuid.UUID `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PaymentInstructionResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	PayeeType        string     `json:"payee_type"`
+	PayeeID          uuid.UUID  `json:"payee_id"`
+	PayeeName        string     `json:"payee_name"`
+	BankCode         *string    `json:"bank_code,omitempty"`
+	AccountNumber    string     `json:"account_number" mask:"bank_account"`
+	Amount           int64      `json:"amount"`
+	CurrencyCode     string     `json:"currency_code"`
+	Status           string     `json:"status"`
+	PaymentReference *string    `json:"payment_reference,omitempty"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+}
+
+type ClaimResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ParticipantID    uuid.UUID  `json:"participant_id"`
+	ClaimType        string     `json:"claim_type"`
+	Status           string     `json:"status"`
+	EventDate        time.Time  `json:"event_date"`
+	Notes            *string    `json:"notes,omitempty"`
+	EmployerAmount   int64      `json:"employer_amount"`
+	EmployeeAmount   int64      `json:"employee_amount"`
+	TotalAmount      int64      `json:"total_amount"`
+	OpenedBy         uuid.UUID  `json:"opened_by"`
+	VerifiedBy       *uuid.UUID `json:"verified_by,omitempty"`
+	VerifiedAt       *time.Time `json:"verified_at,omitempty"`
+	ApprovedBy       *uuid.UUID `json:"approved_by,omitempty"`
+	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
+	RejectedBy       *uuid.UUID `json:"rejected_by,omitempty"`
+	RejectedAt       *time.Time `json:"rejected_at,omitempty"`
+	RejectionReason  *string    `json:"rejection_reason,omitempty"`
+	PaidBy           *uuid.UUID `json:"paid_by,omitempty"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+	PaymentReference *string    `json:"payment_reference,omitempty"`
+	Version          int        `json:"version"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	RequiredDocuments   []string                     `json:"required_documents,omitempty"`
+	MissingDocuments    []string                     `json:"missing_documents,omitempty"`
+	Documents           []ClaimDocumentResponse      `json:"documents,omitempty"`
+	PaymentInstructions []PaymentInstructionResponse `json:"payment_instructions,omitempty"`
+}
+
+type ListClaimsResponse struct {
+	Claims     []ClaimResponse `json:"claims"`
+	Pagination PaginationMeta  `json:"pagination"`
+}
+
+type StatusHistoryResponse struct {
+	ID         uuid.UUID `json:"id"`
+	FromStatus *string   `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	Reason     *string   `json:"reason,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PaginationMeta struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
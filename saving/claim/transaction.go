@@ -0,0 +1,7 @@
+package claim
+
+import "context"
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
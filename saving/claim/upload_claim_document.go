@@ -0,0 +1,87 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+func (uc *usecase) UploadClaimDocument(ctx context.Context, req *UploadClaimDocumentRequest) (*ClaimDocumentResponse, error) {
+	claim, err := uc.getOwnedClaim(ctx, req.ClaimID, req.TenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claim.AcceptsDocuments() {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("claim in %s status cannot receive documents", claim.Status))
+	}
+
+	if !claim.ClaimType.IsRequiredDocument(req.DocumentType) {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("%s is not a document of a %s claim", req.DocumentType, claim.ClaimType))
+	}
+
+	objectKey := generateObjectKey(claim, req.DocumentType, req.FileName)
+	storageKey := objectKey
+	env, err := uc.fileStorage.UploadEncryptedFile(ctx, req.TenantID, claimBucket, objectKey, req.Reader, req.Size)
+	if err != nil {
+		return nil, fmt.Errorf("upload to storage: %w", err)
+	}
+
+	var result *ClaimDocumentResponse
+
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+
+		// Claim documents are attached on upload, so the file is created
+		// permanent instead of going through the expiring upload stage.
+		file := &entity.File{
+			TenantID:      req.TenantID,
+			ProductID:     req.ProductID,
+			ParticipantID: &claim.ParticipantID,
+			UploadedBy:    req.UploadedBy,
+			Bucket:        claimBucket,
+			StorageKey:    storageKey,
+			OriginalName:  req.FileName,
+			ContentType:   req.ContentType,
+			SizeBytes:     req.Size,
+			ScanStatus:    entity.FileScanStatusPending,
+			DocumentType:  &req.DocumentType,
+		}
+		file.SetEnvelope(env)
+		if err := uc.fileRepo.Create(txCtx, file); err != nil {
+			return fmt.Errorf("persist file metadata: %w", err)
+		}
+
+		document := &entity.ClaimDocument{
+			ClaimID:      claim.ID,
+			DocumentType: req.DocumentType,
+			FileID:       file.ID,
+			UploadedBy:   req.UploadedBy,
+			CreatedAt:    now,
+		}
+		if err := uc.documentRepo.Create(txCtx, document); err != nil {
+			return fmt.Errorf("create claim document: %w", err)
+		}
+
+		resp := mapDocumentToResponse(document)
+		result = &resp
+		return nil
+	})
+	if err != nil {
+		if delErr := uc.fileStorage.DeleteFile(ctx, claimBucket, storageKey); delErr != nil {
+			uc.logger.Warn("failed to clean up orphaned storage object after DB insert failure",
+				zap.String("bucket", claimBucket),
+				zap.String("storage_key", storageKey),
+				zap.Error(delErr),
+			)
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
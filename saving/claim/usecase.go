@@ -0,0 +1,15 @@
+package claim
+
+import "context"
+
+type Usecase interface {
+	OpenClaim(ctx context.Context, req *OpenClaimRequest) (*ClaimResponse, error)
+	UploadClaimDocument(ctx context.Context, req *UploadClaimDocumentRequest) (*ClaimDocumentResponse, error)
+	GetClaim(ctx context.Context, req *GetClaimRequest) (*ClaimResponse, error)
+	ListClaims(ctx context.Context, req *ListClaimsRequest) (*ListClaimsResponse, error)
+	VerifyClaim(ctx context.Context, req *VerifyClaimRequest) (*ClaimResponse, error)
+	ApproveClaim(ctx context.Context, req *ApproveClaimRequest) (*ClaimResponse, error)
+	RejectClaim(ctx context.Context, req *RejectClaimRequest) (*ClaimResponse, error)
+	PayClaim(ctx context.Context, req *PayClaimRequest) (*ClaimResponse, error)
+	GetStatusHistory(ctx context.Context, req *GetClaimRequest) ([]StatusHistoryResponse, error)
+}
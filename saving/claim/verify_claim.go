@@ -0,0 +1,56 @@
+package claim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) VerifyClaim(ctx context.Context, req *VerifyClaimRequest) (*ClaimResponse, error) {
+	var result *ClaimResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claim, err := uc.getOwnedClaim(txCtx, req.ClaimID, req.TenantID, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if !claim.CanBeVerified() {
+			return errors.ErrBadRequest(fmt.Sprintf("claim in %s status cannot be verified", claim.Status))
+		}
+
+		documents, err := uc.listCleanDocuments(txCtx, claim.ID)
+		if err != nil {
+			return err
+		}
+		if missing := missingDocuments(claim.ClaimType, documents); len(missing) > 0 {
+			return errors.ErrBadRequest(fmt.Sprintf("claim is missing required documents: %s", strings.Join(missing, ", ")))
+		}
+
+		now := time.Now()
+		from := claim.Status
+		claim.Status = entity.ClaimStatusVerified
+		claim.VerifiedBy = &req.UserID
+		claim.VerifiedAt = &now
+
+		if err := uc.updateStatus(txCtx, claim, from, req.UserID, nil, now); err != nil {
+			return err
+		}
+
+		resp, err := uc.buildClaimResponse(txCtx, claim)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,34 @@
+package contribution
+
+import (
+	"erp-service/config"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg             *config.Config
+	logger          *zap.Logger
+	txManager       TransactionManager
+	participantRepo ParticipantRepository
+	ledgerRepo      LedgerRepository
+	batchRepo       ImportBatchRepository
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	txManager TransactionManager,
+	participantRepo ParticipantRepository,
+	ledgerRepo LedgerRepository,
+	batchRepo ImportBatchRepository,
+) Usecase {
+	return &usecase{
+		cfg:             cfg,
+		logger:          logger,
+		txManager:       txManager,
+		participantRepo: participantRepo,
+		ledgerRepo:      ledgerRepo,
+		batchRepo:       batchRepo,
+	}
+}
@@ -0,0 +1,22 @@
+package contribution
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) GetImportBatch(ctx context.Context, req *GetImportBatchRequest) (*ImportBatchResponse, error) {
+	batch, err := uc.batchRepo.GetByID(ctx, req.BatchID)
+	if err != nil {
+		return nil, fmt.Errorf("get import batch: %w", err)
+	}
+
+	if batch.TenantID != req.TenantID || batch.ProductID != req.ProductID {
+		return nil, errors.ErrForbidden("import batch does not belong to this tenant/product")
+	}
+
+	resp := mapBatchToResponse(batch)
+	return &resp, nil
+}
@@ -0,0 +1,75 @@
+package contribution
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/pkg/errors"
+)
+
+// GetStatement lists a participant's ledger movements between two posting
+// dates. Lines are ordered by posting date, so running balances are
+// recomputed here rather than taken from the ledger, whose stored balances
+// follow entry order and may differ for back-dated postings.
+func (uc *usecase) GetStatement(ctx context.Context, req *GetStatementRequest) (*StatementResponse, error) {
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		return nil, errors.ErrBadRequest("to must not be before from")
+	}
+
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+
+	if err := validateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	var openingEmployer, openingEmployee int64
+	if req.From != nil {
+		openingEmployer, openingEmployee, err = uc.ledgerRepo.SumBefore(ctx, participant.ID, truncateToDate(*req.From))
+		if err != nil {
+			return nil, fmt.Errorf("sum opening balance: %w", err)
+		}
+	}
+
+	filter := &StatementFilter{ParticipantID: participant.ID}
+	if req.From != nil {
+		from := truncateToDate(*req.From)
+		filter.From = &from
+	}
+	if req.To != nil {
+		to := truncateToDate(*req.To)
+		filter.To = &to
+	}
+
+	entries, err := uc.ledgerRepo.ListForStatement(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list ledger entries: %w", err)
+	}
+
+	employer, employee := openingEmployer, openingEmployee
+	lines := make([]LedgerEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		employer += entry.EmployerAmount
+		employee += entry.EmployeeAmount
+
+		line := mapEntryToResponse(entry)
+		line.EmployerBalance = employer
+		line.EmployeeBalance = employee
+		line.TotalBalance = employer + employee
+		lines = append(lines, line)
+	}
+
+	return &StatementResponse{
+		ParticipantID:  participant.ID,
+		FullName:       participant.FullName,
+		EmployeeNumber: participant.EmployeeNumber,
+		From:           filter.From,
+		To:             filter.To,
+		OpeningBalance: newBalance(openingEmployer, openingEmployee),
+		Movements:      newBalance(employer-openingEmployer, employee-openingEmployee),
+		ClosingBalance: newBalance(employer, employee),
+		Entries:        lines,
+	}, nil
+}
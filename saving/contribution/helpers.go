@@ -0,0 +1,79 @@
+package contribution
+
+import (
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const periodLayout = "2006-01"
+
+func validateParticipantOwnership(participant *entity.Participant, tenantID, productID uuid.UUID) error {
+	if participant.TenantID != tenantID {
+		return errors.ErrForbidden("participant does not belong to this tenant")
+	}
+	if participant.ProductID != productID {
+		return errors.ErrForbidden("participant does not belong to this product")
+	}
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newBalance(employer, employee int64) BalanceResponse {
+	return BalanceResponse{Employer: employer, Employee: employee, Total: employer + employee}
+}
+
+func mapEntryToResponse(e *entity.ContributionLedgerEntry) LedgerEntryResponse {
+	return LedgerEntryResponse{
+		ID:              e.ID,
+		EntryNumber:     e.EntryNumber,
+		ParticipantID:   e.ParticipantID,
+		BatchID:         e.BatchID,
+		EntryType:       string(e.EntryType),
+		Period:          e.Period.Format(periodLayout),
+		PostingDate:     e.PostingDate,
+		EmployerAmount:  e.EmployerAmount,
+		EmployeeAmount:  e.EmployeeAmount,
+		TotalAmount:     e.TotalAmount(),
+		EmployerBalance: e.EmployerBalance,
+		EmployeeBalance: e.EmployeeBalance,
+		TotalBalance:    e.TotalBalance(),
+		ReversesEntryID: e.ReversesEntryID,
+		Reference:       e.Reference,
+		Reason:          e.Reason,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt,
+	}
+}
+
+func mapBatchToResponse(b *entity.ContributionImportBatch) ImportBatchResponse {
+	rowErrors := make([]ImportRowErrorResponse, 0, len(b.RowErrors))
+	for _, re := range b.RowErrors {
+		rowErrors = append(rowErrors, ImportRowErrorResponse{
+			Row:            re.Row,
+			EmployeeNumber: re.EmployeeNumber,
+			Message:        re.Message,
+		})
+	}
+	return ImportBatchResponse{
+		ID:                  b.ID,
+		Period:              b.Period.Format(periodLayout),
+		PostingDate:         b.PostingDate,
+		FileName:            b.FileName,
+		Status:              string(b.Status),
+		TotalRows:           b.TotalRows,
+		PostedRows:          b.PostedRows,
+		TotalEmployerAmount: b.TotalEmployerAmount,
+		TotalEmployeeAmount: b.TotalEmployeeAmount,
+		RowErrors:           rowErrors,
+		ImportedBy:          b.ImportedBy,
+		CreatedAt:           b.CreatedAt,
+	}
+}
@@ -0,0 +1,167 @@
+package contribution
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+// ImportContributions posts an employer payroll file to the ledger. The file
+// is all-or-nothing: when any row fails validation the batch is stored as
+// REJECTED with its row errors and nothing is posted, so the employer can fix
+// and resubmit the whole file.
+func (uc *usecase) ImportContributions(ctx context.Context, req *ImportContributionsRequest) (*ImportBatchResponse, error) {
+	period, err := time.Parse(periodLayout, req.Period)
+	if err != nil {
+		return nil, errors.ErrBadRequest("period must be in YYYY-MM format")
+	}
+
+	today := truncateToDate(time.Now())
+	if period.After(today) {
+		return nil, errors.ErrBadRequest("period cannot be in the future")
+	}
+
+	postingDate := today
+	if req.PostingDate != nil {
+		postingDate = truncateToDate(*req.PostingDate)
+		if postingDate.After(today) {
+			return nil, errors.ErrBadRequest("posting date cannot be in the future")
+		}
+		if postingDate.Before(period) {
+			return nil, errors.ErrBadRequest("posting date cannot precede the contribution period")
+		}
+	}
+
+	payroll, err := ParsePayrollFile(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	if payroll.TotalRows == 0 {
+		return nil, errors.ErrBadRequest("payroll file contains no rows")
+	}
+
+	checksum := sha256.Sum256(req.Content)
+	batch := &entity.ContributionImportBatch{
+		TenantID:     req.TenantID,
+		ProductID:    req.ProductID,
+		Period:       period,
+		PostingDate:  postingDate,
+		FileName:     req.FileName,
+		FileChecksum: hex.EncodeToString(checksum[:]),
+		TotalRows:    payroll.TotalRows,
+		ImportedBy:   req.UserID,
+	}
+
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		posted, err := uc.batchRepo.ExistsPostedChecksum(txCtx, req.TenantID, req.ProductID, batch.FileChecksum)
+		if err != nil {
+			return fmt.Errorf("check payroll checksum: %w", err)
+		}
+		if posted {
+			return errors.ErrConflict("this payroll file has already been posted")
+		}
+
+		rowErrors := append([]entity.ContributionImportRowError{}, payroll.Errors...)
+		entries := make([]*entity.ContributionLedgerEntry, 0, len(payroll.Rows))
+
+		for _, row := range payroll.Rows {
+			rowErr := func(msg string) {
+				rowErrors = append(rowErrors, entity.ContributionImportRowError{
+					Row:            row.Row,
+					EmployeeNumber: row.EmployeeNumber,
+					Message:        msg,
+				})
+			}
+
+			participant, err := uc.participantRepo.GetByEmployeeNumber(txCtx, req.TenantID, req.ProductID, row.EmployeeNumber)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					rowErr("no participant with this employee number")
+					continue
+				}
+				return fmt.Errorf("get participant by employee number: %w", err)
+			}
+			if !participant.CanReceiveContributions() {
+				rowErr(fmt.Sprintf("participant in %s status cannot receive contributions", participant.Status))
+				continue
+			}
+
+			if err := uc.ledgerRepo.LockParticipant(txCtx, participant.ID); err != nil {
+				return fmt.Errorf("lock participant ledger: %w", err)
+			}
+			exists, err := uc.ledgerRepo.HasContributionForPeriod(txCtx, participant.ID, period)
+			if err != nil {
+				return fmt.Errorf("check existing contribution: %w", err)
+			}
+			if exists {
+				rowErr(fmt.Sprintf("contribution for %s is already posted", req.Period))
+				continue
+			}
+
+			entries = append(entries, &entity.ContributionLedgerEntry{
+				TenantID:       req.TenantID,
+				ProductID:      req.ProductID,
+				ParticipantID:  participant.ID,
+				EntryType:      entity.ContributionEntryTypeContribution,
+				Period:         period,
+				PostingDate:    postingDate,
+				EmployerAmount: row.EmployerAmount,
+				EmployeeAmount: row.EmployeeAmount,
+				Reference:      row.Reference,
+				CreatedBy:      req.UserID,
+			})
+		}
+
+		if len(rowErrors) > 0 {
+			sort.SliceStable(rowErrors, func(i, j int) bool {
+				return rowErrors[i].Row < rowErrors[j].Row
+			})
+			batch.Status = entity.ContributionImportStatusRejected
+			batch.RowErrors = rowErrors
+			if err := uc.batchRepo.Create(txCtx, batch); err != nil {
+				return fmt.Errorf("create import batch: %w", err)
+			}
+			return nil
+		}
+
+		batch.Status = entity.ContributionImportStatusPosted
+		batch.PostedRows = len(entries)
+		batch.RowErrors = []entity.ContributionImportRowError{}
+		for _, entry := range entries {
+			batch.TotalEmployerAmount += entry.EmployerAmount
+			batch.TotalEmployeeAmount += entry.EmployeeAmount
+		}
+		if err := uc.batchRepo.Create(txCtx, batch); err != nil {
+			return fmt.Errorf("create import batch: %w", err)
+		}
+
+		for _, entry := range entries {
+			entry.BatchID = &batch.ID
+			if err := uc.ledgerRepo.Append(txCtx, entry); err != nil {
+				return fmt.Errorf("append ledger entry: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("payroll contribution file imported",
+		zap.String("batch_id", batch.ID.String()),
+		zap.String("status", string(batch.Status)),
+		zap.Int("total_rows", batch.TotalRows),
+		zap.Int("posted_rows", batch.PostedRows),
+	)
+
+	resp := mapBatchToResponse(batch)
+	return &resp, nil
+}
@@ -0,0 +1,39 @@
+package contribution
+
+import (
+	"context"
+	"fmt"
+)
+
+func (uc *usecase) ListImportBatches(ctx context.Context, req *ListImportBatchesRequest) (*ListImportBatchesResponse, error) {
+	batches, total, err := uc.batchRepo.List(ctx, &ImportBatchFilter{
+		TenantID:  req.TenantID,
+		ProductID: req.ProductID,
+		Status:    req.Status,
+		Page:      req.Page,
+		PerPage:   req.PerPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list import batches: %w", err)
+	}
+
+	items := make([]ImportBatchResponse, 0, len(batches))
+	for _, b := range batches {
+		items = append(items, mapBatchToResponse(b))
+	}
+
+	totalPages := 0
+	if req.PerPage > 0 {
+		totalPages = int((total + int64(req.PerPage) - 1) / int64(req.PerPage))
+	}
+
+	return &ListImportBatchesResponse{
+		Batches: items,
+		Pagination: PaginationMeta{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
@@ -0,0 +1,194 @@
+package contribution
+
+import (
+	"bytes"
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+const (
+	PayrollColumnEmployeeNumber = "employee_number"
+	PayrollColumnEmployerAmount = "employer_amount"
+	PayrollColumnEmployeeAmount = "employee_amount"
+	PayrollColumnReference      = "reference"
+
+	MaxPayrollRows = 10000
+
+	maxReferenceLen = 100
+)
+
+var payrollRequiredColumns = []string{
+	PayrollColumnEmployeeNumber,
+	PayrollColumnEmployerAmount,
+	PayrollColumnEmployeeAmount,
+}
+
+type PayrollRow struct {
+	Row            int
+	EmployeeNumber string
+	EmployerAmount int64
+	EmployeeAmount int64
+	Reference      *string
+}
+
+type PayrollFile struct {
+	Rows      []PayrollRow
+	Errors    []entity.ContributionImportRowError
+	TotalRows int
+}
+
+// ParsePayrollFile reads an employer payroll contribution CSV. Comma and
+// semicolon delimiters are accepted since spreadsheet exports differ by
+// locale. Row numbers in the result are 1-based file lines, the header being
+// row 1. Structural problems fail the whole file; data problems are collected
+// per row.
+func ParsePayrollFile(content []byte) (*PayrollFile, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if stderrors.Is(err, io.EOF) {
+			return nil, errors.ErrBadRequest("payroll file is empty")
+		}
+		return nil, errors.ErrBadRequest("payroll file is not valid CSV")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range payrollRequiredColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("payroll file is missing column %q", required))
+		}
+	}
+
+	file := &PayrollFile{}
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if stderrors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.ErrBadRequest("payroll file is not valid CSV")
+		}
+
+		file.TotalRows++
+		if file.TotalRows > MaxPayrollRows {
+			return nil, errors.ErrBadRequest(fmt.Sprintf("payroll file exceeds %d rows", MaxPayrollRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, rowErr := parsePayrollRecord(record, columns, line)
+		if rowErr != nil {
+			file.Errors = append(file.Errors, *rowErr)
+			continue
+		}
+
+		if first, dup := seen[row.EmployeeNumber]; dup {
+			file.Errors = append(file.Errors, entity.ContributionImportRowError{
+				Row:            line,
+				EmployeeNumber: row.EmployeeNumber,
+				Message:        fmt.Sprintf("employee number already listed on row %d", first),
+			})
+			continue
+		}
+		seen[row.EmployeeNumber] = line
+		file.Rows = append(file.Rows, row)
+	}
+
+	return file, nil
+}
+
+func parsePayrollRecord(record []string, columns map[string]int, line int) (PayrollRow, *entity.ContributionImportRowError) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := PayrollRow{Row: line, EmployeeNumber: field(PayrollColumnEmployeeNumber)}
+	fail := func(msg string) (PayrollRow, *entity.ContributionImportRowError) {
+		return PayrollRow{}, &entity.ContributionImportRowError{Row: line, EmployeeNumber: row.EmployeeNumber, Message: msg}
+	}
+
+	if row.EmployeeNumber == "" {
+		return fail("employee number is required")
+	}
+	if len(row.EmployeeNumber) > 50 {
+		return fail("employee number must be at most 50 characters")
+	}
+
+	var err error
+	if row.EmployerAmount, err = ParseAmount(field(PayrollColumnEmployerAmount)); err != nil {
+		return fail("employer amount " + err.Error())
+	}
+	if row.EmployeeAmount, err = ParseAmount(field(PayrollColumnEmployeeAmount)); err != nil {
+		return fail("employee amount " + err.Error())
+	}
+	if row.EmployerAmount == 0 && row.EmployeeAmount == 0 {
+		return fail("employer and employee amounts cannot both be zero")
+	}
+
+	if ref := field(PayrollColumnReference); ref != "" {
+		if len(ref) > maxReferenceLen {
+			return fail(fmt.Sprintf("reference must be at most %d characters", maxReferenceLen))
+		}
+		row.Reference = &ref
+	}
+
+	return row, nil
+}
+
+// ParseAmount converts a non-negative decimal string with at most two
+// fraction digits into minor currency units without going through float64.
+func ParseAmount(s string) (int64, error) {
+	if s == "" {
+		return 0, stderrors.New("is required")
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || (hasFrac && (frac == "" || len(frac) > 2)) {
+		return 0, stderrors.New("must be a number with at most two decimals")
+	}
+	if len(whole) > 15 {
+		return 0, stderrors.New("is too large")
+	}
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return 0, stderrors.New("must be a non-negative number")
+		}
+	}
+
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, stderrors.New("is too large")
+	}
+	return units, nil
+}
+
+func detectDelimiter(content []byte) rune {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
@@ -0,0 +1,53 @@
+package contribution
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type ImportBatchFilter struct {
+	TenantID  uuid.UUID
+	ProductID uuid.UUID
+	Status    *string
+	Page      int
+	PerPage   int
+}
+
+type StatementFilter struct {
+	ParticipantID uuid.UUID
+	From          *time.Time
+	To            *time.Time
+}
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+	GetByEmployeeNumber(ctx context.Context, tenantID, productID uuid.UUID, employeeNumber string) (*entity.Participant, error)
+}
+
+type LedgerRepository interface {
+	// Append serialises postings per participant, fills the running balances
+	// from the participant's latest entry and inserts the entry. It must run
+	// inside a transaction.
+	Append(ctx context.Context, entry *entity.ContributionLedgerEntry) error
+	// LockParticipant holds the participant row until the transaction ends,
+	// so a check-then-append on the participant's ledger cannot interleave
+	// with another posting.
+	LockParticipant(ctx context.Context, participantID uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionLedgerEntry, error)
+	IsReversed(ctx context.Context, entryID uuid.UUID) (bool, error)
+	HasContributionForPeriod(ctx context.Context, participantID uuid.UUID, period time.Time) (bool, error)
+	SumBefore(ctx context.Context, participantID uuid.UUID, before time.Time) (employer int64, employee int64, err error)
+	GetBalance(ctx context.Context, participantID uuid.UUID) (employer int64, employee int64, err error)
+	ListForStatement(ctx context.Context, filter *StatementFilter) ([]*entity.ContributionLedgerEntry, error)
+}
+
+type ImportBatchRepository interface {
+	Create(ctx context.Context, batch *entity.ContributionImportBatch) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionImportBatch, error)
+	ExistsPostedChecksum(ctx context.Context, tenantID, productID uuid.UUID, checksum string) (bool, error)
+	List(ctx context.Context, filter *ImportBatchFilter) ([]*entity.ContributionImportBatch, int64, error)
+}
@@ -0,0 +1,48 @@
+package contribution
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportContributionsRequest struct {
+	TenantID    uuid.UUID  `json:"-"`
+	ProductID   uuid.UUID  `json:"-"`
+	UserID      uuid.UUID  `json:"-"`
+	Period      string     `json:"period" validate:"required,datetime=2006-01"`
+	PostingDate *time.Time `json:"posting_date,omitempty"`
+	FileName    string     `json:"-"`
+	Content     []byte     `json:"-"`
+}
+
+type ListImportBatchesRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	Status    *string   `json:"status,omitempty" validate:"omitempty,oneof=POSTED REJECTED"`
+	Page      int       `json:"page" validate:"min=1"`
+	PerPage   int       `json:"per_page" validate:"min=1,max=100"`
+}
+
+type GetImportBatchRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	BatchID   uuid.UUID `json:"-"`
+}
+
+type ReverseEntryRequest struct {
+	TenantID    uuid.UUID  `json:"-"`
+	ProductID   uuid.UUID  `json:"-"`
+	EntryID     uuid.UUID  `json:"-"`
+	UserID      uuid.UUID  `json:"-"`
+	Reason      string     `json:"reason" validate:"required,min=10,max=500"`
+	PostingDate *time.Time `json:"posting_date,omitempty"`
+}
+
+type GetStatementRequest struct {
+	TenantID      uuid.UUID  `json:"-"`
+	ProductID     uuid.UUID  `json:"-"`
+	ParticipantID uuid.UUID  `json:"-"`
+	From          *time.Time `json:"from,omitempty"`
+	To            *time.Time `json:"to,omitempty"`
+}
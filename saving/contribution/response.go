@@ -0,0 +1,85 @@
+package contribution
+
+import (
+	"time"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type LedgerEntryResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	EntryNumber     int64      `json:"entry_number"`
+	ParticipantID   uuid.UUID  `json:"participant_id"`
+	BatchID         *uuid.UUID `json:"batch_id,omitempty"`
+	EntryType       string     `json:"entry_type"`
+	Period          string     `json:"period"`
+	PostingDate     time.Time  `json:"posting_date"`
+	EmployerAmount  int64      `json:"employer_amount"`
+	EmployeeAmount  int64      `json:"employee_amount"`
+	TotalAmount     int64      `json:"total_amount"`
+	EmployerBalance int64      `json:"employer_balance"`
+	EmployeeBalance int64      `json:"employee_balance"`
+	TotalBalance    int64      `json:"total_balance"`
+	ReversesEntryID *uuid.UUID `json:"reverses_entry_id,omitempty"`
+	Reference       *string    `json:"reference,omitempty"`
+	Reason          *string    `json:"reason,omitempty"`
+	CreatedBy       uuid.UUID  `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ImportRowErrorResponse struct {
+	Row            int    `json:"row"`
+	EmployeeNumber string `json:"employee_number,omitempty"`
+	Message        string `json:"message"`
+}
+
+type ImportBatchResponse struct {
+	ID                  uuid.UUID                `json:"id"`
+	Period              string                   `json:"period"`
+	PostingDate         time.Time                `json:"posting_date"`
+	FileName            string                   `json:"file_name"`
+	Status              string                   `json:"status"`
+	TotalRows           int                      `json:"total_rows"`
+	PostedRows          int                      `json:"posted_rows"`
+	TotalEmployerAmount int64                    `json:"total_employer_amount"`
+	TotalEmployeeAmount int64                    `json:"total_employee_amount"`
+	RowErrors           []ImportRowErrorResponse `json:"row_errors"`
+	ImportedBy          uuid.UUID                `json:"imported_by"`
+	CreatedAt           time.Time                `json:"created_at"`
+}
+
+func (r *ImportBatchResponse) IsPosted() bool {
+	return r.Status == string(entity.ContributionImportStatusPosted)
+}
+
+type ListImportBatchesResponse struct {
+	Batches    []ImportBatchResponse `json:"batches"`
+	Pagination PaginationMeta        `json:"pagination"`
+}
+
+type PaginationMeta struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type BalanceResponse struct {
+	Employer int64 `json:"employer"`
+	Employee int64 `json:"employee"`
+	Total    int64 `json:"total"`
+}
+
+type StatementResponse struct {
+	ParticipantID  uuid.UUID             `json:"participant_id"`
+	FullName       string                `json:"full_name"`
+	EmployeeNumber *string               `json:"employee_number,omitempty"`
+	From           *time.Time            `json:"from,omitempty"`
+	To             *time.Time            `json:"to,omitempty"`
+	OpeningBalance BalanceResponse       `json:"opening_balance"`
+	Movements      BalanceResponse       `json:"movements"`
+	ClosingBalance BalanceResponse       `json:"closing_balance"`
+	Entries        []LedgerEntryResponse `json:"entries"`
+}
@@ -0,0 +1,79 @@
+package contribution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) ReverseEntry(ctx context.Context, req *ReverseEntryRequest) (*LedgerEntryResponse, error) {
+	var result *LedgerEntryResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		entry, err := uc.ledgerRepo.GetByID(txCtx, req.EntryID)
+		if err != nil {
+			return fmt.Errorf("get ledger entry: %w", err)
+		}
+
+		if entry.TenantID != req.TenantID || entry.ProductID != req.ProductID {
+			return errors.ErrForbidden("ledger entry does not belong to this tenant/product")
+		}
+
+		if !entry.CanBeReversed() {
+			return errors.ErrBadRequest(fmt.Sprintf("%s entries cannot be reversed", entry.EntryType))
+		}
+
+		reversed, err := uc.ledgerRepo.IsReversed(txCtx, entry.ID)
+		if err != nil {
+			return fmt.Errorf("check reversal: %w", err)
+		}
+		if reversed {
+			return errors.ErrConflict("ledger entry has already been reversed")
+		}
+
+		today := truncateToDate(time.Now())
+		postingDate := today
+		if req.PostingDate != nil {
+			postingDate = truncateToDate(*req.PostingDate)
+			if postingDate.After(today) {
+				return errors.ErrBadRequest("posting date cannot be in the future")
+			}
+		}
+		if postingDate.Before(truncateToDate(entry.PostingDate)) {
+			return errors.ErrBadRequest("reversal cannot be posted before the original entry")
+		}
+
+		reason := req.Reason
+		reversal := &entity.ContributionLedgerEntry{
+			TenantID:        entry.TenantID,
+			ProductID:       entry.ProductID,
+			ParticipantID:   entry.ParticipantID,
+			BatchID:         entry.BatchID,
+			EntryType:       entity.ContributionEntryTypeReversal,
+			Period:          entry.Period,
+			PostingDate:     postingDate,
+			EmployerAmount:  -entry.EmployerAmount,
+			EmployeeAmount:  -entry.EmployeeAmount,
+			ReversesEntryID: &entry.ID,
+			Reference:       entry.Reference,
+			Reason:          &reason,
+			CreatedBy:       req.UserID,
+		}
+
+		if err := uc.ledgerRepo.Append(txCtx, reversal); err != nil {
+			return fmt.Errorf("append reversal entry: %w", err)
+		}
+
+		resp := mapEntryToResponse(reversal)
+		result = &resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,11 @@
+package contribution
+
+import "context"
+
+type Usecase interface {
+	ImportContributions(ctx context.Context, req *ImportContributionsRequest) (*ImportBatchResponse, error)
+	ListImportBatches(ctx context.Context, req *ListImportBatchesRequest) (*ListImportBatchesResponse, error)
+	GetImportBatch(ctx context.Context, req *GetImportBatchRequest) (*ImportBatchResponse, error)
+	ReverseEntry(ctx context.Context, req *ReverseEntryRequest) (*LedgerEntryResponse, error)
+	GetStatement(ctx context.Context, req *GetStatementRequest) (*StatementResponse, error)
+}
@@ -0,0 +1,35 @@
+package fileaccess
+
+import (
+	"erp-service/config"
+	"erp-service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg             *config.Config
+	logger          *zap.Logger
+	auditLogger     logger.AuditLogger
+	fileRepo        FileRepository
+	participantRepo ParticipantRepository
+	fileStorage     FileStorageAdapter
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	auditLogger logger.AuditLogger,
+	fileRepo FileRepository,
+	participantRepo ParticipantRepository,
+	fileStorage FileStorageAdapter,
+) Usecase {
+	return &usecase{
+		cfg:             cfg,
+		logger:          logger,
+		auditLogger:     auditLogger,
+		fileRepo:        fileRepo,
+		participantRepo: participantRepo,
+		fileStorage:     fileStorage,
+	}
+}
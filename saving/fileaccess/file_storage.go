@@ -0,0 +1,14 @@
+package fileaccess
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"erp-service/pkg/envelope"
+)
+
+type FileStorageAdapter interface {
+	GetPresignedURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error)
+	GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error)
+}
@@ -0,0 +1,12 @@
+package fileaccess
+
+import "context"
+
+func (uc *usecase) GetFile(ctx context.Context, req *GetFileRequest) (*FileResponse, error) {
+	file, err := uc.authorizeFile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapFileToResponse(file), nil
+}
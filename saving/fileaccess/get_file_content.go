@@ -0,0 +1,98 @@
+package fileaccess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/logger"
+)
+
+const defaultPresignExpiry = 5 * time.Minute
+
+const (
+	variantOriginal  = "original"
+	variantThumbnail = "thumbnail"
+)
+
+func (uc *usecase) GetFileContent(ctx context.Context, req *GetFileContentRequest) (*FileContentResponse, error) {
+	file, err := uc.authorizeFile(ctx, &req.GetFileRequest)
+	if err != nil {
+		uc.auditDownload(ctx, req, nil, variantOriginal, err)
+		return nil, err
+	}
+
+	if err := ensureScannedClean(file); err != nil {
+		uc.auditDownload(ctx, req, file, variantOriginal, err)
+		return nil, err
+	}
+
+	result, err := uc.openContent(ctx, req, file)
+	uc.auditDownload(ctx, req, file, variantOriginal, err)
+	return result, err
+}
+
+// openContent returns either the object body (inline) or a presigned URL
+// for the given file. Encrypted objects are always streamed: storage only
+// holds ciphertext, so a presigned URL would be useless to the client.
+func (uc *usecase) openContent(ctx context.Context, req *GetFileContentRequest, file *entity.File) (*FileContentResponse, error) {
+	result := &FileContentResponse{
+		FileName:    file.OriginalName,
+		ContentType: file.ContentType,
+		SizeBytes:   file.SizeBytes,
+	}
+
+	env := file.Envelope()
+	if req.Inline || env != nil {
+		body, err := uc.fileStorage.GetDecryptedObject(ctx, file.Bucket, file.StorageKey, env)
+		if err != nil {
+			return nil, fmt.Errorf("get object: %w", err)
+		}
+		result.Body = body
+		return result, nil
+	}
+
+	expiry := uc.cfg.Infra.Minio.PresignExpiry
+	if expiry <= 0 {
+		expiry = defaultPresignExpiry
+	}
+	url, err := uc.fileStorage.GetPresignedURL(ctx, file.Bucket, file.StorageKey, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("presign url: %w", err)
+	}
+	result.URL = url
+	result.ExpiresAt = time.Now().Add(expiry)
+	return result, nil
+}
+
+func (uc *usecase) auditDownload(ctx context.Context, req *GetFileContentRequest, file *entity.File, variant string, err error) {
+	mode := "redirect"
+	if req.Inline || (file != nil && file.Envelope() != nil) {
+		mode = "inline"
+	}
+
+	event := logger.AuditEvent{
+		Domain:     "saving",
+		Action:     "file_download",
+		ActorID:    req.UserID.String(),
+		ActorType:  "user",
+		TargetID:   req.FileID.String(),
+		TargetType: "file",
+		TenantID:   req.TenantID.String(),
+		Success:    err == nil,
+		Metadata: map[string]any{
+			"product_id": req.ProductID.String(),
+			"mode":       mode,
+			"variant":    variant,
+		},
+	}
+	if err != nil {
+		event.Reason = err.Error()
+	}
+	if file != nil && file.ParticipantID != nil {
+		event.Metadata["participant_id"] = file.ParticipantID.String()
+	}
+
+	uc.auditLogger.Log(ctx, event)
+}
@@ -0,0 +1,41 @@
+package fileaccess
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/pkg/errors"
+)
+
+// GetFileThumbnail serves the thumbnail derived from an image upload. Access
+// is decided on the original file, so the thumbnail is visible exactly to
+// those who may open the original.
+func (uc *usecase) GetFileThumbnail(ctx context.Context, req *GetFileContentRequest) (*FileContentResponse, error) {
+	file, err := uc.authorizeFile(ctx, &req.GetFileRequest)
+	if err != nil {
+		uc.auditDownload(ctx, req, nil, variantThumbnail, err)
+		return nil, err
+	}
+
+	if err := ensureScannedClean(file); err != nil {
+		uc.auditDownload(ctx, req, file, variantThumbnail, err)
+		return nil, err
+	}
+
+	if file.ThumbnailFileID == nil {
+		err := errors.ErrNotFound("file has no thumbnail")
+		uc.auditDownload(ctx, req, file, variantThumbnail, err)
+		return nil, err
+	}
+
+	thumbnail, err := uc.fileRepo.GetByID(ctx, *file.ThumbnailFileID)
+	if err != nil {
+		err = fmt.Errorf("get thumbnail: %w", err)
+		uc.auditDownload(ctx, req, file, variantThumbnail, err)
+		return nil, err
+	}
+
+	result, err := uc.openContent(ctx, req, thumbnail)
+	uc.auditDownload(ctx, req, file, variantThumbnail, err)
+	return result, err
+}
@@ -0,0 +1,87 @@
+package fileaccess
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+// ContentPath is the API path that serves a file's content.
+const ContentPath = "/api/v1/saving/files/%s/content"
+
+// ThumbnailPath is the API path that serves an image file's thumbnail.
+const ThumbnailPath = "/api/v1/saving/files/%s/thumbnail"
+
+// authorizeFile loads the file and checks it against the caller's tenant,
+// product and role, and against the participant that owns it.
+func (uc *usecase) authorizeFile(ctx context.Context, req *GetFileRequest) (*entity.File, error) {
+	file, err := uc.fileRepo.GetByID(ctx, req.FileID)
+	if err != nil {
+		return nil, fmt.Errorf("get file: %w", err)
+	}
+
+	if file.TenantID != req.TenantID {
+		return nil, errors.ErrForbidden("file does not belong to this tenant")
+	}
+	if file.ProductID != req.ProductID {
+		return nil, errors.ErrForbidden("file does not belong to this product")
+	}
+
+	if file.ParticipantID == nil {
+		if !req.CanViewAll && file.UploadedBy != req.UserID {
+			return nil, errors.ErrForbidden("file is not accessible")
+		}
+		return file, nil
+	}
+
+	participant, err := uc.participantRepo.GetByID(ctx, *file.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+	if participant.TenantID != req.TenantID || participant.ProductID != req.ProductID {
+		return nil, errors.ErrForbidden("file is not accessible")
+	}
+
+	if req.CanViewAll || file.UploadedBy == req.UserID || participant.CreatedBy == req.UserID {
+		return file, nil
+	}
+	if participant.UserID != nil && *participant.UserID == req.UserID {
+		return file, nil
+	}
+	return nil, errors.ErrForbidden("file is not accessible")
+}
+
+// ensureScannedClean keeps unscanned and quarantined content from being
+// served to staff machines.
+func ensureScannedClean(file *entity.File) error {
+	if file.IsInfected() {
+		return errors.ErrForbidden("file failed malware scanning and has been quarantined")
+	}
+	if file.IsScanFailed() {
+		return errors.ErrForbidden("file could not be scanned for malware; upload it again")
+	}
+	if !file.IsClean() {
+		return errors.ErrBadRequest("file is still being scanned; try again shortly")
+	}
+	return nil
+}
+
+func mapFileToResponse(file *entity.File) *FileResponse {
+	resp := &FileResponse{
+		ID:            file.ID,
+		ParticipantID: file.ParticipantID,
+		OriginalName:  file.OriginalName,
+		ContentType:   file.ContentType,
+		SizeBytes:     file.SizeBytes,
+		UploadedBy:    file.UploadedBy,
+		ScanStatus:    string(file.ScanStatus),
+		CreatedAt:     file.CreatedAt,
+		ContentURL:    fmt.Sprintf(ContentPath, file.ID),
+	}
+	if file.ThumbnailFileID != nil {
+		resp.ThumbnailURL = fmt.Sprintf(ThumbnailPath, file.ID)
+	}
+	return resp
+}
@@ -0,0 +1,17 @@
+package fileaccess
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type FileRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error)
+}
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+}
@@ -0,0 +1,21 @@
+package fileaccess
+
+import (
+	"github.com/google/uuid"
+)
+
+type GetFileRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+	FileID    uuid.UUID `json:"-"`
+	// CanViewAll is set for approvers and platform admins, who may open any
+	// file of the product. Other callers only see files they uploaded or that
+	// belong to a participant they created.
+	CanViewAll bool `json:"-"`
+}
+
+type GetFileContentRequest struct {
+	GetFileRequest
+	Inline bool `json:"-"`
+}
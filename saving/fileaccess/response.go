@@ -0,0 +1,32 @@
+package fileaccess
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FileResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ParticipantID *uuid.UUID `json:"participant_id,omitempty"`
+	OriginalName  string     `json:"original_name"`
+	ContentType   string     `json:"content_type"`
+	SizeBytes     int64      `json:"size_bytes"`
+	UploadedBy    uuid.UUID  `json:"uploaded_by"`
+	ScanStatus    string     `json:"scan_status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ContentURL    string     `json:"content_url"`
+	ThumbnailURL  string     `json:"thumbnail_url,omitempty"`
+}
+
+// FileContentResponse carries either a presigned URL to redirect to or, for
+// inline requests, the object body to stream. The caller must close Body.
+type FileContentResponse struct {
+	URL         string
+	ExpiresAt   time.Time
+	Body        io.ReadCloser
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+}
@@ -0,0 +1,9 @@
+package fileaccess
+
+import "context"
+
+type Usecase interface {
+	GetFile(ctx context.Context, req *GetFileRequest) (*FileResponse, error)
+	GetFileContent(ctx context.Context, req *GetFileContentRequest) (*FileContentResponse, error)
+	GetFileThumbnail(ctx context.Context, req *GetFileContentRequest) (*FileContentResponse, error)
+}
@@ -6,6 +6,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) ApproveMember(ctx context.Context, req *ApproveRequest) (*MemberDetailResponse, error) {
@@ -56,6 +57,7 @@ func (uc *usecase) ApproveMember(ctx context.Context, req *ApproveRequest) (*Mem
 	if err != nil {
 		return nil, err
 	}
+	uc.publish(ctx, notify.MemberApproved, reg, req.ProductID, &role.Code, &req.ApproverID)
 
 	profile, profileErr := uc.profileRepo.GetByUserID(ctx, reg.UserID)
 	if profileErr != nil && !errors.IsNotFound(profileErr) {
@@ -1,6 +1,10 @@
 package member
 
-import "erp-service/config"
+import (
+	"erp-service/config"
+
+	"go.uber.org/zap"
+)
 
 type usecase struct {
 	cfg         *config.Config
@@ -12,6 +16,8 @@ type usecase struct {
 	configRepo  ProductRegistrationConfigRepository
 	profileRepo UserProfileRepository
 	userRepo    UserRepository
+	events      EventPublisher
+	logger      *zap.Logger
 }
 
 func NewUsecase(
@@ -24,6 +30,8 @@ func NewUsecase(
 	configRepo ProductRegistrationConfigRepository,
 	profileRepo UserProfileRepository,
 	userRepo UserRepository,
+	events EventPublisher,
+	logger *zap.Logger,
 ) Usecase {
 	return &usecase{
 		cfg:         cfg,
@@ -35,5 +43,7 @@ func NewUsecase(
 		configRepo:  configRepo,
 		profileRepo: profileRepo,
 		userRepo:    userRepo,
+		events:      events,
+		logger:      logger,
 	}
 }
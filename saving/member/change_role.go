@@ -6,6 +6,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) ChangeRole(ctx context.Context, req *ChangeRoleRequest) (*MemberDetailResponse, error) {
@@ -59,6 +60,7 @@ func (uc *usecase) ChangeRole(ctx context.Context, req *ChangeRoleRequest) (*Mem
 	if err != nil {
 		return nil, err
 	}
+	uc.publish(ctx, notify.MemberRoleChanged, reg, req.ProductID, &newRole.Code, &req.ActorID)
 
 	profile, profileErr := uc.profileRepo.GetByUserID(ctx, reg.UserID)
 	if profileErr != nil && !errors.IsNotFound(profileErr) {
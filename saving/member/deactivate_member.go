@@ -5,6 +5,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) DeactivateMember(ctx context.Context, req *DeactivateRequest) (*MemberDetailResponse, error) {
@@ -37,6 +38,7 @@ func (uc *usecase) DeactivateMember(ctx context.Context, req *DeactivateRequest)
 	if err != nil {
 		return nil, err
 	}
+	uc.publish(ctx, notify.MemberDeactivated, reg, req.ProductID, nil, &req.ActorID)
 
 	profile, profileErr := uc.profileRepo.GetByUserID(ctx, reg.UserID)
 	if profileErr != nil && !errors.IsNotFound(profileErr) {
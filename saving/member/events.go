@@ -0,0 +1,42 @@
+package member
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EventPublisher pushes workflow events to connected clients. It is optional;
+// without one, membership changes are not announced.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event *notify.Event) error
+}
+
+// publish announces a committed membership change. Delivery is best effort:
+// the change is already durable, so a failure is logged and not returned.
+func (uc *usecase) publish(ctx context.Context, eventType string, reg *entity.UserTenantRegistration, productID uuid.UUID, roleCode *string, changedBy *uuid.UUID) {
+	if uc.events == nil {
+		return
+	}
+	event, err := notify.NewEvent(notify.TopicMembers, eventType, reg.TenantID, productID, notify.MemberData{
+		MemberID:  reg.ID,
+		UserID:    reg.UserID,
+		Status:    string(reg.Status),
+		RoleCode:  roleCode,
+		ChangedBy: changedBy,
+	})
+	if err == nil {
+		err = uc.events.PublishEvent(ctx, event)
+	}
+	if err != nil {
+		uc.logger.Warn("failed to publish member event",
+			zap.String("type", eventType),
+			zap.String("tenant_id", reg.TenantID.String()),
+			zap.Error(err),
+		)
+	}
+}
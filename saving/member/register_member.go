@@ -6,6 +6,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) RegisterMember(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error) {
@@ -49,6 +50,7 @@ func (uc *usecase) RegisterMember(ctx context.Context, req *RegisterRequest) (*R
 	if err := uc.utrRepo.Create(ctx, reg); err != nil {
 		return nil, err
 	}
+	uc.publish(ctx, notify.MemberRegistered, reg, req.ProductID, nil, nil)
 
 	return &RegisterResponse{
 		ID:               reg.ID,
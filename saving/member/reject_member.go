@@ -6,6 +6,7 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) RejectMember(ctx context.Context, req *RejectRequest) (*MemberDetailResponse, error) {
@@ -41,6 +42,7 @@ func (uc *usecase) RejectMember(ctx context.Context, req *RejectRequest) (*Membe
 	if err := uc.utrRepo.UpdateStatus(ctx, reg); err != nil {
 		return nil, err
 	}
+	uc.publish(ctx, notify.MemberRejected, reg, req.ProductID, nil, &req.ApproverID)
 
 	profile, profileErr := uc.profileRepo.GetByUserID(ctx, reg.UserID)
 	if profileErr != nil && !errors.IsNotFound(profileErr) {
@@ -0,0 +1,21 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+)
+
+func (uc *usecase) ActivateParticipant(ctx context.Context, req *ActivateParticipantRequest) (*ParticipantResponse, error) {
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:      req.TenantID,
+		productID:     req.ProductID,
+		participantID: req.ParticipantID,
+		userID:        req.UserID,
+		to:            entity.ParticipantStatusActive,
+		action:        "activated",
+		guard:         (*entity.Participant).CanBeActivated,
+		effectiveDate: req.EffectiveDate,
+		reason:        req.Reason,
+	})
+}
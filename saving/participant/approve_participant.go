@@ -3,14 +3,19 @@ package participant
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
 )
 
 func (uc *usecase) ApproveParticipant(ctx context.Context, req *ApproveParticipantRequest) (*ParticipantResponse, error) {
 	var result *ParticipantResponse
+	var event *notify.Event
 
 	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		participant, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
@@ -26,6 +31,16 @@ func (uc *usecase) ApproveParticipant(ctx context.Context, req *ApproveParticipa
 			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot be approved", participant.Status))
 		}
 
+		unverified, err := uc.unverifiedPrimaryBankAccount(txCtx, participant.ID)
+		if err != nil {
+			return err
+		}
+		override := req.BankVerificationOverrideReason
+		if unverified != nil && (override == nil || strings.TrimSpace(*override) == "") {
+			return errors.ErrBadRequest(fmt.Sprintf(
+				"primary bank account is %s; verify it or approve with bank_verification_override_reason", unverified.VerificationStatus))
+		}
+
 		now := time.Now()
 		fromStatus := string(participant.Status)
 
@@ -46,6 +61,15 @@ func (uc *usecase) ApproveParticipant(ctx context.Context, req *ApproveParticipa
 			CreatedAt:     now,
 			UpdatedAt:     now,
 		}
+		if unverified != nil {
+			reason := strings.TrimSpace(*override)
+			history.Reason = &reason
+			history.Details = map[string]string{
+				"bank_verification_override": "true",
+				"bank_account_id":            unverified.ID.String(),
+				"bank_verification_status":   string(unverified.VerificationStatus),
+			}
+		}
 
 		if err := uc.statusHistoryRepo.Create(txCtx, history); err != nil {
 			return fmt.Errorf("create status history: %w", err)
@@ -56,6 +80,7 @@ func (uc *usecase) ApproveParticipant(ctx context.Context, req *ApproveParticipa
 			return fmt.Errorf("build response: %w", err)
 		}
 		result = resp
+		event = newStatusEvent(notify.TopicParticipantStatus, notify.ParticipantApproved, participant, fromStatus, req.UserID)
 		return nil
 	})
 
@@ -63,5 +88,21 @@ func (uc *usecase) ApproveParticipant(ctx context.Context, req *ApproveParticipa
 		return nil, err
 	}
 
+	uc.publish(ctx, event)
 	return result, nil
 }
+
+// unverifiedPrimaryBankAccount returns the primary payout account when it
+// has not passed verification, or nil when it has or there is none.
+func (uc *usecase) unverifiedPrimaryBankAccount(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantBankAccount, error) {
+	accounts, err := uc.bankAccountRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("list bank accounts: %w", err)
+	}
+	for _, account := range accounts {
+		if account.IsPrimary && !account.IsVerified() {
+			return account, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,29 @@
+package participant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBankAccountNotFound is returned by a verifier when the bank does not
+// know the account.
+var ErrBankAccountNotFound = errors.New("bank account not found")
+
+// BankAccountInquiry is the account to look up. HolderName is the name the
+// participant claimed; providers that only validate names use it, pure
+// inquiry providers ignore it.
+type BankAccountInquiry struct {
+	BankCode      string
+	AccountNumber string
+	HolderName    string
+}
+
+type BankAccountInquiryResult struct {
+	HolderName string
+}
+
+// BankAccountVerifier asks the bank who holds an account.
+type BankAccountVerifier interface {
+	Name() string
+	Inquire(ctx context.Context, inquiry BankAccountInquiry) (*BankAccountInquiryResult, error)
+}
@@ -19,8 +19,12 @@ type usecase struct {
 	pensionRepo       ParticipantPensionRepository
 	beneficiaryRepo   ParticipantBeneficiaryRepository
 	statusHistoryRepo ParticipantStatusHistoryRepository
+	duplicateRepo     ParticipantDuplicateRepository
 	fileStorage       FileStorageAdapter
 	fileRepo          FileRepository
+	uploadSlotRepo    UploadSlotRepository
+	bankVerifier      BankAccountVerifier
+	events            EventPublisher
 
 	tenantRepo        TenantRepository
 	productRepo       ProductRepository
@@ -43,8 +47,12 @@ func NewUsecase(
 	pensionRepo ParticipantPensionRepository,
 	beneficiaryRepo ParticipantBeneficiaryRepository,
 	statusHistoryRepo ParticipantStatusHistoryRepository,
+	duplicateRepo ParticipantDuplicateRepository,
 	fileStorage FileStorageAdapter,
 	fileRepo FileRepository,
+	uploadSlotRepo UploadSlotRepository,
+	bankVerifier BankAccountVerifier,
+	events EventPublisher,
 	tenantRepo TenantRepository,
 	productRepo ProductRepository,
 	configRepo ProductRegistrationConfigRepository,
@@ -65,8 +73,12 @@ func NewUsecase(
 		pensionRepo:       pensionRepo,
 		beneficiaryRepo:   beneficiaryRepo,
 		statusHistoryRepo: statusHistoryRepo,
+		duplicateRepo:     duplicateRepo,
 		fileStorage:       fileStorage,
 		fileRepo:          fileRepo,
+		uploadSlotRepo:    uploadSlotRepo,
+		bankVerifier:      bankVerifier,
+		events:            events,
 		tenantRepo:        tenantRepo,
 		productRepo:       productRepo,
 		configRepo:        configRepo,
@@ -75,3 +87,39 @@ func NewUsecase(
 		masterdataUsecase: masterdataUsecase,
 	}
 }
+
+type batchReader struct {
+	participantRepo   ParticipantRepository
+	identityRepo      ParticipantIdentityRepository
+	addressRepo       ParticipantAddressRepository
+	bankAccountRepo   ParticipantBankAccountRepository
+	familyMemberRepo  ParticipantFamilyMemberRepository
+	employmentRepo    ParticipantEmploymentRepository
+	pensionRepo       ParticipantPensionRepository
+	beneficiaryRepo   ParticipantBeneficiaryRepository
+	statusHistoryRepo ParticipantStatusHistoryRepository
+}
+
+func NewBatchReader(
+	participantRepo ParticipantRepository,
+	identityRepo ParticipantIdentityRepository,
+	addressRepo ParticipantAddressRepository,
+	bankAccountRepo ParticipantBankAccountRepository,
+	familyMemberRepo ParticipantFamilyMemberRepository,
+	employmentRepo ParticipantEmploymentRepository,
+	pensionRepo ParticipantPensionRepository,
+	beneficiaryRepo ParticipantBeneficiaryRepository,
+	statusHistoryRepo ParticipantStatusHistoryRepository,
+) BatchReader {
+	return &batchReader{
+		participantRepo:   participantRepo,
+		identityRepo:      identityRepo,
+		addressRepo:       addressRepo,
+		bankAccountRepo:   bankAccountRepo,
+		familyMemberRepo:  familyMemberRepo,
+		employmentRepo:    employmentRepo,
+		pensionRepo:       pensionRepo,
+		beneficiaryRepo:   beneficiaryRepo,
+		statusHistoryRepo: statusHistoryRepo,
+	}
+}
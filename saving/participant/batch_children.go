@@ -0,0 +1,137 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+func (r *batchReader) ListIdentitiesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]IdentityResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]IdentityResponse{}, err
+	}
+
+	identities, err := r.identityRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load identities: %w", err)
+	}
+	return groupByParticipant(identities, func(i *entity.ParticipantIdentity) uuid.UUID { return i.ParticipantID }, mapIdentityToResponse), nil
+}
+
+func (r *batchReader) ListAddressesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]AddressResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]AddressResponse{}, err
+	}
+
+	addresses, err := r.addressRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load addresses: %w", err)
+	}
+	return groupByParticipant(addresses, func(a *entity.ParticipantAddress) uuid.UUID { return a.ParticipantID }, mapAddressToResponse), nil
+}
+
+func (r *batchReader) ListBankAccountsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]BankAccountResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]BankAccountResponse{}, err
+	}
+
+	accounts, err := r.bankAccountRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load bank accounts: %w", err)
+	}
+	return groupByParticipant(accounts, func(a *entity.ParticipantBankAccount) uuid.UUID { return a.ParticipantID }, mapBankAccountToResponse), nil
+}
+
+func (r *batchReader) ListFamilyMembersByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]FamilyMemberResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]FamilyMemberResponse{}, err
+	}
+
+	members, err := r.familyMemberRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load family members: %w", err)
+	}
+	return groupByParticipant(members, func(m *entity.ParticipantFamilyMember) uuid.UUID { return m.ParticipantID }, mapFamilyMemberToResponse), nil
+}
+
+func (r *batchReader) GetEmploymentsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*EmploymentResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID]*EmploymentResponse{}, err
+	}
+
+	employments, err := r.employmentRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load employment: %w", err)
+	}
+
+	results := make(map[uuid.UUID]*EmploymentResponse, len(employments))
+	for _, e := range employments {
+		resp := mapEmploymentToResponse(e)
+		results[e.ParticipantID] = &resp
+	}
+	return results, nil
+}
+
+func (r *batchReader) GetPensionsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*PensionResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID]*PensionResponse{}, err
+	}
+
+	pensions, err := r.pensionRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load pension: %w", err)
+	}
+
+	results := make(map[uuid.UUID]*PensionResponse, len(pensions))
+	for _, p := range pensions {
+		resp := mapPensionToResponse(p)
+		results[p.ParticipantID] = &resp
+	}
+	return results, nil
+}
+
+func (r *batchReader) ListBeneficiariesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]BeneficiaryResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]BeneficiaryResponse{}, err
+	}
+
+	beneficiaries, err := r.beneficiaryRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load beneficiaries: %w", err)
+	}
+	return groupByParticipant(beneficiaries, func(b *entity.ParticipantBeneficiary) uuid.UUID { return b.ParticipantID }, mapBeneficiaryToResponse), nil
+}
+
+func (r *batchReader) ListStatusHistoriesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]StatusHistoryResponse, error) {
+	ids, err := r.ownedParticipantIDs(ctx, req)
+	if err != nil || len(ids) == 0 {
+		return map[uuid.UUID][]StatusHistoryResponse{}, err
+	}
+
+	histories, err := r.statusHistoryRepo.ListByParticipantIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load status history: %w", err)
+	}
+	return groupByParticipant(histories, func(h *entity.ParticipantStatusHistory) uuid.UUID { return h.ParticipantID }, mapStatusHistoryToResponse), nil
+}
+
+// groupByParticipant maps records to responses keyed by participant,
+// keeping the repository's ordering within each participant.
+func groupByParticipant[E any, R any](records []E, participantID func(E) uuid.UUID, mapFn func(E) R) map[uuid.UUID][]R {
+	results := make(map[uuid.UUID][]R)
+	for _, record := range records {
+		id := participantID(record)
+		results[id] = append(results[id], mapFn(record))
+	}
+	return results
+}
@@ -0,0 +1,95 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// MaxBatchParticipants caps the participants a single batch call may ask for.
+const MaxBatchParticipants = 100
+
+func (r *batchReader) ListParticipantPage(ctx context.Context, req *ListParticipantsRequest) ([]*ParticipantResponse, *PaginationMeta, error) {
+	filter := &ParticipantFilter{
+		TenantID:  req.TenantID,
+		ProductID: req.ProductID,
+		Status:    req.Status,
+		Search:    req.Search,
+		Page:      req.Page,
+		PerPage:   req.PerPage,
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+	}
+
+	participants, total, err := r.participantRepo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list participants: %w", err)
+	}
+
+	results := make([]*ParticipantResponse, 0, len(participants))
+	for _, p := range participants {
+		results = append(results, mapParticipantToResponse(p))
+	}
+
+	return results, &PaginationMeta{
+		Page:       req.Page,
+		PerPage:    req.PerPage,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PerPage))),
+	}, nil
+}
+
+func (r *batchReader) GetParticipantsByIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*ParticipantResponse, error) {
+	participants, err := r.ownedParticipants(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[uuid.UUID]*ParticipantResponse, len(participants))
+	for _, p := range participants {
+		results[p.ID] = mapParticipantToResponse(p)
+	}
+	return results, nil
+}
+
+// ownedParticipants loads the requested participants and drops those of
+// another tenant or product, so a guessed ID reads as not found.
+func (r *batchReader) ownedParticipants(ctx context.Context, req *BatchParticipantsRequest) ([]*entity.Participant, error) {
+	if len(req.ParticipantIDs) > MaxBatchParticipants {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("at most %d participants can be loaded at once", MaxBatchParticipants))
+	}
+	if len(req.ParticipantIDs) == 0 {
+		return nil, nil
+	}
+
+	participants, err := r.participantRepo.GetByIDs(ctx, req.ParticipantIDs)
+	if err != nil {
+		return nil, fmt.Errorf("load participants: %w", err)
+	}
+
+	owned := participants[:0]
+	for _, p := range participants {
+		if ValidateParticipantOwnership(p, req.TenantID, req.ProductID) == nil {
+			owned = append(owned, p)
+		}
+	}
+	return owned, nil
+}
+
+func (r *batchReader) ownedParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) ([]uuid.UUID, error) {
+	participants, err := r.ownedParticipants(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, p.ID)
+	}
+	return ids, nil
+}
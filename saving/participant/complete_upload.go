@@ -0,0 +1,192 @@
+package participant
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const sealedObjectSuffix = ".enc"
+
+var allowedUploadContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"application/pdf": true,
+}
+
+func (uc *usecase) CompleteUpload(ctx context.Context, req *CompleteUploadRequest) (*FileUploadResponse, error) {
+	slot, err := uc.uploadSlotRepo.GetByID(ctx, req.UploadID)
+	if err != nil {
+		return nil, fmt.Errorf("get upload slot: %w", err)
+	}
+
+	if slot.TenantID != req.TenantID || slot.ProductID != req.ProductID || slot.ParticipantID != req.ParticipantID {
+		return nil, errors.ErrNotFound("upload slot not found")
+	}
+
+	if slot.IsCompleted() {
+		return &FileUploadResponse{FileID: *slot.FileID}, nil
+	}
+
+	if slot.IsExpired(time.Now()) {
+		return nil, errors.ErrBadRequest("upload slot has expired; request a new one")
+	}
+
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+
+	if err := validateUploadTarget(participant, slot.FieldName); err != nil {
+		return nil, err
+	}
+
+	if err := uc.uploadSlotRepo.ClaimCompletion(ctx, slot.ID); err != nil {
+		return nil, fmt.Errorf("claim upload slot: %w", err)
+	}
+
+	resp, err := uc.completeClaimedUpload(ctx, slot, req.UploadedBy)
+	if err != nil {
+		if relErr := uc.uploadSlotRepo.ReleaseCompletion(ctx, slot.ID); relErr != nil {
+			uc.logger.Warn("failed to release upload slot after a failed completion",
+				zap.String("upload_id", slot.ID.String()),
+				zap.Error(relErr),
+			)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// completeClaimedUpload verifies, seals and records the object behind a slot
+// this request holds. The content type is sniffed from the same stream that
+// is sealed, so the object cannot be swapped between the check and the copy.
+func (uc *usecase) completeClaimedUpload(ctx context.Context, slot *entity.UploadSlot, uploadedBy uuid.UUID) (*FileUploadResponse, error) {
+	size, err := uc.fileStorage.StatObject(ctx, slot.Bucket, slot.StorageKey)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrBadRequest("file has not been uploaded yet")
+		}
+		return nil, fmt.Errorf("stat uploaded object: %w", err)
+	}
+
+	if size <= 0 || size > slot.MaxSizeBytes {
+		uc.discardUploadedObject(ctx, slot)
+		return nil, errors.ErrBadRequest(fmt.Sprintf("uploaded file size %d does not match the declared limit of %d bytes", size, slot.MaxSizeBytes))
+	}
+
+	body, err := uc.fileStorage.GetObject(ctx, slot.Bucket, slot.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("read uploaded object: %w", err)
+	}
+	defer body.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("read uploaded object: %w", err)
+	}
+	head = head[:n]
+
+	detectedType := http.DetectContentType(head)
+	if !allowedUploadContentTypes[detectedType] {
+		uc.discardUploadedObject(ctx, slot)
+		return nil, errors.ErrBadRequest("file content does not match an allowed type; allowed: jpeg, png, gif, pdf")
+	}
+	if detectedType != slot.ContentType {
+		uc.discardUploadedObject(ctx, slot)
+		return nil, errors.ErrBadRequest(fmt.Sprintf("file content is %s but the upload was requested as %s", detectedType, slot.ContentType))
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), io.LimitReader(body, size-int64(n)))
+	sealedKey, env, err := uc.sealDirectUpload(ctx, slot, content, size)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	documentType := SanitizeFieldName(slot.FieldName)
+	file := &entity.File{
+		TenantID:      slot.TenantID,
+		ProductID:     slot.ProductID,
+		ParticipantID: &slot.ParticipantID,
+		UploadedBy:    uploadedBy,
+		Bucket:        slot.Bucket,
+		StorageKey:    sealedKey,
+		OriginalName:  slot.OriginalName,
+		ContentType:   detectedType,
+		SizeBytes:     size,
+		ScanStatus:    entity.FileScanStatusPending,
+		ExpiresAt:     &expiresAt,
+		DocumentType:  &documentType,
+	}
+	file.SetEnvelope(env)
+
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.fileRepo.Create(txCtx, file); err != nil {
+			return fmt.Errorf("persist file metadata: %w", err)
+		}
+		if err := uc.uploadSlotRepo.Complete(txCtx, slot.ID, file.ID); err != nil {
+			return fmt.Errorf("complete upload slot: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		uc.deleteObjectQuietly(ctx, slot.Bucket, sealedKey)
+		return nil, err
+	}
+
+	// The plaintext the client posted is no longer needed once the
+	// encrypted copy is recorded.
+	uc.deleteObjectQuietly(ctx, slot.Bucket, slot.StorageKey)
+
+	return &FileUploadResponse{
+		FileID: file.ID,
+	}, nil
+}
+
+// sealDirectUpload re-encrypts an object posted straight to storage, which
+// arrives in plaintext, under a new key next to the original. Each attempt
+// gets its own key so a failed attempt's cleanup never removes another
+// attempt's copy.
+func (uc *usecase) sealDirectUpload(ctx context.Context, slot *entity.UploadSlot, content io.Reader, size int64) (string, *envelope.Envelope, error) {
+	sealedKey := slot.StorageKey + "." + uuid.NewString() + sealedObjectSuffix
+	env, err := uc.fileStorage.UploadEncryptedFile(ctx, slot.TenantID, slot.Bucket, sealedKey, content, size)
+	if err != nil {
+		return "", nil, fmt.Errorf("encrypt uploaded object: %w", err)
+	}
+	return sealedKey, env, nil
+}
+
+func (uc *usecase) deleteObjectQuietly(ctx context.Context, bucket, key string) {
+	if err := uc.fileStorage.DeleteFile(ctx, bucket, key); err != nil {
+		uc.logger.Warn("failed to delete storage object",
+			zap.String("bucket", bucket),
+			zap.String("storage_key", key),
+			zap.Error(err),
+		)
+	}
+}
+
+// discardUploadedObject removes an object that failed verification. The slot
+// itself is left for the cleanup worker, which also retries the delete.
+func (uc *usecase) discardUploadedObject(ctx context.Context, slot *entity.UploadSlot) {
+	if err := uc.fileStorage.DeleteFile(ctx, slot.Bucket, slot.StorageKey); err != nil {
+		uc.logger.Warn("failed to delete rejected upload",
+			zap.String("upload_id", slot.ID.String()),
+			zap.String("storage_key", slot.StorageKey),
+			zap.Error(err),
+		)
+	}
+}
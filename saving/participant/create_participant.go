@@ -11,6 +11,7 @@ import (
 
 func (uc *usecase) CreateParticipant(ctx context.Context, req *CreateParticipantRequest) (*ParticipantResponse, error) {
 	var result *ParticipantResponse
+	var saved *entity.Participant
 
 	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 
@@ -77,6 +78,7 @@ func (uc *usecase) CreateParticipant(ctx context.Context, req *CreateParticipant
 			return fmt.Errorf("build response: %w", err)
 		}
 		result = resp
+		saved = participant
 		return nil
 	})
 
@@ -84,5 +86,7 @@ func (uc *usecase) CreateParticipant(ctx context.Context, req *CreateParticipant
 		return nil, err
 	}
 
+	result.DuplicateWarnings = uc.detectDuplicates(ctx, saved)
+
 	return result, nil
 }
@@ -0,0 +1,180 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"erp-service/entity"
+
+	"go.uber.org/zap"
+)
+
+const (
+	duplicateCandidateLimit = 20
+	duplicatePhoneSuffixLen = 9
+	duplicateNamePrefixLen  = 4
+)
+
+// detectDuplicates scores p against likely matches and records every pair
+// above the threshold in the review queue. It must run outside the caller's
+// transaction: detection is advisory and a failing query here must not abort
+// the write that triggered it.
+func (uc *usecase) detectDuplicates(ctx context.Context, p *entity.Participant) []DuplicateMatchResponse {
+	matches, err := uc.scoreDuplicates(ctx, p, false)
+	if err != nil {
+		uc.logger.Warn("duplicate detection failed",
+			zap.String("participant_id", p.ID.String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return matches
+}
+
+func (uc *usecase) scoreDuplicates(ctx context.Context, p *entity.Participant, crossTenant bool) ([]DuplicateMatchResponse, error) {
+	identities, err := uc.identityRepo.ListByParticipantID(ctx, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list identities: %w", err)
+	}
+	accounts, err := uc.bankAccountRepo.ListByParticipantID(ctx, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list bank accounts: %w", err)
+	}
+
+	subject := BuildDuplicateProfile(p, identities, accounts)
+	criteria := buildDuplicateSearchCriteria(p, subject, identities, accounts)
+	if !crossTenant {
+		criteria.TenantID = &p.TenantID
+		criteria.ProductID = &p.ProductID
+	}
+
+	candidates, err := uc.duplicateRepo.FindCandidates(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("find duplicate candidates: %w", err)
+	}
+
+	matches := make([]DuplicateMatchResponse, 0)
+	for _, candidate := range candidates {
+		candIdentities, err := uc.identityRepo.ListByParticipantID(ctx, candidate.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list candidate identities: %w", err)
+		}
+		candAccounts, err := uc.bankAccountRepo.ListByParticipantID(ctx, candidate.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list candidate bank accounts: %w", err)
+		}
+
+		score := ScoreDuplicate(subject, BuildDuplicateProfile(candidate, candIdentities, candAccounts))
+		if !score.IsLikelyDuplicate() {
+			continue
+		}
+
+		if err := uc.duplicateRepo.UpsertCandidate(ctx, &entity.ParticipantDuplicateCandidate{
+			TenantID:             p.TenantID,
+			ProductID:            p.ProductID,
+			ParticipantID:        p.ID,
+			MatchedParticipantID: candidate.ID,
+			MatchedTenantID:      candidate.TenantID,
+			Score:                score.Score,
+			MatchedFields:        score.MatchedFields,
+		}); err != nil {
+			return nil, fmt.Errorf("record duplicate candidate: %w", err)
+		}
+
+		matches = append(matches, DuplicateMatchResponse{
+			ParticipantID: candidate.ID,
+			TenantID:      candidate.TenantID,
+			ProductID:     candidate.ProductID,
+			FullName:      candidate.FullName,
+			Status:        string(candidate.Status),
+			Score:         score.Score,
+			MatchedFields: score.MatchedFields,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches, nil
+}
+
+func buildDuplicateSearchCriteria(p *entity.Participant, subject DuplicateProfile,
+	identities []*entity.ParticipantIdentity, accounts []*entity.ParticipantBankAccount) *DuplicateSearchCriteria {
+	criteria := &DuplicateSearchCriteria{
+		ExcludeParticipantID: p.ID,
+		DateOfBirth:          p.DateOfBirth,
+		Limit:                duplicateCandidateLimit,
+	}
+
+	identityNumbers := append([]string{}, subject.IdentityNumbers...)
+	if p.KTPNumber != nil && *p.KTPNumber != "" {
+		identityNumbers = append(identityNumbers, *p.KTPNumber)
+	}
+	for _, identity := range identities {
+		identityNumbers = append(identityNumbers, identity.IdentityNumber)
+	}
+	criteria.IdentityNumbers = uniqueNonEmpty(identityNumbers)
+
+	if phone := NormalizePhone(subject.PhoneNumber); len(phone) >= duplicatePhoneSuffixLen {
+		criteria.PhoneSuffix = phone[len(phone)-duplicatePhoneSuffixLen:]
+	}
+
+	// Block on the start of the first name token so typos later in the name
+	// still reach the scorer.
+	if tokens := strings.Fields(NormalizeName(p.FullName)); len(tokens) > 0 {
+		first := []rune(tokens[0])
+		if len(first) >= duplicateNamePrefixLen {
+			criteria.NamePrefix = string(first[:duplicateNamePrefixLen])
+		}
+	}
+
+	accountNumbers := make([]string, 0, len(accounts)*2)
+	for _, account := range accounts {
+		accountNumbers = append(accountNumbers, account.AccountNumber, NormalizeIdentifier(account.AccountNumber))
+	}
+	criteria.BankAccountNumbers = uniqueNonEmpty(accountNumbers)
+
+	return criteria
+}
+
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+func mapDuplicateCandidateToResponse(c *entity.ParticipantDuplicateCandidate) DuplicateCandidateResponse {
+	matchedFields := c.MatchedFields
+	if matchedFields == nil {
+		matchedFields = []string{}
+	}
+	return DuplicateCandidateResponse{
+		ID:                   c.ID,
+		TenantID:             c.TenantID,
+		ProductID:            c.ProductID,
+		ParticipantID:        c.ParticipantID,
+		MatchedParticipantID: c.MatchedParticipantID,
+		MatchedTenantID:      c.MatchedTenantID,
+		Score:                c.Score,
+		MatchedFields:        matchedFields,
+		Status:               string(c.Status),
+		ReviewedBy:           c.ReviewedBy,
+		ReviewedAt:           c.ReviewedAt,
+		ReviewNote:           c.ReviewNote,
+		Version:              c.Version,
+		CreatedAt:            c.CreatedAt,
+		UpdatedAt:            c.UpdatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) DismissDuplicateCandidate(ctx context.Context, req *DismissDuplicateCandidateRequest) (*DuplicateCandidateResponse, error) {
+	candidate, err := uc.duplicateRepo.GetCandidateByID(ctx, req.CandidateID)
+	if err != nil {
+		return nil, fmt.Errorf("get duplicate candidate: %w", err)
+	}
+
+	if !req.AllTenants && (candidate.TenantID != req.TenantID || candidate.ProductID != req.ProductID) {
+		return nil, errors.ErrForbidden("access denied to this duplicate candidate")
+	}
+
+	if !candidate.IsPending() {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("duplicate candidate in %s status cannot be dismissed", candidate.Status))
+	}
+
+	now := time.Now()
+	reason := req.Reason
+	candidate.Status = entity.DuplicateCandidateStatusDismissed
+	candidate.ReviewedBy = &req.UserID
+	candidate.ReviewedAt = &now
+	candidate.ReviewNote = &reason
+
+	if err := uc.duplicateRepo.UpdateCandidate(ctx, candidate); err != nil {
+		return nil, fmt.Errorf("update duplicate candidate: %w", err)
+	}
+
+	resp := mapDuplicateCandidateToResponse(candidate)
+	return &resp, nil
+}
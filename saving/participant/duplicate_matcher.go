@@ -0,0 +1,273 @@
+package participant
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DuplicateFieldFullName       = "full_name"
+	DuplicateFieldDateOfBirth    = "date_of_birth"
+	DuplicateFieldPhoneNumber    = "phone_number"
+	DuplicateFieldIdentityNumber = "identity_number"
+	DuplicateFieldBankAccount    = "bank_account"
+)
+
+const (
+	duplicateWeightName        = 0.35
+	duplicateWeightDateOfBirth = 0.20
+	duplicateWeightPhone       = 0.10
+	duplicateWeightIdentity    = 0.25
+	duplicateWeightBankAccount = 0.10
+
+	duplicateNameMinSimilarity = 0.85
+	DuplicateScoreThreshold    = 0.5
+)
+
+var nameHonorifics = map[string]bool{
+	"dr": true, "drs": true, "dra": true, "ir": true, "h": true, "hj": true,
+	"prof": true, "bpk": true, "ibu": true, "sdr": true, "sdri": true,
+	"st": true, "se": true, "sh": true, "mm": true, "skom": true, "spd": true,
+	"amd": true, "mt": true, "msi": true, "ssi": true, "sked": true,
+}
+
+type DuplicateProfile struct {
+	ParticipantID   uuid.UUID
+	TenantID        uuid.UUID
+	FullName        string
+	DateOfBirth     *time.Time
+	PhoneNumber     string
+	IdentityNumbers []string
+	BankAccounts    []string
+}
+
+type DuplicateScore struct {
+	Score         float64
+	MatchedFields []string
+}
+
+func (s DuplicateScore) IsLikelyDuplicate() bool {
+	return s.Score >= DuplicateScoreThreshold
+}
+
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsSpace(r):
+			b.WriteRune(r)
+		case r == ',' || r == '-':
+			b.WriteRune(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	kept := tokens[:0]
+	for _, t := range tokens {
+		if !nameHonorifics[t] {
+			kept = append(kept, t)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	switch {
+	case strings.HasPrefix(digits, "62"):
+		digits = "0" + digits[2:]
+	case digits != "" && !strings.HasPrefix(digits, "0"):
+		digits = "0" + digits
+	}
+	return digits
+}
+
+func NormalizeIdentifier(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func BankAccountKey(bankCode, accountNumber string) string {
+	return NormalizeIdentifier(bankCode) + ":" + NormalizeIdentifier(accountNumber)
+}
+
+// NameSimilarity compares two already-normalised names with Jaro-Winkler,
+// taking the better of the raw and token-sorted forms so that reordered
+// given/family names still match.
+func NameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	direct := jaroWinkler(a, b)
+	sorted := jaroWinkler(sortTokens(a), sortTokens(b))
+	if sorted > direct {
+		return sorted
+	}
+	return direct
+}
+
+func ScoreDuplicate(subject, candidate DuplicateProfile) DuplicateScore {
+	var result DuplicateScore
+
+	if sim := NameSimilarity(NormalizeName(subject.FullName), NormalizeName(candidate.FullName)); sim >= duplicateNameMinSimilarity {
+		result.Score += duplicateWeightName * sim
+		result.MatchedFields = append(result.MatchedFields, DuplicateFieldFullName)
+	}
+
+	if subject.DateOfBirth != nil && candidate.DateOfBirth != nil && sameDate(*subject.DateOfBirth, *candidate.DateOfBirth) {
+		result.Score += duplicateWeightDateOfBirth
+		result.MatchedFields = append(result.MatchedFields, DuplicateFieldDateOfBirth)
+	}
+
+	if p := NormalizePhone(subject.PhoneNumber); p != "" && p == NormalizePhone(candidate.PhoneNumber) {
+		result.Score += duplicateWeightPhone
+		result.MatchedFields = append(result.MatchedFields, DuplicateFieldPhoneNumber)
+	}
+
+	if intersects(subject.IdentityNumbers, candidate.IdentityNumbers) {
+		result.Score += duplicateWeightIdentity
+		result.MatchedFields = append(result.MatchedFields, DuplicateFieldIdentityNumber)
+	}
+
+	if intersects(subject.BankAccounts, candidate.BankAccounts) {
+		result.Score += duplicateWeightBankAccount
+		result.MatchedFields = append(result.MatchedFields, DuplicateFieldBankAccount)
+	}
+
+	if result.Score > 1 {
+		result.Score = 1
+	}
+	return result
+}
+
+func BuildDuplicateProfile(p *entity.Participant, identities []*entity.ParticipantIdentity, accounts []*entity.ParticipantBankAccount) DuplicateProfile {
+	profile := DuplicateProfile{
+		ParticipantID: p.ID,
+		TenantID:      p.TenantID,
+		FullName:      p.FullName,
+		DateOfBirth:   p.DateOfBirth,
+	}
+	if p.PhoneNumber != nil {
+		profile.PhoneNumber = *p.PhoneNumber
+	}
+	if p.KTPNumber != nil && *p.KTPNumber != "" {
+		profile.IdentityNumbers = append(profile.IdentityNumbers, NormalizeIdentifier(*p.KTPNumber))
+	}
+	for _, identity := range identities {
+		if n := NormalizeIdentifier(identity.IdentityNumber); n != "" {
+			profile.IdentityNumbers = append(profile.IdentityNumbers, n)
+		}
+	}
+	for _, account := range accounts {
+		profile.BankAccounts = append(profile.BankAccounts, BankAccountKey(account.BankCode, account.AccountNumber))
+	}
+	return profile
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func intersects(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		if v != "" {
+			set[v] = struct{}{}
+		}
+	}
+	for _, v := range b {
+		if _, ok := set[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func sortTokens(s string) string {
+	tokens := strings.Fields(s)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		lo := max(0, i-window)
+		hi := min(len(rb), i+window+1)
+		for j := lo; j < hi; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for i := 0; i < min(4, len(ra), len(rb)); i++ {
+		if ra[i] != rb[i] {
+			break
+		}
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,42 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EventPublisher pushes workflow events to connected clients. It is optional;
+// without one, transitions are not announced.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event *notify.Event) error
+}
+
+func newStatusEvent(topic notify.Topic, eventType string, participant *entity.Participant, fromStatus string, changedBy uuid.UUID) *notify.Event {
+	event, _ := notify.NewEvent(topic, eventType, participant.TenantID, participant.ProductID, notify.ParticipantStatusData{
+		ParticipantID: participant.ID,
+		FromStatus:    fromStatus,
+		ToStatus:      string(participant.Status),
+		ChangedBy:     changedBy,
+	})
+	return event
+}
+
+// publish announces a committed transition. Delivery is best effort: the
+// change is already durable, so a failure is logged and not returned.
+func (uc *usecase) publish(ctx context.Context, event *notify.Event) {
+	if uc.events == nil || event == nil {
+		return
+	}
+	if err := uc.events.PublishEvent(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish participant event",
+			zap.String("type", event.Type),
+			zap.String("tenant_id", event.TenantID.String()),
+			zap.Error(err),
+		)
+	}
+}
@@ -4,10 +4,33 @@ import (
 	"context"
 	"io"
 	"time"
+
+	"erp-service/pkg/envelope"
+
+	"github.com/google/uuid"
 )
 
+// PresignedUpload is a browser-form POST policy: the client posts FormData
+// plus the file to URL.
+type PresignedUpload struct {
+	URL      string
+	FormData map[string]string
+}
+
 type FileStorageAdapter interface {
 	UploadFile(ctx context.Context, bucket, objectKey string, data io.Reader, size int64, contentType string) (string, error)
 	DeleteFile(ctx context.Context, bucket, objectKey string) error
 	GetPresignedURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error)
+	GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error)
+	CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error
+	PresignUpload(ctx context.Context, bucket, objectKey, contentType string, maxSize int64, expiry time.Duration) (*PresignedUpload, error)
+	// StatObject returns the stored size of the object, or a not-found error
+	// when nothing was uploaded under the key.
+	StatObject(ctx context.Context, bucket, objectKey string) (int64, error)
+	// UploadEncryptedFile stores data encrypted under a new data key wrapped
+	// by the tenant's key; the returned envelope must be saved on the file.
+	UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error)
+	// GetDecryptedObject streams the plaintext of an object; a nil envelope
+	// reads an object stored before encryption as-is.
+	GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error)
 }
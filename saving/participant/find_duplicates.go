@@ -0,0 +1,23 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+)
+
+func (uc *usecase) FindDuplicates(ctx context.Context, req *FindDuplicatesRequest) ([]DuplicateMatchResponse, error) {
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+
+	if err := ValidateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	matches, err := uc.scoreDuplicates(ctx, participant, req.CrossTenant)
+	if err != nil {
+		return nil, fmt.Errorf("score duplicates: %w", err)
+	}
+	return matches, nil
+}
@@ -2,7 +2,12 @@ package participant
 
 import (
 	"context"
+	"fmt"
 
+	"erp-service/saving/fileaccess"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 func (uc *usecase) GetParticipant(ctx context.Context, req *GetParticipantRequest) (*ParticipantResponse, error) {
@@ -15,5 +20,69 @@ func (uc *usecase) GetParticipant(ctx context.Context, req *GetParticipantReques
 		return nil, err
 	}
 
-	return uc.buildFullParticipantResponse(ctx, participant, true)
+	resp, err := uc.buildFullParticipantResponse(ctx, participant, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.FilePreviewURLs = buildFilePreviewURLs(resp)
+	resp.FileThumbnailURLs = uc.buildFileThumbnailURLs(ctx, resp.FilePreviewURLs)
+	return resp, nil
+}
+
+// buildFilePreviewURLs maps every file ID attached to the participant to the
+// endpoint that serves its content.
+func buildFilePreviewURLs(resp *ParticipantResponse) map[string]string {
+	urls := make(map[string]string)
+	add := func(id *uuid.UUID) {
+		if id != nil {
+			urls[id.String()] = fmt.Sprintf(fileaccess.ContentPath, *id)
+		}
+	}
+
+	for _, identity := range resp.Identities {
+		add(identity.PhotoFileID)
+	}
+	for _, member := range resp.FamilyMembers {
+		add(member.SupportingDocFileID)
+	}
+	for _, beneficiary := range resp.Beneficiaries {
+		add(beneficiary.IdentityPhotoFileID)
+		add(beneficiary.FamilyCardPhotoFileID)
+		add(beneficiary.BankBookPhotoFileID)
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+	return urls
+}
+
+// buildFileThumbnailURLs lists the thumbnail endpoint for every attached file
+// that has finished image processing. Lookup failures only drop the
+// thumbnail; reviewers can still open the original.
+func (uc *usecase) buildFileThumbnailURLs(ctx context.Context, previewURLs map[string]string) map[string]string {
+	urls := make(map[string]string)
+	for key := range previewURLs {
+		id, err := uuid.Parse(key)
+		if err != nil {
+			continue
+		}
+		file, err := uc.fileRepo.GetByID(ctx, id)
+		if err != nil {
+			uc.logger.Warn("failed to load file for thumbnail",
+				zap.String("file_id", key),
+				zap.Error(err),
+			)
+			continue
+		}
+		if file.ThumbnailFileID != nil {
+			urls[key] = fmt.Sprintf(fileaccess.ThumbnailPath, file.ID)
+		}
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+	return urls
 }
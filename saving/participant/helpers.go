@@ -30,6 +30,21 @@ func ValidateEditableState(participant *entity.Participant) error {
 	return nil
 }
 
+// ValidateFileAttachable only lets files the malware scanner has cleared be
+// attached; quarantined, unscannable and not yet scanned files are rejected.
+func ValidateFileAttachable(file *entity.File) error {
+	if file.IsInfected() {
+		return errors.ErrBadRequest(fmt.Sprintf("file %s failed malware scanning and has been quarantined", file.ID))
+	}
+	if file.IsScanFailed() {
+		return errors.ErrBadRequest(fmt.Sprintf("file %s could not be scanned for malware; upload it again", file.ID))
+	}
+	if !file.IsClean() {
+		return errors.ErrBadRequest(fmt.Sprintf("file %s is still being scanned; try again shortly", file.ID))
+	}
+	return nil
+}
+
 var allowedFieldNames = map[string]bool{
 	"ktp_photo":       true,
 	"passport_photo":  true,
@@ -38,6 +53,32 @@ var allowedFieldNames = map[string]bool{
 	"bank_book_photo": true,
 	"supporting_doc":  true,
 	"profile_photo":   true,
+
+	"death_certificate":  true,
+	"termination_letter": true,
+	"retirement_letter":  true,
+	"transfer_letter":    true,
+}
+
+// lifecycleDocumentFields are uploaded after enrolment to back a status
+// transition, so they bypass the draft-only edit check.
+var lifecycleDocumentFields = map[string]bool{
+	"death_certificate":  true,
+	"termination_letter": true,
+	"retirement_letter":  true,
+	"transfer_letter":    true,
+}
+
+// validateUploadTarget checks the participant can receive a file for the
+// field: lifecycle documents once enrolled, anything else while editable.
+func validateUploadTarget(participant *entity.Participant, fieldName string) error {
+	if lifecycleDocumentFields[SanitizeFieldName(fieldName)] {
+		if !participant.IsEnrolled() {
+			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot receive lifecycle documents", participant.Status))
+		}
+		return nil
+	}
+	return ValidateEditableState(participant)
 }
 
 func SanitizeFieldName(fieldName string) string {
@@ -112,9 +153,17 @@ func mapBankAccountToResponse(account *entity.ParticipantBankAccount) BankAccoun
 		IsPrimary:         account.IsPrimary,
 		IssueDate:         account.IssueDate,
 		ExpiryDate:        account.ExpiryDate,
-		Version:           account.Version,
-		CreatedAt:         account.CreatedAt,
-		UpdatedAt:         account.UpdatedAt,
+
+		VerificationStatus:    string(account.VerificationStatus),
+		VerificationProvider:  account.VerificationProvider,
+		VerifiedHolderName:    account.VerifiedHolderName,
+		VerificationScore:     account.VerificationScore,
+		VerificationMessage:   account.VerificationMessage,
+		VerificationCheckedAt: account.VerificationCheckedAt,
+
+		Version:   account.Version,
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
 	}
 }
 
@@ -182,6 +231,7 @@ func mapBeneficiaryToResponse(beneficiary *entity.ParticipantBeneficiary) Benefi
 		FamilyCardPhotoFileID:   beneficiary.FamilyCardPhotoFileID,
 		BankBookPhotoFilePath:   beneficiary.BankBookPhotoFilePath,
 		BankBookPhotoFileID:     beneficiary.BankBookPhotoFileID,
+		BankCode:                beneficiary.BankCode,
 		AccountNumber:           beneficiary.AccountNumber,
 		Version:                 beneficiary.Version,
 		CreatedAt:               beneficiary.CreatedAt,
@@ -198,6 +248,10 @@ func mapStatusHistoryToResponse(history *entity.ParticipantStatusHistory) Status
 		Reason:     history.Reason,
 		ChangedAt:  history.ChangedAt,
 		CreatedAt:  history.CreatedAt,
+
+		EffectiveDate:    history.EffectiveDate,
+		SupportingFileID: history.SupportingFileID,
+		Details:          history.Details,
 	}
 }
 
@@ -216,8 +270,8 @@ func BuildStepsCompleted(m map[string]bool) StepsCompleted {
 	}
 }
 
-func (uc *usecase) buildFullParticipantResponse(ctx context.Context, participant *entity.Participant, concurrent bool) (*ParticipantResponse, error) {
-	resp := &ParticipantResponse{
+func mapParticipantToResponse(participant *entity.Participant) *ParticipantResponse {
+	return &ParticipantResponse{
 		ID:              participant.ID,
 		TenantID:        participant.TenantID,
 		ProductID:       participant.ProductID,
@@ -245,7 +299,13 @@ func (uc *usecase) buildFullParticipantResponse(ctx context.Context, participant
 		Version:         participant.Version,
 		CreatedAt:       participant.CreatedAt,
 		UpdatedAt:       participant.UpdatedAt,
+
+		StatusEffectiveDate: participant.StatusEffectiveDate,
 	}
+}
+
+func (uc *usecase) buildFullParticipantResponse(ctx context.Context, participant *entity.Participant, concurrent bool) (*ParticipantResponse, error) {
+	resp := mapParticipantToResponse(participant)
 
 	var (
 		identities    []*entity.ParticipantIdentity
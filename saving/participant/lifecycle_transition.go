@@ -0,0 +1,137 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+type lifecycleTransition struct {
+	tenantID         uuid.UUID
+	productID        uuid.UUID
+	participantID    uuid.UUID
+	userID           uuid.UUID
+	to               entity.ParticipantStatus
+	action           string
+	guard            func(*entity.Participant) bool
+	validate         func(*entity.Participant) error
+	effectiveDate    time.Time
+	reason           *string
+	supportingFileID *uuid.UUID
+	documentType     string
+	details          map[string]string
+}
+
+// transitionLifecycle applies a post-approval status change. Every transition
+// is dated by the business event (not by when it was keyed in) and recorded in
+// participant_status_history together with its mandatory data.
+func (uc *usecase) transitionLifecycle(ctx context.Context, t lifecycleTransition) (*ParticipantResponse, error) {
+	effectiveDate := truncateToDate(t.effectiveDate)
+	if effectiveDate.After(truncateToDate(time.Now())) {
+		return nil, errors.ErrBadRequest("effective date cannot be in the future")
+	}
+
+	var result *ParticipantResponse
+	var event *notify.Event
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		participant, err := uc.participantRepo.GetByID(txCtx, t.participantID)
+		if err != nil {
+			return fmt.Errorf("get participant: %w", err)
+		}
+
+		if err := ValidateParticipantOwnership(participant, t.tenantID, t.productID); err != nil {
+			return err
+		}
+
+		if !t.guard(participant) {
+			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot be %s", participant.Status, t.action))
+		}
+
+		if participant.StatusEffectiveDate != nil && effectiveDate.Before(truncateToDate(*participant.StatusEffectiveDate)) {
+			return errors.ErrBadRequest("effective date cannot precede the current status effective date")
+		}
+
+		if t.validate != nil {
+			if err := t.validate(participant); err != nil {
+				return err
+			}
+		}
+
+		if t.supportingFileID != nil {
+			file, err := uc.fileRepo.GetByID(txCtx, *t.supportingFileID)
+			if err != nil {
+				return fmt.Errorf("get supporting file: %w", err)
+			}
+			if file.TenantID != t.tenantID || file.ProductID != t.productID {
+				return errors.ErrForbidden("supporting_file_id does not belong to this tenant/product")
+			}
+			if file.ParticipantID == nil || *file.ParticipantID != participant.ID {
+				return errors.ErrForbidden("supporting_file_id does not belong to this participant")
+			}
+			if file.DocumentType == nil || *file.DocumentType != t.documentType {
+				return errors.ErrBadRequest(fmt.Sprintf("supporting_file_id must be a %s", t.documentType))
+			}
+			if err := ValidateFileAttachable(file); err != nil {
+				return err
+			}
+			if err := uc.fileRepo.SetPermanent(txCtx, *t.supportingFileID); err != nil {
+				return fmt.Errorf("set supporting file permanent: %w", err)
+			}
+		}
+
+		now := time.Now()
+		fromStatus := string(participant.Status)
+
+		participant.Status = t.to
+		participant.StatusEffectiveDate = &effectiveDate
+
+		if err := uc.participantRepo.Update(txCtx, participant); err != nil {
+			return fmt.Errorf("update participant: %w", err)
+		}
+
+		history := &entity.ParticipantStatusHistory{
+			ParticipantID:    participant.ID,
+			FromStatus:       &fromStatus,
+			ToStatus:         string(t.to),
+			ChangedBy:        t.userID,
+			Reason:           t.reason,
+			ChangedAt:        now,
+			EffectiveDate:    &effectiveDate,
+			SupportingFileID: t.supportingFileID,
+			Details:          t.details,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+
+		if err := uc.statusHistoryRepo.Create(txCtx, history); err != nil {
+			return fmt.Errorf("create status history: %w", err)
+		}
+
+		resp, err := uc.buildFullParticipantResponse(txCtx, participant, true)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		event = newStatusEvent(notify.TopicParticipantStatus, notify.ParticipantStatusChanged, participant, fromStatus, t.userID)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, event)
+	return result, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,41 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func (uc *usecase) ListDuplicateCandidates(ctx context.Context, req *ListDuplicateCandidatesRequest) (*ListDuplicateCandidatesResponse, error) {
+	filter := &DuplicateCandidateFilter{
+		Status:  req.Status,
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	}
+	if !req.AllTenants {
+		filter.TenantID = &req.TenantID
+		filter.ProductID = &req.ProductID
+	}
+
+	candidates, total, err := uc.duplicateRepo.ListCandidates(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list duplicate candidates: %w", err)
+	}
+
+	items := make([]DuplicateCandidateResponse, 0, len(candidates))
+	for _, c := range candidates {
+		items = append(items, mapDuplicateCandidateToResponse(c))
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.PerPage)))
+
+	return &ListDuplicateCandidatesResponse{
+		Candidates: items,
+		Pagination: PaginationMeta{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
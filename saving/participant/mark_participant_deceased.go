@@ -0,0 +1,31 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) MarkParticipantDeceased(ctx context.Context, req *MarkParticipantDeceasedRequest) (*ParticipantResponse, error) {
+	fileID := req.DeathCertificateFileID
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:      req.TenantID,
+		productID:     req.ProductID,
+		participantID: req.ParticipantID,
+		userID:        req.UserID,
+		to:            entity.ParticipantStatusDeceased,
+		action:        "marked deceased",
+		guard:         (*entity.Participant).CanBeMarkedDeceased,
+		validate: func(p *entity.Participant) error {
+			if p.DateOfBirth != nil && truncateToDate(req.DateOfDeath).Before(truncateToDate(*p.DateOfBirth)) {
+				return errors.ErrBadRequest("date of death cannot precede date of birth")
+			}
+			return nil
+		},
+		effectiveDate:    req.DateOfDeath,
+		reason:           req.Reason,
+		supportingFileID: &fileID,
+		documentType:     "death_certificate",
+	})
+}
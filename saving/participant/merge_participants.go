@@ -0,0 +1,145 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) MergeParticipants(ctx context.Context, req *MergeParticipantsRequest) (*ParticipantResponse, error) {
+	if req.ParticipantID == req.SourceParticipantID {
+		return nil, errors.ErrBadRequest("participant cannot be merged into itself")
+	}
+
+	var result *ParticipantResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		survivor, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("get participant: %w", err)
+		}
+		if err := ValidateParticipantOwnership(survivor, req.TenantID, req.ProductID); err != nil {
+			return err
+		}
+
+		source, err := uc.participantRepo.GetByID(txCtx, req.SourceParticipantID)
+		if err != nil {
+			return fmt.Errorf("get source participant: %w", err)
+		}
+		if err := ValidateParticipantOwnership(source, req.TenantID, req.ProductID); err != nil {
+			return err
+		}
+
+		if !survivor.CanAbsorbMerge() {
+			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot be merged into", survivor.Status))
+		}
+		if !source.CanBeMerged() {
+			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot be merged", source.Status))
+		}
+
+		hasFinancialRecords, err := uc.duplicateRepo.HasFinancialRecords(txCtx, source.ID)
+		if err != nil {
+			return fmt.Errorf("check source financial records: %w", err)
+		}
+		if hasFinancialRecords {
+			return errors.ErrBadRequest("participant with contributions or claims cannot be merged")
+		}
+
+		if err := uc.duplicateRepo.ReassignChildRecords(txCtx, source.ID, survivor.ID); err != nil {
+			return fmt.Errorf("reassign child records: %w", err)
+		}
+
+		if err := uc.duplicateRepo.MarkMerged(txCtx, source.ID, survivor.ID); err != nil {
+			return fmt.Errorf("mark participant merged: %w", err)
+		}
+
+		fillMissingPersonalData(survivor, source)
+
+		if err := uc.participantRepo.Update(txCtx, survivor); err != nil {
+			return fmt.Errorf("update participant: %w", err)
+		}
+
+		now := time.Now()
+		status := string(survivor.Status)
+		reason := fmt.Sprintf("merged participant %s: %s", source.ID, req.Reason)
+
+		history := &entity.ParticipantStatusHistory{
+			ParticipantID: survivor.ID,
+			FromStatus:    &status,
+			ToStatus:      status,
+			ChangedBy:     req.UserID,
+			Reason:        &reason,
+			ChangedAt:     now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		if err := uc.statusHistoryRepo.Create(txCtx, history); err != nil {
+			return fmt.Errorf("create status history: %w", err)
+		}
+
+		if err := uc.duplicateRepo.MarkPairMerged(txCtx, source.ID, survivor.ID, req.UserID); err != nil {
+			return fmt.Errorf("mark duplicate pair merged: %w", err)
+		}
+
+		resp, err := uc.buildFullParticipantResponse(txCtx, survivor, false)
+		if err != nil {
+			return fmt.Errorf("build response: %w", err)
+		}
+		result = resp
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func fillMissingPersonalData(survivor, source *entity.Participant) {
+	if survivor.UserID == nil {
+		survivor.UserID = source.UserID
+	}
+	if survivor.Gender == nil {
+		survivor.Gender = source.Gender
+	}
+	if survivor.PlaceOfBirth == nil {
+		survivor.PlaceOfBirth = source.PlaceOfBirth
+	}
+	if survivor.DateOfBirth == nil {
+		survivor.DateOfBirth = source.DateOfBirth
+	}
+	if survivor.MaritalStatus == nil {
+		survivor.MaritalStatus = source.MaritalStatus
+	}
+	if survivor.Citizenship == nil {
+		survivor.Citizenship = source.Citizenship
+	}
+	if survivor.Religion == nil {
+		survivor.Religion = source.Religion
+	}
+	if survivor.KTPNumber == nil {
+		survivor.KTPNumber = source.KTPNumber
+	}
+	if survivor.EmployeeNumber == nil {
+		survivor.EmployeeNumber = source.EmployeeNumber
+	}
+	if survivor.PhoneNumber == nil {
+		survivor.PhoneNumber = source.PhoneNumber
+	}
+
+	if len(source.StepsCompleted) > 0 {
+		if survivor.StepsCompleted == nil {
+			survivor.StepsCompleted = make(map[string]bool, len(source.StepsCompleted))
+		}
+		for step, done := range source.StepsCompleted {
+			if done {
+				survivor.StepsCompleted[step] = true
+			}
+		}
+	}
+}
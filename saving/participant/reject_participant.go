@@ -7,10 +7,12 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 )
 
 func (uc *usecase) RejectParticipant(ctx context.Context, req *RejectParticipantRequest) (*ParticipantResponse, error) {
 	var result *ParticipantResponse
+	var event *notify.Event
 
 	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		participant, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
@@ -58,6 +60,7 @@ func (uc *usecase) RejectParticipant(ctx context.Context, req *RejectParticipant
 			return fmt.Errorf("build response: %w", err)
 		}
 		result = resp
+		event = newStatusEvent(notify.TopicParticipantStatus, notify.ParticipantRejected, participant, fromStatus, req.UserID)
 		return nil
 	})
 
@@ -65,5 +68,6 @@ func (uc *usecase) RejectParticipant(ctx context.Context, req *RejectParticipant
 		return nil, err
 	}
 
+	uc.publish(ctx, event)
 	return result, nil
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"erp-service/entity"
+	"erp-service/pkg/envelope"
 
 	"github.com/google/uuid"
 )
@@ -23,6 +24,7 @@ type ParticipantFilter struct {
 type ParticipantRepository interface {
 	Create(ctx context.Context, participant *entity.Participant) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Participant, error)
 	Update(ctx context.Context, participant *entity.Participant) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filter *ParticipantFilter) ([]*entity.Participant, int64, error)
@@ -56,6 +58,7 @@ type ParticipantIdentityRepository interface {
 	Create(ctx context.Context, identity *entity.ParticipantIdentity) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantIdentity, error)
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantIdentity, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantIdentity, error)
 	Update(ctx context.Context, identity *entity.ParticipantIdentity) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
@@ -64,6 +67,7 @@ type ParticipantAddressRepository interface {
 	Create(ctx context.Context, address *entity.ParticipantAddress) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantAddress, error)
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantAddress, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantAddress, error)
 	Update(ctx context.Context, address *entity.ParticipantAddress) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	SoftDeleteAllByParticipantID(ctx context.Context, participantID uuid.UUID) error
@@ -73,6 +77,7 @@ type ParticipantBankAccountRepository interface {
 	Create(ctx context.Context, account *entity.ParticipantBankAccount) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBankAccount, error)
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBankAccount, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBankAccount, error)
 	Update(ctx context.Context, account *entity.ParticipantBankAccount) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	ClearPrimary(ctx context.Context, participantID uuid.UUID) error
@@ -82,6 +87,7 @@ type ParticipantFamilyMemberRepository interface {
 	Create(ctx context.Context, member *entity.ParticipantFamilyMember) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantFamilyMember, error)
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantFamilyMember, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantFamilyMember, error)
 	Update(ctx context.Context, member *entity.ParticipantFamilyMember) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	SoftDeleteAllByParticipantID(ctx context.Context, participantID uuid.UUID) error
@@ -91,6 +97,7 @@ type ParticipantEmploymentRepository interface {
 	Create(ctx context.Context, employment *entity.ParticipantEmployment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantEmployment, error)
 	GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantEmployment, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantEmployment, error)
 	Update(ctx context.Context, employment *entity.ParticipantEmployment) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
@@ -99,6 +106,7 @@ type ParticipantPensionRepository interface {
 	Create(ctx context.Context, pension *entity.ParticipantPension) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantPension, error)
 	GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantPension, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantPension, error)
 	Update(ctx context.Context, pension *entity.ParticipantPension) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
@@ -107,6 +115,7 @@ type ParticipantBeneficiaryRepository interface {
 	Create(ctx context.Context, beneficiary *entity.ParticipantBeneficiary) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBeneficiary, error)
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBeneficiary, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBeneficiary, error)
 	Update(ctx context.Context, beneficiary *entity.ParticipantBeneficiary) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	SoftDeleteAllByParticipantID(ctx context.Context, participantID uuid.UUID) error
@@ -122,9 +131,77 @@ type FileRepository interface {
 	ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error
 	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	ClaimPendingScan(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error)
+	ReleaseStaleScanClaimsOlderThan(ctx context.Context, age time.Duration) error
+	RecordScanFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error
+	MarkScanned(ctx context.Context, id uuid.UUID) error
+	MarkQuarantined(ctx context.Context, id uuid.UUID, bucket, storageKey, signature string) error
+	ClaimPendingProcessing(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error)
+	ReleaseStaleProcessClaimsOlderThan(ctx context.Context, age time.Duration) error
+	MarkProcessed(ctx context.Context, id uuid.UUID, storageKey, contentType string, sizeBytes int64, env *envelope.Envelope, thumbnailFileID uuid.UUID) error
+	MarkProcessingSkipped(ctx context.Context, id uuid.UUID) error
+	RecordProcessingFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error
+	ListDueForRewrap(ctx context.Context, rewrappedBefore time.Time, limit int) ([]*entity.File, error)
+	UpdateWrappedDataKey(ctx context.Context, id uuid.UUID, oldWrappedKey, newWrappedKey string) error
+	ClaimDueForPurge(ctx context.Context, limit int) ([]*entity.File, error)
+	ReleaseStalePurgeClaimsOlderThan(ctx context.Context, age time.Duration) error
+	ReleasePurgeClaim(ctx context.Context, id uuid.UUID) error
+	ListDerived(ctx context.Context, id uuid.UUID) ([]*entity.File, error)
+	Purge(ctx context.Context, id uuid.UUID) error
+	SetLegalHold(ctx context.Context, id uuid.UUID, hold bool, reason *string, setBy uuid.UUID) error
+}
+
+type UploadSlotRepository interface {
+	Create(ctx context.Context, slot *entity.UploadSlot) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.UploadSlot, error)
+	// ClaimCompletion marks an open slot as being completed. It fails with a
+	// conflict when another completion holds the slot or the slot is being
+	// reaped.
+	ClaimCompletion(ctx context.Context, id uuid.UUID) error
+	ReleaseCompletion(ctx context.Context, id uuid.UUID) error
+	Complete(ctx context.Context, id uuid.UUID, fileID uuid.UUID) error
+	ClaimExpired(ctx context.Context, limit int) ([]*entity.UploadSlot, error)
+	ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error
+	IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
 
 type ParticipantStatusHistoryRepository interface {
 	Create(ctx context.Context, history *entity.ParticipantStatusHistory) error
 	ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantStatusHistory, error)
+	ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantStatusHistory, error)
+}
+
+type DuplicateSearchCriteria struct {
+	ExcludeParticipantID uuid.UUID
+	TenantID             *uuid.UUID
+	ProductID            *uuid.UUID
+	IdentityNumbers      []string
+	PhoneSuffix          string
+	DateOfBirth          *time.Time
+	NamePrefix           string
+	BankAccountNumbers   []string
+	Limit                int
+}
+
+type DuplicateCandidateFilter struct {
+	TenantID  *uuid.UUID
+	ProductID *uuid.UUID
+	Status    *string
+	Page      int
+	PerPage   int
+}
+
+type ParticipantDuplicateRepository interface {
+	FindCandidates(ctx context.Context, criteria *DuplicateSearchCriteria) ([]*entity.Participant, error)
+	UpsertCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error
+	GetCandidateByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantDuplicateCandidate, error)
+	ListCandidates(ctx context.Context, filter *DuplicateCandidateFilter) ([]*entity.ParticipantDuplicateCandidate, int64, error)
+	UpdateCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error
+	MarkPairMerged(ctx context.Context, sourceID, targetID, reviewedBy uuid.UUID) error
+	// HasFinancialRecords reports whether contribution ledger entries or
+	// claims reference the participant; a merge does not move them.
+	HasFinancialRecords(ctx context.Context, participantID uuid.UUID) (bool, error)
+	ReassignChildRecords(ctx context.Context, sourceID, targetID uuid.UUID) error
+	MarkMerged(ctx context.Context, sourceID, targetID uuid.UUID) error
 }
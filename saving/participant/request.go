@@ -30,7 +30,7 @@ type UpdatePersonalDataRequest struct {
 	Religion       *string    `json:"religion,omitempty" validate:"omitempty,max=50"`
 	KTPNumber      *string    `json:"ktp_number,omitempty" validate:"omitempty,len=16,numeric"`
 	EmployeeNumber *string    `json:"employee_number,omitempty" validate:"omitempty,max=50"`
-	PhoneNumber    *string    `json:"phone_number,omitempty" validate:"omitempty,max=20"`
+	PhoneNumber    *string    `json:"phone_number,omitempty" validate:"omitempty,max=20,unmasked"`
 }
 
 type SaveIdentityRequest struct {
@@ -39,7 +39,7 @@ type SaveIdentityRequest struct {
 	ProductID         uuid.UUID  `json:"-"`
 	ParticipantID     uuid.UUID  `json:"-"`
 	IdentityType      string     `json:"identity_type" validate:"required,max=50"`
-	IdentityNumber    string     `json:"identity_number" validate:"required,max=100"`
+	IdentityNumber    string     `json:"identity_number" validate:"required,max=100,unmasked"`
 	IdentityAuthority *string    `json:"identity_authority,omitempty" validate:"omitempty,max=255"`
 	IssueDate         *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
@@ -70,7 +70,7 @@ type SaveBankAccountRequest struct {
 	ProductID         uuid.UUID  `json:"-"`
 	ParticipantID     uuid.UUID  `json:"-"`
 	BankCode          string     `json:"bank_code" validate:"required,max=10"`
-	AccountNumber     string     `json:"account_number" validate:"required,max=50"`
+	AccountNumber     string     `json:"account_number" validate:"required,max=50,unmasked"`
 	AccountHolderName string     `json:"account_holder_name" validate:"required,max=255"`
 	AccountType       *string    `json:"account_type,omitempty" validate:"omitempty,max=50"`
 	CurrencyCode      string     `json:"currency_code" validate:"required,len=3"`
@@ -135,7 +135,8 @@ type SaveBeneficiaryRequest struct {
 	IdentityPhotoFileID   *uuid.UUID `json:"identity_photo_file_id,omitempty"`
 	FamilyCardPhotoFileID *uuid.UUID `json:"family_card_photo_file_id,omitempty"`
 	BankBookPhotoFileID   *uuid.UUID `json:"bank_book_photo_file_id,omitempty"`
-	AccountNumber         *string    `json:"account_number,omitempty" validate:"omitempty,max=50"`
+	BankCode              *string    `json:"bank_code,omitempty" validate:"omitempty,max=10"`
+	AccountNumber         *string    `json:"account_number,omitempty" validate:"omitempty,max=50,unmasked"`
 }
 
 type AddressItem struct {
@@ -183,7 +184,8 @@ type BeneficiaryItem struct {
 	IdentityPhotoFileID   *uuid.UUID `json:"identity_photo_file_id,omitempty"`
 	FamilyCardPhotoFileID *uuid.UUID `json:"family_card_photo_file_id,omitempty"`
 	BankBookPhotoFileID   *uuid.UUID `json:"bank_book_photo_file_id,omitempty"`
-	AccountNumber         *string    `json:"account_number,omitempty" validate:"omitempty,max=50"`
+	BankCode              *string    `json:"bank_code,omitempty" validate:"omitempty,max=10"`
+	AccountNumber         *string    `json:"account_number,omitempty" validate:"omitempty,max=50,unmasked"`
 }
 
 type SaveBeneficiariesRequest struct {
@@ -207,6 +209,26 @@ type UploadFileRequest struct {
 	FieldName string `json:"-"`
 }
 
+type RequestUploadSlotRequest struct {
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"-"`
+	UploadedBy    uuid.UUID `json:"-"`
+
+	FieldName   string `json:"field_name" validate:"required,max=100"`
+	FileName    string `json:"file_name" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required,oneof=image/jpeg image/png image/gif application/pdf"`
+	Size        int64  `json:"size" validate:"required,gt=0,lte=20971520"`
+}
+
+type CompleteUploadRequest struct {
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"-"`
+	UploadedBy    uuid.UUID `json:"-"`
+	UploadID      uuid.UUID `json:"-"`
+}
+
 type SubmitParticipantRequest struct {
 	TenantID      uuid.UUID `json:"-"`
 	ProductID     uuid.UUID `json:"-"`
@@ -219,6 +241,9 @@ type ApproveParticipantRequest struct {
 	ProductID     uuid.UUID `json:"-"`
 	ParticipantID uuid.UUID `json:"-"`
 	UserID        uuid.UUID `json:"-"`
+	// BankVerificationOverrideReason allows approval while the primary bank
+	// account is not verified.
+	BankVerificationOverrideReason *string `json:"bank_verification_override_reason,omitempty" validate:"omitempty,min=10,max=500"`
 }
 
 type RejectParticipantRequest struct {
@@ -229,10 +254,71 @@ type RejectParticipantRequest struct {
 	Reason        string    `json:"reason" validate:"required,min=10,max=500"`
 }
 
+type ActivateParticipantRequest struct {
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"-"`
+	UserID        uuid.UUID `json:"-"`
+	EffectiveDate time.Time `json:"effective_date" validate:"required"`
+	Reason        *string   `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type SuspendParticipantRequest struct {
+	TenantID        uuid.UUID  `json:"-"`
+	ProductID       uuid.UUID  `json:"-"`
+	ParticipantID   uuid.UUID  `json:"-"`
+	UserID          uuid.UUID  `json:"-"`
+	EffectiveDate   time.Time  `json:"effective_date" validate:"required"`
+	ExpectedEndDate *time.Time `json:"expected_end_date,omitempty"`
+	Reason          string     `json:"reason" validate:"required,min=10,max=500"`
+}
+
+type TerminateParticipantRequest struct {
+	TenantID        uuid.UUID  `json:"-"`
+	ProductID       uuid.UUID  `json:"-"`
+	ParticipantID   uuid.UUID  `json:"-"`
+	UserID          uuid.UUID  `json:"-"`
+	TerminationDate time.Time  `json:"termination_date" validate:"required"`
+	Reason          string     `json:"reason" validate:"required,min=10,max=500"`
+	SupportingFile  *uuid.UUID `json:"supporting_file_id,omitempty"`
+}
+
+type RetireParticipantRequest struct {
+	TenantID           uuid.UUID  `json:"-"`
+	ProductID          uuid.UUID  `json:"-"`
+	ParticipantID      uuid.UUID  `json:"-"`
+	UserID             uuid.UUID  `json:"-"`
+	RetirementDate     time.Time  `json:"retirement_date" validate:"required"`
+	RetirementTypeCode string     `json:"retirement_type_code" validate:"required,max=50"`
+	Reason             *string    `json:"reason,omitempty" validate:"omitempty,max=500"`
+	SupportingFile     *uuid.UUID `json:"supporting_file_id,omitempty"`
+}
+
+type MarkParticipantDeceasedRequest struct {
+	TenantID               uuid.UUID `json:"-"`
+	ProductID              uuid.UUID `json:"-"`
+	ParticipantID          uuid.UUID `json:"-"`
+	UserID                 uuid.UUID `json:"-"`
+	DateOfDeath            time.Time `json:"date_of_death" validate:"required"`
+	DeathCertificateFileID uuid.UUID `json:"death_certificate_file_id" validate:"required"`
+	Reason                 *string   `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type TransferOutParticipantRequest struct {
+	TenantID        uuid.UUID  `json:"-"`
+	ProductID       uuid.UUID  `json:"-"`
+	ParticipantID   uuid.UUID  `json:"-"`
+	UserID          uuid.UUID  `json:"-"`
+	TransferDate    time.Time  `json:"transfer_date" validate:"required"`
+	DestinationFund string     `json:"destination_fund" validate:"required,max=255"`
+	Reason          string     `json:"reason" validate:"required,min=10,max=500"`
+	SupportingFile  *uuid.UUID `json:"supporting_file_id,omitempty"`
+}
+
 type ListParticipantsRequest struct {
 	TenantID  uuid.UUID `json:"-"`
 	ProductID uuid.UUID `json:"-"`
-	Status    *string   `json:"status,omitempty" validate:"omitempty,oneof=DRAFT PENDING_APPROVAL APPROVED REJECTED"`
+	Status    *string   `json:"status,omitempty" validate:"omitempty,oneof=DRAFT PENDING_APPROVAL APPROVED REJECTED ACTIVE SUSPENDED TERMINATED RETIRED DECEASED TRANSFERRED_OUT"`
 	Search    string    `json:"search,omitempty"`
 	Page      int       `json:"page" validate:"min=1"`
 	PerPage   int       `json:"per_page" validate:"min=1,max=100"`
@@ -246,6 +332,12 @@ type GetParticipantRequest struct {
 	ProductID     uuid.UUID `json:"-"`
 }
 
+type BatchParticipantsRequest struct {
+	TenantID       uuid.UUID   `json:"-"`
+	ProductID      uuid.UUID   `json:"-"`
+	ParticipantIDs []uuid.UUID `json:"-"`
+}
+
 type DeleteParticipantRequest struct {
 	ParticipantID uuid.UUID `json:"-"`
 	TenantID      uuid.UUID `json:"-"`
@@ -253,6 +345,13 @@ type DeleteParticipantRequest struct {
 	UserID        uuid.UUID `json:"-"`
 }
 
+type VerifyBankAccountRequest struct {
+	AccountID     uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"-"`
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+}
+
 type DeleteChildEntityRequest struct {
 	ChildID       uuid.UUID `json:"-"`
 	ParticipantID uuid.UUID `json:"-"`
@@ -267,3 +366,37 @@ type SelfRegisterRequest struct {
 	ParticipantNumber string    `json:"participant_number" validate:"required,min=5,max=20,alphanum"`
 	PhoneNumber       string    `json:"phone_number"       validate:"required,min=9,max=16"`
 }
+
+type FindDuplicatesRequest struct {
+	TenantID      uuid.UUID `json:"-"`
+	ProductID     uuid.UUID `json:"-"`
+	ParticipantID uuid.UUID `json:"-"`
+	CrossTenant   bool      `json:"-"`
+}
+
+type ListDuplicateCandidatesRequest struct {
+	TenantID   uuid.UUID `json:"-"`
+	ProductID  uuid.UUID `json:"-"`
+	AllTenants bool      `json:"-"`
+	Status     *string   `json:"status,omitempty" validate:"omitempty,oneof=PENDING DISMISSED MERGED"`
+	Page       int       `json:"page" validate:"min=1"`
+	PerPage    int       `json:"per_page" validate:"min=1,max=100"`
+}
+
+type DismissDuplicateCandidateRequest struct {
+	TenantID    uuid.UUID `json:"-"`
+	ProductID   uuid.UUID `json:"-"`
+	CandidateID uuid.UUID `json:"-"`
+	UserID      uuid.UUID `json:"-"`
+	AllTenants  bool      `json:"-"`
+	Reason      string    `json:"reason" validate:"required,min=10,max=500"`
+}
+
+type MergeParticipantsRequest struct {
+	TenantID            uuid.UUID `json:"-"`
+	ProductID           uuid.UUID `json:"-"`
+	ParticipantID       uuid.UUID `json:"-"`
+	UserID              uuid.UUID `json:"-"`
+	SourceParticipantID uuid.UUID `json:"source_participant_id" validate:"required"`
+	Reason              string    `json:"reason" validate:"required,min=10,max=500"`
+}
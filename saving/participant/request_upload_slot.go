@@ -0,0 +1,70 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+// uploadSlotTTL bounds both the presigned policy and the window in which the
+// upload can be completed. Uncompleted slots are reaped after it passes.
+const uploadSlotTTL = 15 * time.Minute
+
+// MaxDirectUploadSize is the largest file an upload slot can be requested
+// for; RequestUploadSlotRequest validates Size against the same value.
+const MaxDirectUploadSize = 20 * 1024 * 1024
+
+func (uc *usecase) RequestUploadSlot(ctx context.Context, req *RequestUploadSlotRequest) (*UploadSlotResponse, error) {
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+
+	if err := ValidateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	if err := validateUploadTarget(participant, req.FieldName); err != nil {
+		return nil, err
+	}
+
+	// Each slot gets its own key so reaping an abandoned slot can never remove
+	// an object another upload of the same file name completed.
+	fileName := fmt.Sprintf("%s-%s", uuid.NewString(), SanitizeFilename(req.FileName))
+	objectKey := GenerateObjectKey(req.TenantID, req.ProductID, req.ParticipantID, req.FieldName, fileName)
+
+	presigned, err := uc.fileStorage.PresignUpload(ctx, defaultBucket, objectKey, req.ContentType, req.Size, uploadSlotTTL)
+	if err != nil {
+		return nil, fmt.Errorf("presign upload: %w", err)
+	}
+
+	slot := &entity.UploadSlot{
+		TenantID:      req.TenantID,
+		ProductID:     req.ProductID,
+		ParticipantID: req.ParticipantID,
+		RequestedBy:   req.UploadedBy,
+		FieldName:     SanitizeFieldName(req.FieldName),
+		Bucket:        defaultBucket,
+		StorageKey:    objectKey,
+		OriginalName:  req.FileName,
+		ContentType:   req.ContentType,
+		MaxSizeBytes:  req.Size,
+		ExpiresAt:     time.Now().Add(uploadSlotTTL),
+	}
+	if err := uc.uploadSlotRepo.Create(ctx, slot); err != nil {
+		return nil, fmt.Errorf("create upload slot: %w", err)
+	}
+
+	return &UploadSlotResponse{
+		UploadID:     slot.ID,
+		Method:       "POST",
+		URL:          presigned.URL,
+		FormData:     presigned.FormData,
+		MaxSizeBytes: slot.MaxSizeBytes,
+		ExpiresAt:    slot.ExpiresAt,
+	}, nil
+}
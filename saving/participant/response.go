@@ -24,13 +24,13 @@ type ParticipantResponse struct {
 	FullName        string                 `json:"full_name"`
 	Gender          *string                `json:"gender,omitempty"`
 	PlaceOfBirth    *string                `json:"place_of_birth,omitempty"`
-	DateOfBirth     *time.Time             `json:"date_of_birth,omitempty"`
+	DateOfBirth     *time.Time             `json:"date_of_birth,omitempty" mask:"date_of_birth"`
 	MaritalStatus   *string                `json:"marital_status,omitempty"`
 	Citizenship     *string                `json:"citizenship,omitempty"`
 	Religion        *string                `json:"religion,omitempty"`
-	KTPNumber       *string                `json:"ktp_number,omitempty"`
+	KTPNumber       *string                `json:"ktp_number,omitempty" mask:"national_id"`
 	EmployeeNumber  *string                `json:"employee_number,omitempty"`
-	PhoneNumber     *string                `json:"phone_number,omitempty"`
+	PhoneNumber     *string                `json:"phone_number,omitempty" mask:"phone"`
 	Status          string                 `json:"status"`
 	StepsCompleted  StepsCompleted         `json:"steps_completed"`
 	CreatedBy       uuid.UUID              `json:"created_by"`
@@ -51,14 +51,19 @@ type ParticipantResponse struct {
 	Employment      *EmploymentResponse    `json:"employment,omitempty"`
 	Pension         *PensionResponse       `json:"pension,omitempty"`
 	Beneficiaries   []BeneficiaryResponse  `json:"beneficiaries,omitempty"`
+
+	StatusEffectiveDate *time.Time               `json:"status_effective_date,omitempty"`
+	DuplicateWarnings   []DuplicateMatchResponse `json:"duplicate_warnings,omitempty"`
+	FilePreviewURLs     map[string]string        `json:"file_preview_urls,omitempty"`
+	FileThumbnailURLs   map[string]string        `json:"file_thumbnail_urls,omitempty"`
 }
 
 type ParticipantSummaryResponse struct {
 	ID             uuid.UUID  `json:"id"`
 	FullName       string     `json:"full_name"`
-	KTPNumber      *string    `json:"ktp_number,omitempty"`
+	KTPNumber      *string    `json:"ktp_number,omitempty" mask:"national_id"`
 	EmployeeNumber *string    `json:"employee_number,omitempty"`
-	PhoneNumber    *string    `json:"phone_number,omitempty"`
+	PhoneNumber    *string    `json:"phone_number,omitempty" mask:"phone"`
 	Status         string     `json:"status"`
 	SubmittedAt    *time.Time `json:"submitted_at,omitempty"`
 	ApprovedAt     *time.Time `json:"approved_at,omitempty"`
@@ -69,7 +74,7 @@ type ParticipantSummaryResponse struct {
 type IdentityResponse struct {
 	ID                uuid.UUID  `json:"id"`
 	IdentityType      string     `json:"identity_type"`
-	IdentityNumber    string     `json:"identity_number"`
+	IdentityNumber    string     `json:"identity_number" mask:"national_id"`
 	IdentityAuthority *string    `json:"identity_authority,omitempty"`
 	IssueDate         *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
@@ -102,16 +107,24 @@ type AddressResponse struct {
 type BankAccountResponse struct {
 	ID                uuid.UUID  `json:"id"`
 	BankCode          string     `json:"bank_code"`
-	AccountNumber     string     `json:"account_number"`
+	AccountNumber     string     `json:"account_number" mask:"bank_account"`
 	AccountHolderName string     `json:"account_holder_name"`
 	AccountType       *string    `json:"account_type,omitempty"`
 	CurrencyCode      string     `json:"currency_code"`
 	IsPrimary         bool       `json:"is_primary"`
 	IssueDate         *time.Time `json:"issue_date,omitempty"`
 	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
-	Version           int        `json:"version"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+
+	VerificationStatus    string     `json:"verification_status"`
+	VerificationProvider  *string    `json:"verification_provider,omitempty"`
+	VerifiedHolderName    *string    `json:"verified_holder_name,omitempty"`
+	VerificationScore     *float64   `json:"verification_score,omitempty"`
+	VerificationMessage   *string    `json:"verification_message,omitempty"`
+	VerificationCheckedAt *time.Time `json:"verification_checked_at,omitempty"`
+
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type FamilyMemberResponse struct {
@@ -175,7 +188,8 @@ type BeneficiaryResponse struct {
 	BankBookPhotoFilePath   *string    `json:"bank_book_photo_file_path,omitempty"`
 	BankBookPhotoFileID     *uuid.UUID `json:"bank_book_photo_file_id,omitempty"`
 	BankBookPhotoURL        *string    `json:"bank_book_photo_url,omitempty"`
-	AccountNumber           *string    `json:"account_number,omitempty"`
+	BankCode                *string    `json:"bank_code,omitempty"`
+	AccountNumber           *string    `json:"account_number,omitempty" mask:"bank_account"`
 	Version                 int        `json:"version"`
 	CreatedAt               time.Time  `json:"created_at"`
 	UpdatedAt               time.Time  `json:"updated_at"`
@@ -189,6 +203,10 @@ type StatusHistoryResponse struct {
 	Reason     *string   `json:"reason,omitempty"`
 	ChangedAt  time.Time `json:"changed_at"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	EffectiveDate    *time.Time        `json:"effective_date,omitempty"`
+	SupportingFileID *uuid.UUID        `json:"supporting_file_id,omitempty"`
+	Details          map[string]string `json:"details,omitempty"`
 }
 
 type ListParticipantsResponse struct {
@@ -203,10 +221,52 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+type DuplicateMatchResponse struct {
+	ParticipantID uuid.UUID `json:"participant_id"`
+	TenantID      uuid.UUID `json:"tenant_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	FullName      string    `json:"full_name"`
+	Status        string    `json:"status"`
+	Score         float64   `json:"score"`
+	MatchedFields []string  `json:"matched_fields"`
+}
+
+type DuplicateCandidateResponse struct {
+	ID                   uuid.UUID  `json:"id"`
+	TenantID             uuid.UUID  `json:"tenant_id"`
+	ProductID            uuid.UUID  `json:"product_id"`
+	ParticipantID        uuid.UUID  `json:"participant_id"`
+	MatchedParticipantID uuid.UUID  `json:"matched_participant_id"`
+	MatchedTenantID      uuid.UUID  `json:"matched_tenant_id"`
+	Score                float64    `json:"score"`
+	MatchedFields        []string   `json:"matched_fields"`
+	Status               string     `json:"status"`
+	ReviewedBy           *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt           *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote           *string    `json:"review_note,omitempty"`
+	Version              int        `json:"version"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+type ListDuplicateCandidatesResponse struct {
+	Candidates []DuplicateCandidateResponse `json:"candidates"`
+	Pagination PaginationMeta               `json:"pagination"`
+}
+
 type FileUploadResponse struct {
 	FileID uuid.UUID `json:"file_id"`
 }
 
+type UploadSlotResponse struct {
+	UploadID     uuid.UUID         `json:"upload_id"`
+	Method       string            `json:"method"`
+	URL          string            `json:"url"`
+	FormData     map[string]string `json:"form_data"`
+	MaxSizeBytes int64             `json:"max_size_bytes"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+}
+
 type SelfRegisterParticipantData struct {
 	ParticipantNumber string    `json:"participant_number"`
 	Status            string    `json:"status"`
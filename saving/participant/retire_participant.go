@@ -0,0 +1,40 @@
+package participant
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/masterdata"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) RetireParticipant(ctx context.Context, req *RetireParticipantRequest) (*ParticipantResponse, error) {
+	validateResp, err := uc.masterdataUsecase.ValidateItemCode(ctx, &masterdata.ValidateCodeRequest{
+		CategoryCode:  "RETIREMENT_TYPE",
+		ItemCode:      req.RetirementTypeCode,
+		TenantID:      &req.TenantID,
+		RequireActive: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("validate retirement type: %w", err)
+	}
+	if !validateResp.Valid {
+		return nil, errors.ErrBadRequest("invalid retirement type code")
+	}
+
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:         req.TenantID,
+		productID:        req.ProductID,
+		participantID:    req.ParticipantID,
+		userID:           req.UserID,
+		to:               entity.ParticipantStatusRetired,
+		action:           "retired",
+		guard:            (*entity.Participant).CanBeRetired,
+		effectiveDate:    req.RetirementDate,
+		reason:           req.Reason,
+		supportingFileID: req.SupportingFile,
+		documentType:     "retirement_letter",
+		details:          map[string]string{"retirement_type_code": req.RetirementTypeCode},
+	})
+}
@@ -44,6 +44,11 @@ func (uc *usecase) SaveBankAccount(ctx context.Context, req *SaveBankAccountRequ
 				return errors.ErrForbidden("bank account does not belong to this participant")
 			}
 
+			if account.BankCode != req.BankCode || account.AccountNumber != req.AccountNumber ||
+				account.AccountHolderName != req.AccountHolderName {
+				account.ResetVerification()
+			}
+
 			account.BankCode = req.BankCode
 			account.AccountNumber = req.AccountNumber
 			account.AccountHolderName = req.AccountHolderName
@@ -59,18 +64,19 @@ func (uc *usecase) SaveBankAccount(ctx context.Context, req *SaveBankAccountRequ
 		} else {
 			now := time.Now()
 			account = &entity.ParticipantBankAccount{
-				ParticipantID:     req.ParticipantID,
-				BankCode:          req.BankCode,
-				AccountNumber:     req.AccountNumber,
-				AccountHolderName: req.AccountHolderName,
-				AccountType:       req.AccountType,
-				CurrencyCode:      req.CurrencyCode,
-				IsPrimary:         req.IsPrimary,
-				IssueDate:         req.IssueDate,
-				ExpiryDate:        req.ExpiryDate,
-				Version:           1,
-				CreatedAt:         now,
-				UpdatedAt:         now,
+				ParticipantID:      req.ParticipantID,
+				BankCode:           req.BankCode,
+				AccountNumber:      req.AccountNumber,
+				AccountHolderName:  req.AccountHolderName,
+				AccountType:        req.AccountType,
+				CurrencyCode:       req.CurrencyCode,
+				IsPrimary:          req.IsPrimary,
+				IssueDate:          req.IssueDate,
+				ExpiryDate:         req.ExpiryDate,
+				VerificationStatus: entity.BankAccountVerificationUnverified,
+				Version:            1,
+				CreatedAt:          now,
+				UpdatedAt:          now,
 			}
 
 			if err := uc.bankAccountRepo.Create(txCtx, account); err != nil {
@@ -68,6 +68,7 @@ func (uc *usecase) SaveBeneficiaries(ctx context.Context, req *SaveBeneficiaries
 				IdentityPhotoFileID:   item.IdentityPhotoFileID,
 				FamilyCardPhotoFileID: item.FamilyCardPhotoFileID,
 				BankBookPhotoFileID:   item.BankBookPhotoFileID,
+				BankCode:              item.BankCode,
 				AccountNumber:         item.AccountNumber,
 				Version:               1,
 				CreatedAt:             now,
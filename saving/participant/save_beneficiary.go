@@ -43,6 +43,9 @@ func (uc *usecase) SaveBeneficiary(ctx context.Context, req *SaveBeneficiaryRequ
 			if file.TenantID != req.TenantID || file.ProductID != req.ProductID {
 				return errors.ErrForbidden("identity_photo_file_id does not belong to this tenant/product")
 			}
+			if err := ValidateFileAttachable(file); err != nil {
+				return err
+			}
 			if err := uc.fileRepo.SetPermanent(txCtx, *req.IdentityPhotoFileID); err != nil {
 				return fmt.Errorf("set identity photo file permanent: %w", err)
 			}
@@ -56,6 +59,9 @@ func (uc *usecase) SaveBeneficiary(ctx context.Context, req *SaveBeneficiaryRequ
 			if file.TenantID != req.TenantID || file.ProductID != req.ProductID {
 				return errors.ErrForbidden("family_card_photo_file_id does not belong to this tenant/product")
 			}
+			if err := ValidateFileAttachable(file); err != nil {
+				return err
+			}
 			if err := uc.fileRepo.SetPermanent(txCtx, *req.FamilyCardPhotoFileID); err != nil {
 				return fmt.Errorf("set family card photo file permanent: %w", err)
 			}
@@ -69,6 +75,9 @@ func (uc *usecase) SaveBeneficiary(ctx context.Context, req *SaveBeneficiaryRequ
 			if file.TenantID != req.TenantID || file.ProductID != req.ProductID {
 				return errors.ErrForbidden("bank_book_photo_file_id does not belong to this tenant/product")
 			}
+			if err := ValidateFileAttachable(file); err != nil {
+				return err
+			}
 			if err := uc.fileRepo.SetPermanent(txCtx, *req.BankBookPhotoFileID); err != nil {
 				return fmt.Errorf("set bank book photo file permanent: %w", err)
 			}
@@ -90,6 +99,7 @@ func (uc *usecase) SaveBeneficiary(ctx context.Context, req *SaveBeneficiaryRequ
 			beneficiary.IdentityPhotoFileID = req.IdentityPhotoFileID
 			beneficiary.FamilyCardPhotoFileID = req.FamilyCardPhotoFileID
 			beneficiary.BankBookPhotoFileID = req.BankBookPhotoFileID
+			beneficiary.BankCode = req.BankCode
 			beneficiary.AccountNumber = req.AccountNumber
 
 			if err := uc.beneficiaryRepo.Update(txCtx, beneficiary); err != nil {
@@ -103,6 +113,7 @@ func (uc *usecase) SaveBeneficiary(ctx context.Context, req *SaveBeneficiaryRequ
 				IdentityPhotoFileID:  req.IdentityPhotoFileID,
 				FamilyCardPhotoFileID: req.FamilyCardPhotoFileID,
 				BankBookPhotoFileID:  req.BankBookPhotoFileID,
+				BankCode:             req.BankCode,
 				AccountNumber:        req.AccountNumber,
 				Version:              1,
 				CreatedAt:            now,
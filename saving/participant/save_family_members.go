@@ -19,7 +19,7 @@ func (uc *usecase) validateFileOwnership(ctx context.Context, fileID uuid.UUID,
 	if file.TenantID != tenantID || file.ProductID != productID {
 		return errors.ErrForbidden("file does not belong to this tenant/product")
 	}
-	return nil
+	return ValidateFileAttachable(file)
 }
 
 func (uc *usecase) SaveFamilyMembers(ctx context.Context, req *SaveFamilyMembersRequest) ([]FamilyMemberResponse, error) {
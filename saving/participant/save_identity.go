@@ -34,6 +34,9 @@ func (uc *usecase) SaveIdentity(ctx context.Context, req *SaveIdentityRequest) (
 			if file.TenantID != req.TenantID || file.ProductID != req.ProductID {
 				return errors.ErrForbidden("photo_file_id does not belong to this tenant/product")
 			}
+			if err := ValidateFileAttachable(file); err != nil {
+				return err
+			}
 			if err := uc.fileRepo.SetPermanent(txCtx, *req.PhotoFileID); err != nil {
 				return fmt.Errorf("set photo file permanent: %w", err)
 			}
@@ -10,6 +10,7 @@ import (
 	"erp-service/entity"
 	"erp-service/masterdata"
 	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 
 	"github.com/google/uuid"
 )
@@ -114,6 +115,7 @@ func (uc *usecase) SelfRegister(ctx context.Context, req *SelfRegisterRequest) (
 	if !tenant.IsActive() {
 		return nil, apperrors.ErrUnprocessable("organization is inactive")
 	}
+	ctx = tenantdb.WithTenant(ctx, tenant.ID)
 
 	product, err := uc.productRepo.GetByCodeAndTenant(ctx, tenant.ID, "frendz-saving")
 	if err != nil {
@@ -7,10 +7,15 @@ import (
 
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
 )
 
 func (uc *usecase) SubmitParticipant(ctx context.Context, req *SubmitParticipantRequest) (*ParticipantResponse, error) {
 	var result *ParticipantResponse
+	var saved *entity.Participant
+	var event *notify.Event
 
 	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		participant, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
@@ -26,6 +31,10 @@ func (uc *usecase) SubmitParticipant(ctx context.Context, req *SubmitParticipant
 			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status cannot be submitted", participant.Status))
 		}
 
+		if err := uc.validateReferencedFilesScanned(txCtx, participant.ID); err != nil {
+			return err
+		}
+
 		now := time.Now()
 		fromStatus := string(participant.Status)
 
@@ -56,6 +65,8 @@ func (uc *usecase) SubmitParticipant(ctx context.Context, req *SubmitParticipant
 			return fmt.Errorf("build response: %w", err)
 		}
 		result = resp
+		saved = participant
+		event = newStatusEvent(notify.TopicApprovalQueue, notify.ParticipantSubmitted, participant, fromStatus, req.UserID)
 		return nil
 	})
 
@@ -63,5 +74,57 @@ func (uc *usecase) SubmitParticipant(ctx context.Context, req *SubmitParticipant
 		return nil, err
 	}
 
+	uc.publish(ctx, event)
+	result.DuplicateWarnings = uc.detectDuplicates(ctx, saved)
+
 	return result, nil
 }
+
+// validateReferencedFilesScanned blocks submission until every document
+// attached to the participant has been scanned and found clean.
+func (uc *usecase) validateReferencedFilesScanned(ctx context.Context, participantID uuid.UUID) error {
+	identities, err := uc.identityRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return fmt.Errorf("list identities: %w", err)
+	}
+	members, err := uc.familyMemberRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return fmt.Errorf("list family members: %w", err)
+	}
+	beneficiaries, err := uc.beneficiaryRepo.ListByParticipantID(ctx, participantID)
+	if err != nil {
+		return fmt.Errorf("list beneficiaries: %w", err)
+	}
+
+	var fileIDs []uuid.UUID
+	add := func(id *uuid.UUID) {
+		if id != nil {
+			fileIDs = append(fileIDs, *id)
+		}
+	}
+	for _, identity := range identities {
+		add(identity.PhotoFileID)
+	}
+	for _, member := range members {
+		add(member.SupportingDocFileID)
+	}
+	for _, beneficiary := range beneficiaries {
+		add(beneficiary.IdentityPhotoFileID)
+		add(beneficiary.FamilyCardPhotoFileID)
+		add(beneficiary.BankBookPhotoFileID)
+	}
+
+	for _, id := range fileIDs {
+		file, err := uc.fileRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get file %s: %w", id, err)
+		}
+		if file.IsInfected() || file.IsScanFailed() {
+			return errors.ErrBadRequest(fmt.Sprintf("file %s failed malware scanning; replace it before submitting", id))
+		}
+		if !file.IsClean() {
+			return errors.ErrBadRequest(fmt.Sprintf("file %s is still being scanned; try again shortly", id))
+		}
+	}
+	return nil
+}
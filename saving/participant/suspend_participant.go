@@ -0,0 +1,32 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) SuspendParticipant(ctx context.Context, req *SuspendParticipantRequest) (*ParticipantResponse, error) {
+	var details map[string]string
+	if req.ExpectedEndDate != nil {
+		if !truncateToDate(*req.ExpectedEndDate).After(truncateToDate(req.EffectiveDate)) {
+			return nil, errors.ErrBadRequest("expected end date must be after the suspension date")
+		}
+		details = map[string]string{"expected_end_date": req.ExpectedEndDate.Format("2006-01-02")}
+	}
+
+	reason := req.Reason
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:      req.TenantID,
+		productID:     req.ProductID,
+		participantID: req.ParticipantID,
+		userID:        req.UserID,
+		to:            entity.ParticipantStatusSuspended,
+		action:        "suspended",
+		guard:         (*entity.Participant).CanBeSuspended,
+		effectiveDate: req.EffectiveDate,
+		reason:        &reason,
+		details:       details,
+	})
+}
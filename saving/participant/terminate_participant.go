@@ -0,0 +1,24 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+)
+
+func (uc *usecase) TerminateParticipant(ctx context.Context, req *TerminateParticipantRequest) (*ParticipantResponse, error) {
+	reason := req.Reason
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:         req.TenantID,
+		productID:        req.ProductID,
+		participantID:    req.ParticipantID,
+		userID:           req.UserID,
+		to:               entity.ParticipantStatusTerminated,
+		action:           "terminated",
+		guard:            (*entity.Participant).CanBeTerminated,
+		effectiveDate:    req.TerminationDate,
+		reason:           &reason,
+		supportingFileID: req.SupportingFile,
+		documentType:     "termination_letter",
+	})
+}
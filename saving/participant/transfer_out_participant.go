@@ -0,0 +1,25 @@
+package participant
+
+import (
+	"context"
+
+	"erp-service/entity"
+)
+
+func (uc *usecase) TransferOutParticipant(ctx context.Context, req *TransferOutParticipantRequest) (*ParticipantResponse, error) {
+	reason := req.Reason
+	return uc.transitionLifecycle(ctx, lifecycleTransition{
+		tenantID:         req.TenantID,
+		productID:        req.ProductID,
+		participantID:    req.ParticipantID,
+		userID:           req.UserID,
+		to:               entity.ParticipantStatusTransferredOut,
+		action:           "transferred out",
+		guard:            (*entity.Participant).CanBeTransferredOut,
+		effectiveDate:    req.TransferDate,
+		reason:           &reason,
+		supportingFileID: req.SupportingFile,
+		documentType:     "transfer_letter",
+		details:          map[string]string{"destination_fund": req.DestinationFund},
+	})
+}
@@ -22,30 +22,36 @@ func (uc *usecase) UploadFile(ctx context.Context, req *UploadFileRequest) (*Fil
 		return nil, err
 	}
 
-	if err := ValidateEditableState(participant); err != nil {
+	if err := validateUploadTarget(participant, req.FieldName); err != nil {
 		return nil, err
 	}
 
 	bucket := defaultBucket
 	objectKey := GenerateObjectKey(req.TenantID, req.ProductID, req.ParticipantID, req.FieldName, req.FileName)
 
-	storageKey, err := uc.fileStorage.UploadFile(ctx, bucket, objectKey, req.Reader, req.Size, req.ContentType)
+	storageKey := objectKey
+	env, err := uc.fileStorage.UploadEncryptedFile(ctx, req.TenantID, bucket, objectKey, req.Reader, req.Size)
 	if err != nil {
 		return nil, fmt.Errorf("upload to storage: %w", err)
 	}
 
 	expiresAt := time.Now().Add(24 * time.Hour)
+	documentType := SanitizeFieldName(req.FieldName)
 	file := &entity.File{
-		TenantID:     req.TenantID,
-		ProductID:    req.ProductID,
-		UploadedBy:   req.UploadedBy,
-		Bucket:       bucket,
-		StorageKey:   storageKey,
-		OriginalName: req.FileName,
-		ContentType:  req.ContentType,
-		SizeBytes:    req.Size,
-		ExpiresAt:    &expiresAt,
+		TenantID:      req.TenantID,
+		ProductID:     req.ProductID,
+		ParticipantID: &participant.ID,
+		UploadedBy:    req.UploadedBy,
+		Bucket:        bucket,
+		StorageKey:    storageKey,
+		OriginalName:  req.FileName,
+		ContentType:   req.ContentType,
+		SizeBytes:     req.Size,
+		ScanStatus:    entity.FileScanStatusPending,
+		ExpiresAt:     &expiresAt,
+		DocumentType:  &documentType,
 	}
+	file.SetEnvelope(env)
 
 	if err := uc.fileRepo.Create(ctx, file); err != nil {
 
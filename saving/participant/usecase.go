@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"erp-service/masterdata"
+
+	"github.com/google/uuid"
 )
 
 type MasterdataValidateUsecase interface {
@@ -40,6 +42,7 @@ type AddressManager interface {
 type BankAccountManager interface {
 	SaveBankAccount(ctx context.Context, req *SaveBankAccountRequest) (*BankAccountResponse, error)
 	DeleteBankAccount(ctx context.Context, req *DeleteChildEntityRequest) error
+	VerifyBankAccount(ctx context.Context, req *VerifyBankAccountRequest) (*BankAccountResponse, error)
 }
 
 type FamilyMemberManager interface {
@@ -64,6 +67,8 @@ type BeneficiaryManager interface {
 
 type FileUploader interface {
 	UploadFile(ctx context.Context, req *UploadFileRequest) (*FileUploadResponse, error)
+	RequestUploadSlot(ctx context.Context, req *RequestUploadSlotRequest) (*UploadSlotResponse, error)
+	CompleteUpload(ctx context.Context, req *CompleteUploadRequest) (*FileUploadResponse, error)
 }
 
 type ParticipantWorkflow interface {
@@ -72,6 +77,22 @@ type ParticipantWorkflow interface {
 	RejectParticipant(ctx context.Context, req *RejectParticipantRequest) (*ParticipantResponse, error)
 }
 
+type DuplicateManager interface {
+	FindDuplicates(ctx context.Context, req *FindDuplicatesRequest) ([]DuplicateMatchResponse, error)
+	ListDuplicateCandidates(ctx context.Context, req *ListDuplicateCandidatesRequest) (*ListDuplicateCandidatesResponse, error)
+	DismissDuplicateCandidate(ctx context.Context, req *DismissDuplicateCandidateRequest) (*DuplicateCandidateResponse, error)
+	MergeParticipants(ctx context.Context, req *MergeParticipantsRequest) (*ParticipantResponse, error)
+}
+
+type ParticipantLifecycle interface {
+	ActivateParticipant(ctx context.Context, req *ActivateParticipantRequest) (*ParticipantResponse, error)
+	SuspendParticipant(ctx context.Context, req *SuspendParticipantRequest) (*ParticipantResponse, error)
+	TerminateParticipant(ctx context.Context, req *TerminateParticipantRequest) (*ParticipantResponse, error)
+	RetireParticipant(ctx context.Context, req *RetireParticipantRequest) (*ParticipantResponse, error)
+	MarkParticipantDeceased(ctx context.Context, req *MarkParticipantDeceasedRequest) (*ParticipantResponse, error)
+	TransferOutParticipant(ctx context.Context, req *TransferOutParticipantRequest) (*ParticipantResponse, error)
+}
+
 type ParticipantRegistration interface {
 	SelfRegister(ctx context.Context, req *SelfRegisterRequest) (*SelfRegisterResponse, error)
 }
@@ -88,5 +109,23 @@ type Usecase interface {
 	BeneficiaryManager
 	FileUploader
 	ParticipantWorkflow
+	ParticipantLifecycle
 	ParticipantRegistration
+	DuplicateManager
+}
+
+// BatchReader loads participants and their child records for many
+// participants in one query per record type. Participants outside the
+// request's tenant and product are left out of every result.
+type BatchReader interface {
+	ListParticipantPage(ctx context.Context, req *ListParticipantsRequest) ([]*ParticipantResponse, *PaginationMeta, error)
+	GetParticipantsByIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*ParticipantResponse, error)
+	ListIdentitiesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]IdentityResponse, error)
+	ListAddressesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]AddressResponse, error)
+	ListBankAccountsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]BankAccountResponse, error)
+	ListFamilyMembersByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]FamilyMemberResponse, error)
+	GetEmploymentsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*EmploymentResponse, error)
+	GetPensionsByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID]*PensionResponse, error)
+	ListBeneficiariesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]BeneficiaryResponse, error)
+	ListStatusHistoriesByParticipantIDs(ctx context.Context, req *BatchParticipantsRequest) (map[uuid.UUID][]StatusHistoryResponse, error)
 }
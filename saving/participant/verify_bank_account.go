@@ -0,0 +1,129 @@
+package participant
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+// bankHolderNameMinSimilarity is the lowest Jaro-Winkler score at which the
+// registered holder name counts as the same person.
+const bankHolderNameMinSimilarity = 0.85
+
+// VerifyBankAccount runs an ownership inquiry and stores the outcome on the
+// account. The provider is called outside the transaction; if the account is
+// edited meanwhile the versioned update fails with a conflict. A provider
+// failure is recorded as PROVIDER_ERROR rather than returned.
+func (uc *usecase) VerifyBankAccount(ctx context.Context, req *VerifyBankAccountRequest) (*BankAccountResponse, error) {
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+	if err := ValidateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.bankAccountRepo.GetByID(ctx, req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("get bank account: %w", err)
+	}
+	if account.ParticipantID != req.ParticipantID {
+		return nil, errors.ErrForbidden("bank account does not belong to this participant")
+	}
+
+	outcome := uc.inquireBankAccount(ctx, account, participant.FullName)
+
+	var result *BankAccountResponse
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		outcome.applyTo(account)
+		if err := uc.bankAccountRepo.Update(txCtx, account); err != nil {
+			return fmt.Errorf("update bank account: %w", err)
+		}
+
+		resp := mapBankAccountToResponse(account)
+		result = &resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type bankVerificationOutcome struct {
+	status     entity.BankAccountVerificationStatus
+	provider   string
+	holderName *string
+	score      *float64
+	message    *string
+	checkedAt  time.Time
+}
+
+func (o bankVerificationOutcome) applyTo(account *entity.ParticipantBankAccount) {
+	account.VerificationStatus = o.status
+	account.VerificationProvider = &o.provider
+	account.VerifiedHolderName = o.holderName
+	account.VerificationScore = o.score
+	account.VerificationMessage = o.message
+	account.VerificationCheckedAt = &o.checkedAt
+}
+
+func (uc *usecase) inquireBankAccount(ctx context.Context, account *entity.ParticipantBankAccount, fullName string) bankVerificationOutcome {
+	outcome := bankVerificationOutcome{provider: uc.bankVerifier.Name()}
+
+	if timeout := uc.cfg.Infra.BankVerification.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	res, err := uc.bankVerifier.Inquire(ctx, BankAccountInquiry{
+		BankCode:      account.BankCode,
+		AccountNumber: account.AccountNumber,
+		HolderName:    account.AccountHolderName,
+	})
+	outcome.checkedAt = time.Now()
+
+	switch {
+	case stderrors.Is(err, ErrBankAccountNotFound):
+		outcome.status = entity.BankAccountVerificationNotFound
+	case err != nil:
+		uc.logger.Warn("bank account inquiry failed",
+			zap.String("bank_account_id", account.ID.String()),
+			zap.String("provider", outcome.provider),
+			zap.Error(err))
+		message := err.Error()
+		outcome.status = entity.BankAccountVerificationProviderError
+		outcome.message = &message
+	default:
+		score := MatchBankHolderName(res.HolderName, account.AccountHolderName, fullName)
+		outcome.holderName = &res.HolderName
+		outcome.score = &score
+		outcome.status = entity.BankAccountVerificationVerified
+		if score < bankHolderNameMinSimilarity {
+			outcome.status = entity.BankAccountVerificationMismatch
+		}
+	}
+	return outcome
+}
+
+// MatchBankHolderName scores the bank's registered holder name against both
+// the holder name on file and the participant's name, and returns the worse
+// of the two: the account must belong to the participant, not just to
+// whoever was typed into the form.
+func MatchBankHolderName(registered, accountHolderName, fullName string) float64 {
+	registered = NormalizeName(registered)
+	holder := NameSimilarity(registered, NormalizeName(accountHolderName))
+	participant := NameSimilarity(registered, NormalizeName(fullName))
+	if participant < holder {
+		return participant
+	}
+	return holder
+}
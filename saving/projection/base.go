@@ -0,0 +1,43 @@
+package projection
+
+import (
+	"erp-service/config"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg               *config.Config
+	logger            *zap.Logger
+	txManager         TransactionManager
+	participantRepo   ParticipantRepository
+	employmentRepo    EmploymentRepository
+	pensionRepo       PensionRepository
+	tenantRepo        TenantRepository
+	ledgerRepo        LedgerRepository
+	masterdataUsecase MasterdataUsecase
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	txManager TransactionManager,
+	participantRepo ParticipantRepository,
+	employmentRepo EmploymentRepository,
+	pensionRepo PensionRepository,
+	tenantRepo TenantRepository,
+	ledgerRepo LedgerRepository,
+	masterdataUsecase MasterdataUsecase,
+) Usecase {
+	return &usecase{
+		cfg:               cfg,
+		logger:            logger,
+		txManager:         txManager,
+		participantRepo:   participantRepo,
+		employmentRepo:    employmentRepo,
+		pensionRepo:       pensionRepo,
+		tenantRepo:        tenantRepo,
+		ledgerRepo:        ledgerRepo,
+		masterdataUsecase: masterdataUsecase,
+	}
+}
@@ -0,0 +1,117 @@
+package projection
+
+import (
+	"math"
+	"time"
+)
+
+// RetirementDate returns the date a participant born on dateOfBirth retires at
+// retirementAge under the given rule. A 29 February birthday falls on 1 March
+// in non-leap years; the month-based rules use the birth month regardless.
+func RetirementDate(dateOfBirth time.Time, retirementAge int, rule string) time.Time {
+	y, m, d := dateOfBirth.Date()
+	y += retirementAge
+
+	switch rule {
+	case RetirementDateRuleBirthday:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	case RetirementDateRuleEndOfMonth:
+		return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// MonthsUntil counts the whole contribution months from the month after asOf
+// up to, but not including, the retirement month.
+func MonthsUntil(asOf, retirementDate time.Time) int {
+	months := (retirementDate.Year()-asOf.Year())*12 + int(retirementDate.Month()-asOf.Month()) - 1
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+type ProjectionInput struct {
+	AsOf                time.Time
+	RetirementDate      time.Time
+	OpeningBalance      int64
+	MonthlyContribution int64
+	PayoutYears         int
+	AnnuityRate         float64
+}
+
+type YearProjection struct {
+	Year           int
+	Contributions  int64
+	Return         int64
+	ClosingBalance int64
+}
+
+type ScenarioResult struct {
+	Scenario           Scenario
+	ProjectedBalance   int64
+	TotalContributions int64
+	TotalReturn        int64
+	MonthlyBenefit     int64
+	Years              []YearProjection
+}
+
+// Project compounds the opening balance monthly until retirement, adding a
+// contribution each month that grows yearly with salary. The result is an
+// estimate, so it is computed in float64 and rounded to minor units at the end
+// of each year.
+func Project(in ProjectionInput, s Scenario) ScenarioResult {
+	monthlyReturn := math.Pow(1+s.ReturnRate, 1.0/12) - 1
+	contribution := float64(in.MonthlyContribution)
+	balance := float64(in.OpeningBalance)
+
+	result := ScenarioResult{Scenario: s}
+	months := MonthsUntil(in.AsOf, in.RetirementDate)
+
+	var yearContrib, yearReturn, totalContrib, totalReturn float64
+	month := time.Date(in.AsOf.Year(), in.AsOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= months; i++ {
+		month = month.AddDate(0, 1, 0)
+		if i > 1 && (i-1)%12 == 0 {
+			contribution *= 1 + s.SalaryGrowthRate
+		}
+
+		earned := balance * monthlyReturn
+		balance += earned + contribution
+		yearContrib += contribution
+		yearReturn += earned
+
+		if month.Month() == time.December || i == months {
+			result.Years = append(result.Years, YearProjection{
+				Year:           month.Year(),
+				Contributions:  int64(math.Round(yearContrib)),
+				Return:         int64(math.Round(yearReturn)),
+				ClosingBalance: int64(math.Round(balance)),
+			})
+			totalContrib += yearContrib
+			totalReturn += yearReturn
+			yearContrib, yearReturn = 0, 0
+		}
+	}
+
+	result.ProjectedBalance = int64(math.Round(balance))
+	result.TotalContributions = int64(math.Round(totalContrib))
+	result.TotalReturn = int64(math.Round(totalReturn))
+	result.MonthlyBenefit = MonthlyAnnuity(result.ProjectedBalance, in.AnnuityRate, in.PayoutYears)
+	return result
+}
+
+// MonthlyAnnuity is the level monthly payment that pays out balance over
+// payoutYears at the given yearly rate.
+func MonthlyAnnuity(balance int64, yearlyRate float64, payoutYears int) int64 {
+	n := float64(payoutYears * 12)
+	if n <= 0 || balance <= 0 {
+		return 0
+	}
+	r := math.Pow(1+yearlyRate, 1.0/12) - 1
+	if r == 0 {
+		return int64(math.Round(float64(balance) / n))
+	}
+	return int64(math.Round(float64(balance) * r / (1 - math.Pow(1+r, -n))))
+}
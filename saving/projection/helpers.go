@@ -0,0 +1,144 @@
+package projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const retirementTypeCategory = "RETIREMENT_TYPE"
+
+func validateParticipantOwnership(participant *entity.Participant, tenantID, productID uuid.UUID) error {
+	if participant.TenantID != tenantID {
+		return errors.ErrForbidden("participant does not belong to this tenant")
+	}
+	if participant.ProductID != productID {
+		return errors.ErrForbidden("participant does not belong to this product")
+	}
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// retirementPlan is the resolved retirement type, age and date of a participant.
+type retirementPlan struct {
+	typeCode string
+	age      int
+	rule     string
+	date     time.Time
+}
+
+func (uc *usecase) loadRules(ctx context.Context, tenantID uuid.UUID) (Rules, error) {
+	tenant, err := uc.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return Rules{}, fmt.Errorf("get tenant: %w", err)
+	}
+	rules, err := ParseRules(tenant.Settings)
+	if err != nil {
+		uc.logger.Warn("invalid tenant retirement rules, using defaults",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err),
+		)
+		return DefaultRules(), nil
+	}
+	return rules, nil
+}
+
+// resolveRetirementPlan picks the retirement type (explicit code, then the
+// participant's employment, then the masterdata default), reads its normal
+// retirement age from masterdata metadata and applies the tenant date rule.
+func (uc *usecase) resolveRetirementPlan(ctx context.Context, participant *entity.Participant, employment *entity.ParticipantEmployment, typeCode *string, rules Rules) (*retirementPlan, error) {
+	if participant.DateOfBirth == nil {
+		return nil, errors.ErrBadRequest("participant date of birth is required to project retirement")
+	}
+
+	code := typeCode
+	if code == nil && employment != nil {
+		code = employment.RetirementTypeCode
+	}
+
+	tenantID := participant.TenantID
+	var metadata json.RawMessage
+	var resolved string
+	if code != nil && *code != "" {
+		item, err := uc.masterdataUsecase.GetItemByCode(ctx, retirementTypeCategory, &tenantID, *code)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, errors.ErrBadRequest(fmt.Sprintf("unknown retirement type %s", *code))
+			}
+			return nil, fmt.Errorf("get retirement type: %w", err)
+		}
+		metadata, resolved = item.Metadata, item.Code
+	} else {
+		item, err := uc.masterdataUsecase.GetItemDefault(ctx, retirementTypeCategory, &tenantID)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, errors.ErrBadRequest("no default retirement type is configured")
+			}
+			return nil, fmt.Errorf("get default retirement type: %w", err)
+		}
+		metadata, resolved = item.Metadata, item.Code
+	}
+
+	var meta struct {
+		NormalRetirementAge int `json:"normal_retirement_age"`
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &meta); err != nil {
+			return nil, fmt.Errorf("parse retirement type metadata: %w", err)
+		}
+	}
+	if meta.NormalRetirementAge <= 0 {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("retirement type %s has no normal retirement age", resolved))
+	}
+
+	return &retirementPlan{
+		typeCode: resolved,
+		age:      meta.NormalRetirementAge,
+		rule:     rules.RetirementDateRule,
+		date:     RetirementDate(*participant.DateOfBirth, meta.NormalRetirementAge, rules.RetirementDateRule),
+	}, nil
+}
+
+func (uc *usecase) getEmployment(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantEmployment, error) {
+	employment, err := uc.employmentRepo.GetByParticipantID(ctx, participantID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get employment: %w", err)
+	}
+	return employment, nil
+}
+
+func mapScenarioToResponse(r ScenarioResult) ScenarioResponse {
+	years := make([]YearProjectionResponse, 0, len(r.Years))
+	for _, y := range r.Years {
+		years = append(years, YearProjectionResponse{
+			Year:           y.Year,
+			Contributions:  y.Contributions,
+			Return:         y.Return,
+			ClosingBalance: y.ClosingBalance,
+		})
+	}
+	return ScenarioResponse{
+		Name:               r.Scenario.Name,
+		SalaryGrowthRate:   r.Scenario.SalaryGrowthRate,
+		ReturnRate:         r.Scenario.ReturnRate,
+		ProjectedBalance:   r.ProjectedBalance,
+		TotalContributions: r.TotalContributions,
+		TotalReturn:        r.TotalReturn,
+		MonthlyBenefit:     r.MonthlyBenefit,
+		Years:              years,
+	}
+}
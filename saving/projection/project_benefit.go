@@ -0,0 +1,138 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+)
+
+// contributionHistoryMonths is how far back the average monthly contribution
+// is taken from when the caller does not supply one.
+const contributionHistoryMonths = 12
+
+func (uc *usecase) ProjectBenefit(ctx context.Context, req *ProjectBenefitRequest) (*ProjectionResponse, error) {
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+
+	if err := validateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+		return nil, err
+	}
+
+	if participant.Status.IsFinal() {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("participant in %s status has no retirement to project", participant.Status))
+	}
+
+	rules, err := uc.loadRules(ctx, participant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	employment, err := uc.getEmployment(ctx, participant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.resolveRetirementPlan(ctx, participant, employment, req.RetirementTypeCode, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := truncateToDate(time.Now())
+	if !plan.date.After(asOf) {
+		return nil, errors.ErrBadRequest("participant has already reached the retirement date")
+	}
+
+	employer, employee, err := uc.ledgerRepo.GetBalance(ctx, participant.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get balance: %w", err)
+	}
+
+	var monthly int64
+	if req.MonthlyContribution != nil {
+		monthly = *req.MonthlyContribution
+	} else {
+		monthly, err = uc.averageMonthlyContribution(ctx, participant.ID, asOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scenarios := rules.Scenarios
+	if len(req.Scenarios) > 0 {
+		scenarios = make([]Scenario, 0, len(req.Scenarios))
+		for _, s := range req.Scenarios {
+			scenarios = append(scenarios, Scenario{Name: s.Name, SalaryGrowthRate: s.SalaryGrowthRate, ReturnRate: s.ReturnRate})
+		}
+	}
+
+	input := ProjectionInput{
+		AsOf:                asOf,
+		RetirementDate:      plan.date,
+		OpeningBalance:      employer + employee,
+		MonthlyContribution: monthly,
+		PayoutYears:         rules.PayoutYears,
+		AnnuityRate:         rules.AnnuityRate,
+	}
+
+	results := make([]ScenarioResponse, 0, len(scenarios))
+	for _, s := range scenarios {
+		results = append(results, mapScenarioToResponse(Project(input, s)))
+	}
+
+	return &ProjectionResponse{
+		ParticipantID:       participant.ID,
+		AsOf:                asOf,
+		RetirementTypeCode:  plan.typeCode,
+		RetirementAge:       plan.age,
+		RetirementDate:      plan.date,
+		MonthsToRetirement:  MonthsUntil(asOf, plan.date),
+		CurrentBalance:      employer + employee,
+		MonthlyContribution: monthly,
+		PayoutYears:         rules.PayoutYears,
+		AnnuityRate:         rules.AnnuityRate,
+		Scenarios:           results,
+	}, nil
+}
+
+// averageMonthlyContribution averages the net contributions (after reversals)
+// over the months that received a contribution in the last year.
+func (uc *usecase) averageMonthlyContribution(ctx context.Context, participantID uuid.UUID, asOf time.Time) (int64, error) {
+	from := asOf.AddDate(0, -contributionHistoryMonths, 0)
+	entries, err := uc.ledgerRepo.ListForStatement(ctx, &contribution.StatementFilter{
+		ParticipantID: participantID,
+		From:          &from,
+		To:            &asOf,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list contribution history: %w", err)
+	}
+
+	perPeriod := make(map[string]int64)
+	for _, e := range entries {
+		if e.EntryType == entity.ContributionEntryTypeContribution || e.EntryType == entity.ContributionEntryTypeReversal {
+			perPeriod[e.Period.Format("2006-01")] += e.TotalAmount()
+		}
+	}
+
+	var total int64
+	var months int64
+	for _, amount := range perPeriod {
+		if amount > 0 {
+			total += amount
+			months++
+		}
+	}
+
+	if months == 0 {
+		return 0, nil
+	}
+	return total / months, nil
+}
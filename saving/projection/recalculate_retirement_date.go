@@ -0,0 +1,97 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) RecalculateRetirementDate(ctx context.Context, req *RecalculateRetirementDateRequest) (*RetirementDateResponse, error) {
+	var result *RetirementDateResponse
+
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		participant, err := uc.participantRepo.GetByID(txCtx, req.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("get participant: %w", err)
+		}
+
+		if err := validateParticipantOwnership(participant, req.TenantID, req.ProductID); err != nil {
+			return err
+		}
+
+		if participant.Status.IsFinal() {
+			return errors.ErrBadRequest(fmt.Sprintf("participant in %s status has no retirement to project", participant.Status))
+		}
+
+		rules, err := uc.loadRules(txCtx, participant.TenantID)
+		if err != nil {
+			return err
+		}
+
+		employment, err := uc.getEmployment(txCtx, participant.ID)
+		if err != nil {
+			return err
+		}
+
+		plan, err := uc.resolveRetirementPlan(txCtx, participant, employment, req.RetirementTypeCode, rules)
+		if err != nil {
+			return err
+		}
+
+		retirementDate := plan.date
+		typeCode := plan.typeCode
+		if employment == nil {
+			employment = &entity.ParticipantEmployment{
+				ParticipantID:      participant.ID,
+				RetirementDate:     &retirementDate,
+				RetirementTypeCode: &typeCode,
+			}
+			if err := uc.employmentRepo.Create(txCtx, employment); err != nil {
+				return fmt.Errorf("create employment: %w", err)
+			}
+		} else {
+			employment.RetirementDate = &retirementDate
+			employment.RetirementTypeCode = &typeCode
+			if err := uc.employmentRepo.Update(txCtx, employment); err != nil {
+				return fmt.Errorf("update employment: %w", err)
+			}
+		}
+
+		pension, err := uc.pensionRepo.GetByParticipantID(txCtx, participant.ID)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("get pension: %w", err)
+		}
+		if pension == nil {
+			pension = &entity.ParticipantPension{
+				ParticipantID:           participant.ID,
+				ProjectedRetirementDate: &retirementDate,
+			}
+			if err := uc.pensionRepo.Create(txCtx, pension); err != nil {
+				return fmt.Errorf("create pension: %w", err)
+			}
+		} else {
+			pension.ProjectedRetirementDate = &retirementDate
+			if err := uc.pensionRepo.Update(txCtx, pension); err != nil {
+				return fmt.Errorf("update pension: %w", err)
+			}
+		}
+
+		result = &RetirementDateResponse{
+			ParticipantID:           participant.ID,
+			DateOfBirth:             participant.DateOfBirth,
+			RetirementTypeCode:      plan.typeCode,
+			RetirementAge:           plan.age,
+			RetirementDateRule:      plan.rule,
+			RetirementDate:          retirementDate,
+			ProjectedRetirementDate: retirementDate,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package projection
+
+import (
+	"context"
+
+	"erp-service/entity"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+)
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+}
+
+type EmploymentRepository interface {
+	Create(ctx context.Context, employment *entity.ParticipantEmployment) error
+	GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantEmployment, error)
+	Update(ctx context.Context, employment *entity.ParticipantEmployment) error
+}
+
+type PensionRepository interface {
+	Create(ctx context.Context, pension *entity.ParticipantPension) error
+	GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantPension, error)
+	Update(ctx context.Context, pension *entity.ParticipantPension) error
+}
+
+type TenantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error)
+}
+
+type LedgerRepository interface {
+	GetBalance(ctx context.Context, participantID uuid.UUID) (employer int64, employee int64, err error)
+	ListForStatement(ctx context.Context, filter *contribution.StatementFilter) ([]*entity.ContributionLedgerEntry, error)
+}
@@ -0,0 +1,27 @@
+package projection
+
+import (
+	"github.com/google/uuid"
+)
+
+type RecalculateRetirementDateRequest struct {
+	TenantID           uuid.UUID `json:"-"`
+	ProductID          uuid.UUID `json:"-"`
+	ParticipantID      uuid.UUID `json:"-"`
+	RetirementTypeCode *string   `json:"retirement_type_code,omitempty" validate:"omitempty,max=50"`
+}
+
+type ScenarioRequest struct {
+	Name             string  `json:"name" validate:"required,max=50"`
+	SalaryGrowthRate float64 `json:"salary_growth_rate" validate:"gte=-0.2,lte=0.3"`
+	ReturnRate       float64 `json:"return_rate" validate:"gte=-0.2,lte=0.3"`
+}
+
+type ProjectBenefitRequest struct {
+	TenantID            uuid.UUID         `json:"-"`
+	ProductID           uuid.UUID         `json:"-"`
+	ParticipantID       uuid.UUID         `json:"-"`
+	RetirementTypeCode  *string           `json:"retirement_type_code,omitempty" validate:"omitempty,max=50"`
+	MonthlyContribution *int64            `json:"monthly_contribution,omitempty" validate:"omitempty,gte=0"`
+	Scenarios           []ScenarioRequest `json:"scenarios,omitempty" validate:"omitempty,max=5,dive"`
+}
@@ -0,0 +1,49 @@
+package projection
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RetirementDateResponse struct {
+	ParticipantID           uuid.UUID  `json:"participant_id"`
+	DateOfBirth             *time.Time `json:"date_of_birth,omitempty" mask:"date_of_birth"`
+	RetirementTypeCode      string     `json:"retirement_type_code"`
+	RetirementAge           int        `json:"retirement_age"`
+	RetirementDateRule      string     `json:"retirement_date_rule"`
+	RetirementDate          time.Time  `json:"retirement_date"`
+	ProjectedRetirementDate time.Time  `json:"projected_retirement_date"`
+}
+
+type YearProjectionResponse struct {
+	Year           int   `json:"year"`
+	Contributions  int64 `json:"contributions"`
+	Return         int64 `json:"return"`
+	ClosingBalance int64 `json:"closing_balance"`
+}
+
+type ScenarioResponse struct {
+	Name               string                   `json:"name"`
+	SalaryGrowthRate   float64                  `json:"salary_growth_rate"`
+	ReturnRate         float64                  `json:"return_rate"`
+	ProjectedBalance   int64                    `json:"projected_balance"`
+	TotalContributions int64                    `json:"total_contributions"`
+	TotalReturn        int64                    `json:"total_return"`
+	MonthlyBenefit     int64                    `json:"monthly_benefit"`
+	Years              []YearProjectionResponse `json:"years"`
+}
+
+type ProjectionResponse struct {
+	ParticipantID       uuid.UUID          `json:"participant_id"`
+	AsOf                time.Time          `json:"as_of"`
+	RetirementTypeCode  string             `json:"retirement_type_code"`
+	RetirementAge       int                `json:"retirement_age"`
+	RetirementDate      time.Time          `json:"retirement_date"`
+	MonthsToRetirement  int                `json:"months_to_retirement"`
+	CurrentBalance      int64              `json:"current_balance"`
+	MonthlyContribution int64              `json:"monthly_contribution"`
+	PayoutYears         int                `json:"payout_years"`
+	AnnuityRate         float64            `json:"annuity_rate"`
+	Scenarios           []ScenarioResponse `json:"scenarios"`
+}
@@ -0,0 +1,82 @@
+package projection
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// RetirementDateRuleBirthday retires the participant on the birthday the
+	// retirement age is reached.
+	RetirementDateRuleBirthday = "BIRTHDAY"
+	// RetirementDateRuleEndOfMonth retires on the last day of that birthday's month.
+	RetirementDateRuleEndOfMonth = "END_OF_MONTH"
+	// RetirementDateRuleFirstOfNextMonth retires on the first day of the month
+	// after that birthday.
+	RetirementDateRuleFirstOfNextMonth = "FIRST_OF_NEXT_MONTH"
+)
+
+type Scenario struct {
+	Name             string  `json:"name"`
+	SalaryGrowthRate float64 `json:"salary_growth_rate"`
+	ReturnRate       float64 `json:"return_rate"`
+}
+
+// Rules are the tenant's retirement and projection rules, read from the
+// "retirement" key of the tenant settings. Missing values fall back to
+// DefaultRules.
+type Rules struct {
+	RetirementDateRule string     `json:"retirement_date_rule"`
+	PayoutYears        int        `json:"payout_years"`
+	AnnuityRate        float64    `json:"annuity_rate"`
+	Scenarios          []Scenario `json:"scenarios"`
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		RetirementDateRule: RetirementDateRuleFirstOfNextMonth,
+		PayoutYears:        15,
+		AnnuityRate:        0.05,
+		Scenarios: []Scenario{
+			{Name: "conservative", SalaryGrowthRate: 0.03, ReturnRate: 0.04},
+			{Name: "moderate", SalaryGrowthRate: 0.05, ReturnRate: 0.06},
+			{Name: "optimistic", SalaryGrowthRate: 0.07, ReturnRate: 0.08},
+		},
+	}
+}
+
+func ParseRules(settings json.RawMessage) (Rules, error) {
+	rules := DefaultRules()
+	if len(settings) == 0 {
+		return rules, nil
+	}
+
+	var wrapper struct {
+		Retirement *Rules `json:"retirement"`
+	}
+	if err := json.Unmarshal(settings, &wrapper); err != nil {
+		return rules, fmt.Errorf("parse tenant settings: %w", err)
+	}
+	if wrapper.Retirement == nil {
+		return rules, nil
+	}
+
+	custom := wrapper.Retirement
+	switch custom.RetirementDateRule {
+	case RetirementDateRuleBirthday, RetirementDateRuleEndOfMonth, RetirementDateRuleFirstOfNextMonth:
+		rules.RetirementDateRule = custom.RetirementDateRule
+	case "":
+	default:
+		return rules, fmt.Errorf("unknown retirement date rule %q", custom.RetirementDateRule)
+	}
+	if custom.PayoutYears > 0 {
+		rules.PayoutYears = custom.PayoutYears
+	}
+	if custom.AnnuityRate > 0 {
+		rules.AnnuityRate = custom.AnnuityRate
+	}
+	if len(custom.Scenarios) > 0 {
+		rules.Scenarios = custom.Scenarios
+	}
+	return rules, nil
+}
@@ -0,0 +1,19 @@
+package projection
+
+import (
+	"context"
+
+	"erp-service/masterdata"
+
+	"github.com/google/uuid"
+)
+
+type MasterdataUsecase interface {
+	GetItemByCode(ctx context.Context, categoryCode string, tenantID *uuid.UUID, itemCode string) (*masterdata.ItemResponse, error)
+	GetItemDefault(ctx context.Context, categoryCode string, tenantID *uuid.UUID) (*masterdata.ItemResponse, error)
+}
+
+type Usecase interface {
+	RecalculateRetirementDate(ctx context.Context, req *RecalculateRetirementDateRequest) (*RetirementDateResponse, error)
+	ProjectBenefit(ctx context.Context, req *ProjectBenefitRequest) (*ProjectionResponse, error)
+}
@@ -0,0 +1,38 @@
+package retention
+
+import (
+	"erp-service/config"
+	"erp-service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg             *config.Config
+	logger          *zap.Logger
+	auditLogger     logger.AuditLogger
+	txManager       TransactionManager
+	policyRepo      PolicyRepository
+	participantRepo ParticipantRepository
+	fileRepo        FileRepository
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	auditLogger logger.AuditLogger,
+	txManager TransactionManager,
+	policyRepo PolicyRepository,
+	participantRepo ParticipantRepository,
+	fileRepo FileRepository,
+) Usecase {
+	return &usecase{
+		cfg:             cfg,
+		logger:          logger,
+		auditLogger:     auditLogger,
+		txManager:       txManager,
+		policyRepo:      policyRepo,
+		participantRepo: participantRepo,
+		fileRepo:        fileRepo,
+	}
+}
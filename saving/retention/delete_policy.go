@@ -0,0 +1,33 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) DeletePolicy(ctx context.Context, req *DeletePolicyRequest) error {
+	var policy *entity.FileRetentionPolicy
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		policy, err = uc.policyRepo.GetByID(txCtx, req.PolicyID)
+		if err != nil {
+			return fmt.Errorf("get retention policy: %w", err)
+		}
+		if policy.TenantID != req.TenantID {
+			return errors.ErrNotFound("retention policy not found")
+		}
+		if err := uc.policyRepo.Delete(txCtx, policy.ID); err != nil {
+			return fmt.Errorf("delete retention policy: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.auditPolicy(ctx, "retention_policy_deleted", req.TenantID, req.UserID, policy)
+	return nil
+}
@@ -0,0 +1,81 @@
+package retention
+
+import (
+	"context"
+	"strings"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+func mapPolicyToResponse(policy *entity.FileRetentionPolicy) PolicyResponse {
+	return PolicyResponse{
+		ID:             policy.ID,
+		DocumentType:   policy.DocumentType,
+		RetentionEvent: string(policy.RetentionEvent),
+		RetentionDays:  policy.RetentionDays,
+		CreatedBy:      policy.CreatedBy,
+		CreatedAt:      policy.CreatedAt,
+		UpdatedAt:      policy.UpdatedAt,
+	}
+}
+
+// holdReason trims the reason and requires one when a hold is placed.
+func holdReason(req *SetLegalHoldRequest) (*string, error) {
+	if !req.LegalHold {
+		return nil, nil
+	}
+	if req.Reason == nil || strings.TrimSpace(*req.Reason) == "" {
+		return nil, errors.ErrBadRequest("reason is required to place a legal hold")
+	}
+	reason := strings.TrimSpace(*req.Reason)
+	return &reason, nil
+}
+
+func (uc *usecase) auditLegalHold(ctx context.Context, req *SetLegalHoldRequest, targetType string, reason *string, err error) {
+	action := "legal_hold_lifted"
+	if req.LegalHold {
+		action = "legal_hold_placed"
+	}
+	event := logger.AuditEvent{
+		Domain:     "saving",
+		Action:     action,
+		ActorID:    req.UserID.String(),
+		ActorType:  "user",
+		TargetID:   req.TargetID.String(),
+		TargetType: targetType,
+		TenantID:   req.TenantID.String(),
+		Success:    err == nil,
+		Metadata: map[string]any{
+			"product_id": req.ProductID.String(),
+		},
+	}
+	if reason != nil {
+		event.Metadata["reason"] = *reason
+	}
+	if err != nil {
+		event.Reason = err.Error()
+	}
+	uc.auditLogger.Log(ctx, event)
+}
+
+func (uc *usecase) auditPolicy(ctx context.Context, action string, tenantID, userID uuid.UUID, policy *entity.FileRetentionPolicy) {
+	uc.auditLogger.Log(ctx, logger.AuditEvent{
+		Domain:     "saving",
+		Action:     action,
+		ActorID:    userID.String(),
+		ActorType:  "user",
+		TargetID:   policy.ID.String(),
+		TargetType: "file_retention_policy",
+		TenantID:   tenantID.String(),
+		Success:    true,
+		Metadata: map[string]any{
+			"document_type":   policy.DocumentType,
+			"retention_event": string(policy.RetentionEvent),
+			"retention_days":  policy.RetentionDays,
+		},
+	})
+}
@@ -0,0 +1,19 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+)
+
+func (uc *usecase) ListPolicies(ctx context.Context, req *ListPoliciesRequest) ([]PolicyResponse, error) {
+	policies, err := uc.policyRepo.ListByTenant(ctx, req.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list retention policies: %w", err)
+	}
+
+	result := make([]PolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, mapPolicyToResponse(policy))
+	}
+	return result, nil
+}
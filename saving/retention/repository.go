@@ -0,0 +1,28 @@
+package retention
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type PolicyRepository interface {
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.FileRetentionPolicy, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.FileRetentionPolicy, error)
+	GetByRule(ctx context.Context, tenantID uuid.UUID, documentType string, event entity.RetentionEvent) (*entity.FileRetentionPolicy, error)
+	Create(ctx context.Context, policy *entity.FileRetentionPolicy) error
+	Update(ctx context.Context, policy *entity.FileRetentionPolicy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+	Update(ctx context.Context, participant *entity.Participant) error
+}
+
+type FileRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error)
+	SetLegalHold(ctx context.Context, id uuid.UUID, hold bool, reason *string, setBy uuid.UUID) error
+}
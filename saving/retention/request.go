@@ -0,0 +1,35 @@
+package retention
+
+import "github.com/google/uuid"
+
+type ListPoliciesRequest struct {
+	TenantID uuid.UUID `json:"-"`
+}
+
+// SavePolicyRequest creates the policy for a document type and event, or
+// replaces its retention period if one exists. DocumentType "*" covers every
+// document type without a policy of its own.
+type SavePolicyRequest struct {
+	TenantID       uuid.UUID `json:"-"`
+	UserID         uuid.UUID `json:"-"`
+	DocumentType   string    `json:"document_type" validate:"required,max=100"`
+	RetentionEvent string    `json:"retention_event" validate:"required,oneof=UPLOADED PARTICIPANT_REJECTED PARTICIPANT_TERMINATED PARTICIPANT_DELETED"`
+	RetentionDays  int       `json:"retention_days" validate:"required,min=1,max=36500"`
+}
+
+type DeletePolicyRequest struct {
+	TenantID uuid.UUID `json:"-"`
+	UserID   uuid.UUID `json:"-"`
+	PolicyID uuid.UUID `json:"-"`
+}
+
+// SetLegalHoldRequest places (LegalHold true, Reason required) or lifts a
+// legal hold on the participant or file identified by TargetID.
+type SetLegalHoldRequest struct {
+	TenantID  uuid.UUID `json:"-"`
+	ProductID uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+	TargetID  uuid.UUID `json:"-"`
+	LegalHold bool      `json:"legal_hold"`
+	Reason    *string   `json:"reason,omitempty" validate:"omitempty,max=1000"`
+}
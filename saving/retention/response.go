@@ -0,0 +1,26 @@
+package retention
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyResponse struct {
+	ID             uuid.UUID `json:"id"`
+	DocumentType   string    `json:"document_type"`
+	RetentionEvent string    `json:"retention_event"`
+	RetentionDays  int       `json:"retention_days"`
+	CreatedBy      uuid.UUID `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type LegalHoldResponse struct {
+	TargetID   uuid.UUID  `json:"target_id"`
+	TargetType string     `json:"target_type"`
+	LegalHold  bool       `json:"legal_hold"`
+	Reason     *string    `json:"reason,omitempty"`
+	SetBy      *uuid.UUID `json:"set_by,omitempty"`
+	SetAt      *time.Time `json:"set_at,omitempty"`
+}
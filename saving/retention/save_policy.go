@@ -0,0 +1,54 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+)
+
+func (uc *usecase) SavePolicy(ctx context.Context, req *SavePolicyRequest) (*PolicyResponse, error) {
+	documentType := strings.TrimSpace(req.DocumentType)
+	if documentType == "" {
+		return nil, errors.ErrBadRequest("document_type is required")
+	}
+	event := entity.RetentionEvent(req.RetentionEvent)
+
+	var policy *entity.FileRetentionPolicy
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		existing, err := uc.policyRepo.GetByRule(txCtx, req.TenantID, documentType, event)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("get retention policy: %w", err)
+		}
+
+		if existing != nil {
+			existing.RetentionDays = req.RetentionDays
+			if err := uc.policyRepo.Update(txCtx, existing); err != nil {
+				return fmt.Errorf("update retention policy: %w", err)
+			}
+			policy = existing
+			return nil
+		}
+
+		policy = &entity.FileRetentionPolicy{
+			TenantID:       req.TenantID,
+			DocumentType:   documentType,
+			RetentionEvent: event,
+			RetentionDays:  req.RetentionDays,
+			CreatedBy:      req.UserID,
+		}
+		if err := uc.policyRepo.Create(txCtx, policy); err != nil {
+			return fmt.Errorf("create retention policy: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.auditPolicy(ctx, "retention_policy_saved", req.TenantID, req.UserID, policy)
+	resp := mapPolicyToResponse(policy)
+	return &resp, nil
+}
@@ -0,0 +1,54 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/pkg/errors"
+)
+
+// SetFileLegalHold places or lifts a hold on a single file. Holds on
+// derived files are rejected; they are purged together with their original.
+func (uc *usecase) SetFileLegalHold(ctx context.Context, req *SetLegalHoldRequest) (*LegalHoldResponse, error) {
+	reason, err := holdReason(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *LegalHoldResponse
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		file, err := uc.fileRepo.GetByID(txCtx, req.TargetID)
+		if err != nil {
+			return fmt.Errorf("get file: %w", err)
+		}
+		if file.TenantID != req.TenantID || file.ProductID != req.ProductID {
+			return errors.ErrNotFound("file not found")
+		}
+		if file.IsDerived() {
+			return errors.ErrBadRequest("legal hold must be placed on the original file")
+		}
+
+		if err := uc.fileRepo.SetLegalHold(txCtx, file.ID, req.LegalHold, reason, req.UserID); err != nil {
+			return fmt.Errorf("set file legal hold: %w", err)
+		}
+		file, err = uc.fileRepo.GetByID(txCtx, file.ID)
+		if err != nil {
+			return fmt.Errorf("get file: %w", err)
+		}
+
+		result = &LegalHoldResponse{
+			TargetID:   file.ID,
+			TargetType: "file",
+			LegalHold:  file.LegalHold,
+			Reason:     file.LegalHoldReason,
+			SetBy:      file.LegalHoldSetBy,
+			SetAt:      file.LegalHoldSetAt,
+		}
+		return nil
+	})
+	uc.auditLegalHold(ctx, req, "file", reason, err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp-service/pkg/errors"
+)
+
+// SetParticipantLegalHold places or lifts a hold that keeps every file of
+// the participant from being purged.
+func (uc *usecase) SetParticipantLegalHold(ctx context.Context, req *SetLegalHoldRequest) (*LegalHoldResponse, error) {
+	reason, err := holdReason(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *LegalHoldResponse
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		participant, err := uc.participantRepo.GetByID(txCtx, req.TargetID)
+		if err != nil {
+			return fmt.Errorf("get participant: %w", err)
+		}
+		if participant.TenantID != req.TenantID || participant.ProductID != req.ProductID {
+			return errors.ErrNotFound("participant not found")
+		}
+
+		participant.LegalHold = req.LegalHold
+		participant.LegalHoldReason = reason
+		participant.LegalHoldSetBy = nil
+		participant.LegalHoldSetAt = nil
+		if req.LegalHold {
+			now := time.Now()
+			participant.LegalHoldSetBy = &req.UserID
+			participant.LegalHoldSetAt = &now
+		}
+		if err := uc.participantRepo.Update(txCtx, participant); err != nil {
+			return fmt.Errorf("update participant: %w", err)
+		}
+
+		result = &LegalHoldResponse{
+			TargetID:   participant.ID,
+			TargetType: "participant",
+			LegalHold:  participant.LegalHold,
+			Reason:     participant.LegalHoldReason,
+			SetBy:      participant.LegalHoldSetBy,
+			SetAt:      participant.LegalHoldSetAt,
+		}
+		return nil
+	})
+	uc.auditLegalHold(ctx, req, "participant", reason, err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,11 @@
+package retention
+
+import "context"
+
+type Usecase interface {
+	ListPolicies(ctx context.Context, req *ListPoliciesRequest) ([]PolicyResponse, error)
+	SavePolicy(ctx context.Context, req *SavePolicyRequest) (*PolicyResponse, error)
+	DeletePolicy(ctx context.Context, req *DeletePolicyRequest) error
+	SetParticipantLegalHold(ctx context.Context, req *SetLegalHoldRequest) (*LegalHoldResponse, error)
+	SetFileLegalHold(ctx context.Context, req *SetLegalHoldRequest) (*LegalHoldResponse, error)
+}
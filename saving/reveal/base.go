@@ -0,0 +1,38 @@
+package reveal
+
+import (
+	"erp-service/config"
+	"erp-service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type usecase struct {
+	cfg             *config.Config
+	logger          *zap.Logger
+	auditLogger     logger.AuditLogger
+	participantRepo ParticipantRepository
+	identityRepo    IdentityRepository
+	bankAccountRepo BankAccountRepository
+	beneficiaryRepo BeneficiaryRepository
+}
+
+func NewUsecase(
+	cfg *config.Config,
+	logger *zap.Logger,
+	auditLogger logger.AuditLogger,
+	participantRepo ParticipantRepository,
+	identityRepo IdentityRepository,
+	bankAccountRepo BankAccountRepository,
+	beneficiaryRepo BeneficiaryRepository,
+) Usecase {
+	return &usecase{
+		cfg:             cfg,
+		logger:          logger,
+		auditLogger:     auditLogger,
+		participantRepo: participantRepo,
+		identityRepo:    identityRepo,
+		bankAccountRepo: bankAccountRepo,
+		beneficiaryRepo: beneficiaryRepo,
+	}
+}
@@ -0,0 +1,97 @@
+package reveal
+
+import (
+	"context"
+	"fmt"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+func (uc *usecase) fieldValue(ctx context.Context, req *RevealRequest, participant *entity.Participant) (string, error) {
+	switch req.Field {
+	case FieldKTPNumber:
+		return deref(participant.KTPNumber), nil
+	case FieldPhoneNumber:
+		return deref(participant.PhoneNumber), nil
+	case FieldDateOfBirth:
+		if participant.DateOfBirth == nil {
+			return "", nil
+		}
+		return participant.DateOfBirth.Format("2006-01-02"), nil
+	case FieldIdentityNumber:
+		identity, err := uc.identityRepo.GetByID(ctx, *req.RecordID)
+		if err != nil {
+			return "", fmt.Errorf("get identity: %w", err)
+		}
+		if err := ownedBy(identity.ParticipantID, participant.ID, "identity"); err != nil {
+			return "", err
+		}
+		return identity.IdentityNumber, nil
+	case FieldBankAccountNumber:
+		account, err := uc.bankAccountRepo.GetByID(ctx, *req.RecordID)
+		if err != nil {
+			return "", fmt.Errorf("get bank account: %w", err)
+		}
+		if err := ownedBy(account.ParticipantID, participant.ID, "bank account"); err != nil {
+			return "", err
+		}
+		return account.AccountNumber, nil
+	case FieldBeneficiaryAccountNumber:
+		beneficiary, err := uc.beneficiaryRepo.GetByID(ctx, *req.RecordID)
+		if err != nil {
+			return "", fmt.Errorf("get beneficiary: %w", err)
+		}
+		if err := ownedBy(beneficiary.ParticipantID, participant.ID, "beneficiary"); err != nil {
+			return "", err
+		}
+		return deref(beneficiary.AccountNumber), nil
+	}
+	return "", errors.ErrBadRequest("unsupported field")
+}
+
+func ownedBy(owner, participantID uuid.UUID, resource string) error {
+	if owner != participantID {
+		return errors.ErrNotFound(resource + " not found")
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// auditReveal records the attempt without the revealed value.
+func (uc *usecase) auditReveal(ctx context.Context, req *RevealRequest, err error) {
+	event := logger.AuditEvent{
+		Domain:     "saving",
+		Action:     "pii_revealed",
+		ActorID:    req.UserID.String(),
+		ActorType:  "user",
+		TargetID:   req.ParticipantID.String(),
+		TargetType: "participant",
+		TenantID:   req.TenantID.String(),
+		Success:    err == nil,
+		Metadata: map[string]any{
+			"product_id": req.ProductID.String(),
+			"field":      req.Field,
+			"reason":     req.Reason,
+		},
+	}
+	if classification, ok := fieldClassifications[req.Field]; ok {
+		event.Metadata["classification"] = string(classification)
+	}
+	if req.RecordID != nil {
+		event.Metadata["record_id"] = req.RecordID.String()
+	}
+	if err != nil {
+		event.Reason = err.Error()
+	}
+	uc.auditLogger.Log(ctx, event)
+}
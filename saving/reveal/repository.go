@@ -0,0 +1,25 @@
+package reveal
+
+import (
+	"context"
+
+	"erp-service/entity"
+
+	"github.com/google/uuid"
+)
+
+type ParticipantRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error)
+}
+
+type IdentityRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantIdentity, error)
+}
+
+type BankAccountRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBankAccount, error)
+}
+
+type BeneficiaryRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBeneficiary, error)
+}
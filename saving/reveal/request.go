@@ -0,0 +1,25 @@
+package reveal
+
+import "github.com/google/uuid"
+
+const (
+	FieldKTPNumber                = "ktp_number"
+	FieldPhoneNumber              = "phone_number"
+	FieldDateOfBirth              = "date_of_birth"
+	FieldIdentityNumber           = "identity_number"
+	FieldBankAccountNumber        = "bank_account_number"
+	FieldBeneficiaryAccountNumber = "beneficiary_account_number"
+)
+
+// RevealRequest asks for the unmasked value of one participant field.
+// RecordID names the identity, bank account or beneficiary for the fields
+// that live on those records.
+type RevealRequest struct {
+	TenantID      uuid.UUID  `json:"-"`
+	ProductID     uuid.UUID  `json:"-"`
+	UserID        uuid.UUID  `json:"-"`
+	ParticipantID uuid.UUID  `json:"-"`
+	Field         string     `json:"field" validate:"required,oneof=ktp_number phone_number date_of_birth identity_number bank_account_number beneficiary_account_number"`
+	RecordID      *uuid.UUID `json:"record_id,omitempty"`
+	Reason        string     `json:"reason" validate:"required,max=1000"`
+}
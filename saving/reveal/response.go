@@ -0,0 +1,11 @@
+package reveal
+
+import "github.com/google/uuid"
+
+type RevealResponse struct {
+	ParticipantID  uuid.UUID  `json:"participant_id"`
+	Field          string     `json:"field"`
+	RecordID       *uuid.UUID `json:"record_id,omitempty"`
+	Classification string     `json:"classification"`
+	Value          string     `json:"value"`
+}
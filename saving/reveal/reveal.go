@@ -0,0 +1,74 @@
+package reveal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"erp-service/pkg/errors"
+	"erp-service/pkg/masking"
+)
+
+// RevealParticipantField returns one unmasked value. Every call is audited,
+// including refused ones, so the log shows who looked at what and why.
+func (uc *usecase) RevealParticipantField(ctx context.Context, req *RevealRequest) (*RevealResponse, error) {
+	result, err := uc.reveal(ctx, req)
+	uc.auditReveal(ctx, req, err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *usecase) reveal(ctx context.Context, req *RevealRequest) (*RevealResponse, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.ErrBadRequest("reason is required")
+	}
+	classification, ok := fieldClassifications[req.Field]
+	if !ok {
+		return nil, errors.ErrBadRequest("unsupported field")
+	}
+	if recordFields[req.Field] && req.RecordID == nil {
+		return nil, errors.ErrBadRequest("record_id is required for " + req.Field)
+	}
+
+	participant, err := uc.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("get participant: %w", err)
+	}
+	if participant.TenantID != req.TenantID || participant.ProductID != req.ProductID {
+		return nil, errors.ErrNotFound("participant not found")
+	}
+
+	value, err := uc.fieldValue(ctx, req, participant)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, errors.ErrNotFound(req.Field + " is not set")
+	}
+
+	return &RevealResponse{
+		ParticipantID:  participant.ID,
+		Field:          req.Field,
+		RecordID:       req.RecordID,
+		Classification: string(classification),
+		Value:          value,
+	}, nil
+}
+
+var fieldClassifications = map[string]masking.Classification{
+	FieldKTPNumber:                masking.NationalID,
+	FieldPhoneNumber:              masking.Phone,
+	FieldDateOfBirth:              masking.DateOfBirth,
+	FieldIdentityNumber:           masking.NationalID,
+	FieldBankAccountNumber:        masking.BankAccount,
+	FieldBeneficiaryAccountNumber: masking.BankAccount,
+}
+
+// recordFields live on a child record named by RecordID.
+var recordFields = map[string]bool{
+	FieldIdentityNumber:           true,
+	FieldBankAccountNumber:        true,
+	FieldBeneficiaryAccountNumber: true,
+}
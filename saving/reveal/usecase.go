@@ -0,0 +1,7 @@
+package reveal
+
+import "context"
+
+type Usecase interface {
+	RevealParticipantField(ctx context.Context, req *RevealRequest) (*RevealResponse, error)
+}
@@ -0,0 +1,164 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/iam/auth"
+	"erp-service/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type checkPermissionMocks struct {
+	users       *MockUserRepository
+	userRoles   *MockUserRoleRepository
+	roles       *MockRoleRepository
+	regs        *MockUserTenantRegistrationRepository
+	products    *MockProductRepository
+	permissions *MockPermissionRepository
+}
+
+func TestCheckPermission(t *testing.T) {
+	userID := uuid.New()
+	tenantID := uuid.New()
+	productID := uuid.New()
+	productRoleID := uuid.New()
+	platformRoleID := uuid.New()
+
+	activeUser := &entity.User{ID: userID, Status: entity.UserStatusActive}
+	productRole := entity.UserRole{UserID: userID, RoleID: productRoleID, ProductID: &productID}
+	platformRole := entity.UserRole{UserID: userID, RoleID: platformRoleID}
+	registered := []entity.UserTenantRegistration{{UserID: userID, TenantID: tenantID}}
+
+	req := &auth.CheckPermissionRequest{
+		UserID:     userID,
+		TenantID:   tenantID,
+		ProductID:  productID,
+		Permission: "member:read",
+	}
+
+	tests := []struct {
+		name    string
+		req     *auth.CheckPermissionRequest
+		setup   func(m checkPermissionMocks)
+		want    *auth.CheckPermissionResponse
+		wantErr string
+	}{
+		{
+			name: "allowed - role grants permission",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(activeUser, nil)
+				m.userRoles.On("ListActiveByUserID", mock.Anything, userID, &productID).Return([]entity.UserRole{productRole}, nil)
+				m.regs.On("ListActiveByUserID", mock.Anything, userID).Return(registered, nil)
+				m.products.On("GetByIDAndTenant", mock.Anything, productID, tenantID).Return(&entity.Product{ID: productID}, nil)
+				m.permissions.On("GetCodesByRoleIDs", mock.Anything, []uuid.UUID{productRoleID}).Return([]string{"member:read", "member:update"}, nil)
+			},
+			want: &auth.CheckPermissionResponse{Allowed: true},
+		},
+		{
+			name: "denied - permission not granted",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(activeUser, nil)
+				m.userRoles.On("ListActiveByUserID", mock.Anything, userID, &productID).Return([]entity.UserRole{productRole}, nil)
+				m.regs.On("ListActiveByUserID", mock.Anything, userID).Return(registered, nil)
+				m.products.On("GetByIDAndTenant", mock.Anything, productID, tenantID).Return(&entity.Product{ID: productID}, nil)
+				m.permissions.On("GetCodesByRoleIDs", mock.Anything, []uuid.UUID{productRoleID}).Return([]string{"member:update"}, nil)
+			},
+			want: &auth.CheckPermissionResponse{},
+		},
+		{
+			name: "allowed - platform admin",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(activeUser, nil)
+				m.userRoles.On("ListActiveByUserID", mock.Anything, userID, &productID).Return([]entity.UserRole{platformRole}, nil)
+				m.roles.On("GetByIDs", mock.Anything, []uuid.UUID{platformRoleID}).Return([]*entity.Role{{ID: platformRoleID, Code: auth.PlatformAdminRole}}, nil)
+			},
+			want: &auth.CheckPermissionResponse{Allowed: true, PlatformAdmin: true},
+		},
+		{
+			name: "denied - not registered with tenant",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(activeUser, nil)
+				m.userRoles.On("ListActiveByUserID", mock.Anything, userID, &productID).Return([]entity.UserRole{productRole}, nil)
+				m.regs.On("ListActiveByUserID", mock.Anything, userID).Return([]entity.UserTenantRegistration{{UserID: userID, TenantID: uuid.New()}}, nil)
+			},
+			want: &auth.CheckPermissionResponse{},
+		},
+		{
+			name: "denied - product not active in tenant",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(activeUser, nil)
+				m.userRoles.On("ListActiveByUserID", mock.Anything, userID, &productID).Return([]entity.UserRole{productRole}, nil)
+				m.regs.On("ListActiveByUserID", mock.Anything, userID).Return(registered, nil)
+				m.products.On("GetByIDAndTenant", mock.Anything, productID, tenantID).Return(nil, errors.ErrNotFound("product not found"))
+			},
+			want: &auth.CheckPermissionResponse{},
+		},
+		{
+			name: "denied - inactive user",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Status: entity.UserStatusSuspended}, nil)
+			},
+			want: &auth.CheckPermissionResponse{},
+		},
+		{
+			name: "error - user not found",
+			req:  req,
+			setup: func(m checkPermissionMocks) {
+				m.users.On("GetByID", mock.Anything, userID).Return(nil, errors.ErrNotFound("user not found"))
+			},
+			wantErr: errors.CodeUserNotFound,
+		},
+		{
+			name:    "error - missing permission",
+			req:     &auth.CheckPermissionRequest{UserID: userID, TenantID: tenantID, ProductID: productID},
+			setup:   func(m checkPermissionMocks) {},
+			wantErr: errors.CodeBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := checkPermissionMocks{
+				users:       new(MockUserRepository),
+				userRoles:   new(MockUserRoleRepository),
+				roles:       new(MockRoleRepository),
+				regs:        new(MockUserTenantRegistrationRepository),
+				products:    new(MockProductRepository),
+				permissions: new(MockPermissionRepository),
+			}
+			tt.setup(m)
+
+			uc := auth.NewUsecase(nil, &config.Config{}, m.users, nil, nil, nil, nil, m.roles, nil, m.userRoles, m.products, m.permissions, nil, nil, nil, m.regs, nil, nil, nil)
+
+			resp, err := uc.CheckPermission(context.Background(), tt.req)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, errors.GetCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp)
+
+			m.users.AssertExpectations(t)
+			m.userRoles.AssertExpectations(t)
+			m.roles.AssertExpectations(t)
+			m.regs.AssertExpectations(t)
+			m.products.AssertExpectations(t)
+			m.permissions.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package claim_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_VerifyClaim(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	verifierID := uuid.New()
+
+	allRequired := []string{"claim_form", "ktp", "bank_book", "resignation_letter"}
+
+	tests := []struct {
+		name      string
+		status    entity.ClaimStatus
+		documents scannedDocuments
+		errKind   errors.Kind
+	}{
+		{name: "success - all required documents uploaded", status: entity.ClaimStatusOpen, documents: claimDocuments(entity.FileScanStatusClean, allRequired...)},
+		{name: "error - missing required document", status: entity.ClaimStatusOpen, documents: claimDocuments(entity.FileScanStatusClean, "claim_form", "ktp"), errKind: errors.KindBadRequest},
+		{name: "error - document still being scanned", status: entity.ClaimStatusOpen, documents: claimDocuments(entity.FileScanStatusPending, allRequired...), errKind: errors.KindBadRequest},
+		{name: "error - infected document", status: entity.ClaimStatusOpen, documents: claimDocuments(entity.FileScanStatusInfected, allRequired...), errKind: errors.KindBadRequest},
+		{name: "error - document could not be scanned", status: entity.ClaimStatusOpen, documents: claimDocuments(entity.FileScanStatusFailed, allRequired...), errKind: errors.KindBadRequest},
+		{name: "error - already verified", status: entity.ClaimStatusVerified, errKind: errors.KindBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			c := createClaim(entity.ClaimTypeResignation, tt.status, tenantID, productID)
+
+			m.claimRepo.On("GetByID", mock.Anything, c.ID).Return(c, nil)
+			m.documentRepo.On("ListByClaimID", mock.Anything, c.ID).Return(tt.documents.list(), nil).Maybe()
+			tt.documents.expectFiles(m.fileRepo)
+			m.claimRepo.On("Update", mock.Anything, c).Return(nil).Maybe()
+			m.historyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ClaimStatusHistory")).Return(nil).Maybe()
+			m.instructionRepo.On("ListByClaimID", mock.Anything, c.ID).Return([]*entity.PaymentInstruction{}, nil).Maybe()
+
+			uc := newTestUsecase(m)
+			resp, err := uc.VerifyClaim(context.Background(), &claim.VerifyClaimRequest{
+				TenantID:  tenantID,
+				ProductID: productID,
+				ClaimID:   c.ID,
+				UserID:    verifierID,
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				m.claimRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ClaimStatusVerified), resp.Status)
+			assert.Equal(t, &verifierID, resp.VerifiedBy)
+			assert.Empty(t, resp.MissingDocuments)
+		})
+	}
+}
+
+func TestUsecase_ApproveClaim(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	verifierID := uuid.New()
+	approverID := uuid.New()
+
+	beneficiaryAccount := "1234567890"
+	beneficiaryBank := "009"
+
+	tests := []struct {
+		name             string
+		claimType        entity.ClaimType
+		approverID       uuid.UUID
+		employer         int64
+		employee         int64
+		bankAccounts     []*entity.ParticipantBankAccount
+		beneficiaries    []*entity.ParticipantBeneficiary
+		wantInstructions []int64
+		wantPayeeType    entity.PayeeType
+		documents        scannedDocuments
+		errKind          errors.Kind
+	}{
+		{
+			name:       "success - retirement paid to primary bank account",
+			claimType:  entity.ClaimTypeNormalRetirement,
+			approverID: approverID,
+			employer:   60000000,
+			employee:   40000000,
+			bankAccounts: []*entity.ParticipantBankAccount{
+				{ID: uuid.New(), BankCode: "014", AccountNumber: "111", AccountHolderName: "Secondary", CurrencyCode: "IDR"},
+				{ID: uuid.New(), BankCode: "008", AccountNumber: "222", AccountHolderName: "Primary", CurrencyCode: "IDR", IsPrimary: true},
+			},
+			wantInstructions: []int64{100000000},
+			wantPayeeType:    entity.PayeeTypeParticipant,
+		},
+		{
+			name:       "success - death claim split across beneficiaries with remainder on first",
+			claimType:  entity.ClaimTypeDeath,
+			approverID: approverID,
+			employer:   50,
+			employee:   50,
+			beneficiaries: []*entity.ParticipantBeneficiary{
+				{ID: uuid.New(), BankCode: &beneficiaryBank, AccountNumber: &beneficiaryAccount},
+				{ID: uuid.New(), BankCode: &beneficiaryBank, AccountNumber: &beneficiaryAccount},
+				{ID: uuid.New(), BankCode: &beneficiaryBank, AccountNumber: &beneficiaryAccount},
+			},
+			wantInstructions: []int64{34, 33, 33},
+			wantPayeeType:    entity.PayeeTypeBeneficiary,
+		},
+		{
+			name:       "error - document still being scanned",
+			claimType:  entity.ClaimTypeNormalRetirement,
+			approverID: approverID,
+			employer:   100,
+			employee:   100,
+			documents:  claimDocuments(entity.FileScanStatusPending, "claim_form"),
+			errKind:    errors.KindBadRequest,
+		},
+		{
+			name:       "error - approver is the verifier",
+			claimType:  entity.ClaimTypeNormalRetirement,
+			approverID: verifierID,
+			employer:   100,
+			employee:   100,
+			errKind:    errors.KindForbidden,
+		},
+		{
+			name:       "error - no balance to pay out",
+			claimType:  entity.ClaimTypeResignation,
+			approverID: approverID,
+			errKind:    errors.KindBadRequest,
+		},
+		{
+			name:       "error - no primary bank account",
+			claimType:  entity.ClaimTypeResignation,
+			approverID: approverID,
+			employer:   100,
+			employee:   100,
+			bankAccounts: []*entity.ParticipantBankAccount{
+				{ID: uuid.New(), BankCode: "014", AccountNumber: "111", AccountHolderName: "Secondary", CurrencyCode: "IDR"},
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:          "error - death claim without payable beneficiary",
+			claimType:     entity.ClaimTypeDeath,
+			approverID:    approverID,
+			employer:      100,
+			employee:      100,
+			beneficiaries: []*entity.ParticipantBeneficiary{{ID: uuid.New()}},
+			errKind:       errors.KindBadRequest,
+		},
+		{
+			name:       "error - death claim with one beneficiary missing an account",
+			claimType:  entity.ClaimTypeDeath,
+			approverID: approverID,
+			employer:   100,
+			employee:   100,
+			beneficiaries: []*entity.ParticipantBeneficiary{
+				{ID: uuid.New(), BankCode: &beneficiaryBank, AccountNumber: &beneficiaryAccount},
+				{ID: uuid.New()},
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:       "error - death claim with beneficiary missing a bank code",
+			claimType:  entity.ClaimTypeDeath,
+			approverID: approverID,
+			employer:   100,
+			employee:   100,
+			beneficiaries: []*entity.ParticipantBeneficiary{
+				{ID: uuid.New(), AccountNumber: &beneficiaryAccount},
+			},
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			c := createClaim(tt.claimType, entity.ClaimStatusVerified, tenantID, productID)
+			c.VerifiedBy = &verifierID
+
+			members := make([]*entity.ParticipantFamilyMember, 0, len(tt.beneficiaries))
+			for i, b := range tt.beneficiaries {
+				b.FamilyMemberID = uuid.New()
+				members = append(members, &entity.ParticipantFamilyMember{ID: b.FamilyMemberID, FullName: "Heir " + string(rune('A'+i))})
+			}
+
+			var created []*entity.PaymentInstruction
+			m.claimRepo.On("GetByID", mock.Anything, c.ID).Return(c, nil)
+			m.ledgerRepo.On("GetBalance", mock.Anything, c.ParticipantID).Return(tt.employer, tt.employee, nil).Maybe()
+			m.bankAccountRepo.On("ListByParticipantID", mock.Anything, c.ParticipantID).Return(tt.bankAccounts, nil).Maybe()
+			m.beneficiaryRepo.On("ListByParticipantID", mock.Anything, c.ParticipantID).Return(tt.beneficiaries, nil).Maybe()
+			m.familyRepo.On("ListByParticipantID", mock.Anything, c.ParticipantID).Return(members, nil).Maybe()
+			m.claimRepo.On("Update", mock.Anything, c).Return(nil).Maybe()
+			m.historyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ClaimStatusHistory")).Return(nil).Maybe()
+			m.instructionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.PaymentInstruction")).
+				Run(func(args mock.Arguments) {
+					created = append(created, args.Get(1).(*entity.PaymentInstruction))
+				}).Return(nil).Maybe()
+			m.documentRepo.On("ListByClaimID", mock.Anything, c.ID).Return(tt.documents.list(), nil).Maybe()
+			tt.documents.expectFiles(m.fileRepo)
+			m.instructionRepo.On("ListByClaimID", mock.Anything, c.ID).Return([]*entity.PaymentInstruction{}, nil).Maybe()
+
+			uc := newTestUsecase(m)
+			resp, err := uc.ApproveClaim(context.Background(), &claim.ApproveClaimRequest{
+				TenantID:  tenantID,
+				ProductID: productID,
+				ClaimID:   c.ID,
+				UserID:    tt.approverID,
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				m.claimRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				m.instructionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ClaimStatusApproved), resp.Status)
+			assert.Equal(t, tt.employer+tt.employee, resp.TotalAmount)
+			require.Len(t, created, len(tt.wantInstructions))
+			for i, want := range tt.wantInstructions {
+				assert.Equal(t, want, created[i].Amount)
+				assert.Equal(t, tt.wantPayeeType, created[i].PayeeType)
+				assert.Equal(t, entity.PaymentInstructionStatusPending, created[i].Status)
+				require.NotNil(t, created[i].BankCode)
+			}
+			if tt.wantPayeeType == entity.PayeeTypeParticipant {
+				assert.Equal(t, "Primary", created[0].PayeeName)
+				require.NotNil(t, created[0].BankCode)
+				assert.Equal(t, "008", *created[0].BankCode)
+			}
+		})
+	}
+}
+
+// scannedDocuments pairs claim documents with the files behind them.
+type scannedDocuments map[*entity.ClaimDocument]*entity.File
+
+func claimDocuments(status entity.FileScanStatus, types ...string) scannedDocuments {
+	docs := make(scannedDocuments, len(types))
+	for _, dt := range types {
+		d := &entity.ClaimDocument{ID: uuid.New(), DocumentType: dt, FileID: uuid.New()}
+		docs[d] = &entity.File{ID: d.FileID, ScanStatus: status}
+	}
+	return docs
+}
+
+func (d scannedDocuments) list() []*entity.ClaimDocument {
+	list := make([]*entity.ClaimDocument, 0, len(d))
+	for doc := range d {
+		list = append(list, doc)
+	}
+	return list
+}
+
+func (d scannedDocuments) expectFiles(fileRepo *MockFileRepository) {
+	for doc, file := range d {
+		fileRepo.On("GetByID", mock.Anything, doc.FileID).Return(file, nil).Maybe()
+	}
+}
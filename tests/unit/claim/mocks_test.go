@@ -0,0 +1,285 @@
+package claim_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	_ claim.ClaimRepository              = (*MockClaimRepository)(nil)
+	_ claim.ClaimDocumentRepository      = (*MockClaimDocumentRepository)(nil)
+	_ claim.ClaimStatusHistoryRepository = (*MockClaimStatusHistoryRepository)(nil)
+	_ claim.PaymentInstructionRepository = (*MockPaymentInstructionRepository)(nil)
+	_ claim.ParticipantRepository        = (*MockParticipantRepository)(nil)
+	_ claim.BankAccountRepository        = (*MockBankAccountRepository)(nil)
+	_ claim.BeneficiaryRepository        = (*MockBeneficiaryRepository)(nil)
+	_ claim.FamilyMemberRepository       = (*MockFamilyMemberRepository)(nil)
+	_ claim.LedgerRepository             = (*MockLedgerRepository)(nil)
+	_ claim.FileRepository               = (*MockFileRepository)(nil)
+	_ claim.FileStorageAdapter           = (*MockFileStorage)(nil)
+)
+
+type testMocks struct {
+	txMgr           *MockTransactionManager
+	claimRepo       *MockClaimRepository
+	documentRepo    *MockClaimDocumentRepository
+	historyRepo     *MockClaimStatusHistoryRepository
+	instructionRepo *MockPaymentInstructionRepository
+	participantRepo *MockParticipantRepository
+	bankAccountRepo *MockBankAccountRepository
+	beneficiaryRepo *MockBeneficiaryRepository
+	familyRepo      *MockFamilyMemberRepository
+	ledgerRepo      *MockLedgerRepository
+	fileRepo        *MockFileRepository
+	fileStorage     *MockFileStorage
+}
+
+func newTestMocks() *testMocks {
+	m := &testMocks{
+		txMgr:           new(MockTransactionManager),
+		claimRepo:       new(MockClaimRepository),
+		documentRepo:    new(MockClaimDocumentRepository),
+		historyRepo:     new(MockClaimStatusHistoryRepository),
+		instructionRepo: new(MockPaymentInstructionRepository),
+		participantRepo: new(MockParticipantRepository),
+		bankAccountRepo: new(MockBankAccountRepository),
+		beneficiaryRepo: new(MockBeneficiaryRepository),
+		familyRepo:      new(MockFamilyMemberRepository),
+		ledgerRepo:      new(MockLedgerRepository),
+		fileRepo:        new(MockFileRepository),
+		fileStorage:     new(MockFileStorage),
+	}
+	m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+func newTestUsecase(m *testMocks) claim.Usecase {
+	return claim.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txMgr,
+		m.claimRepo,
+		m.documentRepo,
+		m.historyRepo,
+		m.instructionRepo,
+		m.participantRepo,
+		m.bankAccountRepo,
+		m.beneficiaryRepo,
+		m.familyRepo,
+		m.ledgerRepo,
+		m.fileRepo,
+		m.fileStorage,
+	)
+}
+
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if args.Get(0) == nil {
+		if fn != nil {
+			return fn(ctx)
+		}
+		return nil
+	}
+	return args.Error(0)
+}
+
+type MockClaimRepository struct {
+	mock.Mock
+}
+
+func (m *MockClaimRepository) Create(ctx context.Context, c *entity.Claim) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockClaimRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Claim, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Claim), args.Error(1)
+}
+
+func (m *MockClaimRepository) Update(ctx context.Context, c *entity.Claim) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockClaimRepository) List(ctx context.Context, filter *claim.ClaimFilter) ([]*entity.Claim, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.Claim), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockClaimRepository) HasInProgress(ctx context.Context, participantID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, participantID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockClaimDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *MockClaimDocumentRepository) Create(ctx context.Context, document *entity.ClaimDocument) error {
+	args := m.Called(ctx, document)
+	return args.Error(0)
+}
+
+func (m *MockClaimDocumentRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimDocument, error) {
+	args := m.Called(ctx, claimID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ClaimDocument), args.Error(1)
+}
+
+type MockClaimStatusHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockClaimStatusHistoryRepository) Create(ctx context.Context, history *entity.ClaimStatusHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *MockClaimStatusHistoryRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.ClaimStatusHistory, error) {
+	args := m.Called(ctx, claimID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ClaimStatusHistory), args.Error(1)
+}
+
+type MockPaymentInstructionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentInstructionRepository) Create(ctx context.Context, instruction *entity.PaymentInstruction) error {
+	args := m.Called(ctx, instruction)
+	return args.Error(0)
+}
+
+func (m *MockPaymentInstructionRepository) ListByClaimID(ctx context.Context, claimID uuid.UUID) ([]*entity.PaymentInstruction, error) {
+	args := m.Called(ctx, claimID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.PaymentInstruction), args.Error(1)
+}
+
+func (m *MockPaymentInstructionRepository) MarkPaidByClaimID(ctx context.Context, claimID uuid.UUID, reference string, paidAt time.Time) error {
+	args := m.Called(ctx, claimID, reference, paidAt)
+	return args.Error(0)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+type MockBankAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockBankAccountRepository) ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBankAccount, error) {
+	args := m.Called(ctx, participantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantBankAccount), args.Error(1)
+}
+
+type MockBeneficiaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockBeneficiaryRepository) ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantBeneficiary, error) {
+	args := m.Called(ctx, participantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantBeneficiary), args.Error(1)
+}
+
+type MockFamilyMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockFamilyMemberRepository) ListByParticipantID(ctx context.Context, participantID uuid.UUID) ([]*entity.ParticipantFamilyMember, error) {
+	args := m.Called(ctx, participantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantFamilyMember), args.Error(1)
+}
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) GetBalance(ctx context.Context, participantID uuid.UUID) (int64, int64, error) {
+	args := m.Called(ctx, participantID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLedgerRepository) Append(ctx context.Context, entry *entity.ContributionLedgerEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+type MockFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileRepository) Create(ctx context.Context, file *entity.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.File), args.Error(1)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error) {
+	args := m.Called(ctx, tenantID, bucket, objectKey, data, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envelope.Envelope), args.Error(1)
+}
+
+func (m *MockFileStorage) DeleteFile(ctx context.Context, bucket, objectKey string) error {
+	args := m.Called(ctx, bucket, objectKey)
+	return args.Error(0)
+}
@@ -0,0 +1,111 @@
+package claim_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createParticipant(status entity.ParticipantStatus, tenantID, productID uuid.UUID) *entity.Participant {
+	return &entity.Participant{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		ProductID: productID,
+		FullName:  "Test Participant",
+		Status:    status,
+	}
+}
+
+func createClaim(claimType entity.ClaimType, status entity.ClaimStatus, tenantID, productID uuid.UUID) *entity.Claim {
+	return &entity.Claim{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: uuid.New(),
+		ClaimType:     claimType,
+		Status:        status,
+		EventDate:     time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		OpenedBy:      uuid.New(),
+		Version:       1,
+	}
+}
+
+func assertAppErrorKind(t *testing.T, err error, kind errors.Kind) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, kind, appErr.Kind)
+}
+
+func TestUsecase_OpenClaim(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	tests := []struct {
+		name       string
+		status     entity.ParticipantStatus
+		claimType  string
+		eventDate  time.Time
+		tenantID   uuid.UUID
+		inProgress bool
+		errKind    errors.Kind
+	}{
+		{name: "success - retirement claim for active participant", status: entity.ParticipantStatusActive, claimType: "NORMAL_RETIREMENT", eventDate: yesterday, tenantID: tenantID},
+		{name: "success - death claim for deceased participant", status: entity.ParticipantStatusDeceased, claimType: "DEATH", eventDate: yesterday, tenantID: tenantID},
+		{name: "error - death claim for living participant", status: entity.ParticipantStatusActive, claimType: "DEATH", eventDate: yesterday, tenantID: tenantID, errKind: errors.KindBadRequest},
+		{name: "error - participant not yet approved", status: entity.ParticipantStatusPendingApproval, claimType: "RESIGNATION", eventDate: yesterday, tenantID: tenantID, errKind: errors.KindBadRequest},
+		{name: "error - event date in the future", status: entity.ParticipantStatusActive, claimType: "RESIGNATION", eventDate: time.Now().AddDate(0, 0, 2), tenantID: tenantID, errKind: errors.KindBadRequest},
+		{name: "error - other tenant", status: entity.ParticipantStatusActive, claimType: "RESIGNATION", eventDate: yesterday, tenantID: uuid.New(), errKind: errors.KindForbidden},
+		{name: "error - claim already in progress", status: entity.ParticipantStatusActive, claimType: "RESIGNATION", eventDate: yesterday, tenantID: tenantID, inProgress: true, errKind: errors.KindDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			participant := createParticipant(tt.status, tenantID, productID)
+
+			m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil).Maybe()
+			m.claimRepo.On("HasInProgress", mock.Anything, participant.ID).Return(tt.inProgress, nil).Maybe()
+			m.claimRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Claim")).Return(nil).Maybe()
+			m.historyRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *entity.ClaimStatusHistory) bool {
+				return h.FromStatus == nil && h.ToStatus == string(entity.ClaimStatusOpen)
+			})).Return(nil).Maybe()
+			m.documentRepo.On("ListByClaimID", mock.Anything, mock.Anything).Return([]*entity.ClaimDocument{}, nil).Maybe()
+			m.instructionRepo.On("ListByClaimID", mock.Anything, mock.Anything).Return([]*entity.PaymentInstruction{}, nil).Maybe()
+
+			uc := newTestUsecase(m)
+			resp, err := uc.OpenClaim(context.Background(), &claim.OpenClaimRequest{
+				TenantID:      tt.tenantID,
+				ProductID:     productID,
+				UserID:        userID,
+				ParticipantID: participant.ID,
+				ClaimType:     tt.claimType,
+				EventDate:     tt.eventDate,
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				m.claimRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ClaimStatusOpen), resp.Status)
+			assert.Equal(t, entity.ClaimType(tt.claimType).RequiredDocuments(), resp.RequiredDocuments)
+			assert.Equal(t, resp.RequiredDocuments, resp.MissingDocuments)
+			m.historyRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package claim_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_PayClaim(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		status    entity.ClaimStatus
+		employer  int64
+		employee  int64
+		documents scannedDocuments
+		errKind   errors.Kind
+	}{
+		{name: "success - posts payout and marks instructions paid", status: entity.ClaimStatusApproved, employer: 60000000, employee: 40000000, documents: claimDocuments(entity.FileScanStatusClean, "claim_form")},
+		{name: "error - infected document", status: entity.ClaimStatusApproved, employer: 60000000, employee: 40000000, documents: claimDocuments(entity.FileScanStatusInfected, "claim_form"), errKind: errors.KindBadRequest},
+		{name: "error - claim not approved", status: entity.ClaimStatusVerified, employer: 60000000, employee: 40000000, errKind: errors.KindBadRequest},
+		{name: "error - balance reduced since approval", status: entity.ClaimStatusApproved, employer: 50000000, employee: 40000000, errKind: errors.KindDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			c := createClaim(entity.ClaimTypeNormalRetirement, tt.status, tenantID, productID)
+			approvedAt := time.Now().Add(-time.Hour)
+			c.ApprovedAt = &approvedAt
+			c.EmployerAmount = 60000000
+			c.EmployeeAmount = 40000000
+
+			m.claimRepo.On("GetByID", mock.Anything, c.ID).Return(c, nil)
+			m.ledgerRepo.On("GetBalance", mock.Anything, c.ParticipantID).Return(tt.employer, tt.employee, nil).Maybe()
+			m.ledgerRepo.On("Append", mock.Anything, mock.MatchedBy(func(e *entity.ContributionLedgerEntry) bool {
+				return e.EntryType == entity.ContributionEntryTypePayout &&
+					e.ParticipantID == c.ParticipantID &&
+					e.EmployerAmount == -c.EmployerAmount &&
+					e.EmployeeAmount == -c.EmployeeAmount &&
+					e.Reference != nil && *e.Reference == "TRX-0001"
+			})).Return(nil).Maybe()
+			m.instructionRepo.On("MarkPaidByClaimID", mock.Anything, c.ID, "TRX-0001", mock.AnythingOfType("time.Time")).Return(nil).Maybe()
+			m.claimRepo.On("Update", mock.Anything, c).Return(nil).Maybe()
+			m.historyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ClaimStatusHistory")).Return(nil).Maybe()
+			m.documentRepo.On("ListByClaimID", mock.Anything, c.ID).Return(tt.documents.list(), nil).Maybe()
+			tt.documents.expectFiles(m.fileRepo)
+			m.instructionRepo.On("ListByClaimID", mock.Anything, c.ID).Return([]*entity.PaymentInstruction{}, nil).Maybe()
+
+			uc := newTestUsecase(m)
+			resp, err := uc.PayClaim(context.Background(), &claim.PayClaimRequest{
+				TenantID:         tenantID,
+				ProductID:        productID,
+				ClaimID:          c.ID,
+				UserID:           userID,
+				PaymentReference: "TRX-0001",
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				m.ledgerRepo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ClaimStatusPaid), resp.Status)
+			require.NotNil(t, resp.PaymentReference)
+			assert.Equal(t, "TRX-0001", *resp.PaymentReference)
+			m.ledgerRepo.AssertExpectations(t)
+			m.instructionRepo.AssertCalled(t, "MarkPaidByClaimID", mock.Anything, c.ID, "TRX-0001", mock.AnythingOfType("time.Time"))
+		})
+	}
+}
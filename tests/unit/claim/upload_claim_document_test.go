@@ -0,0 +1,85 @@
+package claim_test
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+	"erp-service/saving/claim"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_UploadClaimDocument(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		status       entity.ClaimStatus
+		documentType string
+		createErr    error
+		wantCleanup  bool
+		errKind      errors.Kind
+	}{
+		{name: "success - required document attached", status: entity.ClaimStatusOpen, documentType: "death_certificate"},
+		{name: "error - document not required for claim type", status: entity.ClaimStatusOpen, documentType: "resignation_letter", errKind: errors.KindBadRequest},
+		{name: "error - claim already verified", status: entity.ClaimStatusVerified, documentType: "death_certificate", errKind: errors.KindBadRequest},
+		{name: "error - storage object removed when metadata insert fails", status: entity.ClaimStatusOpen, documentType: "death_certificate", createErr: stderrors.New("db down"), wantCleanup: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			c := createClaim(entity.ClaimTypeDeath, tt.status, tenantID, productID)
+
+			m.claimRepo.On("GetByID", mock.Anything, c.ID).Return(c, nil)
+			var storedKey string
+			m.fileStorage.On("UploadEncryptedFile", mock.Anything, tenantID, "claims", mock.Anything, mock.Anything, int64(4)).
+				Run(func(args mock.Arguments) { storedKey = args.String(3) }).
+				Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}, nil).Maybe()
+			m.fileStorage.On("DeleteFile", mock.Anything, "claims", mock.Anything).Return(nil).Maybe()
+			m.fileRepo.On("Create", mock.Anything, mock.MatchedBy(func(f *entity.File) bool {
+				return f.ExpiresAt == nil && f.StorageKey == storedKey &&
+					f.WrappedDataKey != nil && *f.WrappedDataKey == "wrapped"
+			})).Return(tt.createErr).Maybe()
+			m.documentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ClaimDocument")).Return(nil).Maybe()
+
+			uc := newTestUsecase(m)
+			resp, err := uc.UploadClaimDocument(context.Background(), &claim.UploadClaimDocumentRequest{
+				TenantID:     tenantID,
+				ProductID:    productID,
+				ClaimID:      c.ID,
+				UploadedBy:   userID,
+				DocumentType: tt.documentType,
+				FileName:     "cert.pdf",
+				ContentType:  "application/pdf",
+				Reader:       bytes.NewReader([]byte("%PDF")),
+				Size:         4,
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				m.fileStorage.AssertNotCalled(t, "UploadEncryptedFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			if tt.wantCleanup {
+				require.Error(t, err)
+				m.fileStorage.AssertCalled(t, "DeleteFile", mock.Anything, "claims", storedKey)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.documentType, resp.DocumentType)
+			m.fileStorage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
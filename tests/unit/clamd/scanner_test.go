@@ -0,0 +1,164 @@
+package clamd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	"erp-service/files"
+	"erp-service/impl/clamd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd accepts INSTREAM sessions, reassembles the chunks and answers
+// with the given reply.
+type fakeClamd struct {
+	listener net.Listener
+	received chan []byte
+	reply    func(data []byte) string
+}
+
+func defaultReply(data []byte) string {
+	if bytes.Contains(data, []byte("EICAR-STANDARD-ANTIVIRUS-TEST-FILE")) {
+		return "stream: Eicar-Test-Signature FOUND"
+	}
+	return "stream: OK"
+}
+
+func startFakeClamd(t *testing.T, network, address string, reply func(data []byte) string) *fakeClamd {
+	t.Helper()
+	l, err := net.Listen(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	f := &fakeClamd{
+		listener: l,
+		received: make(chan []byte, 1),
+		reply:    reply,
+	}
+	go f.serve()
+	return f
+}
+
+func (f *fakeClamd) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+
+	cmd := make([]byte, len("zINSTREAM\x00"))
+	if _, err := io.ReadFull(conn, cmd); err != nil || string(cmd) != "zINSTREAM\x00" {
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var data []byte
+	size := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+		chunk := make([]byte, n)
+		if _, err := io.ReadFull(conn, chunk); err != nil {
+			return
+		}
+		data = append(data, chunk...)
+	}
+
+	f.received <- data
+	_, _ = conn.Write([]byte(f.reply(data) + "\x00"))
+}
+
+func newScanner(t *testing.T, address string) files.MalwareScanner {
+	t.Helper()
+	s, err := clamd.NewScanner(config.ClamdConfig{Address: address, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	return s
+}
+
+func TestScanner_TCP_Clean(t *testing.T) {
+	f := startFakeClamd(t, "tcp", "127.0.0.1:0", defaultReply)
+	s := newScanner(t, "tcp://"+f.listener.Addr().String())
+
+	// Larger than one chunk so the stream is split.
+	payload := bytes.Repeat([]byte("%PDF-1.7 clean "), 10000)
+	verdict, err := s.Scan(context.Background(), bytes.NewReader(payload))
+
+	require.NoError(t, err)
+	assert.False(t, verdict.Infected)
+	assert.Equal(t, payload, <-f.received)
+}
+
+func TestScanner_TCP_Infected(t *testing.T) {
+	f := startFakeClamd(t, "tcp", "127.0.0.1:0", defaultReply)
+	s := newScanner(t, "tcp://"+f.listener.Addr().String())
+
+	verdict, err := s.Scan(context.Background(), strings.NewReader(eicar))
+
+	require.NoError(t, err)
+	assert.True(t, verdict.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", verdict.Signature)
+}
+
+func TestScanner_UnixSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "clamd.sock")
+	f := startFakeClamd(t, "unix", sock, defaultReply)
+	s := newScanner(t, "unix://"+sock)
+
+	verdict, err := s.Scan(context.Background(), strings.NewReader(eicar))
+
+	require.NoError(t, err)
+	assert.True(t, verdict.Infected)
+	<-f.received
+}
+
+func TestScanner_ErrorReply(t *testing.T) {
+	f := startFakeClamd(t, "tcp", "127.0.0.1:0", func([]byte) string {
+		return "INSTREAM size limit exceeded. ERROR"
+	})
+	s := newScanner(t, "tcp://"+f.listener.Addr().String())
+
+	_, err := s.Scan(context.Background(), strings.NewReader("data"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "size limit exceeded")
+}
+
+func TestScanner_Unreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	s := newScanner(t, "tcp://"+addr)
+	_, err = s.Scan(context.Background(), strings.NewReader("data"))
+
+	require.Error(t, err)
+}
+
+func TestNewScanner_InvalidAddress(t *testing.T) {
+	_, err := clamd.NewScanner(config.ClamdConfig{Address: "localhost:3310"})
+	require.Error(t, err)
+}
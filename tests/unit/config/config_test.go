@@ -0,0 +1,28 @@
+package config_test
+
+import (
+	"testing"
+
+	"erp-service/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_BankVerificationProvider(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("BANK_VERIFICATION_PROVIDER", "")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Infra.BankVerification.Provider, "the provider has no default")
+
+	t.Setenv("BANK_VERIFICATION_PROVIDER", "fake")
+	cfg, err = config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "fake", cfg.Infra.BankVerification.Provider)
+
+	t.Setenv("APP_ENV", "production")
+	_, err = config.Load()
+	require.ErrorContains(t, err, "BANK_VERIFICATION_PROVIDER 'fake' cannot be used in production")
+}
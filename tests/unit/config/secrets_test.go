@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"erp-service/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecrets struct {
+	secrets map[string]map[string]interface{}
+	reads   map[string]int
+}
+
+func (f *fakeSecrets) ReadSecret(_ context.Context, path string) (map[string]interface{}, error) {
+	f.reads[path]++
+	data, ok := f.secrets[path]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+	return data, nil
+}
+
+func (f *fakeSecrets) open(config.VaultConfig) (config.SecretReader, error) {
+	return f, nil
+}
+
+func newFakeSecrets() *fakeSecrets {
+	return &fakeSecrets{
+		secrets: map[string]map[string]interface{}{
+			"erp/jwt":      {"access_secret": "access-from-vault", "refresh_secret": "refresh-from-vault"},
+			"erp/postgres": {"password": "pg-from-vault"},
+		},
+		reads: map[string]int{},
+	}
+}
+
+func setBaseEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("JWT_SIGNING_METHOD", "HS256")
+	t.Setenv("JWT_ACCESS_SECRET", "plain-access")
+	t.Setenv("JWT_REFRESH_SECRET", "plain-refresh")
+	t.Setenv("POSTGRES_USER", "erp")
+	t.Setenv("POSTGRES_PASSWORD", "plain-password")
+	t.Setenv("POSTGRES_VAULT_ROLE", "")
+	t.Setenv("EMAIL_SMTP_PASS", "")
+}
+
+func TestLoad_ResolvesVaultReferences(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("JWT_ACCESS_SECRET", "vault:erp/jwt#access_secret")
+	t.Setenv("JWT_REFRESH_SECRET", "vault:erp/jwt#refresh_secret")
+	t.Setenv("POSTGRES_PASSWORD", "vault:erp/postgres#password")
+	secrets := newFakeSecrets()
+
+	cfg, err := config.Load(config.WithSecrets(secrets.open))
+	require.NoError(t, err)
+
+	assert.Equal(t, "access-from-vault", cfg.JWT.AccessSecret)
+	assert.Equal(t, "refresh-from-vault", cfg.JWT.RefreshSecret)
+	assert.Equal(t, "pg-from-vault", cfg.Infra.Postgres.Platform.Password)
+	assert.Equal(t, "erp", cfg.Infra.Postgres.Platform.User, "plain values are left alone")
+	assert.Equal(t, 1, secrets.reads["erp/jwt"], "each secret path is read once")
+}
+
+func TestLoad_VaultReferenceErrors(t *testing.T) {
+	t.Run("without a secret reader", func(t *testing.T) {
+		setBaseEnv(t)
+		t.Setenv("POSTGRES_PASSWORD", "vault:erp/postgres#password")
+
+		_, err := config.Load()
+		assert.ErrorContains(t, err, "vault: references")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		setBaseEnv(t)
+		t.Setenv("EMAIL_SMTP_PASS", "vault:erp/postgres#smtp")
+
+		_, err := config.Load(config.WithSecrets(newFakeSecrets().open))
+		assert.ErrorContains(t, err, `no string key "smtp"`)
+	})
+
+	t.Run("malformed reference", func(t *testing.T) {
+		setBaseEnv(t)
+		t.Setenv("POSTGRES_PASSWORD", "vault:erp/postgres")
+
+		_, err := config.Load(config.WithSecrets(newFakeSecrets().open))
+		assert.ErrorContains(t, err, "vault:<path>#<key>")
+	})
+}
+
+func TestLoad_DynamicCredentialsNeedNoStaticPassword(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("POSTGRES_USER", "")
+	t.Setenv("POSTGRES_PASSWORD", "")
+
+	_, err := config.Load()
+	require.ErrorContains(t, err, "POSTGRES_USER is required")
+
+	t.Setenv("POSTGRES_VAULT_ROLE", "erp-app")
+	_, err = config.Load()
+	require.ErrorContains(t, err, "MIGRATION_ROLE is required")
+
+	t.Setenv("MIGRATION_ROLE", "erp_owner")
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "erp-app", cfg.Infra.Postgres.Platform.VaultRole)
+	assert.Equal(t, "erp_owner", cfg.Migration.Role)
+}
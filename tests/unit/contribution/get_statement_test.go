@@ -0,0 +1,67 @@
+package contribution_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_GetStatement(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("computes opening, running and closing balances", func(t *testing.T) {
+		partRepo := new(MockParticipantRepository)
+		ledgerRepo := new(MockLedgerRepository)
+		p := createParticipant(entity.ParticipantStatusActive, tenantID, productID)
+		from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+		partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+		ledgerRepo.On("SumBefore", mock.Anything, p.ID, from).Return(int64(1000), int64(500), nil)
+		ledgerRepo.On("ListForStatement", mock.Anything, mock.MatchedBy(func(f *contribution.StatementFilter) bool {
+			return f.ParticipantID == p.ID && f.From != nil && f.From.Equal(from) && f.To == nil
+		})).Return([]*entity.ContributionLedgerEntry{
+			{ID: uuid.New(), EntryType: entity.ContributionEntryTypeContribution, EmployerAmount: 200, EmployeeAmount: 100},
+			{ID: uuid.New(), EntryType: entity.ContributionEntryTypeContribution, EmployerAmount: 300, EmployeeAmount: 150},
+			{ID: uuid.New(), EntryType: entity.ContributionEntryTypeReversal, EmployerAmount: -200, EmployeeAmount: -100},
+		}, nil)
+
+		uc := newTestUsecase(new(MockTransactionManager), partRepo, ledgerRepo, new(MockImportBatchRepository))
+		resp, err := uc.GetStatement(context.Background(), &contribution.GetStatementRequest{
+			TenantID: tenantID, ProductID: productID, ParticipantID: p.ID, From: &from,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500), resp.OpeningBalance.Total)
+		assert.Equal(t, int64(300), resp.Movements.Employer)
+		assert.Equal(t, int64(150), resp.Movements.Employee)
+		assert.Equal(t, int64(1950), resp.ClosingBalance.Total)
+		require.Len(t, resp.Entries, 3)
+		assert.Equal(t, int64(1200), resp.Entries[0].EmployerBalance)
+		assert.Equal(t, int64(2250), resp.Entries[1].TotalBalance)
+	})
+
+	t.Run("rejects participant from another product", func(t *testing.T) {
+		partRepo := new(MockParticipantRepository)
+		p := createParticipant(entity.ParticipantStatusActive, tenantID, uuid.New())
+		partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+
+		uc := newTestUsecase(new(MockTransactionManager), partRepo, new(MockLedgerRepository), new(MockImportBatchRepository))
+		_, err := uc.GetStatement(context.Background(), &contribution.GetStatementRequest{
+			TenantID: tenantID, ProductID: productID, ParticipantID: p.ID,
+		})
+
+		var appErr *errors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errors.KindForbidden, appErr.Kind)
+	})
+}
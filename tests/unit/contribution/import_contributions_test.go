@@ -0,0 +1,176 @@
+package contribution_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestUsecase(txMgr *MockTransactionManager, partRepo *MockParticipantRepository, ledgerRepo *MockLedgerRepository, batchRepo *MockImportBatchRepository) contribution.Usecase {
+	return contribution.NewUsecase(&config.Config{}, zap.NewNop(), txMgr, partRepo, ledgerRepo, batchRepo)
+}
+
+func createParticipant(status entity.ParticipantStatus, tenantID, productID uuid.UUID) *entity.Participant {
+	return &entity.Participant{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		ProductID: productID,
+		FullName:  "Test Participant",
+		Status:    status,
+	}
+}
+
+func TestUsecase_ImportContributions(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	period := time.Now().AddDate(0, -1, 0).Format("2006-01")
+
+	payroll := []byte("employee_number,employer_amount,employee_amount\nEMP001,100000,50000\nEMP002,200000,100000\n")
+
+	tests := []struct {
+		name       string
+		req        *contribution.ImportContributionsRequest
+		setup      func(*MockParticipantRepository, *MockLedgerRepository, *MockImportBatchRepository)
+		wantStatus string
+		wantErrors int
+		errKind    errors.Kind
+	}{
+		{
+			name: "success - posts every row",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: period, FileName: "payroll.csv", Content: payroll,
+			},
+			setup: func(partRepo *MockParticipantRepository, ledgerRepo *MockLedgerRepository, batchRepo *MockImportBatchRepository) {
+				batchRepo.On("ExistsPostedChecksum", mock.Anything, tenantID, productID, mock.Anything).Return(false, nil)
+				partRepo.On("GetByEmployeeNumber", mock.Anything, tenantID, productID, mock.Anything).
+					Return(createParticipant(entity.ParticipantStatusActive, tenantID, productID), nil)
+				ledgerRepo.On("LockParticipant", mock.Anything, mock.Anything).Return(nil).Twice()
+				ledgerRepo.On("HasContributionForPeriod", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				batchRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *entity.ContributionImportBatch) bool {
+					return b.IsPosted() && b.PostedRows == 2 &&
+						b.TotalEmployerAmount == 30000000 && b.TotalEmployeeAmount == 15000000
+				})).Return(nil)
+				ledgerRepo.On("Append", mock.Anything, mock.MatchedBy(func(e *entity.ContributionLedgerEntry) bool {
+					return e.EntryType == entity.ContributionEntryTypeContribution && e.BatchID != nil
+				})).Return(nil).Twice()
+			},
+			wantStatus: string(entity.ContributionImportStatusPosted),
+		},
+		{
+			name: "rejected - unknown employee and inactive participant",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: period, FileName: "payroll.csv", Content: payroll,
+			},
+			setup: func(partRepo *MockParticipantRepository, ledgerRepo *MockLedgerRepository, batchRepo *MockImportBatchRepository) {
+				batchRepo.On("ExistsPostedChecksum", mock.Anything, tenantID, productID, mock.Anything).Return(false, nil)
+				partRepo.On("GetByEmployeeNumber", mock.Anything, tenantID, productID, "EMP001").
+					Return(nil, errors.ErrNotFound("participant not found"))
+				partRepo.On("GetByEmployeeNumber", mock.Anything, tenantID, productID, "EMP002").
+					Return(createParticipant(entity.ParticipantStatusSuspended, tenantID, productID), nil)
+				batchRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *entity.ContributionImportBatch) bool {
+					return b.Status == entity.ContributionImportStatusRejected && b.PostedRows == 0
+				})).Return(nil)
+			},
+			wantStatus: string(entity.ContributionImportStatusRejected),
+			wantErrors: 2,
+		},
+		{
+			name: "rejected - contribution already posted for period",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: period, FileName: "payroll.csv", Content: payroll,
+			},
+			setup: func(partRepo *MockParticipantRepository, ledgerRepo *MockLedgerRepository, batchRepo *MockImportBatchRepository) {
+				batchRepo.On("ExistsPostedChecksum", mock.Anything, tenantID, productID, mock.Anything).Return(false, nil)
+				partRepo.On("GetByEmployeeNumber", mock.Anything, tenantID, productID, mock.Anything).
+					Return(createParticipant(entity.ParticipantStatusActive, tenantID, productID), nil)
+				ledgerRepo.On("LockParticipant", mock.Anything, mock.Anything).Return(nil)
+				ledgerRepo.On("HasContributionForPeriod", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				batchRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantStatus: string(entity.ContributionImportStatusRejected),
+			wantErrors: 2,
+		},
+		{
+			name: "error - file already posted",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: period, FileName: "payroll.csv", Content: payroll,
+			},
+			setup: func(partRepo *MockParticipantRepository, ledgerRepo *MockLedgerRepository, batchRepo *MockImportBatchRepository) {
+				batchRepo.On("ExistsPostedChecksum", mock.Anything, tenantID, productID, mock.Anything).Return(true, nil)
+			},
+			errKind: errors.KindDuplicate,
+		},
+		{
+			name: "error - future period",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: time.Now().AddDate(0, 2, 0).Format("2006-01"), FileName: "payroll.csv", Content: payroll,
+			},
+			setup:   func(*MockParticipantRepository, *MockLedgerRepository, *MockImportBatchRepository) {},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name: "error - empty payroll file",
+			req: &contribution.ImportContributionsRequest{
+				TenantID: tenantID, ProductID: productID, UserID: userID,
+				Period: period, FileName: "payroll.csv", Content: []byte("employee_number,employer_amount,employee_amount\n"),
+			},
+			setup:   func(*MockParticipantRepository, *MockLedgerRepository, *MockImportBatchRepository) {},
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txMgr := new(MockTransactionManager)
+			partRepo := new(MockParticipantRepository)
+			ledgerRepo := new(MockLedgerRepository)
+			batchRepo := new(MockImportBatchRepository)
+
+			txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tt.setup(partRepo, ledgerRepo, batchRepo)
+
+			uc := newTestUsecase(txMgr, partRepo, ledgerRepo, batchRepo)
+			resp, err := uc.ImportContributions(context.Background(), tt.req)
+
+			if tt.errKind != 0 {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				ledgerRepo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, 2, resp.TotalRows)
+			assert.Len(t, resp.RowErrors, tt.wantErrors)
+			if tt.wantErrors > 0 {
+				ledgerRepo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+			}
+
+			partRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+			batchRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package contribution_test
+
+import (
+	"context"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ contribution.ParticipantRepository = (*MockParticipantRepository)(nil)
+	_ contribution.LedgerRepository      = (*MockLedgerRepository)(nil)
+	_ contribution.ImportBatchRepository = (*MockImportBatchRepository)(nil)
+)
+
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if args.Get(0) == nil {
+		if fn != nil {
+			return fn(ctx)
+		}
+		return nil
+	}
+	return args.Error(0)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+func (m *MockParticipantRepository) GetByEmployeeNumber(ctx context.Context, tenantID, productID uuid.UUID, employeeNumber string) (*entity.Participant, error) {
+	args := m.Called(ctx, tenantID, productID, employeeNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) Append(ctx context.Context, entry *entity.ContributionLedgerEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) LockParticipant(ctx context.Context, participantID uuid.UUID) error {
+	args := m.Called(ctx, participantID)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionLedgerEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ContributionLedgerEntry), args.Error(1)
+}
+
+func (m *MockLedgerRepository) IsReversed(ctx context.Context, entryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, entryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLedgerRepository) HasContributionForPeriod(ctx context.Context, participantID uuid.UUID, period time.Time) (bool, error) {
+	args := m.Called(ctx, participantID, period)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLedgerRepository) SumBefore(ctx context.Context, participantID uuid.UUID, before time.Time) (int64, int64, error) {
+	args := m.Called(ctx, participantID, before)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLedgerRepository) GetBalance(ctx context.Context, participantID uuid.UUID) (int64, int64, error) {
+	args := m.Called(ctx, participantID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLedgerRepository) ListForStatement(ctx context.Context, filter *contribution.StatementFilter) ([]*entity.ContributionLedgerEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ContributionLedgerEntry), args.Error(1)
+}
+
+type MockImportBatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportBatchRepository) Create(ctx context.Context, batch *entity.ContributionImportBatch) error {
+	args := m.Called(ctx, batch)
+	return args.Error(0)
+}
+
+func (m *MockImportBatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ContributionImportBatch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ContributionImportBatch), args.Error(1)
+}
+
+func (m *MockImportBatchRepository) ExistsPostedChecksum(ctx context.Context, tenantID, productID uuid.UUID, checksum string) (bool, error) {
+	args := m.Called(ctx, tenantID, productID, checksum)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImportBatchRepository) List(ctx context.Context, filter *contribution.ImportBatchFilter) ([]*entity.ContributionImportBatch, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.ContributionImportBatch), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,99 @@
+package contribution_test
+
+import (
+	"testing"
+
+	"erp-service/saving/contribution"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1500000", want: 150000000},
+		{in: "1500000.5", want: 150000050},
+		{in: "0.05", want: 5},
+		{in: "0", want: 0},
+		{in: "", wantErr: true},
+		{in: "-10", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "1,500", wantErr: true},
+		{in: "12.", wantErr: true},
+		{in: "1234567890123456", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := contribution.ParseAmount(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePayrollFile(t *testing.T) {
+	t.Run("parses comma separated file with BOM and optional reference", func(t *testing.T) {
+		content := "\xef\xbb\xbfEmployee_Number,employer_amount,employee_amount,reference\n" +
+			"EMP001,100000,50000.25,PAY-09\n" +
+			"EMP002,200000,0,\n"
+
+		file, err := contribution.ParsePayrollFile([]byte(content))
+		require.NoError(t, err)
+		assert.Equal(t, 2, file.TotalRows)
+		assert.Empty(t, file.Errors)
+		require.Len(t, file.Rows, 2)
+		assert.Equal(t, 2, file.Rows[0].Row)
+		assert.Equal(t, "EMP001", file.Rows[0].EmployeeNumber)
+		assert.Equal(t, int64(10000000), file.Rows[0].EmployerAmount)
+		assert.Equal(t, int64(5000025), file.Rows[0].EmployeeAmount)
+		require.NotNil(t, file.Rows[0].Reference)
+		assert.Equal(t, "PAY-09", *file.Rows[0].Reference)
+		assert.Nil(t, file.Rows[1].Reference)
+	})
+
+	t.Run("accepts semicolon delimiter", func(t *testing.T) {
+		content := "employee_number;employer_amount;employee_amount\nEMP001;100;50\n"
+
+		file, err := contribution.ParsePayrollFile([]byte(content))
+		require.NoError(t, err)
+		require.Len(t, file.Rows, 1)
+		assert.Equal(t, int64(10000), file.Rows[0].EmployerAmount)
+	})
+
+	t.Run("collects row errors", func(t *testing.T) {
+		content := "employee_number,employer_amount,employee_amount\n" +
+			"EMP001,100,50\n" +
+			",100,50\n" +
+			"EMP003,abc,50\n" +
+			"EMP004,0,0\n" +
+			"EMP001,10,5\n"
+
+		file, err := contribution.ParsePayrollFile([]byte(content))
+		require.NoError(t, err)
+		assert.Equal(t, 5, file.TotalRows)
+		assert.Len(t, file.Rows, 1)
+		require.Len(t, file.Errors, 4)
+		assert.Equal(t, 3, file.Errors[0].Row)
+		assert.Equal(t, "EMP003", file.Errors[1].EmployeeNumber)
+		assert.Contains(t, file.Errors[3].Message, "row 2")
+	})
+
+	t.Run("rejects file missing a required column", func(t *testing.T) {
+		_, err := contribution.ParsePayrollFile([]byte("employee_number,employer_amount\nEMP001,100\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects empty file", func(t *testing.T) {
+		_, err := contribution.ParsePayrollFile([]byte(""))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,92 @@
+package contribution_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/contribution"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_ReverseEntry(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	newEntry := func(entryType entity.ContributionEntryType) *entity.ContributionLedgerEntry {
+		return &entity.ContributionLedgerEntry{
+			ID:             uuid.New(),
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantID:  uuid.New(),
+			EntryType:      entryType,
+			Period:         time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+			PostingDate:    time.Date(2026, 9, 5, 0, 0, 0, 0, time.UTC),
+			EmployerAmount: 10000000,
+			EmployeeAmount: 5000000,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		entry    *entity.ContributionLedgerEntry
+		tenantID uuid.UUID
+		reversed bool
+		errKind  errors.Kind
+	}{
+		{name: "success - posts negating entry", entry: newEntry(entity.ContributionEntryTypeContribution), tenantID: tenantID},
+		{name: "error - other tenant", entry: newEntry(entity.ContributionEntryTypeContribution), tenantID: uuid.New(), errKind: errors.KindForbidden},
+		{name: "error - reversal cannot be reversed", entry: newEntry(entity.ContributionEntryTypeReversal), tenantID: tenantID, errKind: errors.KindBadRequest},
+		{name: "error - already reversed", entry: newEntry(entity.ContributionEntryTypeContribution), tenantID: tenantID, reversed: true, errKind: errors.KindDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txMgr := new(MockTransactionManager)
+			partRepo := new(MockParticipantRepository)
+			ledgerRepo := new(MockLedgerRepository)
+			batchRepo := new(MockImportBatchRepository)
+
+			txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+			ledgerRepo.On("GetByID", mock.Anything, tt.entry.ID).Return(tt.entry, nil)
+			ledgerRepo.On("IsReversed", mock.Anything, tt.entry.ID).Return(tt.reversed, nil).Maybe()
+			ledgerRepo.On("Append", mock.Anything, mock.MatchedBy(func(e *entity.ContributionLedgerEntry) bool {
+				return e.EntryType == entity.ContributionEntryTypeReversal &&
+					e.ReversesEntryID != nil && *e.ReversesEntryID == tt.entry.ID &&
+					e.EmployerAmount == -tt.entry.EmployerAmount &&
+					e.EmployeeAmount == -tt.entry.EmployeeAmount &&
+					e.Period.Equal(tt.entry.Period)
+			})).Return(nil).Maybe()
+
+			uc := newTestUsecase(txMgr, partRepo, ledgerRepo, batchRepo)
+			resp, err := uc.ReverseEntry(context.Background(), &contribution.ReverseEntryRequest{
+				TenantID:  tt.tenantID,
+				ProductID: productID,
+				EntryID:   tt.entry.ID,
+				UserID:    userID,
+				Reason:    "employer reported wrong salary base",
+			})
+
+			if tt.errKind != 0 {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				ledgerRepo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ContributionEntryTypeReversal), resp.EntryType)
+			assert.Equal(t, int64(-15000000), resp.TotalAmount)
+			ledgerRepo.AssertExpectations(t)
+		})
+	}
+}
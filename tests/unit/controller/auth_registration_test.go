@@ -121,6 +121,14 @@ func (m *MockAuthUsecase) GetLoginStatus(ctx context.Context, req *auth.GetLogin
 	return args.Get(0).(*auth.LoginStatusResponse), args.Error(1)
 }
 
+func (m *MockAuthUsecase) CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.CheckPermissionResponse), args.Error(1)
+}
+
 func setupTestApp() *fiber.App {
 	return fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -0,0 +1,134 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+	"erp-service/delivery/http/router"
+	"erp-service/impl/localfs"
+	"erp-service/saving/participant"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLocalStorageApp(t *testing.T) (*fiber.App, participant.FileStorageAdapter) {
+	t.Helper()
+	root := t.TempDir()
+	signer, err := localfs.NewSigner([]byte(strings.Repeat("s", 32)), "http://files.test")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	router.SetupLocalStorageRoutes(app, controller.NewLocalStorageController(localfs.NewServer(root, signer)))
+	return app, localfs.NewFileStorage(root, signer, nil)
+}
+
+func postForm(t *testing.T, app *fiber.App, target string, fields map[string]string, contentType string, content []byte) int {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		require.NoError(t, w.WriteField(k, v))
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="`+localfs.FormFile+`"; filename="upload.png"`)
+	header.Set(fiber.HeaderContentType, contentType)
+	part, err := w.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	u, err := url.Parse(target)
+	require.NoError(t, err)
+	req := httptest.NewRequest(fiber.MethodPost, u.RequestURI(), &body)
+	req.Header.Set(fiber.HeaderContentType, w.FormDataContentType())
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestLocalStorageController_PresignedUploadThenDownload(t *testing.T) {
+	app, storage := setupLocalStorageApp(t)
+	ctx := context.Background()
+	key := "uploads/slot 1/ktp.png"
+
+	presigned, err := storage.PresignUpload(ctx, "participants", key, "image/png", 1024, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, postForm(t, app, presigned.URL, presigned.FormData, "image/png", []byte("png-bytes")))
+
+	download, err := storage.GetPresignedURL(ctx, "participants", key, time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(download)
+	require.NoError(t, err)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, u.RequestURI(), nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get(fiber.HeaderContentType))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "png-bytes", string(body))
+
+	q := u.Query()
+	q.Set(localfs.FormSignature, strings.Repeat("0", 64))
+	u.RawQuery = q.Encode()
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, u.RequestURI(), nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestLocalStorageController_UploadRejectsOversizedFile(t *testing.T) {
+	app, storage := setupLocalStorageApp(t)
+
+	presigned, err := storage.PresignUpload(context.Background(), "participants", "uploads/a.png", "image/png", 4, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, postForm(t, app, presigned.URL, presigned.FormData, "image/png", []byte("too large")))
+	_, err = storage.StatObject(context.Background(), "participants", "uploads/a.png")
+	assert.Error(t, err)
+}
+
+func TestLocalStorageController_UploadRejectsOtherContentType(t *testing.T) {
+	app, storage := setupLocalStorageApp(t)
+
+	presigned, err := storage.PresignUpload(context.Background(), "participants", "uploads/a.png", "image/png", 1024, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusBadRequest, postForm(t, app, presigned.URL, presigned.FormData, "text/html", []byte("<script>")))
+	_, err = storage.StatObject(context.Background(), "participants", "uploads/a.png")
+	assert.Error(t, err)
+}
+
+func TestLocalStorageController_UploadAboveAPIBodyLimit(t *testing.T) {
+	root := t.TempDir()
+	signer, err := localfs.NewSigner([]byte(strings.Repeat("s", 32)), "http://files.test")
+	require.NoError(t, err)
+	storage := localfs.NewFileStorage(root, signer, nil)
+
+	const apiLimit = 1024
+	app := fiber.New(fiber.Config{BodyLimit: participant.MaxDirectUploadSize + localfs.FormOverhead})
+	router.SetupLocalStorageRoutes(app, controller.NewLocalStorageController(localfs.NewServer(root, signer)))
+	app.Group("/api").Use(middleware.BodyLimit(apiLimit)).Post("/echo", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	content := bytes.Repeat([]byte{0}, 4*apiLimit)
+	presigned, err := storage.PresignUpload(context.Background(), "participants", "uploads/big.png", "image/png", int64(len(content)), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, postForm(t, app, presigned.URL, presigned.FormData, "image/png", content))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/api/echo", bytes.NewReader(content)))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+}
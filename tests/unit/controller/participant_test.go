@@ -98,6 +98,14 @@ func (m *MockParticipantUsecase) DeleteBankAccount(ctx context.Context, req *par
 	return args.Error(0)
 }
 
+func (m *MockParticipantUsecase) VerifyBankAccount(ctx context.Context, req *participant.VerifyBankAccountRequest) (*participant.BankAccountResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.BankAccountResponse), args.Error(1)
+}
+
 func (m *MockParticipantUsecase) SaveFamilyMember(ctx context.Context, req *participant.SaveFamilyMemberRequest) (*participant.FamilyMemberResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -172,6 +180,22 @@ func (m *MockParticipantUsecase) UploadFile(ctx context.Context, req *participan
 	return args.Get(0).(*participant.FileUploadResponse), args.Error(1)
 }
 
+func (m *MockParticipantUsecase) RequestUploadSlot(ctx context.Context, req *participant.RequestUploadSlotRequest) (*participant.UploadSlotResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.UploadSlotResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) CompleteUpload(ctx context.Context, req *participant.CompleteUploadRequest) (*participant.FileUploadResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.FileUploadResponse), args.Error(1)
+}
+
 func (m *MockParticipantUsecase) SubmitParticipant(ctx context.Context, req *participant.SubmitParticipantRequest) (*participant.ParticipantResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -196,6 +220,54 @@ func (m *MockParticipantUsecase) RejectParticipant(ctx context.Context, req *par
 	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
 }
 
+func (m *MockParticipantUsecase) ActivateParticipant(ctx context.Context, req *participant.ActivateParticipantRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) SuspendParticipant(ctx context.Context, req *participant.SuspendParticipantRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) TerminateParticipant(ctx context.Context, req *participant.TerminateParticipantRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) RetireParticipant(ctx context.Context, req *participant.RetireParticipantRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) MarkParticipantDeceased(ctx context.Context, req *participant.MarkParticipantDeceasedRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) TransferOutParticipant(ctx context.Context, req *participant.TransferOutParticipantRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
 func (m *MockParticipantUsecase) GetStatusHistory(ctx context.Context, req *participant.GetParticipantRequest) ([]participant.StatusHistoryResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -212,6 +284,38 @@ func (m *MockParticipantUsecase) SelfRegister(ctx context.Context, req *particip
 	return args.Get(0).(*participant.SelfRegisterResponse), args.Error(1)
 }
 
+func (m *MockParticipantUsecase) FindDuplicates(ctx context.Context, req *participant.FindDuplicatesRequest) ([]participant.DuplicateMatchResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]participant.DuplicateMatchResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) ListDuplicateCandidates(ctx context.Context, req *participant.ListDuplicateCandidatesRequest) (*participant.ListDuplicateCandidatesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ListDuplicateCandidatesResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) DismissDuplicateCandidate(ctx context.Context, req *participant.DismissDuplicateCandidateRequest) (*participant.DuplicateCandidateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.DuplicateCandidateResponse), args.Error(1)
+}
+
+func (m *MockParticipantUsecase) MergeParticipants(ctx context.Context, req *participant.MergeParticipantsRequest) (*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.ParticipantResponse), args.Error(1)
+}
+
 func setupParticipantApp(uc *MockParticipantUsecase, userID uuid.UUID) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
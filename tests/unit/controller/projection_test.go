@@ -0,0 +1,85 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"erp-service/delivery/http/controller"
+	"erp-service/delivery/http/middleware"
+	jwtpkg "erp-service/pkg/jwt"
+	"erp-service/saving/projection"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockProjectionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProjectionUsecase) RecalculateRetirementDate(ctx context.Context, req *projection.RecalculateRetirementDateRequest) (*projection.RetirementDateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*projection.RetirementDateResponse), args.Error(1)
+}
+
+func (m *MockProjectionUsecase) ProjectBenefit(ctx context.Context, req *projection.ProjectBenefitRequest) (*projection.ProjectionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*projection.ProjectionResponse), args.Error(1)
+}
+
+func recalculateRetirementDate(t *testing.T, claims *jwtpkg.MultiTenantClaims) map[string]interface{} {
+	t.Helper()
+	tenantID, productID, participantID := uuid.New(), uuid.New(), uuid.New()
+	dob := time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	uc := new(MockProjectionUsecase)
+	uc.On("RecalculateRetirementDate", mock.Anything, mock.Anything).Return(&projection.RetirementDateResponse{
+		ParticipantID:  participantID,
+		DateOfBirth:    &dob,
+		RetirementAge:  55,
+		RetirementDate: time.Date(2035, 5, 17, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	app := fiber.New()
+	app.Post("/participants/:id/retirement-date", func(c *fiber.Ctx) error {
+		c.Locals("tenant_id", tenantID)
+		c.Locals("product_id", productID)
+		c.Locals(middleware.MultiTenantClaimsKey, claims)
+		return c.Next()
+	}, controller.NewProjectionController(uc).RecalculateRetirementDate)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/participants/"+participantID.String()+"/retirement-date", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body.Data
+}
+
+func TestProjectionController_RecalculateRetirementDate_MasksDateOfBirth(t *testing.T) {
+	data := recalculateRetirementDate(t, &jwtpkg.MultiTenantClaims{UserID: uuid.New()})
+
+	assert.NotContains(t, data, "date_of_birth")
+	assert.Equal(t, "2035-05-17T00:00:00Z", data["retirement_date"])
+}
+
+func TestProjectionController_RecalculateRetirementDate_PlatformAdminSeesDateOfBirth(t *testing.T) {
+	data := recalculateRetirementDate(t, &jwtpkg.MultiTenantClaims{UserID: uuid.New(), Roles: []string{"PLATFORM_ADMIN"}})
+
+	assert.Equal(t, "1980-05-17T00:00:00Z", data["date_of_birth"])
+}
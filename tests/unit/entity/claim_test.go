@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"testing"
+
+	"erp-service/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimType_AcceptsParticipantStatus(t *testing.T) {
+	tests := []struct {
+		claimType entity.ClaimType
+		status    entity.ParticipantStatus
+		want      bool
+	}{
+		{entity.ClaimTypeNormalRetirement, entity.ParticipantStatusActive, true},
+		{entity.ClaimTypeNormalRetirement, entity.ParticipantStatusRetired, true},
+		{entity.ClaimTypeNormalRetirement, entity.ParticipantStatusPendingApproval, false},
+		{entity.ClaimTypeResignation, entity.ParticipantStatusTerminated, true},
+		{entity.ClaimTypeResignation, entity.ParticipantStatusDeceased, false},
+		{entity.ClaimTypeDeath, entity.ParticipantStatusDeceased, true},
+		{entity.ClaimTypeDeath, entity.ParticipantStatusActive, false},
+		{entity.ClaimTypeDisability, entity.ParticipantStatusTransferredOut, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.claimType)+"/"+string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.claimType.AcceptsParticipantStatus(tt.status))
+		})
+	}
+}
+
+func TestClaim_StatusGuards(t *testing.T) {
+	open := &entity.Claim{Status: entity.ClaimStatusOpen}
+	assert.True(t, open.AcceptsDocuments())
+	assert.True(t, open.CanBeVerified())
+	assert.True(t, open.CanBeRejected())
+	assert.False(t, open.CanBeApproved())
+
+	verified := &entity.Claim{Status: entity.ClaimStatusVerified}
+	assert.False(t, verified.AcceptsDocuments())
+	assert.True(t, verified.CanBeApproved())
+	assert.True(t, verified.CanBeRejected())
+
+	approved := &entity.Claim{Status: entity.ClaimStatusApproved}
+	assert.True(t, approved.CanBePaid())
+	assert.False(t, approved.CanBeRejected())
+
+	assert.False(t, entity.ClaimType("PARTIAL_WITHDRAWAL").IsValid())
+}
@@ -0,0 +1,42 @@
+package entity_test
+
+import (
+	"testing"
+
+	"erp-service/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParticipantStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from entity.ParticipantStatus
+		to   entity.ParticipantStatus
+		want bool
+	}{
+		{entity.ParticipantStatusDraft, entity.ParticipantStatusPendingApproval, true},
+		{entity.ParticipantStatusPendingApproval, entity.ParticipantStatusApproved, true},
+		{entity.ParticipantStatusApproved, entity.ParticipantStatusActive, true},
+		{entity.ParticipantStatusApproved, entity.ParticipantStatusSuspended, false},
+		{entity.ParticipantStatusActive, entity.ParticipantStatusSuspended, true},
+		{entity.ParticipantStatusActive, entity.ParticipantStatusTransferredOut, true},
+		{entity.ParticipantStatusSuspended, entity.ParticipantStatusActive, true},
+		{entity.ParticipantStatusTerminated, entity.ParticipantStatusActive, false},
+		{entity.ParticipantStatusRetired, entity.ParticipantStatusDeceased, true},
+		{entity.ParticipantStatusDeceased, entity.ParticipantStatusActive, false},
+		{entity.ParticipantStatusTransferredOut, entity.ParticipantStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestParticipantStatus_IsFinal(t *testing.T) {
+	assert.True(t, entity.ParticipantStatusDeceased.IsFinal())
+	assert.True(t, entity.ParticipantStatusTransferredOut.IsFinal())
+	assert.False(t, entity.ParticipantStatusRetired.IsFinal())
+	assert.False(t, entity.ParticipantStatusActive.IsFinal())
+}
@@ -0,0 +1,109 @@
+package envelope_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"erp-service/pkg/envelope"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
+
+func newEncryptor(t *testing.T) *envelope.Encryptor {
+	t.Helper()
+	keys, err := envelope.NewLocalKeyProvider(randomBytes(t, envelope.DataKeySize))
+	require.NoError(t, err)
+	return envelope.NewEncryptor(keys, "tenant-")
+}
+
+func seal(t *testing.T, enc *envelope.Encryptor, tenantID uuid.UUID, plaintext []byte) ([]byte, *envelope.Envelope) {
+	t.Helper()
+	r, size, env, err := enc.Seal(context.Background(), tenantID, bytes.NewReader(plaintext), int64(len(plaintext)))
+	require.NoError(t, err)
+	ciphertext, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, size, int64(len(ciphertext)))
+	return ciphertext, env
+}
+
+func open(enc *envelope.Encryptor, env *envelope.Envelope, ciphertext []byte) ([]byte, error) {
+	r, err := enc.Open(context.Background(), env, bytes.NewReader(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestEncryptor_RoundTrip(t *testing.T) {
+	enc := newEncryptor(t)
+	tenantID := uuid.New()
+
+	for _, size := range []int{0, 1, envelope.ChunkSize - 1, envelope.ChunkSize, envelope.ChunkSize + 1, 3*envelope.ChunkSize + 17} {
+		plaintext := randomBytes(t, size)
+		ciphertext, env := seal(t, enc, tenantID, plaintext)
+
+		assert.Equal(t, "tenant-"+tenantID.String(), env.KeyID)
+
+		got, err := open(enc, env, ciphertext)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, plaintext, got, "size %d", size)
+	}
+}
+
+func TestEncryptor_RejectsTamperedCiphertext(t *testing.T) {
+	enc := newEncryptor(t)
+	plaintext := randomBytes(t, 2*envelope.ChunkSize+10)
+	ciphertext, env := seal(t, enc, uuid.New(), plaintext)
+
+	flipped := append([]byte{}, ciphertext...)
+	flipped[len(flipped)/2] ^= 0x01
+	_, err := open(enc, env, flipped)
+	assert.ErrorIs(t, err, envelope.ErrInvalidCiphertext)
+
+	// Dropping the final chunk must not pass as a shorter file.
+	truncated := ciphertext[:envelope.CiphertextSize(2*envelope.ChunkSize)]
+	_, err = open(enc, env, truncated)
+	assert.ErrorIs(t, err, envelope.ErrInvalidCiphertext)
+}
+
+func TestEncryptor_WrongTenantKeyFails(t *testing.T) {
+	enc := newEncryptor(t)
+	ciphertext, env := seal(t, enc, uuid.New(), []byte("identity document"))
+
+	other := &envelope.Envelope{KeyID: enc.TenantKeyID(uuid.New()), WrappedKey: env.WrappedKey}
+	_, err := open(enc, other, ciphertext)
+	assert.Error(t, err)
+}
+
+func TestEncryptor_RewrapKeepsObjectReadable(t *testing.T) {
+	enc := newEncryptor(t)
+	plaintext := []byte("identity document")
+	ciphertext, env := seal(t, enc, uuid.New(), plaintext)
+
+	rewrapped, err := enc.Rewrap(context.Background(), env)
+	require.NoError(t, err)
+	assert.Equal(t, env.KeyID, rewrapped.KeyID)
+	assert.NotEqual(t, env.WrappedKey, rewrapped.WrappedKey)
+
+	got, err := open(enc, rewrapped, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+}
+
+func TestNewLocalKeyProvider_RejectsShortKey(t *testing.T) {
+	_, err := envelope.NewLocalKeyProvider([]byte("short"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,252 @@
+package fileaccess_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+	"erp-service/saving/fileaccess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func assertAppErrorKind(t *testing.T, err error, kind errors.Kind) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, kind, appErr.Kind)
+}
+
+func createFile(tenantID, productID uuid.UUID, participantID *uuid.UUID, uploadedBy uuid.UUID) *entity.File {
+	return &entity.File{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: participantID,
+		UploadedBy:    uploadedBy,
+		Bucket:        "participants",
+		StorageKey:    "participants/key/ktp.jpg",
+		OriginalName:  "ktp.jpg",
+		ContentType:   "image/jpeg",
+		SizeBytes:     4,
+		ScanStatus:    entity.FileScanStatusClean,
+	}
+}
+
+func TestUsecase_GetFile(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	creatorID := uuid.New()
+	participantID := uuid.New()
+
+	participant := &entity.Participant{
+		ID:        participantID,
+		TenantID:  tenantID,
+		ProductID: productID,
+		CreatedBy: creatorID,
+	}
+
+	tests := []struct {
+		name       string
+		file       *entity.File
+		userID     uuid.UUID
+		canViewAll bool
+		errKind    errors.Kind
+	}{
+		{
+			name:       "approver sees any file of the product",
+			file:       createFile(tenantID, productID, &participantID, uuid.New()),
+			userID:     uuid.New(),
+			canViewAll: true,
+		},
+		{
+			name:   "creator of the participant",
+			file:   createFile(tenantID, productID, &participantID, uuid.New()),
+			userID: creatorID,
+		},
+		{
+			name:    "other creator",
+			file:    createFile(tenantID, productID, &participantID, uuid.New()),
+			userID:  uuid.New(),
+			errKind: errors.KindForbidden,
+		},
+		{
+			name:       "other tenant",
+			file:       createFile(uuid.New(), productID, &participantID, uuid.New()),
+			userID:     uuid.New(),
+			canViewAll: true,
+			errKind:    errors.KindForbidden,
+		},
+		{
+			name:       "other product",
+			file:       createFile(tenantID, uuid.New(), &participantID, uuid.New()),
+			userID:     uuid.New(),
+			canViewAll: true,
+			errKind:    errors.KindForbidden,
+		},
+		{
+			name:   "unattached file seen by its uploader",
+			file:   createFile(tenantID, productID, nil, creatorID),
+			userID: creatorID,
+		},
+		{
+			name:    "unattached file of another uploader",
+			file:    createFile(tenantID, productID, nil, uuid.New()),
+			userID:  creatorID,
+			errKind: errors.KindForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMocks()
+			m.fileRepo.On("GetByID", mock.Anything, tt.file.ID).Return(tt.file, nil)
+			m.participantRepo.On("GetByID", mock.Anything, participantID).Return(participant, nil)
+
+			result, err := newTestUsecase(m).GetFile(context.Background(), &fileaccess.GetFileRequest{
+				TenantID:   tenantID,
+				ProductID:  productID,
+				UserID:     tt.userID,
+				FileID:     tt.file.ID,
+				CanViewAll: tt.canViewAll,
+			})
+
+			if tt.errKind != 0 {
+				assertAppErrorKind(t, err, tt.errKind)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.file.ID, result.ID)
+			assert.Equal(t, "/api/v1/saving/files/"+tt.file.ID.String()+"/content", result.ContentURL)
+		})
+	}
+}
+
+func TestUsecase_GetFile_NotFound(t *testing.T) {
+	m := newTestMocks()
+	fileID := uuid.New()
+	m.fileRepo.On("GetByID", mock.Anything, fileID).Return(nil, errors.ErrNotFound("file not found"))
+
+	_, err := newTestUsecase(m).GetFile(context.Background(), &fileaccess.GetFileRequest{
+		TenantID:   uuid.New(),
+		ProductID:  uuid.New(),
+		FileID:     fileID,
+		CanViewAll: true,
+	})
+
+	assertAppErrorKind(t, err, errors.KindNotFound)
+}
+
+func TestUsecase_GetFileContent(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("redirect to presigned url", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, userID)
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+		m.fileStorage.On("GetPresignedURL", mock.Anything, file.Bucket, file.StorageKey, 2*time.Minute).
+			Return("https://storage/presigned", nil)
+
+		result, err := newTestUsecase(m).GetFileContent(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://storage/presigned", result.URL)
+		assert.Nil(t, result.Body)
+		require.Len(t, m.audit.events, 1)
+		assert.True(t, m.audit.events[0].Success)
+		assert.Equal(t, "file_download", m.audit.events[0].Action)
+		assert.Equal(t, "redirect", m.audit.events[0].Metadata["mode"])
+	})
+
+	t.Run("inline stream", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, userID)
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+		m.fileStorage.On("GetDecryptedObject", mock.Anything, file.Bucket, file.StorageKey, file.Envelope()).
+			Return(io.NopCloser(strings.NewReader("data")), nil)
+
+		result, err := newTestUsecase(m).GetFileContent(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+			Inline:         true,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Body)
+		body, _ := io.ReadAll(result.Body)
+		assert.Equal(t, "data", string(body))
+		assert.Equal(t, "image/jpeg", result.ContentType)
+		m.fileStorage.AssertNotCalled(t, "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("encrypted file is streamed instead of redirected", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, userID)
+		env := &envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}
+		file.SetEnvelope(env)
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+		m.fileStorage.On("GetDecryptedObject", mock.Anything, file.Bucket, file.StorageKey, env).
+			Return(io.NopCloser(strings.NewReader("plain")), nil)
+
+		result, err := newTestUsecase(m).GetFileContent(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, result.Body)
+		body, _ := io.ReadAll(result.Body)
+		assert.Equal(t, "plain", string(body))
+		assert.Empty(t, result.URL)
+		m.fileStorage.AssertNotCalled(t, "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		require.Len(t, m.audit.events, 1)
+		assert.Equal(t, "inline", m.audit.events[0].Metadata["mode"])
+	})
+
+	t.Run("denied download is audited", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, uuid.New())
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+
+		_, err := newTestUsecase(m).GetFileContent(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+		})
+
+		assertAppErrorKind(t, err, errors.KindForbidden)
+		require.Len(t, m.audit.events, 1)
+		assert.False(t, m.audit.events[0].Success)
+		assert.NotEmpty(t, m.audit.events[0].Reason)
+	})
+	t.Run("unscanned and quarantined files are not served", func(t *testing.T) {
+		for status, kind := range map[entity.FileScanStatus]errors.Kind{
+			entity.FileScanStatusPending:  errors.KindBadRequest,
+			entity.FileScanStatusInfected: errors.KindForbidden,
+		} {
+			m := newTestMocks()
+			file := createFile(tenantID, productID, nil, userID)
+			file.ScanStatus = status
+			m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+
+			_, err := newTestUsecase(m).GetFileContent(context.Background(), &fileaccess.GetFileContentRequest{
+				GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+				Inline:         true,
+			})
+
+			assertAppErrorKind(t, err, kind)
+			m.fileStorage.AssertNotCalled(t, "GetDecryptedObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			require.Len(t, m.audit.events, 1)
+			assert.False(t, m.audit.events[0].Success)
+		}
+	})
+}
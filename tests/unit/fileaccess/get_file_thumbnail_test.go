@@ -0,0 +1,79 @@
+package fileaccess_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/fileaccess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_GetFileThumbnail(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("streams the derived thumbnail", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, userID)
+		thumbnail := createFile(tenantID, productID, nil, userID)
+		thumbnail.StorageKey = "participants/key/ktp_thumb.jpg"
+		thumbnail.OriginalName = "ktp_thumb.jpg"
+		thumbnail.DerivedFromFileID = &file.ID
+		file.ThumbnailFileID = &thumbnail.ID
+
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+		m.fileRepo.On("GetByID", mock.Anything, thumbnail.ID).Return(thumbnail, nil)
+		m.fileStorage.On("GetDecryptedObject", mock.Anything, thumbnail.Bucket, thumbnail.StorageKey, thumbnail.Envelope()).
+			Return(io.NopCloser(strings.NewReader("thumb")), nil)
+
+		result, err := newTestUsecase(m).GetFileThumbnail(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+			Inline:         true,
+		})
+
+		require.NoError(t, err)
+		body, _ := io.ReadAll(result.Body)
+		assert.Equal(t, "thumb", string(body))
+		assert.Equal(t, "ktp_thumb.jpg", result.FileName)
+		require.Len(t, m.audit.events, 1)
+		assert.Equal(t, "thumbnail", m.audit.events[0].Metadata["variant"])
+		assert.Equal(t, file.ID.String(), m.audit.events[0].TargetID)
+	})
+
+	t.Run("access is checked against the original", func(t *testing.T) {
+		m := newTestMocks()
+		thumbnailID := uuid.New()
+		file := createFile(tenantID, productID, nil, uuid.New())
+		file.ThumbnailFileID = &thumbnailID
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+
+		_, err := newTestUsecase(m).GetFileThumbnail(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+		})
+
+		assertAppErrorKind(t, err, errors.KindForbidden)
+		m.fileRepo.AssertNotCalled(t, "GetByID", mock.Anything, thumbnailID)
+	})
+
+	t.Run("not found when not yet processed", func(t *testing.T) {
+		m := newTestMocks()
+		file := createFile(tenantID, productID, nil, userID)
+		file.ProcessingStatus = entity.FileProcessingStatusPending
+		m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil)
+
+		_, err := newTestUsecase(m).GetFileThumbnail(context.Background(), &fileaccess.GetFileContentRequest{
+			GetFileRequest: fileaccess.GetFileRequest{TenantID: tenantID, ProductID: productID, UserID: userID, FileID: file.ID},
+		})
+
+		assertAppErrorKind(t, err, errors.KindNotFound)
+	})
+}
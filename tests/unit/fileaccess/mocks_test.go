@@ -0,0 +1,108 @@
+package fileaccess_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/logger"
+	"erp-service/saving/fileaccess"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	_ fileaccess.FileRepository        = (*MockFileRepository)(nil)
+	_ fileaccess.ParticipantRepository = (*MockParticipantRepository)(nil)
+	_ fileaccess.FileStorageAdapter    = (*MockFileStorage)(nil)
+	_ logger.AuditLogger               = (*recordingAuditLogger)(nil)
+)
+
+type testMocks struct {
+	fileRepo        *MockFileRepository
+	participantRepo *MockParticipantRepository
+	fileStorage     *MockFileStorage
+	audit           *recordingAuditLogger
+}
+
+func newTestMocks() *testMocks {
+	return &testMocks{
+		fileRepo:        new(MockFileRepository),
+		participantRepo: new(MockParticipantRepository),
+		fileStorage:     new(MockFileStorage),
+		audit:           new(recordingAuditLogger),
+	}
+}
+
+func newTestUsecase(m *testMocks) fileaccess.Usecase {
+	cfg := &config.Config{}
+	cfg.Infra.Minio.PresignExpiry = 2 * time.Minute
+	return fileaccess.NewUsecase(
+		cfg,
+		zap.NewNop(),
+		m.audit,
+		m.fileRepo,
+		m.participantRepo,
+		m.fileStorage,
+	)
+}
+
+type MockFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.File), args.Error(1)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) GetPresignedURL(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, bucket, objectKey, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileStorage) GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, objectKey, env)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type recordingAuditLogger struct {
+	mu     sync.Mutex
+	events []logger.AuditEvent
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, event logger.AuditEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *recordingAuditLogger) Sync() error { return nil }
@@ -2,11 +2,14 @@ package files_test
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
 	"erp-service/entity"
 	"erp-service/files"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/logger"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -40,14 +43,182 @@ func (m *mockFileRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
 
+func (m *mockFileRepo) ClaimPendingScan(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *mockFileRepo) ReleaseStaleScanClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	return m.Called(ctx, age).Error(0)
+}
+
+func (m *mockFileRepo) RecordScanFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	return m.Called(ctx, id, maxAttempts).Error(0)
+}
+
+func (m *mockFileRepo) MarkScanned(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockFileRepo) MarkQuarantined(ctx context.Context, id uuid.UUID, bucket, storageKey, signature string) error {
+	return m.Called(ctx, id, bucket, storageKey, signature).Error(0)
+}
+
+func (m *mockFileRepo) Create(ctx context.Context, file *entity.File) error {
+	return m.Called(ctx, file).Error(0)
+}
+
+func (m *mockFileRepo) ClaimPendingProcessing(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *mockFileRepo) ReleaseStaleProcessClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	return m.Called(ctx, age).Error(0)
+}
+
+func (m *mockFileRepo) MarkProcessed(ctx context.Context, id uuid.UUID, storageKey, contentType string, sizeBytes int64, env *envelope.Envelope, thumbnailFileID uuid.UUID) error {
+	return m.Called(ctx, id, storageKey, contentType, sizeBytes, env, thumbnailFileID).Error(0)
+}
+
+func (m *mockFileRepo) MarkProcessingSkipped(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockFileRepo) RecordProcessingFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	return m.Called(ctx, id, maxAttempts).Error(0)
+}
+
+func (m *mockFileRepo) ListDueForRewrap(ctx context.Context, rewrappedBefore time.Time, limit int) ([]*entity.File, error) {
+	args := m.Called(ctx, rewrappedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *mockFileRepo) UpdateWrappedDataKey(ctx context.Context, id uuid.UUID, oldWrappedKey, newWrappedKey string) error {
+	return m.Called(ctx, id, oldWrappedKey, newWrappedKey).Error(0)
+}
+
+func (m *mockFileRepo) ClaimDueForPurge(ctx context.Context, limit int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *mockFileRepo) ReleaseStalePurgeClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	return m.Called(ctx, age).Error(0)
+}
+
+func (m *mockFileRepo) ReleasePurgeClaim(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockFileRepo) ListDerived(ctx context.Context, id uuid.UUID) ([]*entity.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *mockFileRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+type mockSlotRepo struct{ mock.Mock }
+
+var _ files.UploadSlotRepository = (*mockSlotRepo)(nil)
+
+func (m *mockSlotRepo) ClaimExpired(ctx context.Context, limit int) ([]*entity.UploadSlot, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UploadSlot), args.Error(1)
+}
+
+func (m *mockSlotRepo) ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	return m.Called(ctx, age).Error(0)
+}
+
+func (m *mockSlotRepo) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockSlotRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// newIdleSlotRepo has no expired upload slots to reap.
+func newIdleSlotRepo() *mockSlotRepo {
+	slots := new(mockSlotRepo)
+	slots.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	slots.On("ClaimExpired", mock.Anything, mock.Anything).Return([]*entity.UploadSlot{}, nil)
+	return slots
+}
+
 type mockFileStorage struct{ mock.Mock }
 
 var _ files.FileStorageAdapter = (*mockFileStorage)(nil)
 
+func (m *mockFileStorage) UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error) {
+	args := m.Called(ctx, tenantID, bucket, objectKey, data, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envelope.Envelope), args.Error(1)
+}
+
 func (m *mockFileStorage) DeleteFile(ctx context.Context, bucket, objectKey string) error {
 	return m.Called(ctx, bucket, objectKey).Error(0)
 }
 
+func (m *mockFileStorage) GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, objectKey, env)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockFileStorage) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	return m.Called(ctx, srcBucket, srcKey, dstBucket, dstKey).Error(0)
+}
+
+type mockScanner struct{ mock.Mock }
+
+var _ files.MalwareScanner = (*mockScanner)(nil)
+
+func (m *mockScanner) Scan(ctx context.Context, data io.Reader) (*files.ScanVerdict, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*files.ScanVerdict), args.Error(1)
+}
+
+type mockKeyRewrapper struct{ mock.Mock }
+
+var _ files.KeyRewrapper = (*mockKeyRewrapper)(nil)
+
+func (m *mockKeyRewrapper) Rewrap(ctx context.Context, env *envelope.Envelope) (*envelope.Envelope, error) {
+	args := m.Called(ctx, env)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envelope.Envelope), args.Error(1)
+}
+
 type mockTxManager struct{ mock.Mock }
 
 var _ files.TransactionManager = (*mockTxManager)(nil)
@@ -61,7 +232,7 @@ func (m *mockTxManager) WithTransaction(ctx context.Context, fn func(context.Con
 }
 
 func newUC(repo *mockFileRepo, storage *mockFileStorage, tx *mockTxManager) files.Usecase {
-	return files.NewUsecase(repo, storage, tx, zap.NewNop(), files.DefaultConfig())
+	return files.NewUsecase(repo, newIdleSlotRepo(), storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, files.DefaultConfig())
 }
 
 func makeFile(bucket, storageKey string) *entity.File {
@@ -227,7 +398,7 @@ func TestCleanupBatch_UsesConfigValues(t *testing.T) {
 	repo, storage, tx := new(mockFileRepo), new(mockFileStorage), new(mockTxManager)
 
 	customCfg := files.Config{BatchSize: 10, StaleClaimAge: 15 * time.Minute}
-	uc := files.NewUsecase(repo, storage, tx, zap.NewNop(), customCfg)
+	uc := files.NewUsecase(repo, newIdleSlotRepo(), storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, customCfg)
 
 	tx.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	repo.On("ReleaseStaleClaimsOlderThan", mock.Anything, 15*time.Minute).Return(nil)
@@ -240,3 +411,59 @@ func TestCleanupBatch_UsesConfigValues(t *testing.T) {
 	repo.AssertCalled(t, "ReleaseStaleClaimsOlderThan", mock.Anything, 15*time.Minute)
 	repo.AssertCalled(t, "ClaimExpired", mock.Anything, 10)
 }
+
+func makeSlot(storageKey string) *entity.UploadSlot {
+	return &entity.UploadSlot{
+		ID:         uuid.New(),
+		Bucket:     testBucket,
+		StorageKey: storageKey,
+		ExpiresAt:  time.Now().Add(-1 * time.Hour),
+	}
+}
+
+func TestCleanupBatch_ReapsExpiredUploadSlots(t *testing.T) {
+	repo, slots, storage, tx := new(mockFileRepo), new(mockSlotRepo), new(mockFileStorage), new(mockTxManager)
+	good := makeSlot("participants/slot-a.jpg")
+	bad := makeSlot("participants/slot-b.jpg")
+
+	tx.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	repo.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimExpired", mock.Anything, mock.Anything).Return([]*entity.File{}, nil)
+	slots.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	slots.On("ClaimExpired", mock.Anything, files.DefaultConfig().BatchSize).Return([]*entity.UploadSlot{good, bad}, nil)
+
+	storage.On("DeleteFile", mock.Anything, testBucket, "participants/slot-a.jpg").Return(nil)
+	slots.On("SoftDelete", mock.Anything, good.ID).Return(nil)
+
+	storage.On("DeleteFile", mock.Anything, testBucket, "participants/slot-b.jpg").Return(assert.AnError)
+	slots.On("IncrementFailedAttempts", mock.Anything, bad.ID).Return(nil)
+
+	uc := files.NewUsecase(repo, slots, storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, files.DefaultConfig())
+	result, err := uc.CleanupBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Processed)
+	assert.Equal(t, 1, result.SlotsReaped)
+	assert.Equal(t, 1, result.SlotsFailed)
+	slots.AssertNotCalled(t, "SoftDelete", mock.Anything, bad.ID)
+}
+
+func TestCleanupBatch_ClaimExpiredSlotsFails_FileBatchStillSucceeds(t *testing.T) {
+	repo, slots, storage, tx := new(mockFileRepo), new(mockSlotRepo), new(mockFileStorage), new(mockTxManager)
+	file := makeFile(testBucket, testStorageKey)
+
+	tx.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	repo.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimExpired", mock.Anything, mock.Anything).Return([]*entity.File{file}, nil)
+	storage.On("DeleteFile", mock.Anything, testBucket, testStorageKey).Return(nil)
+	repo.On("SoftDelete", mock.Anything, file.ID).Return(nil)
+	slots.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	slots.On("ClaimExpired", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	uc := files.NewUsecase(repo, slots, storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, files.DefaultConfig())
+	result, err := uc.CleanupBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Processed)
+	assert.Equal(t, 0, result.SlotsReaped)
+}
@@ -0,0 +1,51 @@
+package files_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/files"
+	"erp-service/impl/localfs"
+	"erp-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// The cleanup flow against the filesystem backend instead of a storage mock.
+func TestCleanupBatch_LocalStorage_RemovesObjects(t *testing.T) {
+	signer, err := localfs.NewSigner([]byte(strings.Repeat("s", 32)), "http://files.test")
+	require.NoError(t, err)
+	storage := localfs.NewFileStorage(t.TempDir(), signer, nil)
+	ctx := context.Background()
+
+	file := makeFile(testBucket, testStorageKey)
+	slot := makeSlot("participants/slot-a.jpg")
+	for _, key := range []string{file.StorageKey, slot.StorageKey} {
+		_, err := storage.UploadFile(ctx, testBucket, key, strings.NewReader("data"), 4, "image/jpeg")
+		require.NoError(t, err)
+	}
+
+	repo, slots, tx := new(mockFileRepo), new(mockSlotRepo), new(mockTxManager)
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimExpired", mock.Anything, mock.Anything).Return([]*entity.File{file}, nil)
+	repo.On("SoftDelete", mock.Anything, file.ID).Return(nil)
+	slots.On("ReleaseStaleClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	slots.On("ClaimExpired", mock.Anything, mock.Anything).Return([]*entity.UploadSlot{slot}, nil)
+	slots.On("SoftDelete", mock.Anything, slot.ID).Return(nil)
+
+	uc := files.NewUsecase(repo, slots, storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, files.DefaultConfig())
+	result, err := uc.CleanupBatch(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, files.BatchResult{Processed: 1, SlotsReaped: 1}, result)
+	for _, key := range []string{file.StorageKey, slot.StorageKey} {
+		_, err := storage.StatObject(ctx, testBucket, key)
+		assert.Error(t, err, key)
+	}
+}
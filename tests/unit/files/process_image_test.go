@@ -0,0 +1,149 @@
+package files_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/files"
+	"erp-service/pkg/envelope"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func makeCleanImageFile() *entity.File {
+	file := makeFile(testBucket, testStorageKey)
+	file.OriginalName = "photo.jpg"
+	file.ContentType = "image/jpeg"
+	file.ScanStatus = entity.FileScanStatusClean
+	file.ProcessingStatus = entity.FileProcessingStatusPending
+	return file
+}
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func TestProcessImage_NormalizesAndCreatesThumbnail(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makeCleanImageFile()
+	thumbnailID := uuid.New()
+
+	thumbEnv := &envelope.Envelope{KeyID: "tenant", WrappedKey: "thumb"}
+	imageEnv := &envelope.Envelope{KeyID: "tenant", WrappedKey: "image"}
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, file.Envelope()).
+		Return(io.NopCloser(bytes.NewReader(encodeTestJPEG(t, 3000, 1500))), nil)
+	storage.On("UploadEncryptedFile", mock.Anything, file.TenantID, testBucket, "participants/path/photo_thumb.jpg", mock.Anything, mock.Anything).
+		Return(thumbEnv, nil)
+	storage.On("UploadEncryptedFile", mock.Anything, file.TenantID, testBucket, "participants/path/photo_normalized.jpg", mock.Anything, mock.Anything).
+		Return(imageEnv, nil)
+	storage.On("DeleteFile", mock.Anything, testBucket, testStorageKey).Return(nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(f *entity.File) bool {
+		return f.DerivedFromFileID != nil && *f.DerivedFromFileID == file.ID &&
+			f.ScanStatus == entity.FileScanStatusClean &&
+			f.StorageKey == "participants/path/photo_thumb.jpg" &&
+			f.WrappedDataKey != nil && *f.WrappedDataKey == "thumb"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.File).ID = thumbnailID
+	}).Return(nil)
+	repo.On("MarkProcessed", mock.Anything, file.ID, "participants/path/photo_normalized.jpg", "image/jpeg",
+		mock.AnythingOfType("int64"), imageEnv, thumbnailID).Return(nil)
+
+	status, err := newScanUC(repo, storage, scanner, tx).ProcessImage(context.Background(), file)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.FileProcessingStatusDone, status)
+	repo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestProcessImage_MarkFails_KeepsOriginal(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makeCleanImageFile()
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, file.Envelope()).
+		Return(io.NopCloser(bytes.NewReader(encodeTestJPEG(t, 64, 64))), nil)
+	storage.On("UploadEncryptedFile", mock.Anything, file.TenantID, testBucket, mock.Anything, mock.Anything, mock.Anything).
+		Return(&envelope.Envelope{KeyID: "tenant", WrappedKey: "k"}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	repo.On("MarkProcessed", mock.Anything, file.ID, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(assert.AnError)
+	repo.On("RecordProcessingFailure", mock.Anything, file.ID, files.DefaultConfig().MaxProcessAttempts).Return(nil)
+
+	_, err := newScanUC(repo, storage, scanner, tx).ProcessImage(context.Background(), file)
+
+	require.Error(t, err)
+	storage.AssertNotCalled(t, "DeleteFile", mock.Anything, testBucket, testStorageKey)
+}
+
+func TestProcessImage_NonImage_Skipped(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makeCleanImageFile()
+	file.ContentType = "application/pdf"
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("MarkProcessingSkipped", mock.Anything, file.ID).Return(nil)
+
+	status, err := newScanUC(repo, storage, scanner, tx).ProcessImage(context.Background(), file)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.FileProcessingStatusSkipped, status)
+	storage.AssertNotCalled(t, "GetDecryptedObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessImage_CorruptImage_RecordsFailure(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makeCleanImageFile()
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, file.Envelope()).
+		Return(io.NopCloser(bytes.NewReader([]byte("not a jpeg"))), nil)
+	repo.On("RecordProcessingFailure", mock.Anything, file.ID, files.DefaultConfig().MaxProcessAttempts).Return(nil)
+
+	_, err := newScanUC(repo, storage, scanner, tx).ProcessImage(context.Background(), file)
+
+	require.Error(t, err)
+	storage.AssertNotCalled(t, "UploadEncryptedFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "MarkProcessed", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessImagesBatch_CountsOutcomes(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	pdf := makeCleanImageFile()
+	pdf.ContentType = "application/pdf"
+	broken := makeCleanImageFile()
+	broken.StorageKey = "participants/path/broken.jpg"
+	cfg := files.DefaultConfig()
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStaleProcessClaimsOlderThan", mock.Anything, cfg.StaleClaimAge).Return(nil)
+	repo.On("ClaimPendingProcessing", mock.Anything, cfg.ProcessBatchSize, cfg.MaxProcessAttempts).
+		Return([]*entity.File{pdf, broken}, nil)
+	repo.On("MarkProcessingSkipped", mock.Anything, pdf.ID).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, broken.StorageKey, broken.Envelope()).Return(nil, assert.AnError)
+	repo.On("RecordProcessingFailure", mock.Anything, broken.ID, cfg.MaxProcessAttempts).Return(nil)
+
+	result, err := newScanUC(repo, storage, scanner, tx).ProcessImagesBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, files.ProcessBatchResult{Skipped: 1, Failed: 1}, result)
+}
@@ -0,0 +1,116 @@
+package files_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/files"
+	"erp-service/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingAuditLogger struct {
+	events []logger.AuditEvent
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, event logger.AuditEvent) {
+	l.events = append(l.events, event)
+}
+
+func (l *recordingAuditLogger) Sync() error { return nil }
+
+func makePermanentFile(storageKey string) *entity.File {
+	documentType := "ktp"
+	participantID := uuid.New()
+	return &entity.File{
+		ID:            uuid.New(),
+		TenantID:      uuid.New(),
+		ParticipantID: &participantID,
+		Bucket:        testBucket,
+		StorageKey:    storageKey,
+		DocumentType:  &documentType,
+	}
+}
+
+func newPurgeUC(repo *mockFileRepo, storage *mockFileStorage, tx *mockTxManager, audit *recordingAuditLogger) files.Usecase {
+	return files.NewUsecase(repo, newIdleSlotRepo(), storage, new(mockScanner), new(mockKeyRewrapper), tx, zap.NewNop(), audit, files.DefaultConfig())
+}
+
+func TestPurgeBatch_DeletesObjectsThenRows(t *testing.T) {
+	repo, storage, tx, audit := new(mockFileRepo), new(mockFileStorage), new(mockTxManager), new(recordingAuditLogger)
+	file := makePermanentFile("participants/t/p/x/ktp/ktp_normalized.jpg")
+	thumb := makeFile(testBucket, "participants/t/p/x/ktp/ktp_thumb.jpg")
+
+	var calls []string
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStalePurgeClaimsOlderThan", mock.Anything, files.DefaultConfig().StaleClaimAge).Return(nil)
+	repo.On("ClaimDueForPurge", mock.Anything, files.DefaultConfig().PurgeBatchSize).Return([]*entity.File{file}, nil)
+	repo.On("ListDerived", mock.Anything, file.ID).Return([]*entity.File{thumb}, nil)
+	storage.On("DeleteFile", mock.Anything, testBucket, mock.Anything).
+		Run(func(args mock.Arguments) { calls = append(calls, args.String(2)) }).
+		Return(nil)
+	repo.On("Purge", mock.Anything, file.ID).
+		Run(func(mock.Arguments) { calls = append(calls, "purge") }).
+		Return(nil)
+
+	result, err := newPurgeUC(repo, storage, tx, audit).PurgeBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, files.PurgeBatchResult{Purged: 1}, result)
+	assert.Equal(t, []string{thumb.StorageKey, file.StorageKey, "purge"}, calls)
+	repo.AssertNotCalled(t, "ReleasePurgeClaim", mock.Anything, mock.Anything)
+
+	require.Len(t, audit.events, 1)
+	event := audit.events[0]
+	assert.Equal(t, "file_purged", event.Action)
+	assert.True(t, event.Success)
+	assert.Equal(t, file.ID.String(), event.TargetID)
+	assert.Equal(t, file.TenantID.String(), event.TenantID)
+	assert.Equal(t, "ktp", event.Metadata["document_type"])
+}
+
+func TestPurgeBatch_StorageFailure_KeepsRowAndReleasesClaim(t *testing.T) {
+	repo, storage, tx, audit := new(mockFileRepo), new(mockFileStorage), new(mockTxManager), new(recordingAuditLogger)
+	failing := makePermanentFile("participants/t/p/x/kk/kk.pdf")
+	purged := makePermanentFile("participants/t/p/y/kk/kk.pdf")
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStalePurgeClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimDueForPurge", mock.Anything, mock.Anything).Return([]*entity.File{failing, purged}, nil)
+	repo.On("ListDerived", mock.Anything, mock.Anything).Return([]*entity.File{}, nil)
+	storage.On("DeleteFile", mock.Anything, testBucket, failing.StorageKey).Return(assert.AnError)
+	storage.On("DeleteFile", mock.Anything, testBucket, purged.StorageKey).Return(nil)
+	repo.On("ReleasePurgeClaim", mock.Anything, failing.ID).Return(nil)
+	repo.On("Purge", mock.Anything, purged.ID).Return(nil)
+
+	result, err := newPurgeUC(repo, storage, tx, audit).PurgeBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, files.PurgeBatchResult{Purged: 1, Failed: 1}, result)
+	repo.AssertNotCalled(t, "Purge", mock.Anything, failing.ID)
+	repo.AssertExpectations(t)
+
+	require.Len(t, audit.events, 2)
+	assert.False(t, audit.events[0].Success)
+	assert.NotEmpty(t, audit.events[0].Reason)
+	assert.True(t, audit.events[1].Success)
+}
+
+func TestPurgeBatch_ClaimError(t *testing.T) {
+	repo, storage, tx := new(mockFileRepo), new(mockFileStorage), new(mockTxManager)
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStalePurgeClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimDueForPurge", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	_, err := newPurgeUC(repo, storage, tx, new(recordingAuditLogger)).PurgeBatch(context.Background())
+
+	assert.Error(t, err)
+	storage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,50 @@
+package files_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/files"
+	"erp-service/pkg/envelope"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func makeEncryptedFile(wrappedKey string) *entity.File {
+	file := makeFile(testBucket, testStorageKey)
+	file.SetEnvelope(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: wrappedKey})
+	return file
+}
+
+func TestRewrapKeysBatch_CountsOutcomes(t *testing.T) {
+	repo, storage, keys, tx := new(mockFileRepo), new(mockFileStorage), new(mockKeyRewrapper), new(mockTxManager)
+	cfg := files.DefaultConfig()
+	rewrapped := makeEncryptedFile("v1:a")
+	raced := makeEncryptedFile("v1:b")
+	broken := makeEncryptedFile("v1:c")
+	plaintext := makeFile(testBucket, "participants/path/legacy.pdf")
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ListDueForRewrap", mock.Anything, mock.Anything, cfg.RewrapBatchSize).
+		Return([]*entity.File{rewrapped, raced, broken, plaintext}, nil)
+	keys.On("Rewrap", mock.Anything, rewrapped.Envelope()).
+		Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "v2:a"}, nil)
+	keys.On("Rewrap", mock.Anything, raced.Envelope()).
+		Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "v2:b"}, nil)
+	keys.On("Rewrap", mock.Anything, broken.Envelope()).Return(nil, assert.AnError)
+	repo.On("UpdateWrappedDataKey", mock.Anything, rewrapped.ID, "v1:a", "v2:a").Return(nil)
+	repo.On("UpdateWrappedDataKey", mock.Anything, raced.ID, "v1:b", "v2:b").Return(apperrors.ErrNotFound("file"))
+
+	uc := files.NewUsecase(repo, newIdleSlotRepo(), storage, new(mockScanner), keys, tx, zap.NewNop(), &logger.NoopAuditLogger{}, cfg)
+	result, err := uc.RewrapKeysBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, files.RewrapBatchResult{Rewrapped: 1, Skipped: 2, Failed: 1}, result)
+	repo.AssertExpectations(t)
+}
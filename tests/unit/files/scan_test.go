@@ -0,0 +1,126 @@
+package files_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/files"
+	"erp-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newScanUC(repo *mockFileRepo, storage *mockFileStorage, scanner *mockScanner, tx *mockTxManager) files.Usecase {
+	return files.NewUsecase(repo, newIdleSlotRepo(), storage, scanner, new(mockKeyRewrapper), tx, zap.NewNop(), &logger.NoopAuditLogger{}, files.DefaultConfig())
+}
+
+func makePendingFile() *entity.File {
+	file := makeFile(testBucket, testStorageKey)
+	file.ScanStatus = entity.FileScanStatusPending
+	return file
+}
+
+func TestScanFile_Clean_MarksScanned(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makePendingFile()
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(&files.ScanVerdict{}, nil)
+	repo.On("MarkScanned", mock.Anything, file.ID).Return(nil)
+
+	status, err := newScanUC(repo, storage, scanner, tx).ScanFile(context.Background(), file)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.FileScanStatusClean, status)
+	storage.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	storage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScanFile_Infected_QuarantinesBeforeDeletingOriginal(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makePendingFile()
+	quarantine := files.DefaultConfig().QuarantineBucket
+
+	var order []string
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("X5O!P%@AP")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).
+		Return(&files.ScanVerdict{Infected: true, Signature: "Eicar-Test-Signature"}, nil)
+	storage.On("CopyObject", mock.Anything, testBucket, testStorageKey, quarantine, testStorageKey).
+		Run(func(mock.Arguments) { order = append(order, "copy") }).Return(nil)
+	repo.On("MarkQuarantined", mock.Anything, file.ID, quarantine, testStorageKey, "Eicar-Test-Signature").
+		Run(func(mock.Arguments) { order = append(order, "mark") }).Return(nil)
+	storage.On("DeleteFile", mock.Anything, testBucket, testStorageKey).
+		Run(func(mock.Arguments) { order = append(order, "delete") }).Return(nil)
+
+	status, err := newScanUC(repo, storage, scanner, tx).ScanFile(context.Background(), file)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.FileScanStatusInfected, status)
+	assert.Equal(t, []string{"copy", "mark", "delete"}, order)
+}
+
+func TestScanFile_ScannerUnavailable_RecordsFailure(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	file := makePendingFile()
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, testStorageKey, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("data")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	repo.On("RecordScanFailure", mock.Anything, file.ID, files.DefaultConfig().MaxScanAttempts).Return(nil)
+
+	_, err := newScanUC(repo, storage, scanner, tx).ScanFile(context.Background(), file)
+
+	require.Error(t, err)
+	repo.AssertCalled(t, "RecordScanFailure", mock.Anything, file.ID, files.DefaultConfig().MaxScanAttempts)
+	repo.AssertNotCalled(t, "MarkScanned", mock.Anything, mock.Anything)
+}
+
+func TestScanBatch_CountsOutcomes(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+	clean := makePendingFile()
+	clean.StorageKey = "participants/clean.pdf"
+	broken := makePendingFile()
+	broken.StorageKey = "participants/broken.pdf"
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStaleScanClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimPendingScan", mock.Anything, files.DefaultConfig().ScanBatchSize, files.DefaultConfig().MaxScanAttempts).
+		Return([]*entity.File{clean, broken}, nil)
+
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, clean.StorageKey, mock.Anything).
+		Return(io.NopCloser(strings.NewReader("ok")), nil)
+	storage.On("GetDecryptedObject", mock.Anything, testBucket, broken.StorageKey, mock.Anything).
+		Return(nil, assert.AnError)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(&files.ScanVerdict{}, nil)
+	repo.On("MarkScanned", mock.Anything, clean.ID).Return(nil)
+	repo.On("RecordScanFailure", mock.Anything, broken.ID, files.DefaultConfig().MaxScanAttempts).Return(nil)
+
+	result, err := newScanUC(repo, storage, scanner, tx).ScanBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, files.ScanBatchResult{Clean: 1, Failed: 1}, result)
+}
+
+func TestScanBatch_ClaimFails(t *testing.T) {
+	repo, storage, scanner, tx := new(mockFileRepo), new(mockFileStorage), new(mockScanner), new(mockTxManager)
+
+	tx.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ReleaseStaleScanClaimsOlderThan", mock.Anything, mock.Anything).Return(nil)
+	repo.On("ClaimPendingScan", mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	_, err := newScanUC(repo, storage, scanner, tx).ScanBatch(context.Background())
+
+	require.Error(t, err)
+	scanner.AssertNotCalled(t, "Scan", mock.Anything, mock.Anything)
+}
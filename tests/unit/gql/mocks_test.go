@@ -0,0 +1,107 @@
+package gql_test
+
+import (
+	"context"
+
+	"erp-service/masterdata"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBatchReader struct {
+	mock.Mock
+}
+
+func (m *MockBatchReader) ListParticipantPage(ctx context.Context, req *participant.ListParticipantsRequest) ([]*participant.ParticipantResponse, *participant.PaginationMeta, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*participant.ParticipantResponse), args.Get(1).(*participant.PaginationMeta), args.Error(2)
+}
+
+func (m *MockBatchReader) GetParticipantsByIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID]*participant.ParticipantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*participant.ParticipantResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListIdentitiesByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.IdentityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.IdentityResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListAddressesByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.AddressResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.AddressResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListBankAccountsByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.BankAccountResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.BankAccountResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListFamilyMembersByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.FamilyMemberResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.FamilyMemberResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) GetEmploymentsByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID]*participant.EmploymentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*participant.EmploymentResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) GetPensionsByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID]*participant.PensionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*participant.PensionResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListBeneficiariesByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.BeneficiaryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.BeneficiaryResponse), args.Error(1)
+}
+
+func (m *MockBatchReader) ListStatusHistoriesByParticipantIDs(ctx context.Context, req *participant.BatchParticipantsRequest) (map[uuid.UUID][]participant.StatusHistoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]participant.StatusHistoryResponse), args.Error(1)
+}
+
+type MockMasterdataReader struct {
+	mock.Mock
+}
+
+func (m *MockMasterdataReader) GetItemTree(ctx context.Context, categoryCode string, tenantID *uuid.UUID) ([]*masterdata.ItemResponse, error) {
+	args := m.Called(ctx, categoryCode, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*masterdata.ItemResponse), args.Error(1)
+}
@@ -0,0 +1,317 @@
+package gql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"erp-service/config"
+	gql "erp-service/delivery/graphql"
+	"erp-service/masterdata"
+	"erp-service/saving/participant"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testGraphQLConfig = config.GraphQLConfig{
+	MaxDepth:        8,
+	MaxComplexity:   5000,
+	DefaultListSize: 5,
+	Introspection:   true,
+}
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newTestApp(t *testing.T, cfg config.GraphQLConfig, tenantID, productID uuid.UUID, reader *MockBatchReader, items *MockMasterdataReader) *fiber.App {
+	t.Helper()
+
+	schema, err := gql.NewSchema(cfg)
+	require.NoError(t, err)
+	handler := gql.NewHandler(schema, reader, items, cfg, zap.NewNop())
+
+	app := fiber.New(fiber.Config{ErrorHandler: gql.ErrorHandler(zap.NewNop())})
+	app.Post("/graphql", func(c *fiber.Ctx) error {
+		c.Locals("tenant_id", tenantID)
+		c.Locals("product_id", productID)
+		return c.Next()
+	}, handler.Serve)
+	return app
+}
+
+func execute(t *testing.T, app *fiber.App, query string, variables map[string]any) (int, *graphQLResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var out graphQLResponse
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	}
+	return resp.StatusCode, &out
+}
+
+func strPtr(s string) *string { return &s }
+
+func batchOf(ids ...uuid.UUID) interface{} {
+	return mock.MatchedBy(func(req *participant.BatchParticipantsRequest) bool {
+		if len(req.ParticipantIDs) != len(ids) {
+			return false
+		}
+		want := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			want[id] = true
+		}
+		for _, id := range req.ParticipantIDs {
+			if !want[id] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestHandler_BatchesNestedFieldsAcrossAPage(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	reader := new(MockBatchReader)
+	items := new(MockMasterdataReader)
+
+	page := make([]*participant.ParticipantResponse, len(ids))
+	identities := make(map[uuid.UUID][]participant.IdentityResponse)
+	for i, id := range ids {
+		page[i] = &participant.ParticipantResponse{
+			ID:        id,
+			TenantID:  tenantID,
+			ProductID: productID,
+			FullName:  "Participant",
+			Gender:    strPtr("M"),
+			KTPNumber: strPtr("3171234567890001"),
+			Status:    "DRAFT",
+		}
+		identities[id] = []participant.IdentityResponse{{
+			ID:             uuid.New(),
+			IdentityType:   "KTP",
+			IdentityNumber: "3171234567890001",
+		}}
+	}
+	reader.On("ListParticipantPage", mock.Anything, mock.MatchedBy(func(req *participant.ListParticipantsRequest) bool {
+		return req.TenantID == tenantID && req.ProductID == productID && req.PerPage == 3
+	})).Return(page, &participant.PaginationMeta{Page: 1, PerPage: 3, Total: 3, TotalPages: 1}, nil).Once()
+	reader.On("ListIdentitiesByParticipantIDs", mock.Anything, batchOf(ids...)).Return(identities, nil).Once()
+	reader.On("GetEmploymentsByParticipantIDs", mock.Anything, batchOf(ids...)).
+		Return(map[uuid.UUID]*participant.EmploymentResponse{}, nil).Once()
+
+	items.On("GetItemTree", mock.Anything, "GENDER", &tenantID).
+		Return([]*masterdata.ItemResponse{{Code: "M", Name: "Male"}}, nil).Once()
+	items.On("GetItemTree", mock.Anything, "IDENTITY_TYPE", &tenantID).
+		Return([]*masterdata.ItemResponse{{Code: "KTP", Name: "Kartu Tanda Penduduk"}}, nil).Once()
+
+	app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, items)
+	status, resp := execute(t, app, `{
+		participantPage(perPage: 3) {
+			items {
+				id
+				genderLabel
+				ktpNumber
+				identities { identityNumber identityTypeLabel }
+				employment { id }
+			}
+			pagination { total }
+		}
+	}`, nil)
+
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, resp.Errors)
+
+	var data struct {
+		Items []struct {
+			ID          string  `json:"id"`
+			GenderLabel *string `json:"genderLabel"`
+			KTPNumber   *string `json:"ktpNumber"`
+			Identities  []struct {
+				IdentityNumber    string  `json:"identityNumber"`
+				IdentityTypeLabel *string `json:"identityTypeLabel"`
+			} `json:"identities"`
+			Employment *struct{} `json:"employment"`
+		} `json:"items"`
+		Pagination struct {
+			Total int `json:"total"`
+		} `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data["participantPage"], &data))
+	require.Len(t, data.Items, 3)
+	assert.Equal(t, 3, data.Pagination.Total)
+	for i, item := range data.Items {
+		assert.Equal(t, ids[i].String(), item.ID)
+		assert.Equal(t, "Male", *item.GenderLabel)
+		assert.Nil(t, item.KTPNumber, "PII is hidden without a permission")
+		require.Len(t, item.Identities, 1)
+		assert.Empty(t, item.Identities[0].IdentityNumber)
+		assert.Equal(t, "Kartu Tanda Penduduk", *item.Identities[0].IdentityTypeLabel)
+		assert.Nil(t, item.Employment)
+	}
+
+	reader.AssertExpectations(t)
+	items.AssertExpectations(t)
+}
+
+func TestHandler_Participants(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	known := uuid.New()
+	unknown := uuid.New()
+
+	t.Run("unknown IDs resolve to null in place", func(t *testing.T) {
+		reader := new(MockBatchReader)
+		reader.On("GetParticipantsByIDs", mock.Anything, batchOf(unknown, known)).
+			Return(map[uuid.UUID]*participant.ParticipantResponse{
+				known: {ID: known, TenantID: tenantID, ProductID: productID, FullName: "Known", Status: "ACTIVE"},
+			}, nil).Once()
+
+		app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, new(MockMasterdataReader))
+		status, resp := execute(t, app, `query($ids: [ID!]!) { participants(ids: $ids) { fullName } }`,
+			map[string]any{"ids": []string{unknown.String(), known.String()}})
+
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `[null, {"fullName": "Known"}]`, string(resp.Data["participants"]))
+		reader.AssertExpectations(t)
+	})
+
+	t.Run("invalid ID is a bad request", func(t *testing.T) {
+		reader := new(MockBatchReader)
+
+		app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, new(MockMasterdataReader))
+		status, resp := execute(t, app, `{ participant(id: "not-a-uuid") { id } }`, nil)
+
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "ERR_BAD_REQUEST", resp.Errors[0].Extensions["code"])
+		reader.AssertNotCalled(t, "GetParticipantsByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failures below the usecase are not leaked", func(t *testing.T) {
+		reader := new(MockBatchReader)
+		reader.On("GetParticipantsByIDs", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).Once()
+
+		app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, new(MockMasterdataReader))
+		status, resp := execute(t, app, `{ participant(id: "`+known.String()+`") { id } }`, nil)
+
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "internal server error", resp.Errors[0].Message)
+		assert.Equal(t, "ERR_INTERNAL", resp.Errors[0].Extensions["code"])
+	})
+}
+
+func TestHandler_Limits(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("rejects queries over the complexity limit before resolving", func(t *testing.T) {
+		cfg := testGraphQLConfig
+		cfg.MaxComplexity = 100
+		reader := new(MockBatchReader)
+
+		app := newTestApp(t, cfg, tenantID, productID, reader, new(MockMasterdataReader))
+		status, _ := execute(t, app, `query($n: Int) {
+			participantPage(perPage: $n) {
+				items { id identities { id } addresses { id } }
+			}
+		}`, map[string]any{"n": 50})
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		reader.AssertNotCalled(t, "ListParticipantPage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("counts fragments toward complexity", func(t *testing.T) {
+		cfg := testGraphQLConfig
+		cfg.MaxComplexity = 100
+		reader := new(MockBatchReader)
+
+		app := newTestApp(t, cfg, tenantID, productID, reader, new(MockMasterdataReader))
+		status, _ := execute(t, app, `
+			query { participantPage(perPage: 50) { items { ...Detail } } }
+			fragment Detail on Participant { id identities { id } }
+		`, nil)
+
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("rejects queries the complexity check cannot parse", func(t *testing.T) {
+		for name, query := range map[string]string{
+			"syntax error":     `{ participantPage(perPage: 50) { items { id } }`,
+			"unknown fragment": `{ participantPage(perPage: 50) { items { ...Missing } } }`,
+			"no root type":     `subscription { participantPage { items { id } } }`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				reader := new(MockBatchReader)
+
+				app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, new(MockMasterdataReader))
+				status, _ := execute(t, app, query, nil)
+
+				assert.Equal(t, http.StatusBadRequest, status)
+				reader.AssertNotCalled(t, "ListParticipantPage", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("rejects queries deeper than the limit", func(t *testing.T) {
+		cfg := testGraphQLConfig
+		cfg.MaxDepth = 2
+		reader := new(MockBatchReader)
+
+		app := newTestApp(t, cfg, tenantID, productID, reader, new(MockMasterdataReader))
+		status, resp := execute(t, app, `{ participantPage { items { identities { id } } } }`, nil)
+
+		require.Equal(t, http.StatusOK, status)
+		require.NotEmpty(t, resp.Errors)
+		assert.Nil(t, resp.Data)
+		reader.AssertNotCalled(t, "ListParticipantPage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validates page arguments like the REST list", func(t *testing.T) {
+		reader := new(MockBatchReader)
+
+		app := newTestApp(t, testGraphQLConfig, tenantID, productID, reader, new(MockMasterdataReader))
+		status, resp := execute(t, app, `{ participantPage(perPage: 500) { items { id } } }`, nil)
+
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "ERR_VALIDATION", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("introspection can be disabled", func(t *testing.T) {
+		cfg := testGraphQLConfig
+		cfg.Introspection = false
+
+		app := newTestApp(t, cfg, tenantID, productID, new(MockBatchReader), new(MockMasterdataReader))
+		status, resp := execute(t, app, `{ __schema { queryType { name } } }`, nil)
+
+		require.Equal(t, http.StatusOK, status)
+		assert.NotContains(t, resp.Data, "__schema")
+	})
+}
@@ -0,0 +1,126 @@
+package hashivault_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"erp-service/impl/hashivault"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeVault issues leases of leaseTTL seconds, one by default. Renewals
+// grant renewTTL seconds.
+type fakeVault struct {
+	mu        sync.Mutex
+	issued    int
+	leaseTTL  int
+	renewTTL  int
+	renewErr  error
+	renewals  []string
+	revoked   []string
+	renewable bool
+}
+
+func (f *fakeVault) GenerateDatabaseCredentials(_ context.Context, role string) (*hashivault.DatabaseCredentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issued++
+	ttl := f.leaseTTL
+	if ttl == 0 {
+		ttl = 1
+	}
+	return &hashivault.DatabaseCredentials{
+		Username:      fmt.Sprintf("v-%s-%d", role, f.issued),
+		Password:      fmt.Sprintf("secret-%d", f.issued),
+		LeaseID:       fmt.Sprintf("database/creds/%s/%d", role, f.issued),
+		LeaseDuration: ttl,
+		Renewable:     f.renewable,
+	}, nil
+}
+
+func (f *fakeVault) RenewLease(_ context.Context, leaseID string, increment int) (*hashivault.LeaseInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.renewals = append(f.renewals, leaseID)
+	if f.renewErr != nil {
+		return nil, f.renewErr
+	}
+	return &hashivault.LeaseInfo{LeaseID: leaseID, LeaseDuration: f.renewTTL, Renewable: true}, nil
+}
+
+func (f *fakeVault) RevokeLease(_ context.Context, leaseID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revoked = append(f.revoked, leaseID)
+	return nil
+}
+
+func (f *fakeVault) renewCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.renewals)
+}
+
+func newManager(t *testing.T, vault *fakeVault) *hashivault.DatabaseCredentialManager {
+	t.Helper()
+	m, err := hashivault.NewDatabaseCredentialManager(context.Background(), vault, "erp-app", zap.NewNop())
+	require.NoError(t, err)
+	return m
+}
+
+func TestDatabaseCredentialManager_RenewsWhileVaultExtendsTheLease(t *testing.T) {
+	vault := &fakeVault{renewable: true, renewTTL: 1}
+	m := newManager(t, vault)
+	defer m.Close(context.Background())
+
+	require.Eventually(t, func() bool { return vault.renewCount() >= 1 }, 3*time.Second, 10*time.Millisecond)
+
+	username, password := m.Credentials()
+	assert.Equal(t, "v-erp-app-1", username)
+	assert.Equal(t, "secret-1", password)
+}
+
+func TestDatabaseCredentialManager_Rotates(t *testing.T) {
+	cases := map[string]*fakeVault{
+		"max TTL reached":        {renewable: true, renewTTL: 0},
+		"renewal fails":          {renewable: true, renewErr: errors.New("permission denied")},
+		"lease is not renewable": {renewable: false},
+	}
+	for name, vault := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := newManager(t, vault)
+
+			require.Eventually(t, func() bool {
+				username, _ := m.Credentials()
+				return username == "v-erp-app-2"
+			}, 3*time.Second, 10*time.Millisecond)
+
+			require.NoError(t, m.Close(context.Background()))
+			vault.mu.Lock()
+			defer vault.mu.Unlock()
+			assert.Contains(t, vault.revoked, "database/creds/erp-app/2", "the current lease is revoked on close")
+		})
+	}
+}
+
+func TestDatabaseCredentialManager_CloseRevokesRetiredLeasesStillValid(t *testing.T) {
+	vault := &fakeVault{leaseTTL: 2, renewable: true, renewErr: errors.New("vault sealed")}
+	m := newManager(t, vault)
+
+	require.Eventually(t, func() bool {
+		username, _ := m.Credentials()
+		return username == "v-erp-app-2"
+	}, 3*time.Second, 10*time.Millisecond)
+	require.NoError(t, m.Close(context.Background()))
+
+	vault.mu.Lock()
+	defer vault.mu.Unlock()
+	assert.ElementsMatch(t, []string{"database/creds/erp-app/1", "database/creds/erp-app/2"}, vault.revoked)
+}
@@ -0,0 +1,191 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"erp-service/pkg/health"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flaky fails while its error is set and counts its runs.
+type flaky struct {
+	runs atomic.Int32
+	err  atomic.Pointer[error]
+}
+
+func (f *flaky) fail(err error) { f.err.Store(&err) }
+func (f *flaky) recover()       { f.err.Store(nil) }
+
+func (f *flaky) run(context.Context) error {
+	f.runs.Add(1)
+	if err := f.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func TestRegistry_Ready_Aggregates(t *testing.T) {
+	t.Run("all up", func(t *testing.T) {
+		registry := health.NewRegistry(health.Options{})
+		registry.Register(health.Check{Name: "postgres", Critical: true, Run: func(context.Context) error { return nil }})
+		registry.Register(health.Check{Name: "smtp", Run: func(context.Context) error { return nil }})
+
+		report := registry.Ready(context.Background())
+
+		assert.Equal(t, health.StatusUp, report.Status)
+		assert.True(t, report.Healthy())
+		assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+		assert.True(t, report.Checks["postgres"].Critical)
+		assert.Equal(t, health.StatusUp, report.Checks["smtp"].Status)
+	})
+
+	t.Run("a non-critical check down only degrades", func(t *testing.T) {
+		registry := health.NewRegistry(health.Options{})
+		registry.Register(health.Check{Name: "postgres", Critical: true, Run: func(context.Context) error { return nil }})
+		registry.Register(health.Check{Name: "smtp", Run: func(context.Context) error { return errors.New("connection refused") }})
+
+		report := registry.Ready(context.Background())
+
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.True(t, report.Healthy())
+		assert.Equal(t, health.StatusDown, report.Checks["smtp"].Status)
+		assert.Equal(t, "connection refused", report.Checks["smtp"].Error)
+	})
+
+	t.Run("a critical check down takes the process out of rotation", func(t *testing.T) {
+		registry := health.NewRegistry(health.Options{})
+		registry.Register(health.Check{Name: "postgres", Critical: true, Run: func(context.Context) error { return errors.New("dial tcp: i/o timeout") }})
+		registry.Register(health.Check{Name: "smtp", Run: func(context.Context) error { return nil }})
+
+		report := registry.Ready(context.Background())
+
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.False(t, report.Healthy())
+	})
+}
+
+func TestRegistry_FailureThreshold(t *testing.T) {
+	check := &flaky{}
+	registry := health.NewRegistry(health.Options{FailureThreshold: 3})
+	registry.Register(health.Check{Name: "redis", Critical: true, Run: check.run})
+	check.fail(errors.New("timeout"))
+
+	first := registry.Ready(context.Background())
+	assert.Equal(t, health.StatusDegraded, first.Status, "a single failure must not fail readiness")
+	assert.Equal(t, health.StatusFailing, first.Checks["redis"].Status)
+	assert.Equal(t, 1, first.Checks["redis"].ConsecutiveFailures)
+
+	registry.Ready(context.Background())
+	third := registry.Ready(context.Background())
+	assert.Equal(t, health.StatusDown, third.Status)
+	assert.Equal(t, 3, third.Checks["redis"].ConsecutiveFailures)
+
+	check.recover()
+	recovered := registry.Ready(context.Background())
+	assert.Equal(t, health.StatusUp, recovered.Status)
+	assert.Zero(t, recovered.Checks["redis"].ConsecutiveFailures)
+
+	check.fail(errors.New("timeout"))
+	assert.Equal(t, health.StatusFailing, registry.Ready(context.Background()).Checks["redis"].Status, "a success resets the count")
+}
+
+func TestRegistry_CachesResults(t *testing.T) {
+	check := &flaky{}
+	registry := health.NewRegistry(health.Options{CacheTTL: time.Hour})
+	registry.Register(health.Check{Name: "minio", Run: check.run})
+
+	for i := 0; i < 5; i++ {
+		registry.Ready(context.Background())
+	}
+
+	assert.Equal(t, int32(1), check.runs.Load())
+}
+
+func TestRegistry_CheckTimeout(t *testing.T) {
+	registry := health.NewRegistry(health.Options{Timeout: 20 * time.Millisecond})
+	block := make(chan struct{})
+	defer close(block)
+	registry.Register(health.Check{Name: "vault", Critical: true, Run: func(context.Context) error {
+		<-block
+		return nil
+	}})
+	registry.Register(health.Check{Name: "panics", Run: func(context.Context) error {
+		panic("nil client")
+	}})
+
+	start := time.Now()
+	report := registry.Ready(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second, "a check ignoring its context must not hold the probe")
+	assert.Contains(t, report.Checks["vault"].Error, "timed out")
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Contains(t, report.Checks["panics"].Error, "panicked")
+}
+
+func TestRegistry_LivenessIgnoresDependencies(t *testing.T) {
+	registry := health.NewRegistry(health.Options{})
+	registry.Register(health.Check{Name: "postgres", Critical: true, Run: func(context.Context) error { return errors.New("down") }})
+	registry.RegisterLiveness(health.Check{Name: "job_processor", Run: func(context.Context) error { return nil }})
+
+	assert.False(t, registry.Ready(context.Background()).Healthy())
+
+	live := registry.Live(context.Background())
+	assert.True(t, live.Healthy())
+	assert.NotContains(t, live.Checks, "postgres")
+	assert.True(t, live.Checks["job_processor"].Critical, "liveness checks are always critical")
+}
+
+func TestHandler(t *testing.T) {
+	registry := health.NewRegistry(health.Options{})
+	registry.Register(health.Check{Name: "postgres", Critical: true, Run: func(context.Context) error { return errors.New("dial tcp 10.0.0.5:5432") }})
+
+	serve := func(detailed bool) (*httptest.ResponseRecorder, health.Report) {
+		rec := httptest.NewRecorder()
+		health.Handler(registry.Ready, detailed).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec, report
+	}
+
+	rec, report := serve(false)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Empty(t, report.Checks["postgres"].Error, "addresses must not leak outside development")
+
+	_, report = serve(true)
+	assert.Equal(t, "dial tcp 10.0.0.5:5432", report.Checks["postgres"].Error)
+}
+
+func TestPoolCheck(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+
+	require.NoError(t, health.PoolCheck(db, 0)(ctx), "a pool with free slots passes")
+
+	held, err := db.Conn(ctx)
+	require.NoError(t, err)
+
+	assert.NoError(t, health.PoolCheck(db, time.Hour)(ctx), "a saturated pool is not wedged until stall has passed")
+
+	check := health.PoolCheck(db, 0)
+	assert.ErrorContains(t, check(ctx), "none released")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = held.Close()
+	}()
+	assert.NoError(t, check(ctx), "a connection handed back within the wait counts as progress")
+}
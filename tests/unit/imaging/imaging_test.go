@@ -0,0 +1,186 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"erp-service/pkg/imaging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// splitImage is red on the left half and blue on the right half.
+func splitImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 segment carrying the orientation tag and a fake
+// GPS marker right after the JPEG SOI.
+func withExif(t *testing.T, jpg []byte, orientation uint16) []byte {
+	t.Helper()
+	require.Equal(t, []byte{0xFF, 0xD8}, jpg[:2])
+
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("II")
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(0x2A))
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(8))
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(1))
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(0x0112))
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(3))
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(1))
+	_ = binary.Write(tiff, binary.LittleEndian, orientation)
+	_ = binary.Write(tiff, binary.LittleEndian, uint16(0))
+	_ = binary.Write(tiff, binary.LittleEndian, uint32(0))
+	tiff.WriteString("GPSLatitude-6.2088")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+func isReddish(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > b
+}
+
+func TestProcess_AppliesExifOrientation(t *testing.T) {
+	// Orientation 6: the camera was rotated, display needs 90° clockwise.
+	data := withExif(t, encodeJPEG(t, splitImage(40, 20)), 6)
+
+	result, err := imaging.Process(data, imaging.Options{MaxDimension: 2048, ThumbnailDimension: 320})
+	require.NoError(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(result.Image))
+	require.NoError(t, err)
+	assert.Equal(t, 20, img.Bounds().Dx())
+	assert.Equal(t, 40, img.Bounds().Dy())
+	// The left (red) half ends up on top.
+	assert.True(t, isReddish(img.At(10, 5)))
+	assert.False(t, isReddish(img.At(10, 35)))
+}
+
+func TestProcess_AllOrientations(t *testing.T) {
+	src := encodeJPEG(t, splitImage(40, 20))
+	tests := []struct {
+		orientation uint16
+		w, h        int
+		redAt       image.Point
+	}{
+		{1, 40, 20, image.Pt(5, 10)},
+		{2, 40, 20, image.Pt(35, 10)},
+		{3, 40, 20, image.Pt(35, 10)},
+		{4, 40, 20, image.Pt(5, 10)},
+		{5, 20, 40, image.Pt(10, 5)},
+		{6, 20, 40, image.Pt(10, 5)},
+		{7, 20, 40, image.Pt(10, 35)},
+		{8, 20, 40, image.Pt(10, 35)},
+	}
+	for _, tt := range tests {
+		result, err := imaging.Process(withExif(t, src, tt.orientation), imaging.Options{MaxDimension: 2048})
+		require.NoError(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(result.Image))
+		require.NoError(t, err)
+		assert.Equal(t, tt.w, img.Bounds().Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.h, img.Bounds().Dy(), "orientation %d", tt.orientation)
+		assert.True(t, isReddish(img.At(tt.redAt.X, tt.redAt.Y)), "orientation %d", tt.orientation)
+	}
+}
+
+func TestProcess_StripsMetadata(t *testing.T) {
+	data := withExif(t, encodeJPEG(t, splitImage(40, 20)), 1)
+	require.True(t, bytes.Contains(data, []byte("GPSLatitude")))
+
+	result, err := imaging.Process(data, imaging.Options{MaxDimension: 2048, ThumbnailDimension: 320})
+	require.NoError(t, err)
+
+	for _, out := range [][]byte{result.Image, result.Thumbnail} {
+		assert.False(t, bytes.Contains(out, []byte("Exif\x00\x00")))
+		assert.False(t, bytes.Contains(out, []byte("GPSLatitude")))
+	}
+}
+
+func TestProcess_BoundsResolutionAndThumbnail(t *testing.T) {
+	data := encodeJPEG(t, splitImage(3000, 1500))
+
+	result, err := imaging.Process(data, imaging.Options{MaxDimension: 2048, ThumbnailDimension: 320})
+	require.NoError(t, err)
+
+	assert.Equal(t, imaging.ContentTypeJPEG, result.ContentType)
+	assert.Equal(t, 2048, result.Width)
+	assert.Equal(t, 1024, result.Height)
+	assert.Equal(t, imaging.ContentTypeJPEG, result.ThumbnailType)
+	assert.Equal(t, 320, result.ThumbnailWidth)
+	assert.Equal(t, 160, result.ThumbnailHeight)
+
+	thumb, err := jpeg.Decode(bytes.NewReader(result.Thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 320, 160), thumb.Bounds())
+}
+
+func TestProcess_PNGStaysPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	result, err := imaging.Process(buf.Bytes(), imaging.Options{MaxDimension: 2048, ThumbnailDimension: 16})
+	require.NoError(t, err)
+
+	assert.Equal(t, imaging.ContentTypePNG, result.ContentType)
+	_, err = png.Decode(bytes.NewReader(result.Image))
+	require.NoError(t, err)
+	assert.Equal(t, 16, result.ThumbnailWidth)
+	assert.Equal(t, 8, result.ThumbnailHeight)
+}
+
+func TestProcess_RejectsOversizedImage(t *testing.T) {
+	data := encodeJPEG(t, splitImage(200, 100))
+
+	_, err := imaging.Process(data, imaging.Options{MaxDimension: 2048, MaxPixels: 10_000})
+
+	require.ErrorIs(t, err, imaging.ErrTooLarge)
+}
+
+func TestProcess_RejectsNonImage(t *testing.T) {
+	_, err := imaging.Process([]byte("%PDF-1.7"), imaging.Options{MaxDimension: 2048})
+	require.Error(t, err)
+}
+
+func TestSupported(t *testing.T) {
+	assert.True(t, imaging.Supported("image/jpeg"))
+	assert.True(t, imaging.Supported("image/png"))
+	assert.False(t, imaging.Supported("application/pdf"))
+}
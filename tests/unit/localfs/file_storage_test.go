@@ -0,0 +1,201 @@
+package localfs_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/impl/localfs"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBucket = "participants"
+
+func newSigner(t *testing.T) *localfs.Signer {
+	t.Helper()
+	signer, err := localfs.NewSigner([]byte(strings.Repeat("k", 32)), "http://files.test/")
+	require.NoError(t, err)
+	return signer
+}
+
+func newStorage(t *testing.T) (participant.FileStorageAdapter, *localfs.Server, string) {
+	t.Helper()
+	root := t.TempDir()
+	masterKey := make([]byte, envelope.DataKeySize)
+	_, err := rand.Read(masterKey)
+	require.NoError(t, err)
+	keys, err := envelope.NewLocalKeyProvider(masterKey)
+	require.NoError(t, err)
+
+	signer := newSigner(t)
+	return localfs.NewFileStorage(root, signer, envelope.NewEncryptor(keys, "tenant-")),
+		localfs.NewServer(root, signer), root
+}
+
+func readAll(t *testing.T, r io.ReadCloser) string {
+	t.Helper()
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func assertAppErrorKind(t *testing.T, err error, kind errors.Kind) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, kind, appErr.Kind)
+}
+
+func TestFileStorage_ObjectLifecycle(t *testing.T) {
+	storage, _, root := newStorage(t)
+	ctx := context.Background()
+	key := "tenant/product/participant/ktp/photo.jpg"
+
+	stored, err := storage.UploadFile(ctx, testBucket, key, strings.NewReader("hello"), 5, "image/jpeg")
+	require.NoError(t, err)
+	assert.Equal(t, key, stored)
+
+	size, err := storage.StatObject(ctx, testBucket, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	obj, err := storage.GetObject(ctx, testBucket, key)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", readAll(t, obj))
+
+	require.NoError(t, storage.CopyObject(ctx, testBucket, key, "quarantine", key))
+	copied, err := storage.GetObject(ctx, "quarantine", key)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", readAll(t, copied))
+
+	require.NoError(t, storage.DeleteFile(ctx, testBucket, key))
+	_, err = storage.StatObject(ctx, testBucket, key)
+	assertAppErrorKind(t, err, errors.KindNotFound)
+
+	// Deleting again succeeds, and emptied directories are pruned.
+	require.NoError(t, storage.DeleteFile(ctx, testBucket, key))
+	entries, err := os.ReadDir(filepath.Join(root, testBucket))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStorage_SizeMismatch_LeavesNothingBehind(t *testing.T) {
+	storage, _, root := newStorage(t)
+	ctx := context.Background()
+
+	_, err := storage.UploadFile(ctx, testBucket, "a/b.pdf", strings.NewReader("short"), 10, "application/pdf")
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(root, testBucket, "a"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStorage_RejectsKeysOutsideBucket(t *testing.T) {
+	storage, _, _ := newStorage(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"../escape.txt", "/abs.txt", "a/../../b.txt", "a//b.txt", ""} {
+		_, err := storage.UploadFile(ctx, testBucket, key, strings.NewReader("x"), 1, "text/plain")
+		assertAppErrorKind(t, err, errors.KindBadRequest)
+	}
+	_, err := storage.UploadFile(ctx, "../etc", "x.txt", strings.NewReader("x"), 1, "text/plain")
+	assertAppErrorKind(t, err, errors.KindBadRequest)
+}
+
+func TestFileStorage_EncryptedRoundTrip(t *testing.T) {
+	storage, _, root := newStorage(t)
+	ctx := context.Background()
+	plaintext := bytes.Repeat([]byte("identity "), 20000)
+
+	env, err := storage.UploadEncryptedFile(ctx, uuid.New(), testBucket, "ktp.jpg", bytes.NewReader(plaintext), int64(len(plaintext)))
+	require.NoError(t, err)
+
+	onDisk, err := os.ReadFile(filepath.Join(root, testBucket, "ktp.jpg"))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(onDisk, []byte("identity")))
+
+	obj, err := storage.GetDecryptedObject(ctx, testBucket, "ktp.jpg", env)
+	require.NoError(t, err)
+	assert.Equal(t, string(plaintext), readAll(t, obj))
+}
+
+func TestFileStorage_PresignedDownload(t *testing.T) {
+	storage, server, _ := newStorage(t)
+	ctx := context.Background()
+	key := "dir/file name.pdf"
+	_, err := storage.UploadFile(ctx, testBucket, key, strings.NewReader("pdf"), 3, "application/pdf")
+	require.NoError(t, err)
+
+	raw, err := storage.GetPresignedURL(ctx, testBucket, key, time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "files.test", u.Host)
+	assert.Equal(t, localfs.RoutePrefix+"/"+testBucket+"/"+key, u.Path)
+
+	expires, signature := u.Query().Get(localfs.FormExpires), u.Query().Get(localfs.FormSignature)
+	f, info, err := server.Open(testBucket, key, expires, signature)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), info.Size())
+	assert.Equal(t, "pdf", readAll(t, f))
+
+	_, _, err = server.Open(testBucket, "dir/other.pdf", expires, signature)
+	assertAppErrorKind(t, err, errors.KindForbidden)
+
+	expired, err := storage.GetPresignedURL(ctx, testBucket, key, -time.Minute)
+	require.NoError(t, err)
+	u, _ = url.Parse(expired)
+	_, _, err = server.Open(testBucket, key, u.Query().Get(localfs.FormExpires), u.Query().Get(localfs.FormSignature))
+	assertAppErrorKind(t, err, errors.KindForbidden)
+}
+
+func TestFileStorage_PresignedUpload(t *testing.T) {
+	storage, server, _ := newStorage(t)
+	ctx := context.Background()
+	key := "uploads/slot/ktp.png"
+
+	presigned, err := storage.PresignUpload(ctx, testBucket, key, "image/png", 8, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "http://files.test"+localfs.RoutePrefix+"/"+testBucket, presigned.URL)
+	assert.Equal(t, key, presigned.FormData[localfs.FormKey])
+
+	err = server.Accept(testBucket, presigned.FormData, "image/png", strings.NewReader("too large!"), 10)
+	assertAppErrorKind(t, err, errors.KindBadRequest)
+
+	tampered := make(map[string]string)
+	for k, v := range presigned.FormData {
+		tampered[k] = v
+	}
+	tampered[localfs.FormKey] = "uploads/slot/other.png"
+	err = server.Accept(testBucket, tampered, "image/png", strings.NewReader("png"), 3)
+	assertAppErrorKind(t, err, errors.KindForbidden)
+
+	err = server.Accept(testBucket, presigned.FormData, "text/html", strings.NewReader("png"), 3)
+	assertAppErrorKind(t, err, errors.KindBadRequest)
+
+	require.NoError(t, server.Accept(testBucket, presigned.FormData, "image/png", strings.NewReader("png"), 3))
+	size, err := storage.StatObject(ctx, testBucket, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), size)
+}
+
+func TestNewSigner_RejectsShortKey(t *testing.T) {
+	_, err := localfs.NewSigner([]byte("short"), "http://files.test")
+	assert.Error(t, err)
+}
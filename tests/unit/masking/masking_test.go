@@ -0,0 +1,102 @@
+package masking_test
+
+import (
+	"testing"
+	"time"
+
+	"erp-service/pkg/masking"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	AccountNumber string `mask:"bank_account"`
+	BankName      string
+}
+
+type person struct {
+	KTPNumber   *string    `mask:"national_id"`
+	PhoneNumber *string    `mask:"phone"`
+	DateOfBirth *time.Time `mask:"date_of_birth"`
+	FullName    string
+	Accounts    []account
+	Primary     *account
+}
+
+func newPerson() *person {
+	ktp, phone := "3201012345678901", "081234567890"
+	dob := time.Date(1970, 3, 14, 0, 0, 0, 0, time.UTC)
+	return &person{
+		KTPNumber:   &ktp,
+		PhoneNumber: &phone,
+		DateOfBirth: &dob,
+		FullName:    "Budi",
+		Accounts:    []account{{AccountNumber: "1234567890", BankName: "BCA"}},
+		Primary:     &account{AccountNumber: "9876543210", BankName: "BNI"},
+	}
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "************8901", masking.Mask("3201012345678901"))
+	assert.Equal(t, "****", masking.Mask("1234"))
+	assert.Equal(t, "", masking.Mask(""))
+	assert.True(t, masking.IsMasked(masking.Mask("3201012345678901")))
+	assert.False(t, masking.IsMasked("3201012345678901"))
+}
+
+func TestPolicyFor_FullWinsOverPartial(t *testing.T) {
+	granted := map[string]bool{
+		masking.FullPermission(masking.NationalID):    true,
+		masking.PartialPermission(masking.NationalID): true,
+		masking.PartialPermission(masking.Phone):      true,
+	}
+	policy := masking.PolicyFor(func(code string) bool { return granted[code] })
+
+	assert.Equal(t, masking.Full, policy.Level(masking.NationalID))
+	assert.Equal(t, masking.Partial, policy.Level(masking.Phone))
+	assert.Equal(t, masking.Hidden, policy.Level(masking.BankAccount))
+	assert.Equal(t, masking.Hidden, policy.Level(masking.DateOfBirth))
+}
+
+func TestApply_Partial(t *testing.T) {
+	p := newPerson()
+	original := p.KTPNumber
+	policy := masking.Policy{
+		masking.NationalID:  masking.Partial,
+		masking.Phone:       masking.Partial,
+		masking.BankAccount: masking.Partial,
+		masking.DateOfBirth: masking.Partial,
+	}
+
+	masking.Apply(p, policy)
+
+	assert.Equal(t, "************8901", *p.KTPNumber)
+	assert.Equal(t, "3201012345678901", *original, "shared pointer must not be written through")
+	assert.Equal(t, "********7890", *p.PhoneNumber)
+	assert.Nil(t, p.DateOfBirth)
+	assert.Equal(t, "******7890", p.Accounts[0].AccountNumber)
+	assert.Equal(t, "******3210", p.Primary.AccountNumber)
+	assert.Equal(t, "Budi", p.FullName)
+	assert.Equal(t, "BCA", p.Accounts[0].BankName)
+}
+
+func TestApply_HiddenByDefault(t *testing.T) {
+	people := []*person{newPerson()}
+
+	masking.Apply(people, masking.Policy{})
+
+	assert.Nil(t, people[0].KTPNumber)
+	assert.Nil(t, people[0].PhoneNumber)
+	assert.Nil(t, people[0].DateOfBirth)
+	assert.Empty(t, people[0].Accounts[0].AccountNumber)
+}
+
+func TestApply_FullPolicy(t *testing.T) {
+	p := newPerson()
+
+	masking.Apply(p, masking.FullPolicy())
+
+	assert.Equal(t, "3201012345678901", *p.KTPNumber)
+	assert.NotNil(t, p.DateOfBirth)
+	assert.Equal(t, "1234567890", p.Accounts[0].AccountNumber)
+}
@@ -0,0 +1,168 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sample returns the value of the series name{labels}, or 0 if it has not
+// been exported yet.
+func sample(t *testing.T, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if matches(m, labels) {
+				switch {
+				case m.Counter != nil:
+					return m.GetCounter().GetValue()
+				case m.Gauge != nil:
+					return m.GetGauge().GetValue()
+				case m.Histogram != nil:
+					return float64(m.GetHistogram().GetSampleCount())
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func matches(m *dto.Metric, labels map[string]string) bool {
+	found := 0
+	for _, pair := range m.GetLabel() {
+		want, ok := labels[pair.GetName()]
+		if !ok {
+			continue
+		}
+		if pair.GetValue() != want {
+			return false
+		}
+		found++
+	}
+	return found == len(labels)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	tenantID := uuid.New()
+
+	app := fiber.New()
+	app.Use(middleware.Metrics())
+	app.Get("/metrics-test/items/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/metrics-test/tenants/items/:id", func(c *fiber.Ctx) error {
+		c.Locals("tenant_id", tenantID)
+		return fiber.ErrConflict
+	})
+
+	t.Run("labels requests with the route template", func(t *testing.T) {
+		for _, id := range []string{"1", "2"} {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/metrics-test/items/"+id, nil))
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		assert.Equal(t, 2.0, sample(t, "erp_http_requests_total", map[string]string{
+			"method": "GET", "route": "/metrics-test/items/:id", "status": "200", "tenant": "",
+		}))
+		assert.Equal(t, 2.0, sample(t, "erp_http_request_duration_seconds", map[string]string{
+			"method": "GET", "route": "/metrics-test/items/:id", "status": "200",
+		}))
+	})
+
+	t.Run("records the status of returned errors and the verified tenant", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/metrics-test/tenants/items/1", nil)
+		req.Header.Set("X-Tenant-ID", uuid.NewString())
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		assert.Equal(t, 1.0, sample(t, "erp_http_requests_total", map[string]string{
+			"route": "/metrics-test/tenants/items/:id", "status": "409", "tenant": tenantID.String(),
+		}))
+	})
+}
+
+type fakeInspector struct {
+	pending, processing, deadLetter int64
+	err                             error
+}
+
+func (f fakeInspector) QueueLen(context.Context, string) (int64, error) {
+	return f.pending, nil
+}
+
+func (f fakeInspector) ProcessingLen(context.Context, string) (int64, error) {
+	return f.processing, nil
+}
+
+func (f fakeInspector) DeadLetterLen(context.Context, string) (int64, error) {
+	return f.deadLetter, f.err
+}
+
+func TestWatchQueues(t *testing.T) {
+	t.Run("exports each state of each queue", func(t *testing.T) {
+		metrics.WatchQueues(fakeInspector{pending: 3, processing: 1, deadLetter: 2}, []string{"metrics-test-ok"})
+
+		for state, want := range map[string]float64{"pending": 3, "processing": 1, "dead_letter": 2} {
+			assert.Equal(t, want, sample(t, "erp_queue_depth", map[string]string{"queue": "metrics-test-ok", "state": state}), state)
+		}
+		assert.Equal(t, 0.0, sample(t, "erp_queue_depth_scrape_error", map[string]string{"queue": "metrics-test-ok"}))
+	})
+
+	t.Run("flags a queue whose depth could not be read", func(t *testing.T) {
+		metrics.WatchQueues(fakeInspector{pending: 5, err: errors.New("redis down")}, []string{"metrics-test-failing"})
+
+		assert.Equal(t, 5.0, sample(t, "erp_queue_depth", map[string]string{"queue": "metrics-test-failing", "state": "pending"}))
+		assert.Equal(t, 1.0, sample(t, "erp_queue_depth_scrape_error", map[string]string{"queue": "metrics-test-failing"}))
+	})
+}
+
+func TestObserveCleanupBatch(t *testing.T) {
+	deleted := map[string]string{"kind": "file", "result": "deleted"}
+	reaped := map[string]string{"kind": "upload_slot", "result": "reaped"}
+	errored := map[string]string{"outcome": "error"}
+	beforeDeleted := sample(t, "erp_file_cleanup_items_total", deleted)
+	beforeReaped := sample(t, "erp_file_cleanup_items_total", reaped)
+	beforeErrored := sample(t, "erp_file_cleanup_batches_total", errored)
+
+	metrics.ObserveCleanupBatch(4, 1, 2, 0, nil)
+	metrics.ObserveCleanupBatch(0, 0, 0, 0, errors.New("claim failed"))
+
+	assert.Equal(t, beforeDeleted+4, sample(t, "erp_file_cleanup_items_total", deleted))
+	assert.Equal(t, beforeReaped+2, sample(t, "erp_file_cleanup_items_total", reaped))
+	assert.Equal(t, beforeErrored+1, sample(t, "erp_file_cleanup_batches_total", errored))
+}
+
+func TestHandler(t *testing.T) {
+	metrics.ObserveEmail(metrics.EmailTemplateLoginOTP, metrics.EmailShed)
+	metrics.OTPFailed(metrics.OTPFlowLogin, metrics.OTPFailExpired)
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, string(body), `erp_email_sends_total{outcome="shed",template="login_otp"}`)
+	assert.Contains(t, string(body), `erp_otp_failed_total{flow="login",reason="expired"}`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
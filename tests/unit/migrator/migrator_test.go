@@ -0,0 +1,197 @@
+package migrator_test
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"erp-service/pkg/migrator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o644))
+	}
+	return dir
+}
+
+func testMigrations(t *testing.T) []migrator.Migration {
+	t.Helper()
+	dir := writeFiles(t,
+		"000001_create_a.up.sql", "000001_create_a.down.sql",
+		"000002_create_b.up.sql", "000002_create_b.down.sql",
+		"000003_seed_b.up.sql", "000003_seed_b.down.sql",
+	)
+	migrations, err := migrator.LoadMigrations(dir)
+	require.NoError(t, err)
+	return migrations
+}
+
+func files(steps []migrator.Step) []string {
+	out := make([]string, 0, len(steps))
+	for _, step := range steps {
+		out = append(out, step.File)
+	}
+	return out
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    migrator.Command
+		wantErr bool
+	}{
+		{args: []string{"up"}, want: migrator.Command{Name: "up"}},
+		{args: []string{"up", "2"}, want: migrator.Command{Name: "up", Arg: 2, HasArg: true}},
+		{args: []string{"up", "0"}, wantErr: true},
+		{args: []string{"down"}, wantErr: true},
+		{args: []string{"down", "1"}, want: migrator.Command{Name: "down", Arg: 1, HasArg: true}},
+		{args: []string{"goto", "40"}, want: migrator.Command{Name: "goto", Arg: 40, HasArg: true}},
+		{args: []string{"goto"}, wantErr: true},
+		{args: []string{"force", "-1"}, want: migrator.Command{Name: "force", Arg: -1, HasArg: true}},
+		{args: []string{"status", "1"}, wantErr: true},
+		{args: []string{"seed"}, want: migrator.Command{Name: "seed"}},
+		{args: []string{"up", "x"}, wantErr: true},
+		{args: []string{"drop"}, wantErr: true},
+		{args: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := migrator.ParseCommand(tt.args)
+		if tt.wantErr {
+			assert.Error(t, err, "%v", tt.args)
+			continue
+		}
+		require.NoError(t, err, "%v", tt.args)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestCommand_Destructive(t *testing.T) {
+	assert.True(t, migrator.Command{Name: "down", Arg: 1, HasArg: true}.Destructive(5, true))
+	assert.True(t, migrator.Command{Name: "goto", Arg: 3, HasArg: true}.Destructive(5, true))
+	assert.False(t, migrator.Command{Name: "goto", Arg: 7, HasArg: true}.Destructive(5, true))
+	assert.False(t, migrator.Command{Name: "goto", Arg: 3, HasArg: true}.Destructive(0, false))
+	assert.False(t, migrator.Command{Name: "up"}.Destructive(5, true))
+	assert.False(t, migrator.Command{Name: "force", Arg: 3, HasArg: true}.Destructive(5, true))
+}
+
+func TestLoadMigrations_PairsFilesAndSkipsOthers(t *testing.T) {
+	dir := writeFiles(t,
+		"000002_b.up.sql", "000002_b.down.sql",
+		"000001_a.up.sql",
+		"README.md",
+	)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "seed"), 0o755))
+
+	migrations, err := migrator.LoadMigrations(dir)
+
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, migrator.Migration{Version: 1, Name: "a", UpFile: "000001_a.up.sql"}, migrations[0])
+	assert.Equal(t, migrator.Migration{Version: 2, Name: "b", UpFile: "000002_b.up.sql", DownFile: "000002_b.down.sql"}, migrations[1])
+}
+
+func TestLoadMigrations_ConflictingNames(t *testing.T) {
+	dir := writeFiles(t, "000001_a.up.sql", "000001_other.down.sql")
+
+	_, err := migrator.LoadMigrations(dir)
+
+	assert.ErrorContains(t, err, "two names")
+}
+
+func TestPlan_UpAll(t *testing.T) {
+	steps, err := migrator.Plan(testMigrations(t), 1, true, migrator.Command{Name: "up"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"000002_create_b.up.sql", "000003_seed_b.up.sql"}, files(steps))
+}
+
+func TestPlan_UpFromEmptyDatabase(t *testing.T) {
+	steps, err := migrator.Plan(testMigrations(t), 0, false, migrator.Command{Name: "up", Arg: 1, HasArg: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"000001_create_a.up.sql"}, files(steps))
+}
+
+func TestPlan_Down(t *testing.T) {
+	steps, err := migrator.Plan(testMigrations(t), 3, true, migrator.Command{Name: "down", Arg: 2, HasArg: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"000003_seed_b.down.sql", "000002_create_b.down.sql"}, files(steps))
+}
+
+func TestPlan_DownMoreThanApplied(t *testing.T) {
+	_, err := migrator.Plan(testMigrations(t), 1, true, migrator.Command{Name: "down", Arg: 2, HasArg: true})
+
+	assert.ErrorContains(t, err, "only 1 applied")
+}
+
+func TestPlan_Goto(t *testing.T) {
+	migrations := testMigrations(t)
+
+	up, err := migrator.Plan(migrations, 1, true, migrator.Command{Name: "goto", Arg: 3, HasArg: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"000002_create_b.up.sql", "000003_seed_b.up.sql"}, files(up))
+
+	down, err := migrator.Plan(migrations, 3, true, migrator.Command{Name: "goto", Arg: 1, HasArg: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"000003_seed_b.down.sql", "000002_create_b.down.sql"}, files(down))
+
+	_, err = migrator.Plan(migrations, 3, true, migrator.Command{Name: "goto", Arg: 9, HasArg: true})
+	assert.ErrorContains(t, err, "no such migration")
+}
+
+func TestPlan_MissingDownFile(t *testing.T) {
+	migrations, err := migrator.LoadMigrations(writeFiles(t, "000001_a.up.sql"))
+	require.NoError(t, err)
+
+	_, err = migrator.Plan(migrations, 1, true, migrator.Command{Name: "down", Arg: 1, HasArg: true})
+
+	assert.ErrorContains(t, err, "no down file")
+}
+
+func TestLoadSeeds_SortedSQLOnly(t *testing.T) {
+	dir := writeFiles(t, "0002_b.sql", "0001_a.sql", "notes.txt")
+
+	seeds, err := migrator.LoadSeeds(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0001_a.sql", "0002_b.sql"}, seeds)
+}
+
+func TestRepositoryMigrations_AreReversible(t *testing.T) {
+	migrations, err := migrator.LoadMigrations("../../../migration")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for _, m := range migrations {
+		assert.NotEmpty(t, m.UpFile, "migration %d has no up file", m.Version)
+		assert.NotEmpty(t, m.DownFile, "migration %d has no down file", m.Version)
+	}
+}
+
+func TestWithRole(t *testing.T) {
+	dsn, err := migrator.WithRole("postgres://v-token-erp-1a2b:secret@db:5432/erp?sslmode=disable", "erp_owner")
+	require.NoError(t, err)
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	assert.Equal(t, "v-token-erp-1a2b", u.User.Username())
+	assert.Equal(t, "disable", u.Query().Get("sslmode"))
+	assert.Equal(t, "-c role=erp_owner", u.Query().Get("options"))
+}
+
+func TestWithRole_KeepsExistingOptions(t *testing.T) {
+	dsn, err := migrator.WithRole("postgres://u:p@db/erp?options=-c%20statement_timeout%3D0", "erp owner")
+	require.NoError(t, err)
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	assert.Equal(t, `-c statement_timeout=0 -c role=erp\ owner`, u.Query().Get("options"))
+}
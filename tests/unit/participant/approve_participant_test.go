@@ -135,6 +135,56 @@ func TestUsecase_ApproveParticipant(t *testing.T) {
 			wantErr: true,
 			errKind: errors.KindBadRequest,
 		},
+		{
+			name: "error - unverified primary bank account blocks approval",
+			req: &participant.ApproveParticipantRequest{
+				ParticipantID: uuid.New(),
+				TenantID:      tenantID,
+				ProductID:     productID,
+				UserID:        approverID,
+			},
+			setup: func(txMgr *MockTransactionManager, partRepo *MockParticipantRepository, histRepo *MockParticipantStatusHistoryRepository, identRepo *MockParticipantIdentityRepository, addrRepo *MockParticipantAddressRepository, bankRepo *MockParticipantBankAccountRepository, famRepo *MockParticipantFamilyMemberRepository, empRepo *MockParticipantEmploymentRepository, penRepo *MockParticipantPensionRepository, benRepo *MockParticipantBeneficiaryRepository) {
+				txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				p := createMockParticipant(entity.ParticipantStatusPendingApproval, tenantID, productID, userID)
+				partRepo.On("GetByID", mock.Anything, mock.Anything).Return(p, nil)
+				bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{
+					{ID: uuid.New(), IsPrimary: true, VerificationStatus: entity.BankAccountVerificationMismatch},
+				}, nil)
+			},
+			wantErr: true,
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name: "success - override reason approves with unverified primary bank account",
+			req: &participant.ApproveParticipantRequest{
+				ParticipantID:                  uuid.New(),
+				TenantID:                       tenantID,
+				ProductID:                      productID,
+				UserID:                         approverID,
+				BankVerificationOverrideReason: strPtr("bank inquiry down, passbook checked in person"),
+			},
+			setup: func(txMgr *MockTransactionManager, partRepo *MockParticipantRepository, histRepo *MockParticipantStatusHistoryRepository, identRepo *MockParticipantIdentityRepository, addrRepo *MockParticipantAddressRepository, bankRepo *MockParticipantBankAccountRepository, famRepo *MockParticipantFamilyMemberRepository, empRepo *MockParticipantEmploymentRepository, penRepo *MockParticipantPensionRepository, benRepo *MockParticipantBeneficiaryRepository) {
+				txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				p := createMockParticipant(entity.ParticipantStatusPendingApproval, tenantID, productID, userID)
+				partRepo.On("GetByID", mock.Anything, mock.Anything).Return(p, nil)
+				partRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				histRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *entity.ParticipantStatusHistory) bool {
+					return h.Reason != nil && *h.Reason == "bank inquiry down, passbook checked in person" &&
+						h.Details["bank_verification_override"] == "true" &&
+						h.Details["bank_verification_status"] == string(entity.BankAccountVerificationProviderError)
+				})).Return(nil)
+				identRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantIdentity{}, nil)
+				addrRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantAddress{}, nil)
+				bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{
+					{ID: uuid.New(), IsPrimary: true, VerificationStatus: entity.BankAccountVerificationProviderError},
+				}, nil)
+				famRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantFamilyMember{}, nil)
+				empRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+				penRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+				benRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBeneficiary{}, nil)
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
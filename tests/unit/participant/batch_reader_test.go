@@ -0,0 +1,151 @@
+package participant_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type batchReaderMocks struct {
+	participantRepo   *MockParticipantRepository
+	identityRepo      *MockParticipantIdentityRepository
+	addressRepo       *MockParticipantAddressRepository
+	bankAccountRepo   *MockParticipantBankAccountRepository
+	familyMemberRepo  *MockParticipantFamilyMemberRepository
+	employmentRepo    *MockParticipantEmploymentRepository
+	pensionRepo       *MockParticipantPensionRepository
+	beneficiaryRepo   *MockParticipantBeneficiaryRepository
+	statusHistoryRepo *MockParticipantStatusHistoryRepository
+}
+
+func newBatchReader() (participant.BatchReader, *batchReaderMocks) {
+	m := &batchReaderMocks{
+		participantRepo:   new(MockParticipantRepository),
+		identityRepo:      new(MockParticipantIdentityRepository),
+		addressRepo:       new(MockParticipantAddressRepository),
+		bankAccountRepo:   new(MockParticipantBankAccountRepository),
+		familyMemberRepo:  new(MockParticipantFamilyMemberRepository),
+		employmentRepo:    new(MockParticipantEmploymentRepository),
+		pensionRepo:       new(MockParticipantPensionRepository),
+		beneficiaryRepo:   new(MockParticipantBeneficiaryRepository),
+		statusHistoryRepo: new(MockParticipantStatusHistoryRepository),
+	}
+	reader := participant.NewBatchReader(
+		m.participantRepo,
+		m.identityRepo,
+		m.addressRepo,
+		m.bankAccountRepo,
+		m.familyMemberRepo,
+		m.employmentRepo,
+		m.pensionRepo,
+		m.beneficiaryRepo,
+		m.statusHistoryRepo,
+	)
+	return reader, m
+}
+
+func TestBatchReader_GetParticipantsByIDs(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("leaves out participants of another tenant or product", func(t *testing.T) {
+		reader, m := newBatchReader()
+		own := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		otherTenant := createMockParticipant(entity.ParticipantStatusDraft, uuid.New(), productID, userID)
+		otherProduct := createMockParticipant(entity.ParticipantStatusDraft, tenantID, uuid.New(), userID)
+		ids := []uuid.UUID{own.ID, otherTenant.ID, otherProduct.ID}
+
+		m.participantRepo.On("GetByIDs", mock.Anything, ids).
+			Return([]*entity.Participant{own, otherTenant, otherProduct}, nil)
+
+		result, err := reader.GetParticipantsByIDs(context.Background(), &participant.BatchParticipantsRequest{
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantIDs: ids,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, own.FullName, result[own.ID].FullName)
+	})
+
+	t.Run("rejects more IDs than a batch allows", func(t *testing.T) {
+		reader, m := newBatchReader()
+		ids := make([]uuid.UUID, participant.MaxBatchParticipants+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+
+		_, err := reader.GetParticipantsByIDs(context.Background(), &participant.BatchParticipantsRequest{
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantIDs: ids,
+		})
+
+		var appErr *errors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errors.KindBadRequest, appErr.Kind)
+		m.participantRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBatchReader_ListIdentitiesByParticipantIDs(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("loads children of owned participants only, grouped by participant", func(t *testing.T) {
+		reader, m := newBatchReader()
+		first := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		second := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		foreign := createMockParticipant(entity.ParticipantStatusDraft, uuid.New(), productID, userID)
+
+		m.participantRepo.On("GetByIDs", mock.Anything, []uuid.UUID{first.ID, second.ID, foreign.ID}).
+			Return([]*entity.Participant{first, second, foreign}, nil)
+		m.identityRepo.On("ListByParticipantIDs", mock.Anything, []uuid.UUID{first.ID, second.ID}).
+			Return([]*entity.ParticipantIdentity{
+				createMockIdentity(first.ID),
+				createMockIdentity(first.ID),
+				createMockIdentity(second.ID),
+			}, nil)
+
+		result, err := reader.ListIdentitiesByParticipantIDs(context.Background(), &participant.BatchParticipantsRequest{
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantIDs: []uuid.UUID{first.ID, second.ID, foreign.ID},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result[first.ID], 2)
+		assert.Len(t, result[second.ID], 1)
+		assert.NotContains(t, result, foreign.ID)
+		m.identityRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips the child query when nothing is owned", func(t *testing.T) {
+		reader, m := newBatchReader()
+		foreign := createMockParticipant(entity.ParticipantStatusDraft, uuid.New(), productID, userID)
+
+		m.participantRepo.On("GetByIDs", mock.Anything, []uuid.UUID{foreign.ID}).
+			Return([]*entity.Participant{foreign}, nil)
+
+		result, err := reader.ListIdentitiesByParticipantIDs(context.Background(), &participant.BatchParticipantsRequest{
+			TenantID:       tenantID,
+			ProductID:      productID,
+			ParticipantIDs: []uuid.UUID{foreign.ID},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+		m.identityRepo.AssertNotCalled(t, "ListByParticipantIDs", mock.Anything, mock.Anything)
+	})
+}
@@ -42,8 +42,11 @@ func makeCreateUsecase() (participant.Usecase, *MockTransactionManager, *MockPar
 		pensionRepo,
 		beneficiaryRepo,
 		statusHistoryRepo,
+		newNoDuplicatesRepo(),
 		new(MockFileStorageAdapter),
 		new(MockFileRepository),
+		new(MockUploadSlotRepository),
+		nil, nil,
 		nil,
 		nil,
 		nil,
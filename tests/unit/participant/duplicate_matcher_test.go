@@ -0,0 +1,154 @@
+package participant_test
+
+import (
+	"testing"
+	"time"
+
+	"erp-service/saving/participant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "lowercases and collapses spaces", input: "  Budi   SANTOSO ", want: "budi santoso"},
+		{name: "strips honorifics and degrees", input: "Dr. Ir. Budi Santoso, S.T., M.M.", want: "budi santoso"},
+		{name: "strips punctuation", input: "Siti-Nur'aini", want: "siti nuraini"},
+		{name: "empty", input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, participant.NormalizeName(tt.input))
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "local format", input: "0812-3456-7890", want: "081234567890"},
+		{name: "international with plus", input: "+62 812 3456 7890", want: "081234567890"},
+		{name: "international without plus", input: "6281234567890", want: "081234567890"},
+		{name: "missing leading zero", input: "81234567890", want: "081234567890"},
+		{name: "empty", input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, participant.NormalizePhone(tt.input))
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{name: "identical", a: "budi santoso", b: "budi santoso", atLeast: 1},
+		{name: "single typo", a: "budi santoso", b: "budi santosa", atLeast: 0.9},
+		{name: "transposed letters", a: "muhammad rizki", b: "muhammad rizik", atLeast: 0.9},
+		{name: "reordered tokens", a: "santoso budi", b: "budi santoso", atLeast: 1},
+		{name: "different people", a: "budi santoso", b: "agus wijaya", below: 0.7},
+		{name: "empty", a: "", b: "budi", below: 0.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := participant.NameSimilarity(tt.a, tt.b)
+			if tt.atLeast > 0 {
+				assert.GreaterOrEqual(t, got, tt.atLeast)
+			}
+			if tt.below > 0 {
+				assert.Less(t, got, tt.below)
+			}
+		})
+	}
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	dob := time.Date(1985, 3, 14, 0, 0, 0, 0, time.UTC)
+	otherDOB := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	subject := participant.DuplicateProfile{
+		FullName:        "Budi Santoso",
+		DateOfBirth:     &dob,
+		PhoneNumber:     "+6281234567890",
+		IdentityNumbers: []string{"3174011403850001"},
+		BankAccounts:    []string{participant.BankAccountKey("014", "123-456-789")},
+	}
+
+	tests := []struct {
+		name       string
+		candidate  participant.DuplicateProfile
+		wantLikely bool
+		wantFields []string
+	}{
+		{
+			name: "typo in name with same dob and phone",
+			candidate: participant.DuplicateProfile{
+				FullName:    "Budi Santosa",
+				DateOfBirth: &dob,
+				PhoneNumber: "081234567890",
+			},
+			wantLikely: true,
+			wantFields: []string{participant.DuplicateFieldFullName, participant.DuplicateFieldDateOfBirth, participant.DuplicateFieldPhoneNumber},
+		},
+		{
+			name: "shared identifiers alone stay below threshold",
+			candidate: participant.DuplicateProfile{
+				FullName:        "Agus Wijaya",
+				IdentityNumbers: []string{"3174011403850001"},
+				BankAccounts:    []string{participant.BankAccountKey("014", "123456789")},
+			},
+			wantLikely: false,
+			wantFields: []string{participant.DuplicateFieldIdentityNumber, participant.DuplicateFieldBankAccount},
+		},
+		{
+			name: "same name and identity number",
+			candidate: participant.DuplicateProfile{
+				FullName:        "Dr. Budi Santoso",
+				IdentityNumbers: []string{"3174011403850001"},
+			},
+			wantLikely: true,
+			wantFields: []string{participant.DuplicateFieldFullName, participant.DuplicateFieldIdentityNumber},
+		},
+		{
+			name: "same name only is not enough",
+			candidate: participant.DuplicateProfile{
+				FullName:    "Budi Santoso",
+				DateOfBirth: &otherDOB,
+			},
+			wantLikely: false,
+			wantFields: []string{participant.DuplicateFieldFullName},
+		},
+		{
+			name: "unrelated participant",
+			candidate: participant.DuplicateProfile{
+				FullName:    "Agus Wijaya",
+				DateOfBirth: &otherDOB,
+				PhoneNumber: "081299998888",
+			},
+			wantLikely: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := participant.ScoreDuplicate(subject, tt.candidate)
+			assert.Equal(t, tt.wantLikely, got.IsLikelyDuplicate(), "score %.3f", got.Score)
+			assert.ElementsMatch(t, tt.wantFields, got.MatchedFields)
+			assert.LessOrEqual(t, got.Score, 1.0)
+		})
+	}
+}
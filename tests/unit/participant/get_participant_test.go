@@ -161,3 +161,63 @@ func TestUsecase_GetParticipant(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_GetParticipant_FilePreviewURLs(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	participantID := uuid.New()
+	photoFileID := uuid.New()
+	bankBookFileID := uuid.New()
+
+	txMgr := new(MockTransactionManager)
+	partRepo := new(MockParticipantRepository)
+	identRepo := new(MockParticipantIdentityRepository)
+	addrRepo := new(MockParticipantAddressRepository)
+	bankRepo := new(MockParticipantBankAccountRepository)
+	famRepo := new(MockParticipantFamilyMemberRepository)
+	empRepo := new(MockParticipantEmploymentRepository)
+	penRepo := new(MockParticipantPensionRepository)
+	benRepo := new(MockParticipantBeneficiaryRepository)
+	histRepo := new(MockParticipantStatusHistoryRepository)
+	fileStorage := new(MockFileStorageAdapter)
+	fileRepo := new(MockFileRepository)
+
+	p := createMockParticipant(entity.ParticipantStatusPendingApproval, tenantID, productID, uuid.New())
+	p.ID = participantID
+	partRepo.On("GetByID", mock.Anything, participantID).Return(p, nil)
+
+	thumbnailID := uuid.New()
+	fileRepo.On("GetByID", mock.Anything, photoFileID).
+		Return(&entity.File{ID: photoFileID, ThumbnailFileID: &thumbnailID}, nil)
+	fileRepo.On("GetByID", mock.Anything, bankBookFileID).
+		Return(&entity.File{ID: bankBookFileID}, nil)
+
+	identity := createMockIdentity(participantID)
+	identity.PhotoFileID = &photoFileID
+	identRepo.On("ListByParticipantID", mock.Anything, participantID).Return([]*entity.ParticipantIdentity{identity}, nil)
+
+	beneficiary := createMockBeneficiary(participantID, uuid.New())
+	beneficiary.BankBookPhotoFileID = &bankBookFileID
+	benRepo.On("ListByParticipantID", mock.Anything, participantID).Return([]*entity.ParticipantBeneficiary{beneficiary}, nil)
+
+	addrRepo.On("ListByParticipantID", mock.Anything, participantID).Return([]*entity.ParticipantAddress{}, nil)
+	bankRepo.On("ListByParticipantID", mock.Anything, participantID).Return([]*entity.ParticipantBankAccount{}, nil)
+	famRepo.On("ListByParticipantID", mock.Anything, participantID).Return([]*entity.ParticipantFamilyMember{}, nil)
+	empRepo.On("GetByParticipantID", mock.Anything, participantID).Return(nil, errors.ErrNotFound("not found"))
+	penRepo.On("GetByParticipantID", mock.Anything, participantID).Return(nil, errors.ErrNotFound("not found"))
+
+	uc := newTestUsecaseWithFileRepo(txMgr, partRepo, identRepo, addrRepo, bankRepo, famRepo, empRepo, penRepo, benRepo, histRepo, fileStorage, fileRepo)
+
+	resp, err := uc.GetParticipant(context.Background(), &participant.GetParticipantRequest{
+		ParticipantID: participantID,
+		TenantID:      tenantID,
+		ProductID:     productID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/saving/files/"+photoFileID.String()+"/content", resp.FilePreviewURLs[photoFileID.String()])
+	assert.Equal(t, "/api/v1/saving/files/"+bankBookFileID.String()+"/content", resp.FilePreviewURLs[bankBookFileID.String()])
+	assert.Equal(t, map[string]string{
+		photoFileID.String(): "/api/v1/saving/files/" + photoFileID.String() + "/thumbnail",
+	}, resp.FileThumbnailURLs)
+}
@@ -23,6 +23,43 @@ func newTestUsecase(
 	beneficiaryRepo *MockParticipantBeneficiaryRepository,
 	statusHistoryRepo *MockParticipantStatusHistoryRepository,
 	fileStorage *MockFileStorageAdapter,
+) participant.Usecase {
+	return newTestUsecaseWithFileRepo(txManager, participantRepo, identityRepo, addressRepo, bankAccountRepo,
+		familyMemberRepo, employmentRepo, pensionRepo, beneficiaryRepo, statusHistoryRepo, fileStorage, new(MockFileRepository))
+}
+
+func newTestUsecaseWithFileRepo(
+	txManager *MockTransactionManager,
+	participantRepo *MockParticipantRepository,
+	identityRepo *MockParticipantIdentityRepository,
+	addressRepo *MockParticipantAddressRepository,
+	bankAccountRepo *MockParticipantBankAccountRepository,
+	familyMemberRepo *MockParticipantFamilyMemberRepository,
+	employmentRepo *MockParticipantEmploymentRepository,
+	pensionRepo *MockParticipantPensionRepository,
+	beneficiaryRepo *MockParticipantBeneficiaryRepository,
+	statusHistoryRepo *MockParticipantStatusHistoryRepository,
+	fileStorage *MockFileStorageAdapter,
+	fileRepo *MockFileRepository,
+) participant.Usecase {
+	return newTestUsecaseWithMasterdata(txManager, participantRepo, identityRepo, addressRepo, bankAccountRepo,
+		familyMemberRepo, employmentRepo, pensionRepo, beneficiaryRepo, statusHistoryRepo, fileStorage, fileRepo, nil)
+}
+
+func newTestUsecaseWithMasterdata(
+	txManager *MockTransactionManager,
+	participantRepo *MockParticipantRepository,
+	identityRepo *MockParticipantIdentityRepository,
+	addressRepo *MockParticipantAddressRepository,
+	bankAccountRepo *MockParticipantBankAccountRepository,
+	familyMemberRepo *MockParticipantFamilyMemberRepository,
+	employmentRepo *MockParticipantEmploymentRepository,
+	pensionRepo *MockParticipantPensionRepository,
+	beneficiaryRepo *MockParticipantBeneficiaryRepository,
+	statusHistoryRepo *MockParticipantStatusHistoryRepository,
+	fileStorage *MockFileStorageAdapter,
+	fileRepo *MockFileRepository,
+	masterdataUsecase participant.MasterdataUsecase,
 ) participant.Usecase {
 	return participant.NewUsecase(
 		&config.Config{},
@@ -37,14 +74,18 @@ func newTestUsecase(
 		pensionRepo,
 		beneficiaryRepo,
 		statusHistoryRepo,
+		newNoDuplicatesRepo(),
 		fileStorage,
-		new(MockFileRepository),
+		fileRepo,
+		new(MockUploadSlotRepository),
+		new(MockBankAccountVerifier),
 		nil,
 		nil,
 		nil,
 		nil,
 		nil,
 		nil,
+		masterdataUsecase,
 	)
 }
 
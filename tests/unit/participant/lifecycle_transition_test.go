@@ -0,0 +1,305 @@
+package participant_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/masterdata"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_LifecycleTransitions(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	approverID := uuid.New()
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name        string
+		status      entity.ParticipantStatus
+		call        func(participant.Usecase, uuid.UUID) (*participant.ParticipantResponse, error)
+		wantStatus  entity.ParticipantStatus
+		wantDetails map[string]string
+		errKind     errors.Kind
+	}{
+		{
+			name:   "activate APPROVED participant",
+			status: entity.ParticipantStatusApproved,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.ActivateParticipant(context.Background(), &participant.ActivateParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					EffectiveDate: yesterday,
+				})
+			},
+			wantStatus: entity.ParticipantStatusActive,
+		},
+		{
+			name:   "reinstate SUSPENDED participant",
+			status: entity.ParticipantStatusSuspended,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.ActivateParticipant(context.Background(), &participant.ActivateParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					EffectiveDate: today,
+				})
+			},
+			wantStatus: entity.ParticipantStatusActive,
+		},
+		{
+			name:   "suspend ACTIVE participant records expected end date",
+			status: entity.ParticipantStatusActive,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				end := time.Date(2099, 1, 31, 0, 0, 0, 0, time.UTC)
+				return uc.SuspendParticipant(context.Background(), &participant.SuspendParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					EffectiveDate: today, ExpectedEndDate: &end, Reason: "unpaid leave for six months",
+				})
+			},
+			wantStatus:  entity.ParticipantStatusSuspended,
+			wantDetails: map[string]string{"expected_end_date": "2099-01-31"},
+		},
+		{
+			name:   "terminate ACTIVE participant",
+			status: entity.ParticipantStatusActive,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.TerminateParticipant(context.Background(), &participant.TerminateParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					TerminationDate: yesterday, Reason: "resigned from employer",
+				})
+			},
+			wantStatus: entity.ParticipantStatusTerminated,
+		},
+		{
+			name:   "retire ACTIVE participant records retirement type",
+			status: entity.ParticipantStatusActive,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.RetireParticipant(context.Background(), &participant.RetireParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					RetirementDate: today, RetirementTypeCode: "RETIREMENT_TYPE_001",
+				})
+			},
+			wantStatus:  entity.ParticipantStatusRetired,
+			wantDetails: map[string]string{"retirement_type_code": "RETIREMENT_TYPE_001"},
+		},
+		{
+			name:   "transfer out SUSPENDED participant records destination fund",
+			status: entity.ParticipantStatusSuspended,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.TransferOutParticipant(context.Background(), &participant.TransferOutParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					TransferDate: today, DestinationFund: "DPLK Example", Reason: "moved to new employer fund",
+				})
+			},
+			wantStatus:  entity.ParticipantStatusTransferredOut,
+			wantDetails: map[string]string{"destination_fund": "DPLK Example"},
+		},
+		{
+			name:   "error - cannot activate PENDING_APPROVAL participant",
+			status: entity.ParticipantStatusPendingApproval,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.ActivateParticipant(context.Background(), &participant.ActivateParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					EffectiveDate: today,
+				})
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:   "error - cannot suspend TERMINATED participant",
+			status: entity.ParticipantStatusTerminated,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.SuspendParticipant(context.Background(), &participant.SuspendParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					EffectiveDate: today, Reason: "should not be allowed",
+				})
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:   "error - cannot retire DECEASED participant",
+			status: entity.ParticipantStatusDeceased,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.RetireParticipant(context.Background(), &participant.RetireParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					RetirementDate: today, RetirementTypeCode: "RETIREMENT_TYPE_001",
+				})
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:   "error - retirement type not in masterdata",
+			status: entity.ParticipantStatusActive,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.RetireParticipant(context.Background(), &participant.RetireParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					RetirementDate: today, RetirementTypeCode: "NORMAL",
+				})
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:   "error - effective date in the future",
+			status: entity.ParticipantStatusActive,
+			call: func(uc participant.Usecase, id uuid.UUID) (*participant.ParticipantResponse, error) {
+				return uc.TerminateParticipant(context.Background(), &participant.TerminateParticipantRequest{
+					TenantID: tenantID, ProductID: productID, ParticipantID: id, UserID: approverID,
+					TerminationDate: tomorrow, Reason: "resigned from employer",
+				})
+			},
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txMgr := new(MockTransactionManager)
+			partRepo := new(MockParticipantRepository)
+			identRepo := new(MockParticipantIdentityRepository)
+			addrRepo := new(MockParticipantAddressRepository)
+			bankRepo := new(MockParticipantBankAccountRepository)
+			famRepo := new(MockParticipantFamilyMemberRepository)
+			empRepo := new(MockParticipantEmploymentRepository)
+			penRepo := new(MockParticipantPensionRepository)
+			benRepo := new(MockParticipantBeneficiaryRepository)
+			histRepo := new(MockParticipantStatusHistoryRepository)
+			fileStorage := new(MockFileStorageAdapter)
+			mdValidator := newRetirementTypeValidator()
+
+			p := createMockParticipant(tt.status, tenantID, productID, userID)
+			txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+			partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil).Maybe()
+
+			if tt.errKind == 0 {
+				partRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.Participant) bool {
+					return u.Status == tt.wantStatus && u.StatusEffectiveDate != nil
+				})).Return(nil)
+				histRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *entity.ParticipantStatusHistory) bool {
+					return h.ToStatus == string(tt.wantStatus) &&
+						*h.FromStatus == string(tt.status) &&
+						h.ChangedBy == approverID &&
+						h.EffectiveDate != nil &&
+						assert.ObjectsAreEqual(tt.wantDetails, h.Details)
+				})).Return(nil)
+				identRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantIdentity{}, nil)
+				addrRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantAddress{}, nil)
+				bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{}, nil)
+				famRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantFamilyMember{}, nil)
+				empRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+				penRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+				benRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBeneficiary{}, nil)
+			}
+
+			uc := newTestUsecaseWithMasterdata(txMgr, partRepo, identRepo, addrRepo, bankRepo, famRepo, empRepo, penRepo, benRepo, histRepo, fileStorage, new(MockFileRepository), mdValidator)
+
+			resp, err := tt.call(uc, p.ID)
+
+			if tt.errKind != 0 {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				partRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, string(tt.wantStatus), resp.Status)
+			assert.NotNil(t, resp.StatusEffectiveDate)
+
+			partRepo.AssertExpectations(t)
+			histRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func newRetirementTypeValidator() *mockMasterdataValidator {
+	md := new(mockMasterdataValidator)
+	md.On("ValidateItemCode", mock.Anything, mock.MatchedBy(func(r *masterdata.ValidateCodeRequest) bool {
+		return r.CategoryCode == "RETIREMENT_TYPE" && r.ItemCode == "RETIREMENT_TYPE_001"
+	})).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil).Maybe()
+	md.On("ValidateItemCode", mock.Anything, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: false}, nil).Maybe()
+	return md
+}
+
+func TestUsecase_LifecycleTransitions_SupportingFile(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	retirementLetter := "retirement_letter"
+	deathCertificate := "death_certificate"
+
+	tests := []struct {
+		name    string
+		file    func(participantID uuid.UUID) *entity.File
+		errKind errors.Kind
+	}{
+		{
+			name: "error - file belongs to another participant",
+			file: func(uuid.UUID) *entity.File {
+				other := uuid.New()
+				return &entity.File{TenantID: tenantID, ProductID: productID, ParticipantID: &other, DocumentType: &retirementLetter, ScanStatus: entity.FileScanStatusClean}
+			},
+			errKind: errors.KindForbidden,
+		},
+		{
+			name: "error - file not attached to a participant",
+			file: func(uuid.UUID) *entity.File {
+				return &entity.File{TenantID: tenantID, ProductID: productID, DocumentType: &retirementLetter, ScanStatus: entity.FileScanStatusClean}
+			},
+			errKind: errors.KindForbidden,
+		},
+		{
+			name: "error - file is a different document type",
+			file: func(id uuid.UUID) *entity.File {
+				return &entity.File{TenantID: tenantID, ProductID: productID, ParticipantID: &id, DocumentType: &deathCertificate, ScanStatus: entity.FileScanStatusClean}
+			},
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txMgr := new(MockTransactionManager)
+			partRepo := new(MockParticipantRepository)
+			fileRepo := new(MockFileRepository)
+
+			p := createMockParticipant(entity.ParticipantStatusActive, tenantID, productID, uuid.New())
+			fileID := uuid.New()
+			file := tt.file(p.ID)
+			file.ID = fileID
+
+			txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+			partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+			fileRepo.On("GetByID", mock.Anything, fileID).Return(file, nil)
+
+			uc := newTestUsecaseWithMasterdata(txMgr, partRepo, new(MockParticipantIdentityRepository), new(MockParticipantAddressRepository),
+				new(MockParticipantBankAccountRepository), new(MockParticipantFamilyMemberRepository), new(MockParticipantEmploymentRepository),
+				new(MockParticipantPensionRepository), new(MockParticipantBeneficiaryRepository), new(MockParticipantStatusHistoryRepository),
+				new(MockFileStorageAdapter), fileRepo, newRetirementTypeValidator())
+
+			resp, err := uc.RetireParticipant(context.Background(), &participant.RetireParticipantRequest{
+				TenantID: tenantID, ProductID: productID, ParticipantID: p.ID, UserID: uuid.New(),
+				RetirementDate: time.Now(), RetirementTypeCode: "RETIREMENT_TYPE_001", SupportingFile: &fileID,
+			})
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			var appErr *errors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.errKind, appErr.Kind)
+			fileRepo.AssertNotCalled(t, "SetPermanent", mock.Anything, mock.Anything)
+			partRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package participant_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mergeMocks struct {
+	txMgr     *MockTransactionManager
+	partRepo  *MockParticipantRepository
+	histRepo  *MockParticipantStatusHistoryRepository
+	dupRepo   *MockParticipantDuplicateRepository
+	identRepo *MockParticipantIdentityRepository
+	addrRepo  *MockParticipantAddressRepository
+	bankRepo  *MockParticipantBankAccountRepository
+	famRepo   *MockParticipantFamilyMemberRepository
+	empRepo   *MockParticipantEmploymentRepository
+	penRepo   *MockParticipantPensionRepository
+	benRepo   *MockParticipantBeneficiaryRepository
+}
+
+func makeMergeUsecase() (participant.Usecase, *mergeMocks) {
+	m := &mergeMocks{
+		txMgr:     new(MockTransactionManager),
+		partRepo:  new(MockParticipantRepository),
+		histRepo:  new(MockParticipantStatusHistoryRepository),
+		dupRepo:   new(MockParticipantDuplicateRepository),
+		identRepo: new(MockParticipantIdentityRepository),
+		addrRepo:  new(MockParticipantAddressRepository),
+		bankRepo:  new(MockParticipantBankAccountRepository),
+		famRepo:   new(MockParticipantFamilyMemberRepository),
+		empRepo:   new(MockParticipantEmploymentRepository),
+		penRepo:   new(MockParticipantPensionRepository),
+		benRepo:   new(MockParticipantBeneficiaryRepository),
+	}
+
+	uc := participant.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txMgr,
+		m.partRepo,
+		m.identRepo,
+		m.addrRepo,
+		m.bankRepo,
+		m.famRepo,
+		m.empRepo,
+		m.penRepo,
+		m.benRepo,
+		m.histRepo,
+		m.dupRepo,
+		new(MockFileStorageAdapter),
+		new(MockFileRepository),
+		new(MockUploadSlotRepository),
+		nil, nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	return uc, m
+}
+
+func (m *mergeMocks) expectEmptyChildren() {
+	m.identRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantIdentity{}, nil)
+	m.addrRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantAddress{}, nil)
+	m.bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{}, nil)
+	m.famRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantFamilyMember{}, nil)
+	m.empRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+	m.penRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+	m.benRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBeneficiary{}, nil)
+}
+
+func TestUsecase_MergeParticipants(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	reviewerID := uuid.New()
+	phone := "081234567890"
+
+	newPair := func(survivorStatus, sourceStatus entity.ParticipantStatus, sourceTenant uuid.UUID) (*entity.Participant, *entity.Participant) {
+		survivor := createMockParticipant(survivorStatus, tenantID, productID, userID)
+		survivor.StepsCompleted = map[string]bool{"personal_data": true}
+		source := createMockParticipant(sourceStatus, sourceTenant, productID, userID)
+		source.PhoneNumber = &phone
+		source.StepsCompleted = map[string]bool{"address": true}
+		return survivor, source
+	}
+
+	tests := []struct {
+		name          string
+		survivorState entity.ParticipantStatus
+		sourceState   entity.ParticipantStatus
+		sourceTenant  uuid.UUID
+		sameID        bool
+		setup         func(m *mergeMocks, survivor, source *entity.Participant)
+		errKind       errors.Kind
+	}{
+		{
+			name:          "success - consolidates source into survivor",
+			survivorState: entity.ParticipantStatusApproved,
+			sourceState:   entity.ParticipantStatusDraft,
+			sourceTenant:  tenantID,
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+				m.dupRepo.On("HasFinancialRecords", mock.Anything, source.ID).Return(false, nil)
+				m.dupRepo.On("ReassignChildRecords", mock.Anything, source.ID, survivor.ID).Return(nil)
+				m.dupRepo.On("MarkMerged", mock.Anything, source.ID, survivor.ID).Return(nil)
+				m.partRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *entity.Participant) bool {
+					return p.ID == survivor.ID && p.PhoneNumber != nil && *p.PhoneNumber == phone &&
+						p.StepsCompleted["personal_data"] && p.StepsCompleted["address"]
+				})).Return(nil)
+				m.histRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *entity.ParticipantStatusHistory) bool {
+					return h.ParticipantID == survivor.ID && h.ToStatus == string(entity.ParticipantStatusApproved) &&
+						h.Reason != nil && h.ChangedBy == reviewerID
+				})).Return(nil)
+				m.dupRepo.On("MarkPairMerged", mock.Anything, source.ID, survivor.ID, reviewerID).Return(nil)
+				m.expectEmptyChildren()
+			},
+		},
+		{
+			name:          "error - merging into itself",
+			survivorState: entity.ParticipantStatusApproved,
+			sourceState:   entity.ParticipantStatusDraft,
+			sourceTenant:  tenantID,
+			sameID:        true,
+			setup:         func(m *mergeMocks, survivor, source *entity.Participant) {},
+			errKind:       errors.KindBadRequest,
+		},
+		{
+			name:          "error - source belongs to another tenant",
+			survivorState: entity.ParticipantStatusApproved,
+			sourceState:   entity.ParticipantStatusDraft,
+			sourceTenant:  uuid.New(),
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+			},
+			errKind: errors.KindForbidden,
+		},
+		{
+			name:          "error - source awaiting approval",
+			survivorState: entity.ParticipantStatusApproved,
+			sourceState:   entity.ParticipantStatusPendingApproval,
+			sourceTenant:  tenantID,
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:          "error - source already active",
+			survivorState: entity.ParticipantStatusApproved,
+			sourceState:   entity.ParticipantStatusActive,
+			sourceTenant:  tenantID,
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:          "error - survivor deceased",
+			survivorState: entity.ParticipantStatusDeceased,
+			sourceState:   entity.ParticipantStatusDraft,
+			sourceTenant:  tenantID,
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+			},
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name:          "error - source has contributions or claims",
+			survivorState: entity.ParticipantStatusActive,
+			sourceState:   entity.ParticipantStatusApproved,
+			sourceTenant:  tenantID,
+			setup: func(m *mergeMocks, survivor, source *entity.Participant) {
+				m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.partRepo.On("GetByID", mock.Anything, survivor.ID).Return(survivor, nil)
+				m.partRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+				m.dupRepo.On("HasFinancialRecords", mock.Anything, source.ID).Return(true, nil)
+			},
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := makeMergeUsecase()
+			survivor, source := newPair(tt.survivorState, tt.sourceState, tt.sourceTenant)
+			if tt.sameID {
+				source.ID = survivor.ID
+			}
+			tt.setup(m, survivor, source)
+
+			resp, err := uc.MergeParticipants(context.Background(), &participant.MergeParticipantsRequest{
+				TenantID:            tenantID,
+				ProductID:           productID,
+				ParticipantID:       survivor.ID,
+				UserID:              reviewerID,
+				SourceParticipantID: source.ID,
+				Reason:              "same person registered twice",
+			})
+
+			if tt.errKind != 0 {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				m.dupRepo.AssertNotCalled(t, "ReassignChildRecords", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, survivor.ID, resp.ID)
+			m.partRepo.AssertExpectations(t)
+			m.histRepo.AssertExpectations(t)
+			m.dupRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/notify"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -47,6 +49,14 @@ func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	return args.Get(0).(*entity.Participant), args.Error(1)
 }
 
+func (m *MockParticipantRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Participant, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Participant), args.Error(1)
+}
+
 func (m *MockParticipantRepository) Update(ctx context.Context, p *entity.Participant) error {
 	args := m.Called(ctx, p)
 	return args.Error(0)
@@ -119,6 +129,14 @@ func (m *MockParticipantIdentityRepository) ListByParticipantID(ctx context.Cont
 	return args.Get(0).([]*entity.ParticipantIdentity), args.Error(1)
 }
 
+func (m *MockParticipantIdentityRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantIdentity, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantIdentity), args.Error(1)
+}
+
 func (m *MockParticipantIdentityRepository) Update(ctx context.Context, identity *entity.ParticipantIdentity) error {
 	args := m.Called(ctx, identity)
 	return args.Error(0)
@@ -154,6 +172,14 @@ func (m *MockParticipantAddressRepository) ListByParticipantID(ctx context.Conte
 	return args.Get(0).([]*entity.ParticipantAddress), args.Error(1)
 }
 
+func (m *MockParticipantAddressRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantAddress, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantAddress), args.Error(1)
+}
+
 func (m *MockParticipantAddressRepository) Update(ctx context.Context, address *entity.ParticipantAddress) error {
 	args := m.Called(ctx, address)
 	return args.Error(0)
@@ -194,6 +220,14 @@ func (m *MockParticipantBankAccountRepository) ListByParticipantID(ctx context.C
 	return args.Get(0).([]*entity.ParticipantBankAccount), args.Error(1)
 }
 
+func (m *MockParticipantBankAccountRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBankAccount, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantBankAccount), args.Error(1)
+}
+
 func (m *MockParticipantBankAccountRepository) Update(ctx context.Context, account *entity.ParticipantBankAccount) error {
 	args := m.Called(ctx, account)
 	return args.Error(0)
@@ -234,6 +268,14 @@ func (m *MockParticipantFamilyMemberRepository) ListByParticipantID(ctx context.
 	return args.Get(0).([]*entity.ParticipantFamilyMember), args.Error(1)
 }
 
+func (m *MockParticipantFamilyMemberRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantFamilyMember, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantFamilyMember), args.Error(1)
+}
+
 func (m *MockParticipantFamilyMemberRepository) Update(ctx context.Context, member *entity.ParticipantFamilyMember) error {
 	args := m.Called(ctx, member)
 	return args.Error(0)
@@ -274,6 +316,14 @@ func (m *MockParticipantEmploymentRepository) GetByParticipantID(ctx context.Con
 	return args.Get(0).(*entity.ParticipantEmployment), args.Error(1)
 }
 
+func (m *MockParticipantEmploymentRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantEmployment, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantEmployment), args.Error(1)
+}
+
 func (m *MockParticipantEmploymentRepository) Update(ctx context.Context, employment *entity.ParticipantEmployment) error {
 	args := m.Called(ctx, employment)
 	return args.Error(0)
@@ -309,6 +359,14 @@ func (m *MockParticipantPensionRepository) GetByParticipantID(ctx context.Contex
 	return args.Get(0).(*entity.ParticipantPension), args.Error(1)
 }
 
+func (m *MockParticipantPensionRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantPension, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantPension), args.Error(1)
+}
+
 func (m *MockParticipantPensionRepository) Update(ctx context.Context, pension *entity.ParticipantPension) error {
 	args := m.Called(ctx, pension)
 	return args.Error(0)
@@ -344,6 +402,14 @@ func (m *MockParticipantBeneficiaryRepository) ListByParticipantID(ctx context.C
 	return args.Get(0).([]*entity.ParticipantBeneficiary), args.Error(1)
 }
 
+func (m *MockParticipantBeneficiaryRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantBeneficiary, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantBeneficiary), args.Error(1)
+}
+
 func (m *MockParticipantBeneficiaryRepository) Update(ctx context.Context, beneficiary *entity.ParticipantBeneficiary) error {
 	args := m.Called(ctx, beneficiary)
 	return args.Error(0)
@@ -376,6 +442,80 @@ func (m *MockParticipantStatusHistoryRepository) ListByParticipantID(ctx context
 	return args.Get(0).([]*entity.ParticipantStatusHistory), args.Error(1)
 }
 
+func (m *MockParticipantStatusHistoryRepository) ListByParticipantIDs(ctx context.Context, participantIDs []uuid.UUID) ([]*entity.ParticipantStatusHistory, error) {
+	args := m.Called(ctx, participantIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ParticipantStatusHistory), args.Error(1)
+}
+
+type MockParticipantDuplicateRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantDuplicateRepository) FindCandidates(ctx context.Context, criteria *participant.DuplicateSearchCriteria) ([]*entity.Participant, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Participant), args.Error(1)
+}
+
+func (m *MockParticipantDuplicateRepository) UpsertCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error {
+	args := m.Called(ctx, candidate)
+	return args.Error(0)
+}
+
+func (m *MockParticipantDuplicateRepository) GetCandidateByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantDuplicateCandidate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantDuplicateCandidate), args.Error(1)
+}
+
+func (m *MockParticipantDuplicateRepository) ListCandidates(ctx context.Context, filter *participant.DuplicateCandidateFilter) ([]*entity.ParticipantDuplicateCandidate, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.ParticipantDuplicateCandidate), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockParticipantDuplicateRepository) UpdateCandidate(ctx context.Context, candidate *entity.ParticipantDuplicateCandidate) error {
+	args := m.Called(ctx, candidate)
+	return args.Error(0)
+}
+
+func (m *MockParticipantDuplicateRepository) MarkPairMerged(ctx context.Context, sourceID, targetID, reviewedBy uuid.UUID) error {
+	args := m.Called(ctx, sourceID, targetID, reviewedBy)
+	return args.Error(0)
+}
+
+func (m *MockParticipantDuplicateRepository) HasFinancialRecords(ctx context.Context, participantID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, participantID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockParticipantDuplicateRepository) ReassignChildRecords(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockParticipantDuplicateRepository) MarkMerged(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Error(0)
+}
+
+// newNoDuplicatesRepo returns a duplicate repository that never finds a match,
+// for tests that exercise create/submit without caring about detection.
+func newNoDuplicatesRepo() *MockParticipantDuplicateRepository {
+	m := new(MockParticipantDuplicateRepository)
+	m.On("FindCandidates", mock.Anything, mock.Anything).Return([]*entity.Participant{}, nil).Maybe()
+	return m
+}
+
 type MockFileStorageAdapter struct {
 	mock.Mock
 }
@@ -395,6 +535,48 @@ func (m *MockFileStorageAdapter) GetPresignedURL(ctx context.Context, bucket, ob
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileStorageAdapter) PresignUpload(ctx context.Context, bucket, objectKey, contentType string, maxSize int64, expiry time.Duration) (*participant.PresignedUpload, error) {
+	args := m.Called(ctx, bucket, objectKey, contentType, maxSize, expiry)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.PresignedUpload), args.Error(1)
+}
+
+func (m *MockFileStorageAdapter) StatObject(ctx context.Context, bucket, objectKey string) (int64, error) {
+	args := m.Called(ctx, bucket, objectKey)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFileStorageAdapter) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockFileStorageAdapter) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string) error {
+	args := m.Called(ctx, srcBucket, srcKey, dstBucket, dstKey)
+	return args.Error(0)
+}
+
+func (m *MockFileStorageAdapter) UploadEncryptedFile(ctx context.Context, tenantID uuid.UUID, bucket, objectKey string, data io.Reader, size int64) (*envelope.Envelope, error) {
+	args := m.Called(ctx, tenantID, bucket, objectKey, data, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envelope.Envelope), args.Error(1)
+}
+
+func (m *MockFileStorageAdapter) GetDecryptedObject(ctx context.Context, bucket, objectKey string, env *envelope.Envelope) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, objectKey, env)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
 type MockFileRepository struct {
 	mock.Mock
 }
@@ -452,3 +634,188 @@ func (m *MockFileRepository) ReleaseStaleClaimsOlderThan(ctx context.Context, ag
 	args := m.Called(ctx, age)
 	return args.Error(0)
 }
+
+func (m *MockFileRepository) ClaimPendingScan(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) ReleaseStaleScanClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	args := m.Called(ctx, age)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) RecordScanFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	args := m.Called(ctx, id, maxAttempts)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) MarkScanned(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) MarkQuarantined(ctx context.Context, id uuid.UUID, bucket, storageKey, signature string) error {
+	args := m.Called(ctx, id, bucket, storageKey, signature)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) ClaimPendingProcessing(ctx context.Context, limit, maxAttempts int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) ReleaseStaleProcessClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	args := m.Called(ctx, age)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) MarkProcessed(ctx context.Context, id uuid.UUID, storageKey, contentType string, sizeBytes int64, env *envelope.Envelope, thumbnailFileID uuid.UUID) error {
+	args := m.Called(ctx, id, storageKey, contentType, sizeBytes, env, thumbnailFileID)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) MarkProcessingSkipped(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) RecordProcessingFailure(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	args := m.Called(ctx, id, maxAttempts)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) ListDueForRewrap(ctx context.Context, rewrappedBefore time.Time, limit int) ([]*entity.File, error) {
+	args := m.Called(ctx, rewrappedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) UpdateWrappedDataKey(ctx context.Context, id uuid.UUID, oldWrappedKey, newWrappedKey string) error {
+	args := m.Called(ctx, id, oldWrappedKey, newWrappedKey)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) ClaimDueForPurge(ctx context.Context, limit int) ([]*entity.File, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) ReleaseStalePurgeClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	args := m.Called(ctx, age)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) ReleasePurgeClaim(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) ListDerived(ctx context.Context, id uuid.UUID) ([]*entity.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) SetLegalHold(ctx context.Context, id uuid.UUID, hold bool, reason *string, setBy uuid.UUID) error {
+	args := m.Called(ctx, id, hold, reason, setBy)
+	return args.Error(0)
+}
+
+type MockUploadSlotRepository struct {
+	mock.Mock
+}
+
+func (m *MockUploadSlotRepository) Create(ctx context.Context, slot *entity.UploadSlot) error {
+	args := m.Called(ctx, slot)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.UploadSlot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UploadSlot), args.Error(1)
+}
+
+func (m *MockUploadSlotRepository) ClaimCompletion(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) ReleaseCompletion(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) Complete(ctx context.Context, id uuid.UUID, fileID uuid.UUID) error {
+	args := m.Called(ctx, id, fileID)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) ClaimExpired(ctx context.Context, limit int) ([]*entity.UploadSlot, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UploadSlot), args.Error(1)
+}
+
+func (m *MockUploadSlotRepository) ReleaseStaleClaimsOlderThan(ctx context.Context, age time.Duration) error {
+	args := m.Called(ctx, age)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) IncrementFailedAttempts(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUploadSlotRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockBankAccountVerifier struct {
+	mock.Mock
+}
+
+func (m *MockBankAccountVerifier) Name() string {
+	return "mock"
+}
+
+func (m *MockBankAccountVerifier) Inquire(ctx context.Context, inquiry participant.BankAccountInquiry) (*participant.BankAccountInquiryResult, error) {
+	args := m.Called(ctx, inquiry)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*participant.BankAccountInquiryResult), args.Error(1)
+}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) PublishEvent(ctx context.Context, event *notify.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
@@ -35,8 +35,11 @@ func makeAddressUsecase() (participant.Usecase, *MockTransactionManager, *MockPa
 		new(MockParticipantPensionRepository),
 		new(MockParticipantBeneficiaryRepository),
 		statusHistoryRepo,
+		newNoDuplicatesRepo(),
 		new(MockFileStorageAdapter),
 		new(MockFileRepository),
+		new(MockUploadSlotRepository),
+		nil, nil,
 		nil, nil, nil, nil, nil, nil,
 	)
 	return uc, txMgr, participantRepo, addressRepo, statusHistoryRepo
@@ -36,8 +36,11 @@ func makeBeneficiaryUsecase() (participant.Usecase, *MockTransactionManager, *Mo
 		new(MockParticipantPensionRepository),
 		beneficiaryRepo,
 		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
 		new(MockFileStorageAdapter),
 		fileRepo,
+		new(MockUploadSlotRepository),
+		nil, nil,
 		nil, nil, nil, nil, nil, nil,
 	)
 	return uc, txMgr, participantRepo, beneficiaryRepo, familyMemberRepo, fileRepo
@@ -223,9 +226,10 @@ func TestSaveBeneficiaries_WithFileIDs_SetsPermanent(t *testing.T) {
 	}
 
 	file := &entity.File{
-		ID:        identityPhotoFileID,
-		TenantID:  tenantID,
-		ProductID: productID,
+		ID:         identityPhotoFileID,
+		TenantID:   tenantID,
+		ProductID:  productID,
+		ScanStatus: entity.FileScanStatusClean,
 	}
 
 	txMgr.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
@@ -35,8 +35,11 @@ func makeFamilyMemberUsecase() (participant.Usecase, *MockTransactionManager, *M
 		new(MockParticipantPensionRepository),
 		new(MockParticipantBeneficiaryRepository),
 		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
 		new(MockFileStorageAdapter),
 		fileRepo,
+		new(MockUploadSlotRepository),
+		nil, nil,
 		nil, nil, nil, nil, nil, nil,
 	)
 	return uc, txMgr, participantRepo, familyMemberRepo, fileRepo
@@ -115,9 +118,10 @@ func TestSaveFamilyMembers_WithSupportingDoc_ValidatesOwnershipAndSetsPermanent(
 	participantID := p.ID
 
 	file := &entity.File{
-		ID:        fileID,
-		TenantID:  tenantID,
-		ProductID: productID,
+		ID:         fileID,
+		TenantID:   tenantID,
+		ProductID:  productID,
+		ScanStatus: entity.FileScanStatusClean,
 	}
 
 	txMgr.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
@@ -259,3 +263,54 @@ func TestSaveFamilyMembers_StepMarkedComplete(t *testing.T) {
 
 	familyMemberRepo.AssertNotCalled(t, "ListByParticipantID", mock.Anything, mock.Anything)
 }
+
+func TestSaveFamilyMembers_UncleanFile_ReturnsBadRequest(t *testing.T) {
+	for _, status := range []entity.FileScanStatus{
+		entity.FileScanStatusInfected,
+		entity.FileScanStatusFailed,
+		entity.FileScanStatusPending,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			tenantID := uuid.New()
+			productID := uuid.New()
+			fileID := uuid.New()
+
+			uc, txMgr, participantRepo, familyMemberRepo, fileRepo := makeFamilyMemberUsecase()
+
+			p := makeDraftParticipant(tenantID, productID)
+
+			file := &entity.File{
+				ID:         fileID,
+				TenantID:   tenantID,
+				ProductID:  productID,
+				ScanStatus: status,
+			}
+
+			txMgr.On("WithTransaction", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+			participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+			familyMemberRepo.On("SoftDeleteAllByParticipantID", mock.Anything, p.ID).Return(nil)
+			fileRepo.On("GetByID", mock.Anything, fileID).Return(file, nil)
+
+			result, err := uc.SaveFamilyMembers(context.Background(), &participant.SaveFamilyMembersRequest{
+				TenantID:      tenantID,
+				ProductID:     productID,
+				ParticipantID: p.ID,
+				UserID:        uuid.New(),
+				FamilyMembers: []participant.FamilyMemberItem{
+					{
+						FullName:            "Jane",
+						RelationshipType:    "PARENT",
+						SupportingDocFileID: &fileID,
+					},
+				},
+			})
+			require.Error(t, err)
+			assert.Nil(t, result)
+
+			var appErr *errors.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, errors.KindBadRequest, appErr.Kind)
+			fileRepo.AssertNotCalled(t, "SetPermanent", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -180,8 +180,11 @@ func buildSelfRegisterUsecase(
 		pensionRepo,
 		&MockParticipantBeneficiaryRepository{},
 		statusHistoryRepo,
+		newNoDuplicatesRepo(),
 		&MockFileStorageAdapter{},
 		&MockFileRepository{},
+		new(MockUploadSlotRepository),
+		nil, nil,
 		tenantRepo,
 		productRepo,
 		configRepo,
@@ -9,6 +9,7 @@ import (
 	"erp-service/entity"
 	"erp-service/masterdata"
 	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
@@ -69,8 +70,11 @@ func minimalSelfRegisterUsecase(
 		&MockParticipantPensionRepository{},
 		&MockParticipantBeneficiaryRepository{},
 		&MockParticipantStatusHistoryRepository{},
+		newNoDuplicatesRepo(),
 		&MockFileStorageAdapter{},
 		&MockFileRepository{},
+		new(MockUploadSlotRepository),
+		nil, nil,
 		tr,
 		pr,
 		cr,
@@ -104,21 +108,22 @@ func TestSelfRegister_Success_NewParticipant(t *testing.T) {
 	})).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(nil, nil, apperrors.ErrNotFound("not found"))
-	utrRepo.On("GetByUserAndProduct", ctx, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
+	utrRepo.On("GetByUserAndProduct", routed, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
 		Return(nil, apperrors.ErrNotFound("not found"))
 
-	txMgr.On("WithTransaction", ctx, mock.Anything).Return(nil)
-	partRepo.On("Create", ctx, mock.AnythingOfType("*entity.Participant")).Return(nil)
-	pensionRepo.On("Create", ctx, mock.AnythingOfType("*entity.ParticipantPension")).Return(nil)
-	statusHistoryRepo.On("Create", ctx, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
-	utrRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
+	txMgr.On("WithTransaction", routed, mock.Anything).Return(nil)
+	partRepo.On("Create", routed, mock.AnythingOfType("*entity.Participant")).Return(nil)
+	pensionRepo.On("Create", routed, mock.AnythingOfType("*entity.ParticipantPension")).Return(nil)
+	statusHistoryRepo.On("Create", routed, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
+	utrRepo.On("Create", routed, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
 
-	partRepo.MockParticipantRepository.On("GetByID", ctx, mock.Anything).Return(&entity.Participant{
+	partRepo.MockParticipantRepository.On("GetByID", routed, mock.Anything).Return(&entity.Participant{
 		ID:        uuid.New(),
 		TenantID:  tenant.ID,
 		ProductID: product.ID,
@@ -160,19 +165,20 @@ func TestSelfRegister_Success_ResponseDataHasNoKTPNumber(t *testing.T) {
 
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(nil, nil, apperrors.ErrNotFound("not found"))
-	utrRepo.On("GetByUserAndProduct", ctx, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
+	utrRepo.On("GetByUserAndProduct", routed, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
 		Return(nil, apperrors.ErrNotFound("not found"))
 
-	txMgr.On("WithTransaction", ctx, mock.Anything).Return(nil)
-	partRepo.On("Create", ctx, mock.AnythingOfType("*entity.Participant")).Return(nil)
-	pensionRepo.On("Create", ctx, mock.AnythingOfType("*entity.ParticipantPension")).Return(nil)
-	statusHistoryRepo.On("Create", ctx, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
-	utrRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
+	txMgr.On("WithTransaction", routed, mock.Anything).Return(nil)
+	partRepo.On("Create", routed, mock.AnythingOfType("*entity.Participant")).Return(nil)
+	pensionRepo.On("Create", routed, mock.AnythingOfType("*entity.ParticipantPension")).Return(nil)
+	statusHistoryRepo.On("Create", routed, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
+	utrRepo.On("Create", routed, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
 
 	uc := buildSelfRegisterUsecase(txMgr, partRepo, pensionRepo, statusHistoryRepo, tenantRepo, productRepo, configRepo, utrRepo, profileRepo, mdValidator)
 
@@ -222,16 +228,17 @@ func TestSelfRegister_Success_LinkExistingParticipant(t *testing.T) {
 
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(existingParticipant, existingPension, nil)
 
-	txMgr.On("WithTransaction", ctx, mock.Anything).Return(nil)
-	partRepo.MockParticipantRepository.On("Update", ctx, mock.AnythingOfType("*entity.Participant")).Return(nil)
-	utrRepo.On("Create", ctx, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
-	statusHistoryRepo.On("Create", ctx, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
+	txMgr.On("WithTransaction", routed, mock.Anything).Return(nil)
+	partRepo.MockParticipantRepository.On("Update", routed, mock.AnythingOfType("*entity.Participant")).Return(nil)
+	utrRepo.On("Create", routed, mock.AnythingOfType("*entity.UserTenantRegistration")).Return(nil)
+	statusHistoryRepo.On("Create", routed, mock.AnythingOfType("*entity.ParticipantStatusHistory")).Return(nil)
 
 	uc := buildSelfRegisterUsecase(txMgr, partRepo, pensionRepo, statusHistoryRepo, tenantRepo, productRepo, configRepo, utrRepo, profileRepo, mdValidator)
 
@@ -389,8 +396,9 @@ func TestSelfRegister_ProductNotFound(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(nil, apperrors.ErrNotFound("product not found"))
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(nil, apperrors.ErrNotFound("product not found"))
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, nil, nil, nil, nil)
 	resp, err := uc.SelfRegister(ctx, req)
@@ -412,11 +420,12 @@ func TestSelfRegister_ConfigNotFound(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
 
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(nil, apperrors.ErrNotFound("config not found"))
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(nil, apperrors.ErrNotFound("config not found"))
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, nil, nil, nil)
 	resp, err := uc.SelfRegister(ctx, req)
@@ -444,10 +453,11 @@ func TestSelfRegister_ConfigNotActive(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(inactiveConfig, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(inactiveConfig, nil)
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, nil, nil, nil)
 	resp, err := uc.SelfRegister(ctx, req)
@@ -478,12 +488,13 @@ func TestSelfRegister_IncompleteProfile_MissingGender(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
 	profileRepo := &mockUserProfileRepository{}
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(incompleteProfile, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(incompleteProfile, nil)
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, profileRepo, nil, nil)
 	resp, err := uc.SelfRegister(ctx, req)
@@ -520,14 +531,15 @@ func TestSelfRegister_AlreadyLinked_DifferentUser(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
 	profileRepo := &mockUserProfileRepository{}
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
 	partRepo := &mockParticipantRepositoryWithKTP{}
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(linkedParticipant, existingPension, nil)
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, profileRepo, partRepo, nil)
@@ -568,14 +580,15 @@ func TestSelfRegister_AlreadyLinked_SameUser(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
 	profileRepo := &mockUserProfileRepository{}
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
 	partRepo := &mockParticipantRepositoryWithKTP{}
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(linkedParticipant, existingPension, nil)
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, profileRepo, partRepo, nil)
@@ -602,17 +615,18 @@ func TestSelfRegister_AlreadyRegistered_UTRExists(t *testing.T) {
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo := &mockTenantRepository{}
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
 	productRepo := &mockProductRepository{}
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
 	configRepo := &mockProductRegistrationConfigRepository{}
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
 	profileRepo := &mockUserProfileRepository{}
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
 	partRepo := &mockParticipantRepositoryWithKTP{}
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(nil, nil, apperrors.ErrNotFound("not found"))
 	utrRepo := &mockUserTenantRegistrationRepository{}
-	utrRepo.On("GetByUserAndProduct", ctx, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
+	utrRepo.On("GetByUserAndProduct", routed, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
 		Return(&entity.UserTenantRegistration{ID: uuid.New()}, nil)
 
 	uc := minimalSelfRegisterUsecase(mdValidator, tenantRepo, productRepo, configRepo, profileRepo, partRepo, utrRepo)
@@ -648,16 +662,17 @@ func TestSelfRegister_UniqueConstraintViolation_OnCreate(t *testing.T) {
 
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(nil, nil, apperrors.ErrNotFound("not found"))
-	utrRepo.On("GetByUserAndProduct", ctx, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
+	utrRepo.On("GetByUserAndProduct", routed, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
 		Return(nil, apperrors.ErrNotFound("not found"))
 
 	conflictErr := apperrors.ErrConflict("registration not eligible")
-	txMgr.On("WithTransaction", ctx, mock.Anything).Return(conflictErr)
+	txMgr.On("WithTransaction", routed, mock.Anything).Return(conflictErr)
 
 	uc := buildSelfRegisterUsecase(txMgr, partRepo, pensionRepo, statusHistoryRepo, tenantRepo, productRepo, configRepo, utrRepo, profileRepo, mdValidator)
 
@@ -692,16 +707,17 @@ func TestSelfRegister_TransactionRollback_OnUTRCreateFailure(t *testing.T) {
 
 	mdValidator.On("ValidateItemCode", ctx, mock.Anything).Return(&masterdata.ValidateCodeResponse{Valid: true}, nil)
 	tenantRepo.On("GetByCode", ctx, req.Organization).Return(tenant, nil)
-	productRepo.On("GetByCodeAndTenant", ctx, tenant.ID, "frendz-saving").Return(product, nil)
-	configRepo.On("GetByProductAndType", ctx, product.ID, "PARTICIPANT").Return(cfg, nil)
-	profileRepo.On("GetByUserID", ctx, req.UserID).Return(profile, nil)
-	partRepo.On("GetByKTPAndPensionNumber", ctx, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
+	routed := tenantdb.WithTenant(ctx, tenant.ID)
+	productRepo.On("GetByCodeAndTenant", routed, tenant.ID, "frendz-saving").Return(product, nil)
+	configRepo.On("GetByProductAndType", routed, product.ID, "PARTICIPANT").Return(cfg, nil)
+	profileRepo.On("GetByUserID", routed, req.UserID).Return(profile, nil)
+	partRepo.On("GetByKTPAndPensionNumber", routed, req.IdentityNumber, req.ParticipantNumber, tenant.ID, product.ID).
 		Return(nil, nil, apperrors.ErrNotFound("not found"))
-	utrRepo.On("GetByUserAndProduct", ctx, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
+	utrRepo.On("GetByUserAndProduct", routed, req.UserID, tenant.ID, product.ID, "PARTICIPANT").
 		Return(nil, apperrors.ErrNotFound("not found"))
 
 	txErr := apperrors.ErrInternal("db error")
-	txMgr.On("WithTransaction", ctx, mock.Anything).Return(txErr)
+	txMgr.On("WithTransaction", routed, mock.Anything).Return(txErr)
 
 	uc := buildSelfRegisterUsecase(txMgr, partRepo, pensionRepo, statusHistoryRepo, tenantRepo, productRepo, configRepo, utrRepo, profileRepo, mdValidator)
 
@@ -2,16 +2,20 @@ package participant_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
+	"erp-service/config"
 	"erp-service/entity"
 	"erp-service/pkg/errors"
+	"erp-service/pkg/notify"
 	"erp-service/saving/participant"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestUsecase_SubmitParticipant(t *testing.T) {
@@ -185,3 +189,199 @@ func TestUsecase_SubmitParticipant(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_SubmitParticipant_FileScanGate(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  entity.FileScanStatus
+		wantErr bool
+	}{
+		{name: "clean files allow submission", status: entity.FileScanStatusClean},
+		{name: "file pending scan blocks submission", status: entity.FileScanStatusPending, wantErr: true},
+		{name: "infected file blocks submission", status: entity.FileScanStatusInfected, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txMgr := new(MockTransactionManager)
+			partRepo := new(MockParticipantRepository)
+			identRepo := new(MockParticipantIdentityRepository)
+			addrRepo := new(MockParticipantAddressRepository)
+			bankRepo := new(MockParticipantBankAccountRepository)
+			famRepo := new(MockParticipantFamilyMemberRepository)
+			empRepo := new(MockParticipantEmploymentRepository)
+			penRepo := new(MockParticipantPensionRepository)
+			benRepo := new(MockParticipantBeneficiaryRepository)
+			histRepo := new(MockParticipantStatusHistoryRepository)
+			fileRepo := new(MockFileRepository)
+
+			p := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+			identity := createMockIdentity(p.ID)
+			photoID := uuid.New()
+			identity.PhotoFileID = &photoID
+
+			txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+			partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+			partRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			histRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			identRepo.On("ListByParticipantID", mock.Anything, p.ID).Return([]*entity.ParticipantIdentity{identity}, nil)
+			addrRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantAddress{}, nil)
+			bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{}, nil)
+			famRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantFamilyMember{}, nil)
+			empRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+			penRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+			benRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBeneficiary{}, nil)
+			fileRepo.On("GetByID", mock.Anything, photoID).Return(&entity.File{
+				ID:         photoID,
+				TenantID:   tenantID,
+				ProductID:  productID,
+				ScanStatus: tt.status,
+			}, nil)
+
+			uc := participant.NewUsecase(
+				&config.Config{},
+				zap.NewNop(),
+				txMgr,
+				partRepo,
+				identRepo,
+				addrRepo,
+				bankRepo,
+				famRepo,
+				empRepo,
+				penRepo,
+				benRepo,
+				histRepo,
+				newNoDuplicatesRepo(),
+				new(MockFileStorageAdapter),
+				fileRepo,
+				new(MockUploadSlotRepository),
+				nil, nil,
+				nil, nil, nil, nil, nil, nil,
+			)
+
+			resp, err := uc.SubmitParticipant(context.Background(), &participant.SubmitParticipantRequest{
+				ParticipantID: p.ID,
+				TenantID:      tenantID,
+				ProductID:     productID,
+				UserID:        userID,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, errors.KindBadRequest, appErr.Kind)
+				partRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, string(entity.ParticipantStatusPendingApproval), resp.Status)
+		})
+	}
+}
+
+func TestUsecase_SubmitParticipant_PublishesToApprovalQueue(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+
+	newUsecase := func(p *entity.Participant, events *MockEventPublisher, updateErr error) participant.Usecase {
+		txMgr := new(MockTransactionManager)
+		partRepo := new(MockParticipantRepository)
+		identRepo := new(MockParticipantIdentityRepository)
+		addrRepo := new(MockParticipantAddressRepository)
+		bankRepo := new(MockParticipantBankAccountRepository)
+		famRepo := new(MockParticipantFamilyMemberRepository)
+		empRepo := new(MockParticipantEmploymentRepository)
+		penRepo := new(MockParticipantPensionRepository)
+		benRepo := new(MockParticipantBeneficiaryRepository)
+		histRepo := new(MockParticipantStatusHistoryRepository)
+
+		txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+		partRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+		partRepo.On("Update", mock.Anything, mock.Anything).Return(updateErr)
+		histRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		identRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantIdentity{}, nil)
+		addrRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantAddress{}, nil)
+		bankRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBankAccount{}, nil)
+		famRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantFamilyMember{}, nil)
+		empRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+		penRepo.On("GetByParticipantID", mock.Anything, mock.Anything).Return(nil, errors.ErrNotFound("not found"))
+		benRepo.On("ListByParticipantID", mock.Anything, mock.Anything).Return([]*entity.ParticipantBeneficiary{}, nil)
+
+		return participant.NewUsecase(
+			&config.Config{},
+			zap.NewNop(),
+			txMgr,
+			partRepo,
+			identRepo,
+			addrRepo,
+			bankRepo,
+			famRepo,
+			empRepo,
+			penRepo,
+			benRepo,
+			histRepo,
+			newNoDuplicatesRepo(),
+			new(MockFileStorageAdapter),
+			new(MockFileRepository),
+			new(MockUploadSlotRepository),
+			nil,
+			events,
+			nil, nil, nil, nil, nil, nil,
+		)
+	}
+
+	t.Run("announces the submission after it is committed", func(t *testing.T) {
+		p := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		events := new(MockEventPublisher)
+		events.On("PublishEvent", mock.Anything, mock.MatchedBy(func(e *notify.Event) bool {
+			var data notify.ParticipantStatusData
+			require.NoError(t, json.Unmarshal(e.Data, &data))
+			return e.Topic == notify.TopicApprovalQueue &&
+				e.Type == notify.ParticipantSubmitted &&
+				e.TenantID == tenantID && e.ProductID == productID &&
+				data.ParticipantID == p.ID &&
+				data.FromStatus == string(entity.ParticipantStatusDraft) &&
+				data.ToStatus == string(entity.ParticipantStatusPendingApproval) &&
+				data.ChangedBy == userID
+		})).Return(nil).Once()
+
+		_, err := newUsecase(p, events, nil).SubmitParticipant(context.Background(), &participant.SubmitParticipantRequest{
+			ParticipantID: p.ID, TenantID: tenantID, ProductID: productID, UserID: userID,
+		})
+
+		require.NoError(t, err)
+		events.AssertExpectations(t)
+	})
+
+	t.Run("a failed transition is not announced", func(t *testing.T) {
+		p := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		events := new(MockEventPublisher)
+
+		_, err := newUsecase(p, events, assert.AnError).SubmitParticipant(context.Background(), &participant.SubmitParticipantRequest{
+			ParticipantID: p.ID, TenantID: tenantID, ProductID: productID, UserID: userID,
+		})
+
+		require.Error(t, err)
+		events.AssertNotCalled(t, "PublishEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a publish failure does not fail the submission", func(t *testing.T) {
+		p := createMockParticipant(entity.ParticipantStatusDraft, tenantID, productID, userID)
+		events := new(MockEventPublisher)
+		events.On("PublishEvent", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+		resp, err := newUsecase(p, events, nil).SubmitParticipant(context.Background(), &participant.SubmitParticipantRequest{
+			ParticipantID: p.ID, TenantID: tenantID, ProductID: productID, UserID: userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entity.ParticipantStatusPendingApproval), resp.Status)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"erp-service/config"
 	"erp-service/entity"
+	"erp-service/pkg/envelope"
 	"erp-service/pkg/errors"
 	"erp-service/saving/participant"
 
@@ -36,8 +37,11 @@ func makeUploadUsecase() (participant.Usecase, *MockParticipantRepository, *Mock
 		new(MockParticipantPensionRepository),
 		new(MockParticipantBeneficiaryRepository),
 		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
 		fileStorage,
 		fileRepo,
+		new(MockUploadSlotRepository),
+		nil, nil,
 		nil, nil, nil, nil, nil, nil,
 	)
 	return uc, participantRepo, fileRepo, fileStorage
@@ -64,10 +68,12 @@ func TestUploadFile_Success_ReturnsFileID(t *testing.T) {
 	participantRepo.On("GetByID", mock.Anything, participantID).Return(p, nil)
 
 	fileData := []byte("fake-image-data")
-	storageKey := "participants/tenant/product/participant/ktp_photo/photo.jpg"
-	fileStorage.On("UploadFile", mock.Anything, "participants", mock.AnythingOfType("string"),
-		mock.Anything, int64(len(fileData)), "image/jpeg").
-		Return(storageKey, nil)
+	var storageKey string
+	env := &envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}
+	fileStorage.On("UploadEncryptedFile", mock.Anything, tenantID, "participants", mock.AnythingOfType("string"),
+		mock.Anything, int64(len(fileData))).
+		Run(func(args mock.Arguments) { storageKey = args.String(3) }).
+		Return(env, nil)
 
 	var createdFile *entity.File
 	fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
@@ -95,12 +101,13 @@ func TestUploadFile_Success_ReturnsFileID(t *testing.T) {
 
 	assert.NotEqual(t, uuid.Nil, result.FileID)
 
-	fileStorage.AssertCalled(t, "UploadFile", mock.Anything, "participants", mock.AnythingOfType("string"),
-		mock.Anything, int64(len(fileData)), "image/jpeg")
+	fileStorage.AssertCalled(t, "UploadEncryptedFile", mock.Anything, tenantID, "participants", mock.AnythingOfType("string"),
+		mock.Anything, int64(len(fileData)))
 
 	require.NotNil(t, createdFile)
 	assert.Equal(t, "participants", createdFile.Bucket)
 	assert.Equal(t, storageKey, createdFile.StorageKey)
+	assert.Equal(t, env, createdFile.Envelope())
 
 	require.NotNil(t, createdFile.ExpiresAt)
 	duration := createdFile.ExpiresAt.Sub(time.Now())
@@ -220,9 +227,9 @@ func TestUploadFile_StoredWithUploaderID(t *testing.T) {
 	}
 
 	participantRepo.On("GetByID", mock.Anything, participantID).Return(p, nil)
-	fileStorage.On("UploadFile", mock.Anything, mock.Anything, mock.Anything,
+	fileStorage.On("UploadEncryptedFile", mock.Anything, mock.Anything, mock.Anything,
 		mock.Anything, mock.Anything, mock.Anything).
-		Return("participants/path/doc.pdf", nil)
+		Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}, nil)
 
 	var createdFile *entity.File
 	fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
@@ -271,9 +278,9 @@ func TestUploadFile_StorageError_NoDatabaseInsert(t *testing.T) {
 	}
 
 	participantRepo.On("GetByID", mock.Anything, participantID).Return(p, nil)
-	fileStorage.On("UploadFile", mock.Anything, mock.Anything, mock.Anything,
+	fileStorage.On("UploadEncryptedFile", mock.Anything, mock.Anything, mock.Anything,
 		mock.Anything, mock.Anything, mock.Anything).
-		Return("", errors.ErrInternal("storage unavailable"))
+		Return(nil, errors.ErrInternal("storage unavailable"))
 
 	req := &participant.UploadFileRequest{
 		TenantID:      tenantID,
@@ -308,15 +315,16 @@ func TestUploadFile_DBInsertFails_CleansUpStorage(t *testing.T) {
 		Status:    entity.ParticipantStatusDraft,
 	}
 
-	storageKey := "participants/path/photo.jpg"
+	var storageKey string
 
 	participantRepo.On("GetByID", mock.Anything, participantID).Return(p, nil)
-	fileStorage.On("UploadFile", mock.Anything, "participants", mock.AnythingOfType("string"),
-		mock.Anything, mock.Anything, "image/jpeg").
-		Return(storageKey, nil)
+	fileStorage.On("UploadEncryptedFile", mock.Anything, tenantID, "participants", mock.AnythingOfType("string"),
+		mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { storageKey = args.String(3) }).
+		Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}, nil)
 	fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
 		Return(errors.ErrInternal("db insert failed"))
-	fileStorage.On("DeleteFile", mock.Anything, "participants", storageKey).Return(nil)
+	fileStorage.On("DeleteFile", mock.Anything, "participants", mock.Anything).Return(nil)
 
 	req := &participant.UploadFileRequest{
 		TenantID:      tenantID,
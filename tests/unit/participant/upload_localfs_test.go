@@ -0,0 +1,151 @@
+package participant_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/impl/localfs"
+	"erp-service/pkg/envelope"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// The upload flows against the filesystem backend instead of a storage mock.
+
+func newLocalStorage(t *testing.T) (participant.FileStorageAdapter, *localfs.Server) {
+	t.Helper()
+	root := t.TempDir()
+	masterKey := make([]byte, envelope.DataKeySize)
+	_, err := rand.Read(masterKey)
+	require.NoError(t, err)
+	keys, err := envelope.NewLocalKeyProvider(masterKey)
+	require.NoError(t, err)
+	signer, err := localfs.NewSigner([]byte(strings.Repeat("s", 32)), "http://files.test")
+	require.NoError(t, err)
+	return localfs.NewFileStorage(root, signer, envelope.NewEncryptor(keys, "tenant-")), localfs.NewServer(root, signer)
+}
+
+func makeLocalUploadUsecase(storage participant.FileStorageAdapter, m *uploadSlotMocks) participant.Usecase {
+	return participant.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txManager,
+		m.participantRepo,
+		new(MockParticipantIdentityRepository),
+		new(MockParticipantAddressRepository),
+		new(MockParticipantBankAccountRepository),
+		new(MockParticipantFamilyMemberRepository),
+		new(MockParticipantEmploymentRepository),
+		new(MockParticipantPensionRepository),
+		new(MockParticipantBeneficiaryRepository),
+		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
+		storage,
+		m.fileRepo,
+		m.slotRepo,
+		nil, nil,
+		nil, nil, nil, nil, nil, nil,
+	)
+}
+
+func readDecrypted(t *testing.T, storage participant.FileStorageAdapter, file *entity.File) []byte {
+	t.Helper()
+	body, err := storage.GetDecryptedObject(context.Background(), file.Bucket, file.StorageKey, file.Envelope())
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestUploadFile_LocalStorage_StoresEncryptedObject(t *testing.T) {
+	storage, _ := newLocalStorage(t)
+	m := &uploadSlotMocks{
+		txManager:       new(MockTransactionManager),
+		participantRepo: new(MockParticipantRepository),
+		fileRepo:        new(MockFileRepository),
+		slotRepo:        new(MockUploadSlotRepository),
+	}
+	p := &entity.Participant{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New(), Status: entity.ParticipantStatusDraft}
+	data := []byte("ktp-photo-bytes")
+
+	var created *entity.File
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.File) }).
+		Return(nil)
+
+	_, err := makeLocalUploadUsecase(storage, m).UploadFile(context.Background(), &participant.UploadFileRequest{
+		TenantID:      p.TenantID,
+		ProductID:     p.ProductID,
+		ParticipantID: p.ID,
+		UploadedBy:    uuid.New(),
+		FileName:      "ktp.jpg",
+		ContentType:   "image/jpeg",
+		Reader:        bytes.NewReader(data),
+		Size:          int64(len(data)),
+		FieldName:     "ktp_photo",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	require.NotNil(t, created.Envelope())
+	assert.Equal(t, data, readDecrypted(t, storage, created))
+}
+
+func TestCompleteUpload_LocalStorage_SealsPostedObject(t *testing.T) {
+	storage, server := newLocalStorage(t)
+	ctx := context.Background()
+	m := &uploadSlotMocks{
+		txManager:       new(MockTransactionManager),
+		participantRepo: new(MockParticipantRepository),
+		fileRepo:        new(MockFileRepository),
+		slotRepo:        new(MockUploadSlotRepository),
+	}
+	p := &entity.Participant{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New(), Status: entity.ParticipantStatusDraft}
+	slot := makeUploadSlot(p.TenantID, p.ProductID, p.ID)
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 64)...)
+
+	presigned, err := storage.PresignUpload(ctx, slot.Bucket, slot.StorageKey, slot.ContentType, slot.MaxSizeBytes, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, server.Accept(slot.Bucket, presigned.FormData, slot.ContentType, bytes.NewReader(content), int64(len(content))))
+
+	var created *entity.File
+	m.slotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.slotRepo.On("ClaimCompletion", mock.Anything, slot.ID).Return(nil)
+	m.txManager.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	m.fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.File)
+			created.ID = uuid.New()
+		}).
+		Return(nil)
+	m.slotRepo.On("Complete", mock.Anything, slot.ID, mock.AnythingOfType("uuid.UUID")).Return(nil)
+
+	_, err = makeLocalUploadUsecase(storage, m).CompleteUpload(ctx, &participant.CompleteUploadRequest{
+		TenantID:      p.TenantID,
+		ProductID:     p.ProductID,
+		ParticipantID: p.ID,
+		UploadedBy:    uuid.New(),
+		UploadID:      slot.ID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, content, readDecrypted(t, storage, created))
+	_, err = storage.StatObject(ctx, slot.Bucket, slot.StorageKey)
+	assert.Error(t, err, "plaintext upload should be removed")
+}
@@ -0,0 +1,391 @@
+package participant_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/envelope"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type uploadSlotMocks struct {
+	txManager       *MockTransactionManager
+	participantRepo *MockParticipantRepository
+	fileRepo        *MockFileRepository
+	slotRepo        *MockUploadSlotRepository
+	fileStorage     *MockFileStorageAdapter
+}
+
+func makeUploadSlotUsecase() (participant.Usecase, *uploadSlotMocks) {
+	m := &uploadSlotMocks{
+		txManager:       new(MockTransactionManager),
+		participantRepo: new(MockParticipantRepository),
+		fileRepo:        new(MockFileRepository),
+		slotRepo:        new(MockUploadSlotRepository),
+		fileStorage:     new(MockFileStorageAdapter),
+	}
+
+	uc := participant.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txManager,
+		m.participantRepo,
+		new(MockParticipantIdentityRepository),
+		new(MockParticipantAddressRepository),
+		new(MockParticipantBankAccountRepository),
+		new(MockParticipantFamilyMemberRepository),
+		new(MockParticipantEmploymentRepository),
+		new(MockParticipantPensionRepository),
+		new(MockParticipantBeneficiaryRepository),
+		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
+		m.fileStorage,
+		m.fileRepo,
+		m.slotRepo,
+		nil, nil,
+		nil, nil, nil, nil, nil, nil,
+	)
+	return uc, m
+}
+
+func makeUploadSlot(tenantID, productID, participantID uuid.UUID) *entity.UploadSlot {
+	return &entity.UploadSlot{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		ProductID:     productID,
+		ParticipantID: participantID,
+		RequestedBy:   uuid.New(),
+		FieldName:     "ktp_photo",
+		Bucket:        "participants",
+		StorageKey:    "participants/t/p/pp/ktp_photo/abc-ktp.png",
+		OriginalName:  "ktp.png",
+		ContentType:   "image/png",
+		MaxSizeBytes:  1024,
+		ExpiresAt:     time.Now().Add(10 * time.Minute),
+	}
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestRequestUploadSlot(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	participantID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  entity.ParticipantStatus
+		setup   func(m *uploadSlotMocks)
+		wantErr bool
+		errKind errors.Kind
+	}{
+		{
+			name:   "success returns presigned form",
+			status: entity.ParticipantStatusDraft,
+			setup: func(m *uploadSlotMocks) {
+				m.fileStorage.On("PresignUpload", mock.Anything, "participants", mock.AnythingOfType("string"), "image/png", int64(1024), mock.Anything).
+					Return(&participant.PresignedUpload{URL: "https://storage/participants", FormData: map[string]string{"policy": "p"}}, nil)
+				m.slotRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.UploadSlot")).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*entity.UploadSlot).ID = uuid.New()
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:    "participant not editable",
+			status:  entity.ParticipantStatusPendingApproval,
+			setup:   func(m *uploadSlotMocks) {},
+			wantErr: true,
+			errKind: errors.KindBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := makeUploadSlotUsecase()
+			m.participantRepo.On("GetByID", mock.Anything, participantID).Return(&entity.Participant{
+				ID:        participantID,
+				TenantID:  tenantID,
+				ProductID: productID,
+				Status:    tt.status,
+			}, nil)
+			tt.setup(m)
+
+			resp, err := uc.RequestUploadSlot(context.Background(), &participant.RequestUploadSlotRequest{
+				TenantID:      tenantID,
+				ProductID:     productID,
+				ParticipantID: participantID,
+				UploadedBy:    uuid.New(),
+				FieldName:     "ktp_photo",
+				FileName:      "ktp.png",
+				ContentType:   "image/png",
+				Size:          1024,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				m.fileStorage.AssertNotCalled(t, "PresignUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, resp.UploadID)
+			assert.Equal(t, "POST", resp.Method)
+			assert.Equal(t, "https://storage/participants", resp.URL)
+			assert.Equal(t, int64(1024), resp.MaxSizeBytes)
+			assert.True(t, resp.ExpiresAt.After(time.Now()))
+
+			created := m.slotRepo.Calls[0].Arguments.Get(1).(*entity.UploadSlot)
+			assert.Contains(t, created.StorageKey, "ktp.png")
+			assert.Equal(t, "ktp_photo", created.FieldName)
+		})
+	}
+}
+
+func TestCompleteUpload(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	participantID := uuid.New()
+
+	draft := &entity.Participant{
+		ID:        participantID,
+		TenantID:  tenantID,
+		ProductID: productID,
+		Status:    entity.ParticipantStatusDraft,
+	}
+
+	tests := []struct {
+		name       string
+		slot       func() *entity.UploadSlot
+		setup      func(m *uploadSlotMocks, slot *entity.UploadSlot)
+		claimErr   error
+		wantErr    bool
+		errKind    errors.Kind
+		wantFileID func(slot *entity.UploadSlot) uuid.UUID
+		discarded  bool
+		released   bool
+	}{
+		{
+			name: "success creates file and completes slot",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).Return(int64(len(pngHeader)), nil)
+				m.fileStorage.On("GetObject", mock.Anything, slot.Bucket, slot.StorageKey).
+					Return(io.NopCloser(bytes.NewReader(pngHeader)), nil).Once()
+				env := &envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}
+				m.fileStorage.On("UploadEncryptedFile", mock.Anything, tenantID, slot.Bucket, mock.MatchedBy(isSealedKey(slot)),
+					mock.Anything, int64(len(pngHeader))).
+					Run(func(args mock.Arguments) {
+						sealed, err := io.ReadAll(args.Get(4).(io.Reader))
+						require.NoError(t, err)
+						assert.Equal(t, pngHeader, sealed, "the sniffed bytes must be sealed too")
+					}).
+					Return(env, nil)
+				m.fileStorage.On("DeleteFile", mock.Anything, slot.Bucket, slot.StorageKey).Return(nil)
+				m.txManager.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+				m.fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.File")).
+					Run(func(args mock.Arguments) {
+						f := args.Get(1).(*entity.File)
+						f.ID = uuid.New()
+						assert.Equal(t, "image/png", f.ContentType)
+						assert.Equal(t, int64(len(pngHeader)), f.SizeBytes)
+						assert.Equal(t, &participantID, f.ParticipantID)
+						assert.True(t, isSealedKey(slot)(f.StorageKey))
+						assert.Equal(t, env, f.Envelope())
+					}).
+					Return(nil)
+				m.slotRepo.On("Complete", mock.Anything, slot.ID, mock.AnythingOfType("uuid.UUID")).Return(nil)
+			},
+		},
+		{
+			name: "sealed copy removed when metadata insert fails",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).Return(int64(512), nil)
+				m.fileStorage.On("GetObject", mock.Anything, slot.Bucket, slot.StorageKey).
+					Return(io.NopCloser(bytes.NewReader(pngHeader)), nil).Once()
+				m.fileStorage.On("UploadEncryptedFile", mock.Anything, tenantID, slot.Bucket, mock.MatchedBy(isSealedKey(slot)),
+					mock.Anything, int64(512)).Return(&envelope.Envelope{KeyID: "tenant-key", WrappedKey: "wrapped"}, nil)
+				m.fileStorage.On("DeleteFile", mock.Anything, slot.Bucket, mock.MatchedBy(isSealedKey(slot))).Return(nil)
+				m.txManager.On("WithTransaction", mock.Anything, mock.Anything).Return(errors.ErrInternal("db down"))
+			},
+			wantErr:  true,
+			errKind:  errors.KindUnexpected,
+			released: true,
+		},
+		{
+			name: "slot already being completed",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+			},
+			claimErr: errors.ErrConflict("upload slot is already being completed or is being reaped"),
+			wantErr:  true,
+			errKind:  errors.KindDuplicate,
+		},
+		{
+			name: "already completed slot is idempotent",
+			slot: func() *entity.UploadSlot {
+				s := makeUploadSlot(tenantID, productID, participantID)
+				fileID := uuid.New()
+				now := time.Now()
+				s.FileID = &fileID
+				s.CompletedAt = &now
+				return s
+			},
+			setup:      func(m *uploadSlotMocks, slot *entity.UploadSlot) {},
+			wantFileID: func(slot *entity.UploadSlot) uuid.UUID { return *slot.FileID },
+		},
+		{
+			name:    "slot from another participant is not found",
+			slot:    func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, uuid.New()) },
+			setup:   func(m *uploadSlotMocks, slot *entity.UploadSlot) {},
+			wantErr: true,
+			errKind: errors.KindNotFound,
+		},
+		{
+			name: "expired slot",
+			slot: func() *entity.UploadSlot {
+				s := makeUploadSlot(tenantID, productID, participantID)
+				s.ExpiresAt = time.Now().Add(-time.Minute)
+				return s
+			},
+			setup:   func(m *uploadSlotMocks, slot *entity.UploadSlot) {},
+			wantErr: true,
+			errKind: errors.KindBadRequest,
+		},
+		{
+			name: "object not uploaded yet",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).
+					Return(int64(0), errors.ErrNotFound("object not found"))
+			},
+			wantErr:  true,
+			errKind:  errors.KindBadRequest,
+			released: true,
+		},
+		{
+			name: "oversized object is discarded",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).Return(int64(4096), nil)
+				m.fileStorage.On("DeleteFile", mock.Anything, slot.Bucket, slot.StorageKey).Return(nil)
+			},
+			wantErr:   true,
+			errKind:   errors.KindBadRequest,
+			discarded: true,
+			released:  true,
+		},
+		{
+			name: "disallowed content is discarded",
+			slot: func() *entity.UploadSlot { return makeUploadSlot(tenantID, productID, participantID) },
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).Return(int64(20), nil)
+				m.fileStorage.On("GetObject", mock.Anything, slot.Bucket, slot.StorageKey).
+					Return(io.NopCloser(bytes.NewReader([]byte("<html><script></script>"))), nil)
+				m.fileStorage.On("DeleteFile", mock.Anything, slot.Bucket, slot.StorageKey).Return(nil)
+			},
+			wantErr:   true,
+			errKind:   errors.KindBadRequest,
+			discarded: true,
+			released:  true,
+		},
+		{
+			name: "content not matching the declared type is discarded",
+			slot: func() *entity.UploadSlot {
+				s := makeUploadSlot(tenantID, productID, participantID)
+				s.ContentType = "application/pdf"
+				return s
+			},
+			setup: func(m *uploadSlotMocks, slot *entity.UploadSlot) {
+				m.participantRepo.On("GetByID", mock.Anything, participantID).Return(draft, nil)
+				m.fileStorage.On("StatObject", mock.Anything, slot.Bucket, slot.StorageKey).Return(int64(512), nil)
+				m.fileStorage.On("GetObject", mock.Anything, slot.Bucket, slot.StorageKey).
+					Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+				m.fileStorage.On("DeleteFile", mock.Anything, slot.Bucket, slot.StorageKey).Return(nil)
+			},
+			wantErr:   true,
+			errKind:   errors.KindBadRequest,
+			discarded: true,
+			released:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := makeUploadSlotUsecase()
+			slot := tt.slot()
+			m.slotRepo.On("GetByID", mock.Anything, slot.ID).Return(slot, nil)
+			m.slotRepo.On("ClaimCompletion", mock.Anything, slot.ID).Return(tt.claimErr).Maybe()
+			m.slotRepo.On("ReleaseCompletion", mock.Anything, slot.ID).Return(nil).Maybe()
+			tt.setup(m, slot)
+
+			resp, err := uc.CompleteUpload(context.Background(), &participant.CompleteUploadRequest{
+				TenantID:      tenantID,
+				ProductID:     productID,
+				ParticipantID: participantID,
+				UploadedBy:    uuid.New(),
+				UploadID:      slot.ID,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				var appErr *errors.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.errKind, appErr.Kind)
+				if tt.discarded {
+					m.fileStorage.AssertCalled(t, "DeleteFile", mock.Anything, slot.Bucket, slot.StorageKey)
+				}
+				if tt.released {
+					m.slotRepo.AssertCalled(t, "ReleaseCompletion", mock.Anything, slot.ID)
+				} else {
+					m.slotRepo.AssertNotCalled(t, "ReleaseCompletion", mock.Anything, mock.Anything)
+				}
+				m.fileStorage.AssertExpectations(t)
+				m.fileRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.wantFileID != nil {
+				assert.Equal(t, tt.wantFileID(slot), resp.FileID)
+				m.fileRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NotEqual(t, uuid.Nil, resp.FileID)
+			m.slotRepo.AssertCalled(t, "Complete", mock.Anything, slot.ID, resp.FileID)
+			m.fileStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func isSealedKey(slot *entity.UploadSlot) func(string) bool {
+	return func(key string) bool {
+		return strings.HasPrefix(key, slot.StorageKey+".") && strings.HasSuffix(key, ".enc") && len(key) > len(slot.StorageKey)+len(".enc")+1
+	}
+}
@@ -0,0 +1,181 @@
+package participant_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/impl/fakebank"
+	"erp-service/pkg/errors"
+	"erp-service/saving/participant"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type verifyMocks struct {
+	txMgr           *MockTransactionManager
+	participantRepo *MockParticipantRepository
+	bankAccountRepo *MockParticipantBankAccountRepository
+	verifier        *MockBankAccountVerifier
+}
+
+func makeVerifyUsecase() (participant.Usecase, *verifyMocks) {
+	m := &verifyMocks{
+		txMgr:           new(MockTransactionManager),
+		participantRepo: new(MockParticipantRepository),
+		bankAccountRepo: new(MockParticipantBankAccountRepository),
+		verifier:        new(MockBankAccountVerifier),
+	}
+	m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	uc := participant.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txMgr,
+		m.participantRepo,
+		new(MockParticipantIdentityRepository),
+		new(MockParticipantAddressRepository),
+		m.bankAccountRepo,
+		new(MockParticipantFamilyMemberRepository),
+		new(MockParticipantEmploymentRepository),
+		new(MockParticipantPensionRepository),
+		new(MockParticipantBeneficiaryRepository),
+		new(MockParticipantStatusHistoryRepository),
+		newNoDuplicatesRepo(),
+		new(MockFileStorageAdapter),
+		new(MockFileRepository),
+		new(MockUploadSlotRepository),
+		m.verifier,
+		nil,
+		nil, nil, nil, nil, nil, nil,
+	)
+	return uc, m
+}
+
+func setupVerify(m *verifyMocks, holderName string) (*entity.Participant, *entity.ParticipantBankAccount) {
+	tenantID, productID := uuid.New(), uuid.New()
+	p := createMockParticipant(entity.ParticipantStatusPendingApproval, tenantID, productID, uuid.New())
+	p.FullName = "Budi Santoso"
+	account := &entity.ParticipantBankAccount{
+		ID:                 uuid.New(),
+		ParticipantID:      p.ID,
+		BankCode:           "014",
+		AccountNumber:      "1234567890",
+		AccountHolderName:  holderName,
+		IsPrimary:          true,
+		VerificationStatus: entity.BankAccountVerificationUnverified,
+		Version:            1,
+	}
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.bankAccountRepo.On("GetByID", mock.Anything, account.ID).Return(account, nil)
+	m.bankAccountRepo.On("Update", mock.Anything, account).Return(nil)
+	return p, account
+}
+
+func verifyRequest(p *entity.Participant, account *entity.ParticipantBankAccount) *participant.VerifyBankAccountRequest {
+	return &participant.VerifyBankAccountRequest{
+		AccountID:     account.ID,
+		ParticipantID: p.ID,
+		TenantID:      p.TenantID,
+		ProductID:     p.ProductID,
+	}
+}
+
+func TestVerifyBankAccount_Verified(t *testing.T) {
+	uc, m := makeVerifyUsecase()
+	p, account := setupVerify(m, "Budi Santoso")
+	m.verifier.On("Inquire", mock.Anything, participant.BankAccountInquiry{
+		BankCode: "014", AccountNumber: "1234567890", HolderName: "Budi Santoso",
+	}).Return(&participant.BankAccountInquiryResult{HolderName: "BUDI SANTOSO"}, nil)
+
+	resp, err := uc.VerifyBankAccount(context.Background(), verifyRequest(p, account))
+
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BankAccountVerificationVerified), resp.VerificationStatus)
+	assert.Equal(t, "BUDI SANTOSO", *resp.VerifiedHolderName)
+	assert.Equal(t, "mock", *resp.VerificationProvider)
+	assert.NotNil(t, resp.VerificationCheckedAt)
+	assert.True(t, account.IsVerified())
+}
+
+func TestVerifyBankAccount_HolderIsNotParticipant_Mismatch(t *testing.T) {
+	uc, m := makeVerifyUsecase()
+	p, account := setupVerify(m, "Siti Rahayu")
+	m.verifier.On("Inquire", mock.Anything, mock.Anything).
+		Return(&participant.BankAccountInquiryResult{HolderName: "SITI RAHAYU"}, nil)
+
+	resp, err := uc.VerifyBankAccount(context.Background(), verifyRequest(p, account))
+
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BankAccountVerificationMismatch), resp.VerificationStatus)
+	assert.Less(t, *resp.VerificationScore, 0.85)
+}
+
+func TestVerifyBankAccount_NotFound(t *testing.T) {
+	uc, m := makeVerifyUsecase()
+	p, account := setupVerify(m, "Budi Santoso")
+	m.verifier.On("Inquire", mock.Anything, mock.Anything).Return(nil, participant.ErrBankAccountNotFound)
+
+	resp, err := uc.VerifyBankAccount(context.Background(), verifyRequest(p, account))
+
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BankAccountVerificationNotFound), resp.VerificationStatus)
+	assert.Nil(t, resp.VerifiedHolderName)
+}
+
+func TestVerifyBankAccount_ProviderError_Recorded(t *testing.T) {
+	uc, m := makeVerifyUsecase()
+	p, account := setupVerify(m, "Budi Santoso")
+	m.verifier.On("Inquire", mock.Anything, mock.Anything).Return(nil, stderrors.New("connection reset"))
+
+	resp, err := uc.VerifyBankAccount(context.Background(), verifyRequest(p, account))
+
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BankAccountVerificationProviderError), resp.VerificationStatus)
+	assert.Equal(t, "connection reset", *resp.VerificationMessage)
+}
+
+func TestVerifyBankAccount_OtherParticipantsAccount_Forbidden(t *testing.T) {
+	uc, m := makeVerifyUsecase()
+	p, account := setupVerify(m, "Budi Santoso")
+	account.ParticipantID = uuid.New()
+
+	_, err := uc.VerifyBankAccount(context.Background(), verifyRequest(p, account))
+
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errors.KindForbidden, appErr.Kind)
+	m.verifier.AssertNotCalled(t, "Inquire", mock.Anything, mock.Anything)
+}
+
+func TestMatchBankHolderName(t *testing.T) {
+	assert.GreaterOrEqual(t, participant.MatchBankHolderName("SANTOSO BUDI", "Budi Santoso", "Ir. Budi Santoso"), 0.85)
+	assert.Less(t, participant.MatchBankHolderName("BUDI SANTOSO", "Budi Santoso", "Siti Rahayu"), 0.85)
+	assert.Equal(t, 0.0, participant.MatchBankHolderName("", "Budi Santoso", "Budi Santoso"))
+}
+
+func TestFakeBankVerifier(t *testing.T) {
+	v := fakebank.NewVerifier()
+	ctx := context.Background()
+
+	res, err := v.Inquire(ctx, participant.BankAccountInquiry{AccountNumber: "1234567890", HolderName: " Budi Santoso "})
+	require.NoError(t, err)
+	assert.Equal(t, "BUDI SANTOSO", res.HolderName)
+
+	_, err = v.Inquire(ctx, participant.BankAccountInquiry{AccountNumber: "123450000"})
+	assert.ErrorIs(t, err, participant.ErrBankAccountNotFound)
+
+	_, err = v.Inquire(ctx, participant.BankAccountInquiry{AccountNumber: "123459999"})
+	assert.ErrorIs(t, err, fakebank.ErrUnavailable)
+
+	res, err = v.Inquire(ctx, participant.BankAccountInquiry{AccountNumber: "123458888", HolderName: "Budi"})
+	require.NoError(t, err)
+	assert.Equal(t, fakebank.MismatchHolderName, res.HolderName)
+}
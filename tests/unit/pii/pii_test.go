@@ -0,0 +1,212 @@
+package pii_test
+
+import (
+	"context"
+	"crypto/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/pii"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func newCipher(t *testing.T) pii.Cipher {
+	t.Helper()
+	key := make([]byte, pii.LocalKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	c, err := pii.NewLocalCipher(key)
+	require.NoError(t, err)
+	return c
+}
+
+func register(t *testing.T) pii.Cipher {
+	t.Helper()
+	c := newCipher(t)
+	pii.Register(c)
+	t.Cleanup(func() { pii.Register(nil) })
+	return c
+}
+
+func TestNewLocalCipher_RejectsWrongKeySize(t *testing.T) {
+	_, err := pii.NewLocalCipher(make([]byte, 16))
+	assert.Error(t, err)
+}
+
+func TestLocalCipher_RoundTrip(t *testing.T) {
+	c := newCipher(t)
+	ctx := context.Background()
+
+	first, err := c.Encrypt(ctx, "ktp_number", []byte("3201010101010001"))
+	require.NoError(t, err)
+	second, err := c.Encrypt(ctx, "ktp_number", []byte("3201010101010001"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second, "ciphertext must not be deterministic")
+	assert.NotContains(t, first, "3201010101010001")
+
+	plaintext, err := c.Decrypt(ctx, "ktp_number", first)
+	require.NoError(t, err)
+	assert.Equal(t, "3201010101010001", string(plaintext))
+}
+
+func TestLocalCipher_CiphertextIsBoundToColumn(t *testing.T) {
+	c := newCipher(t)
+	ctx := context.Background()
+
+	ciphertext, err := c.Encrypt(ctx, "ktp_number", []byte("3201010101010001"))
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(ctx, "account_number", ciphertext)
+	assert.ErrorIs(t, err, pii.ErrInvalidCiphertext)
+}
+
+func TestLocalCipher_RejectsTamperedCiphertext(t *testing.T) {
+	c := newCipher(t)
+	ctx := context.Background()
+
+	_, err := c.Decrypt(ctx, "ktp_number", "local:v1:not-base64!")
+	assert.ErrorIs(t, err, pii.ErrInvalidCiphertext)
+	_, err = c.Decrypt(ctx, "ktp_number", "vault:v1:abc")
+	assert.ErrorIs(t, err, pii.ErrInvalidCiphertext)
+}
+
+func TestBlindIndex_NormalizesAndIsKeyed(t *testing.T) {
+	register(t)
+	ctx := context.Background()
+
+	plain, err := pii.BlindIndex(ctx, "3201010101010001")
+	require.NoError(t, err)
+	formatted, err := pii.BlindIndex(ctx, " 3201-0101-0101-0001 ")
+	require.NoError(t, err)
+	assert.Equal(t, plain, formatted)
+	assert.NotContains(t, plain, "3201")
+
+	other, err := pii.BlindIndex(ctx, "3201010101010002")
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, other)
+
+	pii.Register(newCipher(t))
+	rekeyed, err := pii.BlindIndex(ctx, "3201010101010001")
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, rekeyed)
+}
+
+func TestBlindIndex_EmptyAfterNormalization(t *testing.T) {
+	register(t)
+
+	index, err := pii.BlindIndex(context.Background(), " - ")
+	require.NoError(t, err)
+	assert.Empty(t, index)
+
+	ptr, err := pii.BlindIndexPtr(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, ptr)
+}
+
+func TestBlindIndexes_DropsEmptyAndDuplicates(t *testing.T) {
+	register(t)
+
+	indexes, err := pii.BlindIndexes(context.Background(), []string{"12-34", "1234", "", "5678"})
+	require.NoError(t, err)
+	assert.Len(t, indexes, 2)
+}
+
+func TestSealOpen(t *testing.T) {
+	register(t)
+	ctx := context.Background()
+
+	sealed, err := pii.Seal(ctx, "account_number", "1234567890")
+	require.NoError(t, err)
+	assert.True(t, pii.IsSealed(sealed))
+
+	opened, err := pii.Open(ctx, "account_number", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", opened)
+
+	empty, err := pii.Seal(ctx, "account_number", "")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestOpen_LegacyPlaintextPassesThrough(t *testing.T) {
+	register(t)
+
+	opened, err := pii.Open(context.Background(), "ktp_number", "3201010101010001")
+	require.NoError(t, err)
+	assert.Equal(t, "3201010101010001", opened)
+}
+
+func TestSeal_WithoutCipher_Fails(t *testing.T) {
+	pii.Register(nil)
+
+	_, err := pii.Seal(context.Background(), "ktp_number", "3201010101010001")
+	assert.ErrorIs(t, err, pii.ErrNotConfigured)
+}
+
+func parseField(t *testing.T, model interface{}, dbName string) *schema.Field {
+	t.Helper()
+	s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+	field := s.LookUpField(dbName)
+	require.NotNil(t, field)
+	require.NotNil(t, field.Serializer, "%s must use the pii serializer", dbName)
+	return field
+}
+
+func TestSerializer_NullableField(t *testing.T) {
+	register(t)
+	ctx := context.Background()
+	field := parseField(t, &entity.Participant{}, "ktp_number")
+
+	ktp := "3201010101010001"
+	p := &entity.Participant{KTPNumber: &ktp}
+	stored, err := pii.Serializer{}.Value(ctx, field, reflect.ValueOf(p), p.KTPNumber)
+	require.NoError(t, err)
+	require.IsType(t, "", stored)
+	assert.True(t, pii.IsSealed(stored.(string)))
+
+	var loaded entity.Participant
+	require.NoError(t, pii.Serializer{}.Scan(ctx, field, reflect.ValueOf(&loaded), stored))
+	require.NotNil(t, loaded.KTPNumber)
+	assert.Equal(t, ktp, *loaded.KTPNumber)
+
+	stored, err = pii.Serializer{}.Value(ctx, field, reflect.ValueOf(p), (*string)(nil))
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	loaded = entity.Participant{KTPNumber: &ktp}
+	require.NoError(t, pii.Serializer{}.Scan(ctx, field, reflect.ValueOf(&loaded), nil))
+	assert.Nil(t, loaded.KTPNumber)
+}
+
+func TestSerializer_StringField(t *testing.T) {
+	register(t)
+	ctx := context.Background()
+	field := parseField(t, &entity.ParticipantBankAccount{}, "account_number")
+
+	account := &entity.ParticipantBankAccount{AccountNumber: "1234567890"}
+	stored, err := pii.Serializer{}.Value(ctx, field, reflect.ValueOf(account), account.AccountNumber)
+	require.NoError(t, err)
+	assert.True(t, pii.IsSealed(stored.(string)))
+
+	var loaded entity.ParticipantBankAccount
+	require.NoError(t, pii.Serializer{}.Scan(ctx, field, reflect.ValueOf(&loaded), []byte(stored.(string))))
+	assert.Equal(t, "1234567890", loaded.AccountNumber)
+
+	var legacy entity.ParticipantBankAccount
+	require.NoError(t, pii.Serializer{}.Scan(ctx, field, reflect.ValueOf(&legacy), "1234567890"))
+	assert.Equal(t, "1234567890", legacy.AccountNumber)
+}
+
+func TestEntities_EncryptDesignatedColumns(t *testing.T) {
+	parseField(t, &entity.Participant{}, "ktp_number")
+	parseField(t, &entity.ParticipantIdentity{}, "identity_number")
+	parseField(t, &entity.ParticipantBankAccount{}, "account_number")
+	parseField(t, &entity.ParticipantBeneficiary{}, "account_number")
+	parseField(t, &entity.PaymentInstruction{}, "account_number")
+}
@@ -0,0 +1,62 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	implpg "erp-service/impl/postgres"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileRepository_ClaimDueForPurge_SkipsMergedParticipants(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewFileRepository(tenants)
+
+	mock.ExpectQuery(`WHEN 'PARTICIPANT_DELETED' THEN\s+CASE WHEN p\.merged_into_id IS NULL THEN p\.deleted_at END`).
+		WithArgs(50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	files, err := repo.ClaimDueForPurge(context.Background(), 50)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepository_ClaimPendingScan_SkipsExhaustedFiles(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewFileRepository(tenants)
+
+	mock.ExpectQuery(`AND scan_attempts < \$1\s+ORDER BY created_at ASC\s+LIMIT \$2`).
+		WithArgs(10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	files, err := repo.ClaimPendingScan(context.Background(), 20, 10)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepository_RecordScanFailure_ParksFileAtLimit(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewFileRepository(tenants)
+	id := uuid.New()
+
+	mock.ExpectExec(`SET scan_attempts = scan_attempts \+ 1,\s+scan_claimed_at = NULL,\s+scan_status = CASE WHEN scan_attempts \+ 1 >= \$1 THEN 'SCAN_FAILED' ELSE scan_status END`).
+		WithArgs(10, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.RecordScanFailure(context.Background(), id, 10))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -113,7 +113,7 @@ func TestMasterdataItemRepository_GetByCode(t *testing.T) {
 			nil, 1, now, now, nil,
 		)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "masterdata_items" WHERE (category_id = $1 AND code = $2 AND deleted_at IS NULL) AND ((tenant_id IS NULL OR tenant_id = $3)) ORDER BY "masterdata_items"."id" LIMIT $4`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "masterdata_items" WHERE (category_id = $1 AND code = $2 AND deleted_at IS NULL) AND ((tenant_id IS NULL OR tenant_id = $3)) ORDER BY tenant_id IS NULL,"masterdata_items"."id" LIMIT $4`)).
 			WithArgs(categoryID, "DEPT_IT", tenantID, 1).
 			WillReturnRows(rows)
 
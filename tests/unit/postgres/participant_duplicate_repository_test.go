@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	implpg "erp-service/impl/postgres"
+	"erp-service/pkg/tenantdb"
+	"erp-service/saving/participant"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParticipantDuplicateRepository_ReassignChildRecords_MovesFiles(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewParticipantDuplicateRepository(tenants)
+
+	sourceID, targetID := uuid.New(), uuid.New()
+
+	for _, stmt := range []string{
+		"UPDATE participant_identities SET participant_id",
+		"UPDATE participant_addresses SET participant_id",
+		"UPDATE participant_bank_accounts SET is_primary = false",
+		"UPDATE participant_bank_accounts SET participant_id",
+		"UPDATE participant_family_members SET participant_id",
+		"UPDATE participant_beneficiaries SET participant_id",
+		"UPDATE participant_employments SET participant_id",
+		"UPDATE participant_pensions SET participant_id",
+		"UPDATE participant_status_history SET participant_id",
+	} {
+		mock.ExpectExec(regexp.QuoteMeta(stmt)).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE files SET participant_id = $1 WHERE participant_id = $2`)).
+		WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	require.NoError(t, repo.ReassignChildRecords(context.Background(), sourceID, targetID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantDuplicateRepository_FindCandidates_StrongMatchesAreNotCapped(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewParticipantDuplicateRepository(tenants)
+
+	exact, weak := uuid.New(), uuid.New()
+
+	// Strong identifiers first, without LIMIT.
+	mock.ExpectQuery(`ktp_number_bidx IN .* ORDER BY p\.created_at DESC$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(exact))
+	// Broad keys fill up to the limit and skip what already matched strongly.
+	mock.ExpectQuery(`p\.full_name ILIKE .* AND \(NOT COALESCE\(.*ktp_number_bidx IN .* ORDER BY p\.created_at DESC LIMIT \$\d+$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(weak))
+
+	found, err := repo.FindCandidates(context.Background(), &participant.DuplicateSearchCriteria{
+		ExcludeParticipantID: uuid.New(),
+		IdentityNumbers:      []string{"3201010101010001"},
+		NamePrefix:           "MUHA",
+		Limit:                20,
+	})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, exact, found[0].ID)
+	assert.Equal(t, weak, found[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,185 @@
+package postgres_test
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	implpg "erp-service/impl/postgres"
+	"erp-service/pkg/pii"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func registerPIICipher(t *testing.T) {
+	t.Helper()
+	key := make([]byte, pii.LocalKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	c, err := pii.NewLocalCipher(key)
+	require.NoError(t, err)
+	pii.Register(c)
+	t.Cleanup(func() { pii.Register(nil) })
+}
+
+// sealedArg matches a sealed column value that opens to plaintext.
+type sealedArg struct {
+	column    string
+	plaintext string
+}
+
+func (a sealedArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok || !pii.IsSealed(s) {
+		return false
+	}
+	opened, err := pii.Open(context.Background(), a.column, s)
+	return err == nil && opened == a.plaintext
+}
+
+const getByKTPQuery = `SELECT * FROM "participants" WHERE (tenant_id = $1 AND product_id = $2 AND deleted_at IS NULL) AND ((ktp_number_bidx IN ($3) OR (ktp_number_bidx IS NULL AND upper(regexp_replace(ktp_number, '[^[:alnum:]]', '', 'g')) IN ($4)))) ORDER BY "participants"."id" LIMIT $5`
+
+func TestParticipantRepository_GetByKTPNumber_QueriesBlindIndex(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewParticipantRepository(tenants)
+	ctx := context.Background()
+
+	tenantID, productID, participantID := uuid.New(), uuid.New(), uuid.New()
+	ktp := "3201010101010001"
+	index, err := pii.BlindIndex(ctx, ktp)
+	require.NoError(t, err)
+	sealed, err := pii.Seal(ctx, "ktp_number", ktp)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getByKTPQuery)).
+		WithArgs(tenantID, productID, index, ktp, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ktp_number", "ktp_number_bidx"}).AddRow(participantID, sealed, index))
+
+	participant, err := repo.GetByKTPNumber(ctx, tenantID, productID, "3201-0101-0101-0001")
+	require.NoError(t, err)
+	require.NotNil(t, participant.KTPNumber)
+	assert.Equal(t, ktp, *participant.KTPNumber)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestParticipantRepository_GetByKTPNumber_MatchesUnsealedRows(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewParticipantRepository(tenants)
+	ctx := context.Background()
+
+	tenantID, productID, participantID := uuid.New(), uuid.New(), uuid.New()
+	ktp := "3201010101010001"
+	index, err := pii.BlindIndex(ctx, ktp)
+	require.NoError(t, err)
+
+	// The backfill has not reached this row: plaintext, no blind index.
+	mock.ExpectQuery(regexp.QuoteMeta(getByKTPQuery)).
+		WithArgs(tenantID, productID, index, ktp, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ktp_number", "ktp_number_bidx"}).AddRow(participantID, "3201 0101 0101 0001", nil))
+
+	participant, err := repo.GetByKTPNumber(ctx, tenantID, productID, ktp)
+	require.NoError(t, err)
+	assert.Equal(t, participantID, participant.ID)
+	require.NotNil(t, participant.KTPNumber)
+	assert.Equal(t, "3201 0101 0101 0001", *participant.KTPNumber)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPIIBackfillRepository_SealsPlaintextRows(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewPIIBackfillRepository(tenants)
+	ctx := context.Background()
+
+	participantID := uuid.New().String()
+	ktp := "3201010101010001"
+	index, err := pii.BlindIndex(ctx, ktp)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT id::text AS id, ktp_number AS value\s+FROM participants`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value"}).AddRow(participantID, ktp))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE participants SET ktp_number = $1, ktp_number_bidx = NULLIF($2, '') WHERE id = $3::uuid AND ktp_number = $4`)).
+		WithArgs(sealedArg{column: "ktp_number", plaintext: ktp}, index, participantID, ktp).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"participant_identities", "participant_bank_accounts", "participant_beneficiaries", "claim_payment_instructions"} {
+		mock.ExpectQuery(`FROM ` + table).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	}
+
+	result, err := repo.BackfillBatch(ctx, pii.BackfillCursor{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Sealed)
+	assert.Empty(t, result.Failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPIIBackfillRepository_ReportsRowFailures(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewPIIBackfillRepository(tenants)
+
+	accountID := uuid.New().String()
+
+	mock.ExpectQuery(`FROM participants`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM participant_identities`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM participant_bank_accounts`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value"}).AddRow(accountID, "1234567890"))
+	mock.ExpectExec(`UPDATE participant_bank_accounts`).WillReturnError(assert.AnError)
+	mock.ExpectQuery(`FROM participant_beneficiaries`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM claim_payment_instructions`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+
+	cursor := pii.BackfillCursor{}
+	result, err := repo.BackfillBatch(context.Background(), cursor, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Sealed)
+	assert.Equal(t, 1, result.Visited)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, "participant_bank_accounts", result.Failures[0].Table)
+	assert.Equal(t, accountID, result.Failures[0].ID)
+	assert.Equal(t, accountID, cursor["participant_bank_accounts.account_number"], "the cursor moves past failed rows")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPIIBackfillRepository_ResumesAfterCursor(t *testing.T) {
+	registerPIICipher(t)
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewPIIBackfillRepository(tenants)
+
+	failedID := uuid.New().String()
+
+	mock.ExpectQuery(`FROM participants\s+WHERE .* ORDER BY id`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM participant_identities`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM participant_bank_accounts\s+WHERE .* AND id > \$1::uuid\s+ORDER BY id\s+LIMIT \$2`).
+		WithArgs(failedID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM participant_beneficiaries`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+	mock.ExpectQuery(`FROM claim_payment_instructions`).WillReturnRows(sqlmock.NewRows([]string{"id", "value"}))
+
+	result, err := repo.BackfillBatch(context.Background(), pii.BackfillCursor{"participant_bank_accounts.account_number": failedID}, 10)
+	require.NoError(t, err)
+	assert.Zero(t, result.Visited)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	implpg "erp-service/impl/postgres"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var placementQuery = regexp.QuoteMeta(`SELECT * FROM "tenant_databases" WHERE tenant_id = $1 LIMIT $2`)
+
+// setupTenantRouter routes tenantID to a dedicated mock database in the
+// given status.
+func setupTenantRouter(t *testing.T, tenantID uuid.UUID, status string) (*tenantdb.Router, sqlmock.Sqlmock, sqlmock.Sqlmock) {
+	platformDB, platformMock := setupMockDB(t)
+	dedicatedDB, dedicatedMock := setupMockDB(t)
+
+	platformMock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "database_name", "status"}).AddRow(tenantID, "tenant_acme", status))
+
+	open := func(string) (*gorm.DB, func() error, error) {
+		return dedicatedDB, func() error { return nil }, nil
+	}
+	tenants := tenantdb.NewRouter(platformDB, open, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	return tenants, platformMock, dedicatedMock
+}
+
+func TestTenantRouting_RepositoryUsesDedicatedDatabase(t *testing.T) {
+	tenantID, participantID := uuid.New(), uuid.New()
+	tenants, platformMock, dedicatedMock := setupTenantRouter(t, tenantID, "ACTIVE")
+	repo := implpg.NewParticipantRepository(tenants)
+	ctx := tenantdb.WithTenant(context.Background(), tenantID)
+
+	dedicatedMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "participants" WHERE id = $1 AND deleted_at IS NULL ORDER BY "participants"."id" LIMIT $2`)).
+		WithArgs(participantID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id"}).AddRow(participantID, tenantID))
+
+	participant, err := repo.GetByID(ctx, participantID)
+	require.NoError(t, err)
+	assert.Equal(t, participantID, participant.ID)
+	assert.NoError(t, platformMock.ExpectationsWereMet())
+	assert.NoError(t, dedicatedMock.ExpectationsWereMet())
+}
+
+func TestTenantRouting_MovingTenantIsUnavailable(t *testing.T) {
+	tenantID := uuid.New()
+	tenants, platformMock, dedicatedMock := setupTenantRouter(t, tenantID, "MOVING")
+	repo := implpg.NewParticipantRepository(tenants)
+	ctx := tenantdb.WithTenant(context.Background(), tenantID)
+
+	_, err := repo.GetByID(ctx, uuid.New())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, apperrors.GetHTTPStatus(err))
+	assert.NoError(t, platformMock.ExpectationsWereMet())
+	assert.NoError(t, dedicatedMock.ExpectationsWereMet())
+}
+
+func TestTenantRouting_TransactionSpansBothDatabases(t *testing.T) {
+	tenantID, participantID, categoryID := uuid.New(), uuid.New(), uuid.New()
+	tenants, platformMock, dedicatedMock := setupTenantRouter(t, tenantID, "ACTIVE")
+	participants := implpg.NewParticipantRepository(tenants)
+	categories := implpg.NewMasterdataCategoryRepository(tenants.Platform())
+	txManager := implpg.NewTenantTransactionManager(tenants)
+	ctx := tenantdb.WithTenant(context.Background(), tenantID)
+
+	platformMock.ExpectBegin()
+	platformMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "masterdata_categories" WHERE id = $1 AND deleted_at IS NULL ORDER BY "masterdata_categories"."id" LIMIT $2`)).
+		WithArgs(categoryID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(categoryID))
+	platformMock.ExpectCommit()
+
+	dedicatedMock.ExpectBegin()
+	dedicatedMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "participants" WHERE id = $1 AND deleted_at IS NULL ORDER BY "participants"."id" LIMIT $2`)).
+		WithArgs(participantID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(participantID))
+	dedicatedMock.ExpectCommit()
+
+	err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := categories.GetByID(ctx, categoryID); err != nil {
+			return err
+		}
+		_, err := participants.GetByID(ctx, participantID)
+		return err
+	})
+	require.NoError(t, err)
+	assert.NoError(t, platformMock.ExpectationsWereMet())
+	assert.NoError(t, dedicatedMock.ExpectationsWereMet())
+}
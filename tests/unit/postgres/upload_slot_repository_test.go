@@ -0,0 +1,52 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	implpg "erp-service/impl/postgres"
+	"erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUploadSlotRepository_ClaimCompletion_ConflictsWhenHeld(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewUploadSlotRepository(tenants)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "upload_slots" SET "completing_at"=\$1,"updated_at"=\$2 WHERE \(id = \$3 AND completed_at IS NULL AND completing_at IS NULL AND claimed_at IS NULL AND deleted_at IS NULL\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.ClaimCompletion(context.Background(), id)
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errors.KindDuplicate, appErr.Kind)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUploadSlotRepository_ClaimExpired_SkipsSlotsBeingCompleted(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+	tenants := tenantdb.NewRouter(gormDB, nil, tenantdb.Options{}, zap.NewNop())
+	t.Cleanup(func() { tenants.Close() })
+	repo := implpg.NewUploadSlotRepository(tenants)
+
+	mock.ExpectQuery(`AND claimed_at IS NULL\s+AND completing_at IS NULL`).
+		WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	slots, err := repo.ClaimExpired(context.Background(), 20)
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,118 @@
+package projection_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"erp-service/saving/projection"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRetirementDate(t *testing.T) {
+	tests := []struct {
+		name string
+		dob  time.Time
+		age  int
+		rule string
+		want time.Time
+	}{
+		{"birthday", date(1970, time.May, 17), 56, projection.RetirementDateRuleBirthday, date(2026, time.May, 17)},
+		{"end of month", date(1970, time.May, 17), 56, projection.RetirementDateRuleEndOfMonth, date(2026, time.May, 31)},
+		{"first of next month", date(1970, time.May, 17), 56, projection.RetirementDateRuleFirstOfNextMonth, date(2026, time.June, 1)},
+		{"december rolls into next year", date(1970, time.December, 5), 56, projection.RetirementDateRuleFirstOfNextMonth, date(2027, time.January, 1)},
+		{"leap day in non-leap year", date(1972, time.February, 29), 55, projection.RetirementDateRuleBirthday, date(2027, time.March, 1)},
+		{"leap day end of month", date(1972, time.February, 29), 56, projection.RetirementDateRuleEndOfMonth, date(2028, time.February, 29)},
+		{"leap day end of month in non-leap year", date(1972, time.February, 29), 55, projection.RetirementDateRuleEndOfMonth, date(2027, time.February, 28)},
+		{"leap day first of next month in non-leap year", date(1972, time.February, 29), 55, projection.RetirementDateRuleFirstOfNextMonth, date(2027, time.March, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, projection.RetirementDate(tt.dob, tt.age, tt.rule))
+		})
+	}
+}
+
+func TestMonthsUntil(t *testing.T) {
+	assert.Equal(t, 11, projection.MonthsUntil(date(2026, time.January, 15), date(2027, time.January, 1)))
+	assert.Equal(t, 0, projection.MonthsUntil(date(2026, time.January, 15), date(2026, time.February, 1)))
+	assert.Equal(t, 0, projection.MonthsUntil(date(2026, time.March, 15), date(2026, time.February, 1)))
+}
+
+func TestProject_ZeroReturnSumsContributions(t *testing.T) {
+	in := projection.ProjectionInput{
+		AsOf:                date(2026, time.October, 19),
+		RetirementDate:      date(2029, time.January, 1),
+		OpeningBalance:      1_000_000,
+		MonthlyContribution: 100_000,
+		PayoutYears:         10,
+	}
+
+	result := projection.Project(in, projection.Scenario{Name: "flat"})
+
+	assert.Equal(t, int64(2_600_000), result.TotalContributions)
+	assert.Equal(t, int64(0), result.TotalReturn)
+	assert.Equal(t, int64(3_600_000), result.ProjectedBalance)
+	require.Len(t, result.Years, 3)
+	assert.Equal(t, 2026, result.Years[0].Year)
+	assert.Equal(t, int64(200_000), result.Years[0].Contributions)
+	assert.Equal(t, int64(3_600_000), result.Years[2].ClosingBalance)
+	assert.Equal(t, int64(30_000), result.MonthlyBenefit)
+}
+
+func TestProject_GrowthAndReturn(t *testing.T) {
+	in := projection.ProjectionInput{
+		AsOf:                date(2026, time.December, 1),
+		RetirementDate:      date(2028, time.February, 1),
+		MonthlyContribution: 100_000,
+		PayoutYears:         15,
+		AnnuityRate:         0.05,
+	}
+
+	result := projection.Project(in, projection.Scenario{SalaryGrowthRate: 0.1, ReturnRate: 0.06})
+
+	// 12 months at 100,000 then one month at 110,000.
+	assert.Equal(t, int64(1_310_000), result.TotalContributions)
+	assert.Positive(t, result.TotalReturn)
+	assert.Equal(t, result.TotalContributions+result.TotalReturn, result.ProjectedBalance)
+	assert.Positive(t, result.MonthlyBenefit)
+}
+
+func TestMonthlyAnnuity(t *testing.T) {
+	assert.Equal(t, int64(10_000), projection.MonthlyAnnuity(1_200_000, 0, 10))
+	assert.Equal(t, int64(0), projection.MonthlyAnnuity(0, 0.05, 10))
+	assert.Equal(t, int64(0), projection.MonthlyAnnuity(1_200_000, 0.05, 0))
+
+	withInterest := projection.MonthlyAnnuity(1_200_000, 0.05, 10)
+	assert.Greater(t, withInterest, int64(10_000))
+}
+
+func TestParseRules(t *testing.T) {
+	t.Run("empty settings use defaults", func(t *testing.T) {
+		rules, err := projection.ParseRules(nil)
+		require.NoError(t, err)
+		assert.Equal(t, projection.DefaultRules(), rules)
+	})
+
+	t.Run("overrides are merged", func(t *testing.T) {
+		settings := json.RawMessage(`{"retirement":{"retirement_date_rule":"BIRTHDAY","payout_years":20}}`)
+		rules, err := projection.ParseRules(settings)
+		require.NoError(t, err)
+		assert.Equal(t, projection.RetirementDateRuleBirthday, rules.RetirementDateRule)
+		assert.Equal(t, 20, rules.PayoutYears)
+		assert.Equal(t, 0.05, rules.AnnuityRate)
+		assert.Len(t, rules.Scenarios, 3)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		_, err := projection.ParseRules(json.RawMessage(`{"retirement":{"retirement_date_rule":"WHENEVER"}}`))
+		assert.Error(t, err)
+	})
+}
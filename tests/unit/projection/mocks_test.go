@@ -0,0 +1,182 @@
+package projection_test
+
+import (
+	"context"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/masterdata"
+	"erp-service/saving/contribution"
+	"erp-service/saving/projection"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	_ projection.ParticipantRepository = (*MockParticipantRepository)(nil)
+	_ projection.EmploymentRepository  = (*MockEmploymentRepository)(nil)
+	_ projection.PensionRepository     = (*MockPensionRepository)(nil)
+	_ projection.TenantRepository      = (*MockTenantRepository)(nil)
+	_ projection.LedgerRepository      = (*MockLedgerRepository)(nil)
+	_ projection.MasterdataUsecase     = (*MockMasterdataUsecase)(nil)
+)
+
+type testMocks struct {
+	txMgr           *MockTransactionManager
+	participantRepo *MockParticipantRepository
+	employmentRepo  *MockEmploymentRepository
+	pensionRepo     *MockPensionRepository
+	tenantRepo      *MockTenantRepository
+	ledgerRepo      *MockLedgerRepository
+	masterdata      *MockMasterdataUsecase
+}
+
+func newTestMocks() *testMocks {
+	m := &testMocks{
+		txMgr:           new(MockTransactionManager),
+		participantRepo: new(MockParticipantRepository),
+		employmentRepo:  new(MockEmploymentRepository),
+		pensionRepo:     new(MockPensionRepository),
+		tenantRepo:      new(MockTenantRepository),
+		ledgerRepo:      new(MockLedgerRepository),
+		masterdata:      new(MockMasterdataUsecase),
+	}
+	m.txMgr.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+func newTestUsecase(m *testMocks) projection.Usecase {
+	return projection.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.txMgr,
+		m.participantRepo,
+		m.employmentRepo,
+		m.pensionRepo,
+		m.tenantRepo,
+		m.ledgerRepo,
+		m.masterdata,
+	)
+}
+
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if args.Get(0) == nil {
+		if fn != nil {
+			return fn(ctx)
+		}
+		return nil
+	}
+	return args.Error(0)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+type MockEmploymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmploymentRepository) Create(ctx context.Context, employment *entity.ParticipantEmployment) error {
+	args := m.Called(ctx, employment)
+	return args.Error(0)
+}
+
+func (m *MockEmploymentRepository) GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantEmployment, error) {
+	args := m.Called(ctx, participantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantEmployment), args.Error(1)
+}
+
+func (m *MockEmploymentRepository) Update(ctx context.Context, employment *entity.ParticipantEmployment) error {
+	args := m.Called(ctx, employment)
+	return args.Error(0)
+}
+
+type MockPensionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPensionRepository) Create(ctx context.Context, pension *entity.ParticipantPension) error {
+	args := m.Called(ctx, pension)
+	return args.Error(0)
+}
+
+func (m *MockPensionRepository) GetByParticipantID(ctx context.Context, participantID uuid.UUID) (*entity.ParticipantPension, error) {
+	args := m.Called(ctx, participantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantPension), args.Error(1)
+}
+
+func (m *MockPensionRepository) Update(ctx context.Context, pension *entity.ParticipantPension) error {
+	args := m.Called(ctx, pension)
+	return args.Error(0)
+}
+
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tenant), args.Error(1)
+}
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) GetBalance(ctx context.Context, participantID uuid.UUID) (int64, int64, error) {
+	args := m.Called(ctx, participantID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLedgerRepository) ListForStatement(ctx context.Context, filter *contribution.StatementFilter) ([]*entity.ContributionLedgerEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ContributionLedgerEntry), args.Error(1)
+}
+
+type MockMasterdataUsecase struct {
+	mock.Mock
+}
+
+func (m *MockMasterdataUsecase) GetItemByCode(ctx context.Context, categoryCode string, tenantID *uuid.UUID, itemCode string) (*masterdata.ItemResponse, error) {
+	args := m.Called(ctx, categoryCode, tenantID, itemCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.ItemResponse), args.Error(1)
+}
+
+func (m *MockMasterdataUsecase) GetItemDefault(ctx context.Context, categoryCode string, tenantID *uuid.UUID) (*masterdata.ItemResponse, error) {
+	args := m.Called(ctx, categoryCode, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.ItemResponse), args.Error(1)
+}
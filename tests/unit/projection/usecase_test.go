@@ -0,0 +1,242 @@
+package projection_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/masterdata"
+	"erp-service/pkg/errors"
+	"erp-service/saving/projection"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createParticipant(tenantID, productID uuid.UUID, dob *time.Time) *entity.Participant {
+	return &entity.Participant{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		ProductID:   productID,
+		FullName:    "Test Participant",
+		DateOfBirth: dob,
+		Status:      entity.ParticipantStatusActive,
+	}
+}
+
+func retirementType(code string, age int) *masterdata.ItemResponse {
+	return &masterdata.ItemResponse{
+		ID:       uuid.New(),
+		Code:     code,
+		Metadata: json.RawMessage(fmt.Sprintf(`{"normal_retirement_age":%d}`, age)),
+	}
+}
+
+func assertAppErrorKind(t *testing.T, err error, kind errors.Kind) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, kind, appErr.Kind)
+}
+
+func TestUsecase_RecalculateRetirementDate(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	dob := time.Date(1990, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("uses employment retirement type and tenant rule", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, &dob)
+		code := "RETIREMENT_TYPE_003"
+		employment := &entity.ParticipantEmployment{ParticipantID: participant.ID, RetirementTypeCode: &code}
+		pension := &entity.ParticipantPension{ParticipantID: participant.ID}
+
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+		m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&entity.Tenant{
+			ID:       tenantID,
+			Settings: json.RawMessage(`{"retirement":{"retirement_date_rule":"END_OF_MONTH"}}`),
+		}, nil)
+		m.employmentRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(employment, nil)
+		m.masterdata.On("GetItemByCode", mock.Anything, "RETIREMENT_TYPE", mock.Anything, code).Return(retirementType(code, 60), nil)
+		m.employmentRepo.On("Update", mock.Anything, employment).Return(nil)
+		m.pensionRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(pension, nil)
+		m.pensionRepo.On("Update", mock.Anything, pension).Return(nil)
+
+		result, err := newTestUsecase(m).RecalculateRetirementDate(context.Background(), &projection.RecalculateRetirementDateRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		require.NoError(t, err)
+		want := time.Date(2050, time.March, 31, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, want, result.RetirementDate)
+		assert.Equal(t, 60, result.RetirementAge)
+		assert.Equal(t, projection.RetirementDateRuleEndOfMonth, result.RetirementDateRule)
+		assert.Equal(t, want, *employment.RetirementDate)
+		assert.Equal(t, want, *pension.ProjectedRetirementDate)
+	})
+
+	t.Run("creates employment and pension from the default type", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, &dob)
+
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+		m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&entity.Tenant{ID: tenantID}, nil)
+		m.employmentRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(nil, errors.ErrNotFound("employment not found"))
+		m.masterdata.On("GetItemDefault", mock.Anything, "RETIREMENT_TYPE", mock.Anything).Return(retirementType("RETIREMENT_TYPE_001", 56), nil)
+		m.employmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ParticipantEmployment")).Return(nil)
+		m.pensionRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(nil, errors.ErrNotFound("pension not found"))
+		m.pensionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ParticipantPension")).Return(nil)
+
+		result, err := newTestUsecase(m).RecalculateRetirementDate(context.Background(), &projection.RecalculateRetirementDateRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "RETIREMENT_TYPE_001", result.RetirementTypeCode)
+		assert.Equal(t, time.Date(2046, time.April, 1, 0, 0, 0, 0, time.UTC), result.RetirementDate)
+		m.employmentRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+		m.pensionRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing date of birth", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, nil)
+
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+		m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&entity.Tenant{ID: tenantID}, nil)
+		m.employmentRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(nil, errors.ErrNotFound("employment not found"))
+
+		_, err := newTestUsecase(m).RecalculateRetirementDate(context.Background(), &projection.RecalculateRetirementDateRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		assertAppErrorKind(t, err, errors.KindBadRequest)
+	})
+
+	t.Run("unknown retirement type", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, &dob)
+		code := "NOPE"
+
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+		m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&entity.Tenant{ID: tenantID}, nil)
+		m.employmentRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(nil, errors.ErrNotFound("employment not found"))
+		m.masterdata.On("GetItemByCode", mock.Anything, "RETIREMENT_TYPE", mock.Anything, code).Return(nil, errors.ErrNotFound("item not found"))
+
+		_, err := newTestUsecase(m).RecalculateRetirementDate(context.Background(), &projection.RecalculateRetirementDateRequest{
+			TenantID:           tenantID,
+			ProductID:          productID,
+			ParticipantID:      participant.ID,
+			RetirementTypeCode: &code,
+		})
+
+		assertAppErrorKind(t, err, errors.KindBadRequest)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(uuid.New(), productID, &dob)
+
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+
+		_, err := newTestUsecase(m).RecalculateRetirementDate(context.Background(), &projection.RecalculateRetirementDateRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		assertAppErrorKind(t, err, errors.KindForbidden)
+	})
+}
+
+func TestUsecase_ProjectBenefit(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	dob := time.Now().AddDate(-50, 0, 0)
+
+	setup := func(m *testMocks, participant *entity.Participant) {
+		m.participantRepo.On("GetByID", mock.Anything, participant.ID).Return(participant, nil)
+		m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&entity.Tenant{ID: tenantID}, nil)
+		m.employmentRepo.On("GetByParticipantID", mock.Anything, participant.ID).Return(nil, errors.ErrNotFound("employment not found"))
+		m.masterdata.On("GetItemDefault", mock.Anything, "RETIREMENT_TYPE", mock.Anything).Return(retirementType("RETIREMENT_TYPE_001", 56), nil)
+		m.ledgerRepo.On("GetBalance", mock.Anything, participant.ID).Return(int64(6_000_000), int64(4_000_000), nil)
+	}
+
+	t.Run("averages net contribution history", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, &dob)
+		setup(m, participant)
+
+		thisMonth := time.Now().AddDate(0, -1, 0)
+		lastMonth := time.Now().AddDate(0, -2, 0)
+		reversedMonth := time.Now().AddDate(0, -3, 0)
+		m.ledgerRepo.On("ListForStatement", mock.Anything, mock.Anything).Return([]*entity.ContributionLedgerEntry{
+			{EntryType: entity.ContributionEntryTypeContribution, Period: thisMonth, EmployerAmount: 300_000, EmployeeAmount: 200_000},
+			{EntryType: entity.ContributionEntryTypeContribution, Period: lastMonth, EmployerAmount: 200_000, EmployeeAmount: 100_000},
+			{EntryType: entity.ContributionEntryTypeContribution, Period: reversedMonth, EmployerAmount: 200_000, EmployeeAmount: 100_000},
+			{EntryType: entity.ContributionEntryTypeReversal, Period: reversedMonth, EmployerAmount: -200_000, EmployeeAmount: -100_000},
+		}, nil)
+
+		result, err := newTestUsecase(m).ProjectBenefit(context.Background(), &projection.ProjectBenefitRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10_000_000), result.CurrentBalance)
+		assert.Equal(t, int64(400_000), result.MonthlyContribution)
+		require.Len(t, result.Scenarios, 3)
+		assert.Less(t, result.Scenarios[0].ProjectedBalance, result.Scenarios[2].ProjectedBalance)
+		assert.Equal(t, 56, result.RetirementAge)
+	})
+
+	t.Run("uses requested contribution and scenarios", func(t *testing.T) {
+		m := newTestMocks()
+		participant := createParticipant(tenantID, productID, &dob)
+		setup(m, participant)
+		monthly := int64(250_000)
+
+		result, err := newTestUsecase(m).ProjectBenefit(context.Background(), &projection.ProjectBenefitRequest{
+			TenantID:            tenantID,
+			ProductID:           productID,
+			ParticipantID:       participant.ID,
+			MonthlyContribution: &monthly,
+			Scenarios:           []projection.ScenarioRequest{{Name: "flat"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Scenarios, 1)
+		s := result.Scenarios[0]
+		assert.Equal(t, "flat", s.Name)
+		assert.Equal(t, int64(10_000_000)+monthly*int64(result.MonthsToRetirement), s.ProjectedBalance)
+		m.ledgerRepo.AssertNotCalled(t, "ListForStatement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("already retired", func(t *testing.T) {
+		m := newTestMocks()
+		old := time.Now().AddDate(-60, 0, 0)
+		participant := createParticipant(tenantID, productID, &old)
+		setup(m, participant)
+
+		_, err := newTestUsecase(m).ProjectBenefit(context.Background(), &projection.ProjectBenefitRequest{
+			TenantID:      tenantID,
+			ProductID:     productID,
+			ParticipantID: participant.ID,
+		})
+
+		assertAppErrorKind(t, err, errors.KindBadRequest)
+	})
+}
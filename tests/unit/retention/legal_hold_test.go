@@ -0,0 +1,136 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/retention"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func holdRequest(tenantID, productID, targetID uuid.UUID, hold bool, reason *string) *retention.SetLegalHoldRequest {
+	return &retention.SetLegalHoldRequest{
+		TenantID:  tenantID,
+		ProductID: productID,
+		UserID:    uuid.New(),
+		TargetID:  targetID,
+		LegalHold: hold,
+		Reason:    reason,
+	}
+}
+
+func TestSetParticipantLegalHold_Place(t *testing.T) {
+	m := newTestMocks()
+	p := &entity.Participant{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New()}
+	reason := "  claim dispute #42 "
+	req := holdRequest(p.TenantID, p.ProductID, p.ID, true, &reason)
+
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.participantRepo.On("Update", mock.Anything, p).Return(nil)
+
+	result, err := newTestUsecase(m).SetParticipantLegalHold(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.True(t, result.LegalHold)
+	assert.Equal(t, "claim dispute #42", *result.Reason)
+	assert.Equal(t, req.UserID, *result.SetBy)
+	assert.NotNil(t, result.SetAt)
+	assert.True(t, p.LegalHold)
+
+	require.Len(t, m.audit.events, 1)
+	assert.Equal(t, "legal_hold_placed", m.audit.events[0].Action)
+	assert.Equal(t, "participant", m.audit.events[0].TargetType)
+	assert.True(t, m.audit.events[0].Success)
+}
+
+func TestSetParticipantLegalHold_Lift_ClearsDetails(t *testing.T) {
+	m := newTestMocks()
+	reason, setBy := "investigation", uuid.New()
+	p := &entity.Participant{
+		ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New(),
+		LegalHold: true, LegalHoldReason: &reason, LegalHoldSetBy: &setBy,
+	}
+
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.participantRepo.On("Update", mock.Anything, p).Return(nil)
+
+	result, err := newTestUsecase(m).SetParticipantLegalHold(context.Background(),
+		holdRequest(p.TenantID, p.ProductID, p.ID, false, nil))
+
+	require.NoError(t, err)
+	assert.False(t, result.LegalHold)
+	assert.Nil(t, p.LegalHoldReason)
+	assert.Nil(t, p.LegalHoldSetBy)
+	assert.Equal(t, "legal_hold_lifted", m.audit.events[0].Action)
+}
+
+func TestSetParticipantLegalHold_RequiresReason(t *testing.T) {
+	m := newTestMocks()
+	blank := "   "
+
+	_, err := newTestUsecase(m).SetParticipantLegalHold(context.Background(),
+		holdRequest(uuid.New(), uuid.New(), uuid.New(), true, &blank))
+
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errors.KindBadRequest, appErr.Kind)
+	m.participantRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestSetParticipantLegalHold_OtherProduct_NotFound(t *testing.T) {
+	m := newTestMocks()
+	p := &entity.Participant{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New()}
+	reason := "audit"
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+
+	_, err := newTestUsecase(m).SetParticipantLegalHold(context.Background(),
+		holdRequest(p.TenantID, uuid.New(), p.ID, true, &reason))
+
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+	m.participantRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	require.Len(t, m.audit.events, 1)
+	assert.False(t, m.audit.events[0].Success)
+}
+
+func TestSetFileLegalHold_Place(t *testing.T) {
+	m := newTestMocks()
+	file := &entity.File{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New()}
+	reason := "court order"
+	req := holdRequest(file.TenantID, file.ProductID, file.ID, true, &reason)
+	held := *file
+	held.LegalHold = true
+	held.LegalHoldReason = &reason
+
+	m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(file, nil).Once()
+	m.fileRepo.On("SetLegalHold", mock.Anything, file.ID, true, &reason, req.UserID).Return(nil)
+	m.fileRepo.On("GetByID", mock.Anything, file.ID).Return(&held, nil).Once()
+
+	result, err := newTestUsecase(m).SetFileLegalHold(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.True(t, result.LegalHold)
+	assert.Equal(t, "file", result.TargetType)
+	m.fileRepo.AssertExpectations(t)
+}
+
+func TestSetFileLegalHold_RejectsDerivedFile(t *testing.T) {
+	m := newTestMocks()
+	originalID := uuid.New()
+	thumb := &entity.File{ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New(), DerivedFromFileID: &originalID}
+	reason := "court order"
+	m.fileRepo.On("GetByID", mock.Anything, thumb.ID).Return(thumb, nil)
+
+	_, err := newTestUsecase(m).SetFileLegalHold(context.Background(),
+		holdRequest(thumb.TenantID, thumb.ProductID, thumb.ID, true, &reason))
+
+	require.Error(t, err)
+	m.fileRepo.AssertNotCalled(t, "SetLegalHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
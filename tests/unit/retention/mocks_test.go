@@ -0,0 +1,157 @@
+package retention_test
+
+import (
+	"context"
+	"sync"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/logger"
+	"erp-service/saving/retention"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	_ retention.PolicyRepository      = (*MockPolicyRepository)(nil)
+	_ retention.ParticipantRepository = (*MockParticipantRepository)(nil)
+	_ retention.FileRepository        = (*MockFileRepository)(nil)
+	_ retention.TransactionManager    = (*MockTransactionManager)(nil)
+	_ logger.AuditLogger              = (*recordingAuditLogger)(nil)
+)
+
+type testMocks struct {
+	policyRepo      *MockPolicyRepository
+	participantRepo *MockParticipantRepository
+	fileRepo        *MockFileRepository
+	txManager       *MockTransactionManager
+	audit           *recordingAuditLogger
+}
+
+func newTestMocks() *testMocks {
+	m := &testMocks{
+		policyRepo:      new(MockPolicyRepository),
+		participantRepo: new(MockParticipantRepository),
+		fileRepo:        new(MockFileRepository),
+		txManager:       new(MockTransactionManager),
+		audit:           new(recordingAuditLogger),
+	}
+	m.txManager.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+func newTestUsecase(m *testMocks) retention.Usecase {
+	return retention.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.audit,
+		m.txManager,
+		m.policyRepo,
+		m.participantRepo,
+		m.fileRepo,
+	)
+}
+
+type MockPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPolicyRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.FileRetentionPolicy, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.FileRetentionPolicy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.FileRetentionPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.FileRetentionPolicy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) GetByRule(ctx context.Context, tenantID uuid.UUID, documentType string, event entity.RetentionEvent) (*entity.FileRetentionPolicy, error) {
+	args := m.Called(ctx, tenantID, documentType, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.FileRetentionPolicy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) Create(ctx context.Context, policy *entity.FileRetentionPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockPolicyRepository) Update(ctx context.Context, policy *entity.FileRetentionPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+func (m *MockParticipantRepository) Update(ctx context.Context, participant *entity.Participant) error {
+	args := m.Called(ctx, participant)
+	return args.Error(0)
+}
+
+type MockFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.File, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.File), args.Error(1)
+}
+
+func (m *MockFileRepository) SetLegalHold(ctx context.Context, id uuid.UUID, hold bool, reason *string, setBy uuid.UUID) error {
+	args := m.Called(ctx, id, hold, reason, setBy)
+	return args.Error(0)
+}
+
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if args.Get(0) == nil && fn != nil {
+		return fn(ctx)
+	}
+	return args.Error(0)
+}
+
+type recordingAuditLogger struct {
+	mu     sync.Mutex
+	events []logger.AuditEvent
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, event logger.AuditEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *recordingAuditLogger) Sync() error { return nil }
@@ -0,0 +1,85 @@
+package retention_test
+
+import (
+	"context"
+	"testing"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/retention"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePolicy_CreatesNewRule(t *testing.T) {
+	m := newTestMocks()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	m.policyRepo.On("GetByRule", mock.Anything, tenantID, "ktp", entity.RetentionEventParticipantTerminated).
+		Return(nil, errors.ErrNotFound("retention policy not found"))
+	m.policyRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.FileRetentionPolicy) bool {
+		return p.TenantID == tenantID && p.DocumentType == "ktp" && p.RetentionDays == 3650 && p.CreatedBy == userID
+	})).Return(nil)
+
+	result, err := newTestUsecase(m).SavePolicy(context.Background(), &retention.SavePolicyRequest{
+		TenantID:       tenantID,
+		UserID:         userID,
+		DocumentType:   " ktp ",
+		RetentionEvent: "PARTICIPANT_TERMINATED",
+		RetentionDays:  3650,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PARTICIPANT_TERMINATED", result.RetentionEvent)
+	m.policyRepo.AssertExpectations(t)
+	require.Len(t, m.audit.events, 1)
+	assert.Equal(t, "retention_policy_saved", m.audit.events[0].Action)
+}
+
+func TestSavePolicy_ReplacesExistingPeriod(t *testing.T) {
+	m := newTestMocks()
+	tenantID := uuid.New()
+	existing := &entity.FileRetentionPolicy{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		DocumentType:   entity.AnyDocumentType,
+		RetentionEvent: entity.RetentionEventParticipantRejected,
+		RetentionDays:  30,
+	}
+
+	m.policyRepo.On("GetByRule", mock.Anything, tenantID, "*", entity.RetentionEventParticipantRejected).Return(existing, nil)
+	m.policyRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	result, err := newTestUsecase(m).SavePolicy(context.Background(), &retention.SavePolicyRequest{
+		TenantID:       tenantID,
+		UserID:         uuid.New(),
+		DocumentType:   "*",
+		RetentionEvent: "PARTICIPANT_REJECTED",
+		RetentionDays:  90,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, result.ID)
+	assert.Equal(t, 90, result.RetentionDays)
+	m.policyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDeletePolicy_OtherTenant_NotFound(t *testing.T) {
+	m := newTestMocks()
+	policy := &entity.FileRetentionPolicy{ID: uuid.New(), TenantID: uuid.New()}
+	m.policyRepo.On("GetByID", mock.Anything, policy.ID).Return(policy, nil)
+
+	err := newTestUsecase(m).DeletePolicy(context.Background(), &retention.DeletePolicyRequest{
+		TenantID: uuid.New(),
+		UserID:   uuid.New(),
+		PolicyID: policy.ID,
+	})
+
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+	m.policyRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	assert.Empty(t, m.audit.events)
+}
@@ -0,0 +1,114 @@
+package reveal_test
+
+import (
+	"context"
+	"sync"
+
+	"erp-service/config"
+	"erp-service/entity"
+	"erp-service/pkg/logger"
+	"erp-service/saving/reveal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	_ reveal.ParticipantRepository = (*MockParticipantRepository)(nil)
+	_ reveal.IdentityRepository    = (*MockIdentityRepository)(nil)
+	_ reveal.BankAccountRepository = (*MockBankAccountRepository)(nil)
+	_ reveal.BeneficiaryRepository = (*MockBeneficiaryRepository)(nil)
+	_ logger.AuditLogger           = (*recordingAuditLogger)(nil)
+)
+
+type testMocks struct {
+	participantRepo *MockParticipantRepository
+	identityRepo    *MockIdentityRepository
+	bankAccountRepo *MockBankAccountRepository
+	beneficiaryRepo *MockBeneficiaryRepository
+	audit           *recordingAuditLogger
+}
+
+func newTestMocks() *testMocks {
+	return &testMocks{
+		participantRepo: new(MockParticipantRepository),
+		identityRepo:    new(MockIdentityRepository),
+		bankAccountRepo: new(MockBankAccountRepository),
+		beneficiaryRepo: new(MockBeneficiaryRepository),
+		audit:           new(recordingAuditLogger),
+	}
+}
+
+func newTestUsecase(m *testMocks) reveal.Usecase {
+	return reveal.NewUsecase(
+		&config.Config{},
+		zap.NewNop(),
+		m.audit,
+		m.participantRepo,
+		m.identityRepo,
+		m.bankAccountRepo,
+		m.beneficiaryRepo,
+	)
+}
+
+type MockParticipantRepository struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Participant), args.Error(1)
+}
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantIdentity, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantIdentity), args.Error(1)
+}
+
+type MockBankAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockBankAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBankAccount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantBankAccount), args.Error(1)
+}
+
+type MockBeneficiaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockBeneficiaryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ParticipantBeneficiary, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ParticipantBeneficiary), args.Error(1)
+}
+
+type recordingAuditLogger struct {
+	mu     sync.Mutex
+	events []logger.AuditEvent
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, event logger.AuditEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *recordingAuditLogger) Sync() error { return nil }
@@ -0,0 +1,142 @@
+package reveal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/entity"
+	"erp-service/pkg/errors"
+	"erp-service/saving/reveal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func revealRequest(p *entity.Participant, field string, recordID *uuid.UUID) *reveal.RevealRequest {
+	return &reveal.RevealRequest{
+		TenantID:      p.TenantID,
+		ProductID:     p.ProductID,
+		UserID:        uuid.New(),
+		ParticipantID: p.ID,
+		Field:         field,
+		RecordID:      recordID,
+		Reason:        "payout verification call",
+	}
+}
+
+func newParticipant() *entity.Participant {
+	ktp := "3201012345678901"
+	dob := time.Date(1970, 3, 14, 0, 0, 0, 0, time.UTC)
+	return &entity.Participant{
+		ID: uuid.New(), TenantID: uuid.New(), ProductID: uuid.New(),
+		KTPNumber: &ktp, DateOfBirth: &dob,
+	}
+}
+
+func TestRevealParticipantField_KTPNumber(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+
+	result, err := newTestUsecase(m).RevealParticipantField(context.Background(), revealRequest(p, reveal.FieldKTPNumber, nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, "3201012345678901", result.Value)
+	assert.Equal(t, "national_id", result.Classification)
+
+	require.Len(t, m.audit.events, 1)
+	event := m.audit.events[0]
+	assert.Equal(t, "pii_revealed", event.Action)
+	assert.True(t, event.Success)
+	assert.Equal(t, "payout verification call", event.Metadata["reason"])
+	assert.Equal(t, "ktp_number", event.Metadata["field"])
+	for _, v := range event.Metadata {
+		assert.NotEqual(t, "3201012345678901", v)
+	}
+}
+
+func TestRevealParticipantField_DateOfBirth(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+
+	result, err := newTestUsecase(m).RevealParticipantField(context.Background(), revealRequest(p, reveal.FieldDateOfBirth, nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, "1970-03-14", result.Value)
+}
+
+func TestRevealParticipantField_BankAccount(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	account := &entity.ParticipantBankAccount{ID: uuid.New(), ParticipantID: p.ID, AccountNumber: "1234567890"}
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.bankAccountRepo.On("GetByID", mock.Anything, account.ID).Return(account, nil)
+
+	result, err := newTestUsecase(m).RevealParticipantField(context.Background(),
+		revealRequest(p, reveal.FieldBankAccountNumber, &account.ID))
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", result.Value)
+	assert.Equal(t, "bank_account", result.Classification)
+	assert.Equal(t, account.ID.String(), m.audit.events[0].Metadata["record_id"])
+}
+
+func TestRevealParticipantField_RecordOfOtherParticipant_NotFound(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	identity := &entity.ParticipantIdentity{ID: uuid.New(), ParticipantID: uuid.New(), IdentityNumber: "A1234567"}
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	m.identityRepo.On("GetByID", mock.Anything, identity.ID).Return(identity, nil)
+
+	_, err := newTestUsecase(m).RevealParticipantField(context.Background(),
+		revealRequest(p, reveal.FieldIdentityNumber, &identity.ID))
+
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+	require.Len(t, m.audit.events, 1)
+	assert.False(t, m.audit.events[0].Success)
+}
+
+func TestRevealParticipantField_RequiresRecordID(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+
+	_, err := newTestUsecase(m).RevealParticipantField(context.Background(),
+		revealRequest(p, reveal.FieldBeneficiaryAccountNumber, nil))
+
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errors.KindBadRequest, appErr.Kind)
+	m.participantRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	require.Len(t, m.audit.events, 1)
+}
+
+func TestRevealParticipantField_OtherProduct_NotFound(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+	req := revealRequest(p, reveal.FieldKTPNumber, nil)
+	req.ProductID = uuid.New()
+
+	_, err := newTestUsecase(m).RevealParticipantField(context.Background(), req)
+
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+	assert.False(t, m.audit.events[0].Success)
+}
+
+func TestRevealParticipantField_EmptyValue_NotFound(t *testing.T) {
+	m := newTestMocks()
+	p := newParticipant()
+	m.participantRepo.On("GetByID", mock.Anything, p.ID).Return(p, nil)
+
+	_, err := newTestUsecase(m).RevealParticipantField(context.Background(), revealRequest(p, reveal.FieldPhoneNumber, nil))
+
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+}
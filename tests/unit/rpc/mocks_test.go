@@ -0,0 +1,97 @@
+package rpc_test
+
+import (
+	"context"
+	"time"
+
+	"erp-service/iam/auth"
+	"erp-service/iam/user"
+	"erp-service/masterdata"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAuthorizer struct {
+	mock.Mock
+}
+
+func (m *mockAuthorizer) CheckPermission(ctx context.Context, req *auth.CheckPermissionRequest) (*auth.CheckPermissionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.CheckPermissionResponse), args.Error(1)
+}
+
+type mockUsers struct {
+	mock.Mock
+}
+
+func (m *mockUsers) GetByID(ctx context.Context, callerTenantID *uuid.UUID, id uuid.UUID) (*user.UserDetailResponse, error) {
+	args := m.Called(ctx, callerTenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.UserDetailResponse), args.Error(1)
+}
+
+type mockMasterdata struct {
+	mock.Mock
+}
+
+func (m *mockMasterdata) GetItemByCode(ctx context.Context, categoryCode string, tenantID *uuid.UUID, itemCode string) (*masterdata.ItemResponse, error) {
+	args := m.Called(ctx, categoryCode, tenantID, itemCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.ItemResponse), args.Error(1)
+}
+
+func (m *mockMasterdata) GetItemTree(ctx context.Context, categoryCode string, tenantID *uuid.UUID) ([]*masterdata.ItemResponse, error) {
+	args := m.Called(ctx, categoryCode, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*masterdata.ItemResponse), args.Error(1)
+}
+
+func (m *mockMasterdata) ValidateItemCodes(ctx context.Context, req *masterdata.ValidateCodesRequest) (*masterdata.ValidateCodesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.ValidateCodesResponse), args.Error(1)
+}
+
+// fakeBlacklist implements auth.TokenBlacklistStore in memory.
+type fakeBlacklist struct {
+	tokens map[string]bool
+	users  map[uuid.UUID]time.Time
+}
+
+func newFakeBlacklist() *fakeBlacklist {
+	return &fakeBlacklist{tokens: map[string]bool{}, users: map[uuid.UUID]time.Time{}}
+}
+
+func (f *fakeBlacklist) BlacklistToken(_ context.Context, jti string, _ time.Duration) error {
+	f.tokens[jti] = true
+	return nil
+}
+
+func (f *fakeBlacklist) IsTokenBlacklisted(_ context.Context, jti string) (bool, error) {
+	return f.tokens[jti], nil
+}
+
+func (f *fakeBlacklist) BlacklistUser(_ context.Context, userID uuid.UUID, timestamp time.Time, _ time.Duration) error {
+	f.users[userID] = timestamp
+	return nil
+}
+
+func (f *fakeBlacklist) GetUserBlacklistTimestamp(_ context.Context, userID uuid.UUID) (*time.Time, error) {
+	ts, ok := f.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &ts, nil
+}
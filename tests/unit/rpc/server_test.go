@@ -0,0 +1,389 @@
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	rpc "erp-service/delivery/grpc"
+	"erp-service/iam/auth"
+	"erp-service/iam/user"
+	"erp-service/masterdata"
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/grpcapi"
+	iamv1 "erp-service/pkg/grpcapi/iam/v1"
+	masterdatav1 "erp-service/pkg/grpcapi/masterdata/v1"
+	jwtpkg "erp-service/pkg/jwt"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var testConfig = &config.Config{
+	JWT: config.JWTConfig{
+		AccessSecret: "test-access-secret",
+		AccessExpiry: 15 * time.Minute,
+		Issuer:       "erp-service",
+	},
+}
+
+type harness struct {
+	client     *grpcapi.Client
+	health     healthpb.HealthClient
+	blacklist  *fakeBlacklist
+	authorizer *mockAuthorizer
+	users      *mockUsers
+	masterdata *mockMasterdata
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	h := &harness{
+		blacklist:  newFakeBlacklist(),
+		authorizer: new(mockAuthorizer),
+		users:      new(mockUsers),
+		masterdata: new(mockMasterdata),
+	}
+
+	tokens := rpc.NewTokenValidator(testConfig, h.blacklist)
+	server, _ := rpc.New(zap.NewNop(), tokens, rpc.NewIAMServer(tokens, h.authorizer, h.users), rpc.NewMasterdataServer(h.masterdata), true)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	client, err := grpcapi.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	h.client = client
+	h.health = healthpb.NewHealthClient(conn)
+	return h
+}
+
+func tokenConfig(expiry time.Duration) *jwtpkg.TokenConfig {
+	return &jwtpkg.TokenConfig{
+		AccessSecret: testConfig.JWT.AccessSecret,
+		AccessExpiry: expiry,
+		Issuer:       testConfig.JWT.Issuer,
+	}
+}
+
+func multiTenantToken(t *testing.T, userID uuid.UUID, roles []string, tenants []jwtpkg.TenantClaim) string {
+	t.Helper()
+	token, err := jwtpkg.GenerateMultiTenantAccessToken(userID, "user@example.com", roles, tenants, uuid.New(), tokenConfig(15*time.Minute))
+	require.NoError(t, err)
+	return token
+}
+
+func tenantClaims() []jwtpkg.TenantClaim {
+	return []jwtpkg.TenantClaim{{
+		TenantID: uuid.New(),
+		Products: []jwtpkg.ProductClaim{{ProductID: uuid.New(), ProductCode: "SAVING", Permissions: []string{"member:read"}}},
+	}}
+}
+
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestValidateToken(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("multi-tenant token returns claims", func(t *testing.T) {
+		tenants := tenantClaims()
+		resp, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{
+			AccessToken: multiTenantToken(t, userID, nil, tenants),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Valid)
+		assert.Equal(t, userID.String(), resp.Claims.UserId)
+		require.Len(t, resp.Claims.Tenants, 1)
+		assert.Equal(t, tenants[0].TenantID.String(), resp.Claims.Tenants[0].TenantId)
+		assert.Equal(t, []string{"member:read"}, resp.Claims.Tenants[0].Products[0].Permissions)
+		assert.False(t, resp.Claims.PlatformAdmin)
+	})
+
+	t.Run("legacy token returns flat claims", func(t *testing.T) {
+		tenantID := uuid.New()
+		token, err := jwtpkg.GenerateAccessToken(userID, "user@example.com", &tenantID, nil, []string{"ADMIN"}, []string{"user:read"}, nil, uuid.New(), tokenConfig(15*time.Minute))
+		require.NoError(t, err)
+
+		resp, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{AccessToken: token})
+		require.NoError(t, err)
+		assert.True(t, resp.Valid)
+		assert.Equal(t, tenantID.String(), resp.Claims.TenantId)
+		assert.Equal(t, []string{"user:read"}, resp.Claims.Permissions)
+		assert.Empty(t, resp.Claims.Tenants)
+	})
+
+	t.Run("expired token is reported, not returned as an error", func(t *testing.T) {
+		token, err := jwtpkg.GenerateMultiTenantAccessToken(userID, "user@example.com", nil, tenantClaims(), uuid.New(), tokenConfig(-time.Minute))
+		require.NoError(t, err)
+
+		resp, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{AccessToken: token})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, apperrors.CodeTokenExpired, resp.ErrorCode)
+		assert.Nil(t, resp.Claims)
+	})
+
+	t.Run("garbage token is invalid", func(t *testing.T) {
+		resp, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{AccessToken: "not-a-token"})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, apperrors.CodeTokenInvalid, resp.ErrorCode)
+	})
+
+	t.Run("token issued before logout-all is revoked", func(t *testing.T) {
+		revokedUser := uuid.New()
+		token := multiTenantToken(t, revokedUser, nil, tenantClaims())
+		h.blacklist.users[revokedUser] = time.Now().Add(time.Minute)
+
+		resp, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{AccessToken: token})
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, "TOKEN_REVOKED", resp.ErrorCode)
+	})
+
+	t.Run("missing token is a bad request", func(t *testing.T) {
+		_, err := h.client.IAM.ValidateToken(ctx, &iamv1.ValidateTokenRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCheckPermission(t *testing.T) {
+	h := newHarness(t)
+	userID := uuid.New()
+	tenantID := uuid.New()
+	productID := uuid.New()
+	req := &iamv1.CheckPermissionRequest{
+		UserId:     userID.String(),
+		TenantId:   tenantID.String(),
+		ProductId:  productID.String(),
+		Permission: "member:read",
+	}
+
+	t.Run("requires a token", func(t *testing.T) {
+		_, err := h.client.IAM.CheckPermission(context.Background(), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, apperrors.CodeUnauthorized, errorReason(t, err))
+	})
+
+	t.Run("rejects a revoked token", func(t *testing.T) {
+		revokedUser := uuid.New()
+		token := multiTenantToken(t, revokedUser, nil, tenantClaims())
+		h.blacklist.users[revokedUser] = time.Now().Add(time.Minute)
+
+		_, err := h.client.IAM.CheckPermission(grpcapi.WithAccessToken(context.Background(), token), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, "TOKEN_REVOKED", errorReason(t, err))
+	})
+
+	t.Run("checks the caller and echoes the request id", func(t *testing.T) {
+		h.authorizer.On("CheckPermission", mock.Anything, &auth.CheckPermissionRequest{
+			UserID:     userID,
+			TenantID:   tenantID,
+			ProductID:  productID,
+			Permission: "member:read",
+		}).Return(&auth.CheckPermissionResponse{Allowed: true}, nil).Once()
+
+		ctx := grpcapi.WithAccessToken(context.Background(), multiTenantToken(t, userID, nil, tenantClaims()))
+		ctx = grpcapi.WithRequestID(ctx, "req-123")
+		var header metadata.MD
+		resp, err := h.client.IAM.CheckPermission(ctx, req, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, []string{"req-123"}, header.Get(grpcapi.MetadataRequestID))
+		h.authorizer.AssertExpectations(t)
+	})
+
+	t.Run("forbids checking another user", func(t *testing.T) {
+		ctx := grpcapi.WithAccessToken(context.Background(), multiTenantToken(t, uuid.New(), nil, tenantClaims()))
+		_, err := h.client.IAM.CheckPermission(ctx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("platform admin may check another user", func(t *testing.T) {
+		h.authorizer.On("CheckPermission", mock.Anything, mock.Anything).Return(&auth.CheckPermissionResponse{}, nil).Once()
+
+		ctx := grpcapi.WithAccessToken(context.Background(), multiTenantToken(t, uuid.New(), []string{auth.PlatformAdminRole}, tenantClaims()))
+		resp, err := h.client.IAM.CheckPermission(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("rejects malformed ids", func(t *testing.T) {
+		ctx := grpcapi.WithAccessToken(context.Background(), multiTenantToken(t, userID, nil, tenantClaims()))
+		_, err := h.client.IAM.CheckPermission(ctx, &iamv1.CheckPermissionRequest{UserId: "nope"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	h := newHarness(t)
+	userID := uuid.New()
+	ctx := grpcapi.WithAccessToken(context.Background(), multiTenantToken(t, userID, nil, tenantClaims()))
+
+	t.Run("returns the caller", func(t *testing.T) {
+		phone := "+62811"
+		h.users.On("GetByID", mock.Anything, (*uuid.UUID)(nil), userID).Return(&user.UserDetailResponse{
+			ID:          userID,
+			Email:       "user@example.com",
+			FullName:    "Jane Doe",
+			PhoneNumber: &phone,
+			Status:      "ACTIVE",
+			IsActive:    true,
+			Roles:       []user.RoleInfo{{Code: "MEMBER"}},
+		}, nil).Once()
+
+		resp, err := h.client.IAM.GetUser(ctx, &iamv1.GetUserRequest{UserId: userID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Doe", resp.User.FullName)
+		assert.Equal(t, phone, resp.User.PhoneNumber)
+		assert.Equal(t, []string{"MEMBER"}, resp.User.Roles)
+	})
+
+	t.Run("maps app errors to status codes", func(t *testing.T) {
+		h.users.On("GetByID", mock.Anything, (*uuid.UUID)(nil), userID).Return(nil, apperrors.ErrUserNotFound()).Once()
+
+		_, err := h.client.IAM.GetUser(ctx, &iamv1.GetUserRequest{UserId: userID.String()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, apperrors.CodeUserNotFound, errorReason(t, err))
+	})
+
+	t.Run("hides unexpected errors", func(t *testing.T) {
+		h.users.On("GetByID", mock.Anything, (*uuid.UUID)(nil), userID).Return(nil, assert.AnError).Once()
+
+		_, err := h.client.IAM.GetUser(ctx, &iamv1.GetUserRequest{UserId: userID.String()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, err.Error(), assert.AnError.Error())
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		h.users.On("GetByID", mock.Anything, (*uuid.UUID)(nil), userID).Run(func(mock.Arguments) { panic("boom") }).Once()
+
+		_, err := h.client.IAM.GetUser(ctx, &iamv1.GetUserRequest{UserId: userID.String()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("forbids reading another user", func(t *testing.T) {
+		_, err := h.client.IAM.GetUser(ctx, &iamv1.GetUserRequest{UserId: uuid.New().String()})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestMasterdata(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	t.Run("get item by code needs no token", func(t *testing.T) {
+		parentID := uuid.New()
+		h.masterdata.On("GetItemByCode", mock.Anything, "GENDER", &tenantID, "M").Return(&masterdata.ItemResponse{
+			ID:           uuid.New(),
+			ParentItemID: &parentID,
+			Code:         "M",
+			Name:         "Male",
+			Metadata:     []byte(`{"k":"v"}`),
+		}, nil).Once()
+
+		resp, err := h.client.Masterdata.GetItemByCode(ctx, &masterdatav1.GetItemByCodeRequest{
+			CategoryCode: "GENDER",
+			ItemCode:     "M",
+			TenantId:     tenantID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Male", resp.Item.Name)
+		assert.Equal(t, parentID.String(), resp.Item.ParentItemId)
+		assert.JSONEq(t, `{"k":"v"}`, string(resp.Item.Metadata))
+	})
+
+	t.Run("item tree", func(t *testing.T) {
+		h.masterdata.On("GetItemTree", mock.Anything, "REGION", (*uuid.UUID)(nil)).Return([]*masterdata.ItemResponse{
+			{ID: uuid.New(), Code: "JKT"},
+			{ID: uuid.New(), Code: "BDG"},
+		}, nil).Once()
+
+		resp, err := h.client.Masterdata.GetItemTree(ctx, &masterdatav1.GetItemTreeRequest{CategoryCode: "REGION"})
+		require.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+	})
+
+	t.Run("validate item codes", func(t *testing.T) {
+		h.masterdata.On("ValidateItemCodes", mock.Anything, &masterdata.ValidateCodesRequest{
+			Validations: []masterdata.ValidationItem{{CategoryCode: "GENDER", ItemCode: "X"}},
+		}).Return(&masterdata.ValidateCodesResponse{
+			Results: []masterdata.ValidationResult{{CategoryCode: "GENDER", ItemCode: "X", Message: "not found"}},
+		}, nil).Once()
+
+		resp, err := h.client.Masterdata.ValidateItemCodes(ctx, &masterdatav1.ValidateItemCodesRequest{
+			Validations: []*masterdatav1.ItemCodeValidation{{CategoryCode: "GENDER", ItemCode: "X"}},
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.AllValid)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "not found", resp.Results[0].Message)
+	})
+
+	t.Run("validate item codes enforces the batch limit", func(t *testing.T) {
+		validations := make([]*masterdatav1.ItemCodeValidation, 101)
+		for i := range validations {
+			validations[i] = &masterdatav1.ItemCodeValidation{CategoryCode: "GENDER", ItemCode: "M"}
+		}
+		_, err := h.client.Masterdata.ValidateItemCodes(ctx, &masterdatav1.ValidateItemCodesRequest{Validations: validations})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid tenant id", func(t *testing.T) {
+		_, err := h.client.Masterdata.GetItemTree(ctx, &masterdatav1.GetItemTreeRequest{CategoryCode: "REGION", TenantId: "nope"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestHealth(t *testing.T) {
+	h := newHarness(t)
+
+	for _, service := range []string{"", iamv1.IAMService_ServiceDesc.ServiceName, masterdatav1.MasterdataService_ServiceDesc.ServiceName} {
+		resp, err := h.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status, service)
+	}
+}
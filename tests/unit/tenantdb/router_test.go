@@ -0,0 +1,230 @@
+package tenantdb_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apperrors "erp-service/pkg/errors"
+	"erp-service/pkg/tenantdb"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const placementQuery = `SELECT \* FROM "tenant_databases" WHERE tenant_id = \$1`
+
+var placementColumns = []string{"tenant_id", "database_name", "status"}
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       db,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+// opener hands out mock databases and counts opens and closes.
+type opener struct {
+	t      *testing.T
+	dbs    map[string]*gorm.DB
+	opens  atomic.Int32
+	closes atomic.Int32
+}
+
+func newOpener(t *testing.T) *opener {
+	return &opener{t: t, dbs: make(map[string]*gorm.DB)}
+}
+
+func (o *opener) open(name string) (*gorm.DB, func() error, error) {
+	o.opens.Add(1)
+	db, _ := setupMockDB(o.t)
+	o.dbs[name] = db
+	return db, func() error {
+		o.closes.Add(1)
+		return nil
+	}, nil
+}
+
+func newRouter(t *testing.T, platform *gorm.DB, o *opener, opts tenantdb.Options) *tenantdb.Router {
+	router := tenantdb.NewRouter(platform, o.open, opts, zap.NewNop())
+	t.Cleanup(func() { router.Close() })
+	return router
+}
+
+func TestRouter_DB_WithoutTenantUsesPlatform(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{})
+
+	db, err := router.DB(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, platform, db)
+
+	db, err = router.DB(tenantdb.WithoutTenant(context.Background()))
+	require.NoError(t, err)
+	assert.Same(t, platform, db)
+
+	assert.Zero(t, o.opens.Load())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRouter_DB_SharedTenantUsesPlatform(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{})
+	tenantID := uuid.New()
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns))
+
+	db, err := router.DB(tenantdb.WithTenant(context.Background(), tenantID))
+	require.NoError(t, err)
+	assert.Same(t, platform, db)
+	assert.Zero(t, o.opens.Load())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRouter_DB_MovingTenantIsUnavailable(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{})
+	tenantID := uuid.New()
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(tenantID, "tenant_acme", "MOVING"))
+
+	_, err := router.DB(tenantdb.WithTenant(context.Background(), tenantID))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, tenantdb.ErrTenantMoving)
+	assert.Equal(t, http.StatusServiceUnavailable, apperrors.GetHTTPStatus(err))
+	assert.Zero(t, o.opens.Load())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRouter_DB_ActiveTenantReusesPool(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{PlacementTTL: time.Minute})
+	tenantID := uuid.New()
+	ctx := tenantdb.WithTenant(context.Background(), tenantID)
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(tenantID, "tenant_acme", "ACTIVE"))
+
+	first, err := router.DB(ctx)
+	require.NoError(t, err)
+	second, err := router.DB(ctx)
+	require.NoError(t, err)
+
+	assert.Same(t, o.dbs["tenant_acme"], first)
+	assert.Same(t, first, second)
+	assert.EqualValues(t, 1, o.opens.Load())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRouter_DB_PoolLimit(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{MaxPools: 1})
+	acme, globex := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(acme, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(acme, "tenant_acme", "ACTIVE"))
+	mock.ExpectQuery(placementQuery).
+		WithArgs(globex, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(globex, "tenant_globex", "ACTIVE"))
+
+	_, err := router.DB(tenantdb.WithTenant(context.Background(), acme))
+	require.NoError(t, err)
+
+	// The only pool was just handed out, so it is not evicted.
+	_, err = router.DB(tenantdb.WithTenant(context.Background(), globex))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, tenantdb.ErrPoolLimit)
+	assert.Equal(t, http.StatusServiceUnavailable, apperrors.GetHTTPStatus(err))
+	assert.EqualValues(t, 1, o.opens.Load())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRouter_ClosesIdlePools(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{IdleTimeout: 20 * time.Millisecond})
+	tenantID := uuid.New()
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(tenantID, "tenant_acme", "ACTIVE"))
+
+	_, err := router.DB(tenantdb.WithTenant(context.Background(), tenantID))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return o.closes.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRouter_Close(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := tenantdb.NewRouter(platform, o.open, tenantdb.Options{PlacementTTL: time.Minute}, zap.NewNop())
+	tenantID := uuid.New()
+	ctx := tenantdb.WithTenant(context.Background(), tenantID)
+
+	mock.ExpectQuery(placementQuery).
+		WithArgs(tenantID, 1).
+		WillReturnRows(sqlmock.NewRows(placementColumns).AddRow(tenantID, "tenant_acme", "ACTIVE"))
+
+	_, err := router.DB(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, router.Close())
+	assert.EqualValues(t, 1, o.closes.Load())
+
+	_, err = router.DB(ctx)
+	assert.ErrorIs(t, err, tenantdb.ErrClosed)
+}
+
+func TestRouter_Each(t *testing.T) {
+	platform, mock := setupMockDB(t)
+	o := newOpener(t)
+	router := newRouter(t, platform, o, tenantdb.Options{})
+	acme, globex := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`SELECT \* FROM "tenant_databases" WHERE status = \$1 ORDER BY database_name`).
+		WithArgs("ACTIVE").
+		WillReturnRows(sqlmock.NewRows(placementColumns).
+			AddRow(acme, "tenant_acme", "ACTIVE").
+			AddRow(globex, "tenant_globex", "ACTIVE"))
+
+	var seen []uuid.UUID
+	err := router.Each(tenantdb.WithTenant(context.Background(), acme), func(ctx context.Context) error {
+		tenantID, _ := tenantdb.TenantFromContext(ctx)
+		seen = append(seen, tenantID)
+		if tenantID == globex {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tenant database tenant_globex: boom")
+	assert.Equal(t, []uuid.UUID{uuid.Nil, acme, globex}, seen)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,186 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"erp-service/delivery/http/middleware"
+	"erp-service/pkg/logger"
+	"erp-service/pkg/tracing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	remoteSpanID  = "00f067aa0ba902b7"
+)
+
+func useInMemory(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter, restore := tracing.UseInMemory()
+	t.Cleanup(restore)
+	return exporter
+}
+
+func spanNamed(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not recorded", "no span named %q", name)
+	return tracetest.SpanStub{}
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := useInMemory(t)
+
+	var handlerTraceID string
+	app := fiber.New()
+	app.Use(middleware.Tracing())
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		handlerTraceID = trace.SpanContextFromContext(c.UserContext()).TraceID().String()
+		return fiber.ErrServiceUnavailable
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req.Header.Set("traceparent", "00-"+remoteTraceID+"-"+remoteSpanID+"-01")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	span := spanNamed(t, exporter, "GET /items/:id")
+	assert.Equal(t, remoteTraceID, span.SpanContext.TraceID().String())
+	assert.Equal(t, remoteSpanID, span.Parent.SpanID().String())
+	assert.Equal(t, remoteTraceID, handlerTraceID, "handlers must see the request span")
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+	assert.Equal(t, "/items/:id", attributeValue(span, "http.route").AsString())
+	assert.Equal(t, int64(fiber.StatusServiceUnavailable), attributeValue(span, "http.response.status_code").AsInt64())
+	assert.Equal(t, "Error", span.Status.Code.String())
+}
+
+func TestStartChild(t *testing.T) {
+	exporter := useInMemory(t)
+
+	t.Run("does not start a trace on its own", func(t *testing.T) {
+		_, span := tracing.StartChild(context.Background(), "orphan")
+		span.End()
+
+		assert.False(t, span.IsRecording())
+		assert.Empty(t, exporter.GetSpans())
+	})
+
+	t.Run("continues the trace in ctx", func(t *testing.T) {
+		ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+		_, child := tracing.StartChild(ctx, "child")
+		child.End()
+		parent.End()
+
+		span := spanNamed(t, exporter, "child")
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	})
+}
+
+func TestInjectExtract(t *testing.T) {
+	useInMemory(t)
+
+	assert.Nil(t, tracing.Inject(context.Background()))
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "enqueue")
+	defer span.End()
+	carrier := tracing.Inject(ctx)
+	require.Contains(t, carrier, "traceparent")
+
+	extracted := trace.SpanContextFromContext(tracing.Extract(context.Background(), carrier))
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	assert.True(t, extracted.IsRemote())
+}
+
+func TestTransport(t *testing.T) {
+	exporter := useInMemory(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: tracing.Transport(nil, "minio")}
+	ctx, parent := tracing.Tracer().Start(context.Background(), "upload")
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, server.URL+"/bucket/key", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	span := spanNamed(t, exporter, "minio HEAD")
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, int64(http.StatusNotFound), attributeValue(span, "http.response.status_code").AsInt64())
+	assert.Empty(t, traceparent, "signed requests must not gain headers")
+}
+
+func TestInstrumentGorm(t *testing.T) {
+	exporter := useInMemory(t)
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, tracing.InstrumentGorm(db))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "users" WHERE email = $1`)).
+		WithArgs("someone@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "login")
+	var count int64
+	require.NoError(t, db.WithContext(ctx).Table("users").Where("email = ?", "someone@example.com").Count(&count).Error)
+	parent.End()
+
+	span := spanNamed(t, exporter, "query users")
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, "postgresql", attributeValue(span, "db.system.name").AsString())
+	query := attributeValue(span, "db.query.text").AsString()
+	assert.Contains(t, query, `"users"`)
+	assert.NotContains(t, query, "someone@example.com", "bound values must stay out of traces")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTraceFields(t *testing.T) {
+	assert.Nil(t, logger.TraceFields(context.Background()))
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	fields := logger.TraceFields(ctx)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "trace_id", fields[0].Key)
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields[0].String)
+	assert.Equal(t, "span_id", fields[1].Key)
+}
@@ -0,0 +1,163 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp-service/delivery/worker"
+	"erp-service/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// databases stands in for the tenant router: one pass per name.
+type databases []string
+
+type databaseKey struct{}
+
+func (d databases) Each(ctx context.Context, fn func(ctx context.Context) error) error {
+	var errs []error
+	for _, name := range d {
+		if err := fn(context.WithValue(ctx, databaseKey{}, name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var platformOnly = databases{"platform"}
+
+func registerFileJobs(t *testing.T, uc *mockUsecase) *worker.Registry {
+	t.Helper()
+	registry := worker.NewRegistry()
+	require.NoError(t, worker.NewFileJobs(uc, platformOnly, zap.NewNop()).Register(registry))
+	return registry
+}
+
+func runJob(t *testing.T, registry *worker.Registry, name string) error {
+	t.Helper()
+	jobType, ok := registry.Lookup(name)
+	require.True(t, ok, "job type %s not registered", name)
+	return jobType.Handler(context.Background(), nil)
+}
+
+func TestFileJobs_Register_AllOnFilesQueue(t *testing.T) {
+	registry := registerFileJobs(t, new(mockUsecase))
+
+	for _, name := range []string{worker.JobFileCleanup, worker.JobFileScan, worker.JobFileRewrap, worker.JobFilePurge} {
+		jobType, ok := registry.Lookup(name)
+		require.True(t, ok, name)
+		assert.Equal(t, worker.QueueFiles, jobType.Queue)
+		assert.True(t, jobType.DropOnExhaustion, name)
+	}
+	assert.Equal(t, []string{worker.QueueFiles}, registry.Queues())
+}
+
+func TestFileJobs_Cleanup_CallsCleanupBatch(t *testing.T) {
+	uc := new(mockUsecase)
+	uc.On("CleanupBatch", mock.Anything).Return(files.BatchResult{Processed: 1}, nil).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFileCleanup)
+
+	assert.NoError(t, err)
+	uc.AssertExpectations(t)
+}
+
+func TestFileJobs_Cleanup_ErrorFailsJob(t *testing.T) {
+	uc := new(mockUsecase)
+	uc.On("CleanupBatch", mock.Anything).Return(files.BatchResult{}, errors.New("db down")).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFileCleanup)
+
+	assert.EqualError(t, err, "db down")
+}
+
+func TestFileJobs_RunsOncePerDatabase(t *testing.T) {
+	uc := new(mockUsecase)
+	var seen []string
+	onDatabase := func(args mock.Arguments) {
+		seen = append(seen, args.Get(0).(context.Context).Value(databaseKey{}).(string))
+	}
+	uc.On("PurgeBatch", mock.Anything).Run(onDatabase).Return(files.PurgeBatchResult{}, errors.New("db down")).Once()
+	uc.On("PurgeBatch", mock.Anything).Run(onDatabase).Return(files.PurgeBatchResult{Purged: 1}, nil).Once()
+	registry := worker.NewRegistry()
+	require.NoError(t, worker.NewFileJobs(uc, databases{"platform", "erp_tenant_acme"}, zap.NewNop()).Register(registry))
+
+	err := runJob(t, registry, worker.JobFilePurge)
+
+	assert.EqualError(t, err, "db down", "a failing database must not hold back the next")
+	assert.Equal(t, []string{"platform", "erp_tenant_acme"}, seen)
+	uc.AssertExpectations(t)
+}
+
+func TestFileJobs_Scan_ProcessesImagesAfterScan(t *testing.T) {
+	uc := new(mockUsecase)
+	var order []string
+	uc.On("ScanBatch", mock.Anything).
+		Run(func(mock.Arguments) { order = append(order, "scan") }).
+		Return(files.ScanBatchResult{Clean: 1}, nil).Once()
+	uc.On("ProcessImagesBatch", mock.Anything).
+		Run(func(mock.Arguments) { order = append(order, "images") }).
+		Return(files.ProcessBatchResult{Processed: 1}, nil).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFileScan)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"scan", "images"}, order)
+}
+
+func TestFileJobs_Scan_ScanErrorStillProcessesImages(t *testing.T) {
+	uc := new(mockUsecase)
+	uc.On("ScanBatch", mock.Anything).Return(files.ScanBatchResult{}, errors.New("clamd unreachable")).Once()
+	uc.On("ProcessImagesBatch", mock.Anything).Return(files.ProcessBatchResult{}, nil).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFileScan)
+
+	assert.ErrorContains(t, err, "clamd unreachable")
+	uc.AssertExpectations(t)
+}
+
+func TestFileJobs_Rewrap_CallsRewrapKeysBatch(t *testing.T) {
+	uc := new(mockUsecase)
+	uc.On("RewrapKeysBatch", mock.Anything).Return(files.RewrapBatchResult{Rewrapped: 2}, nil).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFileRewrap)
+
+	assert.NoError(t, err)
+	uc.AssertExpectations(t)
+}
+
+func TestFileJobs_Purge_CallsPurgeBatch(t *testing.T) {
+	uc := new(mockUsecase)
+	uc.On("PurgeBatch", mock.Anything).Return(files.PurgeBatchResult{Purged: 3}, nil).Once()
+
+	err := runJob(t, registerFileJobs(t, uc), worker.JobFilePurge)
+
+	assert.NoError(t, err)
+	uc.AssertExpectations(t)
+}
+
+func TestFileJobs_Schedule_EnqueuesEachJobOncePerSlot(t *testing.T) {
+	uc := new(mockUsecase)
+	registry := registerFileJobs(t, uc)
+	queue := newFakeQueue()
+	scheduler := worker.NewScheduler(queue, registry, zap.NewNop())
+	require.NoError(t, worker.NewFileJobs(uc, platformOnly, zap.NewNop()).Schedule(scheduler, worker.DefaultFileJobIntervals()))
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	scheduler.Tick(context.Background(), now)
+	scheduler.Tick(context.Background(), now.Add(10*time.Second))
+
+	jobs := queue.enqueued[worker.QueueFiles]
+	require.Len(t, jobs, 4)
+	types := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		types = append(types, job.Type)
+	}
+	assert.ElementsMatch(t, []string{worker.JobFileCleanup, worker.JobFileScan, worker.JobFileRewrap, worker.JobFilePurge}, types)
+}
@@ -0,0 +1,182 @@
+package worker_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"erp-service/delivery/worker"
+	"erp-service/entity"
+	"erp-service/files"
+	implredis "erp-service/impl/redis"
+	apperrors "erp-service/pkg/errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockUsecase struct{ mock.Mock }
+
+var _ files.Usecase = (*mockUsecase)(nil)
+
+func (m *mockUsecase) CleanupBatch(ctx context.Context) (files.BatchResult, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(files.BatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) ProcessFile(ctx context.Context, file *entity.File) error {
+	return m.Called(ctx, file).Error(0)
+}
+
+func (m *mockUsecase) ScanBatch(ctx context.Context) (files.ScanBatchResult, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(files.ScanBatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) ScanFile(ctx context.Context, file *entity.File) (entity.FileScanStatus, error) {
+	args := m.Called(ctx, file)
+	return args.Get(0).(entity.FileScanStatus), args.Error(1)
+}
+
+func (m *mockUsecase) ProcessImagesBatch(ctx context.Context) (files.ProcessBatchResult, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(files.ProcessBatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) ProcessImage(ctx context.Context, file *entity.File) (entity.FileProcessingStatus, error) {
+	args := m.Called(ctx, file)
+	return args.Get(0).(entity.FileProcessingStatus), args.Error(1)
+}
+
+func (m *mockUsecase) RewrapKeysBatch(ctx context.Context) (files.RewrapBatchResult, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(files.RewrapBatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) PurgeBatch(ctx context.Context) (files.PurgeBatchResult, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(files.PurgeBatchResult), args.Error(1)
+}
+
+func (m *mockUsecase) PurgeFile(ctx context.Context, file *entity.File) error {
+	return m.Called(ctx, file).Error(0)
+}
+
+// fakeQueue is an in-memory worker.Queue recording how each job was
+// settled.
+type fakeQueue struct {
+	mu        sync.Mutex
+	pending   map[string][]*implredis.Job
+	completed []*implredis.Job
+	retried   []retriedJob
+	dead      []*implredis.Job
+	claims    map[string]bool
+	enqueued  map[string][]*implredis.Job
+	recovered int
+}
+
+type retriedJob struct {
+	job   implredis.Job
+	delay time.Duration
+}
+
+var _ worker.Queue = (*fakeQueue)(nil)
+
+func newFakeQueue() *fakeQueue {
+	return &fakeQueue{
+		pending:  make(map[string][]*implredis.Job),
+		claims:   make(map[string]bool),
+		enqueued: make(map[string][]*implredis.Job),
+	}
+}
+
+func (q *fakeQueue) push(queueName string, jobs ...*implredis.Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending[queueName] = append(q.pending[queueName], jobs...)
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, queueName string, job *implredis.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.enqueued[queueName] = append(q.enqueued[queueName], job)
+	return nil
+}
+
+func (q *fakeQueue) DequeueLeased(ctx context.Context, queueName string, lease time.Duration) (*implredis.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending[queueName]) == 0 {
+		return nil, apperrors.SentinelQueueEmpty
+	}
+	job := q.pending[queueName][0]
+	q.pending[queueName] = q.pending[queueName][1:]
+	job.Attempts++
+	return job, nil
+}
+
+func (q *fakeQueue) ExtendLease(ctx context.Context, queueName string, job *implredis.Job, lease time.Duration) error {
+	return nil
+}
+
+func (q *fakeQueue) CompleteJob(ctx context.Context, queueName string, job *implredis.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.completed = append(q.completed, job)
+	return nil
+}
+
+func (q *fakeQueue) RetryJob(ctx context.Context, queueName string, job *implredis.Job, errMsg string, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job.Error = errMsg
+	q.retried = append(q.retried, retriedJob{job: *job, delay: delay})
+	return nil
+}
+
+func (q *fakeQueue) DeadLetterJob(ctx context.Context, queueName string, job *implredis.Job, errMsg string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job.Error = errMsg
+	q.dead = append(q.dead, job)
+	return nil
+}
+
+func (q *fakeQueue) ProcessDelayed(ctx context.Context, queueName string) (int, error) {
+	return 0, nil
+}
+
+func (q *fakeQueue) RequeueExpired(ctx context.Context, queueName string) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.recovered++
+	return 0, nil
+}
+
+func (q *fakeQueue) ClaimSchedule(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := name + "@" + slot.String()
+	if q.claims[key] {
+		return false, nil
+	}
+	q.claims[key] = true
+	return true, nil
+}
+
+func (q *fakeQueue) counts() (completed, retried, dead int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.completed), len(q.retried), len(q.dead)
+}
+
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,102 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"erp-service/delivery/worker"
+	"erp-service/pkg/pii"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type mockBackfiller struct{ mock.Mock }
+
+var _ pii.Backfiller = (*mockBackfiller)(nil)
+
+func (m *mockBackfiller) BackfillBatch(ctx context.Context, cursor pii.BackfillCursor, limit int) (pii.BackfillResult, error) {
+	args := m.Called(ctx, cursor, limit)
+	return args.Get(0).(pii.BackfillResult), args.Error(1)
+}
+
+func TestPIIBackfill_RunsUntilNothingLeft(t *testing.T) {
+	backfiller := new(mockBackfiller)
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, 50).Return(pii.BackfillResult{Visited: 50, Sealed: 50}, nil).Twice()
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, 50).Return(pii.BackfillResult{Visited: 7, Sealed: 7}, nil).Once()
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, 50).Return(pii.BackfillResult{}, nil).Once()
+
+	b := worker.NewPIIBackfill(backfiller, platformOnly, zap.NewNop())
+	b.SetBatchSize(50)
+	b.Start(context.Background())
+	b.Stop()
+
+	backfiller.AssertNumberOfCalls(t, "BackfillBatch", 4)
+}
+
+func TestPIIBackfill_ContinuesPastFailedRows(t *testing.T) {
+	backfiller := new(mockBackfiller)
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(pii.BackfillCursor)["participants.ktp_number"] = "1"
+		}).
+		Return(pii.BackfillResult{
+			Visited:  1,
+			Failures: []pii.BackfillFailure{{Table: "participants", ID: "1", Err: errors.New("duplicate")}},
+		}, nil).Once()
+	backfiller.On("BackfillBatch", mock.Anything, mock.MatchedBy(func(c pii.BackfillCursor) bool {
+		return c["participants.ktp_number"] == "1"
+	}), mock.Anything).Return(pii.BackfillResult{Visited: 3, Sealed: 3}, nil).Once()
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, mock.Anything).Return(pii.BackfillResult{}, nil).Once()
+
+	b := worker.NewPIIBackfill(backfiller, platformOnly, zap.NewNop())
+	b.Start(context.Background())
+	b.Stop()
+
+	backfiller.AssertNumberOfCalls(t, "BackfillBatch", 3)
+	backfiller.AssertExpectations(t)
+}
+
+func TestPIIBackfill_RunsEveryDatabaseWithItsOwnCursor(t *testing.T) {
+	backfiller := new(mockBackfiller)
+	inDatabase := func(name string) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(databaseKey{}) == name })
+	}
+	backfiller.On("BackfillBatch", inDatabase("platform"), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(pii.BackfillCursor)["participants.ktp_number"] = "9"
+		}).
+		Return(pii.BackfillResult{Visited: 1, Sealed: 1}, nil).Once()
+	backfiller.On("BackfillBatch", inDatabase("platform"), mock.Anything, mock.Anything).Return(pii.BackfillResult{}, errors.New("db down")).Once()
+	backfiller.On("BackfillBatch", inDatabase("erp_tenant_acme"), pii.BackfillCursor{}, mock.Anything).Return(pii.BackfillResult{}, nil).Once()
+
+	b := worker.NewPIIBackfill(backfiller, databases{"platform", "erp_tenant_acme"}, zap.NewNop())
+	b.Start(context.Background())
+	b.Stop()
+
+	backfiller.AssertExpectations(t)
+}
+
+func TestPIIBackfill_StopsOnError(t *testing.T) {
+	backfiller := new(mockBackfiller)
+	backfiller.On("BackfillBatch", mock.Anything, mock.Anything, mock.Anything).Return(pii.BackfillResult{}, errors.New("db down"))
+
+	b := worker.NewPIIBackfill(backfiller, platformOnly, zap.NewNop())
+	b.Start(context.Background())
+	b.Stop()
+
+	backfiller.AssertNumberOfCalls(t, "BackfillBatch", 1)
+}
+
+func TestPIIBackfill_CancelledContext_DoesNotRun(t *testing.T) {
+	backfiller := new(mockBackfiller)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := worker.NewPIIBackfill(backfiller, platformOnly, zap.NewNop())
+	b.Start(ctx)
+	b.Stop()
+
+	backfiller.AssertNotCalled(t, "BackfillBatch", mock.Anything, mock.Anything, mock.Anything)
+}
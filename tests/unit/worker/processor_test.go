@@ -0,0 +1,318 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"erp-service/delivery/worker"
+	implredis "erp-service/impl/redis"
+	"erp-service/pkg/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const testQueue = "test"
+
+func testProcessorConfig() worker.ProcessorConfig {
+	return worker.ProcessorConfig{
+		Concurrency:      map[string]int{},
+		PollInterval:     5 * time.Millisecond,
+		LeaseDuration:    time.Second,
+		JobTimeout:       time.Second,
+		DrainTimeout:     time.Second,
+		RecoveryInterval: time.Hour,
+		RetryBaseDelay:   time.Second,
+		RetryMaxDelay:    time.Minute,
+	}
+}
+
+func newJob(t *testing.T, jobType string, maxRetry int) *implredis.Job {
+	t.Helper()
+	job, err := implredis.NewTypedJob(jobType, map[string]string{"k": "v"}, maxRetry)
+	require.NoError(t, err)
+	return job
+}
+
+func startProcessor(t *testing.T, queue *fakeQueue, registry *worker.Registry, cfg worker.ProcessorConfig) *worker.Processor {
+	t.Helper()
+	p := worker.NewProcessor(queue, registry, zap.NewNop(), cfg)
+	p.Start(context.Background())
+	t.Cleanup(p.Stop)
+	return p
+}
+
+func registryWith(t *testing.T, jobType worker.JobType) *worker.Registry {
+	t.Helper()
+	registry := worker.NewRegistry()
+	require.NoError(t, registry.Register(jobType))
+	return registry
+}
+
+func TestProcessor_Success_CompletesJob(t *testing.T) {
+	queue := newFakeQueue()
+	var payload []byte
+	registry := registryWith(t, worker.JobType{Name: "echo", Queue: testQueue, Handler: func(ctx context.Context, job *implredis.Job) error {
+		payload = job.Payload
+		return nil
+	}})
+	queue.push(testQueue, newJob(t, "echo", 3))
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { completed, _, _ := queue.counts(); return completed == 1 })
+	assert.JSONEq(t, `{"k":"v"}`, string(payload))
+	_, retried, dead := queue.counts()
+	assert.Zero(t, retried)
+	assert.Zero(t, dead)
+}
+
+func TestProcessor_ContinuesTraceOfEnqueuer(t *testing.T) {
+	exporter, restore := tracing.UseInMemory()
+	defer restore()
+
+	enqueueCtx, enqueueSpan := tracing.Tracer().Start(context.Background(), "POST /files")
+	enqueueSpan.End()
+
+	queue := newFakeQueue()
+	handled := make(chan trace.SpanContext, 1)
+	registry := registryWith(t, worker.JobType{Name: "traced", Queue: testQueue, Handler: func(ctx context.Context, _ *implredis.Job) error {
+		handled <- trace.SpanContextFromContext(ctx)
+		return nil
+	}})
+	job := newJob(t, "traced", 3)
+	job.Trace = tracing.Inject(enqueueCtx)
+	queue.push(testQueue, job)
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	var sc trace.SpanContext
+	select {
+	case sc = <-handled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job not handled")
+	}
+	assert.Equal(t, enqueueSpan.SpanContext().TraceID(), sc.TraceID())
+
+	eventually(t, func() bool { return len(exporter.GetSpans()) == 2 })
+	jobSpan := exporter.GetSpans()[1]
+	assert.Equal(t, "job traced", jobSpan.Name)
+	assert.Equal(t, enqueueSpan.SpanContext().SpanID(), jobSpan.Parent.SpanID())
+	assert.Equal(t, trace.SpanKindConsumer, jobSpan.SpanKind)
+}
+
+func TestProcessor_CheckStalled_HandlerIgnoringTimeout(t *testing.T) {
+	queue := newFakeQueue()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	registry := registryWith(t, worker.JobType{Name: "wedged", Queue: testQueue, Timeout: 10 * time.Millisecond, Handler: func(context.Context, *implredis.Job) error {
+		close(started)
+		<-release
+		return nil
+	}})
+	queue.push(testQueue, newJob(t, "wedged", 3))
+
+	cfg := testProcessorConfig()
+	cfg.LeaseDuration = 30 * time.Millisecond
+	p := startProcessor(t, queue, registry, cfg)
+	t.Cleanup(func() { close(release) })
+
+	<-started
+	assert.NoError(t, p.CheckStalled(context.Background()), "a job within its timeout is not stalled")
+	eventually(t, func() bool { return p.CheckStalled(context.Background()) != nil })
+	assert.ErrorContains(t, p.CheckStalled(context.Background()), "type wedged")
+
+	release <- struct{}{}
+	eventually(t, func() bool { completed, _, _ := queue.counts(); return completed == 1 })
+	assert.NoError(t, p.CheckStalled(context.Background()))
+}
+
+func TestProcessor_Failure_RetriesWithBackoff(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "flaky", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		return errors.New("upstream timeout")
+	}})
+	job := newJob(t, "flaky", 3)
+	job.Attempts = 1
+	queue.push(testQueue, job)
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { _, retried, _ := queue.counts(); return retried == 1 })
+	retry := queue.retried[0]
+	assert.Equal(t, 2, retry.job.Attempts)
+	assert.Equal(t, "upstream timeout", retry.job.Error)
+	assert.Equal(t, 2*time.Second, retry.delay)
+}
+
+func TestProcessor_Failure_LastAttemptDeadLetters(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "broken", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		return errors.New("still broken")
+	}})
+	job := newJob(t, "broken", 3)
+	job.Attempts = 2
+	queue.push(testQueue, job)
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { _, _, dead := queue.counts(); return dead == 1 })
+	assert.Equal(t, "still broken", queue.dead[0].Error)
+	_, retried, _ := queue.counts()
+	assert.Zero(t, retried)
+}
+
+func TestProcessor_Failure_DropOnExhaustion(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "periodic", Queue: testQueue, DropOnExhaustion: true, Handler: func(context.Context, *implredis.Job) error {
+		return errors.New("batch failed")
+	}})
+	queue.push(testQueue, newJob(t, "periodic", 1))
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { completed, _, _ := queue.counts(); return completed == 1 })
+	_, _, dead := queue.counts()
+	assert.Zero(t, dead)
+}
+
+func TestProcessor_UnknownJobType_DeadLetters(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "known", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		return nil
+	}})
+	queue.push(testQueue, newJob(t, "unknown", 3))
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { _, _, dead := queue.counts(); return dead == 1 })
+	assert.Contains(t, queue.dead[0].Error, `unknown job type "unknown"`)
+}
+
+func TestProcessor_HandlerPanic_Retried(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "panics", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		panic("nil map")
+	}})
+	queue.push(testQueue, newJob(t, "panics", 3))
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool { _, retried, _ := queue.counts(); return retried == 1 })
+	assert.Contains(t, queue.retried[0].job.Error, "nil map")
+}
+
+func TestProcessor_Concurrency_RunsJobsInParallel(t *testing.T) {
+	queue := newFakeQueue()
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	registry := registryWith(t, worker.JobType{Name: "slow", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		return nil
+	}})
+	queue.push(testQueue, newJob(t, "slow", 1), newJob(t, "slow", 1), newJob(t, "slow", 1))
+
+	cfg := testProcessorConfig()
+	cfg.Concurrency = map[string]int{testQueue: 2}
+	startProcessor(t, queue, registry, cfg)
+
+	eventually(t, func() bool { return peak.Load() == 2 })
+	close(release)
+	eventually(t, func() bool { completed, _, _ := queue.counts(); return completed == 3 })
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestProcessor_Stop_DrainsInFlightJobs(t *testing.T) {
+	queue := newFakeQueue()
+	started := make(chan struct{})
+	registry := registryWith(t, worker.JobType{Name: "slow", Queue: testQueue, Handler: func(ctx context.Context, job *implredis.Job) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	}})
+	queue.push(testQueue, newJob(t, "slow", 3))
+
+	p := worker.NewProcessor(queue, registry, zap.NewNop(), testProcessorConfig())
+	p.Start(context.Background())
+	<-started
+	p.Stop()
+
+	completed, retried, _ := queue.counts()
+	assert.Equal(t, 1, completed)
+	assert.Zero(t, retried)
+}
+
+func TestProcessor_Stop_DrainTimeoutRequeuesWithoutCountingAttempt(t *testing.T) {
+	queue := newFakeQueue()
+	started := make(chan struct{})
+	registry := registryWith(t, worker.JobType{Name: "stuck", Queue: testQueue, Handler: func(ctx context.Context, job *implredis.Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	queue.push(testQueue, newJob(t, "stuck", 3))
+
+	cfg := testProcessorConfig()
+	cfg.DrainTimeout = 20 * time.Millisecond
+	p := worker.NewProcessor(queue, registry, zap.NewNop(), cfg)
+	p.Start(context.Background())
+	<-started
+
+	done := make(chan struct{})
+	go func() { p.Stop(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("processor did not stop within timeout")
+	}
+
+	require.Len(t, queue.retried, 1)
+	assert.Equal(t, 0, queue.retried[0].job.Attempts)
+	assert.Zero(t, queue.retried[0].delay)
+}
+
+func TestProcessor_Start_RecoversExpiredLeases(t *testing.T) {
+	queue := newFakeQueue()
+	registry := registryWith(t, worker.JobType{Name: "noop", Queue: testQueue, Handler: func(context.Context, *implredis.Job) error {
+		return nil
+	}})
+
+	startProcessor(t, queue, registry, testProcessorConfig())
+
+	eventually(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return queue.recovered > 0
+	})
+}
+
+func TestProcessor_StopWithoutStart_Returns(t *testing.T) {
+	p := worker.NewProcessor(newFakeQueue(), worker.NewRegistry(), zap.NewNop(), testProcessorConfig())
+	p.Stop()
+}
+
+func TestProcessor_Backoff_DoublesUpToCap(t *testing.T) {
+	cfg := testProcessorConfig()
+	cfg.RetryBaseDelay = 5 * time.Second
+	cfg.RetryMaxDelay = 30 * time.Second
+	p := worker.NewProcessor(newFakeQueue(), worker.NewRegistry(), zap.NewNop(), cfg)
+
+	assert.Equal(t, 5*time.Second, p.Backoff(1))
+	assert.Equal(t, 10*time.Second, p.Backoff(2))
+	assert.Equal(t, 20*time.Second, p.Backoff(3))
+	assert.Equal(t, 30*time.Second, p.Backoff(4))
+	assert.Equal(t, 30*time.Second, p.Backoff(20))
+}
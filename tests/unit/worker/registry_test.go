@@ -0,0 +1,70 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp-service/delivery/worker"
+	implredis "erp-service/impl/redis"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func noopHandler(context.Context, *implredis.Job) error { return nil }
+
+func TestRegistry_Register_RejectsDuplicate(t *testing.T) {
+	registry := worker.NewRegistry()
+	require.NoError(t, registry.Register(worker.JobType{Name: "a", Queue: "q", Handler: noopHandler}))
+
+	err := registry.Register(worker.JobType{Name: "a", Queue: "other", Handler: noopHandler})
+
+	assert.ErrorContains(t, err, "already registered")
+}
+
+func TestRegistry_Register_RequiresQueueAndHandler(t *testing.T) {
+	registry := worker.NewRegistry()
+
+	assert.Error(t, registry.Register(worker.JobType{Name: "a", Handler: noopHandler}))
+	assert.Error(t, registry.Register(worker.JobType{Name: "a", Queue: "q"}))
+}
+
+func TestRegistry_Queues_SortedAndUnique(t *testing.T) {
+	registry := worker.NewRegistry()
+	require.NoError(t, registry.Register(worker.JobType{Name: "a", Queue: "zeta", Handler: noopHandler}))
+	require.NoError(t, registry.Register(worker.JobType{Name: "b", Queue: "alpha", Handler: noopHandler}))
+	require.NoError(t, registry.Register(worker.JobType{Name: "c", Queue: "zeta", Handler: noopHandler}))
+
+	assert.Equal(t, []string{"alpha", "zeta"}, registry.Queues())
+}
+
+func TestScheduler_Every_RejectsUnregisteredType(t *testing.T) {
+	scheduler := worker.NewScheduler(newFakeQueue(), worker.NewRegistry(), zap.NewNop())
+
+	assert.Error(t, scheduler.Every("missing", time.Minute))
+}
+
+func TestScheduler_Tick_EnqueuesOncePerSlotAcrossReplicas(t *testing.T) {
+	queue := newFakeQueue()
+	registry := worker.NewRegistry()
+	require.NoError(t, registry.Register(worker.JobType{Name: "report", Queue: "q", Handler: noopHandler}))
+
+	replicaA := worker.NewScheduler(queue, registry, zap.NewNop())
+	replicaB := worker.NewScheduler(queue, registry, zap.NewNop())
+	require.NoError(t, replicaA.Every("report", time.Minute))
+	require.NoError(t, replicaB.Every("report", time.Minute))
+
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	replicaA.Tick(context.Background(), base.Add(5*time.Second))
+	replicaB.Tick(context.Background(), base.Add(20*time.Second))
+	replicaA.Tick(context.Background(), base.Add(40*time.Second))
+	require.Len(t, queue.enqueued["q"], 1)
+
+	replicaB.Tick(context.Background(), base.Add(61*time.Second))
+	require.Len(t, queue.enqueued["q"], 2)
+	job := queue.enqueued["q"][1]
+	assert.Equal(t, "report", job.Type)
+	assert.Equal(t, 1, job.MaxRetry)
+}
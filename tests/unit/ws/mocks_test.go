@@ -0,0 +1,22 @@
+package ws_test
+
+import (
+	"context"
+
+	"erp-service/pkg/notify"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventLog struct {
+	mock.Mock
+}
+
+func (m *MockEventLog) ReplayEvents(ctx context.Context, tenantID, productID uuid.UUID, cursor string, limit int64) ([]*notify.Event, bool, error) {
+	args := m.Called(ctx, tenantID, productID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]*notify.Event), args.Bool(1), args.Error(2)
+}
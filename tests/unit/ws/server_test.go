@@ -0,0 +1,293 @@
+package ws_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"erp-service/config"
+	"erp-service/delivery/http/middleware"
+	ws "erp-service/delivery/websocket"
+	jwtpkg "erp-service/pkg/jwt"
+	"erp-service/pkg/notify"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testWebSocketConfig = config.WebSocketConfig{
+	PingInterval: time.Minute,
+	PongTimeout:  time.Minute,
+	WriteTimeout: time.Second,
+	SendBuffer:   16,
+	ReplayLimit:  100,
+}
+
+type testServer struct {
+	hub       *ws.Hub
+	url       string
+	tenantID  uuid.UUID
+	productID uuid.UUID
+}
+
+func newTestServer(t *testing.T, cfg config.WebSocketConfig, eventLog *MockEventLog, roles ...string) *testServer {
+	t.Helper()
+
+	s := &testServer{hub: ws.NewHub(), tenantID: uuid.New(), productID: uuid.New()}
+	claims := &jwtpkg.MultiTenantClaims{
+		UserID: uuid.New(),
+		Tenants: []jwtpkg.TenantClaim{{
+			TenantID: s.tenantID,
+			Products: []jwtpkg.ProductClaim{{ProductID: s.productID, Roles: roles}},
+		}},
+	}
+	handler := ws.NewHandler(s.hub, eventLog, cfg, zap.NewNop())
+
+	app := fiber.New(fiber.Config{ErrorHandler: ws.ErrorHandler(zap.NewNop())})
+	app.Get("/ws", func(c *fiber.Ctx) error {
+		c.Locals(middleware.MultiTenantClaimsKey, claims)
+		c.Locals("tenant_id", s.tenantID)
+		c.Locals("product_id", s.productID)
+		return c.Next()
+	}, handler.Authorize, handler.Serve())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() {
+		s.hub.Close()
+		_ = app.Shutdown()
+	})
+
+	s.url = "ws://" + ln.Addr().String() + "/ws"
+	return s
+}
+
+func (s *testServer) dial(t *testing.T, query string) *websocket.Conn {
+	t.Helper()
+	url := s.url
+	if query != "" {
+		url += "?" + query
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func (s *testServer) event(id string, topic notify.Topic) *notify.Event {
+	return &notify.Event{
+		ID:        id,
+		Type:      "test." + string(topic),
+		Topic:     topic,
+		TenantID:  s.tenantID,
+		ProductID: s.productID,
+	}
+}
+
+type message struct {
+	ID     string         `json:"id"`
+	Type   string         `json:"type"`
+	Topic  notify.Topic   `json:"topic"`
+	Topics []notify.Topic `json:"topics"`
+	Cursor string         `json:"cursor"`
+}
+
+func read(t *testing.T, conn *websocket.Conn) message {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg message
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestHandler_DeliversTopicsAllowedByRole(t *testing.T) {
+	t.Run("approvers receive the approval queue and status changes", func(t *testing.T) {
+		s := newTestServer(t, testWebSocketConfig, new(MockEventLog), "PARTICIPANT_APPROVER")
+		conn := s.dial(t, "")
+
+		ready := read(t, conn)
+		assert.Equal(t, "ready", ready.Type)
+		assert.ElementsMatch(t, []notify.Topic{notify.TopicApprovalQueue, notify.TopicParticipantStatus}, ready.Topics)
+
+		other := s.event("1-0", notify.TopicApprovalQueue)
+		other.TenantID = uuid.New()
+		s.hub.Dispatch(other)
+		s.hub.Dispatch(s.event("2-0", notify.TopicMembers))
+		s.hub.Dispatch(s.event("3-0", notify.TopicApprovalQueue))
+
+		got := read(t, conn)
+		assert.Equal(t, "3-0", got.ID)
+		assert.Equal(t, notify.TopicApprovalQueue, got.Topic)
+	})
+
+	t.Run("creators do not see the approval queue", func(t *testing.T) {
+		s := newTestServer(t, testWebSocketConfig, new(MockEventLog), "PARTICIPANT_CREATOR")
+		conn := s.dial(t, "")
+
+		ready := read(t, conn)
+		assert.Equal(t, []notify.Topic{notify.TopicParticipantStatus}, ready.Topics)
+
+		s.hub.Dispatch(s.event("1-0", notify.TopicApprovalQueue))
+		s.hub.Dispatch(s.event("2-0", notify.TopicParticipantStatus))
+
+		assert.Equal(t, "2-0", read(t, conn).ID)
+	})
+
+	t.Run("roles without topics are refused before the upgrade", func(t *testing.T) {
+		s := newTestServer(t, testWebSocketConfig, new(MockEventLog), "CONTRIBUTION_VIEWER")
+
+		_, resp, err := websocket.DefaultDialer.Dial(s.url, nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestHandler_ResumesFromCursor(t *testing.T) {
+	t.Run("replays missed events and skips live ones already replayed", func(t *testing.T) {
+		eventLog := new(MockEventLog)
+		s := newTestServer(t, testWebSocketConfig, eventLog, "PARTICIPANT_APPROVER")
+		eventLog.On("ReplayEvents", mock.Anything, s.tenantID, s.productID, "5-0", int64(100)).
+			Return([]*notify.Event{
+				s.event("6-0", notify.TopicApprovalQueue),
+				s.event("7-0", notify.TopicMembers),
+				s.event("8-0", notify.TopicParticipantStatus),
+			}, false, nil).Once()
+
+		conn := s.dial(t, "cursor=5-0")
+
+		assert.Equal(t, "6-0", read(t, conn).ID)
+		assert.Equal(t, "8-0", read(t, conn).ID)
+		ready := read(t, conn)
+		assert.Equal(t, "ready", ready.Type)
+		assert.Equal(t, "8-0", ready.Cursor)
+
+		s.hub.Dispatch(s.event("8-0", notify.TopicParticipantStatus))
+		s.hub.Dispatch(s.event("9-0", notify.TopicParticipantStatus))
+
+		assert.Equal(t, "9-0", read(t, conn).ID)
+		eventLog.AssertExpectations(t)
+	})
+
+	t.Run("asks the client to resync when the cursor is out of reach", func(t *testing.T) {
+		eventLog := new(MockEventLog)
+		s := newTestServer(t, testWebSocketConfig, eventLog, "PARTICIPANT_APPROVER")
+		eventLog.On("ReplayEvents", mock.Anything, s.tenantID, s.productID, "1-0", int64(100)).
+			Return(nil, true, nil).Once()
+
+		conn := s.dial(t, "cursor=1-0")
+
+		assert.Equal(t, "resync", read(t, conn).Type)
+		assert.Equal(t, "ready", read(t, conn).Type)
+	})
+
+	t.Run("rejects a malformed cursor", func(t *testing.T) {
+		s := newTestServer(t, testWebSocketConfig, new(MockEventLog), "PARTICIPANT_APPROVER")
+
+		_, resp, err := websocket.DefaultDialer.Dial(s.url+"?cursor=latest", nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestHandler_ConnectionHealth(t *testing.T) {
+	t.Run("disconnects a client that falls behind", func(t *testing.T) {
+		cfg := testWebSocketConfig
+		cfg.SendBuffer = 1
+		eventLog := new(MockEventLog)
+		s := newTestServer(t, cfg, eventLog, "PARTICIPANT_APPROVER")
+
+		release := make(chan struct{})
+		eventLog.On("ReplayEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { <-release }).
+			Return([]*notify.Event{}, false, nil).Once()
+
+		conn := s.dial(t, "cursor=1-0")
+		require.Eventually(t, func() bool { return s.hub.Len() == 1 }, time.Second, 5*time.Millisecond)
+		for i := 2; i < 10; i++ {
+			s.hub.Dispatch(s.event(fmt.Sprintf("%d-0", i), notify.TopicApprovalQueue))
+		}
+		close(release)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		var err error
+		for err == nil {
+			_, _, err = conn.ReadMessage()
+		}
+		assert.True(t, websocket.IsCloseError(err, ws.CloseSlowConsumer), "got %v", err)
+		require.Eventually(t, func() bool { return s.hub.Len() == 0 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("pings idle clients", func(t *testing.T) {
+		cfg := testWebSocketConfig
+		cfg.PingInterval = 20 * time.Millisecond
+		s := newTestServer(t, cfg, new(MockEventLog), "PARTICIPANT_APPROVER")
+		conn := s.dial(t, "")
+
+		pinged := make(chan struct{}, 1)
+		conn.SetPingHandler(func(string) error {
+			select {
+			case pinged <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-pinged:
+		case <-time.After(2 * time.Second):
+			t.Fatal("no ping received")
+		}
+	})
+
+	t.Run("closes connections as going away on shutdown", func(t *testing.T) {
+		s := newTestServer(t, testWebSocketConfig, new(MockEventLog), "PARTICIPANT_APPROVER")
+		conn := s.dial(t, "")
+		read(t, conn)
+
+		s.hub.Close()
+
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+	})
+}
+
+func TestQueryCredentials(t *testing.T) {
+	tenantID := uuid.New()
+	var authorization, tenant, query string
+
+	app := fiber.New()
+	app.Use(ws.QueryCredentials())
+	app.Get("/ws", func(c *fiber.Ctx) error {
+		authorization = c.Get(fiber.HeaderAuthorization)
+		tenant = c.Get("X-Tenant-ID")
+		query = string(c.Request().URI().QueryString())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/ws?access_token=secret&tenant_id="+tenantID.String()+"&cursor=1-0", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, tenantID.String(), tenant)
+	assert.NotContains(t, query, "secret", "the token must not reach request logging")
+	assert.Contains(t, query, "cursor=1-0")
+}